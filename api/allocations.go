@@ -171,6 +171,7 @@ type Allocation struct {
 	TaskStates            map[string]*TaskState
 	DeploymentID          string
 	DeploymentStatus      *AllocDeploymentStatus
+	NetworkStatus         *AllocNetworkStatus
 	FollowupEvalID        string
 	PreviousAllocation    string
 	NextAllocation        string
@@ -300,9 +301,19 @@ type AllocDeploymentStatus struct {
 	Healthy     *bool
 	Timestamp   time.Time
 	Canary      bool
+	Standby     bool
 	ModifyIndex uint64
 }
 
+// AllocNetworkStatus captures the status of an allocation's network during
+// runtime.
+type AllocNetworkStatus struct {
+	InterfaceName string
+	Address       string
+	AddressIPv6   string
+	DNS           *DNSConfig
+}
+
 type AllocatedResources struct {
 	Tasks  map[string]*AllocatedTaskResources
 	Shared AllocatedSharedResources
@@ -322,10 +333,11 @@ type AllocatedSharedResources struct {
 }
 
 type PortMapping struct {
-	Label  string
-	Value  int
-	To     int
-	HostIP string
+	Label    string
+	Value    int
+	To       int
+	HostIP   string
+	Protocol string
 }
 
 type AllocatedCpuResources struct {
@@ -114,6 +114,16 @@ func (v *CSIVolumes) Detach(volID, nodeID string, w *WriteOptions) error {
 	return err
 }
 
+// Reclaim releases the claims on a volume of allocations that are terminal
+// or garbage collected. If dryRun is set, it returns the claims that would be
+// released without releasing them.
+func (v *CSIVolumes) Reclaim(volID string, dryRun bool, w *WriteOptions) ([]*CSIVolumeReclaimedClaim, *WriteMeta, error) {
+	var resp []*CSIVolumeReclaimedClaim
+	meta, err := v.client.write(fmt.Sprintf("/v1/volume/csi/%v/reclaim?dry_run=%t",
+		url.PathEscape(volID), dryRun), nil, &resp, w)
+	return resp, meta, err
+}
+
 // CreateSnapshot snapshots an external storage volume.
 func (v *CSIVolumes) CreateSnapshot(snap *CSISnapshot, w *WriteOptions) (*CSISnapshotCreateResponse, *WriteMeta, error) {
 	req := &CSISnapshotCreateRequest{
@@ -225,6 +235,7 @@ type CSIVolume struct {
 	RequestedCapacityMin  int64                  `hcl:"capacity_min"`
 	RequestedCapacityMax  int64                  `hcl:"capacity_max"`
 	RequestedCapabilities []*CSIVolumeCapability `hcl:"capability"`
+	RequestedTopologies   *CSITopologyRequest    `hcl:"topology_request"`
 	CloneID               string                 `mapstructure:"clone_id" hcl:"clone_id"`
 	SnapshotID            string                 `mapstructure:"snapshot_id" hcl:"snapshot_id"`
 
@@ -241,6 +252,9 @@ type CSIVolume struct {
 	// Allocations is a combined list of readers and writers
 	Allocations []*AllocationListStub
 
+	// ClaimHistory is the most recent claim events, oldest first
+	ClaimHistory []*CSIVolumeClaimEvent
+
 	// Schedulable is true if all the denormalized plugin health fields are true
 	Schedulable         bool
 	PluginID            string `mapstructure:"plugin_id" hcl:"plugin_id"`
@@ -267,6 +281,30 @@ type CSIVolumeCapability struct {
 	AttachmentMode CSIVolumeAttachmentMode `mapstructure:"attachment_mode" hcl:"attachment_mode"`
 }
 
+// CSIVolumeClaimEvent records a claim on a volume moving to a new state. If
+// Error is set, the claim failed to move to that state.
+type CSIVolumeClaimEvent struct {
+	AllocationID string
+	NodeID       string
+	Type         string
+	Error        string
+	Time         int64
+}
+
+// CSIVolumeReclaimedClaim is a claim released by Reclaim
+type CSIVolumeReclaimedClaim struct {
+	AllocationID string
+	NodeID       string
+	Reason       string
+}
+
+// CSITopologyRequest are the topologies submitted to the storage provider
+// when a volume is created
+type CSITopologyRequest struct {
+	Required  []*CSITopology `hcl:"required"`
+	Preferred []*CSITopology `hcl:"preferred"`
+}
+
 // CSIVolumeIndexSort is a helper used for sorting volume stubs by creation
 // time.
 type CSIVolumeIndexSort []*CSIVolumeListStub
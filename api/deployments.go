@@ -65,6 +65,20 @@ func (d *Deployments) Fail(deploymentID string, q *WriteOptions) (*DeploymentUpd
 	return &resp, wm, nil
 }
 
+// Rollback is used to roll back a blue/green deployment to the previous
+// allocations it retains.
+func (d *Deployments) Rollback(deploymentID string, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
+	var resp DeploymentUpdateResponse
+	req := &DeploymentRollbackRequest{
+		DeploymentID: deploymentID,
+	}
+	wm, err := d.client.write("/v1/deployment/rollback/"+deploymentID, req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
 // Pause is used to pause or unpause the given deployment.
 func (d *Deployments) Pause(deploymentID string, pause bool, q *WriteOptions) (*DeploymentUpdateResponse, *WriteMeta, error) {
 	var resp DeploymentUpdateResponse
@@ -193,6 +207,13 @@ type DeploymentState struct {
 	PlacedAllocs      int
 	HealthyAllocs     int
 	UnhealthyAllocs   int
+	CanarySteps       []*CanaryStep
+	CanaryStep        int
+	NextCanaryStepAt  time.Time
+	BlueGreen         bool
+	RetainPrevious    time.Duration
+	PreviousAllocs    []string
+	RetainUntil       time.Time
 }
 
 // DeploymentIndexSort is a wrapper to sort deployments by CreateIndex. We
@@ -273,6 +294,12 @@ type DeploymentFailRequest struct {
 	WriteRequest
 }
 
+// DeploymentRollbackRequest is used to roll back a blue/green deployment
+type DeploymentRollbackRequest struct {
+	DeploymentID string
+	WriteRequest
+}
+
 // DeploymentUnblockRequest is used to unblock a particular deployment
 type DeploymentUnblockRequest struct {
 	DeploymentID string
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	DrainBatchStatusRunning   = "running"
+	DrainBatchStatusComplete  = "complete"
+	DrainBatchStatusCancelled = "cancelled"
+
+	DrainBatchNodeStatusPending  = "pending"
+	DrainBatchNodeStatusDraining = "draining"
+	DrainBatchNodeStatusComplete = "complete"
+	DrainBatchNodeStatusSkipped  = "skipped"
+)
+
+// DrainBatches is used to query the drain batch endpoints.
+type DrainBatches struct {
+	client *Client
+}
+
+// DrainBatches returns a new handle on the drain batches.
+func (c *Client) DrainBatches() *DrainBatches {
+	return &DrainBatches{client: c}
+}
+
+// List is used to dump all of the drain batches.
+func (d *DrainBatches) List(q *QueryOptions) ([]*DrainBatch, *QueryMeta, error) {
+	var resp []*DrainBatch
+	qm, err := d.client.query("/v1/drain/batches", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Sort(DrainBatchIndexSort(resp))
+	return resp, qm, nil
+}
+
+func (d *DrainBatches) PrefixList(prefix string) ([]*DrainBatch, *QueryMeta, error) {
+	return d.List(&QueryOptions{Prefix: prefix})
+}
+
+// Info is used to query a single drain batch by its ID.
+func (d *DrainBatches) Info(batchID string, q *QueryOptions) (*DrainBatch, *QueryMeta, error) {
+	var resp DrainBatch
+	qm, err := d.client.query("/v1/drain/batch/"+batchID, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Create is used to create a drain batch of the nodes matching the batch's
+// filter. The leader drains the nodes within the batch's concurrency limits.
+func (d *DrainBatches) Create(batch *DrainBatch, q *WriteOptions) (*DrainBatchCreateResponse, *WriteMeta, error) {
+	var resp DrainBatchCreateResponse
+	req := &DrainBatchCreateRequest{
+		Batch: batch,
+	}
+	wm, err := d.client.write("/v1/drain/batches", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Cancel is used to stop a drain batch from draining more nodes. Nodes that
+// are already draining keep draining.
+func (d *DrainBatches) Cancel(batchID string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := d.client.write("/v1/drain/batch/"+batchID+"/cancel", nil, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// DrainBatch drains the nodes matching a filter, draining at most a limited
+// number of them at the same time.
+type DrainBatch struct {
+	ID string
+
+	// Filter selects the nodes of the batch when it is created
+	Filter *DrainBatchFilter
+
+	// DrainSpec is the drain specification applied to each node
+	DrainSpec *DrainSpec
+
+	// MaxParallel is the maximum number of nodes draining at the same time
+	MaxParallel int
+
+	// MaxParallelPercent is the maximum percent of the nodes of a datacenter
+	// draining at the same time
+	MaxParallelPercent int
+
+	Nodes []*DrainBatchNode
+
+	Status            string
+	StatusDescription string
+
+	CreateTime  int64
+	ModifyTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// DrainBatchFilter selects the nodes of a drain batch. A node matches if it
+// matches all of the set fields.
+type DrainBatchFilter struct {
+	Datacenters []string
+	NodeClass   string
+	Meta        map[string]string
+}
+
+// DrainBatchNode is the progress of a node of a drain batch
+type DrainBatchNode struct {
+	NodeID      string
+	Datacenter  string
+	Status      string
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+type DrainBatchCreateRequest struct {
+	Batch *DrainBatch
+	WriteRequest
+}
+
+type DrainBatchCreateResponse struct {
+	Batch *DrainBatch
+	WriteMeta
+}
+
+// DrainBatchIndexSort is a wrapper to sort drain batches by CreateIndex. We
+// reverse the test so that we get the highest index first.
+type DrainBatchIndexSort []*DrainBatch
+
+func (d DrainBatchIndexSort) Len() int {
+	return len(d)
+}
+
+func (d DrainBatchIndexSort) Less(i, j int) bool {
+	return d[i].CreateIndex > d[j].CreateIndex
+}
+
+func (d DrainBatchIndexSort) Swap(i, j int) {
+	d[i], d[j] = d[j], d[i]
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/url"
+	"sort"
+)
+
+// HostVolumes is used to access the dynamic host volume endpoints.
+type HostVolumes struct {
+	client *Client
+}
+
+// HostVolumes returns a handle on the HostVolumes endpoint.
+func (c *Client) HostVolumes() *HostVolumes {
+	return &HostVolumes{client: c}
+}
+
+const (
+	// HostVolumeStatePending is the state of a volume that its client has
+	// not created yet.
+	HostVolumeStatePending = "pending"
+
+	// HostVolumeStateReady is the state of a volume that can be claimed by
+	// allocations.
+	HostVolumeStateReady = "ready"
+)
+
+// HostVolume is a directory-backed volume created by Nomad on a client. Once
+// it's ready, jobs claim it by name with a host volume request.
+type HostVolume struct {
+	ID        string `hcl:"id"`
+	Name      string `hcl:"name"`
+	Namespace string `hcl:"namespace"`
+
+	// PluginID is "mkdir" for the builtin plugin, or the name of an
+	// executable in the client's host volume plugin directory.
+	PluginID string `mapstructure:"plugin_id" hcl:"plugin_id"`
+
+	// NodeID is the node to create the volume on. If it's empty, the server
+	// picks a node that has the plugin and meets the Constraints.
+	NodeID      string        `mapstructure:"node_id" hcl:"node_id"`
+	Constraints []*Constraint `mapstructure:"constraint" hcl:"constraint,block"`
+
+	RequestedCapacityMinBytes int64 `mapstructure:"capacity_min" hcl:"capacity_min"`
+	RequestedCapacityMaxBytes int64 `mapstructure:"capacity_max" hcl:"capacity_max"`
+	CapacityBytes             int64
+
+	Parameters map[string]string `mapstructure:"parameters" hcl:"parameters"`
+
+	// UID and GID are the owner of the volume's directory; nil leaves it
+	// unchanged. Mode is its octal permissions.
+	UID  *int   `mapstructure:"uid" hcl:"uid"`
+	GID  *int   `mapstructure:"gid" hcl:"gid"`
+	Mode string `mapstructure:"mode" hcl:"mode"`
+
+	HostPath string
+	State    string
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+}
+
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+}
+
+// Create creates a dynamic host volume and returns it once its client has
+// created it.
+func (v *HostVolumes) Create(vol *HostVolume, w *WriteOptions) (*HostVolume, *WriteMeta, error) {
+	req := &HostVolumeCreateRequest{Volume: vol}
+	resp := &HostVolumeCreateResponse{}
+	meta, err := v.client.write("/v1/volume/host/create", req, resp, w)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Volume, meta, nil
+}
+
+// Delete deletes a dynamic host volume from its client. Volumes in use by
+// allocations can't be deleted.
+func (v *HostVolumes) Delete(id string, w *WriteOptions) (*WriteMeta, error) {
+	return v.client.delete("/v1/volume/host/"+url.PathEscape(id), nil, w)
+}
+
+// Info is used to retrieve a single dynamic host volume.
+func (v *HostVolumes) Info(id string, q *QueryOptions) (*HostVolume, *QueryMeta, error) {
+	var resp HostVolume
+	qm, err := v.client.query("/v1/volume/host/"+url.PathEscape(id), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// List returns the dynamic host volumes of the namespace.
+func (v *HostVolumes) List(q *QueryOptions) ([]*HostVolume, *QueryMeta, error) {
+	var resp []*HostVolume
+	qm, err := v.client.query("/v1/volumes?type=host", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].ID < resp[j].ID })
+	return resp, qm, nil
+}
@@ -442,15 +442,101 @@ type periodicForceResponse struct {
 
 // UpdateStrategy defines a task groups update strategy.
 type UpdateStrategy struct {
-	Stagger          *time.Duration `mapstructure:"stagger" hcl:"stagger,optional"`
-	MaxParallel      *int           `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
-	HealthCheck      *string        `mapstructure:"health_check" hcl:"health_check,optional"`
-	MinHealthyTime   *time.Duration `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
-	HealthyDeadline  *time.Duration `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
-	ProgressDeadline *time.Duration `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
-	Canary           *int           `mapstructure:"canary" hcl:"canary,optional"`
-	AutoRevert       *bool          `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
-	AutoPromote      *bool          `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	Stagger          *time.Duration      `mapstructure:"stagger" hcl:"stagger,optional"`
+	MaxParallel      *int                `mapstructure:"max_parallel" hcl:"max_parallel,optional"`
+	HealthCheck      *string             `mapstructure:"health_check" hcl:"health_check,optional"`
+	MinHealthyTime   *time.Duration      `mapstructure:"min_healthy_time" hcl:"min_healthy_time,optional"`
+	HealthyDeadline  *time.Duration      `mapstructure:"healthy_deadline" hcl:"healthy_deadline,optional"`
+	ProgressDeadline *time.Duration      `mapstructure:"progress_deadline" hcl:"progress_deadline,optional"`
+	Canary           *int                `mapstructure:"canary" hcl:"canary,optional"`
+	AutoRevert       *bool               `mapstructure:"auto_revert" hcl:"auto_revert,optional"`
+	AutoPromote      *bool               `mapstructure:"auto_promote" hcl:"auto_promote,optional"`
+	CanarySteps      []*CanaryStep       `mapstructure:"canary_step" hcl:"canary_step,block"`
+	Analysis         *DeploymentAnalysis `mapstructure:"analysis" hcl:"analysis,block"`
+	Strategy         *string             `mapstructure:"strategy" hcl:"strategy,optional"`
+	RetainPrevious   *time.Duration      `mapstructure:"retain_previous" hcl:"retain_previous,optional"`
+}
+
+// DeploymentAnalysis is a query against a Prometheus compatible HTTP API that
+// must satisfy a threshold while allocations are waiting to be marked healthy.
+type DeploymentAnalysis struct {
+	Address   *string        `mapstructure:"address" hcl:"address,optional"`
+	Query     *string        `mapstructure:"query" hcl:"query,optional"`
+	Operator  *string        `mapstructure:"operator" hcl:"operator,optional"`
+	Threshold *float64       `mapstructure:"threshold" hcl:"threshold,optional"`
+	Interval  *time.Duration `mapstructure:"interval" hcl:"interval,optional"`
+}
+
+func (a *DeploymentAnalysis) Copy() *DeploymentAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	copy := new(DeploymentAnalysis)
+	if a.Address != nil {
+		copy.Address = stringToPtr(*a.Address)
+	}
+	if a.Query != nil {
+		copy.Query = stringToPtr(*a.Query)
+	}
+	if a.Operator != nil {
+		copy.Operator = stringToPtr(*a.Operator)
+	}
+	if a.Threshold != nil {
+		copy.Threshold = float64ToPtr(*a.Threshold)
+	}
+	if a.Interval != nil {
+		copy.Interval = timeToPtr(*a.Interval)
+	}
+	return copy
+}
+
+func (a *DeploymentAnalysis) Canonicalize() {
+	if a.Address == nil {
+		a.Address = stringToPtr("")
+	}
+	if a.Query == nil {
+		a.Query = stringToPtr("")
+	}
+	if a.Operator == nil {
+		a.Operator = stringToPtr("<=")
+	}
+	if a.Threshold == nil {
+		a.Threshold = float64ToPtr(0)
+	}
+	if a.Interval == nil {
+		a.Interval = timeToPtr(30 * time.Second)
+	}
+}
+
+// CanaryStep is a step of a progressive canary deployment
+type CanaryStep struct {
+	Percent *int           `mapstructure:"percent" hcl:"percent,optional"`
+	Pause   *time.Duration `mapstructure:"pause" hcl:"pause,optional"`
+}
+
+func (s *CanaryStep) Copy() *CanaryStep {
+	if s == nil {
+		return nil
+	}
+
+	copy := new(CanaryStep)
+	if s.Percent != nil {
+		copy.Percent = intToPtr(*s.Percent)
+	}
+	if s.Pause != nil {
+		copy.Pause = timeToPtr(*s.Pause)
+	}
+	return copy
+}
+
+func (s *CanaryStep) Canonicalize() {
+	if s.Percent == nil {
+		s.Percent = intToPtr(0)
+	}
+	if s.Pause == nil {
+		s.Pause = timeToPtr(0)
+	}
 }
 
 // DefaultUpdateStrategy provides a baseline that can be used to upgrade
@@ -512,6 +598,23 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 		copy.AutoPromote = boolToPtr(*u.AutoPromote)
 	}
 
+	if u.CanarySteps != nil {
+		copy.CanarySteps = make([]*CanaryStep, len(u.CanarySteps))
+		for i, step := range u.CanarySteps {
+			copy.CanarySteps[i] = step.Copy()
+		}
+	}
+
+	copy.Analysis = u.Analysis.Copy()
+
+	if u.Strategy != nil {
+		copy.Strategy = stringToPtr(*u.Strategy)
+	}
+
+	if u.RetainPrevious != nil {
+		copy.RetainPrevious = timeToPtr(*u.RetainPrevious)
+	}
+
 	return copy
 }
 
@@ -555,6 +658,22 @@ func (u *UpdateStrategy) Merge(o *UpdateStrategy) {
 	if o.AutoPromote != nil {
 		u.AutoPromote = boolToPtr(*o.AutoPromote)
 	}
+
+	if o.CanarySteps != nil {
+		u.CanarySteps = o.Copy().CanarySteps
+	}
+
+	if o.Analysis != nil {
+		u.Analysis = o.Analysis.Copy()
+	}
+
+	if o.Strategy != nil {
+		u.Strategy = stringToPtr(*o.Strategy)
+	}
+
+	if o.RetainPrevious != nil {
+		u.RetainPrevious = timeToPtr(*o.RetainPrevious)
+	}
 }
 
 func (u *UpdateStrategy) Canonicalize() {
@@ -595,6 +714,16 @@ func (u *UpdateStrategy) Canonicalize() {
 	if u.AutoPromote == nil {
 		u.AutoPromote = d.AutoPromote
 	}
+
+	for _, step := range u.CanarySteps {
+		if step != nil {
+			step.Canonicalize()
+		}
+	}
+
+	if u.Analysis != nil {
+		u.Analysis.Canonicalize()
+	}
 }
 
 // Empty returns whether the UpdateStrategy is empty or has user defined values.
@@ -639,6 +768,22 @@ func (u *UpdateStrategy) Empty() bool {
 		return false
 	}
 
+	if len(u.CanarySteps) != 0 {
+		return false
+	}
+
+	if u.Analysis != nil {
+		return false
+	}
+
+	if u.Strategy != nil && *u.Strategy != "" {
+		return false
+	}
+
+	if u.RetainPrevious != nil && *u.RetainPrevious != 0 {
+		return false
+	}
+
 	return true
 }
 
@@ -712,6 +857,54 @@ type MultiregionRegion struct {
 	Meta        map[string]string `hcl:"meta,block"`
 }
 
+// PreemptionPolicy controls whether and how often the allocations of a job may
+// be preempted to place allocations of higher priority jobs.
+type PreemptionPolicy struct {
+	Preemptible           *bool                `hcl:"preemptible,optional"`
+	MaxPreemptions        *int                 `mapstructure:"max_preemptions" hcl:"max_preemptions,optional"`
+	MaxPreemptionsPercent *int                 `mapstructure:"max_preemptions_percent" hcl:"max_preemptions_percent,optional"`
+	Window                *time.Duration       `hcl:"window,optional"`
+	MaintenanceWindows    []*MaintenanceWindow `hcl:"maintenance_window,block"`
+}
+
+func (p *PreemptionPolicy) Canonicalize() {
+	if p.Preemptible == nil {
+		p.Preemptible = boolToPtr(true)
+	}
+	if p.MaxPreemptions == nil {
+		p.MaxPreemptions = intToPtr(0)
+	}
+	if p.MaxPreemptionsPercent == nil {
+		p.MaxPreemptionsPercent = intToPtr(0)
+	}
+	if p.Window == nil {
+		p.Window = timeToPtr(1 * time.Hour)
+	}
+	for _, w := range p.MaintenanceWindows {
+		w.Canonicalize()
+	}
+}
+
+// MaintenanceWindow is a recurring window of time starting at each time
+// matching a cron expression.
+type MaintenanceWindow struct {
+	Cron     *string        `hcl:"cron,optional"`
+	Duration *time.Duration `hcl:"duration,optional"`
+	TimeZone *string        `mapstructure:"time_zone" hcl:"time_zone,optional"`
+}
+
+func (w *MaintenanceWindow) Canonicalize() {
+	if w.Cron == nil {
+		w.Cron = stringToPtr("")
+	}
+	if w.Duration == nil {
+		w.Duration = timeToPtr(0)
+	}
+	if w.TimeZone == nil || *w.TimeZone == "" {
+		w.TimeZone = stringToPtr("UTC")
+	}
+}
+
 // PeriodicConfig is for serializing periodic config for a job.
 type PeriodicConfig struct {
 	Enabled         *bool   `hcl:"enabled,optional"`
@@ -803,6 +996,8 @@ type Job struct {
 	Update           *UpdateStrategy         `hcl:"update,block"`
 	Multiregion      *Multiregion            `hcl:"multiregion,block"`
 	Spreads          []*Spread               `hcl:"spread,block"`
+	Scoring          *ScoringConfig          `hcl:"scoring,block"`
+	Preemption       *PreemptionPolicy       `hcl:"preemption,block"`
 	Periodic         *PeriodicConfig         `hcl:"periodic,block"`
 	ParameterizedJob *ParameterizedJobConfig `hcl:"parameterized,block"`
 	Reschedule       *ReschedulePolicy       `hcl:"reschedule,block"`
@@ -923,6 +1118,9 @@ func (j *Job) Canonicalize() {
 	if j.Multiregion != nil {
 		j.Multiregion.Canonicalize()
 	}
+	if j.Preemption != nil {
+		j.Preemption.Canonicalize()
+	}
 
 	for _, tg := range j.TaskGroups {
 		tg.Canonicalize(j)
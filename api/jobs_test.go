@@ -271,9 +271,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr(""),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -355,9 +356,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr(""),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -444,9 +446,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("bar"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -630,9 +633,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 							Unlimited:     boolToPtr(true),
 						},
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						Consul: &Consul{
 							Namespace: "",
@@ -871,9 +875,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("bar"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -918,9 +923,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("baz"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -1047,9 +1053,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("bar"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(15 * time.Second),
@@ -1099,9 +1106,10 @@ func TestJobs_Canonicalize(t *testing.T) {
 						Name:  stringToPtr("baz"),
 						Count: intToPtr(1),
 						EphemeralDisk: &EphemeralDisk{
-							Sticky:  boolToPtr(false),
-							Migrate: boolToPtr(false),
-							SizeMB:  intToPtr(300),
+							Sticky:     boolToPtr(false),
+							Migrate:    boolToPtr(false),
+							Checkpoint: boolToPtr(false),
+							SizeMB:     intToPtr(300),
 						},
 						RestartPolicy: &RestartPolicy{
 							Delay:    timeToPtr(20 * time.Second),
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	NodeMaintenanceWindowStatusPending   = "pending"
+	NodeMaintenanceWindowStatusActive    = "active"
+	NodeMaintenanceWindowStatusComplete  = "complete"
+	NodeMaintenanceWindowStatusCancelled = "cancelled"
+
+	NodeMaintenanceWindowNodeStatusActive    = "active"
+	NodeMaintenanceWindowNodeStatusRestored  = "restored"
+	NodeMaintenanceWindowNodeStatusUnhealthy = "unhealthy"
+	NodeMaintenanceWindowNodeStatusSkipped   = "skipped"
+)
+
+// NodeMaintenanceWindows is used to query the node maintenance window
+// endpoints.
+type NodeMaintenanceWindows struct {
+	client *Client
+}
+
+// NodeMaintenanceWindows returns a new handle on the node maintenance windows.
+func (c *Client) NodeMaintenanceWindows() *NodeMaintenanceWindows {
+	return &NodeMaintenanceWindows{client: c}
+}
+
+// List is used to dump all of the maintenance windows.
+func (m *NodeMaintenanceWindows) List(q *QueryOptions) ([]*NodeMaintenanceWindow, *QueryMeta, error) {
+	var resp []*NodeMaintenanceWindow
+	qm, err := m.client.query("/v1/maintenance/windows", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Sort(NodeMaintenanceWindowIndexSort(resp))
+	return resp, qm, nil
+}
+
+func (m *NodeMaintenanceWindows) PrefixList(prefix string) ([]*NodeMaintenanceWindow, *QueryMeta, error) {
+	return m.List(&QueryOptions{Prefix: prefix})
+}
+
+// ListByNode is used to list the maintenance windows that include a node.
+func (m *NodeMaintenanceWindows) ListByNode(nodeID string, q *QueryOptions) ([]*NodeMaintenanceWindow, *QueryMeta, error) {
+	if q == nil {
+		q = &QueryOptions{}
+	}
+	if q.Params == nil {
+		q.Params = make(map[string]string)
+	}
+	q.Params["node_id"] = nodeID
+	return m.List(q)
+}
+
+// Info is used to query a single maintenance window by its ID.
+func (m *NodeMaintenanceWindows) Info(windowID string, q *QueryOptions) (*NodeMaintenanceWindow, *QueryMeta, error) {
+	var resp NodeMaintenanceWindow
+	qm, err := m.client.query("/v1/maintenance/window/"+windowID, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
+// Create is used to schedule a maintenance window. The leader drains the
+// nodes of the window when it starts, and restores their eligibility when it
+// ends.
+func (m *NodeMaintenanceWindows) Create(window *NodeMaintenanceWindow, q *WriteOptions) (*NodeMaintenanceWindowCreateResponse, *WriteMeta, error) {
+	var resp NodeMaintenanceWindowCreateResponse
+	req := &NodeMaintenanceWindowCreateRequest{
+		Window: window,
+	}
+	wm, err := m.client.write("/v1/maintenance/windows", req, &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, wm, nil
+}
+
+// Cancel is used to cancel a maintenance window. A pending window doesn't
+// start, and an active window ends immediately.
+func (m *NodeMaintenanceWindows) Cancel(windowID string, q *WriteOptions) (*WriteMeta, error) {
+	wm, err := m.client.write("/v1/maintenance/window/"+windowID+"/cancel", nil, nil, q)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// NodeMaintenanceWindow schedules the maintenance of a set of nodes. The nodes
+// are drained when the window starts and kept ineligible until it ends.
+type NodeMaintenanceWindow struct {
+	ID string
+
+	// NodeIDs selects nodes by ID, and Filter selects the nodes matching it
+	NodeIDs []string
+	Filter  *DrainBatchFilter
+
+	// StartTime is when the nodes start draining, and Duration how long they
+	// are kept ineligible
+	StartTime time.Time
+	Duration  time.Duration
+
+	// LeadTime is how long before StartTime the scheduler avoids placing
+	// service allocations on the nodes of the window
+	LeadTime time.Duration
+
+	// DrainSpec is the drain specification applied to each node
+	DrainSpec *DrainSpec
+
+	Nodes []*NodeMaintenanceWindowNode
+
+	Status            string
+	StatusDescription string
+
+	CreateTime  int64
+	ModifyTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// EndTime returns the time the window ends.
+func (w *NodeMaintenanceWindow) EndTime() time.Time {
+	return w.StartTime.Add(w.Duration)
+}
+
+// NodeMaintenanceWindowNode is the state of a node of a maintenance window
+type NodeMaintenanceWindowNode struct {
+	NodeID      string
+	Status      string
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+type NodeMaintenanceWindowCreateRequest struct {
+	Window *NodeMaintenanceWindow
+	WriteRequest
+}
+
+type NodeMaintenanceWindowCreateResponse struct {
+	Window *NodeMaintenanceWindow
+	WriteMeta
+}
+
+// NodeMaintenanceWindowIndexSort is a wrapper to sort maintenance windows by
+// CreateIndex. We reverse the test so that we get the highest index first.
+type NodeMaintenanceWindowIndexSort []*NodeMaintenanceWindow
+
+func (m NodeMaintenanceWindowIndexSort) Len() int {
+	return len(m)
+}
+
+func (m NodeMaintenanceWindowIndexSort) Less(i, j int) bool {
+	return m[i].CreateIndex > m[j].CreateIndex
+}
+
+func (m NodeMaintenanceWindowIndexSort) Swap(i, j int) {
+	m[i], m[j] = m[j], m[i]
+}
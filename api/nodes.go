@@ -651,6 +651,46 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// DeadlineOverrides overrides Deadline for the allocations of specific
+	// jobs, or of the jobs within priority bands.
+	DeadlineOverrides []*DrainDeadline
+
+	// SkipJobs are the jobs whose allocations stay on the node. The drain
+	// completes without migrating them.
+	SkipJobs []DrainSkipJob
+}
+
+// DrainSkipJob selects a job whose allocations stay on a draining node.
+type DrainSkipJob struct {
+	// Namespace is the namespace of the job. It defaults to the namespace of
+	// the request.
+	Namespace string
+	JobID     string
+}
+
+// DrainDeadline overrides the deadline of a drain for the allocations of a job,
+// or of the jobs whose priority is within a band. An override of a job takes
+// precedence over priority bands, and the first matching band is used.
+type DrainDeadline struct {
+	// Namespace and JobID select the allocations of the job. The namespace
+	// defaults to the namespace of the request.
+	Namespace string
+	JobID     string
+
+	// MinPriority and MaxPriority select the allocations of the jobs whose
+	// priority is within the inclusive band, if JobID isn't set
+	MinPriority int
+	MaxPriority int
+
+	// Deadline is the duration after which the selected allocations are told
+	// to stop. A negative deadline stops them immediately and a zero deadline
+	// never forces them to stop.
+	Deadline time.Duration
+
+	// ForceDeadline is the deadline time after which the selected allocations
+	// are told to stop. It is set by the server.
+	ForceDeadline time.Time
 }
 
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
@@ -667,6 +707,19 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
 	}
+	if len(d.SkipJobs) != len(o.SkipJobs) || len(d.DeadlineOverrides) != len(o.DeadlineOverrides) {
+		return false
+	}
+	for i, job := range d.SkipJobs {
+		if job != o.SkipJobs[i] {
+			return false
+		}
+	}
+	for i, override := range d.DeadlineOverrides {
+		if *override != *o.DeadlineOverrides[i] {
+			return false
+		}
+	}
 
 	return true
 }
@@ -129,6 +129,10 @@ type SchedulerConfiguration struct {
 	// MemoryOversubscriptionEnabled specifies whether memory oversubscription is enabled
 	MemoryOversubscriptionEnabled bool
 
+	// Scoring configures how the fit of allocations on nodes is scored.
+	// Jobs may override it.
+	Scoring *ScoringConfig
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -142,6 +146,42 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// EvalQueueResponse is the response object that wraps EvalQueueStats
+type EvalQueueResponse struct {
+	// Queue describes the evaluations queued on the leader
+	Queue *EvalQueueStats
+
+	QueryMeta
+}
+
+// EvalQueueStats describes the evaluations queued in the eval broker and the
+// blocked evaluations tracker of the leader.
+type EvalQueueStats struct {
+	NamespaceFairness bool
+	TotalReady        int
+	TotalUnacked      int
+	TotalPending      int
+	TotalWaiting      int
+	TotalBlocked      int
+	ByScheduler       map[string]*EvalQueueSchedulerStats
+	ByNamespace       map[string]*EvalQueueNamespaceStats
+}
+
+// EvalQueueSchedulerStats describes the queued evaluations of a scheduler
+// type.
+type EvalQueueSchedulerStats struct {
+	Ready   int
+	Unacked int
+}
+
+// EvalQueueNamespaceStats describes the queued evaluations of a namespace.
+type EvalQueueNamespaceStats struct {
+	Weight  int
+	Ready   int
+	Unacked int
+	Blocked int
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -162,6 +202,27 @@ const (
 	SchedulerAlgorithmSpread  SchedulerAlgorithm = "spread"
 )
 
+// FitFunction is an enum string that encapsulates the valid options for how
+// the scheduler turns the utilization of a node into a fit score.
+type FitFunction string
+
+const (
+	FitFunctionBestFit          FitFunction = "best-fit"
+	FitFunctionWorstFit         FitFunction = "worst-fit"
+	FitFunctionDominantResource FitFunction = "dominant-resource"
+)
+
+// ScoringConfig configures how the scheduler scores the fit of an allocation
+// on a node, with the relative weight of the utilization of each resource
+type ScoringConfig struct {
+	FitFunction   FitFunction `mapstructure:"fit_function" hcl:"fit_function,optional"`
+	CPUWeight     float64     `mapstructure:"cpu_weight" hcl:"cpu_weight,optional"`
+	MemoryWeight  float64     `mapstructure:"memory_weight" hcl:"memory_weight,optional"`
+	DiskWeight    float64     `mapstructure:"disk_weight" hcl:"disk_weight,optional"`
+	NetworkWeight float64     `mapstructure:"network_weight" hcl:"network_weight,optional"`
+	DevicesWeight float64     `mapstructure:"devices_weight" hcl:"devices_weight,optional"`
+}
+
 // PreemptionConfig specifies whether preemption is enabled based on scheduler type
 type PreemptionConfig struct {
 	SystemSchedulerEnabled   bool
@@ -180,6 +241,16 @@ func (op *Operator) SchedulerGetConfiguration(q *QueryOptions) (*SchedulerConfig
 	return &resp, qm, nil
 }
 
+// SchedulerQueue is used to inspect the evaluations queued on the leader.
+func (op *Operator) SchedulerQueue(q *QueryOptions) (*EvalQueueResponse, *QueryMeta, error) {
+	var resp EvalQueueResponse
+	qm, err := op.c.query("/v1/operator/scheduler/queue", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // SchedulerSetConfiguration is used to set the current Scheduler configuration.
 func (op *Operator) SchedulerSetConfiguration(conf *SchedulerConfiguration, q *WriteOptions) (*SchedulerSetConfigurationResponse, *WriteMeta, error) {
 	var out SchedulerSetConfigurationResponse
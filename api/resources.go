@@ -101,6 +101,7 @@ type Port struct {
 	Value       int    `mapstructure:"static" hcl:"static,optional"`
 	To          int    `mapstructure:"to" hcl:"to,optional"`
 	HostNetwork string `mapstructure:"host_network" hcl:"host_network,optional"`
+	Protocol    string `mapstructure:"protocol" hcl:"protocol,optional"`
 }
 
 type DNSConfig struct {
@@ -41,11 +41,28 @@ type CpuStats struct {
 	Measured         []string
 }
 
+// DiskStats holds the ephemeral disk usage of an allocation
+type DiskStats struct {
+	Used  uint64
+	Limit uint64
+}
+
+// NetworkStats holds the network usage of an allocation
+type NetworkStats struct {
+	RxBytes    uint64
+	TxBytes    uint64
+	RxMBits    float64
+	TxMBits    float64
+	LimitMBits int
+}
+
 // ResourceUsage holds information related to cpu and memory stats
 type ResourceUsage struct {
-	MemoryStats *MemoryStats
-	CpuStats    *CpuStats
-	DeviceStats []*DeviceGroupStats
+	MemoryStats  *MemoryStats
+	CpuStats     *CpuStats
+	DeviceStats  []*DeviceGroupStats
+	DiskStats    *DiskStats
+	NetworkStats *NetworkStats
 }
 
 // TaskResourceUsage holds aggregated resource usage of all processes in a Task
@@ -256,6 +273,7 @@ func (p *ReschedulePolicy) String() string {
 // Spread is used to serialize task group allocation spread preferences
 type Spread struct {
 	Attribute    string          `hcl:"attribute,optional"`
+	Hierarchy    []string        `hcl:"hierarchy,optional"`
 	Weight       *int8           `hcl:"weight,optional"`
 	SpreadTarget []*SpreadTarget `hcl:"target,block"`
 }
@@ -281,6 +299,16 @@ func NewSpread(attribute string, weight int8, spreadTargets []*SpreadTarget) *Sp
 	}
 }
 
+// NewHierarchicalSpread returns a spread that balances allocations at every
+// level of the hierarchy of node attributes, from the outermost to the
+// innermost.
+func NewHierarchicalSpread(hierarchy []string, weight int8) *Spread {
+	return &Spread{
+		Hierarchy: hierarchy,
+		Weight:    int8ToPtr(weight),
+	}
+}
+
 func (s *Spread) Canonicalize() {
 	if s.Weight == nil {
 		s.Weight = int8ToPtr(50)
@@ -289,16 +317,18 @@ func (s *Spread) Canonicalize() {
 
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
-	Sticky  *bool `hcl:"sticky,optional"`
-	Migrate *bool `hcl:"migrate,optional"`
-	SizeMB  *int  `mapstructure:"size" hcl:"size,optional"`
+	Sticky     *bool `hcl:"sticky,optional"`
+	Migrate    *bool `hcl:"migrate,optional"`
+	Checkpoint *bool `hcl:"checkpoint,optional"`
+	SizeMB     *int  `mapstructure:"size" hcl:"size,optional"`
 }
 
 func DefaultEphemeralDisk() *EphemeralDisk {
 	return &EphemeralDisk{
-		Sticky:  boolToPtr(false),
-		Migrate: boolToPtr(false),
-		SizeMB:  intToPtr(300),
+		Sticky:     boolToPtr(false),
+		Migrate:    boolToPtr(false),
+		Checkpoint: boolToPtr(false),
+		SizeMB:     intToPtr(300),
 	}
 }
 
@@ -309,6 +339,9 @@ func (e *EphemeralDisk) Canonicalize() {
 	if e.Migrate == nil {
 		e.Migrate = boolToPtr(false)
 	}
+	if e.Checkpoint == nil {
+		e.Checkpoint = boolToPtr(false)
+	}
 	if e.SizeMB == nil {
 		e.SizeMB = intToPtr(300)
 	}
@@ -412,6 +445,16 @@ func (vm *VolumeMount) Canonicalize() {
 	}
 }
 
+const (
+	// TaskGroupPlacementBestEffort places as many allocations of a task group
+	// as fit.
+	TaskGroupPlacementBestEffort = "best_effort"
+
+	// TaskGroupPlacementAllOrNothing places the allocations of a task group
+	// only if every one of them fits.
+	TaskGroupPlacementAllOrNothing = "all_or_nothing"
+)
+
 // TaskGroup is the unit of scheduling.
 type TaskGroup struct {
 	Name                      *string                   `hcl:"name,label"`
@@ -431,6 +474,7 @@ type TaskGroup struct {
 	Services                  []*Service                `hcl:"service,block"`
 	ShutdownDelay             *time.Duration            `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
 	StopAfterClientDisconnect *time.Duration            `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
+	Placement                 *string                   `mapstructure:"placement" hcl:"placement,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
 }
@@ -948,6 +992,9 @@ const (
 	TaskRestartSignal          = "Restart Signaled"
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
+	TaskOOMKilled              = "OOM Killed"
+	TaskDiskExceeded           = "Disk Resources Exceeded"
+	TaskResized                = "Resized"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
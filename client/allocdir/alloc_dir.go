@@ -3,12 +3,14 @@ package allocdir
 import (
 	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
+	"syscall"
 	"time"
 
 	"net/http"
@@ -58,6 +60,10 @@ var (
 	// directory
 	TaskSecrets = "secrets"
 
+	// TaskCheckpoint is the name of the directory inside each task directory
+	// where checkpoint images are written. It is included in snapshots.
+	TaskCheckpoint = "checkpoint"
+
 	// TaskDirs is the set of directories created in each tasks directory.
 	TaskDirs = map[string]os.FileMode{TmpDirName: os.ModeSticky | 0777}
 
@@ -160,6 +166,11 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	rootPaths := []string{allocDataDir}
 	for _, taskdir := range d.TaskDirs {
 		rootPaths = append(rootPaths, taskdir.LocalDir)
+
+		// Checkpoint images only exist if the task was checkpointed
+		if pathExists(taskdir.CheckpointDir) {
+			rootPaths = append(rootPaths, taskdir.CheckpointDir)
+		}
 	}
 
 	tw := tar.NewWriter(w)
@@ -248,7 +259,7 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 	dataDir := filepath.Join(d.SharedDir, SharedDataDir)
 	if fileInfo, err := os.Stat(otherDataDir); fileInfo != nil && err == nil {
 		os.Remove(dataDir) // remove an empty data dir if it exists
-		if err := os.Rename(otherDataDir, dataDir); err != nil {
+		if err := moveDir(otherDataDir, dataDir); err != nil {
 			return fmt.Errorf("error moving data dir: %v", err)
 		}
 	}
@@ -267,10 +278,23 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 			}
 			localDir := filepath.Join(newTaskDir, TaskLocal)
 			os.Remove(localDir) // remove an empty local dir if it exists
-			if err := os.Rename(otherTaskLocal, localDir); err != nil {
+			if err := moveDir(otherTaskLocal, localDir); err != nil {
 				return fmt.Errorf("error moving task %q local dir: %v", task.Name, err)
 			}
 		}
+
+		otherTaskCheckpoint := filepath.Join(otherTaskDir, TaskCheckpoint)
+		fileInfo, err = os.Stat(otherTaskCheckpoint)
+		if fileInfo != nil && err == nil {
+			newTaskDir := filepath.Join(d.AllocDir, task.Name)
+			if err := os.MkdirAll(newTaskDir, 0777); err != nil {
+				return fmt.Errorf("error creating task %q dir: %v", task.Name, err)
+			}
+			checkpointDir := filepath.Join(newTaskDir, TaskCheckpoint)
+			if err := moveDir(otherTaskCheckpoint, checkpointDir); err != nil {
+				return fmt.Errorf("error moving task %q checkpoint dir: %v", task.Name, err)
+			}
+		}
 	}
 
 	return nil
@@ -551,6 +575,59 @@ func fileCopy(src, dst string, uid, gid int, perm os.FileMode) error {
 	return nil
 }
 
+// moveDir renames src to dst. If both are on different filesystems, as is the
+// case when alloc dirs are backed by per allocation disk images or project
+// quotas, the tree is copied and src removed instead.
+func moveDir(src, dst string) error {
+	err := os.Rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+
+	err = filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		uid, gid := getOwner(fi)
+
+		switch {
+		case fi.IsDir():
+			if err := os.MkdirAll(target, fi.Mode().Perm()); err != nil {
+				return err
+			}
+			if err := os.Chmod(target, fi.Mode().Perm()); err != nil {
+				return err
+			}
+			if uid != idUnsupported && gid != idUnsupported {
+				return os.Lchown(target, uid, gid)
+			}
+			return nil
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case fi.Mode().IsRegular():
+			return fileCopy(path, target, uid, gid, fi.Mode().Perm())
+		default:
+			// Skip sockets, pipes and devices as they can't be copied
+			return nil
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy %q to %q: %v", src, dst, err)
+	}
+
+	return os.RemoveAll(src)
+}
+
 // pathExists is a helper function to check if the path exists.
 func pathExists(path string) bool {
 	if _, err := os.Stat(path); err != nil {
@@ -289,6 +289,103 @@ func TestAllocDir_Move(t *testing.T) {
 	}
 }
 
+// TestAllocDir_MoveDir_CrossDevice asserts directories are copied when they
+// can't be renamed across filesystems.
+func TestAllocDir_MoveDir_CrossDevice(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("test requires /dev/shm")
+	}
+	require := require.New(t)
+
+	src, err := ioutil.TempDir("", "MoveDir")
+	require.NoError(err)
+	defer os.RemoveAll(src)
+
+	other, err := ioutil.TempDir("/dev/shm", "MoveDir")
+	if err != nil {
+		t.Skipf("/dev/shm is not usable: %v", err)
+	}
+	defer os.RemoveAll(other)
+
+	var st1, st2 syscall.Stat_t
+	require.NoError(syscall.Stat(src, &st1))
+	require.NoError(syscall.Stat(other, &st2))
+	if st1.Dev == st2.Dev {
+		t.Skip("temp dir and /dev/shm are on the same filesystem")
+	}
+
+	require.NoError(os.MkdirAll(filepath.Join(src, "sub"), 0750))
+	require.NoError(ioutil.WriteFile(filepath.Join(src, "sub", "file"), []byte("foo"), 0600))
+	require.NoError(os.Symlink("sub/file", filepath.Join(src, "link")))
+
+	dst := filepath.Join(other, "dst")
+	require.NoError(moveDir(src, dst))
+
+	_, err = os.Stat(src)
+	require.True(os.IsNotExist(err))
+
+	fi, err := os.Stat(filepath.Join(dst, "sub"))
+	require.NoError(err)
+	require.Equal(os.FileMode(0750), fi.Mode().Perm())
+
+	contents, err := ioutil.ReadFile(filepath.Join(dst, "link"))
+	require.NoError(err)
+	require.Equal([]byte("foo"), contents)
+
+	fi, err = os.Stat(filepath.Join(dst, "sub", "file"))
+	require.NoError(err)
+	require.Equal(os.FileMode(0600), fi.Mode().Perm())
+}
+
+func TestAllocDir_Checkpoint(t *testing.T) {
+	tmp1, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp1)
+
+	tmp2, err := ioutil.TempDir("", "AllocDir")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp2)
+
+	d1 := NewAllocDir(testlog.HCLogger(t), tmp1)
+	require.NoError(t, d1.Build())
+	defer d1.Destroy()
+
+	d2 := NewAllocDir(testlog.HCLogger(t), tmp2)
+	require.NoError(t, d2.Build())
+	defer d2.Destroy()
+
+	td1 := d1.NewTaskDir(t1.Name)
+	require.NoError(t, td1.Build(false, nil))
+	d2.NewTaskDir(t1.Name)
+
+	// Write a checkpoint image as a driver would
+	require.NoError(t, os.MkdirAll(td1.CheckpointDir, 0700))
+	image := "pages-1.img"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(td1.CheckpointDir, image), []byte("foo"), 0600))
+
+	// Ensure the checkpoint is included in snapshots
+	var b bytes.Buffer
+	require.NoError(t, d1.Snapshot(&b))
+
+	var found bool
+	tr := tar.NewReader(&b)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if hdr.Name == filepath.Join(t1.Name, TaskCheckpoint, image) {
+			found = true
+		}
+	}
+	require.True(t, found, "checkpoint image not in snapshot")
+
+	// Ensure the checkpoint is moved along with the task local dir
+	require.NoError(t, d2.Move(d1, []*structs.Task{t1}))
+	require.FileExists(t, filepath.Join(d2.TaskDirs[t1.Name].CheckpointDir, image))
+}
+
 func TestAllocDir_EscapeChecking(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "AllocDir")
 	if err != nil {
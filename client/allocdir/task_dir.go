@@ -39,6 +39,12 @@ type TaskDir struct {
 	// <task_dir>/secrets/
 	SecretsDir string
 
+	// CheckpointDir is the path to the checkpoint/ directory on the host
+	// where drivers write checkpoint images. It only exists once a task
+	// has been checkpointed.
+	// <task_dir>/checkpoint/
+	CheckpointDir string
+
 	logger hclog.Logger
 }
 
@@ -59,6 +65,7 @@ func newTaskDir(logger hclog.Logger, allocDir, taskName string) *TaskDir {
 		SharedTaskDir:  filepath.Join(taskDir, SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, TaskSecrets),
+		CheckpointDir:  filepath.Join(taskDir, TaskCheckpoint),
 		logger:         logger,
 	}
 }
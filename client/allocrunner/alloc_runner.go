@@ -133,6 +133,16 @@ type allocRunner struct {
 	// tasks are the set of task runners
 	tasks map[string]*taskrunner.TaskRunner
 
+	// diskUsage is the latest ephemeral disk usage of the allocation. It is
+	// nil unless the client enforces ephemeral disk sizes.
+	diskUsage     *cstructs.DiskStats
+	diskUsageLock sync.RWMutex
+
+	// networkUsage is the latest bandwidth usage of the allocation. It is
+	// nil unless the allocation has its own network namespace.
+	networkUsage     *cstructs.NetworkStats
+	networkUsageLock sync.RWMutex
+
 	// deviceStatsReporter is used to lookup resource usage for alloc devices
 	deviceStatsReporter cinterfaces.DeviceStatsReporter
 
@@ -1099,6 +1109,16 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		},
 	}
 
+	ar.diskUsageLock.RLock()
+	disk := ar.diskUsage
+	ar.diskUsageLock.RUnlock()
+	astat.ResourceUsage.DiskStats = disk
+
+	ar.networkUsageLock.RLock()
+	network := ar.networkUsage
+	ar.networkUsageLock.RUnlock()
+	astat.ResourceUsage.NetworkStats = network
+
 	for name, tr := range ar.tasks {
 		if taskFilter != "" && taskFilter != name {
 			// Getting stats for a particular task and its not this one!
@@ -1106,11 +1126,22 @@ func (ar *allocRunner) LatestAllocStats(taskFilter string) (*cstructs.AllocResou
 		}
 
 		if usage := tr.LatestResourceUsage(); usage != nil {
-			astat.Tasks[name] = usage
 			astat.ResourceUsage.Add(usage.ResourceUsage)
 			if usage.Timestamp > astat.Timestamp {
 				astat.Timestamp = usage.Timestamp
 			}
+
+			// Report the disk and network usage of the alloc for each task,
+			// copying the task's usage as it is shared with the task runner
+			if (disk != nil || network != nil) && usage.ResourceUsage != nil {
+				ru := *usage.ResourceUsage
+				ru.DiskStats = disk
+				ru.NetworkStats = network
+				taskUsage := *usage
+				taskUsage.ResourceUsage = &ru
+				usage = &taskUsage
+			}
+			astat.Tasks[name] = usage
 		}
 	}
 
@@ -1,12 +1,14 @@
 package allocrunner
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
 	clientconfig "github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/diskquota"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/client/taskenv"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -41,6 +43,38 @@ func (a *allocHookResourceSetter) SetAllocHookResources(res *cstructs.AllocHookR
 	}
 }
 
+// allocDiskQuotaEnforcer is a shim to allow the disk quota hook to report
+// disk usage and act on the tasks of the allocation.
+type allocDiskQuotaEnforcer struct {
+	ar *allocRunner
+}
+
+func (a *allocDiskQuotaEnforcer) SetDiskUsage(usage *diskquota.Usage) {
+	a.ar.diskUsageLock.Lock()
+	defer a.ar.diskUsageLock.Unlock()
+
+	a.ar.diskUsage = &cstructs.DiskStats{
+		Used:  usage.Used,
+		Limit: usage.Limit,
+	}
+}
+
+func (a *allocDiskQuotaEnforcer) EmitTaskEvent(event *structs.TaskEvent) {
+	for _, tr := range a.ar.tasks {
+		if tr.TaskState().State != structs.TaskStateDead {
+			tr.EmitEvent(event.Copy())
+		}
+	}
+}
+
+func (a *allocDiskQuotaEnforcer) KillTasks(event *structs.TaskEvent) {
+	for _, tr := range a.ar.tasks {
+		if tr.TaskState().State != structs.TaskStateDead {
+			go tr.Kill(context.TODO(), event.Copy())
+		}
+	}
+}
+
 // allocHealthSetter is a shim to allow the alloc health watcher hook to set
 // and clear the alloc health without full access to the alloc runner state
 type allocHealthSetter struct {
@@ -133,16 +167,25 @@ func (ar *allocRunner) initRunnerHooks(config *clientconfig.Config) error {
 		return fmt.Errorf("failed to initialize network configurator: %v", err)
 	}
 
-	// Create the alloc directory hook. This is run first to ensure the
-	// directory path exists for other hooks.
+	// create disk quota enforcing shim
+	dqe := &allocDiskQuotaEnforcer{ar: ar}
+
+	// Create the disk quota and alloc directory hooks. These are run first
+	// to ensure the directory path exists, limited to the ephemeral disk
+	// size, for other hooks.
 	alloc := ar.Alloc()
 	ar.runnerHooks = []interfaces.RunnerHook{
+		newDiskQuotaHook(hookLogger, alloc, ar.allocDir, config.DiskQuota, dqe),
 		newAllocDirHook(hookLogger, ar.allocDir),
 		newCgroupHook(ar.Alloc(), ar.cpusetManager),
 		newUpstreamAllocsHook(hookLogger, ar.prevAllocWatcher),
 		newDiskMigrationHook(hookLogger, ar.prevAllocMigrator, ar.allocDir),
 		newAllocHealthWatcherHook(hookLogger, alloc, hs, ar.Listener(), ar.consulClient),
-		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar),
+		newNetworkHook(hookLogger, ns, alloc, nm, nc, ar, networkBandwidthConfig{
+			enforce:     config.EnforceNetworkBandwidth,
+			interval:    config.StatsCollectionInterval,
+			usageSetter: &allocNetworkUsageSetter{ar: ar},
+		}),
 		newGroupServiceHook(groupServiceHookConfig{
 			alloc:               alloc,
 			consul:              ar.consulClient,
@@ -0,0 +1,171 @@
+package allocrunner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocdir"
+	clientconfig "github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/diskquota"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// diskQuotaEnforcer is used by the disk quota hook to report the disk usage of
+// the allocation and act on its tasks without full access to the alloc
+// runner.
+type diskQuotaEnforcer interface {
+	// SetDiskUsage records the latest disk usage of the allocation.
+	SetDiskUsage(*diskquota.Usage)
+
+	// EmitTaskEvent emits the event on all running tasks.
+	EmitTaskEvent(*structs.TaskEvent)
+
+	// KillTasks kills all running tasks with the event.
+	KillTasks(*structs.TaskEvent)
+}
+
+// diskQuotaHook limits the allocation directory to the size of the task
+// group's ephemeral disk and watches its usage. It must run before the alloc
+// dir is built so the whole directory is covered by the quota.
+type diskQuotaHook struct {
+	alloc    *structs.Allocation
+	allocDir *allocdir.AllocDir
+	config   *clientconfig.DiskQuotaConfig
+	enforcer diskQuotaEnforcer
+	logger   log.Logger
+
+	// stopWatch stops the usage watcher if one is running
+	stopWatch     context.CancelFunc
+	stopWatchLock sync.Mutex
+}
+
+func newDiskQuotaHook(logger log.Logger, alloc *structs.Allocation, allocDir *allocdir.AllocDir,
+	config *clientconfig.DiskQuotaConfig, enforcer diskQuotaEnforcer) *diskQuotaHook {
+	h := &diskQuotaHook{
+		alloc:    alloc,
+		allocDir: allocDir,
+		config:   config,
+		enforcer: enforcer,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (h *diskQuotaHook) Name() string {
+	return "disk_quota"
+}
+
+func (h *diskQuotaHook) Prerun() error {
+	if h.config == nil {
+		return nil
+	}
+
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil || tg.EphemeralDisk.SizeMB <= 0 {
+		return nil
+	}
+	sizeMB := tg.EphemeralDisk.SizeMB
+
+	// The alloc dir already exists when restoring an allocation
+	_, err := os.Stat(h.allocDir.AllocDir)
+	restored := err == nil
+
+	quota, err := diskquota.New(h.config.Mode, h.allocDir.AllocDir, sizeMB)
+	if err != nil {
+		if restored {
+			// The allocation may have been started before quotas were
+			// enabled, so keep running it without one
+			h.logger.Warn("failed to limit existing alloc dir, ephemeral disk size is not enforced", "error", err)
+			return nil
+		}
+		return fmt.Errorf("failed to limit alloc dir to %d MB: %v", sizeMB, err)
+	}
+	h.logger.Debug("limited alloc dir", "mode", quota.Mode(), "size_mb", sizeMB)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stopWatchLock.Lock()
+	h.stopWatch = cancel
+	h.stopWatchLock.Unlock()
+
+	go h.watch(ctx, quota, sizeMB)
+	return nil
+}
+
+// watch periodically reports the disk usage of the allocation and applies the
+// configured policy once its ephemeral disk is full.
+func (h *diskQuotaHook) watch(ctx context.Context, quota diskquota.Quota, sizeMB int) {
+	ticker := time.NewTicker(h.config.CheckInterval)
+	defer ticker.Stop()
+
+	exceeded := false
+	for {
+		usage, err := quota.Usage()
+		if err != nil {
+			h.logger.Debug("failed to get disk usage", "error", err)
+		} else {
+			h.enforcer.SetDiskUsage(usage)
+
+			// Only act once each time the disk fills up
+			if usage.Exceeded() && !exceeded {
+				h.exceeded(usage, sizeMB)
+			}
+			exceeded = usage.Exceeded()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *diskQuotaHook) exceeded(usage *diskquota.Usage, sizeMB int) {
+	event := structs.NewTaskEvent(structs.TaskDiskExceeded).
+		SetDiskLimit(int64(sizeMB)).
+		SetDiskUsage(usage.Used)
+
+	if h.config.Policy == clientconfig.DiskQuotaPolicyKill {
+		h.logger.Warn("ephemeral disk is full, killing tasks", "used", usage.Used, "limit_mb", sizeMB)
+		h.enforcer.KillTasks(event.SetFailsTask())
+		return
+	}
+
+	h.logger.Warn("ephemeral disk is full", "used", usage.Used, "limit_mb", sizeMB)
+	h.enforcer.EmitTaskEvent(event)
+}
+
+func (h *diskQuotaHook) stop() {
+	h.stopWatchLock.Lock()
+	defer h.stopWatchLock.Unlock()
+
+	if h.stopWatch != nil {
+		h.stopWatch()
+		h.stopWatch = nil
+	}
+}
+
+func (h *diskQuotaHook) Postrun() error {
+	h.stop()
+	return nil
+}
+
+func (h *diskQuotaHook) Shutdown() {
+	h.stop()
+}
+
+// Destroy removes the quota. It runs even if quotas are disabled so quotas
+// of allocations started before they were disabled are cleaned up.
+func (h *diskQuotaHook) Destroy() error {
+	h.stop()
+
+	// Nested mounts have to go before a disk image can be unmounted
+	if err := h.allocDir.UnmountAll(); err != nil {
+		return err
+	}
+	return diskquota.Remove(h.allocDir.AllocDir)
+}
@@ -0,0 +1,164 @@
+package allocrunner
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	clientconfig "github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/diskquota"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// statically assert disk quota hook implements the expected interfaces
+var _ interfaces.RunnerPrerunHook = (*diskQuotaHook)(nil)
+var _ interfaces.RunnerPostrunHook = (*diskQuotaHook)(nil)
+var _ interfaces.RunnerDestroyHook = (*diskQuotaHook)(nil)
+var _ interfaces.ShutdownHook = (*diskQuotaHook)(nil)
+
+type mockDiskQuotaEnforcer struct {
+	lock   sync.Mutex
+	usage  *diskquota.Usage
+	events []*structs.TaskEvent
+	kills  []*structs.TaskEvent
+}
+
+func (m *mockDiskQuotaEnforcer) SetDiskUsage(usage *diskquota.Usage) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.usage = usage
+}
+
+func (m *mockDiskQuotaEnforcer) EmitTaskEvent(event *structs.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.events = append(m.events, event)
+}
+
+func (m *mockDiskQuotaEnforcer) KillTasks(event *structs.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.kills = append(m.kills, event)
+}
+
+func (m *mockDiskQuotaEnforcer) get() (*diskquota.Usage, []*structs.TaskEvent, []*structs.TaskEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.usage, m.events, m.kills
+}
+
+func diskQuotaTestAllocDir(t *testing.T, alloc *structs.Allocation) *allocdir.AllocDir {
+	tmp, err := ioutil.TempDir("", "diskquota")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(tmp) })
+
+	return allocdir.NewAllocDir(testlog.HCLogger(t), filepath.Join(tmp, alloc.ID))
+}
+
+func TestDiskQuotaHook_Disabled(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	allocDir := diskQuotaTestAllocDir(t, alloc)
+	enforcer := &mockDiskQuotaEnforcer{}
+
+	h := newDiskQuotaHook(testlog.HCLogger(t), alloc, allocDir, nil, enforcer)
+	require.NoError(t, h.Prerun())
+	require.NoDirExists(t, allocDir.AllocDir)
+	require.NoError(t, h.Postrun())
+	require.NoError(t, h.Destroy())
+
+	usage, _, _ := enforcer.get()
+	require.Nil(t, usage)
+}
+
+func TestDiskQuotaHook_Loopback(t *testing.T) {
+	if runtime.GOOS != "linux" || syscall.Geteuid() != 0 {
+		t.Skip("Test only available running as root on linux")
+	}
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		t.Skip("Test requires mkfs.ext4")
+	}
+
+	for _, policy := range []string{clientconfig.DiskQuotaPolicyEvent, clientconfig.DiskQuotaPolicyKill} {
+		t.Run(policy, func(t *testing.T) {
+			require := require.New(t)
+
+			alloc := mock.Alloc()
+			alloc.Job.TaskGroups[0].EphemeralDisk.SizeMB = 16
+			allocDir := diskQuotaTestAllocDir(t, alloc)
+			enforcer := &mockDiskQuotaEnforcer{}
+			config := &clientconfig.DiskQuotaConfig{
+				Mode:          diskquota.ModeLoopback,
+				Policy:        policy,
+				CheckInterval: 10 * time.Millisecond,
+			}
+
+			h := newDiskQuotaHook(testlog.HCLogger(t), alloc, allocDir, config, enforcer)
+			require.NoError(h.Prerun())
+			defer h.Destroy()
+			require.NoError(allocDir.Build())
+
+			testutil.WaitForResult(func() (bool, error) {
+				usage, _, _ := enforcer.get()
+				return usage != nil, nil
+			}, func(err error) {
+				t.Fatalf("disk usage not reported")
+			})
+
+			// Fill the disk
+			f, err := os.Create(filepath.Join(allocDir.SharedDir, allocdir.SharedDataDir, "file"))
+			require.NoError(err)
+			buf := bytes.Repeat([]byte{'x'}, 1024*1024)
+			for i := 0; i < 16; i++ {
+				if _, err := f.Write(buf); err != nil {
+					break
+				}
+			}
+			require.NoError(f.Close())
+
+			testutil.WaitForResult(func() (bool, error) {
+				_, events, kills := enforcer.get()
+				return len(events)+len(kills) > 0, nil
+			}, func(err error) {
+				t.Fatalf("full disk not detected")
+			})
+
+			usage, events, kills := enforcer.get()
+			require.True(usage.Exceeded())
+			if policy == clientconfig.DiskQuotaPolicyKill {
+				require.Empty(events)
+				require.Len(kills, 1)
+				require.True(kills[0].FailsTask)
+				require.Equal(structs.TaskDiskExceeded, kills[0].Type)
+			} else {
+				require.Empty(kills)
+				require.Len(events, 1)
+				require.Equal(structs.TaskDiskExceeded, events[0].Type)
+				require.Equal("16", events[0].Details["disk_limit"])
+			}
+
+			// Stopping the watcher keeps the disk until the alloc is
+			// destroyed
+			require.NoError(h.Postrun())
+			require.FileExists(filepath.Join(allocDir.SharedDir, allocdir.SharedDataDir, "file"))
+
+			require.NoError(h.Destroy())
+			require.NoFileExists(allocDir.AllocDir + ".img")
+			require.NoFileExists(filepath.Join(allocDir.SharedDir, allocdir.SharedDataDir, "file"))
+		})
+	}
+}
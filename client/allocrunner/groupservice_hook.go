@@ -84,7 +84,7 @@ func newGroupServiceHook(cfg groupServiceHookConfig) *groupServiceHook {
 	}
 
 	if cfg.alloc.DeploymentStatus != nil {
-		h.canary = cfg.alloc.DeploymentStatus.Canary
+		h.canary = cfg.alloc.DeploymentStatus.UsesCanaryTags()
 	}
 
 	return h
@@ -122,7 +122,7 @@ func (h *groupServiceHook) Update(req *interfaces.RunnerUpdateRequest) error {
 	// Store new updated values out of request
 	canary := false
 	if req.Alloc.DeploymentStatus != nil {
-		canary = req.Alloc.DeploymentStatus.Canary
+		canary = req.Alloc.DeploymentStatus.UsesCanaryTags()
 	}
 
 	var networks structs.Networks
@@ -3,8 +3,12 @@ package allocrunner
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/bandwidth"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/drivers"
 )
@@ -33,6 +37,37 @@ func (a *allocNetworkIsolationSetter) SetNetworkIsolation(n *drivers.NetworkIsol
 
 type networkStatusSetter interface {
 	SetNetworkStatus(*structs.AllocNetworkStatus)
+	NetworkStatus() *structs.AllocNetworkStatus
+}
+
+type networkUsageSetter interface {
+	SetNetworkUsage(*cstructs.NetworkStats)
+}
+
+// allocNetworkUsageSetter is a shim to allow the alloc network hook to report
+// the network usage of the alloc without full access to the alloc runner
+type allocNetworkUsageSetter struct {
+	ar *allocRunner
+}
+
+func (a *allocNetworkUsageSetter) SetNetworkUsage(s *cstructs.NetworkStats) {
+	a.ar.networkUsageLock.Lock()
+	defer a.ar.networkUsageLock.Unlock()
+	a.ar.networkUsage = s
+}
+
+// networkBandwidthConfig configures how the network hook limits and reports
+// the bandwidth of the alloc
+type networkBandwidthConfig struct {
+	// enforce limits the alloc's interface to the MBits of its network
+	enforce bool
+
+	// interval is how often the bandwidth usage is sampled
+	interval time.Duration
+
+	// usageSetter is a callback to report the bandwidth usage. Usage isn't
+	// reported if it is nil.
+	usageSetter networkUsageSetter
 }
 
 // networkHook is an alloc lifecycle hook that manages the network namespace
@@ -61,6 +96,13 @@ type networkHook struct {
 	// the alloc network has been created
 	networkConfigurator NetworkConfigurator
 
+	// bandwidth configures limiting and reporting the alloc's bandwidth
+	bandwidth networkBandwidthConfig
+
+	// stopWatch stops the bandwidth usage watcher if one is running
+	stopWatch     context.CancelFunc
+	stopWatchLock sync.Mutex
+
 	logger hclog.Logger
 }
 
@@ -69,13 +111,15 @@ func newNetworkHook(logger hclog.Logger,
 	alloc *structs.Allocation,
 	netManager drivers.DriverNetworkManager,
 	netConfigurator NetworkConfigurator,
-	networkStatusSetter networkStatusSetter) *networkHook {
+	networkStatusSetter networkStatusSetter,
+	bandwidth networkBandwidthConfig) *networkHook {
 	return &networkHook{
 		isolationSetter:     ns,
 		networkStatusSetter: networkStatusSetter,
 		alloc:               alloc,
 		manager:             netManager,
 		networkConfigurator: netConfigurator,
+		bandwidth:           bandwidth,
 		logger:              logger,
 	}
 }
@@ -124,11 +168,118 @@ func (h *networkHook) Prerun() error {
 			}
 		}
 		h.networkStatusSetter.SetNetworkStatus(status)
+		return h.limitBandwidth(status, false)
+	}
+
+	if spec != nil {
+		return h.limitBandwidth(h.networkStatusSetter.NetworkStatus(), true)
+	}
+	return nil
+}
+
+// limitBandwidth limits the alloc's interface to the MBits of its network if
+// enforcement is enabled, and starts watching its bandwidth usage. Failing to
+// limit a restored alloc isn't fatal, as it may have been started before
+// enforcement was enabled.
+func (h *networkHook) limitBandwidth(status *structs.AllocNetworkStatus, restored bool) error {
+	if status == nil || status.InterfaceName == "" {
+		h.logger.Debug("alloc network has no interface, bandwidth is not limited")
+		return nil
+	}
+
+	limit := 0
+	if h.bandwidth.enforce {
+		limit = h.allocMBits()
+	}
+	if limit > 0 {
+		err := bandwidth.Limit(h.spec.Path, status.InterfaceName, limit)
+		switch {
+		case err == nil:
+			h.logger.Debug("limited alloc bandwidth", "interface", status.InterfaceName, "mbits", limit)
+		case restored:
+			h.logger.Warn("failed to limit bandwidth of existing alloc, bandwidth is not enforced", "error", err)
+			limit = 0
+		default:
+			return fmt.Errorf("failed to limit alloc bandwidth to %d MBits: %v", limit, err)
+		}
 	}
+
+	if h.bandwidth.usageSetter == nil || h.bandwidth.interval <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stopWatchLock.Lock()
+	h.stopWatch = cancel
+	h.stopWatchLock.Unlock()
+
+	go h.watchBandwidth(ctx, status.InterfaceName, limit)
 	return nil
 }
 
+// allocMBits returns the bandwidth allocated to the alloc's network
+func (h *networkHook) allocMBits() int {
+	if ar := h.alloc.AllocatedResources; ar != nil && len(ar.Shared.Networks) > 0 {
+		return ar.Shared.Networks[0].MBits
+	}
+
+	tg := h.alloc.Job.LookupTaskGroup(h.alloc.TaskGroup)
+	if tg == nil || len(tg.Networks) == 0 {
+		return 0
+	}
+	return tg.Networks[0].MBits
+}
+
+// watchBandwidth periodically reports the bandwidth usage of the alloc's
+// interface.
+func (h *networkHook) watchBandwidth(ctx context.Context, iface string, limit int) {
+	ticker := time.NewTicker(h.bandwidth.interval)
+	defer ticker.Stop()
+
+	var prev *bandwidth.Counters
+	for {
+		cur, err := bandwidth.Read(h.spec.Path, iface)
+		if err == bandwidth.ErrNotSupported {
+			return
+		} else if err != nil {
+			h.logger.Debug("failed to get bandwidth usage", "error", err)
+		} else {
+			rx, tx := cur.Rate(prev, h.bandwidth.interval)
+			h.bandwidth.usageSetter.SetNetworkUsage(&cstructs.NetworkStats{
+				RxBytes:    cur.RxBytes,
+				TxBytes:    cur.TxBytes,
+				RxMBits:    rx,
+				TxMBits:    tx,
+				LimitMBits: limit,
+			})
+			prev = cur
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *networkHook) stopBandwidthWatch() {
+	h.stopWatchLock.Lock()
+	defer h.stopWatchLock.Unlock()
+
+	if h.stopWatch != nil {
+		h.stopWatch()
+		h.stopWatch = nil
+	}
+}
+
+func (h *networkHook) Shutdown() {
+	h.stopBandwidthWatch()
+}
+
 func (h *networkHook) Postrun() error {
+	h.stopBandwidthWatch()
+
 	if h.spec == nil {
 		return nil
 	}
@@ -15,6 +15,7 @@ import (
 // statically assert network hook implements the expected interfaces
 var _ interfaces.RunnerPrerunHook = (*networkHook)(nil)
 var _ interfaces.RunnerPostrunHook = (*networkHook)(nil)
+var _ interfaces.ShutdownHook = (*networkHook)(nil)
 
 type mockNetworkIsolationSetter struct {
 	t            *testing.T
@@ -38,6 +39,10 @@ func (m *mockNetworkStatusSetter) SetNetworkStatus(status *structs.AllocNetworkS
 	require.Exactly(m.t, m.expectedStatus, status)
 }
 
+func (m *mockNetworkStatusSetter) NetworkStatus() *structs.AllocNetworkStatus {
+	return m.expectedStatus
+}
+
 // Test that the prerun and postrun hooks call the setter with the expected spec when
 // the network mode is not host
 func TestNetworkHook_Prerun_Postrun(t *testing.T) {
@@ -80,7 +85,7 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	require := require.New(t)
 
 	logger := testlog.HCLogger(t)
-	hook := newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter)
+	hook := newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, networkBandwidthConfig{})
 	require.NoError(hook.Prerun())
 	require.True(setter.called)
 	require.False(destroyCalled)
@@ -91,7 +96,7 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	setter.called = false
 	destroyCalled = false
 	alloc.Job.TaskGroups[0].Networks[0].Mode = "host"
-	hook = newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter)
+	hook = newNetworkHook(logger, setter, alloc, nm, &hostNetworkConfigurator{}, statusSetter, networkBandwidthConfig{})
 	require.NoError(hook.Prerun())
 	require.False(setter.called)
 	require.False(destroyCalled)
@@ -99,3 +104,26 @@ func TestNetworkHook_Prerun_Postrun(t *testing.T) {
 	require.False(destroyCalled)
 
 }
+
+// Test that the bandwidth limit comes from the allocated resources and falls
+// back to the task group's network
+func TestNetworkHook_AllocMBits(t *testing.T) {
+	require := require.New(t)
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.Networks = []*structs.NetworkResource{
+		{Mode: "bridge", MBits: 50},
+	}
+	alloc.Job.TaskGroups[0].Networks = []*structs.NetworkResource{
+		{Mode: "bridge", MBits: 20},
+	}
+	hook := newNetworkHook(logger, nil, alloc, nil, nil, nil, networkBandwidthConfig{})
+	require.Equal(50, hook.allocMBits())
+
+	alloc.AllocatedResources = nil
+	require.Equal(20, hook.allocMBits())
+
+	alloc.Job.TaskGroups[0].Networks = nil
+	require.Equal(0, hook.allocMBits())
+}
@@ -2,7 +2,9 @@ package allocrunner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
 
 	"github.com/coreos/go-iptables/iptables"
 	hclog "github.com/hashicorp/go-hclog"
@@ -36,14 +38,20 @@ type bridgeNetworkConfigurator struct {
 	allocSubnet string
 	bridgeName  string
 
+	// allocSubnetIPv6 is the IPv6 subnet addresses are allocated from in
+	// addition to allocSubnet. If allocSubnet is itself an IPv6 subnet the
+	// bridge is IPv6 only.
+	allocSubnetIPv6 string
+
 	logger hclog.Logger
 }
 
-func newBridgeNetworkConfigurator(log hclog.Logger, bridgeName, ipRange, cniPath string, ignorePortMappingHostIP bool) (*bridgeNetworkConfigurator, error) {
+func newBridgeNetworkConfigurator(log hclog.Logger, bridgeName, ipRange, ipv6Range, cniPath string, ignorePortMappingHostIP bool, hostIPv6 map[string]string) (*bridgeNetworkConfigurator, error) {
 	b := &bridgeNetworkConfigurator{
-		bridgeName:  bridgeName,
-		allocSubnet: ipRange,
-		logger:      log,
+		bridgeName:      bridgeName,
+		allocSubnet:     ipRange,
+		allocSubnetIPv6: ipv6Range,
+		logger:          log,
 	}
 
 	if b.bridgeName == "" {
@@ -54,7 +62,17 @@ func newBridgeNetworkConfigurator(log hclog.Logger, bridgeName, ipRange, cniPath
 		b.allocSubnet = defaultNomadAllocSubnet
 	}
 
-	c, err := newCNINetworkConfiguratorWithConf(log, cniPath, bridgeNetworkAllocIfPrefix, ignorePortMappingHostIP, buildNomadBridgeNetConfig(b.bridgeName, b.allocSubnet))
+	subnets, err := b.subnets()
+	if err != nil {
+		return nil, err
+	}
+
+	netConf, err := buildNomadBridgeNetConfig(b.bridgeName, subnets)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := newCNINetworkConfiguratorWithConf(log, cniPath, bridgeNetworkAllocIfPrefix, ignorePortMappingHostIP, hostIPv6, netConf)
 	if err != nil {
 		return nil, err
 	}
@@ -63,20 +81,59 @@ func newBridgeNetworkConfigurator(log hclog.Logger, bridgeName, ipRange, cniPath
 	return b, nil
 }
 
+// subnets validates the configured subnets and returns them, IPv4 first.
+func (b *bridgeNetworkConfigurator) subnets() ([]string, error) {
+	subnets := []string{b.allocSubnet}
+	if isIPv6Subnet(b.allocSubnet) && b.allocSubnetIPv6 != "" {
+		return nil, fmt.Errorf("bridge network subnet %q and IPv6 subnet %q can't both be IPv6", b.allocSubnet, b.allocSubnetIPv6)
+	}
+	if b.allocSubnetIPv6 != "" {
+		if !isIPv6Subnet(b.allocSubnetIPv6) {
+			return nil, fmt.Errorf("bridge network IPv6 subnet %q is not an IPv6 subnet", b.allocSubnetIPv6)
+		}
+		subnets = append(subnets, b.allocSubnetIPv6)
+	}
+
+	for _, subnet := range subnets {
+		if _, _, err := net.ParseCIDR(subnet); err != nil {
+			return nil, fmt.Errorf("invalid bridge network subnet %q: %v", subnet, err)
+		}
+	}
+	return subnets, nil
+}
+
+// isIPv6Subnet returns true if subnet is an IPv6 CIDR.
+func isIPv6Subnet(subnet string) bool {
+	ip, _, err := net.ParseCIDR(subnet)
+	return err == nil && ip.To4() == nil
+}
+
 // ensureForwardingRules ensures that a forwarding rule is added to iptables
-// to allow traffic inbound to the bridge network
+// and ip6tables to allow traffic inbound to the bridge network
 func (b *bridgeNetworkConfigurator) ensureForwardingRules() error {
-	ipt, err := iptables.New()
+	subnets, err := b.subnets()
 	if err != nil {
 		return err
 	}
 
-	if err = ensureChain(ipt, "filter", cniAdminChainName); err != nil {
-		return err
-	}
+	for _, subnet := range subnets {
+		proto := iptables.ProtocolIPv4
+		if isIPv6Subnet(subnet) {
+			proto = iptables.ProtocolIPv6
+		}
 
-	if err := appendChainRule(ipt, cniAdminChainName, b.generateAdminChainRule()); err != nil {
-		return err
+		ipt, err := iptables.NewWithProtocol(proto)
+		if err != nil {
+			return err
+		}
+
+		if err = ensureChain(ipt, "filter", cniAdminChainName); err != nil {
+			return err
+		}
+
+		if err := appendChainRule(ipt, cniAdminChainName, b.generateAdminChainRule(subnet)); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -115,9 +172,10 @@ func appendChainRule(ipt *iptables.IPTables, chain string, rule []string) error
 }
 
 // generateAdminChainRule builds the iptables rule that is inserted into the
-// CNI admin chain to ensure traffic forwarding to the bridge network
-func (b *bridgeNetworkConfigurator) generateAdminChainRule() []string {
-	return []string{"-o", b.bridgeName, "-d", b.allocSubnet, "-j", "ACCEPT"}
+// CNI admin chain to ensure traffic forwarding to the subnet of the bridge
+// network
+func (b *bridgeNetworkConfigurator) generateAdminChainRule(subnet string) []string {
+	return []string{"-o", b.bridgeName, "-d", subnet, "-j", "ACCEPT"}
 }
 
 // Setup calls the CNI plugins with the add action
@@ -134,8 +192,32 @@ func (b *bridgeNetworkConfigurator) Teardown(ctx context.Context, alloc *structs
 	return b.cni.Teardown(ctx, alloc, spec)
 }
 
-func buildNomadBridgeNetConfig(bridgeName, subnet string) []byte {
-	return []byte(fmt.Sprintf(nomadCNIConfigTemplate, bridgeName, subnet, cniAdminChainName))
+// buildNomadBridgeNetConfig builds the CNI config of the bridge network. An
+// address is allocated from each of the subnets, so passing an IPv4 and an
+// IPv6 subnet gives allocations dual-stack networking.
+func buildNomadBridgeNetConfig(bridgeName string, subnets []string) ([]byte, error) {
+	var ranges [][]map[string]string
+	var routes []map[string]string
+	for _, subnet := range subnets {
+		ranges = append(ranges, []map[string]string{{"subnet": subnet}})
+
+		dst := "0.0.0.0/0"
+		if isIPv6Subnet(subnet) {
+			dst = "::/0"
+		}
+		routes = append(routes, map[string]string{"dst": dst})
+	}
+
+	rangesJSON, err := json.Marshal(ranges)
+	if err != nil {
+		return nil, err
+	}
+	routesJSON, err := json.Marshal(routes)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(fmt.Sprintf(nomadCNIConfigTemplate, bridgeName, rangesJSON, routesJSON, cniAdminChainName)), nil
 }
 
 const nomadCNIConfigTemplate = `{
@@ -150,16 +232,8 @@ const nomadCNIConfigTemplate = `{
 			"forceAddress": true,
 			"ipam": {
 				"type": "host-local",
-				"ranges": [
-					[
-						{
-							"subnet": "%s"
-						}
-					]
-				],
-				"routes": [
-					{ "dst": "0.0.0.0/0" }
-				]
+				"ranges": %s,
+				"routes": %s
 			}
 		},
 		{
@@ -0,0 +1,96 @@
+package allocrunner
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeNetworkConfigurator_Subnets(t *testing.T) {
+	cases := []struct {
+		name    string
+		subnet  string
+		ipv6    string
+		subnets []string
+		err     bool
+	}{
+		{
+			name:    "default",
+			subnets: []string{defaultNomadAllocSubnet},
+		},
+		{
+			name:    "dual stack",
+			subnet:  "10.0.0.0/16",
+			ipv6:    "fd00:a110:c8::/64",
+			subnets: []string{"10.0.0.0/16", "fd00:a110:c8::/64"},
+		},
+		{
+			name:    "ipv6 only",
+			subnet:  "fd00:a110:c8::/64",
+			subnets: []string{"fd00:a110:c8::/64"},
+		},
+		{
+			name:   "both ipv6",
+			subnet: "fd00:a110:c8::/64",
+			ipv6:   "fd00:a110:c9::/64",
+			err:    true,
+		},
+		{
+			name:   "ipv4 as ipv6",
+			subnet: "10.0.0.0/16",
+			ipv6:   "10.1.0.0/16",
+			err:    true,
+		},
+		{
+			name:   "invalid",
+			subnet: "10.0.0.0",
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := newBridgeNetworkConfigurator(testlog.HCLogger(t), "", tc.subnet, tc.ipv6, "", false, nil)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			subnets, err := b.subnets()
+			require.NoError(t, err)
+			require.Equal(t, tc.subnets, subnets)
+		})
+	}
+}
+
+func TestBuildNomadBridgeNetConfig_DualStack(t *testing.T) {
+	conf, err := buildNomadBridgeNetConfig("nomad", []string{"10.0.0.0/16", "fd00:a110:c8::/64"})
+	require.NoError(t, err)
+
+	var netConf struct {
+		Plugins []struct {
+			Type string
+			IPAM struct {
+				Ranges [][]struct {
+					Subnet string
+				}
+				Routes []struct {
+					Dst string
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(conf, &netConf))
+
+	ipam := netConf.Plugins[0].IPAM
+	require.Equal(t, "bridge", netConf.Plugins[0].Type)
+	require.Len(t, ipam.Ranges, 2)
+	require.Equal(t, "10.0.0.0/16", ipam.Ranges[0][0].Subnet)
+	require.Equal(t, "fd00:a110:c8::/64", ipam.Ranges[1][0].Subnet)
+	require.Len(t, ipam.Routes, 2)
+	require.Equal(t, "0.0.0.0/0", ipam.Routes[0].Dst)
+	require.Equal(t, "::/0", ipam.Routes[1].Dst)
+}
@@ -42,25 +42,30 @@ type cniNetworkConfigurator struct {
 	cniConf                 []byte
 	ignorePortMappingHostIP bool
 
+	// hostIPv6 maps the IPv4 addresses of the node's host networks to the
+	// IPv6 address of the same host network
+	hostIPv6 map[string]string
+
 	rand   *rand.Rand
 	logger log.Logger
 }
 
-func newCNINetworkConfigurator(logger log.Logger, cniPath, cniInterfacePrefix, cniConfDir, networkName string, ignorePortMappingHostIP bool) (*cniNetworkConfigurator, error) {
+func newCNINetworkConfigurator(logger log.Logger, cniPath, cniInterfacePrefix, cniConfDir, networkName string, ignorePortMappingHostIP bool, hostIPv6 map[string]string) (*cniNetworkConfigurator, error) {
 	cniConf, err := loadCNIConf(cniConfDir, networkName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load CNI config: %v", err)
 	}
 
-	return newCNINetworkConfiguratorWithConf(logger, cniPath, cniInterfacePrefix, ignorePortMappingHostIP, cniConf)
+	return newCNINetworkConfiguratorWithConf(logger, cniPath, cniInterfacePrefix, ignorePortMappingHostIP, hostIPv6, cniConf)
 }
 
-func newCNINetworkConfiguratorWithConf(logger log.Logger, cniPath, cniInterfacePrefix string, ignorePortMappingHostIP bool, cniConf []byte) (*cniNetworkConfigurator, error) {
+func newCNINetworkConfiguratorWithConf(logger log.Logger, cniPath, cniInterfacePrefix string, ignorePortMappingHostIP bool, hostIPv6 map[string]string, cniConf []byte) (*cniNetworkConfigurator, error) {
 	conf := &cniNetworkConfigurator{
 		cniConf:                 cniConf,
 		rand:                    rand.New(rand.NewSource(time.Now().Unix())),
 		logger:                  logger,
 		ignorePortMappingHostIP: ignorePortMappingHostIP,
+		hostIPv6:                hostIPv6,
 	}
 	if cniPath == "" {
 		if cniPath = os.Getenv(envCNIPath); cniPath == "" {
@@ -96,7 +101,7 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 	var res *cni.CNIResult
 	for attempt := 1; ; attempt++ {
 		var err error
-		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path, cni.WithCapabilityPortMap(getPortMapping(alloc, c.ignorePortMappingHostIP))); err != nil {
+		if res, err = c.cni.Setup(ctx, alloc.ID, spec.Path, cni.WithCapabilityPortMap(getPortMapping(alloc, c.ignorePortMappingHostIP, c.hostIPv6))); err != nil {
 			c.logger.Warn("failed to configure network", "err", err, "attempt", attempt)
 			switch attempt {
 			case 1:
@@ -123,7 +128,9 @@ func (c *cniNetworkConfigurator) Setup(ctx context.Context, alloc *structs.Alloc
 
 // cniToAllocNet converts a CNIResult to an AllocNetworkStatus or returns an
 // error. The first interface and IP with a sandbox and address set are
-// preferred. Failing that the first interface with an IP is selected.
+// preferred. Failing that the first interface with an IP is selected. If the
+// selected interface has both an IPv4 and an IPv6 address, both are recorded
+// and the IPv4 address is used as the primary address.
 //
 // Unfortunately the go-cni library returns interfaces in an unordered map so
 // the results may be nondeterministic depending on CNI plugin output.
@@ -137,10 +144,11 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.CNIResult) (*structs.All
 				// this should never happen but this value is coming from external
 				// plugins so we should guard against it
 				delete(res.Interfaces, name)
+				continue
 			}
 
 			if iface.Sandbox != "" && len(iface.IPConfigs) > 0 {
-				netStatus.Address = iface.IPConfigs[0].IP.String()
+				setAllocNetAddresses(netStatus, iface.IPConfigs)
 				netStatus.InterfaceName = name
 				break
 			}
@@ -153,9 +161,8 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.CNIResult) (*structs.All
 		var found bool
 		for name, iface := range res.Interfaces {
 			if len(iface.IPConfigs) > 0 {
-				ip := iface.IPConfigs[0].IP.String()
-				c.logger.Debug("no sandbox interface with an address found CNI result, using first available", "interface", name, "ip", ip)
-				netStatus.Address = ip
+				setAllocNetAddresses(netStatus, iface.IPConfigs)
+				c.logger.Debug("no sandbox interface with an address found CNI result, using first available", "interface", name, "ip", netStatus.Address)
 				netStatus.InterfaceName = name
 				found = true
 				break
@@ -184,6 +191,29 @@ func (c *cniNetworkConfigurator) cniToAllocNet(res *cni.CNIResult) (*structs.All
 	return netStatus, nil
 }
 
+// setAllocNetAddresses sets the addresses of the network status to the first
+// IPv4 and IPv6 addresses of an interface.
+func setAllocNetAddresses(netStatus *structs.AllocNetworkStatus, ipConfigs []*cni.IPConfig) {
+	var ipv4 string
+	for _, ipConfig := range ipConfigs {
+		if ipConfig == nil || ipConfig.IP == nil {
+			continue
+		}
+		if ipConfig.IP.To4() != nil {
+			if ipv4 == "" {
+				ipv4 = ipConfig.IP.String()
+			}
+		} else if netStatus.AddressIPv6 == "" {
+			netStatus.AddressIPv6 = ipConfig.IP.String()
+		}
+	}
+
+	netStatus.Address = ipv4
+	if netStatus.Address == "" {
+		netStatus.Address = netStatus.AddressIPv6
+	}
+}
+
 func loadCNIConf(confDir, name string) ([]byte, error) {
 	files, err := cnilibrary.ConfFiles(confDir, []string{".conf", ".conflist", ".json"})
 	switch {
@@ -225,7 +255,7 @@ func (c *cniNetworkConfigurator) Teardown(ctx context.Context, alloc *structs.Al
 		return err
 	}
 
-	return c.cni.Remove(ctx, alloc.ID, spec.Path, cni.WithCapabilityPortMap(getPortMapping(alloc, c.ignorePortMappingHostIP)))
+	return c.cni.Remove(ctx, alloc.ID, spec.Path, cni.WithCapabilityPortMap(getPortMapping(alloc, c.ignorePortMappingHostIP, c.hostIPv6)))
 }
 
 func (c *cniNetworkConfigurator) ensureCNIInitialized() error {
@@ -237,8 +267,11 @@ func (c *cniNetworkConfigurator) ensureCNIInitialized() error {
 }
 
 // getPortMapping builds a list of portMapping structs that are used as the
-// portmapping capability arguments for the portmap CNI plugin
-func getPortMapping(alloc *structs.Allocation, ignoreHostIP bool) []cni.PortMapping {
+// portmapping capability arguments for the portmap CNI plugin. Ports are
+// mapped for their protocol, or for both TCP and UDP if they have none. Ports
+// published on an IPv4 host IP are also mapped on the IPv6 address hostIPv6
+// maps it to, so that dual-stack host networks forward both families.
+func getPortMapping(alloc *structs.Allocation, ignoreHostIP bool, hostIPv6 map[string]string) []cni.PortMapping {
 	ports := []cni.PortMapping{}
 
 	if len(alloc.AllocatedResources.Shared.Ports) == 0 && len(alloc.AllocatedResources.Shared.Networks) > 0 {
@@ -247,7 +280,7 @@ func getPortMapping(alloc *structs.Allocation, ignoreHostIP bool) []cni.PortMapp
 				if port.To < 1 {
 					port.To = port.Value
 				}
-				for _, proto := range []string{"tcp", "udp"} {
+				for _, proto := range port.Protocols() {
 					ports = append(ports, cni.PortMapping{
 						HostPort:      int32(port.Value),
 						ContainerPort: int32(port.To),
@@ -261,18 +294,59 @@ func getPortMapping(alloc *structs.Allocation, ignoreHostIP bool) []cni.PortMapp
 			if port.To < 1 {
 				port.To = port.Value
 			}
-			for _, proto := range []string{"tcp", "udp"} {
-				portMapping := cni.PortMapping{
-					HostPort:      int32(port.Value),
-					ContainerPort: int32(port.To),
-					Protocol:      proto,
+
+			hostIPs := []string{""}
+			if !ignoreHostIP {
+				hostIPs = []string{port.HostIP}
+				if ipv6, ok := hostIPv6[port.HostIP]; ok {
+					hostIPs = append(hostIPs, ipv6)
 				}
-				if !ignoreHostIP {
-					portMapping.HostIP = port.HostIP
+			}
+
+			for _, proto := range port.Protocols() {
+				for _, hostIP := range hostIPs {
+					ports = append(ports, cni.PortMapping{
+						HostPort:      int32(port.Value),
+						ContainerPort: int32(port.To),
+						Protocol:      proto,
+						HostIP:        hostIP,
+					})
 				}
-				ports = append(ports, portMapping)
 			}
 		}
 	}
 	return ports
 }
+
+// hostIPv6Addresses maps the IPv4 addresses of the node's host networks to
+// the first IPv6 address of the same host network.
+func hostIPv6Addresses(node *structs.Node) map[string]string {
+	if node == nil || node.NodeResources == nil {
+		return nil
+	}
+
+	ipv4 := map[string][]string{}
+	ipv6 := map[string]string{}
+	for _, network := range node.NodeResources.NodeNetworks {
+		for _, addr := range network.Addresses {
+			switch addr.Family {
+			case structs.NodeNetworkAF_IPv4:
+				ipv4[addr.Alias] = append(ipv4[addr.Alias], addr.Address)
+			case structs.NodeNetworkAF_IPv6:
+				if _, ok := ipv6[addr.Alias]; !ok {
+					ipv6[addr.Alias] = addr.Address
+				}
+			}
+		}
+	}
+
+	m := map[string]string{}
+	for alias, addrs := range ipv4 {
+		if v6, ok := ipv6[alias]; ok {
+			for _, addr := range addrs {
+				m[addr] = v6
+			}
+		}
+	}
+	return m
+}
@@ -8,6 +8,8 @@ import (
 
 	cni "github.com/containerd/go-cni"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -63,3 +65,127 @@ func TestCNI_cniToAllocNet_Invalid(t *testing.T) {
 	require.Error(t, err)
 	require.Nil(t, allocNet)
 }
+
+// TestCNI_cniToAllocNet_DualStack asserts both addresses of a dual-stack
+// sandbox interface are recorded and the IPv4 address is preferred.
+func TestCNI_cniToAllocNet_DualStack(t *testing.T) {
+	cniResult := &cni.CNIResult{
+		Interfaces: map[string]*cni.Config{
+			"eth0": &cni.Config{
+				Sandbox: "/var/run/netns/test",
+				IPConfigs: []*cni.IPConfig{
+					&cni.IPConfig{
+						IP: net.ParseIP("fd00:a110:c8::2"),
+					},
+					&cni.IPConfig{
+						IP: net.IPv4(172, 26, 64, 2),
+					},
+				},
+			},
+		},
+	}
+
+	// Only need a logger
+	c := &cniNetworkConfigurator{
+		logger: testlog.HCLogger(t),
+	}
+	allocNet, err := c.cniToAllocNet(cniResult)
+	require.NoError(t, err)
+	require.NotNil(t, allocNet)
+	assert.Equal(t, "172.26.64.2", allocNet.Address)
+	assert.Equal(t, "fd00:a110:c8::2", allocNet.AddressIPv6)
+	assert.Equal(t, "eth0", allocNet.InterfaceName)
+}
+
+// TestCNI_cniToAllocNet_IPv6 asserts the IPv6 address is used as the primary
+// address of an IPv6 only interface.
+func TestCNI_cniToAllocNet_IPv6(t *testing.T) {
+	cniResult := &cni.CNIResult{
+		Interfaces: map[string]*cni.Config{
+			"eth0": &cni.Config{
+				Sandbox: "/var/run/netns/test",
+				IPConfigs: []*cni.IPConfig{
+					&cni.IPConfig{
+						IP: net.ParseIP("fd00:a110:c8::2"),
+					},
+				},
+			},
+		},
+	}
+
+	// Only need a logger
+	c := &cniNetworkConfigurator{
+		logger: testlog.HCLogger(t),
+	}
+	allocNet, err := c.cniToAllocNet(cniResult)
+	require.NoError(t, err)
+	require.NotNil(t, allocNet)
+	assert.Equal(t, "fd00:a110:c8::2", allocNet.Address)
+	assert.Equal(t, "fd00:a110:c8::2", allocNet.AddressIPv6)
+}
+
+// TestCNI_getPortMapping_Protocol asserts ports are only mapped for their
+// protocol, and for both tcp and udp if they have none.
+func TestCNI_getPortMapping_Protocol(t *testing.T) {
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 25000, To: 8080},
+		{Label: "dns", Value: 53, To: 53, Protocol: structs.PortProtocolUDP},
+		{Label: "diameter", Value: 3868, To: 3868, Protocol: structs.PortProtocolSCTP},
+	}
+
+	mappings := getPortMapping(alloc, true, nil)
+	require.ElementsMatch(t, []cni.PortMapping{
+		{HostPort: 25000, ContainerPort: 8080, Protocol: "tcp"},
+		{HostPort: 25000, ContainerPort: 8080, Protocol: "udp"},
+		{HostPort: 53, ContainerPort: 53, Protocol: "udp"},
+		{HostPort: 3868, ContainerPort: 3868, Protocol: "sctp"},
+	}, mappings)
+}
+
+// TestCNI_getPortMapping_DualStack asserts ports published on a dual-stack
+// host network are mapped on both its IPv4 and IPv6 addresses.
+func TestCNI_getPortMapping_DualStack(t *testing.T) {
+	node := mock.Node()
+	node.NodeResources.NodeNetworks = []*structs.NodeNetworkResource{
+		{
+			Mode:   "host",
+			Device: "eth0",
+			Addresses: []structs.NodeNetworkAddress{
+				{Alias: "default", Address: "192.168.0.100", Family: structs.NodeNetworkAF_IPv4},
+				{Alias: "default", Address: "2001:db8::100", Family: structs.NodeNetworkAF_IPv6},
+				{Alias: "default", Address: "2001:db8::101", Family: structs.NodeNetworkAF_IPv6},
+			},
+		},
+		{
+			Mode:   "host",
+			Device: "eth1",
+			Addresses: []structs.NodeNetworkAddress{
+				{Alias: "private", Address: "10.0.0.100", Family: structs.NodeNetworkAF_IPv4},
+			},
+		},
+	}
+
+	hostIPv6 := hostIPv6Addresses(node)
+	require.Equal(t, map[string]string{"192.168.0.100": "2001:db8::100"}, hostIPv6)
+
+	alloc := mock.Alloc()
+	alloc.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{Label: "http", Value: 25000, To: 8080, HostIP: "192.168.0.100", Protocol: structs.PortProtocolTCP},
+		{Label: "admin", Value: 25001, To: 9090, HostIP: "10.0.0.100", Protocol: structs.PortProtocolTCP},
+	}
+
+	mappings := getPortMapping(alloc, false, hostIPv6)
+	require.ElementsMatch(t, []cni.PortMapping{
+		{HostPort: 25000, ContainerPort: 8080, Protocol: "tcp", HostIP: "192.168.0.100"},
+		{HostPort: 25000, ContainerPort: 8080, Protocol: "tcp", HostIP: "2001:db8::100"},
+		{HostPort: 25001, ContainerPort: 9090, Protocol: "tcp", HostIP: "10.0.0.100"},
+	}, mappings)
+
+	// Without host IPs the portmap plugin forwards both families itself
+	mappings = getPortMapping(alloc, true, hostIPv6)
+	require.ElementsMatch(t, []cni.PortMapping{
+		{HostPort: 25000, ContainerPort: 8080, Protocol: "tcp"},
+		{HostPort: 25001, ContainerPort: 9090, Protocol: "tcp"},
+	}, mappings)
+}
@@ -56,6 +56,28 @@ func (h *DriverHandle) Signal(s string) error {
 	return h.driver.SignalTask(h.taskID, s)
 }
 
+// Checkpoint writes the state of the running task into dir and stops the
+// task. It returns an error if the driver does not support checkpointing.
+func (h *DriverHandle) Checkpoint(dir string) error {
+	d, ok := h.driver.(drivers.CheckpointDriver)
+	if !ok {
+		return fmt.Errorf("driver does not support checkpointing")
+	}
+
+	return d.CheckpointTask(h.taskID, dir)
+}
+
+// UpdateResources applies new resource limits to the running task. It
+// returns an error if the driver does not support resizing tasks.
+func (h *DriverHandle) UpdateResources(resources *drivers.Resources) error {
+	d, ok := h.driver.(drivers.ResizeDriver)
+	if !ok {
+		return fmt.Errorf("driver does not support resizing tasks")
+	}
+
+	return d.UpdateTaskResources(h.taskID, resources)
+}
+
 // Exec is the handled used by client endpoint handler to invoke the appropriate task driver exec.
 func (h *DriverHandle) Exec(timeout time.Duration, cmd string, args []string) ([]byte, int, error) {
 	command := append([]string{cmd}, args...)
@@ -0,0 +1,66 @@
+package taskrunner
+
+import (
+	"context"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// TaskResizer is the interface required by the resizeHook to update the
+// resources of a task. Satisfied by TaskRunner.
+type TaskResizer interface {
+	TaskResources() *structs.AllocatedTaskResources
+	Resize(context.Context, *structs.AllocatedTaskResources) error
+}
+
+var _ interfaces.TaskUpdateHook = (*resizeHook)(nil)
+
+// resizeHook applies CPU and memory changes made to an allocation by an
+// in-place update to the running task.
+type resizeHook struct {
+	resizer  TaskResizer
+	taskName string
+
+	logger hclog.Logger
+}
+
+func newResizeHook(resizer TaskResizer, taskName string, logger hclog.Logger) *resizeHook {
+	h := &resizeHook{
+		resizer:  resizer,
+		taskName: taskName,
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (*resizeHook) Name() string {
+	return "resize"
+}
+
+func (h *resizeHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, _ *interfaces.TaskUpdateResponse) error {
+	if req.Alloc == nil || req.Alloc.AllocatedResources == nil {
+		return nil
+	}
+
+	updated, ok := req.Alloc.AllocatedResources.Tasks[h.taskName]
+	if !ok || !resourcesResized(h.resizer.TaskResources(), updated) {
+		return nil
+	}
+
+	h.logger.Debug("task resources changed",
+		"cpu", updated.Cpu.CpuShares, "memory", updated.Memory.MemoryMB, "memory_max", updated.Memory.MemoryMaxMB)
+	return h.resizer.Resize(ctx, updated)
+}
+
+// resourcesResized returns whether the CPU or memory of the task changed.
+func resourcesResized(old, new *structs.AllocatedTaskResources) bool {
+	if old == nil || new == nil {
+		return false
+	}
+
+	return old.Cpu.CpuShares != new.Cpu.CpuShares ||
+		old.Memory.MemoryMB != new.Memory.MemoryMB ||
+		old.Memory.MemoryMaxMB != new.Memory.MemoryMaxMB
+}
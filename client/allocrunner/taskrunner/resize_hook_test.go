@@ -0,0 +1,56 @@
+package taskrunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTaskResizer struct {
+	resources *structs.AllocatedTaskResources
+	resized   []*structs.AllocatedTaskResources
+}
+
+func (m *mockTaskResizer) TaskResources() *structs.AllocatedTaskResources {
+	return m.resources
+}
+
+func (m *mockTaskResizer) Resize(_ context.Context, r *structs.AllocatedTaskResources) error {
+	m.resources = r
+	m.resized = append(m.resized, r)
+	return nil
+}
+
+func TestTaskRunner_ResizeHook(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	resizer := &mockTaskResizer{resources: alloc.AllocatedResources.Tasks[task.Name]}
+	h := newResizeHook(resizer, task.Name, testlog.HCLogger(t))
+
+	// An update that doesn't change the resources is a no-op
+	req := &interfaces.TaskUpdateRequest{Alloc: alloc}
+	require.NoError(t, h.Update(context.Background(), req, nil))
+	require.Empty(t, resizer.resized)
+
+	// Changing the memory resizes the task
+	updated := alloc.Copy()
+	updated.AllocatedResources.Tasks[task.Name].Memory.MemoryMB += 128
+	req = &interfaces.TaskUpdateRequest{Alloc: updated}
+	require.NoError(t, h.Update(context.Background(), req, nil))
+	require.Len(t, resizer.resized, 1)
+	require.Equal(t, updated.AllocatedResources.Tasks[task.Name], resizer.resized[0])
+
+	// Changing the CPU resizes the task again
+	updated = updated.Copy()
+	updated.AllocatedResources.Tasks[task.Name].Cpu.CpuShares += 100
+	req = &interfaces.TaskUpdateRequest{Alloc: updated}
+	require.NoError(t, h.Update(context.Background(), req, nil))
+	require.Len(t, resizer.resized, 2)
+}
@@ -74,7 +74,7 @@ func newServiceHook(c serviceHookConfig) *serviceHook {
 		h.networks = res.Networks
 	}
 
-	if c.alloc.DeploymentStatus != nil && c.alloc.DeploymentStatus.Canary {
+	if c.alloc.DeploymentStatus.UsesCanaryTags() {
 		h.canary = true
 	}
 
@@ -129,7 +129,7 @@ func (h *serviceHook) updateHookFields(req *interfaces.TaskUpdateRequest) error
 	// Store new updated values out of request
 	canary := false
 	if req.Alloc.DeploymentStatus != nil {
-		canary = req.Alloc.DeploymentStatus.Canary
+		canary = req.Alloc.DeploymentStatus.UsesCanaryTags()
 	}
 
 	var networks structs.Networks
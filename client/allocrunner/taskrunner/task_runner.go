@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -63,12 +64,16 @@ const (
 )
 
 type TaskRunner struct {
-	// allocID, taskName, taskLeader, and taskResources are immutable so these fields may
+	// allocID, taskName, and taskLeader are immutable so these fields may
 	// be accessed without locks
-	allocID       string
-	taskName      string
-	taskLeader    bool
-	taskResources *structs.AllocatedTaskResources
+	allocID    string
+	taskName   string
+	taskLeader bool
+
+	// taskResources are the resources allocated to the task. They may be
+	// updated in place when the allocation is resized.
+	taskResources     *structs.AllocatedTaskResources
+	taskResourcesLock sync.Mutex
 
 	alloc     *structs.Allocation
 	allocLock sync.Mutex
@@ -689,8 +694,15 @@ func (tr *TaskRunner) handleTaskExitResult(result *drivers.ExitResult) (retryWai
 	return false
 }
 
-// emitExitResultEvent emits a TaskTerminated event for an ExitResult.
+// emitExitResultEvent emits a TaskTerminated event for an ExitResult,
+// preceded by a TaskOOMKilled event if the task was killed by the OOM killer.
 func (tr *TaskRunner) emitExitResultEvent(result *drivers.ExitResult) {
+	if result.OOMKilled {
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskOOMKilled).
+			SetMemoryUsage(result.MemoryPeak, result.MemoryLimit))
+		metrics.IncrCounterWithLabels([]string{"client", "allocs", "oom_killed"}, 1, tr.baseLabels)
+	}
+
 	event := structs.NewTaskEvent(structs.TaskTerminated).
 		SetExitCode(result.ExitCode).
 		SetSignal(result.Signal).
@@ -698,10 +710,6 @@ func (tr *TaskRunner) emitExitResultEvent(result *drivers.ExitResult) {
 		SetExitMessage(result.Err)
 
 	tr.EmitEvent(event)
-
-	if result.OOMKilled {
-		metrics.IncrCounterWithLabels([]string{"client", "allocs", "oom_killed"}, 1, tr.baseLabels)
-	}
 }
 
 // handleUpdates runs update hooks when triggerUpdateCh is ticked and exits
@@ -808,7 +816,7 @@ func (tr *TaskRunner) runDriver() error {
 	}
 
 	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	handle, net, err := tr.startTask(taskConfig)
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -852,6 +860,73 @@ func (tr *TaskRunner) runDriver() error {
 	return nil
 }
 
+// startTask starts the task with the driver. If a checkpoint image was
+// migrated from the previous allocation and the driver supports it, the task
+// is restored from the checkpoint instead. Failed restores fall back to
+// starting the task from scratch.
+func (tr *TaskRunner) startTask(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	dir := tr.taskDir.CheckpointDir
+	if _, err := os.Stat(dir); err != nil {
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	// Checkpoints are only usable once
+	defer os.RemoveAll(dir)
+
+	d, ok := tr.driver.(drivers.CheckpointDriver)
+	if !ok || !tr.driverCapabilities.Checkpoint {
+		tr.logger.Debug("driver does not support checkpointing; starting task without checkpoint")
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	handle, net, err := d.RestoreTask(taskConfig, dir)
+	if err != nil {
+		tr.logger.Warn("failed to restore task from checkpoint; starting task without checkpoint", "error", err)
+		return tr.driver.StartTask(taskConfig)
+	}
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskRestoredFromCheckpoint))
+	return handle, net, nil
+}
+
+// shouldCheckpoint returns true if the task should be checkpointed before
+// being killed. Tasks are only checkpointed when their allocation is being
+// migrated and the group opted into checkpointing.
+func (tr *TaskRunner) shouldCheckpoint() bool {
+	if tr.driverCapabilities == nil || !tr.driverCapabilities.Checkpoint {
+		return false
+	}
+
+	alloc := tr.Alloc()
+	if !alloc.DesiredTransition.ShouldMigrate() {
+		return false
+	}
+
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil {
+		return false
+	}
+
+	return tg.EphemeralDisk.Migrate && tg.EphemeralDisk.Checkpoint
+}
+
+// checkpointTask writes a checkpoint of the running task into the task's
+// checkpoint directory. Failures are not fatal as the task will be killed
+// and started from scratch on the new node.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) {
+	dir := tr.taskDir.CheckpointDir
+	if err := handle.Checkpoint(dir); err != nil {
+		tr.logger.Warn("failed to checkpoint task", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointFailed).SetMessage(err.Error()))
+
+		// Don't migrate a partial image
+		os.RemoveAll(dir)
+		return
+	}
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointed))
+}
+
 // initDriver retrives the DriverPlugin from the plugin loader for this task
 func (tr *TaskRunner) initDriver() error {
 	driver, err := tr.driverManager.Dispense(tr.Task().Driver)
@@ -915,6 +990,12 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
+	// Checkpoint the task before killing it so it can be restored on the
+	// node the allocation is being migrated to.
+	if tr.shouldCheckpoint() {
+		tr.checkpointTask(handle)
+	}
+
 	// Kill the task using an exponential backoff in-case of failures.
 	result, killErr := tr.killTask(handle, resultCh)
 	if killErr != nil {
@@ -999,7 +1080,6 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	task := tr.Task()
 	alloc := tr.Alloc()
 	invocationid := uuid.Generate()[:8]
-	taskResources := tr.taskResources
 	ports := tr.Alloc().AllocatedResources.Shared.Ports
 	env := tr.envBuilder.Build()
 	tr.networkIsolationLock.Lock()
@@ -1017,35 +1097,16 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		}
 	}
 
-	memoryLimit := taskResources.Memory.MemoryMB
-	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
-		memoryLimit = max
-	}
-
-	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
-	for i, v := range taskResources.Cpu.ReservedCores {
-		cpusetCpus[i] = fmt.Sprintf("%d", v)
-	}
-
 	return &drivers.TaskConfig{
-		ID:            fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
-		Name:          task.Name,
-		JobName:       alloc.Job.Name,
-		JobID:         alloc.Job.ID,
-		TaskGroupName: alloc.TaskGroup,
-		Namespace:     alloc.Namespace,
-		NodeName:      alloc.NodeName,
-		NodeID:        alloc.NodeID,
-		Resources: &drivers.Resources{
-			NomadResources: taskResources,
-			LinuxResources: &drivers.LinuxResources{
-				MemoryLimitBytes: memoryLimit * 1024 * 1024,
-				CPUShares:        taskResources.Cpu.CpuShares,
-				CpusetCpus:       strings.Join(cpusetCpus, ","),
-				PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
-			},
-			Ports: &ports,
-		},
+		ID:               fmt.Sprintf("%s/%s/%s", alloc.ID, task.Name, invocationid),
+		Name:             task.Name,
+		JobName:          alloc.Job.Name,
+		JobID:            alloc.Job.ID,
+		TaskGroupName:    alloc.TaskGroup,
+		Namespace:        alloc.Namespace,
+		NodeName:         alloc.NodeName,
+		NodeID:           alloc.NodeID,
+		Resources:        tr.buildDriverResources(tr.TaskResources(), &ports),
 		Devices:          tr.hookResources.getDevices(),
 		Mounts:           tr.hookResources.getMounts(),
 		Env:              env.Map(),
@@ -1060,6 +1121,62 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 	}
 }
 
+// buildDriverResources converts the task's allocated resources into the
+// resources passed to the driver.
+func (tr *TaskRunner) buildDriverResources(taskResources *structs.AllocatedTaskResources, ports *structs.AllocatedPorts) *drivers.Resources {
+	memoryLimit := taskResources.Memory.MemoryMB
+	if max := taskResources.Memory.MemoryMaxMB; max > memoryLimit {
+		memoryLimit = max
+	}
+
+	cpusetCpus := make([]string, len(taskResources.Cpu.ReservedCores))
+	for i, v := range taskResources.Cpu.ReservedCores {
+		cpusetCpus[i] = fmt.Sprintf("%d", v)
+	}
+
+	return &drivers.Resources{
+		NomadResources: taskResources,
+		LinuxResources: &drivers.LinuxResources{
+			MemoryLimitBytes: memoryLimit * 1024 * 1024,
+			CPUShares:        taskResources.Cpu.CpuShares,
+			CpusetCpus:       strings.Join(cpusetCpus, ","),
+			PercentTicks:     float64(taskResources.Cpu.CpuShares) / float64(tr.clientConfig.Node.NodeResources.Cpu.CpuShares),
+		},
+		Ports: ports,
+	}
+}
+
+// Resize updates the resources of the task. If the task is running and its
+// driver supports resizing, the new limits are applied in place. Otherwise
+// the task is restarted so it picks up the new limits.
+func (tr *TaskRunner) Resize(ctx context.Context, taskResources *structs.AllocatedTaskResources) error {
+	tr.setTaskResources(taskResources)
+
+	handle := tr.getDriverHandle()
+	if handle == nil {
+		// The task will use the new resources when it is started
+		return nil
+	}
+
+	if tr.driverCapabilities != nil && tr.driverCapabilities.Resize {
+		ports := tr.Alloc().AllocatedResources.Shared.Ports
+		err := handle.UpdateResources(tr.buildDriverResources(taskResources, &ports))
+		if err == nil {
+			tr.EmitEvent(structs.NewTaskEvent(structs.TaskResized))
+			return nil
+		}
+		tr.logger.Warn("failed to resize task, restarting it instead", "error", err)
+	}
+
+	event := structs.NewTaskEvent(structs.TaskRestartSignal).
+		SetRestartReason("Restarting task to apply updated resources")
+	err := tr.Restart(ctx, event, false)
+	if err == ErrTaskNotRunning {
+		return nil
+	}
+	return err
+}
+
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
 // but before Run so no locks need to be acquired.
 func (tr *TaskRunner) Restore() error {
@@ -1351,7 +1468,7 @@ func (tr *TaskRunner) LatestResourceUsage() *cstructs.TaskResourceUsage {
 
 	// Look up device statistics lazily when fetched, as currently we do not emit any stats for them yet
 	if ru != nil && tr.deviceStatsReporter != nil {
-		deviceResources := tr.taskResources.Devices
+		deviceResources := tr.TaskResources().Devices
 		ru.ResourceUsage.DeviceStats = tr.deviceStatsReporter.LatestDeviceResourceStats(deviceResources)
 	}
 	return ru
@@ -23,6 +23,20 @@ func (tr *TaskRunner) setAlloc(updated *structs.Allocation, task *structs.Task)
 	tr.task = task
 }
 
+// TaskResources returns the resources currently allocated to the task.
+func (tr *TaskRunner) TaskResources() *structs.AllocatedTaskResources {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+	return tr.taskResources
+}
+
+// setTaskResources updates the resources allocated to the task.
+func (tr *TaskRunner) setTaskResources(taskResources *structs.AllocatedTaskResources) {
+	tr.taskResourcesLock.Lock()
+	defer tr.taskResourcesLock.Unlock()
+	tr.taskResources = taskResources
+}
+
 // IsLeader returns true if this task is the leader of its task group.
 func (tr *TaskRunner) IsLeader() bool {
 	return tr.taskLeader
@@ -68,6 +68,7 @@ func (tr *TaskRunner) initHooks() {
 		newArtifactHook(tr, hookLogger),
 		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
 		newDeviceHook(tr.devicemanager, hookLogger),
+		newResizeHook(tr, tr.taskName, hookLogger),
 	}
 
 	// If the task has a CSI stanza, add the hook.
@@ -203,7 +204,7 @@ func (tr *TaskRunner) prestart() error {
 			Task:          tr.Task(),
 			TaskDir:       tr.taskDir,
 			TaskEnv:       tr.envBuilder.Build(),
-			TaskResources: tr.taskResources,
+			TaskResources: tr.TaskResources(),
 		}
 
 		origHookState := tr.hookState(name)
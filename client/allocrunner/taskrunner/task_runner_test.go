@@ -26,6 +26,7 @@ import (
 	agentconsul "github.com/hashicorp/nomad/command/agent/consul"
 	mockdriver "github.com/hashicorp/nomad/drivers/mock"
 	"github.com/hashicorp/nomad/drivers/rawexec"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -1065,6 +1066,110 @@ func TestTaskRunner_SignalFailure(t *testing.T) {
 	require.EqualError(t, tr.Signal(&structs.TaskEvent{}, "SIGINT"), errMsg)
 }
 
+// TestTaskRunner_Checkpoint asserts that tasks of migrating allocations are
+// checkpointed before being killed and that a replacement task is restored
+// from the migrated checkpoint.
+// TestTaskRunner_OOMKilled asserts an OOM Killed event is emitted for tasks
+// killed by the OOM killer.
+func TestTaskRunner_OOMKilled(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for":         "1ms",
+		"exit_code":       137,
+		"exit_signal":     9,
+		"exit_oom_killed": true,
+	}
+	alloc.Job.TaskGroups[0].RestartPolicy.Attempts = 0
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	select {
+	case <-tr.WaitCh():
+	case <-time.After(time.Duration(testutil.TestMultiplier()*15) * time.Second):
+		require.Fail(t, "timeout waiting for task to exit")
+	}
+
+	var types []string
+	for _, e := range tr.TaskState().Events {
+		types = append(types, e.Type)
+		if e.Type == structs.TaskTerminated {
+			require.Equal(t, "true", e.Details["oom_killed"])
+		}
+	}
+	require.Subset(t, types, []string{structs.TaskOOMKilled, structs.TaskTerminated})
+}
+
+func TestTaskRunner_Checkpoint(t *testing.T) {
+	t.Parallel()
+
+	alloc := mock.Alloc()
+	alloc.Job.TaskGroups[0].EphemeralDisk.Migrate = true
+	alloc.Job.TaskGroups[0].EphemeralDisk.Checkpoint = true
+	alloc.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "10m",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+
+	testWaitForTaskToStart(t, tr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, tr.Kill(ctx, structs.NewTaskEvent("migrate")))
+
+	found := false
+	for _, e := range tr.TaskState().Events {
+		if e.Type == structs.TaskCheckpointed {
+			found = true
+		}
+	}
+	require.True(t, found, "checkpointed event not found: %v", tr.TaskState().Events)
+
+	files, err := ioutil.ReadDir(tr.taskDir.CheckpointDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// Start a replacement task with the checkpoint as if it was migrated
+	alloc2 := alloc.Copy()
+	alloc2.ID = uuid.Generate()
+	alloc2.DesiredTransition.Migrate = nil
+	conf2, cleanup2 := testTaskRunnerConfig(t, alloc2, task.Name)
+	defer cleanup2()
+
+	require.NoError(t, os.MkdirAll(conf2.TaskDir.CheckpointDir, 0700))
+	image := filepath.Join(tr.taskDir.CheckpointDir, files[0].Name())
+	data, err := ioutil.ReadFile(image)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(conf2.TaskDir.CheckpointDir, files[0].Name()), data, 0600))
+
+	tr2, err := NewTaskRunner(conf2)
+	require.NoError(t, err)
+	go tr2.Run()
+	defer tr2.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+
+	testWaitForTaskToStart(t, tr2)
+
+	found = false
+	for _, e := range tr2.TaskState().Events {
+		if e.Type == structs.TaskRestoredFromCheckpoint {
+			found = true
+		}
+	}
+	require.True(t, found, "restored event not found: %v", tr2.TaskState().Events)
+
+	// Checkpoints are removed once used
+	require.NoDirExists(t, conf2.TaskDir.CheckpointDir)
+}
+
 // TestTaskRunner_RestartTask asserts that restarting a task works and emits a
 // Restarting event.
 func TestTaskRunner_RestartTask(t *testing.T) {
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -34,6 +35,7 @@ import (
 	"github.com/hashicorp/nomad/client/devicemanager"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	"github.com/hashicorp/nomad/client/fingerprint"
+	hvm "github.com/hashicorp/nomad/client/hostvolumemanager"
 	"github.com/hashicorp/nomad/client/lib/cgutil"
 	"github.com/hashicorp/nomad/client/pluginmanager"
 	"github.com/hashicorp/nomad/client/pluginmanager/csimanager"
@@ -277,6 +279,9 @@ type Client struct {
 	// csimanager is responsible for managing csi plugins.
 	csimanager csimanager.Manager
 
+	// hostVolumeManager is responsible for managing dynamic host volumes.
+	hostVolumeManager *hvm.HostVolumeManager
+
 	// devicemanger is responsible for managing device plugins.
 	devicemanager devicemanager.Manager
 
@@ -443,6 +448,22 @@ func NewClient(cfg *config.Config, consulCatalog consul.CatalogAPI, consulProxie
 	c.csimanager = csiManager
 	c.pluginManagers.RegisterAndRun(csiManager.PluginManager())
 
+	// Setup the host volume manager and restore the dynamic host volumes
+	// created before the client restarted
+	c.hostVolumeManager = hvm.NewHostVolumeManager(c.logger, &hvm.Config{
+		PluginDir:      c.configCopy.HostVolumePluginDir,
+		SharedMountDir: c.configCopy.HostVolumesDir,
+		StateMgr:       c.stateDB,
+		StaticVolumes:  c.configCopy.HostVolumes,
+		UpdateNodeVols: c.updateNodeFromHostVolume,
+	})
+	c.updateNodeFromFingerprint(&fingerprint.FingerprintResponse{
+		Attributes: c.hostVolumeManager.PluginAttributes(context.Background()),
+	})
+	if err := c.hostVolumeManager.Restore(context.Background()); err != nil {
+		c.logger.Error("failed to restore dynamic host volumes", "error", err)
+	}
+
 	// Setup the driver manager
 	driverConfig := &drivermanager.Config{
 		Logger:              c.logger,
@@ -643,6 +664,15 @@ func (c *Client) init() error {
 
 	c.logger.Info("using alloc directory", "alloc_dir", c.config.AllocDir)
 
+	// Dynamic host volumes created by the builtin plugin are kept in the
+	// state directory by default, so they survive the client restarting
+	if c.config.HostVolumesDir == "" {
+		c.config.HostVolumesDir = filepath.Join(c.config.StateDir, "host_volumes")
+	}
+	if err := os.MkdirAll(c.config.HostVolumesDir, 0711); err != nil {
+		return fmt.Errorf("failed creating host volumes dir: %s", err)
+	}
+
 	// Ensure cgroups are created on linux platform
 	if runtime.GOOS == "linux" && c.cpusetManager != nil {
 		err := c.cpusetManager.Init()
@@ -45,6 +45,7 @@ var (
 	DefaultUserCheckedDrivers = strings.Join([]string{
 		"exec",
 		"qemu",
+		"firecracker",
 		"java",
 	}, ",")
 
@@ -252,9 +253,23 @@ type Config struct {
 	// notation
 	BridgeNetworkAllocSubnet string
 
+	// BridgeNetworkAllocSubnetIPv6 is the IPv6 subnet to use for address
+	// allocation in addition to BridgeNetworkAllocSubnet, giving allocations
+	// in bridge networking mode dual-stack networking. Subnet must be in CIDR
+	// notation
+	BridgeNetworkAllocSubnetIPv6 string
+
 	// HostVolumes is a map of the configured host volumes by name.
 	HostVolumes map[string]*structs.ClientHostVolumeConfig
 
+	// HostVolumesDir is the directory where the builtin "mkdir" plugin
+	// creates dynamic host volumes.
+	HostVolumesDir string
+
+	// HostVolumePluginDir is the directory of the executable plugins that
+	// create and delete dynamic host volumes.
+	HostVolumePluginDir string
+
 	// HostNetworks is a map of the conigured host networks by name.
 	HostNetworks map[string]*structs.ClientHostNetworkConfig
 
@@ -268,12 +283,57 @@ type Config struct {
 	// This configuration is only considered if no host networks are defined.
 	BindWildcardDefaultHostNetwork bool
 
+	// EnforceNetworkBandwidth limits the bandwidth of allocations in bridge
+	// and CNI network modes to the MBits of their network resource.
+	EnforceNetworkBandwidth bool
+
 	// CgroupParent is the parent cgroup Nomad should use when managing any cgroup subsystems.
 	// Currently this only includes the 'cpuset' cgroup subsystem.
 	CgroupParent string
 
 	// ReservableCores if set overrides the set of reservable cores reported in fingerprinting.
 	ReservableCores []uint16
+
+	// DiskQuota enforces the ephemeral disk size of allocations. It is nil
+	// when enforcement is disabled.
+	DiskQuota *DiskQuotaConfig
+}
+
+const (
+	// DiskQuotaPolicyEvent emits a task event when an allocation fills its
+	// ephemeral disk.
+	DiskQuotaPolicyEvent = "event"
+
+	// DiskQuotaPolicyKill kills the tasks of an allocation that fills its
+	// ephemeral disk.
+	DiskQuotaPolicyKill = "kill"
+
+	// DefaultDiskQuotaCheckInterval is how often the disk usage of
+	// allocations is checked by default.
+	DefaultDiskQuotaCheckInterval = 10 * time.Second
+)
+
+// DiskQuotaConfig configures how ephemeral disk sizes are enforced.
+type DiskQuotaConfig struct {
+	// Mode is the diskquota mode used to limit allocation directories.
+	Mode string
+
+	// Policy is the action taken when an allocation fills its ephemeral
+	// disk.
+	Policy string
+
+	// CheckInterval is how often the disk usage of allocations is checked.
+	CheckInterval time.Duration
+}
+
+func (c *DiskQuotaConfig) Copy() *DiskQuotaConfig {
+	if c == nil {
+		return nil
+	}
+
+	nc := new(DiskQuotaConfig)
+	*nc = *c
+	return nc
 }
 
 type ClientTemplateConfig struct {
@@ -302,6 +362,7 @@ func (c *Config) Copy() *Config {
 	nc.ConsulConfig = c.ConsulConfig.Copy()
 	nc.VaultConfig = c.VaultConfig.Copy()
 	nc.TemplateConfig = c.TemplateConfig.Copy()
+	nc.DiskQuota = c.DiskQuota.Copy()
 	if c.ReservableCores != nil {
 		nc.ReservableCores = make([]uint16, len(c.ReservableCores))
 		copy(nc.ReservableCores, c.ReservableCores)
@@ -523,6 +523,91 @@ func TestCSIController_DeleteVolume(t *testing.T) {
 	}
 }
 
+func TestCSIController_ExpandVolume(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name             string
+		ClientSetupFunc  func(*fake.Client)
+		Request          *structs.ClientCSIControllerExpandVolumeRequest
+		ExpectedErr      error
+		ExpectedResponse *structs.ClientCSIControllerExpandVolumeResponse
+	}{
+		{
+			Name: "returns plugin not found errors",
+			Request: &structs.ClientCSIControllerExpandVolumeRequest{
+				CSIControllerQuery: structs.CSIControllerQuery{
+					PluginID: "some-garbage",
+				},
+			},
+			ExpectedErr: errors.New("CSI.ControllerExpandVolume: CSI client error (retryable): plugin some-garbage for type csi-controller not found"),
+		},
+		{
+			Name: "returns transitive errors",
+			ClientSetupFunc: func(fc *fake.Client) {
+				fc.NextControllerExpandVolumeErr = errors.New("internal plugin error")
+			},
+			Request: &structs.ClientCSIControllerExpandVolumeRequest{
+				CSIControllerQuery: structs.CSIControllerQuery{
+					PluginID: fakePlugin.Name,
+				},
+				ExternalVolumeID: "1234-4321-1234-4321",
+				CapacityMin:      2000,
+			},
+			ExpectedErr: errors.New("CSI.ControllerExpandVolume: internal plugin error"),
+		},
+		{
+			Name: "handles success",
+			ClientSetupFunc: func(fc *fake.Client) {
+				fc.NextControllerExpandVolumeResponse = &csi.ControllerExpandVolumeResponse{
+					CapacityBytes:         2000,
+					NodeExpansionRequired: true,
+				}
+			},
+			Request: &structs.ClientCSIControllerExpandVolumeRequest{
+				CSIControllerQuery: structs.CSIControllerQuery{
+					PluginID: fakePlugin.Name,
+				},
+				ExternalVolumeID: "1234-4321-1234-4321",
+				CapacityMin:      2000,
+			},
+			ExpectedResponse: &structs.ClientCSIControllerExpandVolumeResponse{
+				CapacityBytes:         2000,
+				NodeExpansionRequired: true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require := require.New(t)
+			client, cleanup := TestClient(t, nil)
+			defer cleanup()
+
+			fakeClient := &fake.Client{}
+			if tc.ClientSetupFunc != nil {
+				tc.ClientSetupFunc(fakeClient)
+			}
+
+			dispenserFunc := func(*dynamicplugins.PluginInfo) (interface{}, error) {
+				return fakeClient, nil
+			}
+			client.dynamicRegistry.StubDispenserForType(
+				dynamicplugins.PluginTypeCSIController, dispenserFunc)
+
+			err := client.dynamicRegistry.RegisterPlugin(fakePlugin)
+			require.Nil(err)
+
+			var resp structs.ClientCSIControllerExpandVolumeResponse
+			err = client.ClientRPC("CSI.ControllerExpandVolume", tc.Request, &resp)
+			require.Equal(tc.ExpectedErr, err)
+			if tc.ExpectedResponse != nil {
+				require.Equal(tc.ExpectedResponse, &resp)
+			}
+		})
+	}
+}
+
 func TestCSIController_ListVolumes(t *testing.T) {
 	t.Parallel()
 
@@ -972,3 +1057,67 @@ func TestCSINode_DetachVolume(t *testing.T) {
 		})
 	}
 }
+
+func TestCSINode_ExpandVolume(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		Name        string
+		Request     *structs.ClientCSINodeExpandVolumeRequest
+		ExpectedErr error
+	}{
+		{
+			Name: "validates pluginid is not empty",
+			Request: &structs.ClientCSINodeExpandVolumeRequest{
+				VolumeID: "1234-4321-1234-4321",
+			},
+			ExpectedErr: errors.New("CSI.NodeExpandVolume: PluginID is required"),
+		},
+		{
+			Name: "validates volumeid is not empty",
+			Request: &structs.ClientCSINodeExpandVolumeRequest{
+				PluginID: fakeNodePlugin.Name,
+			},
+			ExpectedErr: errors.New("CSI.NodeExpandVolume: VolumeID is required"),
+		},
+		{
+			Name: "validates allocid is not empty",
+			Request: &structs.ClientCSINodeExpandVolumeRequest{
+				PluginID: fakeNodePlugin.Name,
+				VolumeID: "1234-4321-1234-4321",
+			},
+			ExpectedErr: errors.New("CSI.NodeExpandVolume: AllocID is required"),
+		},
+		{
+			Name: "returns plugin not found errors",
+			Request: &structs.ClientCSINodeExpandVolumeRequest{
+				PluginID:    fakeNodePlugin.Name,
+				VolumeID:    "1234-4321-1234-4321",
+				AllocID:     "4321-1234-4321-1234",
+				CapacityMin: 2000,
+			},
+			// we don't have a csimanager in this context
+			ExpectedErr: errors.New("CSI.NodeExpandVolume: plugin test-plugin for type csi-node not found"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			require := require.New(t)
+			client, cleanup := TestClient(t, nil)
+			defer cleanup()
+
+			fakeClient := &fake.Client{}
+			dispenserFunc := func(*dynamicplugins.PluginInfo) (interface{}, error) {
+				return fakeClient, nil
+			}
+			client.dynamicRegistry.StubDispenserForType(dynamicplugins.PluginTypeCSINode, dispenserFunc)
+			err := client.dynamicRegistry.RegisterPlugin(fakeNodePlugin)
+			require.Nil(err)
+
+			var resp structs.ClientCSINodeExpandVolumeResponse
+			err = client.ClientRPC("CSI.NodeExpandVolume", tc.Request, &resp)
+			require.Equal(tc.ExpectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// HostVolume endpoint is used for creating and deleting dynamic host volumes
+// on a client.
+type HostVolume struct {
+	c *Client
+}
+
+const (
+	// HostVolumePluginRequestTimeout is the timeout of a host volume plugin
+	// operation.
+	HostVolumePluginRequestTimeout = 2 * time.Minute
+)
+
+// Create creates a dynamic host volume with its plugin and fingerprints it
+// into the node.
+func (v *HostVolume) Create(req *cstructs.ClientHostVolumeCreateRequest, resp *cstructs.ClientHostVolumeCreateResponse) error {
+	defer metrics.MeasureSince([]string{"client", "host_volume", "create"}, time.Now())
+
+	if req.ID == "" {
+		return errors.New("HostVolume.Create: ID is required")
+	}
+	if req.Name == "" {
+		return errors.New("HostVolume.Create: Name is required")
+	}
+
+	// a dynamic volume can't shadow a static volume from the client config
+	if vol, ok := v.c.Node().HostVolumes[req.Name]; ok && vol.ID != req.ID {
+		return fmt.Errorf("HostVolume.Create: host volume %q already exists on node", req.Name)
+	}
+
+	ctx, cancelFn := v.requestContext()
+	defer cancelFn()
+
+	cresp, err := v.c.hostVolumeManager.Create(ctx, req)
+	if err != nil {
+		v.c.logger.Error("failed to create host volume", "name", req.Name, "error", err)
+		return fmt.Errorf("HostVolume.Create: %v", err)
+	}
+
+	*resp = *cresp
+	v.c.logger.Info("created host volume", "id", req.ID, "path", resp.HostPath)
+	return nil
+}
+
+// Delete deletes a dynamic host volume with its plugin and removes it from
+// the node.
+func (v *HostVolume) Delete(req *cstructs.ClientHostVolumeDeleteRequest, resp *cstructs.ClientHostVolumeDeleteResponse) error {
+	defer metrics.MeasureSince([]string{"client", "host_volume", "delete"}, time.Now())
+
+	if req.ID == "" {
+		return errors.New("HostVolume.Delete: ID is required")
+	}
+
+	ctx, cancelFn := v.requestContext()
+	defer cancelFn()
+
+	_, err := v.c.hostVolumeManager.Delete(ctx, req)
+	if err != nil {
+		v.c.logger.Error("failed to delete host volume", "id", req.ID, "error", err)
+		return fmt.Errorf("HostVolume.Delete: %v", err)
+	}
+
+	v.c.logger.Info("deleted host volume", "id", req.ID, "path", req.HostPath)
+	return nil
+}
+
+func (v *HostVolume) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), HostVolumePluginRequestTimeout)
+}
@@ -0,0 +1,214 @@
+package hostvolumemanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// HostVolumePlugin creates and deletes dynamic host volumes on the client.
+type HostVolumePlugin interface {
+	Fingerprint(ctx context.Context) (*PluginFingerprint, error)
+	Create(ctx context.Context, req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error)
+	Delete(ctx context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error
+}
+
+// PluginFingerprint is the output of a plugin's fingerprint operation.
+type PluginFingerprint struct {
+	Version string `json:"version"`
+}
+
+// HostVolumePluginCreateResponse is the output of a plugin's create
+// operation.
+type HostVolumePluginCreateResponse struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"bytes"`
+}
+
+var _ HostVolumePlugin = &HostVolumePluginMkdir{}
+
+// HostVolumePluginMkdir is the builtin plugin, which creates each volume as a
+// directory named after the volume ID in the TargetPath. It can't enforce the
+// capacity of the volume.
+type HostVolumePluginMkdir struct {
+	ID         string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+func (p *HostVolumePluginMkdir) Fingerprint(_ context.Context) (*PluginFingerprint, error) {
+	return &PluginFingerprint{Version: "1.0.0"}, nil
+}
+
+func (p *HostVolumePluginMkdir) Create(_ context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With("operation", "create", "volume_id", req.ID, "path", path)
+	log.Debug("running plugin")
+
+	// creating the directory is idempotent, so the volume can be created
+	// again when the client restores it after a restart
+	if err := os.MkdirAll(path, 0700); err != nil {
+		log.Debug("error with plugin", "error", err)
+		return nil, err
+	}
+
+	log.Debug("plugin ran successfully")
+	return &HostVolumePluginCreateResponse{Path: path}, nil
+}
+
+func (p *HostVolumePluginMkdir) Delete(_ context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	path := filepath.Join(p.TargetPath, req.ID)
+	log := p.log.With("operation", "delete", "volume_id", req.ID, "path", path)
+	log.Debug("running plugin")
+
+	if err := os.RemoveAll(path); err != nil {
+		log.Debug("error with plugin", "error", err)
+		return err
+	}
+
+	log.Debug("plugin ran successfully")
+	return nil
+}
+
+var _ HostVolumePlugin = &HostVolumePluginExternal{}
+
+// NewHostVolumePluginExternal returns the plugin for the executable with the
+// name of the plugin ID in the plugin directory.
+func NewHostVolumePluginExternal(log hclog.Logger,
+	id, executable, targetPath string) (*HostVolumePluginExternal, error) {
+
+	// this should only be called with already-detected executables,
+	// but we'll double-check it
+	f, err := os.Stat(executable)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+		}
+		return nil, err
+	}
+	if !f.Mode().IsRegular() || f.Mode().Perm()&0111 == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExecutable, id)
+	}
+	return &HostVolumePluginExternal{
+		ID:         id,
+		Executable: executable,
+		TargetPath: targetPath,
+		log:        log,
+	}, nil
+}
+
+// HostVolumePluginExternal is a plugin executable provided by the operator.
+// The executable is called with the operation ("fingerprint", "create" or
+// "delete") as its only argument, and the volume in DHV_* environment
+// variables. The fingerprint and create operations write their response as
+// JSON to stdout.
+type HostVolumePluginExternal struct {
+	ID         string
+	Executable string
+	TargetPath string
+
+	log hclog.Logger
+}
+
+func (p *HostVolumePluginExternal) Fingerprint(ctx context.Context) (*PluginFingerprint, error) {
+	stdout, err := p.runPlugin(ctx, "fingerprint", nil)
+	if err != nil {
+		return nil, err
+	}
+	fprint := &PluginFingerprint{}
+	if err := json.Unmarshal(stdout, fprint); err != nil {
+		return nil, fmt.Errorf("error parsing fingerprint output as json: %w", err)
+	}
+	if fprint.Version == "" {
+		return nil, errors.New("plugin did not report a version")
+	}
+	return fprint, nil
+}
+
+func (p *HostVolumePluginExternal) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*HostVolumePluginCreateResponse, error) {
+
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+	env := []string{
+		"DHV_VOLUME_ID=" + req.ID,
+		"DHV_VOLUME_NAME=" + req.Name,
+		"DHV_NODE_ID=" + req.NodeID,
+		"DHV_HOST_PATH=" + filepath.Join(p.TargetPath, req.ID),
+		"DHV_CAPACITY_MIN_BYTES=" + strconv.FormatInt(req.RequestedCapacityMinBytes, 10),
+		"DHV_CAPACITY_MAX_BYTES=" + strconv.FormatInt(req.RequestedCapacityMaxBytes, 10),
+		"DHV_PARAMETERS=" + string(params),
+	}
+
+	stdout, err := p.runPlugin(ctx, "create", env)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &HostVolumePluginCreateResponse{}
+	if err := json.Unmarshal(stdout, resp); err != nil {
+		return nil, fmt.Errorf("error parsing create output as json: %w", err)
+	}
+	if resp.Path == "" {
+		return nil, errors.New("plugin did not report a path")
+	}
+	return resp, nil
+}
+
+func (p *HostVolumePluginExternal) Delete(ctx context.Context, req *cstructs.ClientHostVolumeDeleteRequest) error {
+	params, err := json.Marshal(req.Parameters)
+	if err != nil {
+		return fmt.Errorf("error marshaling volume parameters: %w", err)
+	}
+	env := []string{
+		"DHV_VOLUME_ID=" + req.ID,
+		"DHV_VOLUME_NAME=" + req.Name,
+		"DHV_NODE_ID=" + req.NodeID,
+		"DHV_HOST_PATH=" + req.HostPath,
+		"DHV_PARAMETERS=" + string(params),
+	}
+
+	_, err = p.runPlugin(ctx, "delete", env)
+	return err
+}
+
+// runPlugin runs the plugin executable for the operation and returns its
+// stdout. Stderr is included in the error if the plugin fails.
+func (p *HostVolumePluginExternal) runPlugin(ctx context.Context, op string, env []string) ([]byte, error) {
+	log := p.log.With("operation", op)
+	log.Debug("running plugin")
+
+	cmd := exec.CommandContext(ctx, p.Executable, op)
+	cmd.Env = append([]string{
+		"DHV_OPERATION=" + op,
+		"DHV_PLUGIN_DIR=" + filepath.Dir(p.Executable),
+		"PATH=" + os.Getenv("PATH"),
+	}, env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		log.Debug("error with plugin", "error", err, "stderr", stderr.String())
+		return nil, fmt.Errorf("error running plugin %q: %w: %s",
+			p.ID, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	log.Debug("plugin ran successfully")
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,315 @@
+package hostvolumemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+var (
+	ErrPluginNotExists     = errors.New("no such plugin")
+	ErrPluginNotExecutable = errors.New("plugin not executable")
+	ErrStaticVolumeExists  = errors.New("host volume is configured on the node")
+)
+
+// HostVolumeStateManager persists the dynamic host volumes created on the
+// client, so they can be restored after a restart.
+type HostVolumeStateManager interface {
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+	DeleteDynamicHostVolume(string) error
+}
+
+// UpdateVolumeMap is called to fingerprint a dynamic host volume into the
+// node, or to remove it from the node if the volume is nil.
+type UpdateVolumeMap func(name string, volume *structs.ClientHostVolumeConfig)
+
+// Config is the configuration of the HostVolumeManager.
+type Config struct {
+	// PluginDir is the directory of the external host volume plugins.
+	PluginDir string
+
+	// SharedMountDir is the directory where volumes are created by the
+	// builtin plugin, and the default path external plugins are passed.
+	SharedMountDir string
+
+	// StateMgr persists the volumes.
+	StateMgr HostVolumeStateManager
+
+	// StaticVolumes are the host volumes in the client configuration, which
+	// dynamic host volumes can't shadow.
+	StaticVolumes map[string]*structs.ClientHostVolumeConfig
+
+	// UpdateNodeVols fingerprints volumes into the node.
+	UpdateNodeVols UpdateVolumeMap
+}
+
+// HostVolumeManager creates and deletes the dynamic host volumes on a client
+// with host volume plugins.
+type HostVolumeManager struct {
+	pluginDir      string
+	sharedMountDir string
+	stateMgr       HostVolumeStateManager
+	staticVolumes  map[string]*structs.ClientHostVolumeConfig
+	updateNodeVols UpdateVolumeMap
+
+	// volumesLock serializes operations on the same volume name, so that two
+	// volumes can't be created with the same name
+	volumesLock sync.Mutex
+	volumes     map[string]string // name -> ID
+
+	log hclog.Logger
+}
+
+// NewHostVolumeManager returns a HostVolumeManager. Restore must be called to
+// fingerprint the volumes that were created before the client restarted.
+func NewHostVolumeManager(logger hclog.Logger, config *Config) *HostVolumeManager {
+	return &HostVolumeManager{
+		pluginDir:      config.PluginDir,
+		sharedMountDir: config.SharedMountDir,
+		stateMgr:       config.StateMgr,
+		staticVolumes:  config.StaticVolumes,
+		updateNodeVols: config.UpdateNodeVols,
+		volumes:        map[string]string{},
+		log:            logger.Named("host_volume_manager"),
+	}
+}
+
+// Create creates a volume with its plugin, sets the ownership of its
+// directory, persists it and fingerprints it into the node.
+func (hvm *HostVolumeManager) Create(ctx context.Context,
+	req *cstructs.ClientHostVolumeCreateRequest) (*cstructs.ClientHostVolumeCreateResponse, error) {
+
+	hvm.volumesLock.Lock()
+	defer hvm.volumesLock.Unlock()
+
+	if _, ok := hvm.staticVolumes[req.Name]; ok {
+		return nil, fmt.Errorf("%w: %q", ErrStaticVolumeExists, req.Name)
+	}
+	if id, ok := hvm.volumes[req.Name]; ok && id != req.ID {
+		return nil, fmt.Errorf("host volume %q already exists on node", req.Name)
+	}
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	pluginResp, err := plug.Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setOwnership(pluginResp.Path, req); err != nil {
+		hvm.cleanup(ctx, plug, req, pluginResp.Path)
+		return nil, err
+	}
+
+	volState := &cstructs.HostVolumeState{
+		ID:        req.ID,
+		CreateReq: req,
+		HostPath:  pluginResp.Path,
+	}
+	if err := hvm.stateMgr.PutDynamicHostVolume(volState); err != nil {
+		// if we can't persist the volume, we won't be able to restore it
+		// after a restart, so delete it rather than leaking it
+		hvm.log.Error("failed to save volume in state", "volume_id", req.ID, "error", err)
+		hvm.cleanup(ctx, plug, req, pluginResp.Path)
+		return nil, err
+	}
+
+	hvm.volumes[req.Name] = req.ID
+	hvm.updateNodeVols(req.Name, &structs.ClientHostVolumeConfig{
+		Name: req.Name,
+		Path: pluginResp.Path,
+		ID:   req.ID,
+	})
+
+	return &cstructs.ClientHostVolumeCreateResponse{
+		HostPath:      pluginResp.Path,
+		CapacityBytes: pluginResp.SizeBytes,
+	}, nil
+}
+
+// cleanup deletes a volume that was created by the plugin but couldn't be
+// finished.
+func (hvm *HostVolumeManager) cleanup(ctx context.Context, plug HostVolumePlugin,
+	req *cstructs.ClientHostVolumeCreateRequest, path string) {
+
+	delReq := &cstructs.ClientHostVolumeDeleteRequest{
+		ID:         req.ID,
+		Name:       req.Name,
+		PluginID:   req.PluginID,
+		NodeID:     req.NodeID,
+		HostPath:   path,
+		Parameters: req.Parameters,
+	}
+	if err := plug.Delete(ctx, delReq); err != nil {
+		hvm.log.Warn("failed to clean up volume", "volume_id", req.ID, "error", err)
+	}
+}
+
+// Delete deletes a volume with its plugin and removes it from the node.
+func (hvm *HostVolumeManager) Delete(ctx context.Context,
+	req *cstructs.ClientHostVolumeDeleteRequest) (*cstructs.ClientHostVolumeDeleteResponse, error) {
+
+	hvm.volumesLock.Lock()
+	defer hvm.volumesLock.Unlock()
+
+	plug, err := hvm.getPlugin(req.PluginID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := plug.Delete(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := hvm.stateMgr.DeleteDynamicHostVolume(req.ID); err != nil {
+		hvm.log.Error("failed to delete volume in state", "volume_id", req.ID, "error", err)
+		return nil, err
+	}
+
+	if hvm.volumes[req.Name] == req.ID {
+		delete(hvm.volumes, req.Name)
+		hvm.updateNodeVols(req.Name, nil)
+	}
+
+	return &cstructs.ClientHostVolumeDeleteResponse{}, nil
+}
+
+// Restore creates the volumes in the client state again, which plugins must
+// do idempotently, and fingerprints them into the node.
+func (hvm *HostVolumeManager) Restore(ctx context.Context) error {
+	vols, err := hvm.stateMgr.GetDynamicHostVolumes()
+	if err != nil {
+		return err
+	}
+
+	hvm.volumesLock.Lock()
+	defer hvm.volumesLock.Unlock()
+
+	var mErr multierror.Error
+	for _, vol := range vols {
+		// a host volume with the same name may have been added to the
+		// client configuration since the volume was created
+		if _, ok := hvm.staticVolumes[vol.CreateReq.Name]; ok {
+			multierror.Append(&mErr, fmt.Errorf("failed to restore volume %s: %w: %q",
+				vol.ID, ErrStaticVolumeExists, vol.CreateReq.Name))
+			continue
+		}
+
+		plug, err := hvm.getPlugin(vol.CreateReq.PluginID)
+		if err != nil {
+			multierror.Append(&mErr, fmt.Errorf("failed to restore volume %s: %w", vol.ID, err))
+			continue
+		}
+		resp, err := plug.Create(ctx, vol.CreateReq)
+		if err != nil {
+			multierror.Append(&mErr, fmt.Errorf("failed to restore volume %s: %w", vol.ID, err))
+			continue
+		}
+
+		hvm.volumes[vol.CreateReq.Name] = vol.ID
+		hvm.updateNodeVols(vol.CreateReq.Name, &structs.ClientHostVolumeConfig{
+			Name: vol.CreateReq.Name,
+			Path: resp.Path,
+			ID:   vol.ID,
+		})
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// PluginAttributes fingerprints the host volume plugins and returns the node
+// attributes that advertise them, so that the server only creates volumes on
+// nodes with the plugin.
+func (hvm *HostVolumeManager) PluginAttributes(ctx context.Context) map[string]string {
+	attrs := map[string]string{}
+
+	plugins := []string{structs.HostVolumePluginMkdir}
+	if hvm.pluginDir != "" {
+		files, err := ioutil.ReadDir(hvm.pluginDir)
+		if err != nil && !os.IsNotExist(err) {
+			hvm.log.Warn("failed to read plugin directory", "error", err)
+		}
+		for _, f := range files {
+			if f.Name() == structs.HostVolumePluginMkdir {
+				continue
+			}
+			if f.Mode().IsRegular() && f.Mode().Perm()&0111 != 0 {
+				plugins = append(plugins, f.Name())
+			}
+		}
+	}
+
+	for _, id := range plugins {
+		plug, err := hvm.getPlugin(id)
+		if err != nil {
+			hvm.log.Warn("failed to load plugin", "plugin_id", id, "error", err)
+			continue
+		}
+		fprint, err := plug.Fingerprint(ctx)
+		if err != nil {
+			hvm.log.Warn("failed to fingerprint plugin", "plugin_id", id, "error", err)
+			continue
+		}
+		attrs[PluginAttribute(id)] = fprint.Version
+	}
+
+	return attrs
+}
+
+// PluginAttribute is the node attribute with the version of a host volume
+// plugin.
+func PluginAttribute(pluginID string) string {
+	return structs.HostVolumePluginAttribute(pluginID)
+}
+
+func (hvm *HostVolumeManager) getPlugin(id string) (HostVolumePlugin, error) {
+	log := hvm.log.With("plugin_id", id)
+
+	if id == structs.HostVolumePluginMkdir {
+		return &HostVolumePluginMkdir{
+			ID:         id,
+			TargetPath: hvm.sharedMountDir,
+			log:        log,
+		}, nil
+	}
+
+	if hvm.pluginDir == "" || filepath.Base(id) != id {
+		return nil, fmt.Errorf("%w: %q", ErrPluginNotExists, id)
+	}
+	path := filepath.Join(hvm.pluginDir, id)
+	return NewHostVolumePluginExternal(log, id, path, hvm.sharedMountDir)
+}
+
+// setOwnership applies the requested ownership and permissions to the
+// volume's directory.
+func setOwnership(path string, req *cstructs.ClientHostVolumeCreateRequest) error {
+	if req.Mode != "" {
+		vol := &structs.HostVolume{Mode: req.Mode}
+		mode, err := vol.FileMode()
+		if err != nil {
+			return err
+		}
+		if err := os.Chmod(path, os.FileMode(mode)); err != nil {
+			return fmt.Errorf("failed to set mode of volume: %w", err)
+		}
+	}
+	if req.UID != -1 || req.GID != -1 {
+		if err := os.Chown(path, req.UID, req.GID); err != nil {
+			return fmt.Errorf("failed to set owner of volume: %w", err)
+		}
+	}
+	return nil
+}
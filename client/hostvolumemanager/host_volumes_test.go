@@ -0,0 +1,214 @@
+package hostvolumemanager
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/client/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func testHostVolumeManager(t *testing.T, pluginDir string,
+	vols map[string]*structs.ClientHostVolumeConfig) (*HostVolumeManager, *state.MemDB) {
+
+	stateMgr := state.NewMemDB(testlog.HCLogger(t))
+	hvm := NewHostVolumeManager(testlog.HCLogger(t), &Config{
+		PluginDir:      pluginDir,
+		SharedMountDir: t.TempDir(),
+		StateMgr:       stateMgr,
+		UpdateNodeVols: func(name string, vol *structs.ClientHostVolumeConfig) {
+			if vol == nil {
+				delete(vols, name)
+			} else {
+				vols[name] = vol
+			}
+		},
+	})
+	return hvm, stateMgr
+}
+
+func TestHostVolumeManager_Mkdir(t *testing.T) {
+	vols := map[string]*structs.ClientHostVolumeConfig{}
+	hvm, stateMgr := testHostVolumeManager(t, "", vols)
+	ctx := context.Background()
+
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: structs.HostVolumePluginMkdir,
+		UID:      -1,
+		GID:      -1,
+		Mode:     "0750",
+	}
+	resp, err := hvm.Create(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(hvm.sharedMountDir, "vol-id"), resp.HostPath)
+
+	fi, err := os.Stat(resp.HostPath)
+	require.NoError(t, err)
+	require.True(t, fi.IsDir())
+	require.Equal(t, os.FileMode(0750), fi.Mode().Perm())
+
+	require.Equal(t, &structs.ClientHostVolumeConfig{
+		Name: "vol-name", Path: resp.HostPath, ID: "vol-id"}, vols["vol-name"])
+
+	persisted, err := stateMgr.GetDynamicHostVolumes()
+	require.NoError(t, err)
+	require.Len(t, persisted, 1)
+
+	// creating another volume with the same name fails
+	_, err = hvm.Create(ctx, &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "other-id",
+		Name:     "vol-name",
+		PluginID: structs.HostVolumePluginMkdir,
+		UID:      -1,
+		GID:      -1,
+	})
+	require.EqualError(t, err, `host volume "vol-name" already exists on node`)
+
+	_, err = hvm.Delete(ctx, &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: structs.HostVolumePluginMkdir,
+		HostPath: resp.HostPath,
+	})
+	require.NoError(t, err)
+
+	_, err = os.Stat(resp.HostPath)
+	require.True(t, os.IsNotExist(err))
+	require.Empty(t, vols)
+
+	persisted, err = stateMgr.GetDynamicHostVolumes()
+	require.NoError(t, err)
+	require.Empty(t, persisted)
+}
+
+func TestHostVolumeManager_Restore(t *testing.T) {
+	vols := map[string]*structs.ClientHostVolumeConfig{}
+	hvm, stateMgr := testHostVolumeManager(t, "", vols)
+	ctx := context.Background()
+
+	// a volume whose directory was removed while the client was down is
+	// created again on restore
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: structs.HostVolumePluginMkdir,
+		UID:      -1,
+		GID:      -1,
+	}
+	path := filepath.Join(hvm.sharedMountDir, "vol-id")
+	require.NoError(t, stateMgr.PutDynamicHostVolume(&cstructs.HostVolumeState{
+		ID:        "vol-id",
+		CreateReq: req,
+		HostPath:  path,
+	}))
+
+	require.NoError(t, hvm.Restore(ctx))
+	require.DirExists(t, path)
+	require.Equal(t, &structs.ClientHostVolumeConfig{
+		Name: "vol-name", Path: path, ID: "vol-id"}, vols["vol-name"])
+}
+
+func TestHostVolumeManager_StaticVolume(t *testing.T) {
+	vols := map[string]*structs.ClientHostVolumeConfig{
+		"static": {Name: "static", Path: "/srv/static"},
+	}
+	hvm, stateMgr := testHostVolumeManager(t, "", vols)
+	hvm.staticVolumes = map[string]*structs.ClientHostVolumeConfig{
+		"static": vols["static"],
+	}
+	ctx := context.Background()
+
+	// a dynamic volume can't shadow a volume in the client configuration
+	req := &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		Name:     "static",
+		PluginID: structs.HostVolumePluginMkdir,
+		UID:      -1,
+		GID:      -1,
+	}
+	_, err := hvm.Create(ctx, req)
+	require.True(t, errors.Is(err, ErrStaticVolumeExists), "unexpected error: %v", err)
+	require.NoDirExists(t, filepath.Join(hvm.sharedMountDir, "vol-id"))
+	require.Equal(t, "/srv/static", vols["static"].Path)
+
+	// nor can one created before the volume was added to the configuration
+	require.NoError(t, stateMgr.PutDynamicHostVolume(&cstructs.HostVolumeState{
+		ID:        "vol-id",
+		CreateReq: req,
+		HostPath:  filepath.Join(hvm.sharedMountDir, "vol-id"),
+	}))
+	err = hvm.Restore(ctx)
+	require.True(t, errors.Is(err, ErrStaticVolumeExists), "unexpected error: %v", err)
+	require.Equal(t, "/srv/static", vols["static"].Path)
+}
+
+func TestHostVolumeManager_ExternalPlugin(t *testing.T) {
+	pluginDir := t.TempDir()
+	script := `#!/bin/sh
+set -e
+case "$1" in
+fingerprint) echo '{"version": "0.0.1"}' ;;
+create) mkdir -p "$DHV_HOST_PATH"; echo "{\"path\": \"$DHV_HOST_PATH\", \"bytes\": $DHV_CAPACITY_MIN_BYTES}" ;;
+delete) rm -rf "$DHV_HOST_PATH" ;;
+*) echo "unknown operation $1" >&2; exit 1 ;;
+esac
+`
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(pluginDir, "example"), []byte(script), 0755))
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(pluginDir, "not-executable"), []byte(script), 0644))
+
+	vols := map[string]*structs.ClientHostVolumeConfig{}
+	hvm, _ := testHostVolumeManager(t, pluginDir, vols)
+	ctx := context.Background()
+
+	require.Equal(t, map[string]string{
+		"plugins.host_volume.mkdir.version":   "1.0.0",
+		"plugins.host_volume.example.version": "0.0.1",
+	}, hvm.PluginAttributes(ctx))
+
+	resp, err := hvm.Create(ctx, &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        "vol-id",
+		Name:                      "vol-name",
+		PluginID:                  "example",
+		RequestedCapacityMinBytes: 1024,
+		UID:                       -1,
+		GID:                       -1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(hvm.sharedMountDir, "vol-id"), resp.HostPath)
+	require.Equal(t, int64(1024), resp.CapacityBytes)
+	require.DirExists(t, resp.HostPath)
+
+	_, err = hvm.Delete(ctx, &cstructs.ClientHostVolumeDeleteRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: "example",
+		HostPath: resp.HostPath,
+	})
+	require.NoError(t, err)
+	require.NoDirExists(t, resp.HostPath)
+
+	_, err = hvm.Create(ctx, &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: "not-executable",
+	})
+	require.ErrorIs(t, err, ErrPluginNotExecutable)
+
+	_, err = hvm.Create(ctx, &cstructs.ClientHostVolumeCreateRequest{
+		ID:       "vol-id",
+		Name:     "vol-name",
+		PluginID: "../example",
+	})
+	require.ErrorIs(t, err, ErrPluginNotExists)
+}
@@ -0,0 +1,41 @@
+// Package bandwidth limits the network bandwidth of allocations with their
+// own network namespace.
+//
+// Egress traffic is shaped with a token bucket filter on the allocation's
+// interface. Ingress traffic can't be queued, so it is redirected to an ifb
+// device inside the namespace and shaped on its way out of it.
+package bandwidth
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotSupported is returned on platforms without traffic control.
+var ErrNotSupported = errors.New("bandwidth limits are not supported")
+
+// Counters are the bytes received and sent by an interface.
+type Counters struct {
+	RxBytes uint64
+	TxBytes uint64
+}
+
+// Rate returns the average rates in megabits per second between the earlier
+// counters prev and c, taken interval apart.
+func (c *Counters) Rate(prev *Counters, interval time.Duration) (rx, tx float64) {
+	if prev == nil || interval <= 0 {
+		return 0, 0
+	}
+
+	rate := func(cur, prev uint64) float64 {
+		if cur < prev {
+			// The counters were reset
+			return 0
+		}
+		return float64(cur-prev) * 8 / bitsPerMegabit / interval.Seconds()
+	}
+	return rate(c.RxBytes, prev.RxBytes), rate(c.TxBytes, prev.TxBytes)
+}
+
+// bitsPerMegabit follows tc in using SI units for rates.
+const bitsPerMegabit = 1000 * 1000
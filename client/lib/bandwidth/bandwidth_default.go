@@ -0,0 +1,13 @@
+// +build !linux
+
+package bandwidth
+
+// Limit is not supported on this platform.
+func Limit(nsPath, iface string, mbits int) error {
+	return ErrNotSupported
+}
+
+// Read is not supported on this platform.
+func Read(nsPath, iface string) (*Counters, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,179 @@
+package bandwidth
+
+import (
+	"fmt"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/hashicorp/nomad/client/lib/tc"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ifbName is the name of the device ingress traffic is redirected to.
+	// Every allocation has its own namespace so the name never collides.
+	ifbName = "ifb0"
+
+	// latency is the longest a packet may wait in the token bucket before
+	// it is dropped, in microseconds.
+	latency = 25 * 1000
+
+	// minBurst is the smallest bucket size in bytes. Smaller buckets can't
+	// hold a full TSO segment and would starve the interface.
+	minBurst = 64 * 1024
+)
+
+// Limit shapes the traffic of interface iface in the network namespace at
+// nsPath to mbits megabits per second in each direction. Calling Limit again
+// replaces the previous limits, and an mbits of zero removes them.
+func Limit(nsPath, iface string, mbits int) error {
+	netns, err := ns.GetNS(nsPath)
+	if err != nil {
+		return fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer netns.Close()
+
+	return netns.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName(iface)
+		if err != nil {
+			return fmt.Errorf("failed to find interface %q: %v", iface, err)
+		}
+
+		if mbits <= 0 {
+			return unlimit(link)
+		}
+
+		rate := uint64(mbits) * bitsPerMegabit / 8
+		if err := shape(link, rate); err != nil {
+			return err
+		}
+
+		ifb, err := ensureIfb(link)
+		if err != nil {
+			return err
+		}
+		if err := shape(ifb, rate); err != nil {
+			return err
+		}
+		if err := tc.Redirect(link, ifb, tc.PriorityLimit); err != nil {
+			// Don't leave the interface half limited
+			if uerr := unlimit(link); uerr != nil {
+				return fmt.Errorf("%v (cleanup failed: %v)", err, uerr)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// Read returns the counters of interface iface in the network namespace at
+// nsPath, from the point of view of the allocation.
+func Read(nsPath, iface string) (*Counters, error) {
+	netns, err := ns.GetNS(nsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer netns.Close()
+
+	var c *Counters
+	err = netns.Do(func(ns.NetNS) error {
+		link, err := netlink.LinkByName(iface)
+		if err != nil {
+			return fmt.Errorf("failed to find interface %q: %v", iface, err)
+		}
+		stats := link.Attrs().Statistics
+		if stats == nil {
+			return fmt.Errorf("interface %q has no statistics", iface)
+		}
+		c = &Counters{
+			RxBytes: stats.RxBytes,
+			TxBytes: stats.TxBytes,
+		}
+		return nil
+	})
+	return c, err
+}
+
+// shape replaces the root qdisc of link with a token bucket filter of rate
+// bytes per second. The bucket and queue sizes follow the CNI bandwidth
+// plugin.
+func shape(link netlink.Link, rate uint64) error {
+	burst := uint32(rate / 250)
+	if burst < minBurst {
+		burst = minBurst
+	}
+	buffer := uint32(netlink.Xmittime(rate, burst))
+	limit := uint32(float64(rate)*latency/netlink.TIME_UNITS_PER_SEC) + burst
+
+	qdisc := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+		Rate:   rate,
+		Limit:  limit,
+		Buffer: buffer,
+	}
+	if err := netlink.QdiscReplace(qdisc); err != nil {
+		return fmt.Errorf("failed to limit %q: %v", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// ensureIfb returns the ifb device ingress traffic of link is redirected to,
+// creating it if needed.
+func ensureIfb(link netlink.Link) (netlink.Link, error) {
+	ifb, err := netlink.LinkByName(ifbName)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to find interface %q: %v", ifbName, err)
+		}
+
+		attrs := netlink.NewLinkAttrs()
+		attrs.Name = ifbName
+		attrs.MTU = link.Attrs().MTU
+		attrs.TxQLen = 1000
+		if err := netlink.LinkAdd(&netlink.Ifb{LinkAttrs: attrs}); err != nil {
+			return nil, fmt.Errorf("failed to create interface %q: %v", ifbName, err)
+		}
+		if ifb, err = netlink.LinkByName(ifbName); err != nil {
+			return nil, fmt.Errorf("failed to find interface %q: %v", ifbName, err)
+		}
+	}
+
+	if err := netlink.LinkSetUp(ifb); err != nil {
+		return nil, fmt.Errorf("failed to set %q up: %v", ifbName, err)
+	}
+	return ifb, nil
+}
+
+// unlimit removes the limits set up by Limit from link.
+func unlimit(link netlink.Link) error {
+	if err := tc.Unredirect(link, tc.PriorityLimit); err != nil {
+		return err
+	}
+
+	root := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(1, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		},
+	}
+	if err := netlink.QdiscDel(root); err != nil && err != unix.ENOENT && err != unix.EINVAL {
+		return fmt.Errorf("failed to remove limit of %q: %v", link.Attrs().Name, err)
+	}
+
+	ifb, err := netlink.LinkByName(ifbName)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to find interface %q: %v", ifbName, err)
+	}
+	if err := netlink.LinkDel(ifb); err != nil {
+		return fmt.Errorf("failed to remove interface %q: %v", ifbName, err)
+	}
+	return nil
+}
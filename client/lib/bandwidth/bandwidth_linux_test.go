@@ -0,0 +1,92 @@
+package bandwidth
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+// newTestNS creates a network namespace with a veth pair whose end named
+// eth0 stands in for the allocation's interface.
+func newTestNS(t *testing.T) ns.NetNS {
+	if syscall.Geteuid() != 0 {
+		t.Skip("Test only available running as root")
+	}
+
+	netns, err := testutils.NewNS()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		netns.Close()
+		testutils.UnmountNS(netns)
+	})
+
+	err = netns.Do(func(ns.NetNS) error {
+		veth := &netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: "host0"},
+			PeerName:  "eth0",
+		}
+		return netlink.LinkAdd(veth)
+	})
+	require.NoError(t, err)
+	return netns
+}
+
+func TestLimit(t *testing.T) {
+	netns := newTestNS(t)
+	require := require.New(t)
+
+	require.NoError(Limit(netns.Path(), "eth0", 10))
+
+	// Limits can be changed in place
+	require.NoError(Limit(netns.Path(), "eth0", 20))
+
+	err := netns.Do(func(ns.NetNS) error {
+		for _, name := range []string{"eth0", ifbName} {
+			link, err := netlink.LinkByName(name)
+			require.NoError(err)
+			qdiscs, err := netlink.QdiscList(link)
+			require.NoError(err)
+
+			var tbf *netlink.Tbf
+			for _, q := range qdiscs {
+				if q, ok := q.(*netlink.Tbf); ok {
+					tbf = q
+				}
+			}
+			require.NotNil(tbf, "no tbf qdisc on %s", name)
+			require.Equal(uint64(20*1000*1000/8), tbf.Rate)
+		}
+
+		eth0, err := netlink.LinkByName("eth0")
+		require.NoError(err)
+		filters, err := netlink.FilterList(eth0, netlink.MakeHandle(0xffff, 0))
+		require.NoError(err)
+		require.Len(filters, 1)
+		return nil
+	})
+	require.NoError(err)
+
+	require.NoError(Limit(netns.Path(), "eth0", 0))
+	err = netns.Do(func(ns.NetNS) error {
+		_, err := netlink.LinkByName(ifbName)
+		require.IsType(netlink.LinkNotFoundError{}, err)
+		return nil
+	})
+	require.NoError(err)
+}
+
+func TestRead(t *testing.T) {
+	netns := newTestNS(t)
+	require := require.New(t)
+
+	c, err := Read(netns.Path(), "eth0")
+	require.NoError(err)
+	require.NotNil(c)
+
+	_, err = Read(netns.Path(), "missing0")
+	require.Error(err)
+}
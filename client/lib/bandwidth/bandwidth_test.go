@@ -0,0 +1,29 @@
+package bandwidth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounters_Rate(t *testing.T) {
+	require := require.New(t)
+
+	prev := &Counters{RxBytes: 1000, TxBytes: 5000}
+	cur := &Counters{RxBytes: 1000 + 2500000, TxBytes: 5000 + 125000}
+
+	rx, tx := cur.Rate(prev, 2*time.Second)
+	require.Equal(10.0, rx)
+	require.Equal(0.5, tx)
+
+	// No previous sample
+	rx, tx = cur.Rate(nil, time.Second)
+	require.Zero(rx)
+	require.Zero(tx)
+
+	// Reset counters
+	rx, tx = prev.Rate(cur, time.Second)
+	require.Zero(rx)
+	require.Zero(tx)
+}
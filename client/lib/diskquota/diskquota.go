@@ -0,0 +1,79 @@
+// Package diskquota limits the size of allocation directories.
+//
+// Two backends are supported. Project quotas assign the directory its own
+// project ID on an XFS or ext4 filesystem mounted with project quotas
+// enabled. Where project quotas aren't available, the directory is instead
+// backed by a sparse ext4 image of the requested size mounted through a loop
+// device.
+package diskquota
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ModeAuto uses project quotas if the filesystem supports them and falls
+	// back to loopback images otherwise.
+	ModeAuto = "auto"
+
+	// ModeProject uses XFS or ext4 project quotas.
+	ModeProject = "project"
+
+	// ModeLoopback mounts a sparse filesystem image over the directory.
+	ModeLoopback = "loopback"
+)
+
+// ErrNotSupported is returned when the requested mode can't be used on the
+// filesystem of the directory.
+var ErrNotSupported = errors.New("disk quotas are not supported")
+
+// minSlack is the minimum free space below which a quota is considered
+// exceeded.
+const minSlack = 1024 * 1024
+
+// Usage is the disk usage of a directory with a quota, in bytes.
+type Usage struct {
+	Used  uint64
+	Limit uint64
+}
+
+// Exceeded returns true if the usage has reached the limit. Filesystems
+// refuse writes before the last blocks are used up as they need room for
+// their own metadata, so a quota with less than 1%, or 1 MiB, left counts as
+// exceeded.
+func (u *Usage) Exceeded() bool {
+	if u.Limit == 0 {
+		return false
+	}
+
+	slack := u.Limit / 100
+	if slack < minSlack {
+		slack = minSlack
+	}
+	return u.Used+slack >= u.Limit
+}
+
+// Quota is a size limit placed on a directory.
+type Quota interface {
+	// Mode returns the mode used to enforce the quota.
+	Mode() string
+
+	// Usage returns the current usage of the directory.
+	Usage() (*Usage, error)
+
+	// Destroy removes the quota. The directory contents are discarded when
+	// it is backed by a loopback image.
+	Destroy() error
+}
+
+// ValidateMode returns an error if mode isn't a known mode.
+func ValidateMode(mode string) error {
+	switch mode {
+	case ModeAuto, ModeProject, ModeLoopback:
+		return nil
+	default:
+		return fmt.Errorf("invalid disk quota mode %q, must be one of %q, %q or %q",
+			mode, ModeAuto, ModeProject, ModeLoopback)
+	}
+}
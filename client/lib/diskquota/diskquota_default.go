@@ -0,0 +1,13 @@
+// +build !linux
+
+package diskquota
+
+// New is not supported on this platform.
+func New(mode, dir string, sizeMB int) (Quota, error) {
+	return nil, ErrNotSupported
+}
+
+// Remove is a no-op on this platform.
+func Remove(dir string) error {
+	return nil
+}
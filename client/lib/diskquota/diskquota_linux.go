@@ -0,0 +1,151 @@
+package diskquota
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const bytesPerMegabyte = 1024 * 1024
+
+// New places a quota of sizeMB megabytes on dir, creating dir if it doesn't
+// exist. Calling New on a directory that already has a quota reuses it, so
+// it is safe to call again when restoring an allocation.
+func New(mode, dir string, sizeMB int) (Quota, error) {
+	if sizeMB <= 0 {
+		return nil, fmt.Errorf("invalid disk quota size %d MB", sizeMB)
+	}
+	size := uint64(sizeMB) * bytesPerMegabyte
+
+	switch mode {
+	case ModeProject:
+		return newProjectQuota(dir, size)
+	case ModeLoopback:
+		return newLoopbackQuota(dir, size)
+	case ModeAuto:
+		// Keep using the image of a directory that was already set up with
+		// a loopback quota
+		if _, err := os.Stat(imagePath(dir)); err == nil {
+			return newLoopbackQuota(dir, size)
+		}
+
+		q, err := newProjectQuota(dir, size)
+		if errors.Is(err, ErrNotSupported) {
+			return newLoopbackQuota(dir, size)
+		}
+		return q, err
+	default:
+		return nil, ValidateMode(mode)
+	}
+}
+
+// Remove destroys the quota of dir if it has one. Unlike Quota.Destroy it
+// doesn't need to know the mode the quota was created with, so it can be used
+// to clean up after a restart.
+func Remove(dir string) error {
+	if _, err := os.Stat(imagePath(dir)); err == nil {
+		return (&loopbackQuota{dir: dir, image: imagePath(dir)}).Destroy()
+	}
+	return removeProjectQuota(dir)
+}
+
+// mountInfo is an entry of /proc/self/mountinfo.
+type mountInfo struct {
+	major, minor uint32
+	mountPoint   string
+	fsType       string
+	source       string
+}
+
+// readMountInfo parses the mount table of the current process.
+func readMountInfo() ([]*mountInfo, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mounts []*mountInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw
+		fields := strings.Fields(scanner.Text())
+		sep := -1
+		for i, field := range fields {
+			if field == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep < 5 || len(fields) < sep+3 {
+			return nil, fmt.Errorf("invalid mountinfo line: %q", scanner.Text())
+		}
+
+		devs := strings.SplitN(fields[2], ":", 2)
+		if len(devs) != 2 {
+			return nil, fmt.Errorf("invalid device in mountinfo line: %q", scanner.Text())
+		}
+		major, err := strconv.ParseUint(devs[0], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		minor, err := strconv.ParseUint(devs[1], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		mounts = append(mounts, &mountInfo{
+			major:      uint32(major),
+			minor:      uint32(minor),
+			mountPoint: unescapeMountPath(fields[4]),
+			fsType:     fields[sep+1],
+			source:     unescapeMountPath(fields[sep+2]),
+		})
+	}
+
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPath decodes the octal escapes the kernel uses for
+// whitespace and backslashes in mountinfo paths.
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, `\`) {
+		return path
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if c, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// isMountPoint returns true if dir is the mount point of a filesystem.
+func isMountPoint(dir string) (bool, error) {
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false, err
+	}
+
+	mounts, err := readMountInfo()
+	if err != nil {
+		return false, err
+	}
+	for _, m := range mounts {
+		if m.mountPoint == dir {
+			return true, nil
+		}
+	}
+	return false, nil
+}
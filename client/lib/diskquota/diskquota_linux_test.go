@@ -0,0 +1,213 @@
+package diskquota
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func requireLoopback(t *testing.T) {
+	if syscall.Geteuid() != 0 {
+		t.Skip("Test only available running as root")
+	}
+	if _, err := os.Stat("/dev/loop-control"); err != nil {
+		t.Skip("Test requires loop devices")
+	}
+	if _, err := exec.LookPath("mkfs.ext4"); err != nil {
+		t.Skip("Test requires mkfs.ext4")
+	}
+}
+
+// fill writes to path until the write fails and returns the error.
+func fill(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := bytes.Repeat([]byte{'x'}, 1024*1024)
+	for i := 0; i < 1024; i++ {
+		if _, err := f.Write(buf); err != nil {
+			return err
+		}
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestLoopback(t *testing.T) {
+	requireLoopback(t)
+	require := require.New(t)
+
+	tmp, err := ioutil.TempDir("", "diskquota")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "alloc")
+	q, err := New(ModeLoopback, dir, 16)
+	require.NoError(err)
+	defer q.Destroy()
+	require.Equal(ModeLoopback, q.Mode())
+	require.FileExists(imagePath(dir))
+
+	mounted, err := isMountPoint(dir)
+	require.NoError(err)
+	require.True(mounted)
+
+	usage, err := q.Usage()
+	require.NoError(err)
+	require.False(usage.Exceeded())
+	require.LessOrEqual(usage.Limit, uint64(16*bytesPerMegabyte))
+
+	// Writes stop at the size of the image
+	err = fill(filepath.Join(dir, "file"))
+	require.True(errors.Is(err, unix.ENOSPC), "unexpected error: %v", err)
+
+	usage, err = q.Usage()
+	require.NoError(err)
+	require.True(usage.Exceeded(), "usage: %#v", usage)
+
+	// Restoring the quota reuses the mount
+	q2, err := New(ModeAuto, dir, 16)
+	require.NoError(err)
+	require.Equal(ModeLoopback, q2.Mode())
+	require.FileExists(filepath.Join(dir, "file"))
+
+	require.NoError(Remove(dir))
+	mounted, err = isMountPoint(dir)
+	require.NoError(err)
+	require.False(mounted)
+	require.NoFileExists(imagePath(dir))
+	require.NoFileExists(filepath.Join(dir, "file"))
+}
+
+func TestLoopback_NotEmpty(t *testing.T) {
+	requireLoopback(t)
+	require := require.New(t)
+
+	tmp, err := ioutil.TempDir("", "diskquota")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "alloc")
+	require.NoError(os.MkdirAll(dir, 0755))
+	require.NoError(ioutil.WriteFile(filepath.Join(dir, "file"), []byte("foo"), 0644))
+
+	_, err = New(ModeLoopback, dir, 16)
+	require.Error(err)
+	require.NoFileExists(imagePath(dir))
+}
+
+// mountProjectQuotaFS mounts an ext4 filesystem with project quotas enabled
+// in a temp dir and returns its mount point.
+func mountProjectQuotaFS(t *testing.T) string {
+	requireLoopback(t)
+
+	tmp, err := ioutil.TempDir("", "diskquota")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(tmp) })
+
+	image := filepath.Join(tmp, "fs.img")
+	f, err := os.Create(image)
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(64*bytesPerMegabyte))
+	require.NoError(t, f.Close())
+
+	out, err := exec.Command("mkfs.ext4", "-q", "-F", "-O", "quota,project", image).CombinedOutput()
+	if err != nil {
+		t.Skipf("mkfs.ext4 doesn't support project quotas: %v: %s", err, out)
+	}
+
+	loop, err := attachLoopDevice(image)
+	require.NoError(t, err)
+	defer loop.Close()
+
+	mnt := filepath.Join(tmp, "mnt")
+	require.NoError(t, os.Mkdir(mnt, 0755))
+	if err := unix.Mount(loop.Name(), mnt, "ext4", 0, "prjquota"); err != nil {
+		t.Skipf("kernel doesn't support ext4 project quotas: %v", err)
+	}
+	t.Cleanup(func() { unix.Unmount(mnt, unix.MNT_DETACH) })
+
+	return mnt
+}
+
+func TestProject(t *testing.T) {
+	mnt := mountProjectQuotaFS(t)
+	require := require.New(t)
+
+	dir := filepath.Join(mnt, "alloc")
+	q, err := New(ModeAuto, dir, 8)
+	require.NoError(err)
+	require.Equal(ModeProject, q.Mode())
+	require.NoFileExists(imagePath(dir))
+
+	attr, err := getFsxattr(dir)
+	require.NoError(err)
+	require.Equal(projectID(dir), attr.projid)
+
+	// Files in nested directories count towards the quota
+	require.NoError(os.MkdirAll(filepath.Join(dir, "task", "local"), 0755))
+	err = fill(filepath.Join(dir, "task", "local", "file"))
+	require.True(errors.Is(err, unix.EDQUOT), "unexpected error: %v", err)
+
+	usage, err := q.Usage()
+	require.NoError(err)
+	require.Equal(uint64(8*bytesPerMegabyte), usage.Limit)
+	require.True(usage.Exceeded(), "usage: %#v", usage)
+
+	require.NoError(Remove(dir))
+	usage, err = q.Usage()
+	require.NoError(err)
+	require.Zero(usage.Limit)
+	require.False(usage.Exceeded())
+}
+
+func TestProject_NotSupported(t *testing.T) {
+	requireLoopback(t)
+	require := require.New(t)
+
+	// A loopback filesystem without project quotas
+	tmp, err := ioutil.TempDir("", "diskquota")
+	require.NoError(err)
+	defer os.RemoveAll(tmp)
+
+	mnt := filepath.Join(tmp, "mnt")
+	fs, err := New(ModeLoopback, mnt, 16)
+	require.NoError(err)
+	defer fs.Destroy()
+
+	dir := filepath.Join(mnt, "alloc")
+	_, err = New(ModeProject, dir, 8)
+	require.True(errors.Is(err, ErrNotSupported), "unexpected error: %v", err)
+
+	// Auto mode falls back to a nested loopback image
+	q, err := New(ModeAuto, dir, 8)
+	require.NoError(err)
+	defer q.Destroy()
+	require.Equal(ModeLoopback, q.Mode())
+}
+
+func TestUnescapeMountPath(t *testing.T) {
+	require.Equal(t, "/a b\\c", unescapeMountPath(`/a\040b\134c`))
+	require.Equal(t, "/plain", unescapeMountPath("/plain"))
+	require.Equal(t, `/trailing\04`, unescapeMountPath(`/trailing\04`))
+}
+
+func TestValidateMode(t *testing.T) {
+	for _, mode := range []string{ModeAuto, ModeProject, ModeLoopback} {
+		require.NoError(t, ValidateMode(mode))
+	}
+	require.Error(t, ValidateMode("zfs"))
+}
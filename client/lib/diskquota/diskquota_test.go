@@ -0,0 +1,28 @@
+package diskquota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsage_Exceeded(t *testing.T) {
+	cases := []struct {
+		name     string
+		usage    Usage
+		exceeded bool
+	}{
+		{"no limit", Usage{Used: 100}, false},
+		{"below limit", Usage{Used: 10 << 20, Limit: 100 << 20}, false},
+		{"at limit", Usage{Used: 100 << 20, Limit: 100 << 20}, true},
+		{"within 1%", Usage{Used: 99<<20 + 1, Limit: 100 << 20}, true},
+		{"within 1MiB", Usage{Used: 9<<20 + 1, Limit: 10 << 20}, true},
+		{"large below 1%", Usage{Used: 98 << 30, Limit: 100 << 30}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.exceeded, tc.usage.Exceeded())
+		})
+	}
+}
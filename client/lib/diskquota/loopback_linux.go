@@ -0,0 +1,243 @@
+package diskquota
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// loopMajor is the major device number of loop devices
+	loopMajor = 7
+
+	// loopAttachAttempts is the number of times attaching a loop device is
+	// retried when another process grabs the free device first
+	loopAttachAttempts = 10
+)
+
+// loopbackQuota limits a directory by mounting a filesystem image of the
+// requested size over it.
+type loopbackQuota struct {
+	dir   string
+	image string
+}
+
+// imagePath returns the path of the filesystem image backing dir.
+func imagePath(dir string) string {
+	return filepath.Clean(dir) + ".img"
+}
+
+func newLoopbackQuota(dir string, size uint64) (*loopbackQuota, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %q: %v", dir, err)
+	}
+
+	q := &loopbackQuota{
+		dir:   dir,
+		image: imagePath(dir),
+	}
+
+	mounted, err := isMountPoint(dir)
+	if err != nil {
+		return nil, err
+	}
+	if mounted {
+		// Restoring a directory that is still mounted
+		return q, nil
+	}
+
+	created := false
+	if _, err := os.Stat(q.image); os.IsNotExist(err) {
+		// Refuse to hide existing files under the new mount
+		if empty, err := isEmptyDir(dir); err != nil {
+			return nil, err
+		} else if !empty {
+			return nil, fmt.Errorf("failed to mount disk image on %q: directory is not empty", dir)
+		}
+
+		if err := createImage(q.image, size); err != nil {
+			return nil, err
+		}
+		created = true
+	}
+
+	if err := q.mount(created); err != nil {
+		if created {
+			os.Remove(q.image)
+		}
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *loopbackQuota) Mode() string {
+	return ModeLoopback
+}
+
+func (q *loopbackQuota) Usage() (*Usage, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(q.dir, &st); err != nil {
+		return nil, fmt.Errorf("failed to stat filesystem of %q: %v", q.dir, err)
+	}
+
+	// Blocks ext4 reserves for itself are never available to the
+	// allocation, so they count towards neither usage nor the limit
+	bsize := uint64(st.Bsize)
+	used := (st.Blocks - st.Bfree) * bsize
+	return &Usage{
+		Used:  used,
+		Limit: used + st.Bavail*bsize,
+	}, nil
+}
+
+func (q *loopbackQuota) Destroy() error {
+	if err := unix.Unmount(q.dir, 0); err != nil {
+		switch {
+		case errors.Is(err, unix.EINVAL), errors.Is(err, unix.ENOENT):
+			// Not mounted
+		case errors.Is(err, unix.EBUSY):
+			// Detach lazily so the directory can be removed while a process
+			// that is still exiting holds files open
+			if err := unix.Unmount(q.dir, unix.MNT_DETACH); err != nil {
+				return fmt.Errorf("failed to unmount %q: %v", q.dir, err)
+			}
+		default:
+			return fmt.Errorf("failed to unmount %q: %v", q.dir, err)
+		}
+	}
+
+	if err := os.Remove(q.image); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove disk image %q: %v", q.image, err)
+	}
+	return nil
+}
+
+// mount attaches the image to a loop device and mounts it on the directory.
+func (q *loopbackQuota) mount(fresh bool) error {
+	loop, err := attachLoopDevice(q.image)
+	if err != nil {
+		return err
+	}
+	// The device is detached once it is unmounted and closed
+	defer loop.Close()
+
+	if err := unix.Mount(loop.Name(), q.dir, "ext4", 0, ""); err != nil {
+		return fmt.Errorf("failed to mount disk image %q on %q: %v", q.image, q.dir, err)
+	}
+
+	if fresh {
+		os.Remove(filepath.Join(q.dir, "lost+found"))
+	}
+	return nil
+}
+
+// createImage creates a sparse ext4 filesystem image of the given size.
+func createImage(image string, size uint64) error {
+	f, err := os.OpenFile(image, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create disk image %q: %v", image, err)
+	}
+	err = f.Truncate(int64(size))
+	f.Close()
+	if err != nil {
+		os.Remove(image)
+		return fmt.Errorf("failed to size disk image %q: %v", image, err)
+	}
+
+	// No blocks are reserved for root as the whole filesystem belongs to a
+	// single allocation
+	out, err := exec.Command("mkfs.ext4", "-q", "-F", "-m", "0", image).CombinedOutput()
+	if err != nil {
+		os.Remove(image)
+		return fmt.Errorf("failed to create filesystem on %q: %v: %s", image, err, out)
+	}
+	return nil
+}
+
+// attachLoopDevice attaches image to a free loop device and returns the open
+// device. The device is set to detach automatically once it is no longer
+// used.
+func attachLoopDevice(image string) (*os.File, error) {
+	ctl, err := os.OpenFile("/dev/loop-control", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open loop control device: %v", err)
+	}
+	defer ctl.Close()
+
+	f, err := os.OpenFile(image, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open disk image %q: %v", image, err)
+	}
+	defer f.Close()
+
+	for i := 0; i < loopAttachAttempts; i++ {
+		n, err := unix.IoctlRetInt(int(ctl.Fd()), unix.LOOP_CTL_GET_FREE)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find a free loop device: %v", err)
+		}
+
+		loop, err := openLoopDevice(n)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_SET_FD, int(f.Fd())); err != nil {
+			loop.Close()
+			if errors.Is(err, unix.EBUSY) {
+				// Raced with another process for the device
+				continue
+			}
+			return nil, fmt.Errorf("failed to attach %q to %s: %v", image, loop.Name(), err)
+		}
+
+		info := unix.LoopInfo64{Flags: unix.LO_FLAGS_AUTOCLEAR}
+		copy(info.File_name[:len(info.File_name)-1], image)
+		if err := ioctl(loop.Fd(), unix.LOOP_SET_STATUS64, unsafe.Pointer(&info)); err != nil {
+			unix.IoctlSetInt(int(loop.Fd()), unix.LOOP_CLR_FD, 0)
+			loop.Close()
+			return nil, fmt.Errorf("failed to configure %s: %v", loop.Name(), err)
+		}
+
+		return loop, nil
+	}
+
+	return nil, fmt.Errorf("failed to attach %q to a loop device after %d attempts", image, loopAttachAttempts)
+}
+
+// openLoopDevice opens /dev/loopN, creating the device node if /dev isn't
+// managed by devtmpfs.
+func openLoopDevice(n int) (*os.File, error) {
+	path := fmt.Sprintf("/dev/loop%d", n)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := unix.Mknod(path, unix.S_IFBLK|0660, int(unix.Mkdev(loopMajor, uint32(n)))); err != nil && !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create %s: %v", path, err)
+		}
+	}
+
+	loop, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	return loop, nil
+}
+
+func isEmptyDir(dir string) (bool, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if _, err := f.Readdirnames(1); err == io.EOF {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return false, nil
+}
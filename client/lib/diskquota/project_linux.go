@@ -0,0 +1,253 @@
+package diskquota
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ioctls to get and set the project ID of an inode, from linux/fs.h
+	fsIocFsGetXattr = 0x801c581f
+	fsIocFsSetXattr = 0x401c5820
+
+	// fsXflagProjInherit makes new files and directories inherit the
+	// project ID of their parent directory
+	fsXflagProjInherit = 0x200
+
+	// quotactl commands and flags, from linux/quota.h
+	qGetInfo   = 0x800005
+	qGetQuota  = 0x800007
+	qSetQuota  = 0x800008
+	prjQuota   = 2
+	qifBLimits = 1
+
+	// quotaBlockSize is the unit of the block limits passed to quotactl
+	quotaBlockSize = 1024
+
+	// projectIDBase is set on all project IDs used by Nomad to keep them
+	// clear of the low IDs usually assigned by operators
+	projectIDBase = 1 << 31
+)
+
+// fsxattr is struct fsxattr from linux/fs.h.
+type fsxattr struct {
+	xflags     uint32
+	extsize    uint32
+	nextents   uint32
+	projid     uint32
+	cowextsize uint32
+	pad        [8]byte
+}
+
+// dqblk is struct if_dqblk from linux/quota.h.
+type dqblk struct {
+	bhardlimit uint64
+	bsoftlimit uint64
+	curspace   uint64
+	ihardlimit uint64
+	isoftlimit uint64
+	curinodes  uint64
+	btime      uint64
+	itime      uint64
+	valid      uint32
+	_          uint32
+}
+
+// dqinfo is struct if_dqinfo from linux/quota.h.
+type dqinfo struct {
+	bgrace uint64
+	igrace uint64
+	flags  uint32
+	valid  uint32
+}
+
+// projectQuota limits a directory with an XFS or ext4 project quota.
+type projectQuota struct {
+	device string
+	id     uint32
+}
+
+func newProjectQuota(dir string, size uint64) (*projectQuota, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %q: %v", dir, err)
+	}
+
+	device, err := quotaDevice(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure project quotas are enabled before tagging the directory
+	var info dqinfo
+	if err := quotactl(qGetInfo, device, 0, unsafe.Pointer(&info)); err != nil {
+		return nil, fmt.Errorf("%w: project quotas are not enabled on %s: %v", ErrNotSupported, device, err)
+	}
+
+	q := &projectQuota{
+		device: device,
+		id:     projectID(dir),
+	}
+
+	if err := setProjectID(dir, q.id); err != nil {
+		return nil, err
+	}
+	if err := q.setLimit(size); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *projectQuota) Mode() string {
+	return ModeProject
+}
+
+func (q *projectQuota) Usage() (*Usage, error) {
+	var d dqblk
+	if err := quotactl(qGetQuota, q.device, q.id, unsafe.Pointer(&d)); err != nil {
+		return nil, fmt.Errorf("failed to get quota of project %d: %v", q.id, err)
+	}
+
+	return &Usage{
+		Used:  d.curspace,
+		Limit: d.bhardlimit * quotaBlockSize,
+	}, nil
+}
+
+func (q *projectQuota) Destroy() error {
+	return q.setLimit(0)
+}
+
+// setLimit sets the hard block limit of the project. A limit of zero removes
+// the limit.
+func (q *projectQuota) setLimit(size uint64) error {
+	d := dqblk{
+		bhardlimit: size / quotaBlockSize,
+		valid:      qifBLimits,
+	}
+	if err := quotactl(qSetQuota, q.device, q.id, unsafe.Pointer(&d)); err != nil {
+		return fmt.Errorf("failed to set quota of project %d: %v", q.id, err)
+	}
+	return nil
+}
+
+// removeProjectQuota removes the limit of dir if it was tagged with its
+// project ID by newProjectQuota.
+func removeProjectQuota(dir string) error {
+	attr, err := getFsxattr(dir)
+	if err != nil || attr.projid != projectID(dir) {
+		// Either the directory is gone or it has no project quota
+		return nil
+	}
+
+	device, err := quotaDevice(dir)
+	if err != nil {
+		return nil
+	}
+
+	q := &projectQuota{device: device, id: attr.projid}
+	return q.Destroy()
+}
+
+// projectID returns the project ID of dir. IDs are derived from the path so
+// they can be found again after a restart without tracking them.
+func projectID(dir string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(filepath.Clean(dir)))
+	return h.Sum32() | projectIDBase
+}
+
+// quotaDevice returns the block device of the XFS or ext4 filesystem dir is
+// on.
+func quotaDevice(dir string) (string, error) {
+	var st unix.Stat_t
+	if err := unix.Stat(dir, &st); err != nil {
+		return "", err
+	}
+
+	mounts, err := readMountInfo()
+	if err != nil {
+		return "", err
+	}
+
+	for _, m := range mounts {
+		if m.major != unix.Major(st.Dev) || m.minor != unix.Minor(st.Dev) {
+			continue
+		}
+		switch m.fsType {
+		case "xfs", "ext4":
+			return m.source, nil
+		default:
+			return "", fmt.Errorf("%w: %s filesystem of %q has no project quotas", ErrNotSupported, m.fsType, dir)
+		}
+	}
+
+	return "", fmt.Errorf("%w: failed to find the filesystem of %q", ErrNotSupported, dir)
+}
+
+func getFsxattr(dir string) (*fsxattr, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var attr fsxattr
+	if err := ioctl(f.Fd(), fsIocFsGetXattr, unsafe.Pointer(&attr)); err != nil {
+		return nil, err
+	}
+	return &attr, nil
+}
+
+// setProjectID tags dir with the project ID and makes its children inherit
+// it.
+func setProjectID(dir string, id uint32) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var attr fsxattr
+	if err := ioctl(f.Fd(), fsIocFsGetXattr, unsafe.Pointer(&attr)); err != nil {
+		return fmt.Errorf("%w: failed to get project of %q: %v", ErrNotSupported, dir, err)
+	}
+
+	attr.projid = id
+	attr.xflags |= fsXflagProjInherit
+	if err := ioctl(f.Fd(), fsIocFsSetXattr, unsafe.Pointer(&attr)); err != nil {
+		if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.ENOTTY) {
+			return fmt.Errorf("%w: failed to set project of %q: %v", ErrNotSupported, dir, err)
+		}
+		return fmt.Errorf("failed to set project of %q: %v", dir, err)
+	}
+	return nil
+}
+
+func ioctl(fd uintptr, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// quotactl runs the project quota command cmd against device.
+func quotactl(cmd int, device string, id uint32, addr unsafe.Pointer) error {
+	special, err := unix.BytePtrFromString(device)
+	if err != nil {
+		return err
+	}
+
+	qcmd := cmd<<8 | prjQuota
+	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, uintptr(qcmd),
+		uintptr(unsafe.Pointer(special)), uintptr(id), uintptr(addr), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
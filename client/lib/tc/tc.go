@@ -0,0 +1,23 @@
+// Package tc redirects the ingress traffic of the interfaces inside
+// allocation network namespaces.
+//
+// Redirects share the ingress qdisc of an interface, and each user owns a
+// filter priority. A redirect steals every packet it matches, so only one
+// user may redirect an interface at a time.
+package tc
+
+import "errors"
+
+const (
+	// PriorityTap is the priority of the redirects between the interface of
+	// an allocation and the tap device of a VM.
+	PriorityTap uint16 = 1
+
+	// PriorityLimit is the priority of the redirect of an allocation's
+	// ingress traffic to the ifb device it is shaped on.
+	PriorityLimit uint16 = 2
+)
+
+// ErrRedirected is returned when the ingress traffic of an interface is
+// already redirected at another priority.
+var ErrRedirected = errors.New("ingress traffic is already redirected")
@@ -0,0 +1,104 @@
+package tc
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// ingressParent is the handle of the ingress qdisc filters are attached to
+var ingressParent = netlink.MakeHandle(0xffff, 0)
+
+// Redirect sends every packet arriving on from out of to, replacing any
+// previous redirect of from at priority. It returns ErrRedirected if from is
+// redirected at another priority.
+func Redirect(from, to netlink.Link, priority uint16) error {
+	// The ingress qdisc is kept along with its filters if it already exists
+	if err := netlink.QdiscAdd(ingress(from)); err != nil && err != unix.EEXIST {
+		return fmt.Errorf("failed to add ingress qdisc to %q: %v", from.Attrs().Name, err)
+	}
+
+	other, err := remove(from, priority)
+	if err != nil {
+		return err
+	}
+	if other {
+		return fmt.Errorf("failed to redirect %q: %w", from.Attrs().Name, ErrRedirected)
+	}
+
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: from.Attrs().Index,
+			Parent:    ingressParent,
+			Priority:  priority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{
+			netlink.NewMirredAction(to.Attrs().Index),
+		},
+	}
+	if err := netlink.FilterAdd(filter); err != nil {
+		return fmt.Errorf("failed to redirect %q to %q: %v", from.Attrs().Name, to.Attrs().Name, err)
+	}
+	return nil
+}
+
+// Unredirect removes the redirect of link at priority, along with the
+// ingress qdisc of link once no other filters are left on it.
+func Unredirect(link netlink.Link, priority uint16) error {
+	other, err := remove(link, priority)
+	if err != nil || other {
+		return err
+	}
+
+	if err := netlink.QdiscDel(ingress(link)); err != nil && err != unix.ENOENT && err != unix.EINVAL {
+		return fmt.Errorf("failed to remove ingress qdisc of %q: %v", link.Attrs().Name, err)
+	}
+	return nil
+}
+
+// remove removes the ingress filters of link at priority, and returns
+// whether link has ingress filters at other priorities.
+func remove(link netlink.Link, priority uint16) (bool, error) {
+	filters, err := netlink.FilterList(link, ingressParent)
+	if err != nil {
+		return false, fmt.Errorf("failed to list filters of %q: %v", link.Attrs().Name, err)
+	}
+
+	found, other := false, false
+	for _, f := range filters {
+		if f.Attrs().Priority == priority {
+			found = true
+		} else {
+			other = true
+		}
+	}
+	if !found {
+		return other, nil
+	}
+
+	// Without a handle the kernel removes every filter of the priority
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    ingressParent,
+			Priority:  priority,
+			Protocol:  unix.ETH_P_ALL,
+		},
+	}
+	if err := netlink.FilterDel(filter); err != nil && err != unix.ENOENT {
+		return false, fmt.Errorf("failed to remove redirect of %q: %v", link.Attrs().Name, err)
+	}
+	return other, nil
+}
+
+func ingress(link netlink.Link) *netlink.Ingress {
+	return &netlink.Ingress{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    ingressParent,
+			Parent:    netlink.HANDLE_INGRESS,
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package tc
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+func TestRedirect(t *testing.T) {
+	if syscall.Geteuid() != 0 {
+		t.Skip("Test only available running as root")
+	}
+	require := require.New(t)
+
+	netns, err := testutils.NewNS()
+	require.NoError(err)
+	defer func() {
+		netns.Close()
+		testutils.UnmountNS(netns)
+	}()
+
+	err = netns.Do(func(ns.NetNS) error {
+		for peer, name := range map[string]string{"host0": "eth0", "host1": "tap0"} {
+			veth := &netlink.Veth{
+				LinkAttrs: netlink.LinkAttrs{Name: peer},
+				PeerName:  name,
+			}
+			require.NoError(netlink.LinkAdd(veth))
+		}
+		require.NoError(netlink.LinkAdd(&netlink.Ifb{LinkAttrs: netlink.LinkAttrs{Name: "ifb0"}}))
+		eth0, err := netlink.LinkByName("eth0")
+		require.NoError(err)
+		tap, err := netlink.LinkByName("tap0")
+		require.NoError(err)
+		ifb, err := netlink.LinkByName("ifb0")
+		require.NoError(err)
+
+		requireFilters := func(priorities ...uint16) {
+			filters, err := netlink.FilterList(eth0, ingressParent)
+			require.NoError(err)
+			var found []uint16
+			for _, f := range filters {
+				found = append(found, f.Attrs().Priority)
+			}
+			require.ElementsMatch(priorities, found)
+		}
+
+		requireIngress := func(exists bool) {
+			qdiscs, err := netlink.QdiscList(eth0)
+			require.NoError(err)
+			found := false
+			for _, q := range qdiscs {
+				if _, ok := q.(*netlink.Ingress); ok {
+					found = true
+				}
+			}
+			require.Equal(exists, found)
+		}
+
+		// Redirects can be replaced in place
+		require.NoError(Redirect(eth0, tap, PriorityTap))
+		require.NoError(Redirect(eth0, tap, PriorityTap))
+		requireFilters(PriorityTap)
+
+		// Only one priority may redirect an interface
+		err = Redirect(eth0, ifb, PriorityLimit)
+		require.True(errors.Is(err, ErrRedirected), "unexpected error: %v", err)
+		requireFilters(PriorityTap)
+
+		// Removing a redirect at another priority keeps the ingress qdisc
+		require.NoError(Unredirect(eth0, PriorityLimit))
+		requireFilters(PriorityTap)
+		requireIngress(true)
+
+		require.NoError(Unredirect(eth0, PriorityTap))
+		requireFilters()
+		requireIngress(false)
+
+		// The interface can be redirected at another priority once free
+		require.NoError(Redirect(eth0, ifb, PriorityLimit))
+		requireFilters(PriorityLimit)
+		require.NoError(Unredirect(eth0, PriorityLimit))
+		require.NoError(Unredirect(eth0, PriorityLimit))
+		return nil
+	})
+	require.NoError(err)
+}
@@ -199,6 +199,23 @@ func (c *Client) updateNodeFromCSINodeLocked(name string, info *structs.CSIInfo)
 	return changed
 }
 
+// updateNodeFromHostVolume fingerprints a dynamic host volume into the node,
+// or removes it from the node if the volume is nil
+func (c *Client) updateNodeFromHostVolume(name string, volume *structs.ClientHostVolumeConfig) {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+
+	if c.config.Node.HostVolumes == nil {
+		c.config.Node.HostVolumes = make(map[string]*structs.ClientHostVolumeConfig)
+	}
+	if volume == nil {
+		delete(c.config.Node.HostVolumes, name)
+	} else {
+		c.config.Node.HostVolumes[name] = volume
+	}
+	c.updateNodeLocked()
+}
+
 // updateNodeFromDriver receives a DriverInfo struct for the driver and updates
 // the node accordingly
 func (c *Client) updateNodeFromDriver(name string, info *structs.DriverInfo) {
@@ -363,3 +363,54 @@ func (v *volumeManager) UnmountVolume(ctx context.Context, volID, remoteID, allo
 
 	return err
 }
+
+// ExpandVolume expands the filesystem of a volume that has been expanded by
+// the controller, at the path where it is published for the allocation. It
+// returns the capacity of the volume reported by the plugin.
+func (v *volumeManager) ExpandVolume(ctx context.Context, volID, remoteID, allocID string, usage *UsageOptions, capacity *csi.CapacityRange) (int64, error) {
+	logger := v.logger.With("volume_id", volID, "alloc_id", allocID)
+	ctx = hclog.WithContext(ctx, logger)
+
+	capability, err := csi.VolumeCapabilityFromStructs(usage.AttachmentMode, usage.AccessMode, usage.MountOptions)
+	if err != nil {
+		return 0, err
+	}
+
+	req := &csi.NodeExpandVolumeRequest{
+		ExternalVolumeID: remoteID,
+		Capacity:         capacity,
+		TargetPath:       v.targetForVolume(v.containerMountPoint, volID, allocID, usage),
+		Capability:       capability,
+	}
+	if v.requiresStaging {
+		req.StagingTargetPath = v.stagingDirForVolume(v.containerMountPoint, volID, usage)
+	}
+
+	// CSI NodeExpandVolume errors for timeout, codes.Unavailable and
+	// codes.ResourceExhausted are retried; all other errors are fatal.
+	resp, err := v.plugin.NodeExpandVolume(ctx, req,
+		grpc_retry.WithPerRetryTimeout(DefaultMountActionTimeout),
+		grpc_retry.WithMax(3),
+		grpc_retry.WithBackoff(grpc_retry.BackoffExponential(100*time.Millisecond)),
+	)
+
+	event := structs.NewNodeEvent().
+		SetSubsystem(structs.NodeEventSubsystemStorage).
+		SetMessage("Expand volume").
+		AddDetail("volume_id", volID)
+	if err == nil {
+		event.AddDetail("success", "true")
+	} else {
+		event.AddDetail("success", "false")
+		event.AddDetail("error", err.Error())
+	}
+	v.eventer(event)
+
+	if err != nil {
+		return 0, err
+	}
+	if resp == nil {
+		return 0, nil
+	}
+	return resp.CapacityBytes, nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/nomad/helper/mount"
 	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/plugins/csi"
@@ -467,6 +468,77 @@ func TestVolumeManager_unpublishVolume(t *testing.T) {
 	}
 }
 
+func TestVolumeManager_ExpandVolume(t *testing.T) {
+	t.Parallel()
+
+	usage := &UsageOptions{
+		AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		AccessMode:     structs.CSIVolumeAccessModeSingleNodeWriter,
+	}
+	capacity := &csi.CapacityRange{RequiredBytes: 2048, LimitBytes: 4096}
+
+	cases := []struct {
+		Name            string
+		RequiresStaging bool
+		PluginErr       error
+		ExpectedErr     error
+	}{
+		{
+			Name:            "Returns an error when the plugin returns an error",
+			RequiresStaging: true,
+			PluginErr:       errors.New("Some Unknown Error"),
+			ExpectedErr:     errors.New("Some Unknown Error"),
+		},
+		{
+			Name:            "Happy Path with staging",
+			RequiresStaging: true,
+		},
+		{
+			Name:            "Happy Path without staging",
+			RequiresStaging: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tmpPath := tmpDir(t)
+			defer os.RemoveAll(tmpPath)
+
+			csiFake := &csifake.Client{}
+			csiFake.NextNodeExpandVolumeErr = tc.PluginErr
+			csiFake.NextNodeExpandVolumeResponse = &csi.NodeExpandVolumeResponse{CapacityBytes: 2048}
+
+			var events []*structs.NodeEvent
+			eventer := func(e *structs.NodeEvent) { events = append(events, e) }
+			manager := newVolumeManager(testlog.HCLogger(t), eventer, csiFake, tmpPath, tmpPath, tc.RequiresStaging)
+
+			allocID := uuid.Generate()
+			got, err := manager.ExpandVolume(context.Background(), "foo", "foo-remote", allocID, usage, capacity)
+			require.Equal(t, int64(1), csiFake.NodeExpandVolumeCallCount)
+			require.Len(t, events, 1)
+			require.Equal(t, "Expand volume", events[0].Message)
+
+			if tc.ExpectedErr != nil {
+				require.EqualError(t, err, tc.ExpectedErr.Error())
+				require.Equal(t, "false", events[0].Details["success"])
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(2048), got)
+
+			req := csiFake.PrevNodeExpandVolumeRequest
+			require.Equal(t, "foo-remote", req.ExternalVolumeID)
+			require.Equal(t, capacity, req.Capacity)
+			require.Equal(t, manager.targetForVolume(tmpPath, "foo", allocID, usage), req.TargetPath)
+			if tc.RequiresStaging {
+				require.Equal(t, manager.stagingDirForVolume(tmpPath, "foo", usage), req.StagingTargetPath)
+			} else {
+				require.Empty(t, req.StagingTargetPath)
+			}
+		})
+	}
+}
+
 func TestVolumeManager_MountVolumeEvents(t *testing.T) {
 	if !checkMountSupport() {
 		t.Skip("mount point detection not supported for this platform")
@@ -107,6 +107,10 @@ type instanceManager struct {
 	// lastHealthState is the last known health fingerprinted by the manager
 	lastHealthState   drivers.HealthState
 	lastHealthStateMu sync.Mutex
+
+	// resize is whether the driver supports resizing running tasks. It is
+	// refreshed each time a driver is dispensed for fingerprinting.
+	resize bool
 }
 
 // newInstanceManager returns a new driver instance manager. It is expected that
@@ -268,6 +272,13 @@ func (i *instanceManager) dispenseFingerprintCh() (<-chan *drivers.Fingerprint,
 		return nil, nil, err
 	}
 
+	i.resize = false
+	if caps, err := driver.Capabilities(); err != nil {
+		i.logger.Warn("failed to get driver capabilities", "error", err)
+	} else {
+		i.resize = caps.Resize
+	}
+
 	ctx, cancel := context.WithCancel(i.ctx)
 	fingerCh, err := driver.Fingerprint(ctx)
 	if err != nil {
@@ -362,6 +373,9 @@ func (i *instanceManager) handleFingerprint(fp *drivers.Fingerprint) {
 	for key, attr := range fp.Attributes {
 		attrs[key] = attr.GoString()
 	}
+	if i.resize && fp.Health != drivers.HealthStateUndetected {
+		attrs[structs.DriverResizeAttribute(i.id.Name)] = "true"
+	}
 	di := &structs.DriverInfo{
 		Attributes:        attrs,
 		Detected:          fp.Health != drivers.HealthStateUndetected,
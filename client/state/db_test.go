@@ -12,6 +12,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -379,6 +380,44 @@ func TestStateDB_DynamicRegistry(t *testing.T) {
 	})
 }
 
+// TestStateDB_DynamicHostVolumes asserts the behavior of dynamic host volume
+// related StateDB methods.
+func TestStateDB_DynamicHostVolumes(t *testing.T) {
+	t.Parallel()
+
+	testDB(t, func(t *testing.T, db StateDB) {
+		require := require.New(t)
+
+		// Getting nonexistent state should return nothing
+		vols, err := db.GetDynamicHostVolumes()
+		require.NoError(err)
+		require.Empty(vols)
+
+		vol := &cstructs.HostVolumeState{
+			ID: "vol-1",
+			CreateReq: &cstructs.ClientHostVolumeCreateRequest{
+				ID:       "vol-1",
+				Name:     "data",
+				PluginID: "mkdir",
+				UID:      -1,
+				GID:      -1,
+			},
+			HostPath: "/var/nomad/host_volumes/vol-1",
+		}
+		require.NoError(db.PutDynamicHostVolume(vol))
+
+		vols, err = db.GetDynamicHostVolumes()
+		require.NoError(err)
+		require.Equal([]*cstructs.HostVolumeState{vol}, vols)
+
+		// Deleting should remove the volume
+		require.NoError(db.DeleteDynamicHostVolume(vol.ID))
+		vols, err = db.GetDynamicHostVolumes()
+		require.NoError(err)
+		require.Empty(vols)
+	})
+}
+
 // TestStateDB_Upgrade asserts calling Upgrade on new databases always
 // succeeds.
 func TestStateDB_Upgrade(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -80,6 +81,18 @@ func (m *ErrDB) PutDynamicPluginRegistryState(state *dynamicplugins.RegistryStat
 	return fmt.Errorf("Error!")
 }
 
+func (m *ErrDB) PutDynamicHostVolume(_ *cstructs.HostVolumeState) error {
+	return fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	return nil, fmt.Errorf("Error!")
+}
+
+func (m *ErrDB) DeleteDynamicHostVolume(_ string) error {
+	return fmt.Errorf("Error!")
+}
+
 // GetDevicePluginState stores the device manager's plugin state or returns an
 // error.
 func (m *ErrDB) GetDevicePluginState() (*dmstate.PluginState, error) {
@@ -5,6 +5,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -81,6 +82,18 @@ type StateDB interface {
 	// PutDynamicPluginRegistryState is used to store the dynamic plugin manager's state.
 	PutDynamicPluginRegistryState(state *dynamicplugins.RegistryState) error
 
+	// PutDynamicHostVolume is used to store the state of a dynamic host
+	// volume created on the client.
+	PutDynamicHostVolume(*cstructs.HostVolumeState) error
+
+	// GetDynamicHostVolumes is used to retrieve the state of every dynamic
+	// host volume created on the client.
+	GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error)
+
+	// DeleteDynamicHostVolume is used to delete the state of a dynamic host
+	// volume.
+	DeleteDynamicHostVolume(string) error
+
 	// Close the database. Unsafe for further use after calling regardless
 	// of return value.
 	Close() error
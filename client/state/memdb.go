@@ -8,6 +8,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -36,6 +37,9 @@ type MemDB struct {
 	// dynamicmanager -> registry-state
 	dynamicManagerPs *dynamicplugins.RegistryState
 
+	// volume_id -> value
+	dynamicHostVolumes map[string]*cstructs.HostVolumeState
+
 	logger hclog.Logger
 
 	mu sync.RWMutex
@@ -44,12 +48,13 @@ type MemDB struct {
 func NewMemDB(logger hclog.Logger) *MemDB {
 	logger = logger.Named("memdb")
 	return &MemDB{
-		allocs:         make(map[string]*structs.Allocation),
-		deployStatus:   make(map[string]*structs.AllocDeploymentStatus),
-		networkStatus:  make(map[string]*structs.AllocNetworkStatus),
-		localTaskState: make(map[string]map[string]*state.LocalState),
-		taskState:      make(map[string]map[string]*structs.TaskState),
-		logger:         logger,
+		allocs:             make(map[string]*structs.Allocation),
+		deployStatus:       make(map[string]*structs.AllocDeploymentStatus),
+		networkStatus:      make(map[string]*structs.AllocNetworkStatus),
+		localTaskState:     make(map[string]map[string]*state.LocalState),
+		taskState:          make(map[string]map[string]*structs.TaskState),
+		dynamicHostVolumes: make(map[string]*cstructs.HostVolumeState),
+		logger:             logger,
 	}
 }
 
@@ -227,6 +232,30 @@ func (m *MemDB) PutDynamicPluginRegistryState(ps *dynamicplugins.RegistryState)
 	return nil
 }
 
+func (m *MemDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dynamicHostVolumes[vol.ID] = vol
+	return nil
+}
+
+func (m *MemDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	vols := make([]*cstructs.HostVolumeState, 0, len(m.dynamicHostVolumes))
+	for _, vol := range m.dynamicHostVolumes {
+		vols = append(vols, vol)
+	}
+	return vols, nil
+}
+
+func (m *MemDB) DeleteDynamicHostVolume(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.dynamicHostVolumes, id)
+	return nil
+}
+
 func (m *MemDB) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -5,6 +5,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -87,6 +88,18 @@ func (n NoopDB) GetDynamicPluginRegistryState() (*dynamicplugins.RegistryState,
 	return nil, nil
 }
 
+func (n NoopDB) PutDynamicHostVolume(_ *cstructs.HostVolumeState) error {
+	return nil
+}
+
+func (n NoopDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	return nil, nil
+}
+
+func (n NoopDB) DeleteDynamicHostVolume(_ string) error {
+	return nil
+}
+
 func (n NoopDB) Close() error {
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	dmstate "github.com/hashicorp/nomad/client/devicemanager/state"
 	"github.com/hashicorp/nomad/client/dynamicplugins"
 	driverstate "github.com/hashicorp/nomad/client/pluginmanager/drivermanager/state"
+	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/boltdd"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -96,6 +97,10 @@ var (
 
 	// registryStateKey is the key at which dynamic plugin registry state is stored
 	registryStateKey = []byte("registry_state")
+
+	// dynamicHostVolumesBucket is the bucket name containing the state of
+	// all dynamic host volumes, keyed by volume ID
+	dynamicHostVolumesBucket = []byte("dynamic_host_volumes")
 )
 
 // taskBucketName returns the bucket name for the given task name.
@@ -672,6 +677,60 @@ func (s *BoltStateDB) GetDriverPluginState() (*driverstate.PluginState, error) {
 	return ps, nil
 }
 
+// PutDynamicHostVolume stores the state of a dynamic host volume or returns
+// an error.
+func (s *BoltStateDB) PutDynamicHostVolume(vol *cstructs.HostVolumeState) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		volBkt, err := tx.CreateBucketIfNotExists(dynamicHostVolumesBucket)
+		if err != nil {
+			return err
+		}
+		return volBkt.Put([]byte(vol.ID), vol)
+	})
+}
+
+// GetDynamicHostVolumes retrieves the state of every dynamic host volume or
+// returns an error.
+func (s *BoltStateDB) GetDynamicHostVolumes() ([]*cstructs.HostVolumeState, error) {
+	var vols []*cstructs.HostVolumeState
+
+	err := s.db.View(func(tx *boltdd.Tx) error {
+		volBkt := tx.Bucket(dynamicHostVolumesBucket)
+		if volBkt == nil {
+			// No volumes
+			return nil
+		}
+
+		c := volBkt.BoltBucket().Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			vol := &cstructs.HostVolumeState{}
+			if err := volBkt.Get(k, vol); err != nil {
+				return fmt.Errorf("failed to read dynamic host volume %s: %v", string(k), err)
+			}
+			vols = append(vols, vol)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return vols, nil
+}
+
+// DeleteDynamicHostVolume deletes the state of a dynamic host volume or
+// returns an error.
+func (s *BoltStateDB) DeleteDynamicHostVolume(id string) error {
+	return s.db.Update(func(tx *boltdd.Tx) error {
+		volBkt := tx.Bucket(dynamicHostVolumesBucket)
+		if volBkt == nil {
+			return nil
+		}
+		return volBkt.Delete([]byte(id))
+	})
+}
+
 // PutDynamicPluginRegistryState stores the dynamic plugin registry's
 // state or returns an error.
 func (s *BoltStateDB) PutDynamicPluginRegistryState(ps *dynamicplugins.RegistryState) error {
@@ -220,8 +220,7 @@ type ClientCSIControllerCreateVolumeRequest struct {
 	CapacityMax        int64
 	SnapshotID         string
 	CloneID            string
-	// TODO: topology is not yet supported
-	// TopologyRequirement
+	Requirements       *structs.CSITopologyRequest
 
 	CSIControllerQuery
 }
@@ -241,9 +240,18 @@ func (req *ClientCSIControllerCreateVolumeRequest) ToCSIRequest() (*csi.Controll
 			CloneID:    req.CloneID,
 			SnapshotID: req.SnapshotID,
 		},
-		// TODO: topology is not yet supported
 		AccessibilityRequirements: &csi.TopologyRequirement{},
 	}
+	if req.Requirements != nil {
+		for _, t := range req.Requirements.Required {
+			creq.AccessibilityRequirements.Requisite = append(
+				creq.AccessibilityRequirements.Requisite, &csi.Topology{Segments: t.Segments})
+		}
+		for _, t := range req.Requirements.Preferred {
+			creq.AccessibilityRequirements.Preferred = append(
+				creq.AccessibilityRequirements.Preferred, &csi.Topology{Segments: t.Segments})
+		}
+	}
 	for _, cap := range req.VolumeCapabilities {
 		ccap, err := csi.VolumeCapabilityFromStructs(cap.AttachmentMode, cap.AccessMode, req.MountOptions)
 		if err != nil {
@@ -258,9 +266,7 @@ type ClientCSIControllerCreateVolumeResponse struct {
 	ExternalVolumeID string
 	CapacityBytes    int64
 	VolumeContext    map[string]string
-
-	// TODO: topology is not yet supported
-	// AccessibleTopology []*Topology
+	Topologies       []*structs.CSITopology
 }
 
 // ClientCSIControllerDeleteVolumeRequest the RPC made from the server to a
@@ -282,6 +288,51 @@ func (req *ClientCSIControllerDeleteVolumeRequest) ToCSIRequest() *csi.Controlle
 
 type ClientCSIControllerDeleteVolumeResponse struct{}
 
+// ClientCSIControllerExpandVolumeRequest the RPC made from the server to a
+// Nomad client to tell a CSI controller plugin on that client to perform
+// ControllerExpandVolume
+type ClientCSIControllerExpandVolumeRequest struct {
+	ExternalVolumeID string
+	CapacityMin      int64
+	CapacityMax      int64
+	Secrets          structs.CSISecrets
+
+	// These fields are used to build the capability of the volume to be
+	// expanded
+	AttachmentMode structs.CSIVolumeAttachmentMode
+	AccessMode     structs.CSIVolumeAccessMode
+	MountOptions   *structs.CSIMountOptions
+
+	CSIControllerQuery
+}
+
+func (req *ClientCSIControllerExpandVolumeRequest) ToCSIRequest() (*csi.ControllerExpandVolumeRequest, error) {
+	creq := &csi.ControllerExpandVolumeRequest{
+		ExternalVolumeID: req.ExternalVolumeID,
+		Capacity: &csi.CapacityRange{
+			RequiredBytes: req.CapacityMin,
+			LimitBytes:    req.CapacityMax,
+		},
+		Secrets: req.Secrets,
+	}
+
+	// the capability is optional, so only set it if the volume has a
+	// current attachment and access mode
+	if req.AttachmentMode != "" && req.AccessMode != "" {
+		capability, err := csi.VolumeCapabilityFromStructs(req.AttachmentMode, req.AccessMode, req.MountOptions)
+		if err != nil {
+			return nil, err
+		}
+		creq.Capability = capability
+	}
+	return creq, nil
+}
+
+type ClientCSIControllerExpandVolumeResponse struct {
+	CapacityBytes         int64
+	NodeExpansionRequired bool
+}
+
 // ClientCSIControllerListVolumesVolumeRequest the RPC made from the server to
 // a Nomad client to tell a CSI controller plugin on that client to perform
 // ListVolumes
@@ -398,3 +449,28 @@ type ClientCSINodeDetachVolumeRequest struct {
 }
 
 type ClientCSINodeDetachVolumeResponse struct{}
+
+// ClientCSINodeExpandVolumeRequest is the RPC made from the server to a
+// Nomad client to tell a CSI node plugin on that client to perform
+// NodeExpandVolume for a volume claimed by an allocation on that client.
+type ClientCSINodeExpandVolumeRequest struct {
+	PluginID   string // ID of the plugin that manages the volume (required)
+	VolumeID   string // ID of the volume to be expanded (required)
+	AllocID    string // ID of the allocation that claims the volume (required)
+	NodeID     string // ID of the Nomad client targeted
+	ExternalID string // External ID of the volume to be expanded (required)
+
+	CapacityMin int64
+	CapacityMax int64
+
+	// These fields should match the original volume request so that
+	// we can find the mount points on the client
+	AttachmentMode structs.CSIVolumeAttachmentMode
+	AccessMode     structs.CSIVolumeAccessMode
+	MountOptions   *structs.CSIMountOptions
+	ReadOnly       bool
+}
+
+type ClientCSINodeExpandVolumeResponse struct {
+	CapacityBytes int64
+}
@@ -0,0 +1,64 @@
+package structs
+
+// ClientHostVolumeCreateRequest is the RPC made from the server to a Nomad
+// client to create a dynamic host volume with a host volume plugin.
+type ClientHostVolumeCreateRequest struct {
+	// ID is a UUID-format ID for the volume, generated by the server
+	ID string
+
+	// Name is the name the volume is fingerprinted with on the node, and
+	// which jobs use as the source of their host volumes
+	Name string
+
+	// PluginID is the name of the plugin that creates the volume
+	PluginID string
+
+	// NodeID is the node where the volume is created
+	NodeID string
+
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+
+	// Parameters are passed to the plugin
+	Parameters map[string]string
+
+	// UID, GID and Mode set the ownership and permissions of the volume's
+	// directory after the plugin creates it
+	UID  int
+	GID  int
+	Mode string
+}
+
+type ClientHostVolumeCreateResponse struct {
+	// HostPath is the path of the volume on the node
+	HostPath string
+
+	// CapacityBytes is the capacity reported by the plugin, which may be
+	// zero if the plugin can't enforce a capacity
+	CapacityBytes int64
+}
+
+// ClientHostVolumeDeleteRequest is the RPC made from the server to a Nomad
+// client to delete a dynamic host volume.
+type ClientHostVolumeDeleteRequest struct {
+	ID       string
+	Name     string
+	PluginID string
+	NodeID   string
+
+	// HostPath is the path of the volume on the node
+	HostPath string
+
+	// Parameters are passed to the plugin
+	Parameters map[string]string
+}
+
+type ClientHostVolumeDeleteResponse struct{}
+
+// HostVolumeState is the state of a dynamic host volume persisted by the
+// client, so that the volume can be fingerprinted again after a restart.
+type HostVolumeState struct {
+	ID        string
+	CreateReq *ClientHostVolumeCreateRequest
+	HostPath  string
+}
@@ -257,11 +257,39 @@ func (cs *CpuStats) Add(other *CpuStats) {
 	cs.Measured = joinStringSet(cs.Measured, other.Measured)
 }
 
+// DiskStats holds the ephemeral disk usage of an allocation in bytes. It is
+// only reported when the client enforces ephemeral disk sizes.
+type DiskStats struct {
+	Used  uint64
+	Limit uint64
+}
+
+// NetworkStats holds the network usage of an allocation with its own network
+// namespace. Rates are averaged over the stats collection interval.
+type NetworkStats struct {
+	RxBytes uint64
+	TxBytes uint64
+	RxMBits float64
+	TxMBits float64
+
+	// LimitMBits is the enforced bandwidth limit, or zero if the client
+	// doesn't enforce bandwidth limits.
+	LimitMBits int
+}
+
 // ResourceUsage holds information related to cpu and memory stats
 type ResourceUsage struct {
 	MemoryStats *MemoryStats
 	CpuStats    *CpuStats
 	DeviceStats []*device.DeviceGroupStats
+
+	// DiskStats is shared by all tasks of an allocation, so it isn't
+	// summed by Add.
+	DiskStats *DiskStats
+
+	// NetworkStats is shared by all tasks of an allocation, so it isn't
+	// summed by Add.
+	NetworkStats *NetworkStats
 }
 
 func (ru *ResourceUsage) Add(other *ResourceUsage) {
@@ -994,7 +994,7 @@ func (b *Builder) SetVaultToken(token, namespace string, inject bool) *Builder {
 // addPort keys and values for other tasks to an env var map
 func addPort(m map[string]string, taskName, ip, portLabel string, port int) {
 	key := fmt.Sprintf("%s%s_%s", AddrPrefix, taskName, portLabel)
-	m[key] = fmt.Sprintf("%s:%d", ip, port)
+	m[key] = net.JoinHostPort(ip, strconv.Itoa(port))
 	key = fmt.Sprintf("%s%s_%s", IpPrefix, taskName, portLabel)
 	m[key] = ip
 	key = fmt.Sprintf("%s%s_%s", PortPrefix, taskName, portLabel)
@@ -1015,8 +1015,9 @@ func addGroupPort(m map[string]string, port structs.Port) {
 
 func addPorts(m map[string]string, ports structs.AllocatedPorts) {
 	for _, p := range ports {
-		m[AddrPrefix+p.Label] = fmt.Sprintf("%s:%d", p.HostIP, p.Value)
-		m[HostAddrPrefix+p.Label] = fmt.Sprintf("%s:%d", p.HostIP, p.Value)
+		addr := net.JoinHostPort(p.HostIP, strconv.Itoa(p.Value))
+		m[AddrPrefix+p.Label] = addr
+		m[HostAddrPrefix+p.Label] = addr
 		m[IpPrefix+p.Label] = p.HostIP
 		m[HostIpPrefix+p.Label] = p.HostIP
 		if p.To > 0 {
@@ -739,6 +739,32 @@ func TestEnvironment_Upstreams(t *testing.T) {
 	require.Equal(t, "1234", env["bar"])
 }
 
+func TestEnvironment_IPv6Ports(t *testing.T) {
+	t.Parallel()
+
+	a := mock.Alloc()
+	a.AllocatedResources.Shared.Ports = structs.AllocatedPorts{
+		{
+			Label:  "http",
+			Value:  25000,
+			To:     8080,
+			HostIP: "2001:db8::10",
+		},
+		{
+			Label:  "admin",
+			Value:  25001,
+			HostIP: "2001:db8::10",
+		},
+	}
+	task := a.Job.TaskGroups[0].Tasks[0]
+
+	env := NewBuilder(mock.Node(), a, task, "global").Build().Map()
+	require.Equal(t, "[2001:db8::10]:25000", env["NOMAD_ADDR_http"])
+	require.Equal(t, "[2001:db8::10]:25000", env["NOMAD_HOST_ADDR_http"])
+	require.Equal(t, "2001:db8::10", env["NOMAD_IP_http"])
+	require.Equal(t, "[2001:db8::10]:25001", env["NOMAD_ADDR_admin"])
+}
+
 func TestEnvironment_SetPortMapEnvs(t *testing.T) {
 	envs := map[string]string{
 		"foo":            "bar",
@@ -23,6 +23,7 @@ import (
 	uuidparse "github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/nomad/client"
 	clientconfig "github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/client/lib/diskquota"
 	"github.com/hashicorp/nomad/client/state"
 	"github.com/hashicorp/nomad/command/agent/consul"
 	"github.com/hashicorp/nomad/command/agent/event"
@@ -240,6 +241,9 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	if agentConfig.Server.NonVotingServer {
 		conf.NonVoter = true
 	}
+	if agentConfig.Server.EvalNamespaceFairness {
+		conf.EvalNamespaceFairness = true
+	}
 	if agentConfig.Server.RedundancyZone != "" {
 		conf.RedundancyZone = agentConfig.Server.RedundancyZone
 	}
@@ -429,6 +433,7 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 	} else {
 		return nil, fmt.Errorf("deploy_query_rate_limit must be greater than 0")
 	}
+	conf.DeploymentAnalysisAddresses = agentConfig.Server.DeploymentAnalysisAddresses
 
 	// Add Enterprise license configs
 	conf.LicenseEnv = agentConfig.Server.LicenseEnv
@@ -559,6 +564,8 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.DataDir != "" {
 		conf.StateDir = filepath.Join(agentConfig.DataDir, "client")
 		conf.AllocDir = filepath.Join(agentConfig.DataDir, "alloc")
+		conf.HostVolumesDir = filepath.Join(agentConfig.DataDir, "host_volumes")
+		conf.HostVolumePluginDir = filepath.Join(agentConfig.DataDir, "host_volume_plugins")
 	}
 	if agentConfig.Client.StateDir != "" {
 		conf.StateDir = agentConfig.Client.StateDir
@@ -566,6 +573,12 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	if agentConfig.Client.AllocDir != "" {
 		conf.AllocDir = agentConfig.Client.AllocDir
 	}
+	if agentConfig.Client.HostVolumesDir != "" {
+		conf.HostVolumesDir = agentConfig.Client.HostVolumesDir
+	}
+	if agentConfig.Client.HostVolumePluginDir != "" {
+		conf.HostVolumePluginDir = agentConfig.Client.HostVolumePluginDir
+	}
 	if agentConfig.Client.NetworkInterface != "" {
 		conf.NetworkInterface = agentConfig.Client.NetworkInterface
 	}
@@ -597,6 +610,32 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.TemplateConfig.DisableSandbox = agentConfig.Client.TemplateConfig.DisableSandbox
 
+	if dq := agentConfig.Client.DiskQuota; dq != nil && dq.Enabled {
+		mode := dq.Mode
+		if mode == "" {
+			mode = diskquota.ModeAuto
+		}
+		if err := diskquota.ValidateMode(mode); err != nil {
+			return nil, err
+		}
+
+		policy := dq.Policy
+		switch policy {
+		case "":
+			policy = clientconfig.DiskQuotaPolicyEvent
+		case clientconfig.DiskQuotaPolicyEvent, clientconfig.DiskQuotaPolicyKill:
+		default:
+			return nil, fmt.Errorf("invalid disk quota policy %q, must be one of %q or %q",
+				policy, clientconfig.DiskQuotaPolicyEvent, clientconfig.DiskQuotaPolicyKill)
+		}
+
+		conf.DiskQuota = &clientconfig.DiskQuotaConfig{
+			Mode:          mode,
+			Policy:        policy,
+			CheckInterval: clientconfig.DefaultDiskQuotaCheckInterval,
+		}
+	}
+
 	hvMap := make(map[string]*structs.ClientHostVolumeConfig, len(agentConfig.Client.HostVolumes))
 	for _, v := range agentConfig.Client.HostVolumes {
 		hvMap[v.Name] = v
@@ -685,11 +724,13 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	conf.CNIConfigDir = agentConfig.Client.CNIConfigDir
 	conf.BridgeNetworkName = agentConfig.Client.BridgeNetworkName
 	conf.BridgeNetworkAllocSubnet = agentConfig.Client.BridgeNetworkSubnet
+	conf.BridgeNetworkAllocSubnetIPv6 = agentConfig.Client.BridgeNetworkSubnetIPv6
 
 	for _, hn := range agentConfig.Client.HostNetworks {
 		conf.HostNetworks[hn.Name] = hn
 	}
 	conf.BindWildcardDefaultHostNetwork = agentConfig.Client.BindWildcardDefaultHostNetwork
+	conf.EnforceNetworkBandwidth = agentConfig.Client.EnforceNetworkBandwidth
 
 	conf.CgroupParent = agentConfig.Client.CgroupParent
 	if agentConfig.Client.ReserveableCores != "" {
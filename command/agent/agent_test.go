@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	clientconfig "github.com/hashicorp/nomad/client/config"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -529,6 +530,71 @@ func TestAgent_ClientConfig_ReservedCores(t *testing.T) {
 	require.Exactly(t, []uint16{0, 2, 3}, c.Node.ReservedResources.Cpu.ReservedCpuCores)
 }
 
+func TestAgent_ClientConfig_DiskQuota(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    *ClientDiskQuotaConfig
+		expected *clientconfig.DiskQuotaConfig
+		err      string
+	}{
+		{
+			name: "unset",
+		},
+		{
+			name:  "disabled",
+			input: &ClientDiskQuotaConfig{Mode: "project"},
+		},
+		{
+			name:  "defaults",
+			input: &ClientDiskQuotaConfig{Enabled: true},
+			expected: &clientconfig.DiskQuotaConfig{
+				Mode:          "auto",
+				Policy:        "event",
+				CheckInterval: clientconfig.DefaultDiskQuotaCheckInterval,
+			},
+		},
+		{
+			name:  "explicit",
+			input: &ClientDiskQuotaConfig{Enabled: true, Mode: "loopback", Policy: "kill"},
+			expected: &clientconfig.DiskQuotaConfig{
+				Mode:          "loopback",
+				Policy:        "kill",
+				CheckInterval: clientconfig.DefaultDiskQuotaCheckInterval,
+			},
+		},
+		{
+			name:  "invalid mode",
+			input: &ClientDiskQuotaConfig{Enabled: true, Mode: "zfs"},
+			err:   "invalid disk quota mode",
+		},
+		{
+			name:  "invalid policy",
+			input: &ClientDiskQuotaConfig{Enabled: true, Policy: "evict"},
+			err:   "invalid disk quota policy",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := DefaultConfig()
+			conf.Client.Enabled = true
+			conf.Client.DiskQuota = tc.input
+			a := &Agent{config: conf}
+
+			c, err := a.clientConfig()
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, c.DiskQuota)
+		})
+	}
+}
+
 // Clients should inherit telemetry configuration
 func TestAgent_Client_TelemetryConfiguration(t *testing.T) {
 	assert := assert.New(t)
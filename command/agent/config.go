@@ -187,6 +187,14 @@ type ClientConfig struct {
 	// AllocDir is the directory for storing allocation data
 	AllocDir string `hcl:"alloc_dir"`
 
+	// HostVolumesDir is the directory where dynamic host volumes are created
+	// by the builtin "mkdir" plugin
+	HostVolumesDir string `hcl:"host_volumes_dir"`
+
+	// HostVolumePluginDir is the directory of the plugins for dynamic host
+	// volumes
+	HostVolumePluginDir string `hcl:"host_volume_plugin_dir"`
+
 	// Servers is a list of known server addresses. These are as "host:port"
 	Servers []string `hcl:"servers"`
 
@@ -293,6 +301,11 @@ type ClientConfig struct {
 	// the host
 	BridgeNetworkSubnet string `hcl:"bridge_network_subnet"`
 
+	// BridgeNetworkSubnetIPv6 is an IPv6 subnet to allocate addresses from
+	// in addition to BridgeNetworkSubnet, for dual-stack bridge networking.
+	// This range is local to the host
+	BridgeNetworkSubnetIPv6 string `hcl:"bridge_network_subnet_ipv6"`
+
 	// HostNetworks describes the different host networks available to the host
 	// if the host uses multiple interfaces
 	HostNetworks []*structs.ClientHostNetworkConfig `hcl:"host_network"`
@@ -302,10 +315,18 @@ type ClientConfig struct {
 	// matching any destination address (true). Defaults to true
 	BindWildcardDefaultHostNetwork bool `hcl:"bind_wildcard_default_host_network"`
 
+	// EnforceNetworkBandwidth limits the bandwidth of allocations in bridge
+	// and CNI network modes to the MBits they requested. Defaults to false
+	EnforceNetworkBandwidth bool `hcl:"enforce_network_bandwidth"`
+
 	// CgroupParent sets the parent cgroup for subsystems managed by Nomad. If the cgroup
 	// doest not exist Nomad will attempt to create it during startup. Defaults to '/nomad'
 	CgroupParent string `hcl:"cgroup_parent"`
 
+	// DiskQuota configures the enforcement of the ephemeral disk size of
+	// allocations
+	DiskQuota *ClientDiskQuotaConfig `hcl:"disk_quota"`
+
 	// ExtraKeysHCL is used by hcl to surface unexpected keys
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
@@ -328,6 +349,23 @@ type ClientTemplateConfig struct {
 	DisableSandbox bool `hcl:"disable_file_sandbox"`
 }
 
+// ClientDiskQuotaConfig is configuration on the client specific to limiting
+// allocation directories to the size of their ephemeral disk
+type ClientDiskQuotaConfig struct {
+	// Enabled turns on the enforcement of ephemeral disk sizes.
+	Enabled bool `hcl:"enabled"`
+
+	// Mode is how directories are limited: "auto", "project" or
+	// "loopback". Defaults to "auto", which uses project quotas where the
+	// filesystem supports them and loopback images otherwise.
+	Mode string `hcl:"mode"`
+
+	// Policy is the action taken when an allocation fills its ephemeral
+	// disk: "event" only emits a task event, "kill" also kills the tasks.
+	// Defaults to "event".
+	Policy string `hcl:"policy"`
+}
+
 // ACLConfig is configuration specific to the ACL system
 type ACLConfig struct {
 	// Enabled controls if we are enforce and manage ACLs
@@ -411,6 +449,11 @@ type ServerConfig struct {
 	// can be used to filter by age.
 	EvalGCThreshold string `hcl:"eval_gc_threshold"`
 
+	// EvalNamespaceFairness dequeues evaluations of equal priority fairly
+	// across namespaces, in proportion to the namespace weights, rather than
+	// in the order they were created.
+	EvalNamespaceFairness bool `hcl:"eval_namespace_fairness"`
+
 	// DeploymentGCThreshold controls how "old" a deployment must be to be
 	// collected by GC.  Age is not the only requirement for a deployment to be
 	// GCed but the threshold can be used to filter by age.
@@ -520,6 +563,10 @@ type ServerConfig struct {
 	// DeploymentQueryRateLimit is in queries per second and is used by the
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64 `hcl:"deploy_query_rate_limit"`
+
+	// DeploymentAnalysisAddresses are the metrics server addresses job
+	// update blocks may query to analyze their deployments
+	DeploymentAnalysisAddresses []string `hcl:"deployment_analysis_addresses"`
 }
 
 // Search is used in servers to configure search API options.
@@ -1460,6 +1507,9 @@ func (a *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.EvalGCThreshold != "" {
 		result.EvalGCThreshold = b.EvalGCThreshold
 	}
+	if b.EvalNamespaceFairness {
+		result.EvalNamespaceFairness = true
+	}
 	if b.DeploymentGCThreshold != "" {
 		result.DeploymentGCThreshold = b.DeploymentGCThreshold
 	}
@@ -1532,6 +1582,10 @@ func (a *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		result.DeploymentQueryRateLimit = b.DeploymentQueryRateLimit
 	}
 
+	if len(b.DeploymentAnalysisAddresses) != 0 {
+		result.DeploymentAnalysisAddresses = append(result.DeploymentAnalysisAddresses, b.DeploymentAnalysisAddresses...)
+	}
+
 	if b.Search != nil {
 		result.Search = &Search{FuzzyEnabled: b.Search.FuzzyEnabled}
 		if b.Search.LimitQuery > 0 {
@@ -1574,6 +1628,12 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.AllocDir != "" {
 		result.AllocDir = b.AllocDir
 	}
+	if b.HostVolumesDir != "" {
+		result.HostVolumesDir = b.HostVolumesDir
+	}
+	if b.HostVolumePluginDir != "" {
+		result.HostVolumePluginDir = b.HostVolumePluginDir
+	}
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
@@ -1635,6 +1695,10 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.TemplateConfig = b.TemplateConfig
 	}
 
+	if b.DiskQuota != nil {
+		result.DiskQuota = b.DiskQuota
+	}
+
 	// Add the servers
 	result.Servers = append(result.Servers, b.Servers...)
 
@@ -1684,6 +1748,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.BridgeNetworkSubnet != "" {
 		result.BridgeNetworkSubnet = b.BridgeNetworkSubnet
 	}
+	if b.BridgeNetworkSubnetIPv6 != "" {
+		result.BridgeNetworkSubnetIPv6 = b.BridgeNetworkSubnetIPv6
+	}
 
 	result.HostNetworks = a.HostNetworks
 
@@ -1694,6 +1761,10 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.BindWildcardDefaultHostNetwork {
 		result.BindWildcardDefaultHostNetwork = true
 	}
+
+	if b.EnforceNetworkBandwidth {
+		result.EnforceNetworkBandwidth = true
+	}
 	return &result
 }
 
@@ -86,9 +86,16 @@ var basicConfig = &Config{
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
-		CNIPath:             "/tmp/cni_path",
-		BridgeNetworkName:   "custom_bridge_name",
-		BridgeNetworkSubnet: "custom_bridge_subnet",
+		CNIPath:                 "/tmp/cni_path",
+		BridgeNetworkName:       "custom_bridge_name",
+		BridgeNetworkSubnet:     "custom_bridge_subnet",
+		BridgeNetworkSubnetIPv6: "custom_bridge_subnet_ipv6",
+		EnforceNetworkBandwidth: true,
+		DiskQuota: &ClientDiskQuotaConfig{
+			Enabled: true,
+			Mode:    "loopback",
+			Policy:  "kill",
+		},
 	},
 	Server: &ServerConfig{
 		Enabled:                   true,
@@ -102,6 +109,7 @@ var basicConfig = &Config{
 		EnabledSchedulers:         []string{"test"},
 		NodeGCThreshold:           "12h",
 		EvalGCThreshold:           "12h",
+		EvalNamespaceFairness:     true,
 		JobGCInterval:             "3m",
 		JobGCThreshold:            "12h",
 		DeploymentGCThreshold:     "12h",
@@ -137,6 +145,10 @@ var basicConfig = &Config{
 				BatchSchedulerEnabled:   true,
 				ServiceSchedulerEnabled: true,
 			},
+			Scoring: &structs.ScoringConfig{
+				FitFunction:  structs.FitFunctionDominantResource,
+				MemoryWeight: 2,
+			},
 		},
 		LicensePath: "/tmp/nomad.hclic",
 	},
@@ -77,7 +77,7 @@ func BuildAllocServices(node *structs.Node, alloc *structs.Allocation, restarter
 	}
 
 	if alloc.DeploymentStatus != nil {
-		ws.Canary = alloc.DeploymentStatus.Canary
+		ws.Canary = alloc.DeploymentStatus.UsesCanaryTags()
 	}
 
 	return ws
@@ -18,13 +18,15 @@ func (s *HTTPServer) CSIVolumesRequest(resp http.ResponseWriter, req *http.Reque
 		return nil, CodedError(405, ErrInvalidMethod)
 	}
 
-	// Type filters volume lists to a specific type. When support for non-CSI volumes is
-	// introduced, we'll need to dispatch here
+	// Type filters volume lists to a specific type
 	query := req.URL.Query()
 	qtype, ok := query["type"]
 	if !ok {
 		return []*structs.CSIVolListStub{}, nil
 	}
+	if qtype[0] == structs.VolumeTypeHost {
+		return s.hostVolumesList(resp, req)
+	}
 	if qtype[0] != "csi" {
 		return nil, nil
 	}
@@ -98,6 +100,9 @@ func (s *HTTPServer) CSIVolumeSpecificRequest(resp http.ResponseWriter, req *htt
 			if tokens[1] == "create" {
 				return s.csiVolumeCreate(resp, req)
 			}
+			if tokens[1] == "reclaim" {
+				return s.csiVolumeReclaim(id, resp, req)
+			}
 		case http.MethodDelete:
 			if tokens[1] == "detach" {
 				return s.csiVolumeDetach(id, resp, req)
@@ -266,6 +271,33 @@ func (s *HTTPServer) csiVolumeDetach(id string, resp http.ResponseWriter, req *h
 	return nil, nil
 }
 
+func (s *HTTPServer) csiVolumeReclaim(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != http.MethodPut {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	dryRun, err := parseBool(req, "dry_run")
+	if err != nil {
+		return nil, err
+	}
+
+	args := structs.CSIVolumeReclaimRequest{
+		VolumeID: id,
+	}
+	if dryRun != nil {
+		args.DryRun = *dryRun
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.CSIVolumeReclaimResponse
+	if err := s.agent.RPC("CSIVolume.Reclaim", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.Claims, nil
+}
+
 func (s *HTTPServer) CSISnapshotsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case http.MethodPut, http.MethodPost:
@@ -480,6 +512,9 @@ func structsCSIVolumeToApi(vol *structs.CSIVolume) *api.CSIVolume {
 		Parameters:     vol.Parameters,
 		Context:        vol.Context,
 
+		RequestedTopologies: structsCSITopologyRequestToApi(vol.RequestedTopologies),
+		ClaimHistory:        structsCSIClaimHistoryToApi(vol.ClaimHistory),
+
 		// Allocations is the collapsed list of both read and write allocs
 		Allocations: make([]*api.AllocationListStub, 0, allocCount),
 
@@ -714,6 +749,36 @@ func structsCSITopolgiesToApi(tops []*structs.CSITopology) []*api.CSITopology {
 	return out
 }
 
+// structsCSITopologyRequestToApi converts the requested topologies, part of
+// structsCSIVolumeToApi
+func structsCSITopologyRequestToApi(req *structs.CSITopologyRequest) *api.CSITopologyRequest {
+	if req == nil {
+		return nil
+	}
+
+	return &api.CSITopologyRequest{
+		Required:  structsCSITopolgiesToApi(req.Required),
+		Preferred: structsCSITopolgiesToApi(req.Preferred),
+	}
+}
+
+// structsCSIClaimHistoryToApi converts the claim history, part of
+// structsCSIVolumeToApi
+func structsCSIClaimHistoryToApi(history []*structs.CSIVolumeClaimEvent) []*api.CSIVolumeClaimEvent {
+	out := make([]*api.CSIVolumeClaimEvent, 0, len(history))
+	for _, e := range history {
+		out = append(out, &api.CSIVolumeClaimEvent{
+			AllocationID: e.AllocationID,
+			NodeID:       e.NodeID,
+			Type:         e.Type,
+			Error:        e.Error,
+			Time:         e.Time,
+		})
+	}
+
+	return out
+}
+
 // structsCSIAccessModeToApi converts access mode, part of structsCSIVolumeToApi
 func structsCSIAccessModeToApi(mode structs.CSIVolumeAccessMode) api.CSIVolumeAccessMode {
 	switch mode {
@@ -38,6 +38,9 @@ func (s *HTTPServer) DeploymentSpecificRequest(resp http.ResponseWriter, req *ht
 	case strings.HasPrefix(path, "fail/"):
 		deploymentID := strings.TrimPrefix(path, "fail/")
 		return s.deploymentFail(resp, req, deploymentID)
+	case strings.HasPrefix(path, "rollback/"):
+		deploymentID := strings.TrimPrefix(path, "rollback/")
+		return s.deploymentRollback(resp, req, deploymentID)
 	case strings.HasPrefix(path, "pause/"):
 		deploymentID := strings.TrimPrefix(path, "pause/")
 		return s.deploymentPause(resp, req, deploymentID)
@@ -73,6 +76,23 @@ func (s *HTTPServer) deploymentFail(resp http.ResponseWriter, req *http.Request,
 	return out, nil
 }
 
+func (s *HTTPServer) deploymentRollback(resp http.ResponseWriter, req *http.Request, deploymentID string) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+	args := structs.DeploymentRollbackRequest{
+		DeploymentID: deploymentID,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.DeploymentUpdateResponse
+	if err := s.agent.RPC("Deployment.Rollback", &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
 func (s *HTTPServer) deploymentPause(resp http.ResponseWriter, req *http.Request, deploymentID string) (interface{}, error) {
 	if req.Method != http.MethodPut && req.Method != http.MethodPost {
 		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
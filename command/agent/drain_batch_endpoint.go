@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) DrainBatchesRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.drainBatchList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.drainBatchCreate(resp, req)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) drainBatchList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.DrainBatchListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.DrainBatchListResponse
+	if err := s.agent.RPC("DrainBatch.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Batches == nil {
+		out.Batches = make([]*structs.DrainBatch, 0)
+	}
+	return out.Batches, nil
+}
+
+func (s *HTTPServer) drainBatchCreate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var in api.DrainBatchCreateRequest
+	if err := decodeBody(req, &in); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if in.Batch == nil {
+		return nil, CodedError(http.StatusBadRequest, "missing drain batch")
+	}
+
+	var args structs.DrainBatchCreateRequest
+	s.parseWriteRequest(req, &args.WriteRequest)
+	args.Batch = ApiDrainBatchToStructs(in.Batch, args.Namespace)
+
+	var out structs.DrainBatchCreateResponse
+	if err := s.agent.RPC("DrainBatch.Create", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) DrainBatchSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/drain/batch/")
+	switch {
+	case strings.HasSuffix(path, "/cancel"):
+		batchID := strings.TrimSuffix(path, "/cancel")
+		return s.drainBatchCancel(resp, req, batchID)
+	default:
+		return s.drainBatchQuery(resp, req, path)
+	}
+}
+
+func (s *HTTPServer) drainBatchCancel(resp http.ResponseWriter, req *http.Request, batchID string) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.DrainBatchCancelRequest{
+		BatchID: batchID,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.DrainBatchCancelResponse
+	if err := s.agent.RPC("DrainBatch.Cancel", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) drainBatchQuery(resp http.ResponseWriter, req *http.Request, batchID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.DrainBatchSpecificRequest{
+		BatchID: batchID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.DrainBatchResponse
+	if err := s.agent.RPC("DrainBatch.GetBatch", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Batch == nil {
+		return nil, CodedError(http.StatusNotFound, "drain batch not found")
+	}
+	return out.Batch, nil
+}
+
+// ApiDrainBatchToStructs converts a drain batch as submitted through the API.
+// Only the fields set by users are converted.
+func ApiDrainBatchToStructs(in *api.DrainBatch, namespace string) *structs.DrainBatch {
+	out := &structs.DrainBatch{
+		MaxParallel:        in.MaxParallel,
+		MaxParallelPercent: in.MaxParallelPercent,
+	}
+	if in.Filter != nil {
+		out.Filter = &structs.DrainBatchFilter{
+			Datacenters: in.Filter.Datacenters,
+			NodeClass:   in.Filter.NodeClass,
+			Meta:        in.Filter.Meta,
+		}
+	}
+	if in.DrainSpec != nil {
+		out.DrainSpec = ApiDrainSpecToStructs(in.DrainSpec, namespace)
+	}
+	return out
+}
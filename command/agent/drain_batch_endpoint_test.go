@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_DrainBatchList(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		b1, b2 := mock.DrainBatch(), mock.DrainBatch()
+		require.NoError(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1000,
+			&structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{b1, b2}}))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/drain/batches", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.DrainBatchesRequest(respW, req)
+		require.NoError(err)
+		require.Equal("1000", respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Len(obj.([]*structs.DrainBatch), 2)
+
+		// Query a single batch
+		req, err = http.NewRequest("GET", "/v1/drain/batch/"+b1.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.DrainBatchSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(b1.ID, obj.(*structs.DrainBatch).ID)
+
+		// Query an unknown batch
+		req, err = http.NewRequest("GET", "/v1/drain/batch/"+mock.DrainBatch().ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.DrainBatchSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "drain batch not found")
+	})
+}
+
+func TestHTTP_DrainBatchCreateCancel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		node := mock.Node()
+		require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+		args := api.DrainBatchCreateRequest{
+			Batch: &api.DrainBatch{
+				Filter:             &api.DrainBatchFilter{NodeClass: node.NodeClass},
+				DrainSpec:          &api.DrainSpec{Deadline: time.Hour},
+				MaxParallelPercent: 10,
+			},
+		}
+		req, err := http.NewRequest("PUT", "/v1/drain/batches", encodeReq(args))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.DrainBatchesRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		batch := obj.(structs.DrainBatchCreateResponse).Batch
+		require.NotEmpty(batch.ID)
+		require.Equal(10, batch.MaxParallelPercent)
+		require.Len(batch.Nodes, 1)
+		require.Equal(node.ID, batch.Nodes[0].NodeID)
+
+		// Cancel the batch
+		req, err = http.NewRequest("PUT", "/v1/drain/batch/"+batch.ID+"/cancel", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.DrainBatchSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out, err := state.DrainBatchByID(nil, batch.ID)
+		require.NoError(err)
+		require.Equal(structs.DrainBatchStatusCancelled, out.Status)
+	})
+}
@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// HostVolumeSpecificRequest dispatches GET, PUT and DELETE for dynamic host
+// volumes
+func (s *HTTPServer) HostVolumeSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	// Tokenize the suffix of the path to get the volume id
+	reqSuffix := strings.TrimPrefix(req.URL.Path, "/v1/volume/host/")
+	tokens := strings.Split(reqSuffix, "/")
+	if len(tokens) != 1 || tokens[0] == "" {
+		return nil, CodedError(404, resourceNotFoundErr)
+	}
+
+	if tokens[0] == "create" {
+		return s.hostVolumeCreate(resp, req)
+	}
+
+	id := tokens[0]
+	switch req.Method {
+	case http.MethodGet:
+		return s.hostVolumeGet(id, resp, req)
+	case http.MethodDelete:
+		return s.hostVolumeDelete(id, resp, req)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) hostVolumesList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	args.NodeID = req.URL.Query().Get("node_id")
+
+	var out structs.HostVolumeListResponse
+	if err := s.agent.RPC("HostVolume.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	return out.Volumes, nil
+}
+
+func (s *HTTPServer) hostVolumeGet(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeGetRequest{
+		ID: id,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.HostVolumeGetResponse
+	if err := s.agent.RPC("HostVolume.Get", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Volume == nil {
+		return nil, CodedError(404, "volume not found")
+	}
+
+	return out.Volume, nil
+}
+
+func (s *HTTPServer) hostVolumeCreate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut:
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var in api.HostVolumeCreateRequest
+	if err := decodeBody(req, &in); err != nil {
+		return err, CodedError(400, err.Error())
+	}
+	if in.Volume == nil {
+		return nil, CodedError(400, "missing volume definition")
+	}
+
+	args := structs.HostVolumeCreateRequest{
+		Volume: ApiHostVolumeToStructs(in.Volume),
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.HostVolumeCreateResponse
+	if err := s.agent.RPC("HostVolume.Create", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) hostVolumeDelete(id string, resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.HostVolumeDeleteRequest{
+		VolumeID: id,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.HostVolumeDeleteResponse
+	if err := s.agent.RPC("HostVolume.Delete", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+// ApiHostVolumeToStructs converts a host volume from the API, where an unset
+// UID or GID is nil rather than -1.
+func ApiHostVolumeToStructs(in *api.HostVolume) *structs.HostVolume {
+	out := structs.NewHostVolume()
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.PluginID = in.PluginID
+	out.NodeID = in.NodeID
+	out.Constraints = ApiConstraintsToStructs(in.Constraints)
+	out.RequestedCapacityMinBytes = in.RequestedCapacityMinBytes
+	out.RequestedCapacityMaxBytes = in.RequestedCapacityMaxBytes
+	out.Parameters = in.Parameters
+	if in.UID != nil {
+		out.UID = *in.UID
+	}
+	if in.GID != nil {
+		out.GID = *in.GID
+	}
+	out.Mode = in.Mode
+	return out
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_HostVolumeEndpoint_CRUD(t *testing.T) {
+	t.Parallel()
+	httpTest(t, nil, func(s *TestAgent) {
+		testutil.WaitForResult(func() (bool, error) {
+			nodes, err := s.Agent.Server().State().Nodes(nil)
+			if err != nil {
+				return false, err
+			}
+			return nodes.Next() != nil, nil
+		}, func(err error) {
+			t.Fatalf("client did not register: %v", err)
+		})
+
+		body := encodeReq(&api.HostVolumeCreateRequest{
+			Volume: &api.HostVolume{
+				Name:     "example",
+				PluginID: "mkdir",
+				NodeID:   s.Agent.Client().NodeID(),
+				Mode:     "0700",
+				UID:      helper.IntToPtr(-1),
+			},
+		})
+		req, err := http.NewRequest("PUT", "/v1/volume/host/create", body)
+		require.NoError(t, err)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.HostVolumeSpecificRequest(resp, req)
+		require.NoError(t, err)
+
+		vol := obj.(structs.HostVolumeCreateResponse).Volume
+		require.Equal(t, structs.HostVolumeStateReady, vol.State)
+		require.Equal(t, -1, vol.GID)
+		require.NotEmpty(t, resp.HeaderMap.Get("X-Nomad-Index"))
+
+		req, err = http.NewRequest("GET", "/v1/volume/host/"+vol.ID, nil)
+		require.NoError(t, err)
+		resp = httptest.NewRecorder()
+		obj, err = s.Server.HostVolumeSpecificRequest(resp, req)
+		require.NoError(t, err)
+		require.Equal(t, vol.ID, obj.(*structs.HostVolume).ID)
+
+		req, err = http.NewRequest("GET", "/v1/volumes?type=host", nil)
+		require.NoError(t, err)
+		resp = httptest.NewRecorder()
+		obj, err = s.Server.CSIVolumesRequest(resp, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.HostVolume), 1)
+
+		req, err = http.NewRequest("DELETE", "/v1/volume/host/"+vol.ID, nil)
+		require.NoError(t, err)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.HostVolumeSpecificRequest(resp, req)
+		require.NoError(t, err)
+
+		req, err = http.NewRequest("GET", "/v1/volume/host/"+vol.ID, nil)
+		require.NoError(t, err)
+		resp = httptest.NewRecorder()
+		_, err = s.Server.HostVolumeSpecificRequest(resp, req)
+		require.EqualError(t, err, "volume not found")
+	})
+}
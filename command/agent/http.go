@@ -265,10 +265,17 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/deployments", s.wrap(s.DeploymentsRequest))
 	s.mux.HandleFunc("/v1/deployment/", s.wrap(s.DeploymentSpecificRequest))
 
+	s.mux.HandleFunc("/v1/drain/batches", s.wrap(s.DrainBatchesRequest))
+	s.mux.HandleFunc("/v1/drain/batch/", s.wrap(s.DrainBatchSpecificRequest))
+
+	s.mux.HandleFunc("/v1/maintenance/windows", s.wrap(s.NodeMaintenanceWindowsRequest))
+	s.mux.HandleFunc("/v1/maintenance/window/", s.wrap(s.NodeMaintenanceWindowSpecificRequest))
+
 	s.mux.HandleFunc("/v1/volumes", s.wrap(s.CSIVolumesRequest))
 	s.mux.HandleFunc("/v1/volumes/external", s.wrap(s.CSIExternalVolumesRequest))
 	s.mux.HandleFunc("/v1/volumes/snapshot", s.wrap(s.CSISnapshotsRequest))
 	s.mux.HandleFunc("/v1/volume/csi/", s.wrap(s.CSIVolumeSpecificRequest))
+	s.mux.HandleFunc("/v1/volume/host/", s.wrap(s.HostVolumeSpecificRequest))
 	s.mux.HandleFunc("/v1/plugins", s.wrap(s.CSIPluginsRequest))
 	s.mux.HandleFunc("/v1/plugin/csi/", s.wrap(s.CSIPluginSpecificRequest))
 
@@ -330,6 +337,7 @@ func (s *HTTPServer) registerHandlers(enableDebug bool) {
 	s.mux.HandleFunc("/v1/system/reconcile/summaries", s.wrap(s.ReconcileJobSummaries))
 
 	s.mux.HandleFunc("/v1/operator/scheduler/configuration", s.wrap(s.OperatorSchedulerConfiguration))
+	s.mux.HandleFunc("/v1/operator/scheduler/queue", s.wrap(s.OperatorSchedulerQueue))
 
 	s.mux.HandleFunc("/v1/event/stream", s.wrap(s.EventStream))
 	s.mux.HandleFunc("/v1/namespaces", s.wrap(s.NamespacesRequest))
@@ -839,6 +839,9 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		}
 	}
 
+	j.Scoring = ApiScoringConfigToStructs(job.Scoring)
+	j.Preemption = ApiPreemptionPolicyToStructs(job.Preemption)
+
 	if job.Periodic != nil {
 		j.Periodic = &structs.PeriodicConfig{
 			Enabled:         *job.Periodic.Enabled,
@@ -914,6 +917,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.StopAfterClientDisconnect = taskGroup.StopAfterClientDisconnect
 	}
 
+	if taskGroup.Placement != nil {
+		tg.Placement = *taskGroup.Placement
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -939,9 +946,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	}
 
 	tg.EphemeralDisk = &structs.EphemeralDisk{
-		Sticky:  *taskGroup.EphemeralDisk.Sticky,
-		SizeMB:  *taskGroup.EphemeralDisk.SizeMB,
-		Migrate: *taskGroup.EphemeralDisk.Migrate,
+		Sticky:     *taskGroup.EphemeralDisk.Sticky,
+		SizeMB:     *taskGroup.EphemeralDisk.SizeMB,
+		Migrate:    *taskGroup.EphemeralDisk.Migrate,
+		Checkpoint: *taskGroup.EphemeralDisk.Checkpoint,
 	}
 
 	if len(taskGroup.Spreads) > 0 {
@@ -1000,6 +1008,35 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		if taskGroup.Update.AutoPromote != nil {
 			tg.Update.AutoPromote = *taskGroup.Update.AutoPromote
 		}
+
+		// The blue/green fields are optional and not canonicalized
+		if taskGroup.Update.Strategy != nil {
+			tg.Update.Strategy = *taskGroup.Update.Strategy
+		}
+
+		if taskGroup.Update.RetainPrevious != nil {
+			tg.Update.RetainPrevious = *taskGroup.Update.RetainPrevious
+		}
+
+		for _, step := range taskGroup.Update.CanarySteps {
+			if step == nil {
+				continue
+			}
+			tg.Update.CanarySteps = append(tg.Update.CanarySteps, &structs.CanaryStep{
+				Percent: *step.Percent,
+				Pause:   *step.Pause,
+			})
+		}
+
+		if a := taskGroup.Update.Analysis; a != nil {
+			tg.Update.Analysis = &structs.DeploymentAnalysis{
+				Address:   *a.Address,
+				Query:     *a.Query,
+				Operator:  *a.Operator,
+				Threshold: *a.Threshold,
+				Interval:  *a.Interval,
+			}
+		}
 	}
 
 	if len(taskGroup.Tasks) > 0 {
@@ -1238,6 +1275,7 @@ func ApiPortToStructs(in api.Port) structs.Port {
 		Value:       in.Value,
 		To:          in.To,
 		HostNetwork: in.HostNetwork,
+		Protocol:    in.Protocol,
 	}
 }
 
@@ -1645,9 +1683,47 @@ func ApiAffinityToStructs(a1 *api.Affinity) *structs.Affinity {
 	}
 }
 
+func ApiScoringConfigToStructs(a1 *api.ScoringConfig) *structs.ScoringConfig {
+	if a1 == nil {
+		return nil
+	}
+	return &structs.ScoringConfig{
+		FitFunction:   structs.FitFunction(a1.FitFunction),
+		CPUWeight:     a1.CPUWeight,
+		MemoryWeight:  a1.MemoryWeight,
+		DiskWeight:    a1.DiskWeight,
+		NetworkWeight: a1.NetworkWeight,
+		DevicesWeight: a1.DevicesWeight,
+	}
+}
+
+func ApiPreemptionPolicyToStructs(a1 *api.PreemptionPolicy) *structs.PreemptionPolicy {
+	if a1 == nil {
+		return nil
+	}
+	p := &structs.PreemptionPolicy{
+		Preemptible:           *a1.Preemptible,
+		MaxPreemptions:        *a1.MaxPreemptions,
+		MaxPreemptionsPercent: *a1.MaxPreemptionsPercent,
+		Window:                *a1.Window,
+	}
+	if l := len(a1.MaintenanceWindows); l != 0 {
+		p.MaintenanceWindows = make([]*structs.MaintenanceWindow, l)
+		for i, w := range a1.MaintenanceWindows {
+			p.MaintenanceWindows[i] = &structs.MaintenanceWindow{
+				Cron:     *w.Cron,
+				Duration: *w.Duration,
+				TimeZone: *w.TimeZone,
+			}
+		}
+	}
+	return p
+}
+
 func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
+	ret.Hierarchy = helper.CopySliceString(a1.Hierarchy)
 	ret.Weight = *a1.Weight
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
@@ -1964,6 +1964,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					},
 				},
 			},
+			{
+				Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+				Weight:    helper.Int8ToPtr(50),
+			},
 		},
 		Periodic: &api.PeriodicConfig{
 			Enabled:         helper.BoolToPtr(true),
@@ -1997,8 +2001,9 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		},
 		TaskGroups: []*api.TaskGroup{
 			{
-				Name:  helper.StringToPtr("group1"),
-				Count: helper.IntToPtr(5),
+				Name:      helper.StringToPtr("group1"),
+				Count:     helper.IntToPtr(5),
+				Placement: helper.StringToPtr(api.TaskGroupPlacementAllOrNothing),
 				Constraints: []*api.Constraint{
 					{
 						LTarget: "x",
@@ -2347,6 +2352,10 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 					},
 				},
 			},
+			{
+				Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+				Weight:    50,
+			},
 		},
 		Update: structs.UpdateStrategy{
 			Stagger:     1 * time.Second,
@@ -2384,8 +2393,9 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		},
 		TaskGroups: []*structs.TaskGroup{
 			{
-				Name:  "group1",
-				Count: 5,
+				Name:      "group1",
+				Count:     5,
+				Placement: structs.TaskGroupPlacementAllOrNothing,
 				Constraints: []*structs.Constraint{
 					{
 						LTarget: "x",
@@ -127,15 +127,12 @@ func (s *HTTPServer) nodeToggleDrain(resp http.ResponseWriter, req *http.Request
 		MarkEligible: drainRequest.MarkEligible,
 		Meta:         drainRequest.Meta,
 	}
+	s.parseWriteRequest(req, &args.WriteRequest)
 	if drainRequest.DrainSpec != nil {
 		args.DrainStrategy = &structs.DrainStrategy{
-			DrainSpec: structs.DrainSpec{
-				Deadline:         drainRequest.DrainSpec.Deadline,
-				IgnoreSystemJobs: drainRequest.DrainSpec.IgnoreSystemJobs,
-			},
+			DrainSpec: *ApiDrainSpecToStructs(drainRequest.DrainSpec, args.Namespace),
 		}
 	}
-	s.parseWriteRequest(req, &args.WriteRequest)
 
 	var out structs.NodeDrainUpdateResponse
 	if err := s.agent.RPC("Node.UpdateDrain", &args, &out); err != nil {
@@ -145,6 +142,47 @@ func (s *HTTPServer) nodeToggleDrain(resp http.ResponseWriter, req *http.Request
 	return out, nil
 }
 
+// ApiDrainSpecToStructs converts a drain specification as submitted through
+// the API. Jobs given without a namespace are in the namespace of the request.
+func ApiDrainSpecToStructs(in *api.DrainSpec, namespace string) *structs.DrainSpec {
+	jobNamespace := func(ns string) string {
+		if ns == "" {
+			return namespace
+		}
+		return ns
+	}
+
+	out := &structs.DrainSpec{
+		Deadline:         in.Deadline,
+		IgnoreSystemJobs: in.IgnoreSystemJobs,
+	}
+	if in.DeadlineOverrides != nil {
+		out.DeadlineOverrides = make([]*structs.DrainDeadline, len(in.DeadlineOverrides))
+		for i, o := range in.DeadlineOverrides {
+			if o == nil {
+				continue
+			}
+			out.DeadlineOverrides[i] = &structs.DrainDeadline{
+				Namespace:   o.Namespace,
+				JobID:       o.JobID,
+				MinPriority: o.MinPriority,
+				MaxPriority: o.MaxPriority,
+				Deadline:    o.Deadline,
+			}
+			if o.JobID != "" {
+				out.DeadlineOverrides[i].Namespace = jobNamespace(o.Namespace)
+			}
+		}
+	}
+	if in.SkipJobs != nil {
+		out.SkipJobs = make([]structs.NamespacedID, len(in.SkipJobs))
+		for i, job := range in.SkipJobs {
+			out.SkipJobs[i] = structs.NewNamespacedID(job.JobID, jobNamespace(job.Namespace))
+		}
+	}
+	return out
+}
+
 func (s *HTTPServer) nodeToggleEligibility(resp http.ResponseWriter, req *http.Request,
 	nodeID string) (interface{}, error) {
 	if req.Method != "PUT" && req.Method != "POST" {
@@ -505,3 +505,34 @@ func TestHTTP_NodeQuery(t *testing.T) {
 		}
 	})
 }
+
+func TestHTTP_NodeDrain_ApiDrainSpecToStructs(t *testing.T) {
+	t.Parallel()
+
+	in := &api.DrainSpec{
+		Deadline:         time.Hour,
+		IgnoreSystemJobs: true,
+		DeadlineOverrides: []*api.DrainDeadline{
+			{JobID: "web", Deadline: time.Minute, ForceDeadline: time.Now()},
+			{Namespace: "prod", JobID: "api", Deadline: time.Second},
+			{MinPriority: 70, MaxPriority: 100, Deadline: -1},
+		},
+		SkipJobs: []api.DrainSkipJob{{JobID: "db"}, {Namespace: "prod", JobID: "cache"}},
+	}
+	expected := &structs.DrainSpec{
+		Deadline:         time.Hour,
+		IgnoreSystemJobs: true,
+		DeadlineOverrides: []*structs.DrainDeadline{
+			{Namespace: "dev", JobID: "web", Deadline: time.Minute},
+			{Namespace: "prod", JobID: "api", Deadline: time.Second},
+			{MinPriority: 70, MaxPriority: 100, Deadline: -1},
+		},
+		SkipJobs: []structs.NamespacedID{
+			structs.NewNamespacedID("db", "dev"),
+			structs.NewNamespacedID("cache", "prod"),
+		},
+	}
+
+	// Jobs without a namespace are in the namespace of the request
+	require.Equal(t, expected, ApiDrainSpecToStructs(in, "dev"))
+}
@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) NodeMaintenanceWindowsRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	switch req.Method {
+	case http.MethodGet:
+		return s.nodeMaintenanceWindowList(resp, req)
+	case http.MethodPut, http.MethodPost:
+		return s.nodeMaintenanceWindowCreate(resp, req)
+	default:
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowList(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.NodeMaintenanceWindowListRequest{
+		NodeID: req.URL.Query().Get("node_id"),
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.NodeMaintenanceWindowListResponse
+	if err := s.agent.RPC("NodeMaintenance.List", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Windows == nil {
+		out.Windows = make([]*structs.NodeMaintenanceWindow, 0)
+	}
+	return out.Windows, nil
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowCreate(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	var in api.NodeMaintenanceWindowCreateRequest
+	if err := decodeBody(req, &in); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if in.Window == nil {
+		return nil, CodedError(http.StatusBadRequest, "missing maintenance window")
+	}
+
+	var args structs.NodeMaintenanceWindowCreateRequest
+	s.parseWriteRequest(req, &args.WriteRequest)
+	args.Window = ApiNodeMaintenanceWindowToStructs(in.Window, args.Namespace)
+
+	var out structs.NodeMaintenanceWindowCreateResponse
+	if err := s.agent.RPC("NodeMaintenance.Create", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) NodeMaintenanceWindowSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/maintenance/window/")
+	switch {
+	case strings.HasSuffix(path, "/cancel"):
+		windowID := strings.TrimSuffix(path, "/cancel")
+		return s.nodeMaintenanceWindowCancel(resp, req, windowID)
+	default:
+		return s.nodeMaintenanceWindowQuery(resp, req, path)
+	}
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowCancel(resp http.ResponseWriter, req *http.Request, windowID string) (interface{}, error) {
+	if req.Method != http.MethodPut && req.Method != http.MethodPost {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.NodeMaintenanceWindowCancelRequest{
+		WindowID: windowID,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.NodeMaintenanceWindowCancelResponse
+	if err := s.agent.RPC("NodeMaintenance.Cancel", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setIndex(resp, out.Index)
+	return nil, nil
+}
+
+func (s *HTTPServer) nodeMaintenanceWindowQuery(resp http.ResponseWriter, req *http.Request, windowID string) (interface{}, error) {
+	if req.Method != http.MethodGet {
+		return nil, CodedError(http.StatusMethodNotAllowed, ErrInvalidMethod)
+	}
+
+	args := structs.NodeMaintenanceWindowSpecificRequest{
+		WindowID: windowID,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.NodeMaintenanceWindowResponse
+	if err := s.agent.RPC("NodeMaintenance.GetWindow", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Window == nil {
+		return nil, CodedError(http.StatusNotFound, "maintenance window not found")
+	}
+	return out.Window, nil
+}
+
+// ApiNodeMaintenanceWindowToStructs converts a maintenance window as submitted
+// through the API. Only the fields set by users are converted.
+func ApiNodeMaintenanceWindowToStructs(in *api.NodeMaintenanceWindow, namespace string) *structs.NodeMaintenanceWindow {
+	out := &structs.NodeMaintenanceWindow{
+		NodeIDs:   in.NodeIDs,
+		StartTime: in.StartTime,
+		Duration:  in.Duration,
+		LeadTime:  in.LeadTime,
+	}
+	if in.Filter != nil {
+		out.Filter = &structs.DrainBatchFilter{
+			Datacenters: in.Filter.Datacenters,
+			NodeClass:   in.Filter.NodeClass,
+			Meta:        in.Filter.Meta,
+		}
+	}
+	if in.DrainSpec != nil {
+		out.DrainSpec = ApiDrainSpecToStructs(in.DrainSpec, namespace)
+	}
+	return out
+}
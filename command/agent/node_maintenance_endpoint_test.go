@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_NodeMaintenanceWindowList(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		node := mock.Node()
+		node.Datacenter = "dc2"
+		require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 999, node))
+		w1, w2 := mock.NodeMaintenanceWindow(), mock.NodeMaintenanceWindow()
+		w2.NodeIDs = []string{node.ID}
+		require.NoError(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1000,
+			&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{w1, w2}}))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/maintenance/windows", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.NodeMaintenanceWindowsRequest(respW, req)
+		require.NoError(err)
+		require.Equal("1000", respW.HeaderMap.Get("X-Nomad-Index"))
+		require.Len(obj.([]*structs.NodeMaintenanceWindow), 2)
+
+		// List the windows of the node
+		req, err = http.NewRequest("GET", "/v1/maintenance/windows?node_id="+node.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.NodeMaintenanceWindowsRequest(respW, req)
+		require.NoError(err)
+		windows := obj.([]*structs.NodeMaintenanceWindow)
+		require.Len(windows, 1)
+		require.Equal(w2.ID, windows[0].ID)
+
+		// Query a single window
+		req, err = http.NewRequest("GET", "/v1/maintenance/window/"+w1.ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		obj, err = s.Server.NodeMaintenanceWindowSpecificRequest(respW, req)
+		require.NoError(err)
+		require.Equal(w1.ID, obj.(*structs.NodeMaintenanceWindow).ID)
+
+		// Query an unknown window
+		req, err = http.NewRequest("GET", "/v1/maintenance/window/"+mock.NodeMaintenanceWindow().ID, nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.NodeMaintenanceWindowSpecificRequest(respW, req)
+		require.Error(err)
+		require.Contains(err.Error(), "maintenance window not found")
+	})
+}
+
+func TestHTTP_NodeMaintenanceWindowCreateCancel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// Directly manipulate the state
+		state := s.Agent.server.State()
+		node := mock.Node()
+		require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+		start := time.Now().Add(time.Hour).UTC()
+		args := api.NodeMaintenanceWindowCreateRequest{
+			Window: &api.NodeMaintenanceWindow{
+				NodeIDs:   []string{node.ID},
+				Filter:    &api.DrainBatchFilter{NodeClass: "large"},
+				StartTime: start,
+				Duration:  time.Hour,
+				LeadTime:  10 * time.Minute,
+				DrainSpec: &api.DrainSpec{Deadline: time.Hour},
+			},
+		}
+		req, err := http.NewRequest("PUT", "/v1/maintenance/windows", encodeReq(args))
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.NodeMaintenanceWindowsRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		window := obj.(structs.NodeMaintenanceWindowCreateResponse).Window
+		require.NotEmpty(window.ID)
+		require.Equal([]string{node.ID}, window.NodeIDs)
+		require.Equal("large", window.Filter.NodeClass)
+		require.True(start.Equal(window.StartTime))
+		require.Equal(time.Hour, window.Duration)
+		require.Equal(10*time.Minute, window.LeadTime)
+		require.Equal(time.Hour, window.DrainSpec.Deadline)
+		require.Equal(structs.NodeMaintenanceWindowStatusPending, window.Status)
+
+		// Cancel the window
+		req, err = http.NewRequest("PUT", "/v1/maintenance/window/"+window.ID+"/cancel", nil)
+		require.NoError(err)
+		respW = httptest.NewRecorder()
+
+		_, err = s.Server.NodeMaintenanceWindowSpecificRequest(respW, req)
+		require.NoError(err)
+		require.NotZero(respW.HeaderMap.Get("X-Nomad-Index"))
+
+		out, err := state.NodeMaintenanceWindowByID(nil, window.ID)
+		require.NoError(err)
+		require.Equal(structs.NodeMaintenanceWindowStatusCancelled, out.Status)
+	})
+}
@@ -289,6 +289,27 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	})
 }
 
+func TestOperator_SchedulerQueue(t *testing.T) {
+	t.Parallel()
+	httpTest(t, func(c *Config) {
+		c.Server.EvalNamespaceFairness = true
+	}, func(s *TestAgent) {
+		require := require.New(t)
+		req, _ := http.NewRequest("GET", "/v1/operator/scheduler/queue", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerQueue(resp, req)
+		require.Nil(err)
+		require.Equal(200, resp.Code)
+		out, ok := obj.(structs.EvalQueueResponse)
+		require.True(ok)
+		require.True(out.Queue.NamespaceFairness)
+
+		req, _ = http.NewRequest("PUT", "/v1/operator/scheduler/queue", nil)
+		_, err = s.Server.OperatorSchedulerQueue(httptest.NewRecorder(), req)
+		require.EqualError(err, ErrInvalidMethod)
+	})
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	t.Parallel()
 	httpTest(t, nil, func(s *TestAgent) {
@@ -299,6 +320,10 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
   "PreemptionConfig": {
     "SystemSchedulerEnabled": true,
     "ServiceSchedulerEnabled": true
+  },
+  "Scoring": {
+    "FitFunction": "worst-fit",
+    "MemoryWeight": 2
   }
 }`))
 		req, _ := http.NewRequest("PUT", "/v1/operator/scheduler/configuration", body)
@@ -324,6 +349,10 @@ func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 		require.False(reply.SchedulerConfig.PreemptionConfig.BatchSchedulerEnabled)
 		require.True(reply.SchedulerConfig.PreemptionConfig.ServiceSchedulerEnabled)
 		require.True(reply.SchedulerConfig.MemoryOversubscriptionEnabled)
+		require.Equal(&structs.ScoringConfig{
+			FitFunction:  structs.FitFunctionWorstFit,
+			MemoryWeight: 2,
+		}, reply.SchedulerConfig.Scoring)
 	})
 }
 
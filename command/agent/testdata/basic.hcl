@@ -96,9 +96,17 @@ client {
     path = "/tmp"
   }
 
-  cni_path              = "/tmp/cni_path"
-  bridge_network_name   = "custom_bridge_name"
-  bridge_network_subnet = "custom_bridge_subnet"
+  cni_path                   = "/tmp/cni_path"
+  bridge_network_name        = "custom_bridge_name"
+  bridge_network_subnet      = "custom_bridge_subnet"
+  bridge_network_subnet_ipv6 = "custom_bridge_subnet_ipv6"
+  enforce_network_bandwidth  = true
+
+  disk_quota {
+    enabled = true
+    mode    = "loopback"
+    policy  = "kill"
+  }
 }
 
 server {
@@ -114,6 +122,7 @@ server {
   job_gc_interval               = "3m"
   job_gc_threshold              = "12h"
   eval_gc_threshold             = "12h"
+  eval_namespace_fairness       = true
   deployment_gc_threshold       = "12h"
   csi_volume_claim_gc_threshold = "12h"
   csi_plugin_gc_threshold       = "12h"
@@ -147,6 +156,11 @@ server {
       system_scheduler_enabled  = true
       service_scheduler_enabled = true
     }
+
+    scoring {
+      fit_function  = "dominant-resource"
+      memory_weight = 2
+    }
   }
 
   license_path = "/tmp/nomad.hclic"
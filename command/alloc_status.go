@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"math"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -297,6 +298,13 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 		}
 	}
 
+	if ns := alloc.NetworkStatus; ns != nil && ns.Address != "" {
+		basic = append(basic, fmt.Sprintf("Network Address|%s", ns.Address))
+		if ns.AddressIPv6 != "" && ns.AddressIPv6 != ns.Address {
+			basic = append(basic, fmt.Sprintf("Network IPv6 Address|%s", ns.AddressIPv6))
+		}
+	}
+
 	if alloc.RescheduleTracker != nil && len(alloc.RescheduleTracker.Events) > 0 {
 		attempts, total := alloc.RescheduleInfo(time.Unix(0, alloc.ModifyTime))
 		// Show this section only if the reschedule policy limits the number of attempts
@@ -331,24 +339,24 @@ func formatAllocBasicInfo(alloc *api.Allocation, client *api.Client, uuidLength
 
 func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	nw := alloc.AllocatedResources.Shared.Networks[0]
-	addrs := []string{"Label|Dynamic|Address"}
-	portFmt := func(label string, value, to int, hostIP, dyn string) string {
-		s := fmt.Sprintf("%s|%s|%s:%d", label, dyn, hostIP, value)
+	addrs := []string{"Label|Dynamic|Address|Protocol"}
+	portFmt := func(label string, value, to int, hostIP, dyn, protocol string) string {
+		s := fmt.Sprintf("%s|%s|%s", label, dyn, net.JoinHostPort(hostIP, strconv.Itoa(value)))
 		if to > 0 {
 			s += fmt.Sprintf(" -> %d", to)
 		}
-		return s
+		return s + "|" + formatPortProtocol(protocol)
 	}
 	if len(alloc.AllocatedResources.Shared.Ports) > 0 {
 		for _, port := range alloc.AllocatedResources.Shared.Ports {
-			addrs = append(addrs, portFmt("*"+port.Label, port.Value, port.To, port.HostIP, "yes"))
+			addrs = append(addrs, portFmt("*"+port.Label, port.Value, port.To, port.HostIP, "yes", port.Protocol))
 		}
 	} else {
 		for _, port := range nw.DynamicPorts {
-			addrs = append(addrs, portFmt(port.Label, port.Value, port.To, nw.IP, "yes"))
+			addrs = append(addrs, portFmt(port.Label, port.Value, port.To, nw.IP, "yes", port.Protocol))
 		}
 		for _, port := range nw.ReservedPorts {
-			addrs = append(addrs, portFmt(port.Label, port.Value, port.To, nw.IP, "yes"))
+			addrs = append(addrs, portFmt(port.Label, port.Value, port.To, nw.IP, "yes", port.Protocol))
 		}
 	}
 
@@ -360,6 +368,14 @@ func formatAllocNetworkInfo(alloc *api.Allocation) string {
 	return fmt.Sprintf("Allocation Addresses%s\n%s", mode, formatList(addrs))
 }
 
+// formatPortProtocol returns the protocols a port is published for.
+func formatPortProtocol(protocol string) string {
+	if protocol == "" {
+		return "tcp/udp"
+	}
+	return protocol
+}
+
 // futureEvalTimePretty returns when the eval is eligible to reschedule
 // relative to current time, based on the WaitUntil field
 func futureEvalTimePretty(evalID string, client *api.Client) string {
@@ -584,6 +600,7 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 	if max := resource.MemoryMaxMB; max != nil && *max != 0 && *max != *resource.MemoryMB {
 		memMax = "Max: " + humanize.IBytes(uint64(*resource.MemoryMaxMB*bytesPerMegabyte))
 	}
+	diskUsage := humanize.IBytes(uint64(*alloc.Resources.DiskMB * bytesPerMegabyte))
 	var deviceStats []*api.DeviceGroupStats
 
 	if stats != nil {
@@ -600,13 +617,16 @@ func (c *AllocStatusCommand) outputTaskResources(alloc *api.Allocation, task str
 				}
 				memUsage = fmt.Sprintf("%v/%v", humanize.IBytes(usage), memUsage)
 			}
+			if ds := ru.ResourceUsage.DiskStats; ds != nil {
+				diskUsage = fmt.Sprintf("%v/%v", humanize.IBytes(ds.Used), diskUsage)
+			}
 			deviceStats = ru.ResourceUsage.DeviceStats
 		}
 	}
 	resourcesOutput = append(resourcesOutput, fmt.Sprintf("%v MHz|%v|%v|%v",
 		cpuUsage,
 		memUsage,
-		humanize.IBytes(uint64(*alloc.Resources.DiskMB*bytesPerMegabyte)),
+		diskUsage,
 		firstAddr))
 	if memMax != "" || secondAddr != "" {
 		resourcesOutput = append(resourcesOutput, fmt.Sprintf("|%v||%v", memMax, secondAddr))
@@ -701,6 +721,22 @@ func (c *AllocStatusCommand) outputVerboseResourceUsage(task string, resourceUsa
 		c.Ui.Output(formatList(out))
 	}
 
+	if ns := resourceUsage.NetworkStats; ns != nil {
+		c.Ui.Output("")
+		c.Ui.Output("Network Stats")
+
+		limit := "none"
+		if ns.LimitMBits > 0 {
+			limit = fmt.Sprintf("%d MBits", ns.LimitMBits)
+		}
+		out := []string{
+			"Rx|Tx|Rx Rate|Tx Rate|Limit",
+			fmt.Sprintf("%v|%v|%.2f MBits|%.2f MBits|%v",
+				humanize.Bytes(ns.RxBytes), humanize.Bytes(ns.TxBytes), ns.RxMBits, ns.TxMBits, limit),
+		}
+		c.Ui.Output(formatList(out))
+	}
+
 	if len(deviceStats) > 0 {
 		c.Ui.Output("")
 		c.Ui.Output("Device Stats")
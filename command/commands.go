@@ -237,6 +237,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"deployment rollback": func() (cli.Command, error) {
+			return &DeploymentRollbackCommand{
+				Meta: meta,
+			}, nil
+		},
 		"deployment status": func() (cli.Command, error) {
 			return &DeploymentStatusCommand{
 				Meta: meta,
@@ -816,6 +821,11 @@ func Commands(metaPtr *Meta, agentUi cli.Ui) map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"volume reclaim": func() (cli.Command, error) {
+			return &VolumeReclaimCommand{
+				Meta: meta,
+			}, nil
+		},
 		"volume create": func() (cli.Command, error) {
 			return &VolumeCreateCommand{
 				Meta: meta,
@@ -0,0 +1,145 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type DeploymentRollbackCommand struct {
+	Meta
+}
+
+func (c *DeploymentRollbackCommand) Help() string {
+	helpText := `
+Usage: nomad deployment rollback [options] <deployment id>
+
+  Rollback is used to switch a blue/green deployment back to the previous
+  allocations it retains on standby. The previous allocations become active
+  again without being rescheduled, the allocations placed by the deployment
+  are stopped, the deployment is marked as failed and the job is reverted to
+  its latest stable version. A deployment can only be rolled back while it
+  retains the previous allocations, as configured by the 'retain_previous'
+  parameter of the update stanza.
+
+  When ACLs are enabled, this command requires a token with the 'submit-job'
+  and 'read-job' capabilities for the deployment's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Rollback Options:
+
+  -detach
+    Return immediately instead of entering monitor mode. After deployment
+    rollback, the evaluation ID will be printed to the screen, which can be used
+    to examine the evaluation using the eval-status command.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *DeploymentRollbackCommand) Synopsis() string {
+	return "Roll back a blue/green deployment"
+}
+
+func (c *DeploymentRollbackCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-detach":  complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *DeploymentRollbackCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Deployments, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Deployments]
+	})
+}
+
+func (c *DeploymentRollbackCommand) Name() string { return "deployment rollback" }
+
+func (c *DeploymentRollbackCommand) Run(args []string) int {
+	var detach, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&detach, "detach", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <deployment id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	dID := args[0]
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Do a prefix lookup
+	deploy, possible, err := getDeployment(client.Deployments(), dID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving deployment: %s", err))
+		return 1
+	}
+
+	if len(possible) != 0 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple deployments\n\n%s", formatDeployments(possible, length)))
+		return 1
+	}
+
+	u, _, err := client.Deployments().Rollback(deploy.ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rolling back deployment: %s", err))
+		return 1
+	}
+
+	if u.RevertedJobVersion == nil {
+		c.Ui.Output(fmt.Sprintf("Deployment %q rolled back", deploy.ID))
+	} else {
+		c.Ui.Output(fmt.Sprintf("Deployment %q rolled back. Reverted to job version %d.", deploy.ID, *u.RevertedJobVersion))
+	}
+
+	evalCreated := u.EvalID != ""
+
+	// Nothing to do
+	if detach || !evalCreated {
+		return 0
+	}
+
+	c.Ui.Output("")
+	mon := newMonitor(c.Ui, client, length)
+	return mon.monitor(u.EvalID)
+}
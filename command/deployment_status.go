@@ -586,7 +586,7 @@ func formatMultiregionDeployment(regions map[string]*api.Deployment, uuidLength
 
 func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	// Detect if we need to add these columns
-	var canaries, autorevert, progressDeadline bool
+	var canaries, canarySteps, autorevert, progressDeadline bool
 	tgNames := make([]string, 0, len(d.TaskGroups))
 	for name, state := range d.TaskGroups {
 		tgNames = append(tgNames, name)
@@ -596,6 +596,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if state.DesiredCanaries > 0 {
 			canaries = true
 		}
+		if len(state.CanarySteps) > 0 {
+			canarySteps = true
+		}
 		if state.ProgressDeadline != 0 {
 			progressDeadline = true
 		}
@@ -616,6 +619,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	if canaries {
 		rowString += "Canaries|"
 	}
+	if canarySteps {
+		rowString += "Canary Step|"
+	}
 	rowString += "Placed|Healthy|Unhealthy"
 	if progressDeadline {
 		rowString += "|Progress Deadline"
@@ -641,6 +647,9 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 		if canaries {
 			row += fmt.Sprintf("%d|", state.DesiredCanaries)
 		}
+		if canarySteps {
+			row += fmt.Sprintf("%s|", formatCanaryStep(state))
+		}
 		row += fmt.Sprintf("%d|%d|%d", state.PlacedAllocs, state.HealthyAllocs, state.UnhealthyAllocs)
 		if progressDeadline {
 			if state.RequireProgressBy.IsZero() {
@@ -656,6 +665,20 @@ func formatDeploymentGroups(d *api.Deployment, uuidLength int) string {
 	return formatList(rows)
 }
 
+// formatCanaryStep formats the canary step a task group is at, such as
+// "2/4 (25%)"
+func formatCanaryStep(state *api.DeploymentState) string {
+	if state.CanaryStep >= len(state.CanarySteps) {
+		return "N/A"
+	}
+
+	out := fmt.Sprintf("%d/%d", state.CanaryStep+1, len(state.CanarySteps))
+	if step := state.CanarySteps[state.CanaryStep]; step != nil && step.Percent != nil {
+		out += fmt.Sprintf(" (%d%%)", *step.Percent)
+	}
+	return out
+}
+
 func hasAutoRevert(d *api.Deployment) bool {
 	taskGroups := d.TaskGroups
 	for _, state := range taskGroups {
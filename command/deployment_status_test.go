@@ -3,6 +3,8 @@ package command
 import (
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
@@ -73,3 +75,29 @@ func TestDeploymentStatusCommand_AutocompleteArgs(t *testing.T) {
 	assert.Equal(1, len(res))
 	assert.Equal(d.ID, res[0])
 }
+
+func TestDeploymentStatusCommand_FormatCanaryStep(t *testing.T) {
+	t.Parallel()
+	d := &api.Deployment{
+		TaskGroups: map[string]*api.DeploymentState{
+			"web": {
+				DesiredCanaries: 3,
+				DesiredTotal:    10,
+				CanarySteps: []*api.CanaryStep{
+					{Percent: helper.IntToPtr(10)},
+					{Percent: helper.IntToPtr(25)},
+				},
+				CanaryStep: 1,
+			},
+			"db": {
+				DesiredCanaries: 1,
+				DesiredTotal:    1,
+			},
+		},
+	}
+
+	out := formatDeploymentGroups(d, shortId)
+	require.Regexp(t, `Task Group\s+Promoted\s+Desired\s+Canaries\s+Canary Step\s+Placed`, out)
+	require.Regexp(t, `db\s+false\s+1\s+1\s+N/A`, out)
+	require.Regexp(t, `web\s+false\s+10\s+3\s+2/2 \(25%\)`, out)
+}
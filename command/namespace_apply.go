@@ -2,6 +2,7 @@ package command
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/nomad/api"
@@ -33,6 +34,11 @@ Apply Options:
 
   -description
     An optional description for the namespace.
+
+  -weight
+    The share of evaluation dequeues the namespace receives relative to other
+    namespaces when the servers dequeue evaluations fairly across namespaces.
+    Defaults to 1.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -42,6 +48,7 @@ func (c *NamespaceApplyCommand) AutocompleteFlags() complete.Flags {
 		complete.Flags{
 			"-description": complete.PredictAnything,
 			"-quota":       QuotaPredictor(c.Meta.Client),
+			"-weight":      complete.PredictAnything,
 		})
 }
 
@@ -57,6 +64,7 @@ func (c *NamespaceApplyCommand) Name() string { return "namespace apply" }
 
 func (c *NamespaceApplyCommand) Run(args []string) int {
 	var description, quota *string
+	var weight *int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -68,6 +76,14 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 		quota = &s
 		return nil
 	}), "quota", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		w, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid weight %q: %v", s, err)
+		}
+		weight = &w
+		return nil
+	}), "weight", "")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -116,6 +132,9 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 	if quota != nil {
 		ns.Quota = *quota
 	}
+	if weight != nil {
+		ns.Weight = *weight
+	}
 
 	_, err = client.Namespaces().Register(ns, nil)
 	if err != nil {
@@ -50,11 +50,15 @@ func TestNamespaceApplyCommand_Good(t *testing.T) {
 
 	// Create a namespace
 	name, desc := "foo", "bar"
-	if code := cmd.Run([]string{"-address=" + url, "-description=" + desc, name}); code != 0 {
+	if code := cmd.Run([]string{"-address=" + url, "-description=" + desc, "-weight=3", name}); code != 0 {
 		t.Fatalf("expected exit 0, got: %d; %v", code, ui.ErrorWriter.String())
 	}
 
 	namespaces, _, err := client.Namespaces().List(nil)
 	assert.Nil(t, err)
 	assert.Len(t, namespaces, 2)
+
+	ns, _, err := client.Namespaces().Info(name, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, ns.Weight)
 }
@@ -115,11 +115,20 @@ func formatNamespaceBasics(ns *api.Namespace) string {
 		fmt.Sprintf("Name|%s", ns.Name),
 		fmt.Sprintf("Description|%s", ns.Description),
 		fmt.Sprintf("Quota|%s", ns.Quota),
+		fmt.Sprintf("Weight|%d", namespaceWeight(ns)),
 	}
 
 	return formatKV(basic)
 }
 
+// namespaceWeight returns the weight the eval broker uses for the namespace.
+func namespaceWeight(ns *api.Namespace) int {
+	if ns.Weight < 1 {
+		return 1
+	}
+	return ns.Weight
+}
+
 func getNamespace(client *api.Namespaces, ns string) (match *api.Namespace, possible []*api.Namespace, err error) {
 	// Do a prefix lookup
 	namespaces, _, err := client.PrefixList(ns, nil)
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,11 +27,23 @@ type NodeDrainCommand struct {
 func (c *NodeDrainCommand) Help() string {
 	helpText := `
 Usage: nomad node drain [options] <node>
+       nomad node drain -batch [options] [<batch>]
+       nomad node drain -window [options] [<node>...|<window>]
 
   Toggles node draining on a specified node. It is required that either
   -enable or -disable is specified, but not both.  The -self flag is useful to
   drain the local node.
 
+  With the -batch flag, -enable creates a drain batch that drains the nodes
+  matching the -datacenter, -node-class and -node-meta flags a few at a time,
+  -disable cancels the given drain batch and -monitor monitors it.
+
+  With the -window flag, -enable schedules a maintenance window for the given
+  nodes and the nodes matching the -datacenter, -node-class and -node-meta
+  flags, and -disable cancels the given maintenance window. The nodes are
+  drained when the window starts and are kept ineligible until it ends, when
+  the eligibility of the nodes that are ready is restored.
+
   If ACLs are enabled, this option requires a token with the 'node:write'
   capability.
 
@@ -68,6 +81,23 @@ Node Drain Options:
     Ignore system allows the drain to complete without stopping system job
     allocations. By default system jobs are stopped last.
 
+  -job-deadline <job>=<deadline>
+    Override the deadline for the allocations of a job, can be used multiple
+    times. The deadline is a duration, "force" to stop the allocations
+    immediately or "none" to never force them to stop. The job is in the
+    namespace given by -namespace.
+
+  -priority-deadline <min>-<max>=<deadline>
+    Override the deadline for the allocations of the jobs whose priority is
+    within the inclusive band, can be used multiple times. Overrides of a job
+    take precedence over priority bands, and the first matching band is used.
+    The deadline is formatted as for -job-deadline.
+
+  -skip-job <job>
+    Keep the allocations of the job on the node, can be used multiple times.
+    The drain completes without migrating them. The job is in the namespace
+    given by -namespace.
+
   -keep-ineligible
     Keep ineligible will maintain the node's scheduling ineligibility even if
     the drain is being disabled. This is useful when an existing drain is being
@@ -85,6 +115,48 @@ Node Drain Options:
 
   -yes
     Automatic yes to prompts.
+
+Drain Batch Options:
+
+  -batch
+    Create, cancel or monitor a drain batch instead of draining a single node.
+    The next node of the batch starts draining once the allocations migrated
+    off a drained node are healthy.
+
+  -datacenter <datacenter>
+    Drain the nodes of the datacenter, can be used multiple times.
+
+  -node-class <class>
+    Drain the nodes of the node class.
+
+  -node-meta <key>=<value>
+    Drain the nodes with the metadata, can be used multiple times.
+
+  -max-parallel <count>
+    The maximum number of nodes of the batch draining at the same time.
+
+  -max-parallel-percent <percent>
+    The maximum percent of the nodes of each datacenter draining at the same
+    time. At least one node of each datacenter drains at a time.
+
+Maintenance Window Options:
+
+  -window
+    Schedule or cancel a maintenance window instead of draining a single node.
+    The -datacenter, -node-class and -node-meta flags select the nodes of the
+    window in addition to the given nodes.
+
+  -start <time>
+    The start of the window, either an RFC3339 time or a duration from now.
+    If unspecified, the window starts immediately.
+
+  -duration <duration>
+    How long the nodes of the window are kept ineligible.
+
+  -lead-time <duration>
+    How long before the window starts the scheduler avoids placing service
+    allocations on its nodes. If unspecified, a lead time of one hour is
+    applied.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -96,18 +168,31 @@ func (c *NodeDrainCommand) Synopsis() string {
 func (c *NodeDrainCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-disable":         complete.PredictNothing,
-			"-enable":          complete.PredictNothing,
-			"-deadline":        complete.PredictAnything,
-			"-detach":          complete.PredictNothing,
-			"-force":           complete.PredictNothing,
-			"-no-deadline":     complete.PredictNothing,
-			"-ignore-system":   complete.PredictNothing,
-			"-keep-ineligible": complete.PredictNothing,
-			"-m":               complete.PredictNothing,
-			"-meta":            complete.PredictNothing,
-			"-self":            complete.PredictNothing,
-			"-yes":             complete.PredictNothing,
+			"-disable":              complete.PredictNothing,
+			"-enable":               complete.PredictNothing,
+			"-deadline":             complete.PredictAnything,
+			"-detach":               complete.PredictNothing,
+			"-force":                complete.PredictNothing,
+			"-no-deadline":          complete.PredictNothing,
+			"-ignore-system":        complete.PredictNothing,
+			"-keep-ineligible":      complete.PredictNothing,
+			"-job-deadline":         complete.PredictAnything,
+			"-priority-deadline":    complete.PredictAnything,
+			"-skip-job":             complete.PredictAnything,
+			"-m":                    complete.PredictNothing,
+			"-meta":                 complete.PredictNothing,
+			"-self":                 complete.PredictNothing,
+			"-yes":                  complete.PredictNothing,
+			"-batch":                complete.PredictNothing,
+			"-datacenter":           complete.PredictAnything,
+			"-node-class":           complete.PredictAnything,
+			"-node-meta":            complete.PredictAnything,
+			"-max-parallel":         complete.PredictAnything,
+			"-max-parallel-percent": complete.PredictAnything,
+			"-window":               complete.PredictNothing,
+			"-start":                complete.PredictAnything,
+			"-duration":             complete.PredictAnything,
+			"-lead-time":            complete.PredictAnything,
 		})
 }
 
@@ -131,9 +216,12 @@ func (c *NodeDrainCommand) Name() string { return "node-drain" }
 func (c *NodeDrainCommand) Run(args []string) int {
 	var enable, disable, detach, force,
 		noDeadline, ignoreSystem, keepIneligible,
-		self, autoYes, monitor bool
-	var deadline, message string
-	var metaVars flaghelper.StringFlag
+		self, autoYes, monitor, batch, window bool
+	var deadline, message, nodeClass string
+	var start, duration, leadTime string
+	var metaVars, datacenters, nodeMetaVars flaghelper.StringFlag
+	var jobDeadlines, priorityDeadlines, skipJobs flaghelper.StringFlag
+	var maxParallel, maxParallelPercent int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -150,6 +238,19 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	flags.BoolVar(&monitor, "monitor", false, "Monitor drain status.")
 	flags.StringVar(&message, "m", "", "Drain message")
 	flags.Var(&metaVars, "meta", "Drain metadata")
+	flags.Var(&jobDeadlines, "job-deadline", "Deadline of the allocations of a job")
+	flags.Var(&priorityDeadlines, "priority-deadline", "Deadline of the allocations of the jobs within a priority band")
+	flags.Var(&skipJobs, "skip-job", "Job whose allocations stay on the node")
+	flags.BoolVar(&batch, "batch", false, "Drain a batch of nodes")
+	flags.Var(&datacenters, "datacenter", "Datacenter of the nodes of the batch")
+	flags.StringVar(&nodeClass, "node-class", "", "Node class of the nodes of the batch")
+	flags.Var(&nodeMetaVars, "node-meta", "Metadata of the nodes of the batch")
+	flags.IntVar(&maxParallel, "max-parallel", 0, "Nodes of the batch draining at the same time")
+	flags.IntVar(&maxParallelPercent, "max-parallel-percent", 0, "Percent of the nodes of a datacenter draining at the same time")
+	flags.BoolVar(&window, "window", false, "Schedule a maintenance window")
+	flags.StringVar(&start, "start", "", "Start of the maintenance window")
+	flags.StringVar(&duration, "duration", "", "Duration of the maintenance window")
+	flags.StringVar(&leadTime, "lead-time", "", "Lead time of the maintenance window")
 
 	if err := flags.Parse(args); err != nil {
 		return 1
@@ -169,16 +270,63 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		return 1
 	}
 
+	// Check that the batch and window flags are only set with -batch or
+	// -window
+	hasFilterFlags := len(datacenters) != 0 || nodeClass != "" || len(nodeMetaVars) != 0
+	hasBatchFlags := maxParallel != 0 || maxParallelPercent != 0
+	hasWindowFlags := start != "" || duration != "" || leadTime != ""
+	if batch && window {
+		c.Ui.Error("-batch and -window are mutually exclusive")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if batch && (self || keepIneligible || message != "" || len(metaVars) != 0) {
+		c.Ui.Error("-batch can't be combined with -self, -keep-ineligible, -m or -meta")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if window && (self || keepIneligible || message != "" || len(metaVars) != 0 || monitor || detach) {
+		c.Ui.Error("-window can't be combined with -self, -keep-ineligible, -m, -meta, -monitor or -detach")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !batch && !window && hasFilterFlags {
+		c.Ui.Error("-datacenter, -node-class and -node-meta require -batch or -window")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !batch && hasBatchFlags {
+		c.Ui.Error("-max-parallel and -max-parallel-percent require -batch")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if !window && hasWindowFlags {
+		c.Ui.Error("-start, -duration and -lead-time require -window")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if batch && !enable && (hasFilterFlags || hasBatchFlags) {
+		c.Ui.Error("Node filters and limits can only be set when creating a drain batch with -enable")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if window && !enable && (hasFilterFlags || hasWindowFlags) {
+		c.Ui.Error("Node filters and times can only be set when scheduling a maintenance window with -enable")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
 	// Check that we got a node ID
 	args = flags.Args()
-	if l := len(args); self && l != 0 || !self && l != 1 {
+	if l := len(args); !batch && !window && (self && l != 0 || !self && l != 1) {
 		c.Ui.Error("Node ID must be specified if -self isn't being used")
 		c.Ui.Error(commandErrorText(c))
 		return 1
 	}
 
 	// Validate a compatible set of flags were set
-	if disable && (deadline != "" || force || noDeadline || ignoreSystem) {
+	hasJobFlags := len(jobDeadlines) != 0 || len(priorityDeadlines) != 0 || len(skipJobs) != 0
+	if disable && (deadline != "" || force || noDeadline || ignoreSystem || hasJobFlags) {
 		c.Ui.Error("-disable can't be combined with flags configuring drain strategy")
 		c.Ui.Error(commandErrorText(c))
 		return 1
@@ -216,6 +364,32 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		d = defaultDrainDuration
 	}
 
+	// Parse the deadline overrides
+	var overrides []*api.DrainDeadline
+	for _, v := range jobDeadlines {
+		o, err := parseDrainDeadline(v, false)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse -job-deadline: %v", err))
+			return 1
+		}
+		overrides = append(overrides, o)
+	}
+	for _, v := range priorityDeadlines {
+		o, err := parseDrainDeadline(v, true)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse -priority-deadline: %v", err))
+			return 1
+		}
+		overrides = append(overrides, o)
+	}
+
+	// Skipped jobs, like the jobs of overrides, are in the namespace of the
+	// request
+	var skipped []api.DrainSkipJob
+	for _, jobID := range skipJobs {
+		skipped = append(skipped, api.DrainSkipJob{JobID: jobID})
+	}
+
 	// Get the HTTP client
 	client, err := c.Meta.Client()
 	if err != nil {
@@ -223,6 +397,58 @@ func (c *NodeDrainCommand) Run(args []string) int {
 		return 1
 	}
 
+	if batch {
+		nodeMeta, err := parseNodeMeta(nodeMetaVars)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		return c.runBatch(client, args, &nodeDrainBatchArgs{
+			enable:  enable,
+			disable: disable,
+			monitor: monitor,
+			detach:  detach,
+			spec: &api.DrainSpec{
+				Deadline:          d,
+				IgnoreSystemJobs:  ignoreSystem,
+				DeadlineOverrides: overrides,
+				SkipJobs:          skipped,
+			},
+			filter: &api.DrainBatchFilter{
+				Datacenters: datacenters,
+				NodeClass:   nodeClass,
+				Meta:        nodeMeta,
+			},
+			maxParallel:        maxParallel,
+			maxParallelPercent: maxParallelPercent,
+		})
+	}
+
+	if window {
+		nodeMeta, err := parseNodeMeta(nodeMetaVars)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		return c.runWindow(client, args, &nodeDrainWindowArgs{
+			enable: enable,
+			spec: &api.DrainSpec{
+				Deadline:          d,
+				IgnoreSystemJobs:  ignoreSystem,
+				DeadlineOverrides: overrides,
+				SkipJobs:          skipped,
+			},
+			filter: &api.DrainBatchFilter{
+				Datacenters: datacenters,
+				NodeClass:   nodeClass,
+				Meta:        nodeMeta,
+			},
+			start:    start,
+			duration: duration,
+			leadTime: leadTime,
+		})
+	}
+
 	// If -self flag is set then determine the current node.
 	var nodeID string
 	if !self {
@@ -306,8 +532,10 @@ func (c *NodeDrainCommand) Run(args []string) int {
 	var spec *api.DrainSpec
 	if enable {
 		spec = &api.DrainSpec{
-			Deadline:         d,
-			IgnoreSystemJobs: ignoreSystem,
+			Deadline:          d,
+			IgnoreSystemJobs:  ignoreSystem,
+			DeadlineOverrides: overrides,
+			SkipJobs:          skipped,
 		}
 	}
 
@@ -379,3 +607,48 @@ func (c *NodeDrainCommand) monitorDrain(client *api.Client, ctx context.Context,
 		}
 	}
 }
+
+// parseDrainDeadline parses a deadline override formatted as
+// <job>=<deadline>, or as <min>-<max>=<deadline> for a priority band.
+func parseDrainDeadline(v string, byPriority bool) (*api.DrainDeadline, error) {
+	idx := strings.LastIndex(v, "=")
+	if idx < 1 {
+		return nil, fmt.Errorf("%q must be formatted as <selector>=<deadline>", v)
+	}
+	selector, value := v[:idx], v[idx+1:]
+
+	o := &api.DrainDeadline{}
+	switch value {
+	case "force":
+		o.Deadline = -1 * time.Second
+	case "none":
+		o.Deadline = 0
+	default:
+		dur, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deadline %q: %v", value, err)
+		}
+		if dur <= 0 {
+			return nil, fmt.Errorf("invalid deadline %q: must be positive, \"force\" or \"none\"", value)
+		}
+		o.Deadline = dur
+	}
+
+	if !byPriority {
+		o.JobID = selector
+		return o, nil
+	}
+
+	band := strings.SplitN(selector, "-", 2)
+	if len(band) != 2 {
+		return nil, fmt.Errorf("invalid priority band %q: must be formatted as <min>-<max>", selector)
+	}
+	var err error
+	if o.MinPriority, err = strconv.Atoi(band[0]); err != nil {
+		return nil, fmt.Errorf("invalid priority band %q: %v", selector, err)
+	}
+	if o.MaxPriority, err = strconv.Atoi(band[1]); err != nil {
+		return nil, fmt.Errorf("invalid priority band %q: %v", selector, err)
+	}
+	return o, nil
+}
@@ -0,0 +1,158 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+// nodeDrainBatchArgs are the flags of a node drain command run with -batch
+type nodeDrainBatchArgs struct {
+	enable, disable, monitor, detach bool
+
+	spec               *api.DrainSpec
+	filter             *api.DrainBatchFilter
+	maxParallel        int
+	maxParallelPercent int
+}
+
+// runBatch creates, cancels or monitors a drain batch.
+func (c *NodeDrainCommand) runBatch(client *api.Client, args []string, opts *nodeDrainBatchArgs) int {
+	if opts.enable {
+		if len(args) != 0 {
+			c.Ui.Error("-batch -enable selects nodes with the filter flags and takes no arguments")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		if opts.filter.Datacenters == nil && opts.filter.NodeClass == "" && opts.filter.Meta == nil {
+			c.Ui.Error("-batch requires at least one of -datacenter, -node-class or -node-meta")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+		if opts.maxParallel == 0 && opts.maxParallelPercent == 0 {
+			c.Ui.Error("-batch requires -max-parallel or -max-parallel-percent")
+			c.Ui.Error(commandErrorText(c))
+			return 1
+		}
+
+		resp, _, err := client.DrainBatches().Create(&api.DrainBatch{
+			Filter:             opts.filter,
+			DrainSpec:          opts.spec,
+			MaxParallel:        opts.maxParallel,
+			MaxParallelPercent: opts.maxParallelPercent,
+		}, nil)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error creating drain batch: %s", err))
+			return 1
+		}
+
+		batch := resp.Batch
+		now := time.Now()
+		c.Ui.Output(fmt.Sprintf("%s: Drain batch %q created for %d nodes", formatTime(now), batch.ID, len(batch.Nodes)))
+		if opts.detach {
+			return 0
+		}
+		c.Ui.Info(fmt.Sprintf("%s: Ctrl-C to stop monitoring: will not cancel the drain batch", formatTime(now)))
+		return c.monitorBatch(client, batch.ID, resp.LastIndex)
+	}
+
+	if len(args) != 1 {
+		c.Ui.Error("Drain batch ID must be specified with -batch -disable or -batch -monitor")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	batchID := sanitizeUUIDPrefix(args[0])
+	batches, _, err := client.DrainBatches().PrefixList(batchID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving drain batch: %s", err))
+		return 1
+	}
+	if len(batches) == 0 {
+		c.Ui.Error(fmt.Sprintf("No drain batch(es) with prefix or ID %q found", batchID))
+		return 1
+	}
+	if len(batches) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple drain batches\n\n%s", formatDrainBatches(batches)))
+		return 1
+	}
+	batch := batches[0]
+
+	if opts.monitor {
+		c.Ui.Info(fmt.Sprintf("%s: Monitoring drain batch %q: Ctrl-C to detach monitoring", formatTime(time.Now()), batch.ID))
+		return c.monitorBatch(client, batch.ID, 0)
+	}
+
+	if _, err := client.DrainBatches().Cancel(batch.ID, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error cancelling drain batch: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Drain batch %q cancelled", batch.ID))
+	return 0
+}
+
+// monitorBatch reports the progress of the nodes of a drain batch until the
+// batch is no longer running.
+func (c *NodeDrainCommand) monitorBatch(client *api.Client, batchID string, index uint64) int {
+	reported := make(map[string]string)
+	q := &api.QueryOptions{WaitIndex: index}
+	for {
+		batch, meta, err := client.DrainBatches().Info(batchID, q)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("%s: Error monitoring drain batch: %s", formatTime(time.Now()), err))
+			return 1
+		}
+		q.WaitIndex = meta.LastIndex
+
+		for _, node := range batch.Nodes {
+			if reported[node.NodeID] == node.Status {
+				continue
+			}
+			reported[node.NodeID] = node.Status
+
+			now := formatTime(time.Now())
+			switch node.Status {
+			case api.DrainBatchNodeStatusDraining:
+				c.Ui.Output(fmt.Sprintf("%s: Node %q draining", now, node.NodeID))
+			case api.DrainBatchNodeStatusComplete:
+				c.Ui.Output(fmt.Sprintf("%s: Node %q drained and migrated allocations are healthy", now, node.NodeID))
+			case api.DrainBatchNodeStatusSkipped:
+				c.Ui.Warn(fmt.Sprintf("%s: Node %q skipped", now, node.NodeID))
+			}
+		}
+
+		if batch.Status != api.DrainBatchStatusRunning {
+			c.Ui.Output(fmt.Sprintf("%s: Drain batch %q %s: %s",
+				formatTime(time.Now()), batch.ID, batch.Status, batch.StatusDescription))
+			return 0
+		}
+	}
+}
+
+// formatDrainBatches formats a list of drain batches
+func formatDrainBatches(batches []*api.DrainBatch) string {
+	rows := make([]string, len(batches)+1)
+	rows[0] = "ID|Status|Nodes|Description"
+	for i, b := range batches {
+		rows[i+1] = fmt.Sprintf("%s|%s|%d|%s", b.ID, b.Status, len(b.Nodes), b.StatusDescription)
+	}
+	return formatList(rows)
+}
+
+// parseNodeMeta parses the key=value pairs of -node-meta flags
+func parseNodeMeta(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	meta := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid node meta %q: must be key=value", pair)
+		}
+		meta[kv[0]] = kv[1]
+	}
+	return meta, nil
+}
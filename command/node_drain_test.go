@@ -369,7 +369,7 @@ func TestNodeDrainCommand_Fails(t *testing.T) {
 	ui.ErrorWriter.Reset()
 
 	// Fail on disable being used with drain strategy flags
-	for _, flag := range []string{"-force", "-no-deadline", "-ignore-system"} {
+	for _, flag := range []string{"-force", "-no-deadline", "-ignore-system", "-skip-job=web", "-job-deadline=web=1m", "-priority-deadline=1-50=1m"} {
 		if code := cmd.Run([]string{"-address=" + url, "-disable", flag, "12345678-abcd-efab-cdef-123456789abc"}); code != 1 {
 			t.Fatalf("expected exit 1, got: %d", code)
 		}
@@ -411,6 +411,228 @@ func TestNodeDrainCommand_Fails(t *testing.T) {
 	}
 }
 
+func TestNodeDrainCommand_ParseDrainDeadline(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value      string
+		byPriority bool
+		expected   *api.DrainDeadline
+		err        string
+	}{
+		{
+			value:    "web=10m",
+			expected: &api.DrainDeadline{JobID: "web", Deadline: 10 * time.Minute},
+		},
+		{
+			value:    "web=force",
+			expected: &api.DrainDeadline{JobID: "web", Deadline: -1 * time.Second},
+		},
+		{
+			value:    "web=none",
+			expected: &api.DrainDeadline{JobID: "web"},
+		},
+		{
+			value:      "70-100=1h",
+			byPriority: true,
+			expected:   &api.DrainDeadline{MinPriority: 70, MaxPriority: 100, Deadline: time.Hour},
+		},
+		{
+			value: "web",
+			err:   "must be formatted as <selector>=<deadline>",
+		},
+		{
+			value: "web=-1m",
+			err:   "must be positive",
+		},
+		{
+			value: "web=soon",
+			err:   "invalid deadline",
+		},
+		{
+			value:      "70=1h",
+			byPriority: true,
+			err:        "must be formatted as <min>-<max>",
+		},
+		{
+			value:      "low-100=1h",
+			byPriority: true,
+			err:        "invalid priority band",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			out, err := parseDrainDeadline(tc.value, tc.byPriority)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
+
+func TestNodeDrainCommand_Batch_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NodeDrainCommand{Meta: Meta{Ui: ui}}
+
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{"-enable", "-datacenter=dc1", "-max-parallel=1", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "-datacenter, -node-class and -node-meta require -batch or -window",
+		},
+		{
+			args: []string{"-batch", "-enable", "-self", "-datacenter=dc1", "-max-parallel=1"},
+			err:  "-batch can't be combined with",
+		},
+		{
+			args: []string{"-batch", "-disable", "-datacenter=dc1", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "can only be set when creating a drain batch",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-enable", "-max-parallel=1"},
+			err:  "requires at least one of -datacenter, -node-class or -node-meta",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-enable", "-datacenter=dc1"},
+			err:  "requires -max-parallel or -max-parallel-percent",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-enable", "-datacenter=dc1", "-max-parallel=1", "12345678"},
+			err:  "takes no arguments",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-enable", "-node-meta=rack", "-max-parallel=1"},
+			err:  "must be key=value",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-disable"},
+			err:  "Drain batch ID must be specified",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-disable", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "No drain batch(es) with prefix or ID",
+		},
+		{
+			args: []string{"-address=" + url, "-batch", "-enable", "-datacenter=dc1", "-max-parallel=1"},
+			err:  "no nodes that aren't down or draining match the filter",
+		},
+	}
+
+	for _, tc := range cases {
+		if code := cmd.Run(tc.args); code != 1 {
+			t.Fatalf("%v: expected exit 1, got: %d", tc.args, code)
+		}
+		if out := ui.ErrorWriter.String(); !strings.Contains(out, tc.err) {
+			t.Fatalf("%v: expected %q, got: %s", tc.args, tc.err, out)
+		}
+		ui.ErrorWriter.Reset()
+	}
+}
+
+func TestNodeDrainCommand_Window_Fails(t *testing.T) {
+	t.Parallel()
+	srv, _, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NodeDrainCommand{Meta: Meta{Ui: ui}}
+
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{"-enable", "-duration=1h", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "-start, -duration and -lead-time require -window",
+		},
+		{
+			args: []string{"-batch", "-window", "-enable", "-datacenter=dc1"},
+			err:  "-batch and -window are mutually exclusive",
+		},
+		{
+			args: []string{"-window", "-enable", "-self", "-duration=1h"},
+			err:  "-window can't be combined with",
+		},
+		{
+			args: []string{"-window", "-enable", "-datacenter=dc1", "-duration=1h", "-max-parallel=1"},
+			err:  "-max-parallel and -max-parallel-percent require -batch",
+		},
+		{
+			args: []string{"-window", "-disable", "-duration=1h", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "can only be set when scheduling a maintenance window",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-enable", "-duration=1h"},
+			err:  "requires node IDs or at least one of -datacenter, -node-class or -node-meta",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-enable", "-datacenter=dc1"},
+			err:  "-window requires -duration",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-enable", "-datacenter=dc1", "-duration=1h", "-start=tomorrow"},
+			err:  "must be an RFC3339 time or a duration from now",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-enable", "-datacenter=dc1", "-duration=-1h"},
+			err:  "A positive window duration must be given",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-enable", "-duration=1h", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "No node(s) with prefix or id",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-disable"},
+			err:  "Maintenance window ID must be specified",
+		},
+		{
+			args: []string{"-address=" + url, "-window", "-disable", "12345678-abcd-efab-cdef-123456789abc"},
+			err:  "No maintenance window(s) with prefix or ID",
+		},
+	}
+
+	for _, tc := range cases {
+		if code := cmd.Run(tc.args); code != 1 {
+			t.Fatalf("%v: expected exit 1, got: %d", tc.args, code)
+		}
+		if out := ui.ErrorWriter.String(); !strings.Contains(out, tc.err) {
+			t.Fatalf("%v: expected %q, got: %s", tc.args, tc.err, out)
+		}
+		ui.ErrorWriter.Reset()
+	}
+}
+
+func TestNodeDrainCommand_ParseWindowStart(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	start, err := parseWindowStart("", now)
+	require.NoError(t, err)
+	require.Equal(t, now, start)
+
+	start, err = parseWindowStart("90m", now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(90*time.Minute), start)
+
+	start, err = parseWindowStart("2021-03-02T04:00:00Z", now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2021, 3, 2, 4, 0, 0, 0, time.UTC), start)
+
+	_, err = parseWindowStart("-1h", now)
+	require.Error(t, err)
+}
+
 func TestNodeDrainCommand_AutocompleteArgs(t *testing.T) {
 	assert := assert.New(t)
 	t.Parallel()
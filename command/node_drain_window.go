@@ -0,0 +1,172 @@
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+)
+
+// nodeDrainWindowArgs are the flags of a node drain command run with -window
+type nodeDrainWindowArgs struct {
+	enable bool
+
+	spec     *api.DrainSpec
+	filter   *api.DrainBatchFilter
+	start    string
+	duration string
+	leadTime string
+}
+
+// runWindow schedules or cancels a maintenance window.
+func (c *NodeDrainCommand) runWindow(client *api.Client, args []string, opts *nodeDrainWindowArgs) int {
+	if !opts.enable {
+		return c.cancelWindow(client, args)
+	}
+
+	hasFilter := opts.filter.Datacenters != nil || opts.filter.NodeClass != "" || opts.filter.Meta != nil
+	if len(args) == 0 && !hasFilter {
+		c.Ui.Error("-window requires node IDs or at least one of -datacenter, -node-class or -node-meta")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	if opts.duration == "" {
+		c.Ui.Error("-window requires -duration")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	now := time.Now()
+	start, err := parseWindowStart(opts.start, now)
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+	duration, err := time.ParseDuration(opts.duration)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to parse duration %q: %v", opts.duration, err))
+		return 1
+	}
+	if duration <= 0 {
+		c.Ui.Error("A positive window duration must be given")
+		return 1
+	}
+	var leadTime time.Duration
+	if opts.leadTime != "" {
+		if leadTime, err = time.ParseDuration(opts.leadTime); err != nil {
+			c.Ui.Error(fmt.Sprintf("Failed to parse lead time %q: %v", opts.leadTime, err))
+			return 1
+		}
+		if leadTime < 0 {
+			c.Ui.Error("Lead time must not be negative")
+			return 1
+		}
+	}
+
+	// Resolve the node ID prefixes
+	var nodeIDs []string
+	for _, arg := range args {
+		if len(arg) == 1 {
+			c.Ui.Error("Identifier must contain at least two characters.")
+			return 1
+		}
+		prefix := sanitizeUUIDPrefix(arg)
+		nodes, _, err := client.Nodes().PrefixList(prefix)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error retrieving node: %s", err))
+			return 1
+		}
+		if len(nodes) == 0 {
+			c.Ui.Error(fmt.Sprintf("No node(s) with prefix or id %q found", prefix))
+			return 1
+		}
+		if len(nodes) > 1 {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple nodes\n\n%s",
+				formatNodeStubList(nodes, true)))
+			return 1
+		}
+		nodeIDs = append(nodeIDs, nodes[0].ID)
+	}
+
+	window := &api.NodeMaintenanceWindow{
+		NodeIDs:   nodeIDs,
+		StartTime: start,
+		Duration:  duration,
+		LeadTime:  leadTime,
+		DrainSpec: opts.spec,
+	}
+	if hasFilter {
+		window.Filter = opts.filter
+	}
+
+	resp, _, err := client.NodeMaintenanceWindows().Create(window, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating maintenance window: %s", err))
+		return 1
+	}
+
+	window = resp.Window
+	c.Ui.Output(fmt.Sprintf("%s: Maintenance window %q scheduled from %s to %s",
+		formatTime(now), window.ID, formatTime(window.StartTime), formatTime(window.EndTime())))
+	return 0
+}
+
+// cancelWindow cancels the maintenance window given as argument.
+func (c *NodeDrainCommand) cancelWindow(client *api.Client, args []string) int {
+	if len(args) != 1 {
+		c.Ui.Error("Maintenance window ID must be specified with -window -disable")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	windowID := sanitizeUUIDPrefix(args[0])
+	windows, _, err := client.NodeMaintenanceWindows().PrefixList(windowID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving maintenance window: %s", err))
+		return 1
+	}
+	if len(windows) == 0 {
+		c.Ui.Error(fmt.Sprintf("No maintenance window(s) with prefix or ID %q found", windowID))
+		return 1
+	}
+	if len(windows) > 1 {
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple maintenance windows\n\n%s", formatNodeMaintenanceWindows(windows)))
+		return 1
+	}
+	window := windows[0]
+
+	if _, err := client.NodeMaintenanceWindows().Cancel(window.ID, nil); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error cancelling maintenance window: %s", err))
+		return 1
+	}
+	c.Ui.Output(fmt.Sprintf("Maintenance window %q cancelled", window.ID))
+	return 0
+}
+
+// parseWindowStart parses the start of a maintenance window, given either as
+// an RFC3339 time or as a duration from now. The window starts now if the
+// start isn't set.
+func parseWindowStart(start string, now time.Time) (time.Time, error) {
+	if start == "" {
+		return now, nil
+	}
+	if t, err := time.Parse(time.RFC3339, start); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(start)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("Failed to parse start %q: must be an RFC3339 time or a duration from now", start)
+	}
+	return now.Add(d), nil
+}
+
+// formatNodeMaintenanceWindows formats a list of maintenance windows
+func formatNodeMaintenanceWindows(windows []*api.NodeMaintenanceWindow) string {
+	rows := make([]string, len(windows)+1)
+	rows[0] = "ID|Status|Start|End|Description"
+	for i, w := range windows {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s", w.ID, w.Status,
+			formatTime(w.StartTime), formatTime(w.EndTime()), w.StatusDescription)
+	}
+	return formatList(rows)
+}
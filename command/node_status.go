@@ -446,6 +446,9 @@ func (c *NodeStatusCommand) formatNode(client *api.Client, node *api.Node) int {
 	// Emit node events
 	c.outputNodeStatusEvents(node)
 
+	// Emit the maintenance windows of the node
+	c.outputNodeMaintenanceWindows(client, node)
+
 	// Get list of running allocations on the node
 	allocatedResources := getAllocatedResources(client, runningAllocs, node)
 	c.Ui.Output(c.Colorize().Color("\n[bold]Allocated Resources[reset]"))
@@ -625,6 +628,34 @@ func (c *NodeStatusCommand) outputNodeDriverInfo(node *api.Node) {
 	c.Ui.Output(formatList(nodeDrivers))
 }
 
+// outputNodeMaintenanceWindows outputs the maintenance windows that include the
+// node. Windows that ended are only included in verbose mode.
+func (c *NodeStatusCommand) outputNodeMaintenanceWindows(client *api.Client, node *api.Node) {
+	windows, _, err := client.NodeMaintenanceWindows().ListByNode(node.ID, nil)
+	if err != nil {
+		c.Ui.Output("")
+		c.Ui.Error(fmt.Sprintf("error fetching maintenance windows: %v", err))
+		return
+	}
+
+	var shown []*api.NodeMaintenanceWindow
+	for _, window := range windows {
+		switch window.Status {
+		case api.NodeMaintenanceWindowStatusPending, api.NodeMaintenanceWindowStatusActive:
+			shown = append(shown, window)
+		default:
+			if c.verbose {
+				shown = append(shown, window)
+			}
+		}
+	}
+
+	if len(shown) > 0 {
+		c.Ui.Output(c.Colorize().Color("\n[bold]Maintenance Windows[reset]"))
+		c.Ui.Output(formatNodeMaintenanceWindows(shown))
+	}
+}
+
 func (c *NodeStatusCommand) outputNodeStatusEvents(node *api.Node) {
 	c.Ui.Output(c.Colorize().Color("\n[bold]Node Events"))
 	c.outputNodeEvent(node.Events)
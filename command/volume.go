@@ -32,6 +32,10 @@ Usage: nomad volume <subcommand> [options]
 
       $ nomad volume detach <vol id> <node id>
 
+  Release the claims of terminal allocations on a volume:
+
+      $ nomad volume reclaim <vol id>
+
   Create an external volume and register it:
 
       $ nomad volume create <input>
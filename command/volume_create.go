@@ -17,23 +17,35 @@ func (c *VolumeCreateCommand) Help() string {
 	helpText := `
 Usage: nomad volume create [options] <input>
 
-  Creates a volume in an external storage provider and registers it in Nomad.
+  Creates a volume in an external storage provider and registers it in Nomad,
+  or creates a dynamic host volume on a Nomad client.
 
   If the supplied path is "-" the volume file is read from stdin. Otherwise, it
   is read from the file at the supplied path.
 
   When ACLs are enabled, this command requires a token with the
-  'csi-write-volume' capability for the volume's namespace.
+  'csi-write-volume' capability for the volume's namespace, or the
+  'host-volume-write' capability for host volumes.
 
 General Options:
 
-  ` + generalOptionsUsage(usageOptsDefault)
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Create Options:
+
+  -type <type>
+    Type of the volume to create, either "csi" or "host". Overrides the type
+    in the volume specification.
+`
 
 	return strings.TrimSpace(helpText)
 }
 
 func (c *VolumeCreateCommand) AutocompleteFlags() complete.Flags {
-	return c.Meta.AutocompleteFlags(FlagSetClient)
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-type": complete.PredictSet("csi", "host"),
+		})
 }
 
 func (c *VolumeCreateCommand) AutocompleteArgs() complete.Predictor {
@@ -49,6 +61,8 @@ func (c *VolumeCreateCommand) Name() string { return "volume create" }
 func (c *VolumeCreateCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	var typeArg string
+	flags.StringVar(&typeArg, "type", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
@@ -86,6 +100,9 @@ func (c *VolumeCreateCommand) Run(args []string) int {
 		c.Ui.Error(fmt.Sprintf("Error parsing the volume type: %s", err))
 		return 1
 	}
+	if typeArg != "" {
+		volType = typeArg
+	}
 
 	// Get the HTTP client
 	client, err := c.Meta.Client()
@@ -98,6 +115,8 @@ func (c *VolumeCreateCommand) Run(args []string) int {
 	case "csi":
 		code := c.csiCreate(client, ast)
 		return code
+	case "host":
+		return c.hostVolumeCreate(client, ast)
 	default:
 		c.Ui.Error(fmt.Sprintf("Error unknown volume type: %s", volType))
 		return 1
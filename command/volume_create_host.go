@@ -0,0 +1,114 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/ast"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/mitchellh/mapstructure"
+)
+
+func (c *VolumeCreateCommand) hostVolumeCreate(client *api.Client, ast *ast.File) int {
+	vol, err := hostVolumeDecode(ast)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error decoding the volume definition: %s", err))
+		return 1
+	}
+
+	vol, _, err = client.HostVolumes().Create(vol, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating volume: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(fmt.Sprintf(
+		"Created host volume %s with ID %s on node %s", vol.Name, vol.ID, vol.NodeID))
+	return 0
+}
+
+func hostVolumeDecode(input *ast.File) (*api.HostVolume, error) {
+	var err error
+	vol := &api.HostVolume{}
+
+	list, ok := input.Node.(*ast.ObjectList)
+	if !ok {
+		return nil, fmt.Errorf("error parsing: root should be an object")
+	}
+
+	valid := []string{
+		"type",
+		"id",
+		"name",
+		"namespace",
+		"plugin_id",
+		"node_id",
+		"constraint",
+		"capacity_min",
+		"capacity_max",
+		"parameters",
+		"uid",
+		"gid",
+		"mode",
+	}
+	if err := helper.CheckHCLKeys(list, valid); err != nil {
+		return nil, err
+	}
+
+	// Decode the full thing into a map[string]interface for ease
+	var m map[string]interface{}
+	err = hcl.DecodeObject(&m, list)
+	if err != nil {
+		return nil, err
+	}
+
+	// Need to manually parse these fields
+	delete(m, "constraint")
+	delete(m, "capacity_max")
+	delete(m, "capacity_min")
+	delete(m, "type")
+
+	// Decode the rest
+	err = mapstructure.WeakDecode(m, vol)
+	if err != nil {
+		return nil, err
+	}
+
+	capacityMin, err := parseCapacityBytes(list.Filter("capacity_min"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity_min: %v", err)
+	}
+	vol.RequestedCapacityMinBytes = capacityMin
+	capacityMax, err := parseCapacityBytes(list.Filter("capacity_max"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid capacity_max: %v", err)
+	}
+	vol.RequestedCapacityMaxBytes = capacityMax
+
+	cObj := list.Filter("constraint")
+	if len(cObj.Items) > 0 {
+		for _, o := range cObj.Elem().Items {
+			valid := []string{"attribute", "operator", "value"}
+			if err := helper.CheckHCLKeys(o.Val, valid); err != nil {
+				return nil, err
+			}
+
+			var m map[string]interface{}
+			if err := hcl.DecodeObject(&m, o.Val); err != nil {
+				return nil, err
+			}
+			attr, _ := m["attribute"].(string)
+			value, _ := m["value"].(string)
+			operator, _ := m["operator"].(string)
+			if operator == "" {
+				operator = "="
+			}
+			vol.Constraints = append(vol.Constraints,
+				api.NewConstraint(attr, strings.TrimSpace(operator), value))
+		}
+	}
+
+	return vol, nil
+}
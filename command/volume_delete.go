@@ -22,21 +22,30 @@ Usage: nomad volume delete [options] <vol id>
   unpublished. If the volume no longer exists, this command will silently
   return without an error.
 
+  With -type=host, delete a dynamic host volume from its node. Deleting will
+  fail if the volume is in use by a running allocation.
+
   When ACLs are enabled, this command requires a token with the
   'csi-write-volume' and 'csi-read-volume' capabilities for the volume's
-  namespace.
+  namespace, or the 'host-volume-write' capability for host volumes.
 
 General Options:
 
   ` + generalOptionsUsage(usageOptsDefault) + `
 
+Delete Options:
+
+  -type <type>
+    Type of the volume to delete, either "csi" or "host". Defaults to "csi".
 `
 	return strings.TrimSpace(helpText)
 }
 
 func (c *VolumeDeleteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
-		complete.Flags{})
+		complete.Flags{
+			"-type": complete.PredictSet("csi", "host"),
+		})
 }
 
 func (c *VolumeDeleteCommand) AutocompleteArgs() complete.Predictor {
@@ -70,6 +79,8 @@ func (c *VolumeDeleteCommand) Name() string { return "volume delete" }
 func (c *VolumeDeleteCommand) Run(args []string) int {
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	var typeArg string
+	flags.StringVar(&typeArg, "type", "csi", "")
 
 	if err := flags.Parse(args); err != nil {
 		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
@@ -92,7 +103,15 @@ func (c *VolumeDeleteCommand) Run(args []string) int {
 		return 1
 	}
 
-	err = client.CSIVolumes().Delete(volID, nil)
+	switch typeArg {
+	case "csi":
+		err = client.CSIVolumes().Delete(volID, nil)
+	case "host":
+		_, err = client.HostVolumes().Delete(volID, nil)
+	default:
+		c.Ui.Error(fmt.Sprintf("Error unknown volume type: %s", typeArg))
+		return 1
+	}
 	if err != nil {
 		c.Ui.Error(fmt.Sprintf("Error deleting volume: %s", err))
 		return 1
@@ -0,0 +1,168 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type VolumeReclaimCommand struct {
+	Meta
+}
+
+func (c *VolumeReclaimCommand) Help() string {
+	helpText := `
+Usage: nomad volume reclaim [options] <vol id>
+
+  Reclaim releases the claims on a CSI volume of allocations that are
+  terminal or have been garbage collected. Nomad releases these claims on its
+  own, but they can be left behind if the node plugin or controller plugin
+  fails to unpublish the volume. Claims on nodes that are down or have been
+  garbage collected are released without unpublishing the volume from the
+  node. Claims of running allocations are never released.
+
+  Use 'nomad volume status -verbose' to see the claim history of the volume,
+  including the errors that left the claims behind.
+
+  When ACLs are enabled, this command requires a token with the
+  'csi-write-volume' and 'csi-read-volume' capabilities for the volume's
+  namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Reclaim Options:
+
+  -dry-run
+    List the claims that would be released, without releasing them.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *VolumeReclaimCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-dry-run": complete.PredictNothing,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *VolumeReclaimCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Volumes, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Volumes]
+	})
+}
+
+func (c *VolumeReclaimCommand) Synopsis() string {
+	return "Release the claims of terminal allocations on a volume"
+}
+
+func (c *VolumeReclaimCommand) Name() string { return "volume reclaim" }
+
+func (c *VolumeReclaimCommand) Run(args []string) int {
+	var dryRun, verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error parsing arguments %s", err))
+		return 1
+	}
+
+	// Check that we get exactly one argument
+	args = flags.Args()
+	if l := len(args); l != 1 {
+		c.Ui.Error("This command takes one argument: <vol id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+	volID := args[0]
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Prefix search for the volume
+	vols, _, err := client.CSIVolumes().List(&api.QueryOptions{Prefix: volID})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volumes: %s", err))
+		return 1
+	}
+	if len(vols) > 1 {
+		sort.Slice(vols, func(i, j int) bool { return vols[i].ID < vols[j].ID })
+		out, err := csiFormatSortedVolumes(vols, length)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple volumes\n\n%s", out))
+		return 1
+	}
+	if len(vols) == 0 {
+		c.Ui.Error(fmt.Sprintf("No volumes(s) with prefix or ID %q found", volID))
+		return 1
+	}
+	volID = vols[0].ID
+
+	claims, _, err := client.CSIVolumes().Reclaim(volID, dryRun, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error reclaiming volume: %s", err))
+		return 1
+	}
+
+	if len(claims) == 0 {
+		c.Ui.Output(fmt.Sprintf("No claims to release on volume %q", volID))
+		return 0
+	}
+
+	if dryRun {
+		c.Ui.Output(fmt.Sprintf("Claims that would be released on volume %q:\n", volID))
+	} else {
+		c.Ui.Output(fmt.Sprintf("Releasing claims on volume %q:\n", volID))
+	}
+	c.Ui.Output(formatReclaimedClaims(claims, length))
+	return 0
+}
+
+func formatReclaimedClaims(claims []*api.CSIVolumeReclaimedClaim, length int) string {
+	sort.Slice(claims, func(i, j int) bool { return claims[i].AllocationID < claims[j].AllocationID })
+
+	rows := make([]string, len(claims)+1)
+	rows[0] = "Alloc ID|Node ID|Reason"
+	for i, claim := range claims {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s",
+			limit(claim.AllocationID, length),
+			limit(claim.NodeID, length),
+			claim.Reason,
+		)
+	}
+	return formatList(rows)
+}
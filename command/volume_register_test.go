@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/hcl"
 	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,6 +85,16 @@ capability {
   access_mode     = "single-node-reader-only"
   attachment_mode = "block-device"
 }
+
+topology_request {
+  required {
+    topology { segments { rack = "R1" } }
+    topology { segments { rack = "R2" } }
+  }
+  preferred {
+    topology { segments { rack = "R1" } }
+  }
+}
 `,
 		expected: &api.CSIVolume{
 			ID:                   "testvolume",
@@ -106,6 +117,15 @@ capability {
 				FSType:     "ext4",
 				MountFlags: []string{"ro"},
 			},
+			RequestedTopologies: &api.CSITopologyRequest{
+				Required: []*api.CSITopology{
+					{Segments: map[string]string{"rack": "R1"}},
+					{Segments: map[string]string{"rack": "R2"}},
+				},
+				Preferred: []*api.CSITopology{
+					{Segments: map[string]string{"rack": "R1"}},
+				},
+			},
 			Parameters: map[string]string{"skuname": "Premium_LRS"},
 			Secrets:    map[string]string{"password": "xyzzy"},
 		},
@@ -157,3 +177,69 @@ capability {
 
 	}
 }
+
+func TestHostVolumeDecode(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		hcl      string
+		expected *api.HostVolume
+		err      string
+	}{{
+		name: "volume creation",
+		hcl: `
+name         = "database"
+type         = "host"
+plugin_id    = "mkdir"
+capacity_min = "10GiB"
+capacity_max = "20G"
+uid          = 1000
+mode         = "0750"
+
+parameters {
+  foo = "bar"
+}
+
+constraint {
+  attribute = "${attr.kernel.name}"
+  value     = "linux"
+}
+`,
+		expected: &api.HostVolume{
+			Name:                      "database",
+			PluginID:                  "mkdir",
+			RequestedCapacityMinBytes: 10737418240,
+			RequestedCapacityMaxBytes: 20000000000,
+			UID:                       helper.IntToPtr(1000),
+			Mode:                      "0750",
+			Parameters:                map[string]string{"foo": "bar"},
+			Constraints: []*api.Constraint{
+				api.NewConstraint("${attr.kernel.name}", "=", "linux"),
+			},
+		},
+	}, {
+		name: "unknown key",
+		hcl: `
+name      = "database"
+type      = "host"
+plugin_id = "mkdir"
+node_pool = "default"
+`,
+		err: "invalid key: node_pool",
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ast, err := hcl.ParseString(c.hcl)
+			require.NoError(t, err)
+			vol, err := hostVolumeDecode(ast)
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Contains(t, err.Error(), c.err)
+			}
+			require.Equal(t, c.expected, vol)
+		})
+	}
+}
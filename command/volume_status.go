@@ -21,12 +21,13 @@ func (c *VolumeStatusCommand) Help() string {
 	helpText := `
 Usage: nomad volume status [options] <id>
 
-  Display status information about a CSI volume. If no volume id is given, a
-  list of all volumes will be displayed.
+  Display status information about a CSI volume, or a dynamic host volume
+  with -type=host. If no volume id is given, a list of all volumes will be
+  displayed.
 
   When ACLs are enabled, this command requires a token with the
   'csi-read-volume' and 'csi-list-volumes' capability for the volume's
-  namespace.
+  namespace, or the 'host-volume-read' capability for host volumes.
 
 General Options:
 
@@ -60,7 +61,7 @@ func (c *VolumeStatusCommand) Synopsis() string {
 func (c *VolumeStatusCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-type":    predictVolumeType,
+			"-type":    complete.PredictSet("csi", "host"),
 			"-short":   complete.PredictNothing,
 			"-verbose": complete.PredictNothing,
 			"-json":    complete.PredictNothing,
@@ -123,6 +124,10 @@ func (c *VolumeStatusCommand) Run(args []string) int {
 		id = args[0]
 	}
 
+	if typeArg == "host" {
+		return c.hostVolumeStatus(client, id)
+	}
+
 	code := c.csiStatus(client, id)
 	if code != 0 {
 		return code
@@ -205,48 +205,78 @@ func (c *VolumeStatusCommand) formatBasic(vol *api.CSIVolume) (string, error) {
 		fmt.Sprintf("Namespace|%s", vol.Namespace),
 	}
 
+	full := []string{formatKV(output)}
+
+	if len(vol.Topologies) > 0 {
+		topoBanner := c.Colorize().Color("\n[bold]Topology[reset]")
+		full = append(full, topoBanner, c.formatTopologies(vol))
+	}
+
 	// Exit early
 	if c.short {
-		return formatKV(output), nil
+		return strings.Join(full, "\n"), nil
 	}
 
 	// Format the allocs
 	banner := c.Colorize().Color("\n[bold]Allocations[reset]")
 	allocs := formatAllocListStubs(vol.Allocations, c.verbose, c.length)
-	full := []string{formatKV(output), banner, allocs}
+	full = append(full, banner, allocs)
+
+	if c.verbose && len(vol.ClaimHistory) > 0 {
+		historyBanner := c.Colorize().Color("\n[bold]Claim History[reset]")
+		full = append(full, historyBanner, formatClaimHistory(vol.ClaimHistory, c.length))
+	}
+
 	return strings.Join(full, "\n"), nil
 }
 
-func (c *VolumeStatusCommand) formatTopologies(vol *api.CSIVolume) string {
-	var out []string
+// formatClaimHistory formats the claim events of a volume, newest first
+func formatClaimHistory(history []*api.CSIVolumeClaimEvent, length int) string {
+	rows := make([]string, 0, len(history)+1)
+	rows = append(rows, "Time|Alloc ID|Node ID|Event|Error")
+	for i := len(history) - 1; i >= 0; i-- {
+		e := history[i]
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s",
+			formatUnixNanoTime(e.Time),
+			limit(e.AllocationID, length),
+			limit(e.NodeID, length),
+			e.Type,
+			e.Error,
+		))
+	}
+	return formatList(rows)
+}
 
-	// Find the union of all the keys
-	head := map[string]string{}
+func (c *VolumeStatusCommand) formatTopologies(vol *api.CSIVolume) string {
+	// Find the union of all the keys, so each topology is a row of the table
+	keys := []string{}
+	seen := map[string]bool{}
 	for _, t := range vol.Topologies {
+		if t == nil {
+			continue
+		}
 		for key := range t.Segments {
-			if _, ok := head[key]; !ok {
-				head[key] = ""
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
 			}
 		}
 	}
+	sort.Strings(keys)
 
-	// Append the header
-	var line []string
-	for key := range head {
-		line = append(line, key)
-	}
-	out = append(out, strings.Join(line, " "))
-
-	// Append each topology
+	rows := []string{strings.Join(keys, "|")}
 	for _, t := range vol.Topologies {
-		line = []string{}
-		for key := range head {
+		if t == nil {
+			continue
+		}
+		line := make([]string, 0, len(keys))
+		for _, key := range keys {
 			line = append(line, t.Segments[key])
 		}
-		out = append(out, strings.Join(line, " "))
+		rows = append(rows, strings.Join(line, "|"))
 	}
 
-	return strings.Join(out, "\n")
+	return formatList(rows)
 }
 
 func csiVolMountOption(volume, request *api.CSIMountOptions) string {
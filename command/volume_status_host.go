@@ -0,0 +1,117 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/hashicorp/nomad/api"
+)
+
+func (c *VolumeStatusCommand) hostVolumeStatus(client *api.Client, id string) int {
+	// Invoke list mode if no volume id
+	if id == "" {
+		return c.hostVolumeList(client)
+	}
+
+	// Prefix search for the volume
+	vols, _, err := client.HostVolumes().List(&api.QueryOptions{Prefix: id})
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volumes: %s", err))
+		return 1
+	}
+	if len(vols) > 1 {
+		out, err := c.hostVolumeFormatList(vols)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+			return 1
+		}
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple volumes\n\n%s", out))
+		return 1
+	}
+	if len(vols) == 0 {
+		c.Ui.Error(fmt.Sprintf("No volumes(s) with prefix or ID %q found", id))
+		return 1
+	}
+
+	vol, _, err := client.HostVolumes().Info(vols[0].ID, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volume: %s", err))
+		return 1
+	}
+
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, vol)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	output := []string{
+		fmt.Sprintf("ID|%s", vol.ID),
+		fmt.Sprintf("Name|%s", vol.Name),
+		fmt.Sprintf("Namespace|%s", vol.Namespace),
+		fmt.Sprintf("Plugin ID|%s", vol.PluginID),
+		fmt.Sprintf("Node ID|%s", vol.NodeID),
+		fmt.Sprintf("State|%s", vol.State),
+		fmt.Sprintf("Capacity|%s", humanize.IBytes(uint64(vol.CapacityBytes))),
+		fmt.Sprintf("Host Path|%s", vol.HostPath),
+	}
+	c.Ui.Output(formatKV(output))
+	return 0
+}
+
+func (c *VolumeStatusCommand) hostVolumeList(client *api.Client) int {
+	if !(c.json || len(c.template) > 0) {
+		c.Ui.Output(c.Colorize().Color("[bold]Dynamic Host Volumes[reset]"))
+	}
+
+	vols, _, err := client.HostVolumes().List(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying volumes: %s", err))
+		return 1
+	}
+
+	if len(vols) == 0 {
+		// No output if we have no volumes
+		c.Ui.Error("No dynamic host volumes")
+		return 0
+	}
+
+	str, err := c.hostVolumeFormatList(vols)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error formatting: %s", err))
+		return 1
+	}
+	c.Ui.Output(str)
+	return 0
+}
+
+func (c *VolumeStatusCommand) hostVolumeFormatList(vols []*api.HostVolume) (string, error) {
+	// Sort the output by volume id
+	sort.Slice(vols, func(i, j int) bool { return vols[i].ID < vols[j].ID })
+
+	if c.json || len(c.template) > 0 {
+		out, err := Format(c.json, c.template, vols)
+		if err != nil {
+			return "", fmt.Errorf("format error: %v", err)
+		}
+		return out, nil
+	}
+
+	rows := make([]string, len(vols)+1)
+	rows[0] = "ID|Name|Plugin ID|Node ID|State"
+	for i, v := range vols {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
+			limit(v.ID, c.length),
+			v.Name,
+			v.PluginID,
+			limit(v.NodeID, c.length),
+			v.State,
+		)
+	}
+	return formatList(rows), nil
+}
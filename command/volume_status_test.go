@@ -3,6 +3,7 @@ package command
 import (
 	"testing"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/cli"
@@ -56,3 +57,25 @@ func TestCSIVolumeStatusCommand_AutocompleteArgs(t *testing.T) {
 	require.Equal(t, 1, len(res))
 	require.Equal(t, vol.ID, res[0])
 }
+
+func TestCSIVolumeStatusCommand_Topology(t *testing.T) {
+	t.Parallel()
+	ui := cli.NewMockUi()
+	cmd := &VolumeStatusCommand{Meta: Meta{Ui: ui}, short: true}
+
+	vol := &api.CSIVolume{
+		ID:       "ebs-vol",
+		PluginID: "aws-ebs",
+		Topologies: []*api.CSITopology{
+			{Segments: map[string]string{"region": "us-east-1", "zone": "us-east-1a"}},
+			{Segments: map[string]string{"zone": "us-east-1b"}},
+		},
+	}
+
+	out, err := cmd.formatBasic(vol)
+	require.NoError(t, err)
+	require.Contains(t, out, "Topology")
+	require.Regexp(t, `region\s+zone`, out)
+	require.Regexp(t, `us-east-1\s+us-east-1a`, out)
+	require.Regexp(t, `<none>\s+us-east-1b`, out)
+}
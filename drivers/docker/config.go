@@ -411,6 +411,7 @@ var (
 		},
 		MustInitiateNetwork: true,
 		MountConfigs:        drivers.MountConfigSupportAll,
+		Resize:              true,
 	}
 )
 
@@ -1086,7 +1086,7 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 
 		for _, port := range driverConfig.Ports {
 			if mapping, ok := task.Resources.Ports.Get(port); ok {
-				ports.add(mapping.Label, mapping.HostIP, mapping.Value, mapping.To)
+				ports.add(mapping.Label, mapping.HostIP, mapping.Value, mapping.To, mapping.Protocol)
 			} else {
 				return c, fmt.Errorf("Port %q not found, check network stanza", port)
 			}
@@ -1095,11 +1095,11 @@ func (d *Driver) createContainerConfig(task *drivers.TaskConfig, driverConfig *T
 		network := task.Resources.NomadResources.Networks[0]
 
 		for _, port := range network.ReservedPorts {
-			ports.addMapped(port.Label, network.IP, port.Value, driverConfig.PortMap)
+			ports.addMapped(port.Label, network.IP, port.Value, port.Protocol, driverConfig.PortMap)
 		}
 
 		for _, port := range network.DynamicPorts {
-			ports.addMapped(port.Label, network.IP, port.Value, driverConfig.PortMap)
+			ports.addMapped(port.Label, network.IP, port.Value, port.Protocol, driverConfig.PortMap)
 		}
 
 	default:
@@ -1511,6 +1511,52 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return h.Signal(context.Background(), sig)
 }
 
+// UpdateTaskResources applies new memory and CPU limits to a running
+// container.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+	if resources == nil || resources.NomadResources == nil || resources.LinuxResources == nil {
+		return fmt.Errorf("resources must be set")
+	}
+
+	var driverConfig TaskConfig
+	if err := h.task.DecodeDriverConfig(&driverConfig); err != nil {
+		return fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	memory, memoryReservation := memoryLimits(driverConfig.MemoryHardLimit, resources.NomadResources.Memory)
+	opts := docker.UpdateContainerOptions{
+		Memory:            int(memory),
+		MemoryReservation: int(memoryReservation),
+		CPUShares:         int(resources.LinuxResources.CPUShares),
+	}
+
+	// Windows does not support MemorySwap #2193
+	if runtime.GOOS != "windows" {
+		opts.MemorySwap = int(memory)
+	}
+
+	// Recalculate the CPU quota the same way as when the container was
+	// created
+	if driverConfig.CPUHardLimit {
+		period := driverConfig.CPUCFSPeriod
+		if period == 0 {
+			period = resources.LinuxResources.CPUPeriod
+		}
+		opts.CPUPeriod = int(period)
+		opts.CPUQuota = int(resources.LinuxResources.PercentTicks*float64(period)) * runtime.NumCPU()
+	}
+
+	if err := h.client.UpdateContainer(h.containerID, opts); err != nil {
+		return fmt.Errorf("failed to update container resources: %v", err)
+	}
+
+	return nil
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	h, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -1528,6 +1574,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
+var _ drivers.ResizeDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, opts *drivers.ExecOptions) (*drivers.ExitResult, error) {
 	defer opts.Stdout.Close()
@@ -239,11 +239,15 @@ func (h *taskHandle) run() {
 		ID: h.containerID,
 	})
 	oom := false
+	var memLimit uint64
 	if ierr != nil {
 		h.logger.Error("failed to inspect container", "error", ierr)
 	} else if container.State.OOMKilled {
 		oom = true
 		werr = fmt.Errorf("OOM Killed")
+		if container.HostConfig != nil {
+			memLimit = uint64(container.HostConfig.Memory)
+		}
 	}
 
 	// Shutdown stats collection
@@ -262,10 +266,11 @@ func (h *taskHandle) run() {
 	// Set the result
 	h.exitResultLock.Lock()
 	h.exitResult = &drivers.ExitResult{
-		ExitCode:  exitCode,
-		Signal:    0,
-		OOMKilled: oom,
-		Err:       werr,
+		ExitCode:    exitCode,
+		Signal:      0,
+		OOMKilled:   oom,
+		Err:         werr,
+		MemoryLimit: memLimit,
 	}
 	h.exitResultLock.Unlock()
 	close(h.waitCh)
@@ -6,6 +6,7 @@ import (
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/helper/pluginutils/hclutils"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 // publishedPorts is a utility struct to keep track of the port bindings to publish.
@@ -26,7 +27,7 @@ func newPublishedPorts(logger hclog.Logger) *publishedPorts {
 }
 
 // addMapped adds the port to the structures the Docker API expects for declaring mapped ports
-func (p *publishedPorts) addMapped(label, ip string, port int, portMap hclutils.MapStrInt) {
+func (p *publishedPorts) addMapped(label, ip string, port int, protocol string, portMap hclutils.MapStrInt) {
 	// By default we will map the allocated port 1:1 to the container
 	containerPortInt := port
 
@@ -35,32 +36,23 @@ func (p *publishedPorts) addMapped(label, ip string, port int, portMap hclutils.
 		containerPortInt = mapped
 	}
 
-	p.add(label, ip, port, containerPortInt)
+	p.add(label, ip, port, containerPortInt, protocol)
 }
 
-// add adds a port binding for the given port mapping
-func (p *publishedPorts) add(label, ip string, port, to int) {
+// add adds a port binding for the given port mapping. A binding is created
+// for the port's protocol, or for both tcp and udp if it has none.
+func (p *publishedPorts) add(label, ip string, port, to int, protocol string) {
 	// if to is not set, use the port value per default docker functionality
 	if to == 0 {
 		to = port
 	}
 
-	// two docker port bindings are created for each port for tcp and udp
-	cPortTCP := docker.Port(strconv.Itoa(to) + "/tcp")
-	cPortUDP := docker.Port(strconv.Itoa(to) + "/udp")
 	binding := getPortBinding(ip, strconv.Itoa(port))
-
-	if _, ok := p.publishedPorts[cPortTCP]; !ok {
-		// initialize both tcp and udp binding slices since they are always created together
-		p.publishedPorts[cPortTCP] = []docker.PortBinding{}
-		p.publishedPorts[cPortUDP] = []docker.PortBinding{}
+	for _, proto := range structs.PortProtocols(protocol) {
+		cPort := docker.Port(strconv.Itoa(to) + "/" + proto)
+		p.publishedPorts[cPort] = append(p.publishedPorts[cPort], binding)
+		p.exposedPorts[cPort] = struct{}{}
 	}
-
-	p.publishedPorts[cPortTCP] = append(p.publishedPorts[cPortTCP], binding)
-	p.publishedPorts[cPortUDP] = append(p.publishedPorts[cPortUDP], binding)
 	p.logger.Debug("allocated static port", "ip", ip, "port", port, "label", label)
-
-	p.exposedPorts[cPortTCP] = struct{}{}
-	p.exposedPorts[cPortUDP] = struct{}{}
-	p.logger.Debug("exposed port", "port", port, "label", label)
+	p.logger.Debug("exposed port", "port", port, "label", label, "protocol", protocol)
 }
@@ -3,6 +3,7 @@ package docker
 import (
 	"testing"
 
+	docker "github.com/fsouza/go-dockerclient"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/nomad/helper/testlog"
@@ -10,10 +11,22 @@ import (
 
 func TestPublishedPorts_add(t *testing.T) {
 	p := newPublishedPorts(testlog.HCLogger(t))
-	p.add("label", "10.0.0.1", 1234, 80)
-	p.add("label", "10.0.0.1", 5678, 80)
+	p.add("label", "10.0.0.1", 1234, 80, "")
+	p.add("label", "10.0.0.1", 5678, 80, "")
 	for _, bindings := range p.publishedPorts {
 		require.Len(t, bindings, 2)
 	}
 	require.Len(t, p.exposedPorts, 2)
 }
+
+func TestPublishedPorts_add_Protocol(t *testing.T) {
+	p := newPublishedPorts(testlog.HCLogger(t))
+	p.add("dns", "10.0.0.1", 53, 53, "udp")
+	p.add("sctp", "10.0.0.1", 3868, 0, "sctp")
+
+	require.Len(t, p.exposedPorts, 2)
+	require.Contains(t, p.exposedPorts, docker.Port("53/udp"))
+	require.Contains(t, p.exposedPorts, docker.Port("3868/sctp"))
+	require.NotContains(t, p.exposedPorts, docker.Port("53/tcp"))
+	require.Len(t, p.publishedPorts[docker.Port("53/udp")], 1)
+}
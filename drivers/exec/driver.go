@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -79,17 +80,30 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"default_userns_mode": hclspec.NewDefault(
+			hclspec.NewAttr("default_userns_mode", "string", false),
+			hclspec.NewLiteral(`"host"`),
+		),
+		"userns_pool": hclspec.NewBlock("userns_pool", false, hclspec.NewObject(map[string]*hclspec.Spec{
+			"start": hclspec.NewAttr("start", "number", true),
+			"size":  hclspec.NewAttr("size", "number", true),
+			"range_size": hclspec.NewDefault(
+				hclspec.NewAttr("range_size", "number", false),
+				hclspec.NewLiteral("65536"),
+			),
+		})),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":     hclspec.NewAttr("command", "string", true),
+		"args":        hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":    hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":    hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":     hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":    hclspec.NewAttr("cap_drop", "list(string)", false),
+		"userns_mode": hclspec.NewAttr("userns_mode", "string", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -103,6 +117,7 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportAll,
+		Resize:       true,
 	}
 )
 
@@ -133,6 +148,15 @@ type Driver struct {
 	// whether it has been successful
 	fingerprintSuccess *bool
 	fingerprintLock    sync.Mutex
+
+	// criuFound is set if the criu binary was found on the client the first
+	// time checkpoint support was checked.
+	criuFound bool
+	criuOnce  sync.Once
+
+	// userns is the pool of host IDs mapped into task user namespaces. It is
+	// nil if no pool is configured.
+	userns *userNSPool
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -152,6 +176,14 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// DefaultModeUserNS is the default user namespace isolation set for all
+	// tasks using the exec task driver.
+	DefaultModeUserNS string `codec:"default_userns_mode"`
+
+	// UserNSPool is the range of host IDs private user namespaces are mapped
+	// onto.
+	UserNSPool *UserNSPoolConfig `codec:"userns_pool"`
 }
 
 func (c *Config) validate() error {
@@ -172,6 +204,22 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	switch c.DefaultModeUserNS {
+	case "", executor.IsolationModeHost:
+	case executor.IsolationModePrivate:
+		if c.UserNSPool == nil {
+			return fmt.Errorf("default_userns_mode %q requires userns_pool to be configured", executor.IsolationModePrivate)
+		}
+	default:
+		return fmt.Errorf("default_userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, c.DefaultModeUserNS)
+	}
+
+	if c.UserNSPool != nil {
+		if err := c.UserNSPool.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -196,6 +244,10 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// ModeUserNS indicates whether the task runs in a private user namespace.
+	// Must be "private" or "host" if set.
+	ModeUserNS string `codec:"userns_mode"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -211,6 +263,12 @@ func (tc *TaskConfig) validate() error {
 		return fmt.Errorf("ipc_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeIPC)
 	}
 
+	switch tc.ModeUserNS {
+	case "", executor.IsolationModePrivate, executor.IsolationModeHost:
+	default:
+		return fmt.Errorf("userns_mode must be %q or %q, got %q", executor.IsolationModePrivate, executor.IsolationModeHost, tc.ModeUserNS)
+	}
+
 	supported := capabilities.Supported()
 	badAdds := supported.Difference(capabilities.New(tc.CapAdd))
 	if !badAdds.Empty() {
@@ -232,6 +290,7 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+	UserNS         *executor.UserNSMapping
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
@@ -288,6 +347,10 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 	}
 	d.config = config
 
+	if config.UserNSPool != nil {
+		d.userns = newUserNSPool(*config.UserNSPool)
+	}
+
 	if cfg != nil && cfg.AgentConfig != nil {
 		d.nomadConfig = cfg.AgentConfig.Driver
 	}
@@ -301,7 +364,13 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return driverCapabilities, nil
+	if !d.checkpointSupported() {
+		return driverCapabilities, nil
+	}
+
+	caps := *driverCapabilities
+	caps.Checkpoint = true
+	return &caps, nil
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -422,6 +491,14 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		logger:       d.logger,
 	}
 
+	if taskState.UserNS != nil {
+		if d.userns == nil {
+			d.logger.Warn("recovered task uses a user namespace but no userns_pool is configured", "task_id", handle.Config.ID)
+		} else if err := d.userns.Restore(handle.Config.AllocID, handle.Config.ID, taskState.UserNS); err != nil {
+			d.logger.Warn("failed to reserve user namespace range of recovered task", "error", err, "task_id", handle.Config.ID)
+		}
+	}
+
 	d.tasks.Set(taskState.TaskConfig.ID, h)
 
 	go h.run()
@@ -429,6 +506,20 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// RestoreTask starts a task from a checkpoint image written by CheckpointTask.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if !d.checkpointSupported() {
+		return nil, nil, fmt.Errorf("checkpointing requires criu to be installed")
+	}
+	return d.startTask(cfg, dir)
+}
+
+// startTask launches the task with an executor, restoring it from the
+// checkpoint image in restoreFrom if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, restoreFrom string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -465,9 +556,27 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		user = "nobody"
 	}
 
+	var userNS *executor.UserNSMapping
+	if executor.IsolationMode(d.config.DefaultModeUserNS, driverConfig.ModeUserNS) == executor.IsolationModePrivate {
+		if d.userns == nil {
+			pluginClient.Kill()
+			return nil, nil, fmt.Errorf("userns_mode %q requires the userns_pool plugin option", executor.IsolationModePrivate)
+		}
+
+		userNS, err = d.userns.Acquire(cfg.AllocID, cfg.ID)
+		if err != nil {
+			pluginClient.Kill()
+			return nil, nil, err
+		}
+
+		d.warnUnmappedMounts(cfg)
+	}
+
 	if cfg.DNS != nil {
 		dnsMount, err := resolvconf.GenerateDNSMount(cfg.TaskDir().Dir, cfg.DNS)
 		if err != nil {
+			pluginClient.Kill()
+			d.releaseUserNS(cfg)
 			return nil, nil, fmt.Errorf("failed to build mount for resolv.conf: %v", err)
 		}
 		cfg.Mounts = append(cfg.Mounts, dnsMount)
@@ -477,6 +586,8 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		capabilities.NomadDefaults(), d.config.AllowCaps, driverConfig.CapAdd, driverConfig.CapDrop,
 	)
 	if err != nil {
+		pluginClient.Kill()
+		d.releaseUserNS(cfg)
 		return nil, nil, err
 	}
 	d.logger.Debug("task capabilities", "capabilities", caps)
@@ -498,11 +609,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		RestoreFrom:      restoreFrom,
+		UserNS:           userNS,
 	}
 
 	ps, err := exec.Launch(execCmd)
 	if err != nil {
 		pluginClient.Kill()
+		d.releaseUserNS(cfg)
 		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
 	}
 
@@ -521,12 +635,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		UserNS:         userNS,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
 		d.logger.Error("failed to start task, error setting driver state", "error", err)
 		_ = exec.Shutdown("", 0)
 		pluginClient.Kill()
+		d.releaseUserNS(cfg)
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
 
@@ -535,6 +651,31 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	return handle, nil, nil
 }
 
+// CheckpointTask dumps the state of a running task into dir using CRIU. The
+// task is stopped once the checkpoint has been written.
+func (d *Driver) CheckpointTask(taskID string, dir string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if !d.checkpointSupported() {
+		return fmt.Errorf("checkpointing requires criu to be installed")
+	}
+
+	return handle.exec.Checkpoint(dir)
+}
+
+// checkpointSupported returns true if the criu binary needed to checkpoint and
+// restore tasks is available on the client.
+func (d *Driver) checkpointSupported() bool {
+	d.criuOnce.Do(func() {
+		_, err := osexec.LookPath("criu")
+		d.criuFound = err == nil
+	})
+	return d.criuFound
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -557,8 +698,11 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode: ps.ExitCode,
-			Signal:   ps.Signal,
+			ExitCode:    ps.ExitCode,
+			Signal:      ps.Signal,
+			OOMKilled:   ps.OOMKilled,
+			MemoryPeak:  ps.MemoryPeak,
+			MemoryLimit: ps.MemoryLimit,
 		}
 	}
 
@@ -605,10 +749,36 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 		handle.pluginClient.Kill()
 	}
 
+	d.releaseUserNS(handle.taskConfig)
 	d.tasks.Delete(taskID)
 	return nil
 }
 
+// warnUnmappedMounts emits an event for each volume mounted into the task,
+// as their ownership can't be remapped into the task's user namespace.
+func (d *Driver) warnUnmappedMounts(cfg *drivers.TaskConfig) {
+	for _, m := range cfg.Mounts {
+		d.eventer.EmitEvent(&drivers.TaskEvent{
+			TaskID:    cfg.ID,
+			AllocID:   cfg.AllocID,
+			TaskName:  cfg.Name,
+			Timestamp: time.Now(),
+			Message:   fmt.Sprintf("Mount %s can't be remapped into the user namespace, files owned by host users appear as nobody", m.TaskPath),
+			Annotations: map[string]string{
+				"mount": m.TaskPath,
+			},
+		})
+	}
+}
+
+// releaseUserNS returns the task's claim on its allocation's user namespace
+// range to the pool.
+func (d *Driver) releaseUserNS(cfg *drivers.TaskConfig) {
+	if d.userns != nil {
+		d.userns.Release(cfg.AllocID, cfg.ID)
+	}
+}
+
 func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -647,6 +817,16 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return handle.exec.Signal(sig)
 }
 
+// UpdateTaskResources applies new resource limits to a running task.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.UpdateResources(resources)
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	if len(cmd) == 0 {
 		return nil, fmt.Errorf("error cmd must have at least one value")
@@ -675,6 +855,8 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingRawDriver = (*Driver)(nil)
+var _ drivers.ResizeDriver = (*Driver)(nil)
+var _ drivers.CheckpointDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 	taskID string,
@@ -522,6 +522,125 @@ func TestExecDriver_User(t *testing.T) {
 	}
 }
 
+// TestExecDriver_UserNS asserts a task in a private user namespace runs with
+// its IDs remapped onto a range leased from the pool, and that the range is
+// released when the task is destroyed.
+func TestExecDriver_UserNS(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	config := &Config{
+		DefaultModePID:    executor.IsolationModePrivate,
+		DefaultModeIPC:    executor.IsolationModePrivate,
+		DefaultModeUserNS: executor.IsolationModeHost,
+		UserNSPool:        &UserNSPoolConfig{Start: 200000, Size: 65536, RangeSize: 65536},
+	}
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, config))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		AllocID:   uuid.Generate(),
+		Name:      "userns",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// Like the client's alloc dir, the task dir must be reachable by the
+	// unprivileged host IDs of the user namespace
+	require.NoError(os.Chmod(task.AllocDir, 0711))
+
+	tc := &TaskConfig{
+		Command:    "/bin/sh",
+		Args:       []string{"-c", "cat /proc/self/uid_map > ${NOMAD_TASK_DIR}/uid_map"},
+		ModeUserNS: executor.IsolationModePrivate,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+
+	ch, err := harness.WaitTask(context.Background(), handle.Config.ID)
+	require.NoError(err)
+	result := <-ch
+	require.True(result.Successful(), "result: %v", result)
+
+	// The task wrote to its directory as the remapped nobody user
+	path := filepath.Join(task.TaskDir().LocalDir, "uid_map")
+	out, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	require.Equal([]string{"0", "200000", "65536"}, strings.Fields(string(out)))
+
+	info, err := os.Stat(path)
+	require.NoError(err)
+	require.Equal(uint32(200000+65534), info.Sys().(*syscall.Stat_t).Uid)
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+
+	// The range was returned to the pool
+	_, err = d.(*Driver).userns.Acquire(uuid.Generate(), "task")
+	require.NoError(err)
+}
+
+func TestExecDriver_UserNS_StartFailure(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	ctestutils.ExecCompatible(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+
+	// The pool holds a single range
+	config := &Config{
+		DefaultModePID:    executor.IsolationModePrivate,
+		DefaultModeIPC:    executor.IsolationModePrivate,
+		DefaultModeUserNS: executor.IsolationModeHost,
+		UserNSPool:        &UserNSPoolConfig{Start: 200000, Size: 65536, RangeSize: 65536},
+	}
+
+	var data []byte
+	require.NoError(basePlug.MsgPackEncode(&data, config))
+	require.NoError(harness.SetConfig(&basePlug.Config{PluginConfig: data}))
+
+	task := &drivers.TaskConfig{
+		ID:        uuid.Generate(),
+		AllocID:   uuid.Generate(),
+		Name:      "userns",
+		Resources: testResources,
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	tc := &TaskConfig{
+		Command:    "/bin/sleep",
+		Args:       []string{"1"},
+		ModeUserNS: executor.IsolationModePrivate,
+		CapAdd:     []string{"sys_time"},
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+
+	// sys_time is not in the default allow_caps
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+
+	// The range of the failed task was returned to the pool
+	_, err = d.(*Driver).userns.Acquire(uuid.Generate(), "task")
+	require.NoError(err)
+}
+
 // TestExecDriver_HandlerExec ensures the exec driver's handle properly
 // executes commands inside the container.
 func TestExecDriver_HandlerExec(t *testing.T) {
@@ -801,6 +920,34 @@ func TestDriver_Config_validate(t *testing.T) {
 			}).validate())
 		}
 	})
+
+	t.Run("userns", func(t *testing.T) {
+		pool := &UserNSPoolConfig{Start: 100000, Size: 65536 * 4, RangeSize: 65536}
+		for _, tc := range []struct {
+			mode string
+			pool *UserNSPoolConfig
+			exp  error
+		}{
+			{mode: "host", exp: nil},
+			{mode: "host", pool: pool, exp: nil},
+			{mode: "private", pool: pool, exp: nil},
+			{mode: "private", exp: errors.New(`default_userns_mode "private" requires userns_pool to be configured`)},
+			{mode: "other", pool: pool, exp: errors.New(`default_userns_mode must be "private" or "host", got "other"`)},
+			{mode: "host", pool: &UserNSPoolConfig{Start: 0, Size: 65536, RangeSize: 65536},
+				exp: errors.New("userns_pool start must not include the host's root ID")},
+			{mode: "host", pool: &UserNSPoolConfig{Start: 100000, Size: 65536},
+				exp: errors.New("userns_pool range_size must be greater than zero")},
+			{mode: "host", pool: &UserNSPoolConfig{Start: 100000, Size: 1000, RangeSize: 65536},
+				exp: errors.New("userns_pool size must be at least range_size (65536), got 1000")},
+		} {
+			require.Equal(t, tc.exp, (&Config{
+				DefaultModePID:    "private",
+				DefaultModeIPC:    "private",
+				DefaultModeUserNS: tc.mode,
+				UserNSPool:        tc.pool,
+			}).validate())
+		}
+	})
 }
 
 func TestDriver_TaskConfig_validate(t *testing.T) {
@@ -826,6 +973,13 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 		}
 	})
 
+	t.Run("userns", func(t *testing.T) {
+		require.NoError(t, (&TaskConfig{ModeUserNS: "private"}).validate())
+		require.NoError(t, (&TaskConfig{ModeUserNS: "host"}).validate())
+		require.EqualError(t, (&TaskConfig{ModeUserNS: "other"}).validate(),
+			`userns_mode must be "private" or "host", got "other"`)
+	})
+
 	t.Run("cap_add", func(t *testing.T) {
 		for _, tc := range []struct {
 			adds []string
@@ -860,3 +1014,47 @@ func TestDriver_TaskConfig_validate(t *testing.T) {
 		}
 	})
 }
+
+func TestDriver_UserNSPool(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	pool := newUserNSPool(UserNSPoolConfig{Start: 100000, Size: 2000, RangeSize: 1000})
+
+	// Tasks of an allocation share a range
+	m1, err := pool.Acquire("alloc1", "task1")
+	require.NoError(err)
+	require.Equal(&executor.UserNSMapping{HostID: 100000, Size: 1000}, m1)
+	m2, err := pool.Acquire("alloc1", "task2")
+	require.NoError(err)
+	require.Equal(m1, m2)
+
+	m3, err := pool.Acquire("alloc2", "task1")
+	require.NoError(err)
+	require.Equal(&executor.UserNSMapping{HostID: 101000, Size: 1000}, m3)
+
+	_, err = pool.Acquire("alloc3", "task1")
+	require.EqualError(err, "no free user namespace range: all 2 ranges are in use")
+
+	// The range is only freed once all tasks of the allocation released it
+	pool.Release("alloc1", "task1")
+	_, err = pool.Acquire("alloc3", "task1")
+	require.Error(err)
+	pool.Release("alloc1", "task2")
+	m4, err := pool.Acquire("alloc3", "task1")
+	require.NoError(err)
+	require.Equal(m1, m4)
+
+	// Recovered tasks reserve their range again
+	pool = newUserNSPool(UserNSPoolConfig{Start: 100000, Size: 2000, RangeSize: 1000})
+	require.NoError(pool.Restore("alloc2", "task1", m3))
+	require.NoError(pool.Restore("alloc2", "task2", m3))
+	require.EqualError(pool.Restore("alloc3", "task1", m3),
+		"user namespace range 101000-101999 is in use by allocation alloc2")
+	require.EqualError(pool.Restore("alloc4", "task1", &executor.UserNSMapping{HostID: 102000, Size: 1000}),
+		"user namespace range 102000-102999 is not part of the pool")
+
+	m5, err := pool.Acquire("alloc5", "task1")
+	require.NoError(err)
+	require.Equal(m1, m5)
+}
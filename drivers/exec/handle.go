@@ -73,7 +73,8 @@ func (h *taskHandle) run() {
 	h.procState = drivers.TaskStateExited
 	h.exitResult.ExitCode = ps.ExitCode
 	h.exitResult.Signal = ps.Signal
+	h.exitResult.OOMKilled = ps.OOMKilled
+	h.exitResult.MemoryPeak = ps.MemoryPeak
+	h.exitResult.MemoryLimit = ps.MemoryLimit
 	h.completedAt = ps.Time
-
-	// TODO: detect if the task OOMed
 }
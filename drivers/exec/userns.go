@@ -0,0 +1,153 @@
+package exec
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+)
+
+// UserNSPoolConfig is the pool of subordinate host IDs that task user
+// namespaces are mapped onto.
+type UserNSPoolConfig struct {
+	// Start is the first host UID/GID of the pool.
+	Start uint32 `codec:"start"`
+
+	// Size is the number of host IDs in the pool.
+	Size uint32 `codec:"size"`
+
+	// RangeSize is the number of IDs given to each allocation.
+	RangeSize uint32 `codec:"range_size"`
+}
+
+func (c *UserNSPoolConfig) validate() error {
+	switch {
+	case c.Start == 0:
+		return fmt.Errorf("userns_pool start must not include the host's root ID")
+	case c.RangeSize == 0:
+		return fmt.Errorf("userns_pool range_size must be greater than zero")
+	case c.Size < c.RangeSize:
+		return fmt.Errorf("userns_pool size must be at least range_size (%d), got %d", c.RangeSize, c.Size)
+	case uint64(c.Start)+uint64(c.Size) > 1<<32-1:
+		return fmt.Errorf("userns_pool exceeds the maximum host ID")
+	}
+	return nil
+}
+
+// userNSPool hands out ranges of the configured pool to allocations. All
+// tasks of an allocation share the same range so they may access each other's
+// files in the shared alloc directory. A range is returned to the pool once
+// the last task of the allocation is destroyed.
+type userNSPool struct {
+	config UserNSPoolConfig
+
+	lock sync.Mutex
+
+	// leases maps allocation IDs to the range they are using
+	leases map[string]*userNSLease
+
+	// used maps range indexes to the allocation using them
+	used map[uint32]string
+}
+
+type userNSLease struct {
+	index uint32
+	tasks map[string]struct{}
+}
+
+func newUserNSPool(config UserNSPoolConfig) *userNSPool {
+	return &userNSPool{
+		config: config,
+		leases: map[string]*userNSLease{},
+		used:   map[uint32]string{},
+	}
+}
+
+// ranges returns the number of ranges in the pool.
+func (p *userNSPool) ranges() uint32 {
+	return p.config.Size / p.config.RangeSize
+}
+
+func (p *userNSPool) mapping(index uint32) *executor.UserNSMapping {
+	return &executor.UserNSMapping{
+		HostID: p.config.Start + index*p.config.RangeSize,
+		Size:   p.config.RangeSize,
+	}
+}
+
+// Acquire returns the range of the allocation, reserving a free one if none
+// of its tasks hold one already.
+func (p *userNSPool) Acquire(allocID, taskID string) (*executor.UserNSMapping, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if lease, ok := p.leases[allocID]; ok {
+		lease.tasks[taskID] = struct{}{}
+		return p.mapping(lease.index), nil
+	}
+
+	for i := uint32(0); i < p.ranges(); i++ {
+		if _, ok := p.used[i]; ok {
+			continue
+		}
+		p.used[i] = allocID
+		p.leases[allocID] = &userNSLease{
+			index: i,
+			tasks: map[string]struct{}{taskID: {}},
+		}
+		return p.mapping(i), nil
+	}
+
+	return nil, fmt.Errorf("no free user namespace range: all %d ranges are in use", p.ranges())
+}
+
+// Restore reserves the range m of a recovered task.
+func (p *userNSPool) Restore(allocID, taskID string, m *executor.UserNSMapping) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if m.Size != p.config.RangeSize || m.HostID < p.config.Start ||
+		(m.HostID-p.config.Start)%p.config.RangeSize != 0 {
+		return fmt.Errorf("user namespace range %d-%d is not part of the pool",
+			m.HostID, uint64(m.HostID)+uint64(m.Size)-1)
+	}
+
+	index := (m.HostID - p.config.Start) / p.config.RangeSize
+	if index >= p.ranges() {
+		return fmt.Errorf("user namespace range %d-%d is not part of the pool",
+			m.HostID, uint64(m.HostID)+uint64(m.Size)-1)
+	}
+
+	if owner, ok := p.used[index]; ok && owner != allocID {
+		return fmt.Errorf("user namespace range %d-%d is in use by allocation %s",
+			m.HostID, uint64(m.HostID)+uint64(m.Size)-1, owner)
+	}
+
+	lease, ok := p.leases[allocID]
+	if !ok {
+		lease = &userNSLease{index: index, tasks: map[string]struct{}{}}
+		p.leases[allocID] = lease
+		p.used[index] = allocID
+	} else if lease.index != index {
+		return fmt.Errorf("allocation %s already uses a different user namespace range", allocID)
+	}
+	lease.tasks[taskID] = struct{}{}
+	return nil
+}
+
+// Release drops the task's claim on the range of its allocation.
+func (p *userNSPool) Release(allocID, taskID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	lease, ok := p.leases[allocID]
+	if !ok {
+		return
+	}
+
+	delete(lease.tasks, taskID)
+	if len(lease.tasks) == 0 {
+		delete(p.leases, allocID)
+		delete(p.used, lease.index)
+	}
+}
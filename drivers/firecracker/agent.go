@@ -0,0 +1,105 @@
+package firecracker
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+const (
+	// agentOpSignal asks the agent to send a signal to the guest workload
+	agentOpSignal = "signal"
+
+	// agentOpStats asks the agent for the resource usage of the guest
+	agentOpStats = "stats"
+
+	// agentTimeout bounds every exchange with the guest agent
+	agentTimeout = 5 * time.Second
+)
+
+// agentRequest is a request sent to the agent running inside the guest
+type agentRequest struct {
+	Op     string `json:"op"`
+	Signal string `json:"signal,omitempty"`
+}
+
+// agentResponse is the agent's reply to an agentRequest
+type agentResponse struct {
+	Error string                  `json:"error,omitempty"`
+	Usage *cstructs.ResourceUsage `json:"usage,omitempty"`
+}
+
+// agentClient talks to the agent running inside a microVM over the VM's
+// vsock device. Firecracker exposes guest vsock ports through a unix socket
+// on the host: after connecting, the host writes "CONNECT <port>\n" and
+// Firecracker replies "OK <host port>\n" once the guest accepted the
+// connection. Each request uses its own connection and a single line of JSON
+// in each direction.
+type agentClient struct {
+	socketPath string
+	port       int
+}
+
+func newAgentClient(socketPath string, port int) *agentClient {
+	return &agentClient{
+		socketPath: socketPath,
+		port:       port,
+	}
+}
+
+// Signal asks the agent to deliver sig to the guest workload
+func (a *agentClient) Signal(sig string) error {
+	_, err := a.do(&agentRequest{Op: agentOpSignal, Signal: sig})
+	return err
+}
+
+// Stats returns the resource usage measured inside the guest
+func (a *agentClient) Stats() (*cstructs.ResourceUsage, error) {
+	resp, err := a.do(&agentRequest{Op: agentOpStats})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Usage == nil {
+		return nil, fmt.Errorf("agent returned no resource usage")
+	}
+	return resp.Usage, nil
+}
+
+func (a *agentClient) do(req *agentRequest) (*agentResponse, error) {
+	conn, err := net.DialTimeout("unix", a.socketPath, agentTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to vsock: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(agentTimeout))
+
+	r := bufio.NewReader(conn)
+	if _, err := fmt.Fprintf(conn, "CONNECT %d\n", a.port); err != nil {
+		return nil, fmt.Errorf("failed to connect to agent: %v", err)
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to agent: %v", err)
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return nil, fmt.Errorf("failed to connect to agent: %q", strings.TrimSpace(line))
+	}
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, fmt.Errorf("failed to send %s request to agent: %v", req.Op, err)
+	}
+
+	var resp agentResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("failed to read %s response from agent: %v", req.Op, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("agent failed to handle %s request: %s", req.Op, resp.Error)
+	}
+	return &resp, nil
+}
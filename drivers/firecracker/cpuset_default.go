@@ -0,0 +1,9 @@
+//+build !linux
+
+package firecracker
+
+import "github.com/hashicorp/nomad/plugins/drivers"
+
+func pinVM(pid int, res *drivers.Resources) error {
+	return nil
+}
@@ -0,0 +1,15 @@
+package firecracker
+
+import (
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// pinVM moves the firecracker process pid, and so every vCPU thread it
+// starts, into the cpuset cgroup of the task's reserved cores
+func pinVM(pid int, res *drivers.Resources) error {
+	if res == nil || res.LinuxResources == nil || res.LinuxResources.CpusetCgroupPath == "" {
+		return nil
+	}
+	return cgroups.WriteCgroupProc(res.LinuxResources.CpusetCgroupPath, pid)
+}
@@ -0,0 +1,832 @@
+package firecracker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/drivers/shared/eventer"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/helper/pluginutils/loader"
+	"github.com/hashicorp/nomad/plugins/base"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/shared/hclspec"
+	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
+)
+
+const (
+	// pluginName is the name of the plugin
+	pluginName = "firecracker"
+
+	// fingerprintPeriod is the interval at which the driver will send fingerprint responses
+	fingerprintPeriod = 30 * time.Second
+
+	// The key populated in Node Attributes to indicate presence of the
+	// Firecracker driver
+	driverAttr        = "driver.firecracker"
+	driverVersionAttr = "driver.firecracker.version"
+
+	// kvmDevice must be accessible for Firecracker to run VMs
+	kvmDevice = "/dev/kvm"
+
+	// vmDirName is the name of the directory inside the task directory that
+	// holds the VM's configuration, sockets and writable images. It is not
+	// exposed to the guest.
+	vmDirName = "firecracker"
+
+	// tapName is the name of the tap device created in the allocation's
+	// network namespace
+	tapName = "tap0"
+
+	// maxSocketPathLen is the maximum length of a unix socket path
+	maxSocketPathLen = 108
+
+	// maxVcpus is the maximum number of vCPUs supported by Firecracker
+	maxVcpus = 32
+
+	// minVMMemoryMB is the minimum memory of a VM supported by Firecracker
+	minVMMemoryMB = 128
+
+	// vmmMemoryOverheadMB is the memory kept out of the VM for the
+	// Firecracker process itself, as both count against the task's cgroup
+	vmmMemoryOverheadMB = 32
+
+	// taskHandleVersion is the version of task handle which this driver sets
+	// and understands how to decode driver state
+	taskHandleVersion = 1
+)
+
+var (
+	// PluginID is the firecracker plugin metadata registered in the plugin
+	// catalog.
+	PluginID = loader.PluginID{
+		Name:       pluginName,
+		PluginType: base.PluginTypeDriver,
+	}
+
+	// PluginConfig is the firecracker driver factory function registered in
+	// the plugin catalog.
+	PluginConfig = &loader.InternalPluginConfig{
+		Config:  map[string]interface{}{},
+		Factory: func(ctx context.Context, l hclog.Logger) interface{} { return NewFirecrackerDriver(ctx, l) },
+	}
+
+	versionRegex = regexp.MustCompile(`v(\d+\.\d+\.\d+)`)
+
+	// pluginInfo is the response returned for the PluginInfo RPC
+	pluginInfo = &base.PluginInfoResponse{
+		Type:              base.PluginTypeDriver,
+		PluginApiVersions: []string{drivers.ApiVersion010},
+		PluginVersion:     "0.1.0",
+		Name:              pluginName,
+	}
+
+	// configSpec is the hcl specification returned by the ConfigSchema RPC
+	configSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"firecracker_path": hclspec.NewDefault(
+			hclspec.NewAttr("firecracker_path", "string", false),
+			hclspec.NewLiteral(`"firecracker"`),
+		),
+		"image_paths": hclspec.NewAttr("image_paths", "list(string)", false),
+		"agent_port": hclspec.NewDefault(
+			hclspec.NewAttr("agent_port", "number", false),
+			hclspec.NewLiteral("10000"),
+		),
+	})
+
+	// taskConfigSpec is the hcl specification for the driver config section of
+	// a taskConfig within a job. It is returned in the TaskConfigSchema RPC
+	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
+		"kernel_image":        hclspec.NewAttr("kernel_image", "string", true),
+		"rootfs_image":        hclspec.NewAttr("rootfs_image", "string", true),
+		"rootfs_read_only":    hclspec.NewAttr("rootfs_read_only", "bool", false),
+		"kernel_args":         hclspec.NewAttr("kernel_args", "string", false),
+		"vcpus":               hclspec.NewAttr("vcpus", "number", false),
+		"drives":              hclspec.NewAttr("drives", "list(string)", false),
+		"task_dir_drive":      hclspec.NewAttr("task_dir_drive", "bool", false),
+		"task_dir_drive_size": hclspec.NewAttr("task_dir_drive_size", "number", false),
+	})
+
+	// capabilities is returned by the Capabilities RPC and indicates what
+	// optional features this driver supports
+	capabilities = &drivers.Capabilities{
+		SendSignals: true,
+		Exec:        false,
+		FSIsolation: drivers.FSIsolationImage,
+		NetIsolationModes: []drivers.NetIsolationMode{
+			drivers.NetIsolationModeHost,
+			drivers.NetIsolationModeGroup,
+		},
+		MountConfigs: drivers.MountConfigSupportNone,
+	}
+
+	_ drivers.DriverPlugin = (*Driver)(nil)
+)
+
+// TaskConfig is the driver configuration of a taskConfig within a job
+type TaskConfig struct {
+	// KernelImage is the path of the uncompressed kernel to boot
+	KernelImage string `codec:"kernel_image"`
+
+	// RootfsImage is the path of the root filesystem image. It is copied
+	// into the task directory unless RootfsReadOnly is set.
+	RootfsImage    string `codec:"rootfs_image"`
+	RootfsReadOnly bool   `codec:"rootfs_read_only"`
+
+	// KernelArgs are appended to the default kernel command line
+	KernelArgs string `codec:"kernel_args"`
+
+	// Vcpus overrides the number of vCPUs derived from the task's reserved
+	// cores. It can't exceed the number of reserved cores.
+	Vcpus int `codec:"vcpus"`
+
+	// Drives are additional images attached to the VM as block devices
+	Drives []string `codec:"drives"`
+
+	// TaskDirDrive attaches an ext4 image built from the task's local/
+	// directory to the VM.
+	TaskDirDrive     bool `codec:"task_dir_drive"`
+	TaskDirDriveSize int  `codec:"task_dir_drive_size"`
+}
+
+// TaskState is the state which is encoded in the handle returned in StartTask.
+// This information is needed to rebuild the taskConfig state and handler
+// during recovery.
+type TaskState struct {
+	ReattachConfig *pstructs.ReattachConfig
+	TaskConfig     *drivers.TaskConfig
+	Pid            int
+	StartedAt      time.Time
+	APISocket      string
+	VsockPath      string
+	AgentPort      int
+}
+
+// Config is the driver configuration set by SetConfig RPC call
+type Config struct {
+	// FirecrackerPath is the path of the firecracker binary
+	FirecrackerPath string `codec:"firecracker_path"`
+
+	// ImagePaths is an allow-list of paths kernels and images may be loaded
+	// from, in addition to the allocation directory
+	ImagePaths []string `codec:"image_paths"`
+
+	// AgentPort is the vsock port the agent inside the VM listens on. Setting
+	// it to zero disables signals and stats collection through the agent.
+	AgentPort int `codec:"agent_port"`
+}
+
+// Driver is a driver for running microVMs via Firecracker
+type Driver struct {
+	// eventer is used to handle multiplexing of TaskEvents calls such that an
+	// event can be broadcast to all callers
+	eventer *eventer.Eventer
+
+	// config is the driver configuration set by the SetConfig RPC
+	config Config
+
+	// tasks is the in memory datastore mapping taskIDs to taskHandle
+	tasks *taskStore
+
+	// ctx is the context for the driver. It is passed to other subsystems to
+	// coordinate shutdown
+	ctx context.Context
+
+	// nomadConf is the client agent's configuration
+	nomadConfig *base.ClientDriverConfig
+
+	// logger will log to the Nomad agent
+	logger hclog.Logger
+}
+
+func NewFirecrackerDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
+	logger = logger.Named(pluginName)
+	return &Driver{
+		eventer: eventer.NewEventer(ctx, logger),
+		config:  defaultConfig(),
+		tasks:   newTaskStore(),
+		ctx:     ctx,
+		logger:  logger,
+	}
+}
+
+// defaultConfig returns the driver configuration used when the client
+// doesn't configure the plugin.
+func defaultConfig() Config {
+	return Config{
+		FirecrackerPath: "firecracker",
+		AgentPort:       10000,
+	}
+}
+
+func (d *Driver) PluginInfo() (*base.PluginInfoResponse, error) {
+	return pluginInfo, nil
+}
+
+func (d *Driver) ConfigSchema() (*hclspec.Spec, error) {
+	return configSpec, nil
+}
+
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	config := defaultConfig()
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &config); err != nil {
+			return err
+		}
+	}
+
+	if config.AgentPort < 0 || config.AgentPort > 65535 {
+		return fmt.Errorf("agent_port must be between 0 and 65535: %d", config.AgentPort)
+	}
+
+	d.config = config
+	if cfg.AgentConfig != nil {
+		d.nomadConfig = cfg.AgentConfig.Driver
+	}
+	return nil
+}
+
+func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
+	return taskConfigSpec, nil
+}
+
+func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
+	return capabilities, nil
+}
+
+func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
+	ch := make(chan *drivers.Fingerprint)
+	go d.handleFingerprint(ctx, ch)
+	return ch, nil
+}
+
+func (d *Driver) handleFingerprint(ctx context.Context, ch chan *drivers.Fingerprint) {
+	ticker := time.NewTimer(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			ticker.Reset(fingerprintPeriod)
+			ch <- d.buildFingerprint()
+		}
+	}
+}
+
+func (d *Driver) buildFingerprint() *drivers.Fingerprint {
+	fingerprint := &drivers.Fingerprint{
+		Attributes:        map[string]*pstructs.Attribute{},
+		Health:            drivers.HealthStateHealthy,
+		HealthDescription: drivers.DriverHealthy,
+	}
+
+	outBytes, err := exec.Command(d.config.FirecrackerPath, "--version").Output()
+	if err != nil {
+		// return no error, as it isn't an error to not find firecracker, it
+		// just means we can't use it.
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = ""
+		return fingerprint
+	}
+	out := strings.TrimSpace(string(outBytes))
+
+	matches := versionRegex.FindStringSubmatch(out)
+	if len(matches) != 2 {
+		fingerprint.Health = drivers.HealthStateUndetected
+		fingerprint.HealthDescription = fmt.Sprintf("Failed to parse firecracker version from %v", out)
+		return fingerprint
+	}
+
+	if _, err := os.Stat(kvmDevice); err != nil {
+		fingerprint.Health = drivers.HealthStateUnhealthy
+		fingerprint.HealthDescription = fmt.Sprintf("KVM is not available: %v", err)
+		return fingerprint
+	}
+
+	fingerprint.Attributes[driverAttr] = pstructs.NewBoolAttribute(true)
+	fingerprint.Attributes[driverVersionAttr] = pstructs.NewStringAttribute(matches[1])
+	return fingerprint
+}
+
+func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
+	if handle == nil {
+		return fmt.Errorf("error: handle cannot be nil")
+	}
+
+	// If already attached to handle there's nothing to recover.
+	if _, ok := d.tasks.Get(handle.Config.ID); ok {
+		d.logger.Trace("nothing to recover; task already exists",
+			"task_id", handle.Config.ID,
+			"task_name", handle.Config.Name,
+		)
+		return nil
+	}
+
+	var taskState TaskState
+	if err := handle.GetDriverState(&taskState); err != nil {
+		d.logger.Error("failed to decode taskConfig state from handle", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to decode taskConfig state from handle: %v", err)
+	}
+
+	plugRC, err := pstructs.ReattachConfigToGoPlugin(taskState.ReattachConfig)
+	if err != nil {
+		d.logger.Error("failed to build ReattachConfig from taskConfig state", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to build ReattachConfig from taskConfig state: %v", err)
+	}
+
+	execImpl, pluginClient, err := executor.ReattachToExecutor(plugRC,
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID))
+	if err != nil {
+		d.logger.Error("failed to reattach to executor", "error", err, "task_id", handle.Config.ID)
+		return fmt.Errorf("failed to reattach to executor: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          taskState.Pid,
+		pluginClient: pluginClient,
+		apiSocket:    taskState.APISocket,
+		taskConfig:   taskState.TaskConfig,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    taskState.StartedAt,
+		exitResult:   &drivers.ExitResult{},
+		logger:       d.logger,
+	}
+	if taskState.AgentPort != 0 {
+		h.agent = newAgentClient(taskState.VsockPath, taskState.AgentPort)
+	}
+
+	d.tasks.Set(taskState.TaskConfig.ID, h)
+
+	go h.run()
+	return nil
+}
+
+// isAllowedImagePath returns true if imagePath is inside the allocation
+// directory or one of the allowed paths.
+func isAllowedImagePath(allowedPaths []string, allocDir, imagePath string) bool {
+	isParent := func(parent, path string) bool {
+		rel, err := filepath.Rel(parent, path)
+		return err == nil && !strings.HasPrefix(rel, "..")
+	}
+
+	// check if path is under alloc dir
+	if isParent(allocDir, imagePath) {
+		return true
+	}
+
+	// check allowed paths
+	for _, ap := range allowedPaths {
+		if isParent(ap, imagePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveImagePath returns the absolute path of an image, resolving relative
+// paths against the task directory, and ensures it may be used.
+func (d *Driver) resolveImagePath(cfg *drivers.TaskConfig, field, path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("%s must be set", field)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.TaskDir().Dir, path)
+	}
+	path = filepath.Clean(path)
+
+	if !isAllowedImagePath(d.config.ImagePaths, cfg.AllocDir, path) {
+		return "", fmt.Errorf("%s is not in the allowed paths", field)
+	}
+	return path, nil
+}
+
+// vcpus returns the number of vCPUs to give the VM. A VM is only limited by
+// the cores it is pinned to, so tasks must reserve cores and get one vCPU per
+// core unless overridden in the task config. The reserved cores are read from
+// the task's cpuset, as they aren't passed to plugins otherwise.
+func vcpus(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (int, error) {
+	cores := 0
+	if lr := cfg.Resources.LinuxResources; lr != nil && lr.CpusetCpus != "" {
+		cores = len(strings.Split(lr.CpusetCpus, ","))
+	}
+	if cores == 0 {
+		return 0, fmt.Errorf("firecracker tasks must reserve cores with resources.cores")
+	}
+
+	n := driverConfig.Vcpus
+	if n == 0 {
+		n = cores
+	}
+	if n < 1 || n > maxVcpus {
+		return 0, fmt.Errorf("vcpus must be between 1 and %d: %d", maxVcpus, n)
+	}
+	if n > cores {
+		return 0, fmt.Errorf("vcpus must not exceed the %d reserved cores: %d", cores, n)
+	}
+	return n, nil
+}
+
+func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, ok := d.tasks.Get(cfg.ID); ok {
+		return nil, nil, fmt.Errorf("taskConfig with ID '%s' already started", cfg.ID)
+	}
+
+	var driverConfig TaskConfig
+	if err := cfg.DecodeDriverConfig(&driverConfig); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode driver config: %v", err)
+	}
+
+	handle := drivers.NewTaskHandle(taskHandleVersion)
+	handle.Config = cfg
+
+	spec, err := d.buildVMSpec(cfg, &driverConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Remove the tap device again if the VM doesn't start
+	started := false
+	if spec.Network != nil {
+		defer func() {
+			if started {
+				return
+			}
+			if err := teardownTap(cfg.NetworkIsolation.Path, tapName); err != nil {
+				d.logger.Error("failed to remove tap device", "err", err)
+			}
+		}()
+	}
+
+	vmDir := filepath.Join(cfg.TaskDir().Dir, vmDirName)
+	apiSocket := filepath.Join(vmDir, "api.sock")
+	configPath := filepath.Join(vmDir, "config.json")
+	if err := writeVMConfig(configPath, buildVMConfig(spec)); err != nil {
+		return nil, nil, fmt.Errorf("failed to write firecracker config: %v", err)
+	}
+
+	absPath, err := GetAbsolutePath(d.config.FirecrackerPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := []string{
+		"--api-sock", apiSocket,
+		"--config-file", configPath,
+	}
+	d.logger.Debug("starting firecracker VM", "args", strings.Join(args, " "))
+
+	pluginLogFile := filepath.Join(cfg.TaskDir().Dir, fmt.Sprintf("%s-executor.out", cfg.Name))
+	executorConfig := &executor.ExecutorConfig{
+		LogFile:  pluginLogFile,
+		LogLevel: "debug",
+	}
+
+	execImpl, pluginClient, err := executor.CreateExecutor(
+		d.logger.With("task_name", handle.Config.Name, "alloc_id", handle.Config.AllocID),
+		d.nomadConfig, executorConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	execCmd := &executor.ExecCommand{
+		Cmd:              absPath,
+		Args:             args,
+		Env:              cfg.EnvList(),
+		User:             cfg.User,
+		ResourceLimits:   true,
+		Resources:        cfg.Resources,
+		TaskDir:          cfg.TaskDir().Dir,
+		StdoutPath:       cfg.StdoutPath,
+		StderrPath:       cfg.StderrPath,
+		NetworkIsolation: cfg.NetworkIsolation,
+	}
+	ps, err := execImpl.Launch(execCmd)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+	d.logger.Debug("started firecracker VM", "pid", ps.Pid)
+
+	if err := pinVM(ps.Pid, cfg.Resources); err != nil {
+		execImpl.Shutdown("", 0)
+		pluginClient.Kill()
+		return nil, nil, fmt.Errorf("failed to pin vm to reserved cores: %v", err)
+	}
+
+	h := &taskHandle{
+		exec:         execImpl,
+		pid:          ps.Pid,
+		pluginClient: pluginClient,
+		apiSocket:    apiSocket,
+		taskConfig:   cfg,
+		procState:    drivers.TaskStateRunning,
+		startedAt:    time.Now().Round(time.Millisecond),
+		logger:       d.logger,
+	}
+	if d.config.AgentPort != 0 {
+		h.agent = newAgentClient(spec.VsockPath, d.config.AgentPort)
+	}
+
+	driverState := TaskState{
+		ReattachConfig: pstructs.ReattachConfigFromGoPlugin(pluginClient.ReattachConfig()),
+		Pid:            ps.Pid,
+		TaskConfig:     cfg,
+		StartedAt:      h.startedAt,
+		APISocket:      apiSocket,
+		VsockPath:      spec.VsockPath,
+		AgentPort:      d.config.AgentPort,
+	}
+
+	if err := handle.SetDriverState(&driverState); err != nil {
+		d.logger.Error("failed to start task, error setting driver state", "error", err)
+		execImpl.Shutdown("", 0)
+		pluginClient.Kill()
+		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
+	}
+
+	d.tasks.Set(cfg.ID, h)
+	go h.run()
+
+	started = true
+	return handle, nil, nil
+}
+
+// buildVMSpec resolves the task's configuration into the inputs of the VM's
+// Firecracker configuration. It prepares the VM directory, writable images
+// and the tap device as a side effect.
+func (d *Driver) buildVMSpec(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (*vmSpec, error) {
+	kernelPath, err := d.resolveImagePath(cfg, "kernel_image", driverConfig.KernelImage)
+	if err != nil {
+		return nil, err
+	}
+	rootfsPath, err := d.resolveImagePath(cfg, "rootfs_image", driverConfig.RootfsImage)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := vcpus(cfg, driverConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	mb := cfg.Resources.NomadResources.Memory.MemoryMB - vmmMemoryOverheadMB
+	if mb < minVMMemoryMB {
+		return nil, fmt.Errorf("firecracker tasks require at least %d MB of memory: %d",
+			minVMMemoryMB+vmmMemoryOverheadMB, cfg.Resources.NomadResources.Memory.MemoryMB)
+	}
+
+	vmDir := filepath.Join(cfg.TaskDir().Dir, vmDirName)
+	if err := os.MkdirAll(vmDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create vm directory: %v", err)
+	}
+
+	spec := &vmSpec{
+		KernelPath: kernelPath,
+		KernelArgs: driverConfig.KernelArgs,
+		RootfsPath: rootfsPath,
+		Vcpus:      n,
+		MemoryMB:   int(mb),
+		DNS:        cfg.DNS,
+	}
+
+	// Sockets share the length limit so checking the longest name is enough
+	vsockPath := filepath.Join(vmDir, "vsock.sock")
+	if len(vsockPath) > maxSocketPathLen {
+		return nil, fmt.Errorf("task directory path is too long for firecracker sockets: %s", vmDir)
+	}
+
+	// The vsock device is only needed to talk to the agent
+	if d.config.AgentPort != 0 {
+		spec.VsockPath = vsockPath
+	}
+
+	// Guests write to their root filesystem so each VM needs its own copy
+	if !driverConfig.RootfsReadOnly {
+		spec.RootfsPath = filepath.Join(vmDir, "rootfs.img")
+		if err := copyFile(rootfsPath, spec.RootfsPath); err != nil {
+			return nil, fmt.Errorf("failed to copy rootfs_image: %v", err)
+		}
+	}
+
+	for _, drive := range driverConfig.Drives {
+		path, err := d.resolveImagePath(cfg, "drives", drive)
+		if err != nil {
+			return nil, err
+		}
+		spec.Drives = append(spec.Drives, path)
+	}
+
+	if driverConfig.TaskDirDrive {
+		spec.TaskDirPath = filepath.Join(vmDir, "taskdir.img")
+		size := driverConfig.TaskDirDriveSize
+		if size == 0 {
+			size = 128
+		}
+		if err := buildTaskDirImage(cfg.TaskDir().LocalDir, spec.TaskDirPath, size); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.NetworkIsolation != nil && cfg.NetworkIsolation.Path != "" {
+		spec.Network, err = setupTap(cfg.NetworkIsolation.Path, tapName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up vm network: %v", err)
+		}
+	}
+
+	return spec, nil
+}
+
+// buildTaskDirImage builds an ext4 image of sizeMB from the contents of dir.
+// Changes made by the guest are not synced back to dir.
+func buildTaskDirImage(dir, image string, sizeMB int) error {
+	mkfs, err := GetAbsolutePath("mkfs.ext4")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(image)
+	if err != nil {
+		return fmt.Errorf("failed to create task directory image: %v", err)
+	}
+	err = f.Truncate(int64(sizeMB) * 1024 * 1024)
+	f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to size task directory image: %v", err)
+	}
+
+	out, err := exec.Command(mkfs, "-q", "-F", "-d", dir, image).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to build task directory image: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	ch := make(chan *drivers.ExitResult)
+	go d.handleWait(ctx, handle, ch)
+
+	return ch, nil
+}
+
+func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	// Ask the guest to shut down cleanly before the executor escalates to
+	// killing the VM after the timeout.
+	if err := sendCtrlAltDel(handle.apiSocket, timeout); err != nil {
+		d.logger.Debug("error sending graceful shutdown", "pid", handle.pid, "error", err)
+	}
+
+	if err := handle.exec.Shutdown(signal, timeout); err != nil {
+		if handle.pluginClient.Exited() {
+			return nil
+		}
+		return fmt.Errorf("executor Shutdown failed: %v", err)
+	}
+
+	return nil
+}
+
+func (d *Driver) DestroyTask(taskID string, force bool) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.IsRunning() && !force {
+		return fmt.Errorf("cannot destroy running task")
+	}
+
+	if !handle.pluginClient.Exited() {
+		if err := handle.exec.Shutdown("", 0); err != nil {
+			handle.logger.Error("destroying executor failed", "err", err)
+		}
+
+		handle.pluginClient.Kill()
+	}
+
+	if ni := handle.taskConfig.NetworkIsolation; ni != nil && ni.Path != "" {
+		if err := teardownTap(ni.Path, tapName); err != nil {
+			handle.logger.Error("failed to remove tap device", "err", err)
+		}
+	}
+
+	d.tasks.Delete(taskID)
+	return nil
+}
+
+func (d *Driver) InspectTask(taskID string) (*drivers.TaskStatus, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	return handle.TaskStatus(), nil
+}
+
+func (d *Driver) TaskStats(ctx context.Context, taskID string, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return nil, drivers.ErrTaskNotFound
+	}
+
+	// Without an agent only the usage of the firecracker process is known
+	if handle.agent == nil {
+		return handle.exec.Stats(ctx, interval)
+	}
+
+	return handle.stats(ctx, interval), nil
+}
+
+func (d *Driver) TaskEvents(ctx context.Context) (<-chan *drivers.TaskEvent, error) {
+	return d.eventer.TaskEvents(ctx)
+}
+
+func (d *Driver) SignalTask(taskID string, signal string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if handle.agent == nil {
+		return fmt.Errorf("Firecracker driver can't signal tasks without a VM agent")
+	}
+
+	return handle.agent.Signal(signal)
+}
+
+func (d *Driver) ExecTask(taskID string, cmdArgs []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
+	return nil, fmt.Errorf("Firecracker driver can't execute commands")
+}
+
+// GetAbsolutePath returns the absolute path of the passed binary by resolving
+// it in the path and following symlinks.
+func GetAbsolutePath(bin string) (string, error) {
+	lp, err := exec.LookPath(bin)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve path to %q executable: %v", bin, err)
+	}
+
+	return filepath.EvalSymlinks(lp)
+}
+
+func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *drivers.ExitResult) {
+	defer close(ch)
+	var result *drivers.ExitResult
+	ps, err := handle.exec.Wait(ctx)
+	if err != nil {
+		result = &drivers.ExitResult{
+			Err: fmt.Errorf("executor: error waiting on process: %v", err),
+		}
+	} else {
+		result = &drivers.ExitResult{
+			ExitCode: ps.ExitCode,
+			Signal:   ps.Signal,
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-d.ctx.Done():
+	case ch <- result:
+	}
+}
@@ -0,0 +1,360 @@
+package firecracker
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// stubFirecracker is a stand-in for the firecracker binary. It copies the
+// configuration file it was started with into its working directory and
+// blocks until killed.
+const stubFirecracker = `#!/bin/sh
+if [ "$1" = "--version" ]; then
+  echo "Firecracker v1.0.0"
+  exit 0
+fi
+[ "$3" = "--config-file" ] || exit 2
+cp "$4" stub-config.json
+exec sleep 300
+`
+
+// newStubDriver returns a driver using the stub hypervisor binary
+func newStubDriver(t *testing.T) *Driver {
+	if runtime.GOOS == "windows" {
+		t.Skip("stub hypervisor requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "firecracker")
+	require.NoError(t, ioutil.WriteFile(bin, []byte(stubFirecracker), 0755))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	d := NewFirecrackerDriver(ctx, testlog.HCLogger(t)).(*Driver)
+	d.config.FirecrackerPath = bin
+	d.config.AgentPort = 0
+	return d
+}
+
+func TestFirecrackerDriver_Start_Wait_Stop(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	d := newStubDriver(t)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	task := &drivers.TaskConfig{
+		ID:   uuid.Generate(),
+		Name: "vm",
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 256,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares:     2000,
+					ReservedCores: []uint16{0, 1, 2},
+				},
+			},
+			LinuxResources: &drivers.LinuxResources{
+				CpusetCpus: "0,1,2",
+			},
+		},
+	}
+
+	tc := &TaskConfig{
+		KernelImage: "vmlinux",
+		RootfsImage: "rootfs.ext4",
+		KernelArgs:  "quiet",
+		Vcpus:       2,
+	}
+	require.NoError(task.EncodeConcreteDriverConfig(&tc))
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+
+	taskDir := filepath.Join(task.AllocDir, task.Name)
+	require.NoError(ioutil.WriteFile(filepath.Join(taskDir, "vmlinux"), []byte("kernel"), 0644))
+	require.NoError(ioutil.WriteFile(filepath.Join(taskDir, "rootfs.ext4"), []byte("rootfs"), 0644))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(err)
+	require.NotNil(handle)
+
+	// The stub copies its configuration once started
+	var conf vmConfig
+	testutil.WaitForResult(func() (bool, error) {
+		buf, err := ioutil.ReadFile(filepath.Join(taskDir, "stub-config.json"))
+		if err != nil {
+			return false, err
+		}
+		return true, json.Unmarshal(buf, &conf)
+	}, func(err error) {
+		require.NoError(err)
+	})
+
+	require.Equal(filepath.Join(taskDir, "vmlinux"), conf.BootSource.KernelImagePath)
+	require.Equal(defaultBootArgs+" quiet", conf.BootSource.BootArgs)
+	require.Equal(2, conf.MachineConfig.VcpuCount)
+	require.Equal(256-vmmMemoryOverheadMB, conf.MachineConfig.MemSizeMib)
+	require.Nil(conf.Vsock)
+
+	// The root filesystem must be a private copy
+	require.Len(conf.Drives, 1)
+	require.Equal(filepath.Join(taskDir, vmDirName, "rootfs.img"), conf.Drives[0].PathOnHost)
+	require.True(conf.Drives[0].IsRootDevice)
+	require.False(conf.Drives[0].IsReadOnly)
+
+	// Signals require the agent
+	require.Error(d.SignalTask(task.ID, "SIGHUP"))
+
+	ch, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(err)
+
+	require.NoError(harness.StopTask(task.ID, 2*time.Second, "SIGINT"))
+
+	select {
+	case result := <-ch:
+		require.False(result.Successful())
+	case <-time.After(10 * time.Second):
+		require.Fail("timeout waiting for task to stop")
+	}
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+}
+
+func TestFirecrackerDriver_ImagePaths(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	d := newStubDriver(t)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	task := &drivers.TaskConfig{
+		ID:   uuid.Generate(),
+		Name: "vm",
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 256,
+				},
+			},
+		},
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	path, err := d.resolveImagePath(task, "kernel_image", "local/vmlinux")
+	require.NoError(err)
+	require.Equal(filepath.Join(task.AllocDir, task.Name, "local", "vmlinux"), path)
+
+	_, err = d.resolveImagePath(task, "kernel_image", "/boot/vmlinux")
+	require.EqualError(err, "kernel_image is not in the allowed paths")
+
+	_, err = d.resolveImagePath(task, "kernel_image", "../../../vmlinux")
+	require.EqualError(err, "kernel_image is not in the allowed paths")
+
+	d.config.ImagePaths = []string{"/boot"}
+	path, err = d.resolveImagePath(task, "kernel_image", "/boot/vmlinux")
+	require.NoError(err)
+	require.Equal("/boot/vmlinux", path)
+}
+
+func TestFirecrackerDriver_Fingerprint_Undetected(t *testing.T) {
+	t.Parallel()
+
+	d := NewFirecrackerDriver(context.Background(), testlog.HCLogger(t)).(*Driver)
+	d.config.FirecrackerPath = "/does/not/exist"
+
+	fp := d.buildFingerprint()
+	require.Equal(t, drivers.HealthStateUndetected, fp.Health)
+	require.Empty(t, fp.Attributes)
+}
+
+func TestFirecracker_Vcpus(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		cpuset   string
+		vcpus    int
+		expected int
+		err      bool
+	}{
+		{name: "no reserved cores", err: true},
+		{name: "no reserved cores override", vcpus: 2, err: true},
+		{name: "reserved cores", cpuset: "2,3,4", expected: 3},
+		{name: "override", cpuset: "2,3,4", vcpus: 2, expected: 2},
+		{name: "more than reserved", cpuset: "2,3,4", vcpus: 4, err: true},
+		{name: "too many", cpuset: "2", vcpus: maxVcpus + 1, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &drivers.TaskConfig{
+				Resources: &drivers.Resources{
+					NomadResources: &structs.AllocatedTaskResources{},
+					LinuxResources: &drivers.LinuxResources{
+						CpusetCpus: tc.cpuset,
+					},
+				},
+			}
+
+			n, err := vcpus(task, &TaskConfig{Vcpus: tc.vcpus})
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, n)
+		})
+	}
+}
+
+func TestFirecracker_BuildVMConfig(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	spec := &vmSpec{
+		KernelPath:  "/tmp/vmlinux",
+		KernelArgs:  "init=/sbin/agent",
+		RootfsPath:  "/tmp/rootfs.img",
+		TaskDirPath: "/tmp/taskdir.img",
+		Drives:      []string{"/tmp/data.img"},
+		Vcpus:       2,
+		MemoryMB:    512,
+		VsockPath:   "/tmp/vsock.sock",
+		Network: &guestNetwork{
+			TapName: "tap0",
+			MAC:     "aa:bb:cc:dd:ee:ff",
+			IP:      net.ParseIP("172.26.64.5"),
+			Mask:    net.CIDRMask(20, 32),
+			Gateway: net.ParseIP("172.26.64.1"),
+		},
+		DNS: &drivers.DNSConfig{
+			Servers: []string{"8.8.8.8"},
+		},
+	}
+
+	conf := buildVMConfig(spec)
+	require.Equal("/tmp/vmlinux", conf.BootSource.KernelImagePath)
+	require.Equal(defaultBootArgs+" ip=172.26.64.5::172.26.64.1:255.255.240.0::eth0:off:8.8.8.8 init=/sbin/agent",
+		conf.BootSource.BootArgs)
+
+	require.Equal([]vmDrive{
+		{DriveID: rootDriveID, PathOnHost: "/tmp/rootfs.img", IsRootDevice: true},
+		{DriveID: taskDirDriveID, PathOnHost: "/tmp/taskdir.img"},
+		{DriveID: "drive0", PathOnHost: "/tmp/data.img"},
+	}, conf.Drives)
+
+	require.Equal(vmMachineConfig{VcpuCount: 2, MemSizeMib: 512}, conf.MachineConfig)
+	require.Equal([]vmNetworkIface{{IfaceID: "eth0", GuestMac: "aa:bb:cc:dd:ee:ff", HostDevName: "tap0"}},
+		conf.NetworkInterfaces)
+	require.Equal(&vmVsock{VsockID: "agent", GuestCID: guestCID, UdsPath: "/tmp/vsock.sock"}, conf.Vsock)
+}
+
+// fakeAgent emulates the host side of Firecracker's vsock device with an
+// agent listening on port inside the guest.
+func fakeAgent(t *testing.T, port int, handler func(*agentRequest) *agentResponse) string {
+	path := filepath.Join(t.TempDir(), "vsock.sock")
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if strings.TrimSpace(line) != fmt.Sprintf("CONNECT %d", port) {
+					// Firecracker closes the connection if nothing listens
+					return
+				}
+				fmt.Fprintf(conn, "OK 1073741824\n")
+
+				var req agentRequest
+				if err := json.NewDecoder(r).Decode(&req); err != nil {
+					return
+				}
+				json.NewEncoder(conn).Encode(handler(&req))
+			}()
+		}
+	}()
+
+	return path
+}
+
+func TestFirecracker_AgentClient(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	var signals []string
+	path := fakeAgent(t, 52, func(req *agentRequest) *agentResponse {
+		switch req.Op {
+		case agentOpSignal:
+			if req.Signal == "SIGUSR2" {
+				return &agentResponse{Error: "no such signal"}
+			}
+			signals = append(signals, req.Signal)
+			return &agentResponse{}
+		case agentOpStats:
+			return &agentResponse{
+				Usage: &cstructs.ResourceUsage{
+					MemoryStats: &cstructs.MemoryStats{RSS: 1024, Measured: []string{"RSS"}},
+					CpuStats:    &cstructs.CpuStats{Percent: 50, Measured: []string{"Percent"}},
+				},
+			}
+		}
+		return &agentResponse{Error: "unknown op"}
+	})
+
+	agent := newAgentClient(path, 52)
+	require.NoError(agent.Signal("SIGHUP"))
+	require.Equal([]string{"SIGHUP"}, signals)
+
+	err := agent.Signal("SIGUSR2")
+	require.EqualError(err, "agent failed to handle signal request: no such signal")
+
+	usage, err := agent.Stats()
+	require.NoError(err)
+	require.Equal(uint64(1024), usage.MemoryStats.RSS)
+	require.Equal(float64(50), usage.CpuStats.Percent)
+
+	// Nothing listens on this port inside the guest
+	_, err = newAgentClient(path, 53).Stats()
+	require.Error(err)
+
+	// The VM is gone
+	os.Remove(path)
+	require.Error(agent.Signal("SIGHUP"))
+}
@@ -0,0 +1,13 @@
+//+build !linux
+
+package firecracker
+
+import "errors"
+
+func setupTap(nsPath, tapName string) (*guestNetwork, error) {
+	return nil, errors.New("network isolation is only supported on Linux")
+}
+
+func teardownTap(nsPath, tapName string) error {
+	return nil
+}
@@ -0,0 +1,154 @@
+package firecracker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/hashicorp/nomad/client/lib/tc"
+	"github.com/vishvananda/netlink"
+)
+
+// nsIfaceName is the name of the interface CNI creates inside the
+// allocation's network namespace in bridge mode
+const nsIfaceName = "eth0"
+
+// setupTap creates a tap device named tapName inside the network namespace
+// at nsPath and redirects all traffic between it and the namespace's
+// interface using tc. Setting up a namespace again, as when a task restarts,
+// reuses the tap device and replaces the redirects. The guest takes over the
+// interface's address and MAC, so from the bridge's point of view nothing
+// changes when the VM replaces a process in the namespace.
+func setupTap(nsPath, tapName string) (*guestNetwork, error) {
+	netns, err := ns.GetNS(nsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer netns.Close()
+
+	var gn *guestNetwork
+	err = netns.Do(func(ns.NetNS) error {
+		iface, err := netlink.LinkByName(nsIfaceName)
+		if err != nil {
+			return fmt.Errorf("failed to find interface %q: %v", nsIfaceName, err)
+		}
+
+		addrs, err := netlink.AddrList(iface, netlink.FAMILY_V4)
+		if err != nil {
+			return fmt.Errorf("failed to list addresses of %q: %v", nsIfaceName, err)
+		}
+		if len(addrs) == 0 {
+			return fmt.Errorf("interface %q has no IPv4 address", nsIfaceName)
+		}
+
+		routes, err := netlink.RouteList(iface, netlink.FAMILY_V4)
+		if err != nil {
+			return fmt.Errorf("failed to list routes of %q: %v", nsIfaceName, err)
+		}
+
+		gn = &guestNetwork{
+			TapName: tapName,
+			MAC:     iface.Attrs().HardwareAddr.String(),
+			IP:      addrs[0].IP,
+			Mask:    addrs[0].Mask,
+		}
+		for _, r := range routes {
+			if r.Dst == nil && r.Gw != nil {
+				gn.Gateway = r.Gw
+				break
+			}
+		}
+
+		tap, created, err := ensureTap(tapName, iface.Attrs().MTU)
+		if err != nil {
+			return err
+		}
+		if err := netlink.LinkSetUp(tap); err != nil {
+			return fmt.Errorf("failed to set tap device up: %v", err)
+		}
+
+		err = tc.Redirect(iface, tap, tc.PriorityTap)
+		if err == nil {
+			return tc.Redirect(tap, iface, tc.PriorityTap)
+		}
+
+		if created {
+			netlink.LinkDel(tap)
+		}
+		if errors.Is(err, tc.ErrRedirected) {
+			return fmt.Errorf("%v: firecracker tasks don't support the client's enforce_network_bandwidth", err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gn, nil
+}
+
+// teardownTap removes the tap device named tapName and the redirect to it
+// from the network namespace at nsPath. A namespace that is already gone has
+// nothing left to remove.
+func teardownTap(nsPath, tapName string) error {
+	netns, err := ns.GetNS(nsPath)
+	if err != nil {
+		if _, ok := err.(ns.NSPathNotExistErr); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer netns.Close()
+
+	return netns.Do(func(ns.NetNS) error {
+		iface, err := netlink.LinkByName(nsIfaceName)
+		switch err.(type) {
+		case nil:
+			if err := tc.Unredirect(iface, tc.PriorityTap); err != nil {
+				return err
+			}
+		case netlink.LinkNotFoundError:
+		default:
+			return fmt.Errorf("failed to find interface %q: %v", nsIfaceName, err)
+		}
+
+		// Removing the tap device removes its redirect as well
+		tap, err := netlink.LinkByName(tapName)
+		switch err.(type) {
+		case nil:
+			if err := netlink.LinkDel(tap); err != nil {
+				return fmt.Errorf("failed to remove tap device: %v", err)
+			}
+		case netlink.LinkNotFoundError:
+		default:
+			return fmt.Errorf("failed to find interface %q: %v", tapName, err)
+		}
+		return nil
+	})
+}
+
+// ensureTap returns the tap device named tapName, creating it if needed, and
+// whether it was created
+func ensureTap(tapName string, mtu int) (netlink.Link, bool, error) {
+	tap, err := netlink.LinkByName(tapName)
+	if err == nil {
+		return tap, false, nil
+	}
+	if _, ok := err.(netlink.LinkNotFoundError); !ok {
+		return nil, false, fmt.Errorf("failed to find interface %q: %v", tapName, err)
+	}
+
+	tap = &netlink.Tuntap{
+		LinkAttrs: netlink.LinkAttrs{
+			Name: tapName,
+			MTU:  mtu,
+		},
+		Mode:   netlink.TUNTAP_MODE_TAP,
+		Flags:  netlink.TUNTAP_ONE_QUEUE | netlink.TUNTAP_NO_PI | netlink.TUNTAP_VNET_HDR,
+		Queues: 1,
+	}
+	if err := netlink.LinkAdd(tap); err != nil {
+		return nil, false, fmt.Errorf("failed to create tap device: %v", err)
+	}
+	return tap, true, nil
+}
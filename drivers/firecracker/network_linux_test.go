@@ -0,0 +1,201 @@
+package firecracker
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/containernetworking/plugins/pkg/testutils"
+	"github.com/hashicorp/nomad/client/lib/bandwidth"
+	"github.com/hashicorp/nomad/client/lib/tc"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/plugins/drivers"
+	dtestutil "github.com/hashicorp/nomad/plugins/drivers/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+)
+
+// newTestNS creates a network namespace with an addressed interface named
+// eth0, as CNI does in bridge mode
+func newTestNS(t *testing.T) ns.NetNS {
+	if syscall.Geteuid() != 0 {
+		t.Skip("Test only available running as root")
+	}
+
+	netns, err := testutils.NewNS()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		netns.Close()
+		testutils.UnmountNS(netns)
+	})
+
+	err = netns.Do(func(ns.NetNS) error {
+		veth := &netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: "host0"},
+			PeerName:  nsIfaceName,
+		}
+		if err := netlink.LinkAdd(veth); err != nil {
+			return err
+		}
+		eth0, err := netlink.LinkByName(nsIfaceName)
+		if err != nil {
+			return err
+		}
+		addr, err := netlink.ParseAddr("172.26.64.2/20")
+		if err != nil {
+			return err
+		}
+		return netlink.AddrAdd(eth0, addr)
+	})
+	require.NoError(t, err)
+	return netns
+}
+
+// newNetworkTask returns a task joining the network namespace netns, whose
+// images must be written to its task directory once created
+func newNetworkTask(t *testing.T, netns ns.NetNS) *drivers.TaskConfig {
+	task := &drivers.TaskConfig{
+		ID:   uuid.Generate(),
+		Name: "vm",
+		Resources: &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{
+				Memory: structs.AllocatedMemoryResources{
+					MemoryMB: 256,
+				},
+				Cpu: structs.AllocatedCpuResources{
+					CpuShares:     1000,
+					ReservedCores: []uint16{0},
+				},
+			},
+			LinuxResources: &drivers.LinuxResources{
+				CpusetCpus: "0",
+			},
+		},
+		NetworkIsolation: &drivers.NetworkIsolationSpec{
+			Mode: drivers.NetIsolationModeGroup,
+			Path: netns.Path(),
+		},
+	}
+	tc := &TaskConfig{
+		KernelImage: "vmlinux",
+		RootfsImage: "rootfs.ext4",
+		Vcpus:       1,
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+	return task
+}
+
+// writeImages writes stub images to the task directory of task
+func writeImages(t *testing.T, task *drivers.TaskConfig) {
+	taskDir := filepath.Join(task.AllocDir, task.Name)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "vmlinux"), []byte("kernel"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "rootfs.ext4"), []byte("rootfs"), 0644))
+}
+
+// requireRedirects asserts the ingress traffic of eth0 is redirected to the
+// interfaces named by expected, keyed by filter priority, and whether the
+// tap device exists
+func requireRedirects(t *testing.T, netns ns.NetNS, expected map[uint16]string) {
+	err := netns.Do(func(ns.NetNS) error {
+		eth0, err := netlink.LinkByName(nsIfaceName)
+		require.NoError(t, err)
+		filters, err := netlink.FilterList(eth0, netlink.MakeHandle(0xffff, 0))
+		require.NoError(t, err)
+
+		redirects := map[uint16]string{}
+		for _, f := range filters {
+			index := f.(*netlink.U32).Actions[0].(*netlink.MirredAction).Ifindex
+			link, err := netlink.LinkByIndex(index)
+			require.NoError(t, err)
+			redirects[f.Attrs().Priority] = link.Attrs().Name
+		}
+		require.Equal(t, expected, redirects)
+
+		_, err = netlink.LinkByName(tapName)
+		if expected[tc.PriorityTap] == "" {
+			require.IsType(t, netlink.LinkNotFoundError{}, err)
+		} else {
+			require.NoError(t, err)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestFirecrackerDriver_StartTwice_Network(t *testing.T) {
+	netns := newTestNS(t)
+	require := require.New(t)
+
+	d := newStubDriver(t)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	redirected := map[uint16]string{tc.PriorityTap: tapName}
+
+	task := newNetworkTask(t, netns)
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+	writeImages(t, task)
+
+	_, _, err := harness.StartTask(task)
+	require.NoError(err)
+	requireRedirects(t, netns, redirected)
+
+	// Starting another task in the same namespace reuses the tap device
+	other := newNetworkTask(t, netns)
+	other.AllocDir = task.AllocDir
+	_, _, err = harness.StartTask(other)
+	require.NoError(err)
+	requireRedirects(t, netns, redirected)
+	require.NoError(harness.DestroyTask(other.ID, true))
+	requireRedirects(t, netns, map[uint16]string{})
+
+	// Restarting a task sets its network up again
+	require.NoError(harness.DestroyTask(task.ID, true))
+	requireRedirects(t, netns, map[uint16]string{})
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	requireRedirects(t, netns, redirected)
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+	requireRedirects(t, netns, map[uint16]string{})
+}
+
+// TestFirecrackerDriver_Network_BandwidthLimit asserts VMs and bandwidth
+// limits refuse to take over each other's redirect of the namespace's
+// interface.
+func TestFirecrackerDriver_Network_BandwidthLimit(t *testing.T) {
+	netns := newTestNS(t)
+	require := require.New(t)
+
+	d := newStubDriver(t)
+	harness := dtestutil.NewDriverHarness(t, d)
+	defer harness.Kill()
+
+	task := newNetworkTask(t, netns)
+	cleanup := harness.MkAllocDir(task, true)
+	defer cleanup()
+	writeImages(t, task)
+
+	// A VM can't start in a limited namespace
+	require.NoError(bandwidth.Limit(netns.Path(), nsIfaceName, 10))
+	_, _, err := harness.StartTask(task)
+	require.Error(err)
+	require.Contains(err.Error(), "enforce_network_bandwidth")
+	requireRedirects(t, netns, map[uint16]string{tc.PriorityLimit: "ifb0"})
+
+	// A namespace with a VM can't be limited
+	require.NoError(bandwidth.Limit(netns.Path(), nsIfaceName, 0))
+	_, _, err = harness.StartTask(task)
+	require.NoError(err)
+	err = bandwidth.Limit(netns.Path(), nsIfaceName, 10)
+	require.True(errors.Is(err, tc.ErrRedirected), "unexpected error: %v", err)
+	requireRedirects(t, netns, map[uint16]string{tc.PriorityTap: tapName})
+
+	require.NoError(harness.DestroyTask(task.ID, true))
+	requireRedirects(t, netns, map[uint16]string{})
+}
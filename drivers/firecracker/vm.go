@@ -0,0 +1,219 @@
+package firecracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// defaultBootArgs are the kernel arguments every microVM is booted with.
+	// The serial console is forwarded to the task's stdout.
+	defaultBootArgs = "console=ttyS0 reboot=k panic=1 pci=off"
+
+	// guestCID is the vsock context ID of the guest. Each VM has its own
+	// vsock device so the ID only has to be unique within the VM.
+	guestCID = 3
+
+	// rootDriveID is the Firecracker drive ID of the root filesystem
+	rootDriveID = "rootfs"
+
+	// taskDirDriveID is the Firecracker drive ID of the block device built
+	// from the task's local/ directory
+	taskDirDriveID = "taskdir"
+
+	// guestIfaceID is the name of the network interface inside the guest
+	guestIfaceID = "eth0"
+)
+
+// vmConfig is the Firecracker configuration file passed with --config-file.
+// See https://github.com/firecracker-microvm/firecracker/blob/master/tests/framework/vm_config.json
+type vmConfig struct {
+	BootSource        vmBootSource     `json:"boot-source"`
+	Drives            []vmDrive        `json:"drives"`
+	MachineConfig     vmMachineConfig  `json:"machine-config"`
+	NetworkInterfaces []vmNetworkIface `json:"network-interfaces,omitempty"`
+	Vsock             *vmVsock         `json:"vsock,omitempty"`
+}
+
+type vmBootSource struct {
+	KernelImagePath string `json:"kernel_image_path"`
+	BootArgs        string `json:"boot_args"`
+}
+
+type vmDrive struct {
+	DriveID      string `json:"drive_id"`
+	PathOnHost   string `json:"path_on_host"`
+	IsRootDevice bool   `json:"is_root_device"`
+	IsReadOnly   bool   `json:"is_read_only"`
+}
+
+type vmMachineConfig struct {
+	VcpuCount  int  `json:"vcpu_count"`
+	MemSizeMib int  `json:"mem_size_mib"`
+	HtEnabled  bool `json:"ht_enabled"`
+}
+
+type vmNetworkIface struct {
+	IfaceID     string `json:"iface_id"`
+	GuestMac    string `json:"guest_mac,omitempty"`
+	HostDevName string `json:"host_dev_name"`
+}
+
+type vmVsock struct {
+	VsockID  string `json:"vsock_id"`
+	GuestCID int    `json:"guest_cid"`
+	UdsPath  string `json:"uds_path"`
+}
+
+// guestNetwork is the network configuration handed to the guest kernel when
+// the VM is attached to the allocation's network namespace.
+type guestNetwork struct {
+	// TapName is the name of the tap device in the network namespace
+	TapName string
+
+	// MAC is the hardware address the guest must use for traffic redirected
+	// from the namespace's interface to be accepted
+	MAC string
+
+	IP      net.IP
+	Mask    net.IPMask
+	Gateway net.IP
+}
+
+// vmSpec holds the resolved inputs used to build a vmConfig
+type vmSpec struct {
+	KernelPath  string
+	KernelArgs  string
+	RootfsPath  string
+	TaskDirPath string
+	Drives      []string
+	Vcpus       int
+	MemoryMB    int
+	VsockPath   string
+	Network     *guestNetwork
+	DNS         *drivers.DNSConfig
+}
+
+// buildVMConfig returns the Firecracker configuration for the spec
+func buildVMConfig(spec *vmSpec) *vmConfig {
+	conf := &vmConfig{
+		BootSource: vmBootSource{
+			KernelImagePath: spec.KernelPath,
+			BootArgs:        bootArgs(spec),
+		},
+		Drives: []vmDrive{{
+			DriveID:      rootDriveID,
+			PathOnHost:   spec.RootfsPath,
+			IsRootDevice: true,
+		}},
+		MachineConfig: vmMachineConfig{
+			VcpuCount:  spec.Vcpus,
+			MemSizeMib: spec.MemoryMB,
+		},
+	}
+
+	if spec.TaskDirPath != "" {
+		conf.Drives = append(conf.Drives, vmDrive{
+			DriveID:    taskDirDriveID,
+			PathOnHost: spec.TaskDirPath,
+		})
+	}
+
+	for i, d := range spec.Drives {
+		conf.Drives = append(conf.Drives, vmDrive{
+			DriveID:    fmt.Sprintf("drive%d", i),
+			PathOnHost: d,
+		})
+	}
+
+	if spec.Network != nil {
+		conf.NetworkInterfaces = []vmNetworkIface{{
+			IfaceID:     guestIfaceID,
+			GuestMac:    spec.Network.MAC,
+			HostDevName: spec.Network.TapName,
+		}}
+	}
+
+	if spec.VsockPath != "" {
+		conf.Vsock = &vmVsock{
+			VsockID:  "agent",
+			GuestCID: guestCID,
+			UdsPath:  spec.VsockPath,
+		}
+	}
+
+	return conf
+}
+
+// bootArgs returns the kernel command line for the spec. Network settings
+// are passed using the kernel's ip= parameter so the guest doesn't need a
+// DHCP client.
+func bootArgs(spec *vmSpec) string {
+	args := []string{defaultBootArgs}
+
+	if n := spec.Network; n != nil {
+		// ip=<client-ip>::<gw-ip>:<netmask>:<hostname>:<device>:<autoconf>:<dns0>
+		ip := fmt.Sprintf("ip=%s::%s:%s::%s:off", n.IP, n.Gateway, net.IP(n.Mask), guestIfaceID)
+		if spec.DNS != nil && len(spec.DNS.Servers) > 0 {
+			ip += ":" + spec.DNS.Servers[0]
+		}
+		args = append(args, ip)
+	}
+
+	if spec.KernelArgs != "" {
+		args = append(args, spec.KernelArgs)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// writeVMConfig writes the Firecracker configuration file to path
+func writeVMConfig(path string, conf *vmConfig) error {
+	buf, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf, 0600)
+}
+
+// sendCtrlAltDel asks the guest to shut down using the Firecracker API. This
+// emulates pressing ctrl+alt+del on a keyboard, which most init systems
+// handle by performing an orderly shutdown.
+func sendCtrlAltDel(socketPath string, timeout time.Duration) error {
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+
+	body := strings.NewReader(`{"action_type": "SendCtrlAltDel"}`)
+	req, err := http.NewRequest(http.MethodPut, "http://localhost/actions", body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected response from firecracker (%d): %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -108,6 +108,7 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportNone,
+		Resize:       true,
 	}
 
 	_ drivers.DriverPlugin = (*Driver)(nil)
@@ -599,8 +600,11 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 		}
 	} else {
 		result = &drivers.ExitResult{
-			ExitCode: ps.ExitCode,
-			Signal:   ps.Signal,
+			ExitCode:    ps.ExitCode,
+			Signal:      ps.Signal,
+			OOMKilled:   ps.OOMKilled,
+			MemoryPeak:  ps.MemoryPeak,
+			MemoryLimit: ps.MemoryLimit,
 		}
 	}
 
@@ -689,6 +693,16 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return handle.exec.Signal(sig)
 }
 
+// UpdateTaskResources applies new resource limits to a running task.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return handle.exec.UpdateResources(resources)
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	if len(cmd) == 0 {
 		return nil, fmt.Errorf("error cmd must have at least one value")
@@ -712,6 +726,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingRawDriver = (*Driver)(nil)
+var _ drivers.ResizeDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 	taskID string,
@@ -55,9 +55,10 @@ func runCommand(c Command, stdout, stderr io.WriteCloser, cancelCh <-chan struct
 	}
 
 	return &drivers.ExitResult{
-		ExitCode: c.ExitCode,
-		Signal:   c.ExitSignal,
-		Err:      exitErr,
+		ExitCode:  c.ExitCode,
+		Signal:    c.ExitSignal,
+		OOMKilled: c.ExitOOMKilled,
+		Err:       exitErr,
 	}
 }
 
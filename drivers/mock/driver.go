@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -85,6 +87,7 @@ var (
 		"exit_code":              hclspec.NewAttr("exit_code", "number", false),
 		"exit_signal":            hclspec.NewAttr("exit_signal", "number", false),
 		"exit_err_msg":           hclspec.NewAttr("exit_err_msg", "string", false),
+		"exit_oom_killed":        hclspec.NewAttr("exit_oom_killed", "bool", false),
 		"signal_error":           hclspec.NewAttr("signal_error", "string", false),
 		"stdout_string":          hclspec.NewAttr("stdout_string", "string", false),
 		"stdout_repeat":          hclspec.NewAttr("stdout_repeat", "number", false),
@@ -98,6 +101,7 @@ var (
 			"exit_code":              hclspec.NewAttr("exit_code", "number", false),
 			"exit_signal":            hclspec.NewAttr("exit_signal", "number", false),
 			"exit_err_msg":           hclspec.NewAttr("exit_err_msg", "string", false),
+			"exit_oom_killed":        hclspec.NewAttr("exit_oom_killed", "bool", false),
 			"signal_error":           hclspec.NewAttr("signal_error", "string", false),
 			"stdout_string":          hclspec.NewAttr("stdout_string", "string", false),
 			"stdout_repeat":          hclspec.NewAttr("stdout_repeat", "number", false),
@@ -153,6 +157,7 @@ func NewMockDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugi
 		Exec:         true,
 		FSIsolation:  drivers.FSIsolationNone,
 		MountConfigs: drivers.MountConfigSupportNone,
+		Checkpoint:   true,
 	}
 
 	return &Driver{
@@ -198,6 +203,10 @@ type Command struct {
 	// ExitErrMsg is the error message that the task returns while exiting
 	ExitErrMsg string `codec:"exit_err_msg"`
 
+	// ExitOOMKilled indicates the task was killed by the OOM killer when it
+	// exits
+	ExitOOMKilled bool `codec:"exit_oom_killed"`
+
 	// SignalErr is the error message that the task returns if signalled
 	SignalErr string `codec:"signal_error"`
 
@@ -493,6 +502,38 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 
 }
 
+// mockCheckpointFile is the file the mock driver writes into the checkpoint
+// directory.
+const mockCheckpointFile = "mock.img"
+
+// CheckpointTask writes a fake checkpoint image for the task and stops it.
+func (d *Driver) CheckpointTask(taskID string, dir string) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, mockCheckpointFile), []byte(h.taskConfig.ID), 0600); err != nil {
+		return err
+	}
+
+	h.kill()
+	return nil
+}
+
+// RestoreTask starts the task as StartTask would after ensuring a checkpoint
+// image written by CheckpointTask exists in dir.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, dir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	if _, err := os.Stat(filepath.Join(dir, mockCheckpointFile)); err != nil {
+		return nil, nil, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+
+	return d.StartTask(cfg)
+}
+
 func (d *Driver) WaitTask(ctx context.Context, taskID string) (<-chan *drivers.ExitResult, error) {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -623,6 +664,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingDriver = (*Driver)(nil)
+var _ drivers.CheckpointDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreaming(ctx context.Context, taskID string, execOpts *drivers.ExecOptions) (*drivers.ExitResult, error) {
 	h, ok := d.tasks.Get(taskID)
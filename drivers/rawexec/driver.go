@@ -102,6 +102,7 @@ var (
 			drivers.NetIsolationModeGroup,
 		},
 		MountConfigs: drivers.MountConfigSupportNone,
+		Resize:       true,
 	}
 )
 
@@ -500,6 +501,17 @@ func (d *Driver) SignalTask(taskID string, signal string) error {
 	return handle.exec.Signal(sig)
 }
 
+// UpdateTaskResources accepts new resources for a running task. raw_exec
+// doesn't enforce resource limits, so there is nothing to apply and the
+// allocation can always be updated in place.
+func (d *Driver) UpdateTaskResources(taskID string, resources *drivers.Resources) error {
+	if _, ok := d.tasks.Get(taskID); !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	return nil
+}
+
 func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*drivers.ExecTaskResult, error) {
 	if len(cmd) == 0 {
 		return nil, fmt.Errorf("error cmd must have at least one value")
@@ -523,6 +535,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingRawDriver = (*Driver)(nil)
+var _ drivers.ResizeDriver = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 	taskID string,
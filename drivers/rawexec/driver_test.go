@@ -503,3 +503,17 @@ func TestRawExecDriver_Disabled(t *testing.T) {
 	require.Contains(err.Error(), errDisabledDriver.Error())
 	require.Nil(handle)
 }
+
+// TestRawExecDriver_UpdateTaskResources asserts raw_exec accepts resizes in
+// place, as it enforces no resource limits that would need updating.
+func TestRawExecDriver_UpdateTaskResources(t *testing.T) {
+	t.Parallel()
+
+	d := newEnabledRawExecDriver(t)
+	caps, err := d.Capabilities()
+	require.NoError(t, err)
+	require.True(t, caps.Resize)
+
+	err = d.UpdateTaskResources("missing", &drivers.Resources{})
+	require.Equal(t, drivers.ErrTaskNotFound, err)
+}
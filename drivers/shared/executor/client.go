@@ -48,6 +48,11 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		RestoreFrom:        cmd.RestoreFrom,
+	}
+	if cmd.UserNS != nil {
+		req.UsernsHostId = cmd.UserNS.HostID
+		req.UsernsSize = cmd.UserNS.Size
 	}
 	resp, err := c.client.Launch(ctx, req)
 	if err != nil {
@@ -101,6 +106,16 @@ func (c *grpcExecutorClient) UpdateResources(r *drivers.Resources) error {
 	return nil
 }
 
+func (c *grpcExecutorClient) Checkpoint(imagesDir string) error {
+	ctx := context.Background()
+	req := &proto.CheckpointRequest{ImagesDir: imagesDir}
+	if _, err := c.client.Checkpoint(ctx, req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *grpcExecutorClient) Version() (*ExecutorVersion, error) {
 	ctx := context.Background()
 	resp, err := c.client.Version(ctx, &proto.VersionRequest{})
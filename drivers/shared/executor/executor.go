@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -44,6 +45,10 @@ const (
 )
 
 var (
+	// ErrCheckpointNotSupported is returned by executors which are unable to
+	// checkpoint or restore the user process.
+	ErrCheckpointNotSupported = errors.New("executor does not support checkpointing")
+
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
 	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
@@ -88,6 +93,11 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint writes the state of the user process into imagesDir using
+	// CRIU and stops the process. Executors without libcontainer isolation
+	// return ErrCheckpointNotSupported.
+	Checkpoint(imagesDir string) error
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -154,6 +164,30 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// RestoreFrom is the path of a checkpoint image directory previously
+	// written by Checkpoint. When set the process is restored from the image
+	// rather than started from Cmd.
+	RestoreFrom string
+
+	// UserNS maps the task's user namespace onto a range of host UIDs and
+	// GIDs. Tasks run in the host user namespace if nil.
+	UserNS *UserNSMapping
+}
+
+// UserNSMapping maps UIDs and GIDs [0, Size) in a task's user namespace to
+// [HostID, HostID+Size) on the host.
+type UserNSMapping struct {
+	HostID uint32
+	Size   uint32
+}
+
+// ToHost returns the host ID that id inside the user namespace maps to.
+func (m *UserNSMapping) ToHost(id uint32) (uint32, error) {
+	if id >= m.Size {
+		return 0, fmt.Errorf("id %d is outside of the user namespace range of %d ids", id, m.Size)
+	}
+	return m.HostID + id, nil
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -223,6 +257,13 @@ type ProcessState struct {
 	ExitCode int
 	Signal   int
 	Time     time.Time
+
+	// OOMKilled is set if the kernel OOM killer killed a process of the
+	// task. MemoryPeak and MemoryLimit are the peak memory usage and the
+	// memory limit of the task's cgroup in bytes, if known.
+	OOMKilled   bool
+	MemoryPeak  uint64
+	MemoryLimit uint64
 }
 
 // ExecutorVersion is the version of the executor
@@ -282,6 +323,10 @@ func (e *UniversalExecutor) Version() (*ExecutorVersion, error) {
 func (e *UniversalExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	e.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
+	if command.RestoreFrom != "" {
+		return nil, ErrCheckpointNotSupported
+	}
+
 	e.commandCfg = command
 
 	// setting the user of the process
@@ -456,10 +501,17 @@ func (e *UniversalExecutor) Wait(ctx context.Context) (*ProcessState, error) {
 	}
 }
 
+// UpdateResources is a no-op since the universal executor doesn't isolate
+// resources; there are no limits to update.
 func (e *UniversalExecutor) UpdateResources(resources *drivers.Resources) error {
 	return nil
 }
 
+// Checkpoint is not supported without libcontainer isolation
+func (e *UniversalExecutor) Checkpoint(imagesDir string) error {
+	return ErrCheckpointNotSupported
+}
+
 func (e *UniversalExecutor) wait() {
 	defer close(e.processExited)
 	defer e.commandCfg.Close()
@@ -6,10 +6,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -32,6 +34,7 @@ import (
 	lconfigs "github.com/opencontainers/runc/libcontainer/configs"
 	ldevices "github.com/opencontainers/runc/libcontainer/devices"
 	"github.com/opencontainers/runc/libcontainer/specconv"
+	luser "github.com/opencontainers/runc/libcontainer/user"
 	lutils "github.com/opencontainers/runc/libcontainer/utils"
 	"golang.org/x/sys/unix"
 )
@@ -67,6 +70,11 @@ type LibcontainerExecutor struct {
 	userProc       *libcontainer.Process
 	userProcExited chan interface{}
 	exitState      *ProcessState
+
+	// oomKilled is closed once the kernel OOM killer killed a process of
+	// the container. It is nil if memory events can't be watched.
+	oomKilled   chan struct{}
+	memoryLimit uint64
 }
 
 func NewExecutorWithIsolation(logger hclog.Logger) Executor {
@@ -109,6 +117,12 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, fmt.Errorf("failed to create factory: %v", err)
 	}
 
+	if command.UserNS != nil {
+		if err := chownUserNSDirs(command); err != nil {
+			return nil, err
+		}
+	}
+
 	// A container groups processes under the same isolation enforcement
 	containerCfg, err := newLibcontainerConfig(command)
 	if err != nil {
@@ -177,8 +191,14 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.userCpuStats = stats.NewCpuStats()
 	l.systemCpuStats = stats.NewCpuStats()
 
-	// Starts the task
-	if err := container.Run(process); err != nil {
+	// Starts the task, restoring it from a checkpoint image if one was given
+	if command.RestoreFrom != "" {
+		l.logger.Debug("restoring from checkpoint", "images_dir", command.RestoreFrom)
+		err = container.Restore(process, criuOpts(command.RestoreFrom))
+	} else {
+		err = container.Run(process)
+	}
+	if err != nil {
 		container.Destroy()
 		return nil, err
 	}
@@ -189,6 +209,11 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 		return nil, err
 	}
 
+	if command.ResourceLimits {
+		l.memoryLimit = uint64(containerCfg.Cgroups.Resources.Memory)
+		l.watchOOM()
+	}
+
 	// start a goroutine to wait on the process to complete, so Wait calls can
 	// be multiplexed
 	l.userProcExited = make(chan interface{})
@@ -264,6 +289,85 @@ func (l *LibcontainerExecutor) wait() {
 		Signal:   signal,
 		Time:     time.Now(),
 	}
+
+	if l.wasOOMKilled(signal) {
+		l.exitState.OOMKilled = true
+		l.exitState.MemoryPeak = l.memoryPeak()
+		l.exitState.MemoryLimit = l.memoryLimit
+	}
+}
+
+// oomNotifyGrace is how long to wait for the OOM notification of a task that
+// was killed by SIGKILL, as the notification may race the task's exit.
+const oomNotifyGrace = 100 * time.Millisecond
+
+// watchOOM starts watching the memory events of the container's cgroup for
+// OOM kills.
+func (l *LibcontainerExecutor) watchOOM() {
+	ch, err := l.container.NotifyOOM()
+	if err != nil {
+		l.logger.Warn("unable to watch for OOM kills", "error", err)
+		return
+	}
+
+	l.oomKilled = make(chan struct{})
+	go func() {
+		// the channel is closed once the cgroup is destroyed
+		_, ok := <-ch
+		if ok {
+			close(l.oomKilled)
+		}
+		for range ch {
+		}
+	}()
+}
+
+// wasOOMKilled returns whether the OOM killer killed a process of the task.
+func (l *LibcontainerExecutor) wasOOMKilled(signal int) bool {
+	if l.oomKilled == nil {
+		return false
+	}
+
+	select {
+	case <-l.oomKilled:
+		return true
+	default:
+	}
+
+	if signal != int(syscall.SIGKILL) {
+		return false
+	}
+
+	select {
+	case <-l.oomKilled:
+		return true
+	case <-time.After(oomNotifyGrace):
+		return false
+	}
+}
+
+// memoryPeak returns the peak memory usage of the container's cgroup in
+// bytes, or 0 if it isn't available.
+func (l *LibcontainerExecutor) memoryPeak() uint64 {
+	if cgroups.IsCgroup2UnifiedMode() {
+		// cgroup-v2 only reports the peak usage on recent kernels
+		state, err := l.container.State()
+		if err != nil {
+			return 0
+		}
+		buf, err := ioutil.ReadFile(filepath.Join(state.CgroupPaths[""], "memory.peak"))
+		if err != nil {
+			return 0
+		}
+		peak, _ := strconv.ParseUint(strings.TrimSpace(string(buf)), 10, 64)
+		return peak
+	}
+
+	lstats, err := l.container.Stats()
+	if err != nil || lstats.CgroupStats == nil {
+		return 0
+	}
+	return lstats.CgroupStats.MemoryStats.Usage.MaxUsage
 }
 
 // Shutdown stops all processes started and cleans up any resources
@@ -326,8 +430,53 @@ func (l *LibcontainerExecutor) Shutdown(signal string, grace time.Duration) erro
 	}
 }
 
+// Checkpoint dumps the state of the container into imagesDir using CRIU. The
+// container's processes are stopped once the dump completes.
+func (l *LibcontainerExecutor) Checkpoint(imagesDir string) error {
+	if l.container == nil {
+		return fmt.Errorf("container(%s) has not been started", l.id)
+	}
+
+	if err := os.MkdirAll(imagesDir, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint dir: %v", err)
+	}
+
+	if err := l.container.Checkpoint(criuOpts(imagesDir)); err != nil {
+		return fmt.Errorf("failed to checkpoint container(%s): %v", l.id, err)
+	}
+	return nil
+}
+
+// criuOpts returns the options used to checkpoint a container into, and
+// restore it from, imagesDir.
+func criuOpts(imagesDir string) *libcontainer.CriuOpts {
+	return &libcontainer.CriuOpts{
+		ImagesDirectory: imagesDir,
+		FileLocks:       true,
+	}
+}
+
 // UpdateResources updates the resource isolation with new values to be enforced
 func (l *LibcontainerExecutor) UpdateResources(resources *drivers.Resources) error {
+	if !l.command.ResourceLimits || resources == nil || resources.NomadResources == nil {
+		return nil
+	}
+	if l.container == nil {
+		return fmt.Errorf("container not started")
+	}
+
+	cfg := l.container.Config()
+	if err := configureCgroupResources(cfg.Cgroups.Resources, resources.NomadResources); err != nil {
+		return err
+	}
+	if err := l.container.Set(cfg); err != nil {
+		return fmt.Errorf("failed to update container resources: %v", err)
+	}
+
+	if l.command.Resources != nil {
+		l.command.Resources.NomadResources = resources.NomadResources
+	}
+	l.memoryLimit = uint64(cfg.Cgroups.Resources.Memory)
 	return nil
 }
 
@@ -657,6 +806,88 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
 
+	if command.UserNS != nil {
+		configureUserNS(cfg, command)
+	}
+
+	return nil
+}
+
+// configureUserNS runs the task in a new user namespace where root is
+// mapped to the unprivileged host range of command.UserNS.
+func configureUserNS(cfg *lconfigs.Config, command *ExecCommand) {
+	cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+
+	idMap := []lconfigs.IDMap{{
+		ContainerID: 0,
+		HostID:      int(command.UserNS.HostID),
+		Size:        int(command.UserNS.Size),
+	}}
+	cfg.UidMappings = idMap
+	cfg.GidMappings = idMap
+
+	// sysfs and mqueue may only be mounted by the user namespace owning the
+	// network and IPC namespaces respectively, so bind mount the host's
+	// when these are shared with the host.
+	for i, m := range cfg.Mounts {
+		switch {
+		case m.Device == "sysfs" && command.NetworkIsolation == nil:
+			cfg.Mounts[i] = &lconfigs.Mount{
+				Source:      "/sys",
+				Destination: "/sys",
+				Device:      "bind",
+				Flags:       syscall.MS_BIND | syscall.MS_REC | syscall.MS_RDONLY | syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV,
+			}
+		case m.Device == "mqueue" && command.ModeIPC != IsolationModePrivate:
+			cfg.Mounts[i] = &lconfigs.Mount{
+				Source:      "/dev/mqueue",
+				Destination: "/dev/mqueue",
+				Device:      "bind",
+				Flags:       syscall.MS_BIND | syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV,
+			}
+		}
+	}
+}
+
+// chownUserNSDirs hands the writable task and alloc directories to the
+// host ID the task user is mapped to, so they remain writable from within
+// the user namespace. Files hard linked into the chroot are left owned by
+// the host's root and appear as read only to the task.
+func chownUserNSDirs(command *ExecCommand) error {
+	execUser, err := luser.GetExecUserPath(command.User, nil,
+		filepath.Join(command.TaskDir, "etc", "passwd"),
+		filepath.Join(command.TaskDir, "etc", "group"))
+	if err != nil {
+		return fmt.Errorf("failed to look up task user %q: %v", command.User, err)
+	}
+
+	uid, err := command.UserNS.ToHost(uint32(execUser.Uid))
+	if err != nil {
+		return err
+	}
+	gid, err := command.UserNS.ToHost(uint32(execUser.Gid))
+	if err != nil {
+		return err
+	}
+
+	dirs := []string{
+		filepath.Join(command.TaskDir, allocdir.TaskLocal),
+		filepath.Join(command.TaskDir, allocdir.TaskSecrets),
+		filepath.Join(command.TaskDir, allocdir.TmpDirName),
+		filepath.Join(command.TaskDir, allocdir.SharedAllocName, allocdir.SharedDataDir),
+		filepath.Join(command.TaskDir, allocdir.SharedAllocName, allocdir.TmpDirName),
+	}
+	for _, dir := range dirs {
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(path, int(uid), int(gid))
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to change ownership of %q: %v", dir, err)
+		}
+	}
 	return nil
 }
 
@@ -674,8 +905,25 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 		return nil
 	}
 
+	if err := configureCgroupResources(cfg.Cgroups.Resources, command.Resources.NomadResources); err != nil {
+		return err
+	}
+
+	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
+		cfg.Hooks = lconfigs.Hooks{
+			lconfigs.CreateRuntime: lconfigs.HookList{
+				newSetCPUSetCgroupHook(command.Resources.LinuxResources.CpusetCgroupPath),
+			},
+		}
+	}
+
+	return nil
+}
+
+// configureCgroupResources sets the memory and CPU limits of the cgroup
+// resources from the task's allocated resources.
+func configureCgroupResources(cgroupRes *lconfigs.Resources, res *structs.AllocatedTaskResources) error {
 	// Total amount of memory allowed to consume
-	res := command.Resources.NomadResources
 	memHard, memSoft := res.Memory.MemoryMaxMB, res.Memory.MemoryMB
 	if memHard <= 0 {
 		memHard = res.Memory.MemoryMB
@@ -683,12 +931,12 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	}
 
 	if memHard > 0 {
-		cfg.Cgroups.Resources.Memory = memHard * 1024 * 1024
-		cfg.Cgroups.Resources.MemoryReservation = memSoft * 1024 * 1024
+		cgroupRes.Memory = memHard * 1024 * 1024
+		cgroupRes.MemoryReservation = memSoft * 1024 * 1024
 
 		// Disable swap to avoid issues on the machine
 		var memSwappiness uint64
-		cfg.Cgroups.Resources.MemorySwappiness = &memSwappiness
+		cgroupRes.MemorySwappiness = &memSwappiness
 	}
 
 	cpuShares := res.Cpu.CpuShares
@@ -697,16 +945,7 @@ func configureCgroups(cfg *lconfigs.Config, command *ExecCommand) error {
 	}
 
 	// Set the relative CPU shares for this cgroup.
-	cfg.Cgroups.Resources.CpuShares = uint64(cpuShares)
-
-	if command.Resources.LinuxResources != nil && command.Resources.LinuxResources.CpusetCgroupPath != "" {
-		cfg.Hooks = lconfigs.Hooks{
-			lconfigs.CreateRuntime: lconfigs.HookList{
-				newSetCPUSetCgroupHook(command.Resources.LinuxResources.CpusetCgroupPath),
-			},
-		}
-	}
-
+	cgroupRes.CpuShares = uint64(cpuShares)
 	return nil
 }
 
@@ -387,6 +387,41 @@ func TestExecutor_CgroupPathsAreDestroyed(t *testing.T) {
 	}
 }
 
+func TestExecutor_OOMKilled(t *testing.T) {
+	t.Parallel()
+	r := require.New(t)
+	testutil.ExecCompatible(t)
+
+	testExecCmd := testExecutorCommandWithChroot(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	defer allocDir.Destroy()
+
+	// grow a string until the memory limit is exceeded
+	execCmd.Cmd = "/bin/bash"
+	execCmd.Args = []string{"-c", "a=x; while true; do a=$a$a; done"}
+	execCmd.ResourceLimits = true
+	execCmd.Resources.NomadResources.Memory.MemoryMB = 32
+
+	executor := NewExecutorWithIsolation(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	r.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ps, err := executor.Wait(ctx)
+	r.NoError(err)
+
+	r.Equal(int(unix.SIGKILL), ps.Signal)
+	r.True(ps.OOMKilled)
+	r.Equal(uint64(32*1024*1024), ps.MemoryLimit)
+	if !cgroups.IsCgroup2UnifiedMode() {
+		r.NotZero(ps.MemoryPeak)
+		r.LessOrEqual(ps.MemoryPeak, ps.MemoryLimit)
+	}
+}
+
 func TestUniversalExecutor_LookupTaskBin(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -713,6 +748,57 @@ func TestExecutor_cmdMounts(t *testing.T) {
 	require.EqualValues(t, expected, cmdMounts(input))
 }
 
+func TestExecutor_configureUserNS(t *testing.T) {
+	command := &ExecCommand{
+		TaskDir: "/tmp/task",
+		ModePID: IsolationModePrivate,
+		ModeIPC: IsolationModeHost,
+		UserNS:  &UserNSMapping{HostID: 100000, Size: 65536},
+	}
+
+	cfg := &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+
+	require.True(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+	expected := []lconfigs.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}
+	require.Equal(t, expected, cfg.UidMappings)
+	require.Equal(t, expected, cfg.GidMappings)
+
+	// sysfs and mqueue of the host namespaces are bind mounted
+	devices := map[string]string{}
+	for _, m := range cfg.Mounts {
+		devices[m.Destination] = m.Device
+	}
+	require.Equal(t, "bind", devices["/sys"])
+	require.Equal(t, "bind", devices["/dev/mqueue"])
+
+	// with private network and IPC namespaces they can be mounted
+	command.ModeIPC = IsolationModePrivate
+	command.NetworkIsolation = &drivers.NetworkIsolationSpec{Path: "/var/run/netns/test"}
+	cfg = &lconfigs.Config{}
+	require.NoError(t, configureIsolation(cfg, command))
+	for _, m := range cfg.Mounts {
+		devices[m.Destination] = m.Device
+	}
+	require.Equal(t, "sysfs", devices["/sys"])
+	require.Equal(t, "mqueue", devices["/dev/mqueue"])
+}
+
+func TestUserNSMapping_ToHost(t *testing.T) {
+	m := &UserNSMapping{HostID: 100000, Size: 65536}
+
+	id, err := m.ToHost(0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), id)
+
+	id, err = m.ToHost(1000)
+	require.NoError(t, err)
+	require.Equal(t, uint32(101000), id)
+
+	_, err = m.ToHost(65536)
+	require.Error(t, err)
+}
+
 // TestUniversalExecutor_NoCgroup asserts that commands are executed in the
 // same cgroup as parent process
 func TestUniversalExecutor_NoCgroup(t *testing.T) {
@@ -755,3 +841,45 @@ func TestUniversalExecutor_NoCgroup(t *testing.T) {
 	})
 
 }
+
+// TestUniversalExecutor_ResourceLimits asserts that commands are executed in a
+// cgroup enforcing the task's resources when limits are requested
+func TestUniversalExecutor_ResourceLimits(t *testing.T) {
+	t.Parallel()
+	testutil.ExecCompatible(t)
+	if cgroups.IsCgroup2UnifiedMode() {
+		t.Skip("universal executor only supports cgroups v1")
+	}
+
+	testExecCmd := testExecutorCommand(t)
+	execCmd, allocDir := testExecCmd.command, testExecCmd.allocDir
+	execCmd.Cmd = "/bin/sh"
+	execCmd.Args = []string{"-c", "cat /sys/fs/cgroup/memory/$(grep memory /proc/self/cgroup | cut -d: -f3)/memory.limit_in_bytes"}
+	defer allocDir.Destroy()
+
+	execCmd.ResourceLimits = true
+
+	executor := NewExecutor(testlog.HCLogger(t))
+	defer executor.Shutdown("SIGKILL", 0)
+
+	_, err := executor.Launch(execCmd)
+	require.NoError(t, err)
+
+	_, err = executor.Wait(context.Background())
+	require.NoError(t, err)
+
+	expected := strconv.Itoa(256 * 1024 * 1024)
+	tu.WaitForResult(func() (bool, error) {
+		act := strings.TrimSpace(testExecCmd.stdout.String())
+		if expected != act {
+			return false, fmt.Errorf("expected %q, got %q", expected, act)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Logf("stderr: %v", strings.TrimSpace(testExecCmd.stderr.String()))
+		require.NoError(t, err)
+	})
+
+	// The task's cgroups are destroyed once the executor is shut down
+	require.NoError(t, executor.Shutdown("", 0))
+}
@@ -77,6 +77,10 @@ func (e *UniversalExecutor) configureResourceContainer(pid int) error {
 		cfg.Cgroups.Resources.Devices = append(cfg.Cgroups.Resources.Devices, &device.Rule)
 	}
 
+	if e.commandCfg.ResourceLimits {
+		return e.configureResourceLimits(cfg, pid)
+	}
+
 	err := configureBasicCgroups(cfg)
 	if err != nil {
 		// Log this error to help diagnose cases where nomad is run with too few
@@ -91,6 +95,27 @@ func (e *UniversalExecutor) configureResourceContainer(pid int) error {
 	return cgroups.EnterPid(cfg.Cgroups.Paths, pid)
 }
 
+// configureResourceLimits moves pid into a cgroup enforcing the task's
+// resources, as the libcontainer executor does for its container
+func (e *UniversalExecutor) configureResourceLimits(cfg *lconfigs.Config, pid int) error {
+	if err := configureCgroups(cfg, e.commandCfg); err != nil {
+		return fmt.Errorf("failed to configure cgroups: %v", err)
+	}
+
+	manager := cgroupFs.NewManager(cfg.Cgroups, nil, false)
+	if err := manager.Apply(pid); err != nil {
+		return fmt.Errorf("failed to create cgroup: %v", err)
+	}
+	if err := manager.Set(cfg); err != nil {
+		manager.Destroy()
+		return fmt.Errorf("failed to set cgroup resources: %v", err)
+	}
+
+	cfg.Cgroups.Paths = manager.GetPaths()
+	e.resConCtx.groups = cfg.Cgroups
+	return nil
+}
+
 func (e *UniversalExecutor) getAllPids() (map[int]*nomadPid, error) {
 	if e.resConCtx.isEmpty() {
 		return getAllPidsByScanning()
@@ -107,14 +132,18 @@ func DestroyCgroup(groups *lconfigs.Cgroup, executorPid int) error {
 		return fmt.Errorf("Can't destroy: cgroup configuration empty")
 	}
 
-	// Move the executor into the global cgroup so that the task specific
-	// cgroup can be destroyed.
-	path, err := cgroups.GetInitCgroupPath("freezer")
-	if err != nil {
-		return err
+	// Move the executor into the global cgroups so that the task specific
+	// cgroups can be destroyed.
+	initPaths := make(map[string]string, len(groups.Paths))
+	for subsystem := range groups.Paths {
+		path, err := cgroups.GetInitCgroupPath(subsystem)
+		if err != nil {
+			return err
+		}
+		initPaths[subsystem] = path
 	}
 
-	if err := cgroups.EnterPid(map[string]string{"freezer": path}, executorPid); err != nil {
+	if err := cgroups.EnterPid(initPaths, executorPid); err != nil {
 		return err
 	}
 
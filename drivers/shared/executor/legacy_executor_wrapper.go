@@ -80,6 +80,10 @@ func (l *legacyExecutorWrapper) UpdateResources(*drivers.Resources) error {
 	return fmt.Errorf("operation not supported for legacy exec wrapper")
 }
 
+func (l *legacyExecutorWrapper) Checkpoint(string) error {
+	return ErrCheckpointNotSupported
+}
+
 func (l *legacyExecutorWrapper) Version() (*ExecutorVersion, error) {
 	v, err := l.client.Version()
 	if err != nil {
@@ -46,6 +46,9 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	RestoreFrom          string                       `protobuf:"bytes,20,opt,name=restore_from,json=restoreFrom,proto3" json:"restore_from,omitempty"`
+	UsernsHostId         uint32                       `protobuf:"varint,21,opt,name=userns_host_id,json=usernsHostId,proto3" json:"userns_host_id,omitempty"`
+	UsernsSize           uint32                       `protobuf:"varint,22,opt,name=userns_size,json=usernsSize,proto3" json:"userns_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +212,27 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetRestoreFrom() string {
+	if m != nil {
+		return m.RestoreFrom
+	}
+	return ""
+}
+
+func (m *LaunchRequest) GetUsernsHostId() uint32 {
+	if m != nil {
+		return m.UsernsHostId
+	}
+	return 0
+}
+
+func (m *LaunchRequest) GetUsernsSize() uint32 {
+	if m != nil {
+		return m.UsernsSize
+	}
+	return 0
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -791,6 +815,9 @@ type ProcessState struct {
 	ExitCode             int32                `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
 	Signal               int32                `protobuf:"varint,3,opt,name=signal,proto3" json:"signal,omitempty"`
 	Time                 *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	OomKilled            bool                 `protobuf:"varint,5,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	MemoryPeak           uint64               `protobuf:"varint,6,opt,name=memory_peak,json=memoryPeak,proto3" json:"memory_peak,omitempty"`
+	MemoryLimit          uint64               `protobuf:"varint,7,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -849,6 +876,97 @@ func (m *ProcessState) GetTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *ProcessState) GetOomKilled() bool {
+	if m != nil {
+		return m.OomKilled
+	}
+	return false
+}
+
+func (m *ProcessState) GetMemoryPeak() uint64 {
+	if m != nil {
+		return m.MemoryPeak
+	}
+	return 0
+}
+
+func (m *ProcessState) GetMemoryLimit() uint64 {
+	if m != nil {
+		return m.MemoryLimit
+	}
+	return 0
+}
+
+type CheckpointRequest struct {
+	ImagesDir            string   `protobuf:"bytes,1,opt,name=images_dir,json=imagesDir,proto3" json:"images_dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetImagesDir() string {
+	if m != nil {
+		return m.ImagesDir
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{18}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
 	proto.RegisterType((*LaunchResponse)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchResponse")
@@ -867,6 +985,8 @@ func init() {
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
 }
 
 func init() {
@@ -874,74 +994,84 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	// 1226 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x5d, 0x6f, 0x1b, 0x45,
+	0x17, 0x7e, 0x37, 0x76, 0xfc, 0x71, 0x6c, 0x27, 0xee, 0xbc, 0x25, 0x6c, 0x8d, 0x50, 0xcd, 0x82,
+	0xa8, 0x05, 0xc5, 0x89, 0xd2, 0x2f, 0x3e, 0x24, 0x8a, 0x48, 0x5a, 0xa8, 0x68, 0xab, 0x68, 0x53,
+	0xa8, 0xc4, 0x05, 0xcb, 0x74, 0x77, 0x6a, 0x8f, 0xbc, 0xbb, 0xb3, 0xcc, 0xcc, 0xa6, 0x69, 0x85,
+	0xc4, 0x15, 0xff, 0x80, 0x0b, 0x6e, 0xb8, 0xe5, 0xb7, 0xf1, 0x33, 0xd0, 0x7c, 0x6d, 0xec, 0xb6,
+	0xc0, 0xba, 0x88, 0xab, 0xec, 0x3c, 0x7e, 0x9e, 0x73, 0xce, 0xcc, 0x9c, 0x79, 0x4e, 0xe0, 0x72,
+	0xc2, 0xe9, 0x09, 0xe1, 0x62, 0x57, 0xcc, 0x31, 0x27, 0xc9, 0x2e, 0x39, 0x25, 0x71, 0x29, 0x19,
+	0xdf, 0x2d, 0x38, 0x93, 0xac, 0x5a, 0x4e, 0xf5, 0x12, 0xbd, 0x3b, 0xc7, 0x62, 0x4e, 0x63, 0xc6,
+	0x8b, 0x69, 0xce, 0x32, 0x9c, 0x4c, 0x8b, 0xb4, 0x9c, 0xd1, 0x5c, 0x4c, 0x57, 0x79, 0xa3, 0x8b,
+	0x33, 0xc6, 0x66, 0x29, 0x31, 0x41, 0x1e, 0x95, 0x8f, 0x77, 0x25, 0xcd, 0x88, 0x90, 0x38, 0x2b,
+	0x2c, 0x21, 0xb0, 0xc2, 0x5d, 0x97, 0xde, 0xa4, 0x33, 0x2b, 0xc3, 0x09, 0x7e, 0x6f, 0xc3, 0xe0,
+	0x2e, 0x2e, 0xf3, 0x78, 0x1e, 0x92, 0x1f, 0x4a, 0x22, 0x24, 0x1a, 0x42, 0x23, 0xce, 0x12, 0xdf,
+	0x1b, 0x7b, 0x93, 0x6e, 0xa8, 0x3e, 0x11, 0x82, 0x26, 0xe6, 0x33, 0xe1, 0x6f, 0x8c, 0x1b, 0x93,
+	0x6e, 0xa8, 0xbf, 0xd1, 0x7d, 0xe8, 0x72, 0x22, 0x58, 0xc9, 0x63, 0x22, 0xfc, 0xc6, 0xd8, 0x9b,
+	0xf4, 0xf6, 0xf7, 0xa6, 0x7f, 0x55, 0xb8, 0xcd, 0x6f, 0x52, 0x4e, 0x43, 0xa7, 0x0b, 0xcf, 0x42,
+	0xa0, 0x8b, 0xd0, 0x13, 0x32, 0x61, 0xa5, 0x8c, 0x0a, 0x2c, 0xe7, 0x7e, 0x53, 0x67, 0x07, 0x03,
+	0x1d, 0x61, 0x39, 0xb7, 0x04, 0xc2, 0xb9, 0x21, 0x6c, 0x56, 0x04, 0xc2, 0xb9, 0x26, 0x0c, 0xa1,
+	0x41, 0xf2, 0x13, 0xbf, 0xa5, 0x8b, 0x54, 0x9f, 0xaa, 0xee, 0x52, 0x10, 0xee, 0xb7, 0x35, 0x57,
+	0x7f, 0xa3, 0x0b, 0xd0, 0x91, 0x58, 0x2c, 0xa2, 0x84, 0x72, 0xbf, 0xa3, 0xf1, 0xb6, 0x5a, 0x1f,
+	0x52, 0x8e, 0x2e, 0xc1, 0xb6, 0xab, 0x27, 0x4a, 0x69, 0x46, 0xa5, 0xf0, 0xbb, 0x63, 0x6f, 0xd2,
+	0x09, 0xb7, 0x1c, 0x7c, 0x57, 0xa3, 0x68, 0x0f, 0xce, 0x3f, 0xc2, 0x82, 0xc6, 0x51, 0xc1, 0x59,
+	0x4c, 0x84, 0x88, 0xe2, 0x19, 0x67, 0x65, 0xe1, 0x83, 0x66, 0x23, 0xfd, 0xdb, 0x91, 0xf9, 0xe9,
+	0x40, 0xff, 0x82, 0x0e, 0xa1, 0x95, 0xb1, 0x32, 0x97, 0xc2, 0xef, 0x8d, 0x1b, 0x93, 0xde, 0xfe,
+	0xe5, 0x9a, 0x47, 0x75, 0x4f, 0x89, 0x42, 0xab, 0x45, 0x5f, 0x40, 0x3b, 0x21, 0x27, 0x54, 0x9d,
+	0x78, 0x5f, 0x87, 0xf9, 0xa0, 0x66, 0x98, 0x43, 0xad, 0x0a, 0x9d, 0x1a, 0xcd, 0xe1, 0x5c, 0x4e,
+	0xe4, 0x13, 0xc6, 0x17, 0x11, 0x15, 0x2c, 0xc5, 0x92, 0xb2, 0xdc, 0x1f, 0xe8, 0x4b, 0xfc, 0xa4,
+	0x66, 0xc8, 0xfb, 0x46, 0x7f, 0xc7, 0xc9, 0x8f, 0x0b, 0x12, 0x87, 0xc3, 0xfc, 0x39, 0x14, 0x05,
+	0x30, 0xc8, 0x59, 0x54, 0xd0, 0x13, 0x26, 0x23, 0xce, 0x98, 0xf4, 0xb7, 0xf4, 0x19, 0xf5, 0x72,
+	0x76, 0xa4, 0xb0, 0x90, 0x31, 0x89, 0x26, 0x30, 0x4c, 0xc8, 0x63, 0x5c, 0xa6, 0x32, 0x2a, 0x68,
+	0x12, 0x65, 0x2c, 0x21, 0xfe, 0xb6, 0xbe, 0x9a, 0x2d, 0x8b, 0x1f, 0xd1, 0xe4, 0x1e, 0x4b, 0xc8,
+	0x32, 0x93, 0x16, 0xb1, 0x61, 0x0e, 0x57, 0x98, 0x77, 0x8a, 0x58, 0x33, 0xdf, 0x86, 0x41, 0x5c,
+	0x94, 0x82, 0x48, 0x77, 0x37, 0xe7, 0x34, 0xad, 0x6f, 0x40, 0x7b, 0x2b, 0x6f, 0x02, 0xe0, 0x34,
+	0x65, 0x4f, 0xa2, 0x18, 0x17, 0xc2, 0x47, 0xba, 0x71, 0xba, 0x1a, 0x39, 0xc0, 0x85, 0x40, 0x01,
+	0xf4, 0x63, 0x5c, 0xe0, 0x47, 0x34, 0xa5, 0x92, 0x12, 0xe1, 0xff, 0x5f, 0x13, 0x56, 0x30, 0xf4,
+	0x16, 0xf4, 0x39, 0x11, 0x92, 0x71, 0x12, 0x3d, 0xe6, 0x2c, 0xf3, 0xcf, 0xeb, 0x34, 0x3d, 0x8b,
+	0xdd, 0xe6, 0x2c, 0x43, 0xef, 0xc0, 0x96, 0xea, 0xbc, 0x5c, 0x44, 0x73, 0x26, 0x64, 0x44, 0x13,
+	0xff, 0xb5, 0xb1, 0x37, 0x19, 0x84, 0x7d, 0x83, 0x7e, 0xc9, 0x84, 0xbc, 0x93, 0xa8, 0xf6, 0xb6,
+	0x2c, 0x41, 0x9f, 0x11, 0x7f, 0x47, 0x53, 0xc0, 0x40, 0xc7, 0xf4, 0x19, 0x09, 0xbe, 0x87, 0x2d,
+	0xf7, 0x4e, 0x45, 0xc1, 0x72, 0x41, 0xd0, 0x7d, 0x68, 0xdb, 0x06, 0xd4, 0x8f, 0xb5, 0xb7, 0x7f,
+	0x75, 0x5a, 0xcf, 0x39, 0xa6, 0xb6, 0x39, 0x8f, 0x25, 0x96, 0x24, 0x74, 0x41, 0x82, 0x01, 0xf4,
+	0x1e, 0x62, 0x2a, 0xad, 0x0f, 0x04, 0xdf, 0x41, 0xdf, 0x2c, 0xff, 0xa3, 0x74, 0x77, 0x61, 0xfb,
+	0x78, 0x5e, 0xca, 0x84, 0x3d, 0xc9, 0x9d, 0xf5, 0xec, 0x40, 0x4b, 0xd0, 0x59, 0x8e, 0x53, 0xeb,
+	0x3e, 0x76, 0xa5, 0x4e, 0x79, 0xc6, 0x71, 0x4c, 0xa2, 0x82, 0x70, 0xca, 0x12, 0x7f, 0x63, 0xec,
+	0x4d, 0x1a, 0x61, 0x4f, 0x63, 0x47, 0x1a, 0x0a, 0x10, 0x0c, 0xcf, 0xa2, 0x99, 0x8a, 0x83, 0x39,
+	0xec, 0x7c, 0x5d, 0x24, 0x2a, 0x69, 0xe5, 0x38, 0x36, 0xd1, 0x8a, 0x7b, 0x79, 0xff, 0xda, 0xbd,
+	0x82, 0x0b, 0xf0, 0xfa, 0x0b, 0x99, 0x6c, 0x11, 0x43, 0xd8, 0xfa, 0x86, 0x70, 0x41, 0x99, 0xdb,
+	0x65, 0xf0, 0x3e, 0x6c, 0x57, 0x88, 0x3d, 0x5b, 0x1f, 0xda, 0x27, 0x06, 0xb2, 0x3b, 0x77, 0xcb,
+	0xe0, 0x3d, 0xe8, 0xab, 0x73, 0xab, 0x2a, 0x1f, 0x41, 0x87, 0xe6, 0x92, 0xf0, 0x13, 0x7b, 0x48,
+	0x8d, 0xb0, 0x5a, 0x07, 0x0f, 0x61, 0x60, 0xb9, 0x36, 0xec, 0x6d, 0xd8, 0x14, 0x0a, 0x58, 0x73,
+	0x8b, 0x0f, 0xb0, 0x58, 0x98, 0x40, 0x46, 0x1e, 0x5c, 0x82, 0xc1, 0xb1, 0xbe, 0x89, 0x97, 0x5f,
+	0xd4, 0xa6, 0xbb, 0x28, 0xb5, 0x59, 0x47, 0xb4, 0xdb, 0x5f, 0x40, 0xef, 0xd6, 0x29, 0x89, 0x9d,
+	0xf0, 0x3a, 0x74, 0x12, 0x82, 0x93, 0x94, 0xe6, 0xc4, 0x16, 0x35, 0x9a, 0x9a, 0x31, 0x36, 0x75,
+	0x63, 0x6c, 0xfa, 0xc0, 0x8d, 0xb1, 0xb0, 0xe2, 0xba, 0xa1, 0xb4, 0xf1, 0xe2, 0x50, 0x6a, 0x9c,
+	0x0d, 0xa5, 0xe0, 0x00, 0xfa, 0x26, 0x99, 0xdd, 0xff, 0x0e, 0xb4, 0x58, 0x29, 0x8b, 0x52, 0xea,
+	0x5c, 0xfd, 0xd0, 0xae, 0xd0, 0x1b, 0xd0, 0x25, 0xa7, 0x54, 0x46, 0xb1, 0x32, 0x90, 0x0d, 0xbd,
+	0x83, 0x8e, 0x02, 0x0e, 0x58, 0x42, 0x82, 0x3f, 0x3c, 0xe8, 0x2f, 0x77, 0xac, 0xca, 0x5d, 0xd0,
+	0xc4, 0xee, 0x54, 0x7d, 0xfe, 0xad, 0x7e, 0xe9, 0x6c, 0x1a, 0xcb, 0x67, 0x83, 0xa6, 0xd0, 0x54,
+	0x03, 0xda, 0x6f, 0xfe, 0xe3, 0xb6, 0x35, 0x4f, 0xb9, 0x13, 0x63, 0x59, 0xb4, 0xa0, 0x69, 0x4a,
+	0x12, 0x3d, 0xef, 0x3a, 0x61, 0x97, 0xb1, 0xec, 0x2b, 0x0d, 0x28, 0xc3, 0xc8, 0x48, 0xc6, 0xf8,
+	0xd3, 0xa8, 0x20, 0x78, 0xe1, 0xb7, 0xc6, 0xde, 0xa4, 0x19, 0x82, 0x81, 0x8e, 0x08, 0x5e, 0xa8,
+	0x47, 0x63, 0x09, 0x7a, 0x98, 0xe9, 0x29, 0xd8, 0x0c, 0xad, 0x48, 0x4f, 0xb2, 0x60, 0x1f, 0xce,
+	0x1d, 0xcc, 0x49, 0xbc, 0x28, 0x18, 0xcd, 0xdd, 0xbb, 0x57, 0x79, 0x69, 0x86, 0x67, 0x44, 0xe8,
+	0x19, 0x69, 0xda, 0xb1, 0x6b, 0x90, 0x43, 0xca, 0x83, 0xf3, 0x80, 0x96, 0x35, 0xe6, 0xa4, 0xf7,
+	0x7f, 0x03, 0xe8, 0xdc, 0xb2, 0xaf, 0x1e, 0x3d, 0x85, 0x96, 0xb1, 0x2a, 0x74, 0xad, 0xae, 0x45,
+	0xac, 0xfc, 0x0b, 0x32, 0xba, 0xbe, 0xae, 0xcc, 0x36, 0xdb, 0xff, 0x90, 0x80, 0xa6, 0x32, 0x2d,
+	0x74, 0xa5, 0x6e, 0x84, 0x25, 0xc7, 0x1b, 0x5d, 0x5d, 0x4f, 0x54, 0x25, 0xfd, 0x09, 0x3a, 0xce,
+	0x7b, 0xd0, 0x8d, 0xba, 0x31, 0x9e, 0xf3, 0xbe, 0xd1, 0x87, 0xeb, 0x0b, 0xab, 0x02, 0x7e, 0xf1,
+	0x60, 0xfb, 0x39, 0xff, 0x41, 0x9f, 0xd6, 0x8d, 0xf7, 0x72, 0x8b, 0x1c, 0xdd, 0x7c, 0x65, 0x7d,
+	0x55, 0xd6, 0x8f, 0xd0, 0xb6, 0x46, 0x87, 0x6a, 0xdf, 0xe8, 0xaa, 0x57, 0x8e, 0x6e, 0xac, 0xad,
+	0xab, 0xb2, 0x9f, 0xc2, 0xa6, 0x36, 0x31, 0x54, 0xfb, 0x5a, 0x97, 0x8d, 0x76, 0x74, 0x6d, 0x4d,
+	0x95, 0xcb, 0xbb, 0xe7, 0xa9, 0xfe, 0x37, 0x2e, 0x58, 0xbf, 0xff, 0x57, 0xec, 0x75, 0x74, 0x7d,
+	0x5d, 0xd9, 0x72, 0xff, 0xab, 0x67, 0x58, 0xbf, 0xff, 0x97, 0xcc, 0x79, 0x74, 0x75, 0x3d, 0x51,
+	0x95, 0xf4, 0x57, 0x0f, 0x06, 0x0a, 0x3a, 0x96, 0x9c, 0xe0, 0x8c, 0xe6, 0x33, 0x74, 0xb3, 0xe6,
+	0xa4, 0x51, 0x2a, 0x33, 0x6d, 0xac, 0xd2, 0x95, 0xf2, 0xd9, 0xab, 0x07, 0x70, 0x65, 0x4d, 0xbc,
+	0x3d, 0x0f, 0xfd, 0xec, 0x01, 0x9c, 0xd9, 0x15, 0xfa, 0xa8, 0xee, 0x0e, 0x5f, 0xb0, 0xc5, 0xd1,
+	0xc7, 0xaf, 0x22, 0x75, 0xb5, 0x7c, 0xde, 0xfe, 0x76, 0xd3, 0x18, 0x7d, 0x4b, 0xff, 0xb9, 0xf2,
+	0xe7, 0x00, 0x04, 0x2e, 0xa0, 0xd5, 0x13, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -966,6 +1096,7 @@ type ExecutorClient interface {
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
 }
 
 type executorClient struct {
@@ -1102,6 +1233,15 @@ func (x *executorExecStreamingClient) Recv() (*proto1.ExecTaskStreamingResponse,
 	return m, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExecutorServer is the server API for Executor service.
 type ExecutorServer interface {
 	Launch(context.Context, *LaunchRequest) (*LaunchResponse, error)
@@ -1114,6 +1254,7 @@ type ExecutorServer interface {
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
 }
 
 // UnimplementedExecutorServer can be embedded to have forward compatible implementations.
@@ -1147,6 +1288,9 @@ func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest)
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
 
 func RegisterExecutorServer(s *grpc.Server, srv ExecutorServer) {
 	s.RegisterService(&_Executor_serviceDesc, srv)
@@ -1325,6 +1469,24 @@ func (x *executorExecStreamingServer) Recv() (*proto1.ExecTaskStreamingRequest,
 	return m, nil
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Executor_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.executor.proto.Executor",
 	HandlerType: (*ExecutorServer)(nil),
@@ -1357,6 +1519,10 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
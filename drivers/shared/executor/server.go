@@ -38,6 +38,8 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
+		RestoreFrom:        req.RestoreFrom,
+		UserNS:             userNSMappingFromProto(req),
 	})
 
 	if err != nil {
@@ -86,6 +88,14 @@ func (s *grpcExecutorServer) UpdateResources(ctx context.Context, req *proto.Upd
 	return &proto.UpdateResourcesResponse{}, nil
 }
 
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.ImagesDir); err != nil {
+		return nil, err
+	}
+
+	return &proto.CheckpointResponse{}, nil
+}
+
 func (s *grpcExecutorServer) Version(context.Context, *proto.VersionRequest) (*proto.VersionResponse, error) {
 	v, err := s.impl.Version()
 	if err != nil {
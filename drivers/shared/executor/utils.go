@@ -117,10 +117,13 @@ func processStateToProto(ps *ProcessState) (*proto.ProcessState, error) {
 		return nil, err
 	}
 	pb := &proto.ProcessState{
-		Pid:      int32(ps.Pid),
-		ExitCode: int32(ps.ExitCode),
-		Signal:   int32(ps.Signal),
-		Time:     timestamp,
+		Pid:         int32(ps.Pid),
+		ExitCode:    int32(ps.ExitCode),
+		Signal:      int32(ps.Signal),
+		Time:        timestamp,
+		OomKilled:   ps.OOMKilled,
+		MemoryPeak:  ps.MemoryPeak,
+		MemoryLimit: ps.MemoryLimit,
 	}
 
 	return pb, nil
@@ -133,13 +136,27 @@ func processStateFromProto(pb *proto.ProcessState) (*ProcessState, error) {
 	}
 
 	return &ProcessState{
-		Pid:      int(pb.Pid),
-		ExitCode: int(pb.ExitCode),
-		Signal:   int(pb.Signal),
-		Time:     timestamp,
+		Pid:         int(pb.Pid),
+		ExitCode:    int(pb.ExitCode),
+		Signal:      int(pb.Signal),
+		Time:        timestamp,
+		OOMKilled:   pb.OomKilled,
+		MemoryPeak:  pb.MemoryPeak,
+		MemoryLimit: pb.MemoryLimit,
 	}, nil
 }
 
+func userNSMappingFromProto(req *proto.LaunchRequest) *UserNSMapping {
+	if req.UsernsSize == 0 {
+		return nil
+	}
+
+	return &UserNSMapping{
+		HostID: req.UsernsHostId,
+		Size:   req.UsernsSize,
+	}
+}
+
 // IsolationMode returns the namespace isolation mode as determined from agent
 // plugin configuration and task driver configuration. The task configuration
 // takes precedence, if it is configured.
@@ -74,23 +74,27 @@ func FSMState(p string, plastIdx int64) (interface{}, error) {
 
 	state := fsm.State()
 	result := map[string][]interface{}{
-		"ACLPolicies":      toArray(state.ACLPolicies(nil)),
-		"ACLTokens":        toArray(state.ACLTokens(nil)),
-		"Allocs":           toArray(state.Allocs(nil)),
-		"CSIPlugins":       toArray(state.CSIPlugins(nil)),
-		"CSIVolumes":       toArray(state.CSIVolumes(nil)),
-		"Deployments":      toArray(state.Deployments(nil)),
-		"Evals":            toArray(state.Evals(nil)),
-		"Indexes":          toArray(state.Indexes()),
-		"JobSummaries":     toArray(state.JobSummaries(nil)),
-		"JobVersions":      toArray(state.JobVersions(nil)),
-		"Jobs":             toArray(state.Jobs(nil)),
-		"Nodes":            toArray(state.Nodes(nil)),
-		"PeriodicLaunches": toArray(state.PeriodicLaunches(nil)),
-		"SITokenAccessors": toArray(state.SITokenAccessors(nil)),
-		"ScalingEvents":    toArray(state.ScalingEvents(nil)),
-		"ScalingPolicies":  toArray(state.ScalingPolicies(nil)),
-		"VaultAccessors":   toArray(state.VaultAccessors(nil)),
+		"ACLPolicies":            toArray(state.ACLPolicies(nil)),
+		"ACLTokens":              toArray(state.ACLTokens(nil)),
+		"Allocs":                 toArray(state.Allocs(nil)),
+		"CSIPlugins":             toArray(state.CSIPlugins(nil)),
+		"CSIVolumes":             toArray(state.CSIVolumes(nil)),
+		"Deployments":            toArray(state.Deployments(nil)),
+		"DrainBatches":           toArray(state.DrainBatches(nil)),
+		"Evals":                  toArray(state.Evals(nil)),
+		"HostVolumes":            toArray(state.HostVolumes(nil)),
+		"Indexes":                toArray(state.Indexes()),
+		"JobSummaries":           toArray(state.JobSummaries(nil)),
+		"JobVersions":            toArray(state.JobVersions(nil)),
+		"Jobs":                   toArray(state.Jobs(nil)),
+		"NodeMaintenanceWindows": toArray(state.NodeMaintenanceWindows(nil)),
+		"Nodes":                  toArray(state.Nodes(nil)),
+		"PeriodicLaunches":       toArray(state.PeriodicLaunches(nil)),
+		"PreemptionHistories":    toArray(state.PreemptionHistories(nil)),
+		"SITokenAccessors":       toArray(state.SITokenAccessors(nil)),
+		"ScalingEvents":          toArray(state.ScalingEvents(nil)),
+		"ScalingPolicies":        toArray(state.ScalingPolicies(nil)),
+		"VaultAccessors":         toArray(state.VaultAccessors(nil)),
 	}
 
 	insertEnterpriseState(result, state)
@@ -51,6 +51,12 @@ var msgTypeNames = map[structs.MessageType]string{
 	structs.OneTimeTokenUpsertRequestType:                "OneTimeTokenUpsertRequestType",
 	structs.OneTimeTokenDeleteRequestType:                "OneTimeTokenDeleteRequestType",
 	structs.OneTimeTokenExpireRequestType:                "OneTimeTokenExpireRequestType",
+	structs.HostVolumeUpsertRequestType:                  "HostVolumeUpsertRequestType",
+	structs.HostVolumeDeleteRequestType:                  "HostVolumeDeleteRequestType",
+	structs.DeploymentCanaryStepRequestType:              "DeploymentCanaryStepRequestType",
+	structs.DrainBatchUpsertRequestType:                  "DrainBatchUpsertRequestType",
+	structs.NodeMaintenanceWindowUpsertRequestType:       "NodeMaintenanceWindowUpsertRequestType",
+	structs.CSIVolumeExpandRequestType:                   "CSIVolumeExpandRequestType",
 	structs.NamespaceUpsertRequestType:                   "NamespaceUpsertRequestType",
 	structs.NamespaceDeleteRequestType:                   "NamespaceDeleteRequestType",
 }
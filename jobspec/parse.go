@@ -303,6 +303,7 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 		// Check for invalid keys
 		valid := []string{
 			"attribute",
+			"hierarchy",
 			"weight",
 			"target",
 		}
@@ -431,11 +432,17 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 		"auto_revert",
 		"auto_promote",
 		"canary",
+		"canary_step",
+		"analysis",
+		"strategy",
+		"retain_previous",
 	}
 	if err := checkHCLKeys(o.Val, valid); err != nil {
 		return err
 	}
 
+	delete(m, "analysis")
+
 	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
 		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
 		WeaklyTypedInput: true,
@@ -444,7 +451,65 @@ func parseUpdate(result **api.UpdateStrategy, list *ast.ObjectList) error {
 	if err != nil {
 		return err
 	}
-	return dec.Decode(m)
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	// Filter analysis
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("update: should be an object")
+	}
+	if ao := listVal.Filter("analysis"); len(ao.Items) > 0 {
+		if len(ao.Items) > 1 {
+			return fmt.Errorf("only one 'analysis' block allowed")
+		}
+		analysis, err := parseAnalysis(ao.Items[0])
+		if err != nil {
+			return err
+		}
+		if *result == nil {
+			*result = new(api.UpdateStrategy)
+		}
+		(*result).Analysis = analysis
+	}
+
+	return nil
+}
+
+func parseAnalysis(ao *ast.ObjectItem) (*api.DeploymentAnalysis, error) {
+	valid := []string{
+		"address",
+		"query",
+		"operator",
+		"threshold",
+		"interval",
+	}
+	if err := checkHCLKeys(ao.Val, valid); err != nil {
+		return nil, multierror.Prefix(err, "analysis ->")
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, ao.Val); err != nil {
+		return nil, err
+	}
+
+	var analysis api.DeploymentAnalysis
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &analysis,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := dec.Decode(m); err != nil {
+		return nil, err
+	}
+
+	return &analysis, nil
 }
 
 func parseMigrate(result **api.MigrateStrategy, list *ast.ObjectList) error {
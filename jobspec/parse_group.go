@@ -56,6 +56,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"volume",
 			"scaling",
 			"stop_after_client_disconnect",
+			"placement",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -242,6 +243,7 @@ func parseEphemeralDisk(result **api.EphemeralDisk, list *ast.ObjectList) error
 		"sticky",
 		"size",
 		"migrate",
+		"checkpoint",
 	}
 	if err := checkHCLKeys(obj.Val, valid); err != nil {
 		return err
@@ -38,6 +38,8 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 	delete(m, "vault")
 	delete(m, "spread")
 	delete(m, "multiregion")
+	delete(m, "scoring")
+	delete(m, "preemption")
 
 	// Set the ID and name to the object key
 	result.ID = stringToPtr(obj.Keys[0].Token.Value().(string))
@@ -81,6 +83,8 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"vault_token",
 		"consul_token",
 		"multiregion",
+		"scoring",
+		"preemption",
 	}
 	if err := checkHCLKeys(listVal, valid); err != nil {
 		return multierror.Prefix(err, "job:")
@@ -121,6 +125,20 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		}
 	}
 
+	// If we have a scoring hint, then parse that
+	if o := listVal.Filter("scoring"); len(o.Items) > 0 {
+		if err := parseScoring(&result.Scoring, o); err != nil {
+			return multierror.Prefix(err, "scoring ->")
+		}
+	}
+
+	// If we have a preemption policy, then parse that
+	if o := listVal.Filter("preemption"); len(o.Items) > 0 {
+		if err := parsePreemption(&result.Preemption, o); err != nil {
+			return multierror.Prefix(err, "preemption ->")
+		}
+	}
+
 	// If we have a parameterized definition, then parse that
 	if o := listVal.Filter("parameterized"); len(o.Items) > 0 {
 		if err := parseParameterizedJob(&result.ParameterizedJob, o); err != nil {
@@ -260,6 +278,126 @@ func parsePeriodic(result **api.PeriodicConfig, list *ast.ObjectList) error {
 	return nil
 }
 
+func parseScoring(result **api.ScoringConfig, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'scoring' block allowed per job")
+	}
+
+	// Get our resource object
+	o := list.Items[0]
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"fit_function",
+		"cpu_weight",
+		"memory_weight",
+		"disk_weight",
+		"network_weight",
+		"devices_weight",
+	}
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	// Build the scoring block
+	var s api.ScoringConfig
+	if err := mapstructure.WeakDecode(m, &s); err != nil {
+		return err
+	}
+
+	*result = &s
+	return nil
+}
+
+func parsePreemption(result **api.PreemptionPolicy, list *ast.ObjectList) error {
+	list = list.Elem()
+	if len(list.Items) > 1 {
+		return fmt.Errorf("only one 'preemption' block allowed per job")
+	}
+
+	// Get our resource object
+	o := list.Items[0]
+
+	var listVal *ast.ObjectList
+	if ot, ok := o.Val.(*ast.ObjectType); ok {
+		listVal = ot.List
+	} else {
+		return fmt.Errorf("should be an object")
+	}
+
+	// Check for invalid keys
+	valid := []string{
+		"preemptible",
+		"max_preemptions",
+		"max_preemptions_percent",
+		"window",
+		"maintenance_window",
+	}
+	if err := checkHCLKeys(o.Val, valid); err != nil {
+		return err
+	}
+
+	var m map[string]interface{}
+	if err := hcl.DecodeObject(&m, o.Val); err != nil {
+		return err
+	}
+	delete(m, "maintenance_window")
+
+	// Build the preemption policy
+	var p api.PreemptionPolicy
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		WeaklyTypedInput: true,
+		Result:           &p,
+	})
+	if err != nil {
+		return err
+	}
+	if err := dec.Decode(m); err != nil {
+		return err
+	}
+
+	// Parse the maintenance windows
+	for i, wo := range listVal.Filter("maintenance_window").Items {
+		valid := []string{
+			"cron",
+			"duration",
+			"time_zone",
+		}
+		if err := checkHCLKeys(wo.Val, valid); err != nil {
+			return multierror.Prefix(err, fmt.Sprintf("maintenance_window[%d] ->", i))
+		}
+
+		var wm map[string]interface{}
+		if err := hcl.DecodeObject(&wm, wo.Val); err != nil {
+			return err
+		}
+
+		var w api.MaintenanceWindow
+		dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+			WeaklyTypedInput: true,
+			Result:           &w,
+		})
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(wm); err != nil {
+			return err
+		}
+		p.MaintenanceWindows = append(p.MaintenanceWindows, &w)
+	}
+
+	*result = &p
+	return nil
+}
+
 func parseParameterizedJob(result **api.ParameterizedJobConfig, list *ast.ObjectList) error {
 	list = list.Elem()
 	if len(list.Items) > 1 {
@@ -79,6 +79,7 @@ func parsePorts(networkObj *ast.ObjectList, nw *api.NetworkResource) error {
 			"static",
 			"to",
 			"host_network",
+			"protocol",
 		}
 		if err := checkHCLKeys(port.Val, valid); err != nil {
 			return err
@@ -84,6 +84,10 @@ func TestParse(t *testing.T) {
 							},
 						},
 					},
+					{
+						Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+						Weight:    int8ToPtr(50),
+					},
 				},
 
 				Update: &api.UpdateStrategy{
@@ -198,6 +202,7 @@ func TestParse(t *testing.T) {
 							},
 						},
 						StopAfterClientDisconnect: timeToPtr(120 * time.Second),
+						Placement:                 stringToPtr("all_or_nothing"),
 						ReschedulePolicy: &api.ReschedulePolicy{
 							Interval: timeToPtr(12 * time.Hour),
 							Attempts: intToPtr(5),
@@ -1074,6 +1079,107 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"canary-steps.hcl",
+			&api.Job{
+				ID:          stringToPtr("foo"),
+				Name:        stringToPtr("foo"),
+				Datacenters: []string{"dc1"},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:  stringToPtr("bar"),
+						Count: intToPtr(10),
+						Update: &api.UpdateStrategy{
+							AutoPromote: boolToPtr(true),
+							CanarySteps: []*api.CanaryStep{
+								{
+									Percent: intToPtr(10),
+									Pause:   timeToPtr(5 * time.Minute),
+								},
+								{
+									Percent: intToPtr(50),
+								},
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+									"args":    []interface{}{"-c", "echo hi"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"update-analysis.hcl",
+			&api.Job{
+				ID:          stringToPtr("foo"),
+				Name:        stringToPtr("foo"),
+				Datacenters: []string{"dc1"},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("bar"),
+						Update: &api.UpdateStrategy{
+							MinHealthyTime: timeToPtr(time.Minute),
+							Analysis: &api.DeploymentAnalysis{
+								Address:   stringToPtr("http://prometheus.service.consul:9090"),
+								Query:     stringToPtr(`sum(rate(http_errors{job="{{.JobID}}"}[1m]))`),
+								Operator:  stringToPtr("<"),
+								Threshold: float64ToPtr(0.05),
+								Interval:  timeToPtr(15 * time.Second),
+							},
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+									"args":    []interface{}{"-c", "echo hi"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"update-blue-green.hcl",
+			&api.Job{
+				ID:          stringToPtr("foo"),
+				Name:        stringToPtr("foo"),
+				Datacenters: []string{"dc1"},
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name:  stringToPtr("bar"),
+						Count: intToPtr(3),
+						Update: &api.UpdateStrategy{
+							Strategy:       stringToPtr("blue_green"),
+							RetainPrevious: timeToPtr(30 * time.Minute),
+							AutoPromote:    boolToPtr(true),
+						},
+						Tasks: []*api.Task{
+							{
+								Name:   "bar",
+								Driver: "raw_exec",
+								Config: map[string]interface{}{
+									"command": "bash",
+									"args":    []interface{}{"-c", "echo hi"},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"tg-network.hcl",
 			&api.Job{
@@ -1095,6 +1201,11 @@ func TestParse(t *testing.T) {
 										To:          8080,
 										HostNetwork: "public",
 									},
+									{
+										Label:    "dns",
+										Value:    53,
+										Protocol: "udp",
+									},
 								},
 								DNS: &api.DNSConfig{
 									Servers: []string{"8.8.8.8"},
@@ -1699,6 +1810,40 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"scoring.hcl",
+			&api.Job{
+				ID:   stringToPtr("scoring"),
+				Name: stringToPtr("scoring"),
+				Scoring: &api.ScoringConfig{
+					FitFunction:   api.FitFunctionDominantResource,
+					CPUWeight:     1,
+					MemoryWeight:  2,
+					DevicesWeight: 0.5,
+				},
+			},
+			false,
+		},
+		{
+			"preemption.hcl",
+			&api.Job{
+				ID:   stringToPtr("preemption"),
+				Name: stringToPtr("preemption"),
+				Preemption: &api.PreemptionPolicy{
+					MaxPreemptions:        intToPtr(2),
+					MaxPreemptionsPercent: intToPtr(25),
+					Window:                timeToPtr(30 * time.Minute),
+					MaintenanceWindows: []*api.MaintenanceWindow{
+						{
+							Cron:     stringToPtr("0 2 * * *"),
+							Duration: timeToPtr(2 * time.Hour),
+							TimeZone: stringToPtr("America/New_York"),
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"resources-cores.hcl",
 			&api.Job{
@@ -43,6 +43,11 @@ job "binstore-storagelocker" {
     }
   }
 
+  spread {
+    hierarchy = ["${meta.zone}", "${meta.rack}"]
+    weight    = 50
+  }
+
   update {
     stagger           = "60s"
     max_parallel      = 2
@@ -158,6 +163,7 @@ job "binstore-storagelocker" {
     }
 
     stop_after_client_disconnect = "120s"
+    placement                    = "all_or_nothing"
 
     task "binstore" {
       driver = "docker"
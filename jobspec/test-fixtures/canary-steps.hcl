@@ -0,0 +1,29 @@
+job "foo" {
+  datacenters = ["dc1"]
+
+  group "bar" {
+    count = 10
+
+    update {
+      auto_promote = true
+
+      canary_step {
+        percent = 10
+        pause   = "5m"
+      }
+
+      canary_step {
+        percent = 50
+      }
+    }
+
+    task "bar" {
+      driver = "raw_exec"
+
+      config {
+        command = "bash"
+        args    = ["-c", "echo hi"]
+      }
+    }
+  }
+}
@@ -0,0 +1,13 @@
+job "preemption" {
+  preemption {
+    max_preemptions         = 2
+    max_preemptions_percent = 25
+    window                  = "30m"
+
+    maintenance_window {
+      cron      = "0 2 * * *"
+      duration  = "2h"
+      time_zone = "America/New_York"
+    }
+  }
+}
@@ -0,0 +1,8 @@
+job "scoring" {
+  scoring {
+    fit_function   = "dominant-resource"
+    cpu_weight     = 1
+    memory_weight  = 2
+    devices_weight = 0.5
+  }
+}
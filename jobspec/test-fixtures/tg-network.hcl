@@ -14,6 +14,11 @@ job "foo" {
         host_network = "public"
       }
 
+      port "dns" {
+        static   = 53
+        protocol = "udp"
+      }
+
       dns {
         servers = ["8.8.8.8"]
         options = ["ndots:2", "edns0"]
@@ -0,0 +1,26 @@
+job "foo" {
+  datacenters = ["dc1"]
+
+  group "bar" {
+    update {
+      min_healthy_time = "1m"
+
+      analysis {
+        address   = "http://prometheus.service.consul:9090"
+        query     = "sum(rate(http_errors{job=\"{{.JobID}}\"}[1m]))"
+        operator  = "<"
+        threshold = 0.05
+        interval  = "15s"
+      }
+    }
+
+    task "bar" {
+      driver = "raw_exec"
+
+      config {
+        command = "bash"
+        args    = ["-c", "echo hi"]
+      }
+    }
+  }
+}
@@ -0,0 +1,22 @@
+job "foo" {
+  datacenters = ["dc1"]
+
+  group "bar" {
+    count = 3
+
+    update {
+      strategy        = "blue_green"
+      retain_previous = "30m"
+      auto_promote    = true
+    }
+
+    task "bar" {
+      driver = "raw_exec"
+
+      config {
+        command = "bash"
+        args    = ["-c", "echo hi"]
+      }
+    }
+  }
+}
@@ -675,6 +675,7 @@ func (b *BlockedEvals) Flush() {
 	b.stats.TotalBlocked = 0
 	b.stats.TotalQuotaLimit = 0
 	b.stats.BlockedResources = NewBlockedResourcesStats()
+	b.stats.ByNamespace = make(map[string]int)
 	b.captured = make(map[string]wrappedEval)
 	b.escaped = make(map[string]wrappedEval)
 	b.jobs = make(map[structs.NamespacedID]string)
@@ -700,12 +701,18 @@ func (b *BlockedEvals) Stats() *BlockedStats {
 	stats.TotalBlocked = b.stats.TotalBlocked
 	stats.TotalQuotaLimit = b.stats.TotalQuotaLimit
 	stats.BlockedResources = b.stats.BlockedResources.Copy()
+	for namespace, blocked := range b.stats.ByNamespace {
+		stats.ByNamespace[namespace] = blocked
+	}
 
 	return stats
 }
 
 // EmitStats is used to export metrics about the blocked eval tracker while enabled
 func (b *BlockedEvals) EmitStats(period time.Duration, stopCh <-chan struct{}) {
+	// namespaces tracks the namespaces with gauges so they are zeroed once
+	// the namespace has no blocked evaluations
+	namespaces := make(map[string]struct{})
 	for {
 		select {
 		case <-time.After(period):
@@ -714,6 +721,20 @@ func (b *BlockedEvals) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 			metrics.SetGauge([]string{"nomad", "blocked_evals", "total_blocked"}, float32(stats.TotalBlocked))
 			metrics.SetGauge([]string{"nomad", "blocked_evals", "total_escaped"}, float32(stats.TotalEscaped))
 
+			for namespace := range namespaces {
+				if _, ok := stats.ByNamespace[namespace]; !ok {
+					stats.ByNamespace[namespace] = 0
+					delete(namespaces, namespace)
+				}
+			}
+			for namespace, blocked := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "blocked_evals", "namespace", "total_blocked"}, float32(blocked), labels)
+				if blocked != 0 {
+					namespaces[namespace] = struct{}{}
+				}
+			}
+
 			for k, v := range stats.BlockedResources.ByJob {
 				labels := []metrics.Label{
 					{Name: "namespace", Value: k.Namespace},
@@ -22,12 +22,16 @@ type BlockedStats struct {
 	// BlockedResources stores the amount of resources requested by blocked
 	// evaluations.
 	BlockedResources BlockedResourcesStats
+
+	// ByNamespace is the number of blocked evaluations by namespace.
+	ByNamespace map[string]int
 }
 
 // NewBlockedStats returns a new BlockedStats.
 func NewBlockedStats() *BlockedStats {
 	return &BlockedStats{
 		BlockedResources: NewBlockedResourcesStats(),
+		ByNamespace:      make(map[string]int),
 	}
 }
 
@@ -35,6 +39,7 @@ func NewBlockedStats() *BlockedStats {
 // evaluation being blocked.
 func (b *BlockedStats) Block(eval *structs.Evaluation) {
 	b.TotalBlocked++
+	b.ByNamespace[eval.Namespace]++
 	resourceStats := generateResourceStats(eval)
 	b.BlockedResources = b.BlockedResources.Add(resourceStats)
 }
@@ -43,6 +48,9 @@ func (b *BlockedStats) Block(eval *structs.Evaluation) {
 // evaluation being unblocked.
 func (b *BlockedStats) Unblock(eval *structs.Evaluation) {
 	b.TotalBlocked--
+	if b.ByNamespace[eval.Namespace]--; b.ByNamespace[eval.Namespace] <= 0 {
+		delete(b.ByNamespace, eval.Namespace)
+	}
 	resourceStats := generateResourceStats(eval)
 	b.BlockedResources = b.BlockedResources.Subtract(resourceStats)
 }
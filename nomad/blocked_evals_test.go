@@ -59,6 +59,31 @@ func TestBlockedEvals_Block_SameJob(t *testing.T) {
 	require.Len(blockedStats.BlockedResources.ByJob, 1)
 }
 
+func TestBlockedEvals_Block_ByNamespace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	blocked, _ := testBlockedEvals(t)
+
+	// Create blocked evals in two namespaces.
+	e := mock.BlockedEval()
+	e2 := mock.BlockedEval()
+	e2.Namespace = "other"
+	e3 := mock.BlockedEval()
+	e3.Namespace = "other"
+	blocked.Block(e)
+	blocked.Block(e2)
+	blocked.Block(e3)
+
+	blockedStats := blocked.Stats()
+	require.Equal(map[string]int{e.Namespace: 1, "other": 2}, blockedStats.ByNamespace)
+
+	// Untrack the eval of the default namespace and verify it is dropped.
+	blocked.Untrack(e.JobID, e.Namespace)
+	blockedStats = blocked.Stats()
+	require.Equal(map[string]int{"other": 2}, blockedStats.ByNamespace)
+}
+
 func TestBlockedEvals_Block_Quota(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
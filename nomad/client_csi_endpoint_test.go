@@ -39,6 +39,10 @@ type MockClientCSI struct {
 	NextListExternalSnapshotsError    error
 	NextListExternalSnapshotsResponse *cstructs.ClientCSIControllerListSnapshotsResponse
 	NextNodeDetachError               error
+	NextExpandError                   error
+	NextExpandResponse                *cstructs.ClientCSIControllerExpandVolumeResponse
+	NextNodeExpandError               error
+	NodeExpandCount                   int
 }
 
 func newMockClientCSI() *MockClientCSI {
@@ -48,6 +52,7 @@ func newMockClientCSI() *MockClientCSI {
 		NextListExternalResponse:          &cstructs.ClientCSIControllerListVolumesResponse{},
 		NextCreateSnapshotResponse:        &cstructs.ClientCSIControllerCreateSnapshotResponse{},
 		NextListExternalSnapshotsResponse: &cstructs.ClientCSIControllerListSnapshotsResponse{},
+		NextExpandResponse:                &cstructs.ClientCSIControllerExpandVolumeResponse{},
 	}
 }
 
@@ -69,6 +74,11 @@ func (c *MockClientCSI) ControllerCreateVolume(req *cstructs.ClientCSIController
 	return c.NextCreateError
 }
 
+func (c *MockClientCSI) ControllerExpandVolume(req *cstructs.ClientCSIControllerExpandVolumeRequest, resp *cstructs.ClientCSIControllerExpandVolumeResponse) error {
+	*resp = *c.NextExpandResponse
+	return c.NextExpandError
+}
+
 func (c *MockClientCSI) ControllerDeleteVolume(req *cstructs.ClientCSIControllerDeleteVolumeRequest, resp *cstructs.ClientCSIControllerDeleteVolumeResponse) error {
 	return c.NextDeleteError
 }
@@ -96,6 +106,12 @@ func (c *MockClientCSI) NodeDetachVolume(req *cstructs.ClientCSINodeDetachVolume
 	return c.NextNodeDetachError
 }
 
+func (c *MockClientCSI) NodeExpandVolume(req *cstructs.ClientCSINodeExpandVolumeRequest, resp *cstructs.ClientCSINodeExpandVolumeResponse) error {
+	c.NodeExpandCount++
+	resp.CapacityBytes = req.CapacityMin
+	return c.NextNodeExpandError
+}
+
 func TestClientCSIController_AttachVolume_Local(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
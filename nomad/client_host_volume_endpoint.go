@@ -0,0 +1,53 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+// ClientHostVolume is used to forward RPC requests to the targed Nomad
+// client's HostVolume endpoint.
+type ClientHostVolume struct {
+	srv    *Server
+	logger log.Logger
+}
+
+func (a *ClientHostVolume) Create(args *cstructs.ClientHostVolumeCreateRequest, reply *cstructs.ClientHostVolumeCreateResponse) error {
+	defer metrics.MeasureSince([]string{"nomad", "client_host_volume", "create"}, time.Now())
+	return a.sendClientRPC(args.NodeID, "HostVolume.Create", "ClientHostVolume.Create", args, reply)
+}
+
+func (a *ClientHostVolume) Delete(args *cstructs.ClientHostVolumeDeleteRequest, reply *cstructs.ClientHostVolumeDeleteResponse) error {
+	defer metrics.MeasureSince([]string{"nomad", "client_host_volume", "delete"}, time.Now())
+	return a.sendClientRPC(args.NodeID, "HostVolume.Delete", "ClientHostVolume.Delete", args, reply)
+}
+
+func (a *ClientHostVolume) sendClientRPC(nodeID, method, fwdMethod string, args, reply interface{}) error {
+	// Make sure Node is valid and new enough to support RPC
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	_, err = getNodeForRpc(snap, nodeID)
+	if err != nil {
+		return err
+	}
+
+	// Get the connection to the client
+	state, ok := a.srv.getNodeConn(nodeID)
+	if !ok {
+		return findNodeConnAndForward(a.srv, nodeID, fwdMethod, args, reply)
+	}
+
+	// Make the RPC
+	err = NodeRpc(state.Session, method, args, reply)
+	if err != nil {
+		return fmt.Errorf("%s error: %w", method, err)
+	}
+	return nil
+}
@@ -245,6 +245,11 @@ type Config struct {
 	// are signs of high server resource usage.
 	EvalNackSubsequentReenqueueDelay time.Duration
 
+	// EvalNamespaceFairness dequeues evaluations of equal priority fairly
+	// across namespaces, weighted by the namespace weights, rather than in
+	// the order they were created.
+	EvalNamespaceFairness bool
+
 	// EvalFailedFollowupBaselineDelay is the minimum time waited before
 	// retrying a failed evaluation.
 	EvalFailedFollowupBaselineDelay time.Duration
@@ -368,6 +373,10 @@ type Config struct {
 	// DeploymentQueryRateLimit is in queries per second and is used by the
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
+
+	// DeploymentAnalysisAddresses are the metrics server addresses job
+	// update blocks may query to analyze their deployments
+	DeploymentAnalysisAddresses []string
 }
 
 // CheckVersion is used to check if the ProtocolVersion is valid
@@ -283,6 +283,14 @@ func (v *CSIVolume) Register(args *structs.CSIVolumeRegisterRequest, reply *stru
 		return fmt.Errorf("missing volume definition")
 	}
 
+	// Every volume is validated before any is expanded, so that a request
+	// that fails validation leaves the storage provider alone
+	type volumeExpansion struct {
+		existing *structs.CSIVolume
+		plugin   *structs.CSIPlugin
+	}
+	expansions := make(map[*structs.CSIVolume]volumeExpansion)
+
 	// This is the only namespace we ACL checked, force all the volumes to use it.
 	// We also validate that the plugin exists for each plugin, and validate the
 	// capabilities when the plugin has a controller.
@@ -292,6 +300,12 @@ func (v *CSIVolume) Register(args *structs.CSIVolumeRegisterRequest, reply *stru
 			return err
 		}
 
+		// a registered volume was created outside of Nomad, so the storage
+		// provider can't tell us where it's accessible from
+		if len(vol.Topologies) == 0 && vol.RequestedTopologies != nil {
+			vol.Topologies = vol.RequestedTopologies.Required
+		}
+
 		plugin, err := v.pluginValidateVolume(args, vol)
 		if err != nil {
 			return err
@@ -299,8 +313,38 @@ func (v *CSIVolume) Register(args *structs.CSIVolumeRegisterRequest, reply *stru
 		if err := v.controllerValidateVolume(args, vol, plugin); err != nil {
 			return err
 		}
+
+		existing, err := v.existingVolume(vol)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			expand, err := validateVolumeExpansion(vol, existing, plugin)
+			if err != nil {
+				return err
+			}
+			if expand {
+				expansions[vol] = volumeExpansion{existing: existing, plugin: plugin}
+			}
+		}
 	}
 
+	// Volumes in use can't be registered again, so the volumes that are only
+	// expanded are left out of the registration
+	register := make([]*structs.CSIVolume, 0, len(args.Volumes))
+	for _, vol := range args.Volumes {
+		if exp, ok := expansions[vol]; ok {
+			if err := v.expandVolume(vol, exp.existing, exp.plugin); err != nil {
+				return err
+			}
+			if exp.existing.InUse() {
+				continue
+			}
+		}
+		register = append(register, vol)
+	}
+	args.Volumes = register
+
 	resp, index, err := v.srv.raftApply(structs.CSIVolumeRegisterRequestType, args)
 	if err != nil {
 		v.logger.Error("csi raft apply failed", "error", err, "method", "register")
@@ -402,6 +446,7 @@ func (v *CSIVolume) Claim(args *structs.CSIVolumeClaimRequest, reply *structs.CS
 		}
 	}
 
+	args.UpdatedAt = time.Now().UnixNano()
 	resp, index, err := v.srv.raftApply(structs.CSIVolumeClaimRequestType, args)
 	if err != nil {
 		v.logger.Error("csi raft apply failed", "error", err, "method", "claim")
@@ -581,12 +626,14 @@ func (v *CSIVolume) Unpublish(args *structs.CSIVolumeUnpublishRequest, reply *st
 	}
 	err = v.nodeUnpublishVolume(vol, claim)
 	if err != nil {
+		v.checkpointClaimFailed(vol, claim, structs.CSIVolumeClaimStateNodeDetached, err)
 		return err
 	}
 
 NODE_DETACHED:
 	err = v.controllerUnpublishVolume(vol, claim)
 	if err != nil {
+		v.checkpointClaimFailed(vol, claim, structs.CSIVolumeClaimStateControllerDetached, err)
 		return err
 	}
 
@@ -744,7 +791,7 @@ func (v *CSIVolume) controllerUnpublishVolume(vol *structs.CSIVolume, claim *str
 	if err != nil {
 		return fmt.Errorf("could not detach from controller: %v", err)
 	}
-	claim.State = structs.CSIVolumeClaimStateReadyToFree
+	claim.State = structs.CSIVolumeClaimStateControllerDetached
 	return v.checkpointClaim(vol, claim)
 }
 
@@ -796,6 +843,7 @@ func (v *CSIVolume) checkpointClaim(vol *structs.CSIVolume, claim *structs.CSIVo
 		NodeID:       claim.NodeID,
 		Claim:        claim.Mode,
 		State:        claim.State,
+		UpdatedAt:    time.Now().UnixNano(),
 		WriteRequest: structs.WriteRequest{
 			Namespace: vol.Namespace,
 		},
@@ -812,6 +860,177 @@ func (v *CSIVolume) checkpointClaim(vol *structs.CSIVolume, claim *structs.CSIVo
 	return nil
 }
 
+// checkpointClaimFailed records the failure to move the claim to the state
+// in the volume's claim history. The volumewatcher retries unpublishing
+// stuck claims, so repeated failures are only written once they pass
+// ShouldRecordClaimFailure.
+func (v *CSIVolume) checkpointClaimFailed(vol *structs.CSIVolume, claim *structs.CSIVolumeClaim,
+	state structs.CSIVolumeClaimState, claimErr error) {
+
+	failed := *claim
+	failed.State = state
+	failed.UpdatedAt = time.Now().UnixNano()
+	if !vol.ShouldRecordClaimFailure(structs.NewCSIVolumeClaimEvent(&failed, claimErr.Error())) {
+		return
+	}
+
+	req := structs.CSIVolumeClaimRequest{
+		VolumeID:     vol.ID,
+		AllocationID: claim.AllocationID,
+		NodeID:       claim.NodeID,
+		Claim:        claim.Mode,
+		State:        state,
+		UpdatedAt:    failed.UpdatedAt,
+		Error:        claimErr.Error(),
+		WriteRequest: structs.WriteRequest{
+			Namespace: vol.Namespace,
+		},
+	}
+	resp, _, err := v.srv.raftApply(structs.CSIVolumeClaimRequestType, req)
+	if err == nil {
+		err, _ = resp.(error)
+	}
+	if err != nil {
+		v.logger.Error("failed to record claim error", "error", err)
+	}
+}
+
+// Reclaim releases the claims on a volume of allocations that are terminal
+// or garbage collected, which are stuck if the volumewatcher failed to release
+// them. Claims on nodes that are down or garbage collected skip the node
+// unpublish, because the node can't be reached.
+func (v *CSIVolume) Reclaim(args *structs.CSIVolumeReclaimRequest, reply *structs.CSIVolumeReclaimResponse) error {
+	if done, err := v.srv.forward("CSIVolume.Reclaim", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "volume", "reclaim"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityCSIWriteVolume)
+	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
+	if err != nil {
+		return err
+	}
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.VolumeID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	ws := memdb.NewWatchSet()
+	snap, err := v.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	vol, err := snap.CSIVolumeByID(ws, args.RequestNamespace(), args.VolumeID)
+	if err != nil {
+		return err
+	}
+	if vol == nil {
+		return fmt.Errorf("no such volume")
+	}
+	vol, err = snap.CSIVolumeDenormalize(ws, vol.Copy())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UnixNano()
+	batch := &structs.CSIVolumeClaimBatchRequest{}
+	reply.Claims = []*structs.CSIVolumeReclaimedClaim{}
+
+	reclaim := func(claims map[string]*structs.CSIVolumeClaim, past bool) error {
+		for allocID, claim := range claims {
+			// past claims are also in the read or write claims until
+			// they're freed
+			if _, ok := vol.PastClaims[allocID]; ok && !past {
+				continue
+			}
+
+			node, err := snap.NodeByID(ws, claim.NodeID)
+			if err != nil {
+				return err
+			}
+			alloc, ok := vol.ReadAllocs[allocID]
+			if !ok {
+				alloc = vol.WriteAllocs[allocID]
+			}
+
+			var reason string
+			state := structs.CSIVolumeClaimStateUnpublishing
+			switch {
+			case alloc != nil && !alloc.TerminalStatus():
+				continue
+			case node == nil || node.Status == structs.NodeStatusDown:
+				reason = structs.CSIVolumeReclaimNodeLost
+				state = structs.CSIVolumeClaimStateNodeDetached
+			case alloc == nil:
+				reason = structs.CSIVolumeReclaimAllocGC
+			default:
+				reason = structs.CSIVolumeReclaimAllocTerminal
+			}
+
+			// don't move a past claim back to an earlier step of releasing
+			// it; writing it again has the volumewatcher retry the step
+			if past && (claim.State == structs.CSIVolumeClaimStateNodeDetached ||
+				claim.State == structs.CSIVolumeClaimStateControllerDetached) {
+				state = claim.State
+			}
+
+			reply.Claims = append(reply.Claims, &structs.CSIVolumeReclaimedClaim{
+				AllocationID: allocID,
+				NodeID:       claim.NodeID,
+				Reason:       reason,
+			})
+			batch.Claims = append(batch.Claims, structs.CSIVolumeClaimRequest{
+				VolumeID:       vol.ID,
+				AllocationID:   allocID,
+				NodeID:         claim.NodeID,
+				ExternalNodeID: claim.ExternalNodeID,
+				Claim:          claim.Mode,
+				AccessMode:     claim.AccessMode,
+				AttachmentMode: claim.AttachmentMode,
+				State:          state,
+				UpdatedAt:      now,
+				WriteRequest: structs.WriteRequest{
+					Namespace: vol.Namespace,
+				},
+			})
+		}
+		return nil
+	}
+
+	if err := reclaim(vol.PastClaims, true); err != nil {
+		return err
+	}
+	if err := reclaim(vol.ReadClaims, false); err != nil {
+		return err
+	}
+	if err := reclaim(vol.WriteClaims, false); err != nil {
+		return err
+	}
+
+	if args.DryRun || len(batch.Claims) == 0 {
+		return v.srv.replySetIndex(csiVolumeTable, &reply.QueryMeta)
+	}
+
+	// the claims are released by the volumewatcher, which is notified by
+	// the update to the volume
+	resp, index, err := v.srv.raftApply(structs.CSIVolumeClaimBatchRequestType, batch)
+	if err != nil {
+		v.logger.Error("csi raft apply failed", "error", err, "method", "reclaim")
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	reply.Index = index
+	v.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.CSIVolumeCreateResponse) error {
 
 	if done, err := v.srv.forward("CSIVolume.Create", args, args, reply); done {
@@ -876,12 +1095,42 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 	// eval" that can do the plugin RPCs async.
 
 	var mErr multierror.Error
+	var created []*structs.CSIVolume
 
 	for _, valid := range validatedVols {
+		existing, err := v.existingVolume(valid.vol)
+		if err != nil {
+			multierror.Append(&mErr, err)
+			continue
+		}
+		if existing != nil && existing.ExternalID != "" {
+			// the volume was already created, so only its capacity can be
+			// changed
+			valid.vol.ExternalID = existing.ExternalID
+			valid.vol.Context = existing.Context
+			valid.vol.Topologies = existing.Topologies
+			expanded, err := validateVolumeExpansion(valid.vol, existing, valid.plugin)
+			if err == nil && expanded {
+				err = v.expandVolume(valid.vol, existing, valid.plugin)
+			}
+			if err != nil {
+				multierror.Append(&mErr, err)
+				continue
+			}
+			created = append(created, valid.vol)
+
+			// volumes in use can't be registered again
+			if !expanded || !existing.InUse() {
+				regArgs.Volumes = append(regArgs.Volumes, valid.vol)
+			}
+			continue
+		}
+
 		err = v.createVolume(valid.vol, valid.plugin)
 		if err != nil {
 			multierror.Append(&mErr, err)
 		} else {
+			created = append(created, valid.vol)
 			regArgs.Volumes = append(regArgs.Volumes, valid.vol)
 		}
 	}
@@ -900,7 +1149,7 @@ func (v *CSIVolume) Create(args *structs.CSIVolumeCreateRequest, reply *structs.
 		return err
 	}
 
-	reply.Volumes = regArgs.Volumes
+	reply.Volumes = created
 	reply.Index = index
 	v.srv.setQueryMeta(&reply.QueryMeta)
 	return nil
@@ -919,6 +1168,7 @@ func (v *CSIVolume) createVolume(vol *structs.CSIVolume, plugin *structs.CSIPlug
 		CapacityMax:        vol.RequestedCapacityMax,
 		SnapshotID:         vol.SnapshotID,
 		CloneID:            vol.CloneID,
+		Requirements:       vol.RequestedTopologies,
 	}
 	cReq.PluginID = plugin.ID
 	cResp := &cstructs.ClientCSIControllerCreateVolumeResponse{}
@@ -930,9 +1180,134 @@ func (v *CSIVolume) createVolume(vol *structs.CSIVolume, plugin *structs.CSIPlug
 	vol.ExternalID = cResp.ExternalVolumeID
 	vol.Capacity = cResp.CapacityBytes
 	vol.Context = cResp.VolumeContext
+	vol.Topologies = cResp.Topologies
+	return nil
+}
+
+// existingVolume returns the registered volume with the same ID as vol, or
+// nil if there is none.
+func (v *CSIVolume) existingVolume(vol *structs.CSIVolume) (*structs.CSIVolume, error) {
+	snap, err := v.srv.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snap.CSIVolumeByID(nil, vol.Namespace, vol.ID)
+}
+
+// validateVolumeExpansion returns whether vol requests a minimum capacity
+// larger than the existing volume's current capacity, and an error if the
+// plugin can't expand it. vol's capacity is set to the existing capacity.
+func validateVolumeExpansion(vol, existing *structs.CSIVolume, plugin *structs.CSIPlugin) (bool, error) {
+	vol.Capacity = existing.Capacity
+
+	// volumes registered without being created have no known capacity, so
+	// their requested capacity is taken as their current capacity
+	current := existing.Capacity
+	if current == 0 {
+		current = existing.RequestedCapacityMin
+	}
+	if vol.RequestedCapacityMin <= current {
+		return false, nil
+	}
+	if !plugin.ControllerRequired ||
+		!plugin.HasControllerCapability(structs.CSIControllerSupportsExpand) {
+		return false, fmt.Errorf("plugin %s does not support expanding volumes", plugin.ID)
+	}
+	return true, nil
+}
+
+// expandVolume expands the existing volume to the capacity requested by vol.
+// The controller plugin expands the volume, and then the node plugins expand
+// it on every node where it's claimed if the controller requires it. The new
+// capacity is written to raft on its own, so that the claims of a volume in
+// use are left alone, and is set on vol.
+func (v *CSIVolume) expandVolume(vol, existing *structs.CSIVolume, plugin *structs.CSIPlugin) error {
+	method := "ClientCSI.ControllerExpandVolume"
+	cReq := &cstructs.ClientCSIControllerExpandVolumeRequest{
+		ExternalVolumeID: existing.RemoteID(),
+		CapacityMin:      vol.RequestedCapacityMin,
+		CapacityMax:      vol.RequestedCapacityMax,
+		Secrets:          vol.Secrets,
+		AttachmentMode:   existing.AttachmentMode,
+		AccessMode:       existing.AccessMode,
+		MountOptions:     vol.MountOptions,
+	}
+	cReq.PluginID = plugin.ID
+	cResp := &cstructs.ClientCSIControllerExpandVolumeResponse{}
+	err := v.srv.RPC(method, cReq, cResp)
+	if err != nil {
+		return fmt.Errorf("could not expand volume %s: %v", vol.ID, err)
+	}
+	vol.Capacity = cResp.CapacityBytes
+
+	if cResp.NodeExpansionRequired {
+		if err := v.nodeExpandVolume(vol, existing, plugin); err != nil {
+			return err
+		}
+	}
+
+	req := &structs.CSIVolumeExpandRequest{
+		VolumeID:             vol.ID,
+		Capacity:             vol.Capacity,
+		RequestedCapacityMin: vol.RequestedCapacityMin,
+		RequestedCapacityMax: vol.RequestedCapacityMax,
+		WriteRequest: structs.WriteRequest{
+			Namespace: vol.Namespace,
+		},
+	}
+	resp, _, err := v.srv.raftApply(structs.CSIVolumeExpandRequestType, req)
+	if err != nil {
+		v.logger.Error("csi raft apply failed", "error", err, "method", "expand")
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
 	return nil
 }
 
+// nodeExpandVolume expands the volume on every node where the existing volume
+// is claimed, for plugins where the controller can't finish the expansion
+// alone.
+func (v *CSIVolume) nodeExpandVolume(vol, existing *structs.CSIVolume, plugin *structs.CSIPlugin) error {
+	var mErr multierror.Error
+	expand := func(claim *structs.CSIVolumeClaim) {
+		if claim == nil || claim.AllocationID == "" {
+			return
+		}
+		info, ok := plugin.Nodes[claim.NodeID]
+		if !ok || info.NodeInfo == nil || !info.NodeInfo.SupportsExpand {
+			return
+		}
+		req := &cstructs.ClientCSINodeExpandVolumeRequest{
+			PluginID:       existing.PluginID,
+			VolumeID:       existing.ID,
+			ExternalID:     existing.RemoteID(),
+			AllocID:        claim.AllocationID,
+			NodeID:         claim.NodeID,
+			CapacityMin:    vol.RequestedCapacityMin,
+			CapacityMax:    vol.RequestedCapacityMax,
+			AttachmentMode: existing.AttachmentMode,
+			AccessMode:     existing.AccessMode,
+			MountOptions:   vol.MountOptions,
+			ReadOnly:       claim.Mode == structs.CSIVolumeClaimRead,
+		}
+		resp := &cstructs.ClientCSINodeExpandVolumeResponse{}
+		err := v.srv.RPC("ClientCSI.NodeExpandVolume", req, resp)
+		if err != nil {
+			multierror.Append(&mErr, fmt.Errorf(
+				"could not expand volume %s on node %s: %v", vol.ID, claim.NodeID, err))
+		}
+	}
+	for _, claim := range existing.ReadClaims {
+		expand(claim)
+	}
+	for _, claim := range existing.WriteClaims {
+		expand(claim)
+	}
+	return mErr.ErrorOrNil()
+}
+
 func (v *CSIVolume) Delete(args *structs.CSIVolumeDeleteRequest, reply *structs.CSIVolumeDeleteResponse) error {
 	if done, err := v.srv.forward("CSIVolume.Delete", args, args, reply); done {
 		return err
@@ -577,16 +577,237 @@ func TestCSIVolumeEndpoint_Unpublish(t *testing.T) {
 				require.NoError(t, err)
 				require.NotNil(t, vol)
 				require.Len(t, vol.ReadAllocs, 0)
+				last := vol.ClaimHistory[len(vol.ClaimHistory)-1]
+				require.Equal(t, structs.CSIVolumeClaimEventFreed, last.Type)
+				require.Empty(t, last.Error)
 			} else {
 				require.Error(t, err)
 				require.True(t, strings.Contains(err.Error(), tc.expectedErrMsg),
 					"error message %q did not contain %q", err.Error(), tc.expectedErrMsg)
+
+				// the failure is recorded in the claim history
+				vol, err = state.CSIVolumeByID(nil, ns, volID)
+				require.NoError(t, err)
+				last := vol.ClaimHistory[len(vol.ClaimHistory)-1]
+				require.Equal(t, alloc.ID, last.AllocationID)
+				require.Contains(t, last.Error, tc.expectedErrMsg)
 			}
 		})
 	}
 
 }
 
+func TestCSIVolumeEndpoint_Unpublish_StuckClaims(t *testing.T) {
+	t.Parallel()
+	srv, shutdown := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	// the test retries the unpublish the way the volumewatcher would
+	srv.volumeWatcher.SetEnabled(false, nil)
+
+	index := uint64(1000)
+	ns := structs.DefaultNamespace
+	state := srv.fsm.State()
+	codec := rpcClient(t, srv)
+
+	// the node has no client connected, so unpublishing from it fails
+	node := mock.Node()
+	node.CSINodePlugins = map[string]*structs.CSIInfo{
+		"minnie": {PluginID: "minnie", Healthy: true, NodeInfo: &structs.CSINodeInfo{}},
+	}
+	index++
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, node))
+
+	volID := uuid.Generate()
+	vol := &structs.CSIVolume{
+		ID:        volID,
+		Namespace: ns,
+		PluginID:  "minnie",
+		RequestedCapabilities: []*structs.CSIVolumeCapability{{
+			AccessMode:     structs.CSIVolumeAccessModeMultiNodeMultiWriter,
+			AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		}},
+	}
+	index++
+	require.NoError(t, state.CSIVolumeRegister(index, []*structs.CSIVolume{vol}))
+
+	allocs := []*structs.Allocation{mock.Alloc(), mock.Alloc()}
+	for _, alloc := range allocs {
+		alloc.NodeID = node.ID
+		alloc.ClientStatus = structs.AllocClientStatusFailed
+	}
+	index++
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, index, allocs))
+
+	claims := make([]*structs.CSIVolumeClaim, len(allocs))
+	for i, alloc := range allocs {
+		claims[i] = &structs.CSIVolumeClaim{
+			AllocationID: alloc.ID,
+			NodeID:       node.ID,
+			Mode:         structs.CSIVolumeClaimWrite,
+			State:        structs.CSIVolumeClaimStateTaken,
+		}
+		index++
+		require.NoError(t, state.CSIVolumeClaim(index, ns, volID, claims[i]))
+	}
+
+	volIndex, err := state.Index("csi_volumes")
+	require.NoError(t, err)
+	raftIndex := srv.raft.LastIndex()
+
+	// retrying both stuck claims in turn only records each failure once
+	for i := 0; i < 5; i++ {
+		for _, claim := range claims {
+			retry := *claim
+			req := &structs.CSIVolumeUnpublishRequest{
+				VolumeID: volID,
+				Claim:    &retry,
+				WriteRequest: structs.WriteRequest{
+					Region:    "global",
+					Namespace: ns,
+				},
+			}
+			err := msgpackrpc.CallWithCodec(codec, "CSIVolume.Unpublish", req,
+				&structs.CSIVolumeUnpublishResponse{})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "could not detach from node")
+		}
+	}
+	require.Equal(t, raftIndex+uint64(len(claims)), srv.raft.LastIndex())
+
+	// recording the failures doesn't wake the volumewatcher
+	afterIndex, err := state.Index("csi_volumes")
+	require.NoError(t, err)
+	require.Equal(t, volIndex, afterIndex)
+
+	vol, err = state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	failed := map[string]int{}
+	for _, e := range vol.ClaimHistory {
+		if e.Error != "" {
+			failed[e.AllocationID]++
+		}
+	}
+	require.Equal(t, map[string]int{allocs[0].ID: 1, allocs[1].ID: 1}, failed)
+}
+
+func TestCSIVolumeEndpoint_Reclaim(t *testing.T) {
+	t.Parallel()
+	srv, shutdown := TestServer(t, func(c *Config) { c.NumSchedulers = 0 })
+	defer shutdown()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	// the claims are left for the volumewatcher to release, so keep it from
+	// changing them under the test
+	srv.volumeWatcher.SetEnabled(false, nil)
+
+	index := uint64(1000)
+	ns := structs.DefaultNamespace
+	state := srv.fsm.State()
+	codec := rpcClient(t, srv)
+
+	node := mock.Node()
+	lostNode := mock.Node()
+	lostNode.Status = structs.NodeStatusDown
+	for _, n := range []*structs.Node{node, lostNode} {
+		n.CSINodePlugins = map[string]*structs.CSIInfo{
+			"minnie": {PluginID: "minnie", Healthy: true, NodeInfo: &structs.CSINodeInfo{}},
+		}
+		index++
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, n))
+	}
+
+	volID := uuid.Generate()
+	vol := &structs.CSIVolume{
+		ID:        volID,
+		Namespace: ns,
+		PluginID:  "minnie",
+		RequestedCapabilities: []*structs.CSIVolumeCapability{{
+			AccessMode:     structs.CSIVolumeAccessModeMultiNodeMultiWriter,
+			AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		}},
+	}
+	index++
+	require.NoError(t, state.CSIVolumeRegister(index, []*structs.CSIVolume{vol}))
+
+	running := mock.Alloc()
+	running.NodeID = node.ID
+	terminal := mock.Alloc()
+	terminal.NodeID = node.ID
+	lost := mock.Alloc()
+	lost.NodeID = lostNode.ID
+	collected := mock.Alloc()
+	collected.NodeID = node.ID
+	allocs := []*structs.Allocation{running, terminal, lost, collected}
+	index++
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, index, allocs))
+
+	for _, alloc := range allocs {
+		index++
+		require.NoError(t, state.CSIVolumeClaim(index, ns, volID, &structs.CSIVolumeClaim{
+			AllocationID: alloc.ID,
+			NodeID:       alloc.NodeID,
+			Mode:         structs.CSIVolumeClaimWrite,
+			State:        structs.CSIVolumeClaimStateTaken,
+		}))
+	}
+
+	terminal = terminal.Copy()
+	terminal.ClientStatus = structs.AllocClientStatusComplete
+	lost = lost.Copy()
+	lost.ClientStatus = structs.AllocClientStatusLost
+	index++
+	require.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, index,
+		[]*structs.Allocation{terminal, lost}))
+	index++
+	require.NoError(t, state.DeleteEval(index, nil, []string{collected.ID}))
+
+	expected := map[string]string{
+		terminal.ID:  structs.CSIVolumeReclaimAllocTerminal,
+		lost.ID:      structs.CSIVolumeReclaimNodeLost,
+		collected.ID: structs.CSIVolumeReclaimAllocGC,
+	}
+	reclaimed := func(resp *structs.CSIVolumeReclaimResponse) map[string]string {
+		out := map[string]string{}
+		for _, claim := range resp.Claims {
+			out[claim.AllocationID] = claim.Reason
+		}
+		return out
+	}
+
+	// a dry run doesn't change the claims
+	req := &structs.CSIVolumeReclaimRequest{
+		VolumeID: volID,
+		DryRun:   true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns,
+		},
+	}
+	resp := &structs.CSIVolumeReclaimResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "CSIVolume.Reclaim", req, resp))
+	require.Equal(t, expected, reclaimed(resp))
+
+	vol, err := state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Len(t, vol.PastClaims, 0)
+
+	req.DryRun = false
+	resp = &structs.CSIVolumeReclaimResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "CSIVolume.Reclaim", req, resp))
+	require.Equal(t, expected, reclaimed(resp))
+
+	vol, err = state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Len(t, vol.PastClaims, 3)
+	require.Equal(t, structs.CSIVolumeClaimStateUnpublishing, vol.PastClaims[terminal.ID].State)
+	require.Equal(t, structs.CSIVolumeClaimStateUnpublishing, vol.PastClaims[collected.ID].State)
+	// the lost node can't unpublish the volume
+	require.Equal(t, structs.CSIVolumeClaimStateNodeDetached, vol.PastClaims[lost.ID].State)
+	require.NotContains(t, vol.PastClaims, running.ID)
+}
+
 func TestCSIVolumeEndpoint_List(t *testing.T) {
 	t.Parallel()
 	srv, shutdown := TestServer(t, func(c *Config) {
@@ -806,6 +1027,166 @@ func TestCSIVolumeEndpoint_Create(t *testing.T) {
 	require.Equal(t, "", vol.Context["mycontext"])
 }
 
+func TestCSIVolumeEndpoint_Expand(t *testing.T) {
+	t.Parallel()
+	var err error
+	srv, shutdown := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer shutdown()
+
+	testutil.WaitForLeader(t, srv.RPC)
+
+	fake := newMockClientCSI()
+	fake.NextValidateError = nil
+	fake.NextExpandResponse = &cstructs.ClientCSIControllerExpandVolumeResponse{
+		CapacityBytes:         2048,
+		NodeExpansionRequired: true,
+	}
+
+	client, cleanup := client.TestClientWithRPCs(t,
+		func(c *cconfig.Config) {
+			c.Servers = []string{srv.config.RPCAddr.String()}
+		},
+		map[string]interface{}{"CSI": fake},
+	)
+	defer cleanup()
+
+	node := client.Node()
+	node.Attributes["nomad.version"] = "0.11.0" // client RPCs not supported on early versions
+
+	req0 := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp0 structs.NodeUpdateResponse
+	err = client.RPC("Node.Register", req0, &resp0)
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes := srv.connectedNodes()
+		return len(nodes) == 1, nil
+	}, func(err error) {
+		t.Fatalf("should have a client")
+	})
+
+	ns := structs.DefaultNamespace
+
+	state := srv.fsm.State()
+	codec := rpcClient(t, srv)
+	index := uint64(1000)
+
+	node.CSIControllerPlugins = map[string]*structs.CSIInfo{
+		"minnie": {
+			PluginID: "minnie",
+			Healthy:  true,
+			ControllerInfo: &structs.CSIControllerInfo{
+				SupportsExpand: true,
+			},
+			RequiresControllerPlugin: true,
+		},
+	}
+	node.CSINodePlugins = map[string]*structs.CSIInfo{
+		"minnie": {
+			PluginID: "minnie",
+			Healthy:  true,
+			NodeInfo: &structs.CSINodeInfo{
+				SupportsExpand: true,
+			},
+		},
+	}
+	index++
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, node))
+
+	// Register the volume
+	volID := uuid.Generate()
+	vol := &structs.CSIVolume{
+		ID:                   volID,
+		Namespace:            ns,
+		PluginID:             "minnie",
+		ExternalID:           "vol-12345",
+		Capacity:             1024,
+		RequestedCapacityMin: 1024,
+		RequestedCapabilities: []*structs.CSIVolumeCapability{{
+			AccessMode:     structs.CSIVolumeAccessModeMultiNodeReader,
+			AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		}},
+	}
+	req1 := &structs.CSIVolumeRegisterRequest{
+		Volumes: []*structs.CSIVolume{vol.Copy()},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: ns,
+		},
+	}
+	resp1 := &structs.CSIVolumeRegisterResponse{}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", req1, resp1)
+	require.NoError(t, err)
+
+	// Claim the volume for an allocation on the node
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	index++
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, index, []*structs.Allocation{alloc}))
+	claim := &structs.CSIVolumeClaim{
+		AllocationID:   alloc.ID,
+		NodeID:         node.ID,
+		Mode:           structs.CSIVolumeClaimRead,
+		AccessMode:     structs.CSIVolumeAccessModeMultiNodeReader,
+		AttachmentMode: structs.CSIVolumeAttachmentModeFilesystem,
+		State:          structs.CSIVolumeClaimStateTaken,
+	}
+	index++
+	require.NoError(t, state.CSIVolumeClaim(index, ns, volID, claim))
+
+	// Registering the volume again with the same capacity leaves it alone,
+	// but is an error because the volume is in use
+	req1.Volumes = []*structs.CSIVolume{vol.Copy()}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", req1, resp1)
+	require.EqualError(t, err, fmt.Sprintf("volume exists: %s", volID))
+	require.Equal(t, 0, fake.NodeExpandCount)
+
+	// Nothing is expanded if another volume in the request is invalid
+	expanded := vol.Copy()
+	expanded.RequestedCapacityMin = 2048
+	invalid := vol.Copy()
+	invalid.ID = uuid.Generate()
+	invalid.PluginID = "unknown"
+	req1.Volumes = []*structs.CSIVolume{expanded, invalid}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", req1, resp1)
+	require.Error(t, err)
+	require.Equal(t, 0, fake.NodeExpandCount)
+
+	got, err := state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), got.Capacity)
+
+	// Registering the volume with a larger capacity expands it on the
+	// controller and on the node where it's claimed
+	expanded = vol.Copy()
+	expanded.RequestedCapacityMin = 2048
+	req1.Volumes = []*structs.CSIVolume{expanded}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", req1, resp1)
+	require.NoError(t, err)
+	require.Equal(t, 1, fake.NodeExpandCount)
+
+	got, err = state.CSIVolumeByID(nil, ns, volID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2048), got.Capacity)
+	require.Equal(t, int64(2048), got.RequestedCapacityMin)
+	require.Len(t, got.ReadClaims, 1)
+
+	// Plugins that can't expand volumes return an error
+	node.CSIControllerPlugins["minnie"].ControllerInfo.SupportsExpand = false
+	index++
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, node))
+	expanded = vol.Copy()
+	expanded.RequestedCapacityMin = 4096
+	req1.Volumes = []*structs.CSIVolume{expanded}
+	err = msgpackrpc.CallWithCodec(codec, "CSIVolume.Register", req1, resp1)
+	require.EqualError(t, err, "plugin minnie does not support expanding volumes")
+}
+
 func TestCSIVolumeEndpoint_Delete(t *testing.T) {
 	t.Parallel()
 	var err error
@@ -120,6 +120,49 @@ func (d *Deployment) Fail(args *structs.DeploymentFailRequest, reply *structs.De
 	return d.srv.deploymentWatcher.FailDeployment(args, reply)
 }
 
+// Rollback is used to roll back a blue/green deployment to the previous
+// allocations it retains
+func (d *Deployment) Rollback(args *structs.DeploymentRollbackRequest, reply *structs.DeploymentUpdateResponse) error {
+	if done, err := d.srv.forward("Deployment.Rollback", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "deployment", "rollback"}, time.Now())
+
+	// Validate the arguments
+	if args.DeploymentID == "" {
+		return fmt.Errorf("missing deployment ID")
+	}
+
+	// Lookup the deployment
+	snap, err := d.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	ws := memdb.NewWatchSet()
+	deploy, err := snap.DeploymentByID(ws, args.DeploymentID)
+	if err != nil {
+		return err
+	}
+	if deploy == nil {
+		return fmt.Errorf("deployment not found")
+	}
+
+	// Check namespace submit-job permissions
+	if aclObj, err := d.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(deploy.Namespace, acl.NamespaceCapabilitySubmitJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	if !deploy.Active() {
+		return structs.ErrDeploymentTerminalNoRollback
+	}
+
+	// Call into the deployment watcher
+	return d.srv.deploymentWatcher.RollbackDeployment(args, reply)
+}
+
 // Pause is used to pause a deployment
 func (d *Deployment) Pause(args *structs.DeploymentPauseRequest, reply *structs.DeploymentUpdateResponse) error {
 	if done, err := d.srv.forward("Deployment.Pause", args, args, reply); done {
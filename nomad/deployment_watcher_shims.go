@@ -50,6 +50,11 @@ func (d *deploymentWatcherRaftShim) UpdateDeploymentAllocHealth(req *structs.App
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
 }
 
+func (d *deploymentWatcherRaftShim) UpdateDeploymentCanaryStep(req *structs.ApplyDeploymentCanaryStepRequest) (uint64, error) {
+	fsmErrIntf, index, raftErr := d.apply(structs.DeploymentCanaryStepRequestType, req)
+	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
+}
+
 func (d *deploymentWatcherRaftShim) UpdateAllocDesiredTransition(req *structs.AllocUpdateDesiredTransitionRequest) (uint64, error) {
 	fsmErrIntf, index, raftErr := d.apply(structs.AllocUpdateDesiredTransitionRequestType, req)
 	return d.convertApplyErrors(fsmErrIntf, index, raftErr)
@@ -0,0 +1,231 @@
+package deploymentwatcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// analysisQueryTimeout is the timeout for a single deployment analysis
+	// query
+	analysisQueryTimeout = 10 * time.Second
+
+	// analysisMaxResponseSize limits how much of a query response is read
+	analysisMaxResponseSize = 1 << 20
+)
+
+// analysisHTTPClient is the client used to run deployment analysis queries
+var analysisHTTPClient = &http.Client{Timeout: analysisQueryTimeout}
+
+// analysisResult is the outcome of analyzing a deployment, sent back to the
+// deployment's watch loop
+type analysisResult struct {
+	fail     bool
+	rollback bool
+}
+
+// analysisVars are the values available to the analysis query template
+type analysisVars struct {
+	Namespace    string
+	JobID        string
+	JobVersion   uint64
+	TaskGroup    string
+	DeploymentID string
+}
+
+// analysisInterval returns the shortest analysis interval of the job's task
+// groups, or zero if none of them define an analysis.
+func analysisInterval(job *structs.Job) time.Duration {
+	var interval time.Duration
+	for _, tg := range job.TaskGroups {
+		if tg.Update == nil || tg.Update.Analysis == nil {
+			continue
+		}
+		if i := tg.Update.Analysis.Interval; interval == 0 || i < interval {
+			interval = i
+		}
+	}
+	return interval
+}
+
+// analyzeDeployment runs the analysis queries of the task groups that have
+// allocations waiting to be marked healthy, skipping groups whose interval has
+// not passed since their last run. It returns whether any analysis failed and
+// whether the deployment should be rolled back.
+func (w *deploymentWatcher) analyzeDeployment(allocs []*structs.AllocListStub, lastRun map[string]time.Time) (fail, rollback bool) {
+	d := w.getDeployment()
+	if d == nil || d.Status != structs.DeploymentStatusRunning {
+		return false, false
+	}
+
+	// Analysis only runs while allocations are waiting out their minimum
+	// healthy time
+	waiting := make(map[string]bool)
+	for _, alloc := range allocs {
+		if alloc.ClientStatus == structs.AllocClientStatusRunning && !alloc.DeploymentStatus.HasHealth() {
+			waiting[alloc.TaskGroup] = true
+		}
+	}
+
+	now := time.Now()
+	for group := range waiting {
+		dstate, ok := d.TaskGroups[group]
+		if !ok {
+			continue
+		}
+		tg := w.j.LookupTaskGroup(group)
+		if tg == nil || tg.Update == nil || tg.Update.Analysis == nil {
+			continue
+		}
+		analysis := tg.Update.Analysis
+		if last, ok := lastRun[group]; ok && now.Sub(last) < analysis.Interval {
+			continue
+		}
+		lastRun[group] = now
+
+		// The job may have been registered before the address was removed
+		// from the server's configuration
+		if !analysis.AddressAllowed(w.analysisAddresses) {
+			w.logger.Warn("deployment analysis address is not allowed by the server configuration",
+				"task_group", group, "address", analysis.Address)
+			continue
+		}
+
+		vars := &analysisVars{
+			Namespace:    d.Namespace,
+			JobID:        d.JobID,
+			JobVersion:   d.JobVersion,
+			TaskGroup:    group,
+			DeploymentID: d.ID,
+		}
+		values, err := queryAnalysis(w.ctx, analysisHTTPClient, analysis, vars)
+		if err != nil {
+			// Errors reaching the metrics endpoint do not fail the deployment
+			w.logger.Warn("failed to run deployment analysis", "task_group", group, "error", err)
+			continue
+		}
+
+		for _, value := range values {
+			// A NaN result, such as a ratio of two zero rates, means there
+			// is no data to judge the deployment by
+			if math.IsNaN(value) {
+				continue
+			}
+			passed, err := analysis.Compare(value)
+			if err != nil {
+				w.logger.Error("failed to compare deployment analysis result", "task_group", group, "error", err)
+				break
+			}
+			if !passed {
+				w.logger.Info("deployment analysis failed", "task_group", group,
+					"value", value, "operator", analysis.Operator, "threshold", analysis.Threshold)
+				fail = true
+				rollback = rollback || dstate.AutoRevert
+				break
+			}
+		}
+	}
+
+	return fail, rollback
+}
+
+// queryAnalysis renders the analysis query and evaluates it against the
+// Prometheus compatible HTTP API, returning the values of the result.
+func queryAnalysis(ctx context.Context, client *http.Client, analysis *structs.DeploymentAnalysis, vars *analysisVars) ([]float64, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(analysis.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %v", err)
+	}
+	var query strings.Builder
+	if err := tmpl.Execute(&query, vars); err != nil {
+		return nil, fmt.Errorf("failed to render query: %v", err)
+	}
+
+	u, err := url.Parse(analysis.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse address: %v", err)
+	}
+	u.Path = path.Join(u.Path, "/api/v1/query")
+	u.RawQuery = url.Values{"query": []string{query.String()}}.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Status    string `json:"status"`
+		ErrorType string `json:"errorType"`
+		Error     string `json:"error"`
+		Data      struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, analysisMaxResponseSize)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response with status %d: %v", resp.StatusCode, err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query failed with status %d: %s: %s", resp.StatusCode, result.ErrorType, result.Error)
+	}
+
+	switch result.Data.ResultType {
+	case "scalar":
+		var sample []interface{}
+		if err := json.Unmarshal(result.Data.Result, &sample); err != nil {
+			return nil, fmt.Errorf("failed to decode scalar result: %v", err)
+		}
+		value, err := sampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{value}, nil
+	case "vector":
+		var vector []struct {
+			Value []interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(result.Data.Result, &vector); err != nil {
+			return nil, fmt.Errorf("failed to decode vector result: %v", err)
+		}
+		values := make([]float64, 0, len(vector))
+		for _, sample := range vector {
+			value, err := sampleValue(sample.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q", result.Data.ResultType)
+	}
+}
+
+// sampleValue returns the value of a [timestamp, "value"] sample
+func sampleValue(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return 0, fmt.Errorf("invalid sample: %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value: %v", sample[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,225 @@
+package deploymentwatcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	mocker "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrometheus imitates the query endpoint of the Prometheus HTTP API,
+// recording the queries it receives and answering with the given body.
+type testPrometheus struct {
+	*httptest.Server
+
+	l       sync.Mutex
+	queries []string
+}
+
+func newTestPrometheus(t *testing.T, status int, body string) *testPrometheus {
+	p := &testPrometheus{}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		p.l.Lock()
+		p.queries = append(p.queries, r.URL.Query().Get("query"))
+		p.l.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *testPrometheus) Queries() []string {
+	p.l.Lock()
+	defer p.l.Unlock()
+	return append([]string(nil), p.queries...)
+}
+
+func TestQueryAnalysis(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		expected []float64
+		err      string
+	}{
+		{
+			name:     "vector",
+			status:   http.StatusOK,
+			body:     `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"code":"500"},"value":[1600000000.1,"0.25"]},{"metric":{"code":"503"},"value":[1600000000.1,"1"]}]}}`,
+			expected: []float64{0.25, 1},
+		},
+		{
+			name:     "empty vector",
+			status:   http.StatusOK,
+			body:     `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			expected: []float64{},
+		},
+		{
+			name:     "scalar",
+			status:   http.StatusOK,
+			body:     `{"status":"success","data":{"resultType":"scalar","result":[1600000000.1,"42"]}}`,
+			expected: []float64{42},
+		},
+		{
+			name:   "matrix",
+			status: http.StatusOK,
+			body:   `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			err:    `unsupported result type "matrix"`,
+		},
+		{
+			name:   "error",
+			status: http.StatusBadRequest,
+			body:   `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			err:    "query failed with status 400: bad_data: parse error",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newTestPrometheus(t, tc.status, tc.body)
+			analysis := &structs.DeploymentAnalysis{
+				Address: p.URL,
+				Query:   `errors{job="{{.JobID}}",group="{{.TaskGroup}}",version="{{.JobVersion}}"}`,
+			}
+			vars := &analysisVars{JobID: "example", TaskGroup: "web", JobVersion: 2}
+
+			values, err := queryAnalysis(context.Background(), http.DefaultClient, analysis, vars)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, values)
+			}
+			require.Equal(t, []string{`errors{job="example",group="web",version="2"}`}, p.Queries())
+		})
+	}
+}
+
+func TestWatcher_AnalysisFailsDeployment(t *testing.T) {
+	t.Parallel()
+	w, m := defaultTestDeploymentWatcher(t)
+
+	// The error rate is above the threshold
+	p := newTestPrometheus(t, http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000.1,"0.3"]}]}}`)
+	w.analysisAddresses = []string{p.URL + "/"}
+
+	upd := structs.DefaultUpdateStrategy.Copy()
+	upd.MinHealthyTime = time.Minute
+	upd.Analysis = &structs.DeploymentAnalysis{
+		Address:   p.URL,
+		Query:     `sum(rate(http_errors{job="{{.JobID}}"}[1m]))`,
+		Operator:  "<",
+		Threshold: 0.1,
+		Interval:  time.Second,
+	}
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = upd
+	d := mock.Deployment()
+	d.JobID = j.ID
+
+	// The allocation is running but hasn't been marked healthy yet
+	a := mock.Alloc()
+	a.Job = j
+	a.JobID = j.ID
+	a.DeploymentID = d.ID
+	a.ClientStatus = structs.AllocClientStatusRunning
+
+	require.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j), "UpsertJob")
+	require.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+	require.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), []*structs.Allocation{a}), "UpsertAllocs")
+
+	m.Mock.ExpectedCalls = nil
+	matchConfig := &matchDeploymentStatusUpdateConfig{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusFailed,
+		StatusDescription: structs.DeploymentStatusDescriptionFailedAnalysis,
+		Eval:              true,
+	}
+	matcher := matchDeploymentStatusUpdateRequest(matchConfig)
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matcher)).Return(nil)
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
+		func(err error) { require.Equal(t, 1, watchersCount(w), "Should have 1 deployment") })
+
+	testutil.WaitForResult(func() (bool, error) {
+		d, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		if d.Status != structs.DeploymentStatusFailed {
+			return false, fmt.Errorf("expected deployment to fail: %q", d.Status)
+		}
+		return true, nil
+	}, func(err error) { require.NoError(t, err) })
+
+	d, err := m.state.DeploymentByID(nil, d.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.DeploymentStatusDescriptionFailedAnalysis, d.StatusDescription)
+	require.Contains(t, p.Queries(), fmt.Sprintf(`sum(rate(http_errors{job="%s"}[1m]))`, j.ID))
+}
+
+func TestWatcher_AnalyzeDeployment_AddressNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	// The error rate is above the threshold
+	p := newTestPrometheus(t, http.StatusOK,
+		`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000.1,"0.3"]}]}}`)
+
+	j := mock.Job()
+	j.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	j.TaskGroups[0].Update.Analysis = &structs.DeploymentAnalysis{
+		Address:   p.URL,
+		Query:     "errors",
+		Operator:  "<",
+		Threshold: 0.1,
+		Interval:  time.Second,
+	}
+	d := mock.Deployment()
+	d.JobID = j.ID
+
+	a := mock.Alloc()
+	a.DeploymentID = d.ID
+	a.ClientStatus = structs.AllocClientStatusRunning
+	allocs := []*structs.AllocListStub{a.Stub(nil)}
+
+	w := &deploymentWatcher{
+		ctx:               context.Background(),
+		logger:            testlog.HCLogger(t),
+		d:                 d,
+		j:                 j,
+		analysisAddresses: []string{"http://prometheus.example:9090"},
+	}
+
+	// The metrics server isn't allowed so it is never queried
+	fail, _ := w.analyzeDeployment(allocs, map[string]time.Time{})
+	require.False(t, fail)
+	require.Empty(t, p.Queries())
+
+	// Once allowed the analysis fails the deployment
+	w.analysisAddresses = append(w.analysisAddresses, p.URL)
+	fail, _ = w.analyzeDeployment(allocs, map[string]time.Time{})
+	require.True(t, fail)
+	require.Len(t, p.Queries(), 1)
+}
@@ -50,6 +50,10 @@ type deploymentTriggers interface {
 	// upsertDeploymentAllocHealth is used to set the health of allocations in a
 	// deployment
 	upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
+
+	// upsertDeploymentCanaryStep is used to move a deployment through its
+	// canary steps
+	upsertDeploymentCanaryStep(req *structs.ApplyDeploymentCanaryStepRequest) (uint64, error)
 }
 
 // deploymentWatcher is used to watch a single deployment and trigger the
@@ -98,6 +102,9 @@ type deploymentWatcher struct {
 	// by holding the lock or using the setter and getter methods.
 	latestEval uint64
 
+	// analysisAddresses are the metrics servers deployment analysis may query
+	analysisAddresses []string
+
 	logger log.Logger
 	ctx    context.Context
 	exitFn context.CancelFunc
@@ -109,7 +116,8 @@ type deploymentWatcher struct {
 func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 	logger log.Logger, state *state.StateStore, d *structs.Deployment,
 	j *structs.Job, triggers deploymentTriggers,
-	deploymentRPC DeploymentRPC, jobRPC JobRPC) *deploymentWatcher {
+	deploymentRPC DeploymentRPC, jobRPC JobRPC,
+	analysisAddresses []string) *deploymentWatcher {
 
 	ctx, exitFn := context.WithCancel(parent)
 	w := &deploymentWatcher{
@@ -122,6 +130,7 @@ func newDeploymentWatcher(parent context.Context, queryLimiter *rate.Limiter,
 		deploymentTriggers: triggers,
 		DeploymentRPC:      deploymentRPC,
 		JobRPC:             jobRPC,
+		analysisAddresses:  analysisAddresses,
 		logger:             logger.With("deployment_id", d.ID, "job", j.NamespacedID()),
 		ctx:                ctx,
 		exitFn:             exitFn,
@@ -261,6 +270,7 @@ func (w *deploymentWatcher) PromoteDeployment(
 	areq := &structs.ApplyDeploymentPromoteRequest{
 		DeploymentPromoteRequest: *req,
 		Eval:                     w.getEval(),
+		PromotedAt:               time.Now(),
 	}
 
 	index, err := w.upsertDeploymentPromotion(areq)
@@ -290,6 +300,13 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 			return nil
 		}
 
+		// Progressive deployments are promoted once the pause of their last
+		// canary step has passed
+		if len(dstate.CanarySteps) != 0 && (!dstate.LastCanaryStep() ||
+			dstate.NextCanaryStepAt.IsZero() || time.Now().Before(dstate.NextCanaryStepAt)) {
+			return nil
+		}
+
 		// Find the health status of each canary
 		for _, c := range dstate.PlacedCanaries {
 			for _, a := range allocs {
@@ -304,10 +321,128 @@ func (w *deploymentWatcher) autoPromoteDeployment(allocs []*structs.AllocListStu
 	_, err := w.upsertDeploymentPromotion(&structs.ApplyDeploymentPromoteRequest{
 		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{DeploymentID: d.GetID(), All: true},
 		Eval:                     w.getEval(),
+		PromotedAt:               time.Now(),
 	})
 	return err
 }
 
+// canaryStepDeployment moves the task groups of a progressive deployment
+// through their canary steps. Once the canaries of a step are healthy, the end
+// of the step's pause is recorded. Once the pause has passed, the group
+// advances to the next step and an evaluation places its canaries. The last
+// step waits for the deployment to be promoted.
+func (w *deploymentWatcher) canaryStepDeployment(allocs []*structs.AllocListStub) error {
+	d, err := w.state.DeploymentByID(nil, w.deploymentID)
+	if err != nil || d == nil || d.Status != structs.DeploymentStatusRunning {
+		return err
+	}
+
+	now := time.Now()
+	advance := false
+	var updates []*structs.DeploymentCanaryStepUpdate
+	for name, dstate := range d.TaskGroups {
+		step := dstate.CurrentCanaryStep()
+		if step == nil || dstate.Promoted || !canariesHealthy(dstate, allocs) {
+			continue
+		}
+
+		u := &structs.DeploymentCanaryStepUpdate{
+			TaskGroup:        name,
+			CanaryStep:       dstate.CanaryStep,
+			DesiredCanaries:  dstate.DesiredCanaries,
+			NextCanaryStepAt: dstate.NextCanaryStepAt,
+		}
+		if u.NextCanaryStepAt.IsZero() {
+			u.NextCanaryStepAt = now.Add(step.Pause)
+		}
+
+		tg := w.j.LookupTaskGroup(name)
+		if tg == nil || dstate.LastCanaryStep() || now.Before(u.NextCanaryStepAt) {
+			if dstate.NextCanaryStepAt.IsZero() {
+				updates = append(updates, u)
+			}
+			continue
+		}
+
+		u.CanaryStep++
+		u.DesiredCanaries = dstate.CanarySteps[u.CanaryStep].Canaries(tg.Count)
+		u.NextCanaryStepAt = time.Time{}
+
+		// reset the progress deadline, since the canaries of the new step
+		// must become healthy
+		if dstate.ProgressDeadline > 0 && !dstate.RequireProgressBy.IsZero() {
+			u.RequireProgressBy = now.Add(dstate.ProgressDeadline)
+		}
+		updates = append(updates, u)
+		advance = true
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	req := &structs.ApplyDeploymentCanaryStepRequest{
+		DeploymentID: d.ID,
+		Updates:      updates,
+	}
+	if advance {
+		req.Eval = w.getEval()
+	}
+	_, err = w.upsertDeploymentCanaryStep(req)
+	return err
+}
+
+// nextCanaryStepAt returns the earliest time a group of the deployment can
+// leave its canary step, or zero if no group is waiting for a step's pause.
+func nextCanaryStepAt(d *structs.Deployment) time.Time {
+	var next time.Time
+	for _, dstate := range d.TaskGroups {
+		if dstate.Promoted || len(dstate.CanarySteps) == 0 || dstate.NextCanaryStepAt.IsZero() {
+			continue
+		}
+		if next.IsZero() || dstate.NextCanaryStepAt.Before(next) {
+			next = dstate.NextCanaryStepAt
+		}
+	}
+	return next
+}
+
+// retainUntil returns the earliest time a group of a blue/green deployment
+// stops retaining its previous allocations, or zero if no group retains them.
+func retainUntil(d *structs.Deployment) time.Time {
+	var until time.Time
+	now := time.Now()
+	for _, dstate := range d.TaskGroups {
+		if !dstate.Retaining(now) {
+			continue
+		}
+		if until.IsZero() || dstate.RetainUntil.Before(until) {
+			until = dstate.RetainUntil
+		}
+	}
+	return until
+}
+
+// canariesHealthy returns whether all the canaries of a group have been placed
+// and are healthy.
+func canariesHealthy(dstate *structs.DeploymentState, allocs []*structs.AllocListStub) bool {
+	if dstate.DesiredCanaries == 0 || dstate.DesiredCanaries != len(dstate.PlacedCanaries) {
+		return false
+	}
+
+	healthy := make(map[string]struct{}, len(allocs))
+	for _, a := range allocs {
+		if a.DeploymentStatus.IsHealthy() {
+			healthy[a.ID] = struct{}{}
+		}
+	}
+	for _, c := range dstate.PlacedCanaries {
+		if _, ok := healthy[c]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (w *deploymentWatcher) PauseDeployment(
 	req *structs.DeploymentPauseRequest,
 	resp *structs.DeploymentUpdateResponse) error {
@@ -346,13 +481,7 @@ func (w *deploymentWatcher) FailDeployment(
 	status, desc := structs.DeploymentStatusFailed, structs.DeploymentStatusDescriptionFailedByUser
 
 	// Determine if we should rollback
-	rollback := false
-	for _, dstate := range w.getDeployment().TaskGroups {
-		if dstate.AutoRevert {
-			rollback = true
-			break
-		}
-	}
+	rollback := w.autoRevert()
 
 	var rollbackJob *structs.Job
 	if rollback {
@@ -388,6 +517,66 @@ func (w *deploymentWatcher) FailDeployment(
 	return nil
 }
 
+// RollbackDeployment fails a blue/green deployment that retains its previous
+// allocations, switching back to them and reverting the job to its latest
+// stable version.
+func (w *deploymentWatcher) RollbackDeployment(
+	req *structs.DeploymentRollbackRequest,
+	resp *structs.DeploymentUpdateResponse) error {
+
+	retaining := false
+	now := time.Now()
+	for _, dstate := range w.getDeployment().TaskGroups {
+		if dstate.Retaining(now) {
+			retaining = true
+			break
+		}
+	}
+	if !retaining {
+		return fmt.Errorf("deployment %q is not retaining previous allocations to roll back to", w.deploymentID)
+	}
+
+	desc := structs.DeploymentStatusDescriptionRolledBackByUser
+	rollbackJob, err := w.latestStableJob()
+	if err != nil {
+		return err
+	}
+	if rollbackJob != nil {
+		rollbackJob, desc = w.handleRollbackValidity(rollbackJob, desc)
+	} else {
+		desc = structs.DeploymentStatusDescriptionNoRollbackTarget(desc)
+	}
+
+	// Commit the change
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusFailed, desc)
+	eval := w.getEval()
+	i, err := w.upsertDeploymentStatusUpdate(update, eval, rollbackJob)
+	if err != nil {
+		return err
+	}
+
+	// Build the response
+	resp.EvalID = eval.ID
+	resp.EvalCreateIndex = i
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
+	if rollbackJob != nil {
+		resp.RevertedJobVersion = helper.Uint64ToPtr(rollbackJob.Version)
+	}
+	return nil
+}
+
+// autoRevert returns whether any task group of the deployment reverts to the
+// latest stable job when the deployment fails.
+func (w *deploymentWatcher) autoRevert() bool {
+	for _, dstate := range w.getDeployment().TaskGroups {
+		if dstate.AutoRevert {
+			return true
+		}
+	}
+	return false
+}
+
 // StopWatch stops watching the deployment. This should be called whenever a
 // deployment is completed or the watcher is no longer needed.
 func (w *deploymentWatcher) StopWatch() {
@@ -415,11 +604,67 @@ func (w *deploymentWatcher) watch() {
 		deadlineTimer = time.NewTimer(time.Until(currentDeadline))
 	}
 
+	resetTimer := func(t *time.Timer, next time.Time) {
+		if next.IsZero() {
+			return
+		}
+		if !t.Stop() {
+			select {
+			case <-t.C:
+			default:
+			}
+		}
+		t.Reset(time.Until(next))
+	}
+
+	// The canary step timer fires when the pause of a canary step has passed
+	stepTimer := time.NewTimer(0)
+	if !stepTimer.Stop() {
+		<-stepTimer.C
+	}
+	resetStepTimer := func() {
+		resetTimer(stepTimer, nextCanaryStepAt(w.getDeployment()))
+	}
+	resetStepTimer()
+
+	// The retain timer fires when a blue/green deployment stops retaining
+	// its previous allocations, so that they can be stopped
+	retainTimer := time.NewTimer(0)
+	if !retainTimer.Stop() {
+		<-retainTimer.C
+	}
+	resetRetainTimer := func() {
+		resetTimer(retainTimer, retainUntil(w.getDeployment()))
+	}
+	resetRetainTimer()
+
 	allocIndex := uint64(1)
 	allocsCh := w.getAllocsCh(allocIndex)
 	var updates *allocUpdates
 
-	rollback, deadlineHit := false, false
+	// Multiregion deployments poll their peer regions, since changes to the
+	// deployments of other regions don't trigger this watcher
+	var multiregionCh <-chan time.Time
+	if w.j.IsMultiregion() {
+		ticker := time.NewTicker(multiregionPollInterval)
+		defer ticker.Stop()
+		multiregionCh = ticker.C
+	}
+
+	// Groups with a deployment analysis query their metrics periodically.
+	// The queries run outside of the loop so a slow metrics server can't
+	// hold up the deployment, and only one analysis runs at a time.
+	var analysisCh <-chan time.Time
+	analysisRuns := make(map[string]time.Time)
+	analysisResultCh := make(chan analysisResult, 1)
+	analyzing := false
+	if interval := analysisInterval(w.j); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		analysisCh = ticker.C
+	}
+
+	rollback, deadlineHit, peerFailed, analysisFailed := false, false, false, false
 
 FAIL:
 	for {
@@ -483,8 +728,62 @@ FAIL:
 				}
 			}
 
+			// The deployment may be waiting for a canary step, or resumed
+			// after its step's pause passed
+			resetStepTimer()
+			resetRetainTimer()
+
+			err := w.nextRegion(w.getStatus())
+			if err != nil {
+				w.logger.Error("multiregion deployment error", "error", err)
+				peerFailed, rollback = true, w.autoRevert()
+				break FAIL
+			}
+
+		case <-stepTimer.C:
+			if updates == nil {
+				continue
+			}
+			if err := w.canaryStepDeployment(updates.allocs); err != nil {
+				w.logger.Error("failed to advance canary step", "error", err)
+			}
+			if err := w.autoPromoteDeployment(updates.allocs); err != nil {
+				w.logger.Error("failed to auto promote deployment", "error", err)
+			}
+
+		case <-retainTimer.C:
+			if _, err := w.createUpdate(nil, w.getEval()); err != nil {
+				w.logger.Error("failed to create evaluation for deployment", "error", err)
+			}
+
+		case <-analysisCh:
+			if updates == nil || analyzing {
+				continue
+			}
+			analyzing = true
+			go func(allocs []*structs.AllocListStub) {
+				fail, rback := w.analyzeDeployment(allocs, analysisRuns)
+				analysisResultCh <- analysisResult{fail: fail, rollback: rback}
+			}(updates.allocs)
+
+		case res := <-analysisResultCh:
+			analyzing = false
+			if !res.fail {
+				continue
+			}
+
+			analysisFailed, rollback = true, res.rollback
+			err := w.nextRegion(structs.DeploymentStatusFailed)
+			if err != nil {
+				w.logger.Error("multiregion deployment error", "error", err)
+			}
+			break FAIL
+
+		case <-multiregionCh:
 			err := w.nextRegion(w.getStatus())
 			if err != nil {
+				w.logger.Error("multiregion deployment error", "error", err)
+				peerFailed, rollback = true, w.autoRevert()
 				break FAIL
 			}
 
@@ -522,6 +821,12 @@ FAIL:
 				break FAIL
 			}
 
+			// Move a progressive deployment through its canary steps
+			err = w.canaryStepDeployment(updates.allocs)
+			if err != nil {
+				w.logger.Error("failed to advance canary step", "error", err)
+			}
+
 			// If permitted, automatically promote this canary deployment
 			err = w.autoPromoteDeployment(updates.allocs)
 			if err != nil {
@@ -540,8 +845,12 @@ FAIL:
 
 	// Change the deployments status to failed
 	desc := structs.DeploymentStatusDescriptionFailedAllocations
-	if deadlineHit {
+	if analysisFailed {
+		desc = structs.DeploymentStatusDescriptionFailedAnalysis
+	} else if deadlineHit {
 		desc = structs.DeploymentStatusDescriptionProgressDeadline
+	} else if peerFailed {
+		desc = structs.DeploymentStatusDescriptionFailedByPeer
 	}
 
 	// Rollback to the old job if necessary
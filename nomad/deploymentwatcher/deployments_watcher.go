@@ -49,6 +49,10 @@ type DeploymentRaftEndpoints interface {
 	// deployment
 	UpdateDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error)
 
+	// UpdateDeploymentCanaryStep is used to move a deployment through its
+	// canary steps
+	UpdateDeploymentCanaryStep(req *structs.ApplyDeploymentCanaryStepRequest) (uint64, error)
+
 	// UpdateAllocDesiredTransition is used to update the desired transition
 	// for allocations.
 	UpdateAllocDesiredTransition(req *structs.AllocUpdateDesiredTransitionRequest) (uint64, error)
@@ -81,6 +85,10 @@ type Watcher struct {
 	// server interface for Job RPCs
 	jobRPC JobRPC
 
+	// analysisAddresses are the metrics servers deployment analysis may
+	// query
+	analysisAddresses []string
+
 	// watchers is the set of active watchers, one per deployment
 	watchers map[string]*deploymentWatcher
 
@@ -102,12 +110,14 @@ func NewDeploymentsWatcher(logger log.Logger,
 	deploymentRPC DeploymentRPC, jobRPC JobRPC,
 	stateQueriesPerSecond float64,
 	updateBatchDuration time.Duration,
+	analysisAddresses []string,
 ) *Watcher {
 
 	return &Watcher{
 		raft:                raft,
 		deploymentRPC:       deploymentRPC,
 		jobRPC:              jobRPC,
+		analysisAddresses:   analysisAddresses,
 		queryLimiter:        rate.NewLimiter(rate.Limit(stateQueriesPerSecond), 100),
 		updateBatchDuration: updateBatchDuration,
 		logger:              logger.Named("deployments_watcher"),
@@ -269,7 +279,7 @@ func (w *Watcher) addLocked(d *structs.Deployment) (*deploymentWatcher, error) {
 	}
 
 	watcher := newDeploymentWatcher(w.ctx, w.queryLimiter, w.logger, w.state, d, job,
-		w, w.deploymentRPC, w.jobRPC)
+		w, w.deploymentRPC, w.jobRPC, w.analysisAddresses)
 	w.watchers[d.ID] = watcher
 	return watcher, nil
 }
@@ -375,6 +385,17 @@ func (w *Watcher) FailDeployment(req *structs.DeploymentFailRequest, resp *struc
 	return watcher.FailDeployment(req, resp)
 }
 
+// RollbackDeployment is used to roll back a blue/green deployment to the
+// previous allocations it retains.
+func (w *Watcher) RollbackDeployment(req *structs.DeploymentRollbackRequest, resp *structs.DeploymentUpdateResponse) error {
+	watcher, err := w.getOrCreateWatcher(req.DeploymentID)
+	if err != nil {
+		return err
+	}
+
+	return watcher.RollbackDeployment(req, resp)
+}
+
 // RunDeployment is used to run a pending multiregion deployment.  In
 // single-region deployments, the pending state is unused.
 func (w *Watcher) RunDeployment(req *structs.DeploymentRunRequest, resp *structs.DeploymentUpdateResponse) error {
@@ -447,3 +468,8 @@ func (w *Watcher) upsertDeploymentPromotion(req *structs.ApplyDeploymentPromoteR
 func (w *Watcher) upsertDeploymentAllocHealth(req *structs.ApplyDeploymentAllocHealthRequest) (uint64, error) {
 	return w.raft.UpdateDeploymentAllocHealth(req)
 }
+
+// upsertDeploymentCanaryStep commits the given canary step updates to Raft
+func (w *Watcher) upsertDeploymentCanaryStep(req *structs.ApplyDeploymentCanaryStepRequest) (uint64, error) {
+	return w.raft.UpdateDeploymentCanaryStep(req)
+}
@@ -19,7 +19,7 @@ import (
 
 func testDeploymentWatcher(t *testing.T, qps float64, batchDur time.Duration) (*Watcher, *mockBackend) {
 	m := newMockBackend(t)
-	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur)
+	w := NewDeploymentsWatcher(testlog.HCLogger(t), m, nil, nil, qps, batchDur, nil)
 	return w, m
 }
 
@@ -663,6 +663,118 @@ func TestWatcher_AutoPromoteDeployment(t *testing.T) {
 	require.False(t, b1.DeploymentStatus.Canary)
 }
 
+func TestWatcher_CanaryStepDeployment(t *testing.T) {
+	t.Parallel()
+	w, m := defaultTestDeploymentWatcher(t)
+	now := time.Now()
+
+	// Place half the group as canaries, then all of it for an hour
+	upd := structs.DefaultUpdateStrategy.Copy()
+	upd.AutoPromote = true
+	upd.CanarySteps = []*structs.CanaryStep{
+		{Percent: 50},
+		{Percent: 100, Pause: time.Hour},
+	}
+
+	j := mock.Job()
+	j.TaskGroups[0].Count = 4
+	j.TaskGroups[0].Update = upd
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.TaskGroups = map[string]*structs.DeploymentState{
+		"web": {
+			AutoPromote:       upd.AutoPromote,
+			ProgressDeadline:  upd.ProgressDeadline,
+			RequireProgressBy: now.Add(time.Minute),
+			CanarySteps:       upd.CanarySteps,
+			DesiredCanaries:   2,
+			DesiredTotal:      4,
+		},
+	}
+
+	canaries := func(n int) []*structs.Allocation {
+		allocs := make([]*structs.Allocation, n)
+		for i := range allocs {
+			a := mock.Alloc()
+			a.Job = j
+			a.JobID = j.ID
+			a.DeploymentID = d.ID
+			a.CreateTime = now.UnixNano()
+			a.ModifyTime = now.UnixNano()
+			a.DeploymentStatus = &structs.AllocDeploymentStatus{
+				Canary: true,
+			}
+			allocs[i] = a
+		}
+		return allocs
+	}
+	setHealthy := func(allocs []*structs.Allocation) {
+		req := &structs.DeploymentAllocHealthRequest{DeploymentID: d.ID}
+		for _, a := range allocs {
+			req.HealthyAllocationIDs = append(req.HealthyAllocationIDs, a.ID)
+		}
+		var resp structs.DeploymentUpdateResponse
+		require.NoError(t, w.SetAllocHealth(req, &resp))
+	}
+
+	first := canaries(2)
+	require.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j), "UpsertJob")
+	require.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+	require.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), first), "UpsertAllocs")
+
+	m.Mock.ExpectedCalls = nil
+	m.On("UpdateDeploymentAllocHealth", mocker.Anything).Return(nil)
+	m.On("UpdateDeploymentCanaryStep", mocker.Anything).Return(nil)
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
+		func(err error) { require.Equal(t, 1, watchersCount(w), "Should have 1 deployment") })
+
+	// The first step has no pause, so the healthy canaries advance the
+	// deployment to the second step
+	setHealthy(first)
+	testutil.WaitForResult(func() (bool, error) {
+		d, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		dstate := d.TaskGroups["web"]
+		if dstate.CanaryStep != 1 || dstate.DesiredCanaries != 4 {
+			return false, fmt.Errorf("expected step 1 with 4 canaries: %#v", dstate)
+		}
+		return true, nil
+	}, func(err error) { require.NoError(t, err) })
+
+	m.AssertCalled(t, "UpdateDeploymentCanaryStep", mocker.MatchedBy(func(req *structs.ApplyDeploymentCanaryStepRequest) bool {
+		return req.Eval != nil && req.Updates[0].CanaryStep == 1 &&
+			!req.Updates[0].RequireProgressBy.Before(now.Add(upd.ProgressDeadline))
+	}))
+
+	// The last step waits for its pause before the deployment is promoted
+	second := canaries(2)
+	require.NoError(t, m.state.UpsertAllocs(structs.MsgTypeTestSetup, m.nextIndex(), second), "UpsertAllocs")
+	setHealthy(second)
+	testutil.WaitForResult(func() (bool, error) {
+		d, err := m.state.DeploymentByID(nil, d.ID)
+		if err != nil {
+			return false, err
+		}
+		if d.TaskGroups["web"].NextCanaryStepAt.IsZero() {
+			return false, fmt.Errorf("expected the end of the pause to be set")
+		}
+		return true, nil
+	}, func(err error) { require.NoError(t, err) })
+
+	d, err := m.state.DeploymentByID(nil, d.ID)
+	require.NoError(t, err)
+	dstate := d.TaskGroups["web"]
+	require.Equal(t, 1, dstate.CanaryStep)
+	require.False(t, dstate.Promoted)
+	require.WithinDuration(t, time.Now().Add(time.Hour), dstate.NextCanaryStepAt, time.Minute)
+	m.AssertNotCalled(t, "UpdateDeploymentPromotion", mocker.Anything)
+}
+
 // Test pausing a deployment that is running
 func TestWatcher_PauseDeployment_Pause_Running(t *testing.T) {
 	t.Parallel()
@@ -868,6 +980,61 @@ func TestWatcher_FailDeployment_Running(t *testing.T) {
 	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(matcher))
 }
 
+func TestWatcher_RollbackDeployment(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	w, m := defaultTestDeploymentWatcher(t)
+
+	// Create a job and a deployment that isn't retaining previous allocations
+	j := mock.Job()
+	d := mock.Deployment()
+	d.JobID = j.ID
+	require.Nil(m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j), "UpsertJob")
+	require.Nil(m.state.UpsertDeployment(m.nextIndex(), d), "UpsertDeployment")
+
+	// require that we get a call to UpsertDeploymentStatusUpdate
+	matchConfig := &matchDeploymentStatusUpdateConfig{
+		DeploymentID:      d.ID,
+		Status:            structs.DeploymentStatusFailed,
+		StatusDescription: structs.DeploymentStatusDescriptionNoRollbackTarget(structs.DeploymentStatusDescriptionRolledBackByUser),
+		Eval:              true,
+	}
+	matcher := matchDeploymentStatusUpdateRequest(matchConfig)
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matcher)).Return(nil)
+
+	w.SetEnabled(true, m.state)
+	testutil.WaitForResult(func() (bool, error) { return 1 == watchersCount(w), nil },
+		func(err error) { require.Equal(1, watchersCount(w), "Should have 1 deployment") })
+
+	req := &structs.DeploymentRollbackRequest{
+		DeploymentID: d.ID,
+	}
+	var resp structs.DeploymentUpdateResponse
+	err := w.RollbackDeployment(req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not retaining previous allocations")
+	m.AssertNotCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(matcher))
+
+	// Promote the deployment and retain the previous allocations
+	d2 := d.Copy()
+	dstate := d2.TaskGroups["web"]
+	dstate.BlueGreen = true
+	dstate.Promoted = true
+	dstate.RetainPrevious = time.Hour
+	dstate.RetainUntil = time.Now().Add(time.Hour)
+	dstate.PreviousAllocs = []string{uuid.Generate()}
+	require.Nil(m.state.UpsertDeployment(m.nextIndex(), d2), "UpsertDeployment")
+
+	testutil.WaitForResult(func() (bool, error) {
+		err := w.RollbackDeployment(req, &resp)
+		return err == nil, err
+	}, func(err error) { require.NoError(err, "RollbackDeployment") })
+
+	require.Equal(1, watchersCount(w), "Deployment should still be active")
+	require.NotEmpty(resp.EvalID)
+	m.AssertCalled(t, "UpdateDeploymentStatus", mocker.MatchedBy(matcher))
+}
+
 // Tests that the watcher properly watches for allocation changes and takes the
 // proper actions
 func TestDeploymentWatcher_Watch_NoProgressDeadline(t *testing.T) {
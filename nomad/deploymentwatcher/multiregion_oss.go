@@ -2,26 +2,244 @@
 
 package deploymentwatcher
 
-import "github.com/hashicorp/nomad/nomad/structs"
+import (
+	"fmt"
+	"time"
 
-// DeploymentRPC and JobRPC hold methods for interacting with peer regions
-// in enterprise edition.
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// multiregionPollInterval is how often the watcher of a multiregion
+// deployment checks on the deployments of its peer regions.
+var multiregionPollInterval = 5 * time.Second
+
+// DeploymentRPC holds methods for interacting with peer regions. The state of
+// the deployments in peer regions is read with JobRPC, so no methods are
+// required.
 type DeploymentRPC interface{}
-type JobRPC interface{}
 
+// JobRPC holds methods for interacting with peer regions.
+type JobRPC interface {
+	LatestDeployment(*structs.JobSpecificRequest, *structs.SingleDeploymentResponse) error
+}
+
+// nextRegion advances a multiregion deployment given the status of the
+// deployment in this region. A pending deployment is run once the regions
+// ahead of it allow it, and a blocked deployment is unblocked once every
+// region has completed. An error is returned if a peer region failed in a way
+// that should fail the deployment in this region.
 func (w *deploymentWatcher) nextRegion(status string) error {
+	if !w.j.IsMultiregion() || w.JobRPC == nil {
+		return nil
+	}
+
+	switch status {
+	case structs.DeploymentStatusPending, structs.DeploymentStatusRunning,
+		structs.DeploymentStatusPaused, structs.DeploymentStatusBlocked:
+	default:
+		return nil
+	}
+
+	regions := w.j.Multiregion.Regions
+	local := -1
+	for i, region := range regions {
+		if region.Name == w.j.Region {
+			local = i
+			break
+		}
+	}
+	if local == -1 {
+		return nil
+	}
+
+	// Look up the deployment of this job version in every region. A nil
+	// deployment means the region hasn't created it yet.
+	deploys := make([]*structs.Deployment, len(regions))
+	for i, region := range regions {
+		if i == local {
+			deploys[i] = w.getDeployment()
+			continue
+		}
+		d, err := w.peerDeployment(region.Name)
+		if err != nil {
+			w.logger.Error("failed to lookup deployment in peer region", "region", region.Name, "error", err)
+			return nil
+		}
+		deploys[i] = d
+	}
+
+	if region := w.failedPeer(local, deploys); region != "" {
+		return fmt.Errorf("deployment failed in peer region %q", region)
+	}
+
+	switch status {
+	case structs.DeploymentStatusPending:
+		if !w.canRun(local, deploys) {
+			return nil
+		}
+		var resp structs.DeploymentUpdateResponse
+		return w.RunDeployment(&structs.DeploymentRunRequest{DeploymentID: w.deploymentID}, &resp)
+
+	case structs.DeploymentStatusBlocked:
+		for _, d := range deploys {
+			if d == nil || !multiregionComplete(d.Status) {
+				return nil
+			}
+		}
+		var resp structs.DeploymentUpdateResponse
+		return w.UnblockDeployment(&structs.DeploymentUnblockRequest{DeploymentID: w.deploymentID}, &resp)
+	}
 	return nil
 }
 
+// peerDeployment returns the deployment of the watched multiregion
+// registration in the given region, or nil if it doesn't exist yet.
+func (w *deploymentWatcher) peerDeployment(region string) (*structs.Deployment, error) {
+	req := &structs.JobSpecificRequest{
+		JobID: w.j.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    region,
+			Namespace: w.j.Namespace,
+			AuthToken: w.peerToken(),
+		},
+	}
+	var resp structs.SingleDeploymentResponse
+	if err := w.LatestDeployment(req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Deployment == nil {
+		return nil, nil
+	}
+
+	// The job versions of the regions diverge when the job is registered
+	// again in a single region, so deployments are matched on the ID of the
+	// multiregion registration. Deployments created before the ID existed
+	// fall back to matching the job version.
+	if id := w.getDeployment().MultiregionID; id != "" {
+		if resp.Deployment.MultiregionID != id {
+			return nil, nil
+		}
+	} else if resp.Deployment.JobVersion != w.j.Version {
+		return nil, nil
+	}
+	return resp.Deployment, nil
+}
+
+// peerToken returns the secret of the token the job was submitted with, which
+// is used to read the deployments of peer regions when ACLs are enabled.
+func (w *deploymentWatcher) peerToken() string {
+	if w.j.NomadTokenID == "" {
+		return ""
+	}
+	token, err := w.state.ACLTokenByAccessorID(nil, w.j.NomadTokenID)
+	if err != nil || token == nil {
+		return ""
+	}
+	return token.SecretID
+}
+
+// failedPeer returns the name of a peer region whose failed deployment should
+// fail the deployment in this region, or the empty string if there is none.
+// With on_failure "fail_all" any failed region fails every region, with
+// "fail_local" a failure only affects its own region, and by default a
+// failure fails the regions that haven't started their deployment yet.
+func (w *deploymentWatcher) failedPeer(local int, deploys []*structs.Deployment) string {
+	_, onFailure := w.multiregionStrategy()
+	if onFailure == structs.MultiregionOnFailureFailLocal {
+		return ""
+	}
+
+	for i, d := range deploys {
+		if i == local || d == nil || d.Status != structs.DeploymentStatusFailed {
+			continue
+		}
+		if onFailure == structs.MultiregionOnFailureFailAll || i < local {
+			return w.j.Multiregion.Regions[i].Name
+		}
+	}
+	return ""
+}
+
+// canRun returns whether the pending deployment in this region can be run.
+// The first max_parallel regions run immediately, and every other region runs
+// once the region max_parallel places ahead of it has completed.
+func (w *deploymentWatcher) canRun(local int, deploys []*structs.Deployment) bool {
+	parallel, onFailure := w.multiregionStrategy()
+	if parallel <= 0 || parallel > len(deploys) {
+		parallel = len(deploys)
+	}
+	if local < parallel {
+		return true
+	}
+
+	ahead := deploys[local-parallel]
+	if ahead == nil {
+		return false
+	}
+	if multiregionComplete(ahead.Status) {
+		return true
+	}
+	return ahead.Status == structs.DeploymentStatusFailed &&
+		onFailure == structs.MultiregionOnFailureFailLocal
+}
+
+// multiregionStrategy returns the max_parallel and on_failure of the job's
+// multiregion strategy.
+func (w *deploymentWatcher) multiregionStrategy() (int, string) {
+	if s := w.j.Multiregion.Strategy; s != nil {
+		return s.MaxParallel, s.OnFailure
+	}
+	return 0, ""
+}
+
+// multiregionComplete returns whether a deployment with the given status has
+// completed its placements in its region.
+func multiregionComplete(status string) bool {
+	switch status {
+	case structs.DeploymentStatusBlocked, structs.DeploymentStatusUnblocking,
+		structs.DeploymentStatusSuccessful:
+		return true
+	}
+	return false
+}
+
 // RunDeployment is used to run a pending multiregion deployment.  In
 // single-region deployments, the pending state is unused.
 func (w *deploymentWatcher) RunDeployment(req *structs.DeploymentRunRequest, resp *structs.DeploymentUpdateResponse) error {
+	if status := w.getStatus(); status != structs.DeploymentStatusPending {
+		return fmt.Errorf("can't run deployment with status %q", status)
+	}
+
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusRunning, structs.DeploymentStatusDescriptionRunning)
+	eval := w.getEval()
+	i, err := w.upsertDeploymentStatusUpdate(update, eval, nil)
+	if err != nil {
+		return err
+	}
+
+	resp.EvalID = eval.ID
+	resp.EvalCreateIndex = i
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
 	return nil
 }
 
 // UnblockDeployment is used to unblock a multiregion deployment.  In
 // single-region deployments, the blocked state is unused.
 func (w *deploymentWatcher) UnblockDeployment(req *structs.DeploymentUnblockRequest, resp *structs.DeploymentUpdateResponse) error {
+	if status := w.getStatus(); status != structs.DeploymentStatusBlocked &&
+		status != structs.DeploymentStatusUnblocking {
+		return fmt.Errorf("can't unblock deployment with status %q", status)
+	}
+
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusSuccessful, structs.DeploymentStatusDescriptionSuccessful)
+	i, err := w.upsertDeploymentStatusUpdate(update, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
 	return nil
 }
 
@@ -29,5 +247,17 @@ func (w *deploymentWatcher) UnblockDeployment(req *structs.DeploymentUnblockRequ
 // single-region deployments, the deploymentwatcher has sole responsibility to
 // cancel deployments so this RPC is never used.
 func (w *deploymentWatcher) CancelDeployment(req *structs.DeploymentCancelRequest, resp *structs.DeploymentUpdateResponse) error {
+	if d := w.getDeployment(); !d.Active() {
+		return fmt.Errorf("can't cancel terminal deployment")
+	}
+
+	update := w.getDeploymentStatusUpdate(structs.DeploymentStatusCancelled, structs.DeploymentStatusDescriptionCancelledByUser)
+	i, err := w.upsertDeploymentStatusUpdate(update, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp.DeploymentModifyIndex = i
+	resp.Index = i
 	return nil
 }
@@ -0,0 +1,196 @@
+// +build !ent
+
+package deploymentwatcher
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	mocker "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockJobRPC returns the deployments of peer regions
+type mockJobRPC struct {
+	deploys map[string]*structs.Deployment
+}
+
+func (m *mockJobRPC) LatestDeployment(args *structs.JobSpecificRequest, reply *structs.SingleDeploymentResponse) error {
+	reply.Deployment = m.deploys[args.Region]
+	return nil
+}
+
+// testMultiregionWatcher returns a watcher for the deployment of a job in
+// the "east" region, which is second of the regions west, east and north. The
+// watch loop isn't started so nextRegion can be called directly.
+func testMultiregionWatcher(t *testing.T, onFailure, status string, peers map[string]string) (*deploymentWatcher, *mockBackend) {
+	w, m := defaultTestDeploymentWatcher(t)
+
+	j := mock.MultiregionJob()
+	j.Region = "east"
+	j.Multiregion.Strategy.OnFailure = onFailure
+	j.Multiregion.Regions = append(j.Multiregion.Regions, &structs.MultiregionRegion{Name: "north"})
+	require.NoError(t, m.state.UpsertJob(structs.MsgTypeTestSetup, m.nextIndex(), j))
+
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobVersion = j.Version
+	d.Status = status
+	require.NoError(t, m.state.UpsertDeployment(m.nextIndex(), d))
+
+	rpc := &mockJobRPC{deploys: map[string]*structs.Deployment{}}
+	for region, status := range peers {
+		pd := d.Copy()
+		pd.Status = status
+		rpc.deploys[region] = pd
+	}
+
+	dw := &deploymentWatcher{
+		deploymentTriggers: w,
+		JobRPC:             rpc,
+		state:              m.state,
+		deploymentID:       d.ID,
+		d:                  d,
+		j:                  j,
+		logger:             testlog.HCLogger(t),
+	}
+	return dw, m
+}
+
+func TestDeploymentWatcher_Multiregion_Run(t *testing.T) {
+	t.Parallel()
+
+	// The region ahead of east is still running
+	w, m := testMultiregionWatcher(t, "", structs.DeploymentStatusPending, map[string]string{
+		"west": structs.DeploymentStatusRunning,
+	})
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusPending))
+	m.AssertNotCalled(t, "UpdateDeploymentStatus", mocker.Anything)
+
+	// The region ahead of east is complete
+	w, m = testMultiregionWatcher(t, "", structs.DeploymentStatusPending, map[string]string{
+		"west": structs.DeploymentStatusBlocked,
+	})
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(&matchDeploymentStatusUpdateConfig{
+		DeploymentID: w.deploymentID,
+		Status:       structs.DeploymentStatusRunning,
+		Eval:         true,
+	}))).Return(nil).Once()
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusPending))
+	m.AssertExpectations(t)
+
+	// With max_parallel 2 east doesn't wait for west
+	w, m = testMultiregionWatcher(t, "", structs.DeploymentStatusPending, nil)
+	w.j.Multiregion.Strategy.MaxParallel = 2
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(&matchDeploymentStatusUpdateConfig{
+		DeploymentID: w.deploymentID,
+		Status:       structs.DeploymentStatusRunning,
+		Eval:         true,
+	}))).Return(nil).Once()
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusPending))
+	m.AssertExpectations(t)
+}
+
+func TestDeploymentWatcher_Multiregion_Unblock(t *testing.T) {
+	t.Parallel()
+
+	// A peer region hasn't completed
+	w, m := testMultiregionWatcher(t, "", structs.DeploymentStatusBlocked, map[string]string{
+		"west":  structs.DeploymentStatusSuccessful,
+		"north": structs.DeploymentStatusRunning,
+	})
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusBlocked))
+	m.AssertNotCalled(t, "UpdateDeploymentStatus", mocker.Anything)
+
+	// Every region has completed
+	w, m = testMultiregionWatcher(t, "", structs.DeploymentStatusBlocked, map[string]string{
+		"west":  structs.DeploymentStatusSuccessful,
+		"north": structs.DeploymentStatusBlocked,
+	})
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(&matchDeploymentStatusUpdateConfig{
+		DeploymentID: w.deploymentID,
+		Status:       structs.DeploymentStatusSuccessful,
+	}))).Return(nil).Once()
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusBlocked))
+	m.AssertExpectations(t)
+}
+
+func TestDeploymentWatcher_Multiregion_PeerFailed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		onFailure string
+		peers     map[string]string
+		fail      bool
+	}{
+		{
+			name:      "default fails regions after the failed region",
+			onFailure: "",
+			peers:     map[string]string{"west": structs.DeploymentStatusFailed},
+			fail:      true,
+		},
+		{
+			name:      "default ignores regions after this region",
+			onFailure: "",
+			peers:     map[string]string{"north": structs.DeploymentStatusFailed},
+			fail:      false,
+		},
+		{
+			name:      "fail_all fails every region",
+			onFailure: structs.MultiregionOnFailureFailAll,
+			peers:     map[string]string{"north": structs.DeploymentStatusFailed},
+			fail:      true,
+		},
+		{
+			name:      "fail_local only fails the failed region",
+			onFailure: structs.MultiregionOnFailureFailLocal,
+			peers:     map[string]string{"west": structs.DeploymentStatusFailed},
+			fail:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, _ := testMultiregionWatcher(t, tc.onFailure, structs.DeploymentStatusRunning, tc.peers)
+			err := w.nextRegion(structs.DeploymentStatusRunning)
+			if tc.fail {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeploymentWatcher_Multiregion_VersionsDiverged(t *testing.T) {
+	t.Parallel()
+
+	// The job was registered again in west alone, so the deployment of the
+	// multiregion registration there is for a later job version
+	w, m := testMultiregionWatcher(t, "", structs.DeploymentStatusPending, map[string]string{
+		"west": structs.DeploymentStatusBlocked,
+	})
+	w.d.MultiregionID = "registration"
+	w.JobRPC.(*mockJobRPC).deploys["west"].JobVersion = w.j.Version + 1
+	w.JobRPC.(*mockJobRPC).deploys["west"].MultiregionID = "registration"
+	m.On("UpdateDeploymentStatus", mocker.MatchedBy(matchDeploymentStatusUpdateRequest(&matchDeploymentStatusUpdateConfig{
+		DeploymentID: w.deploymentID,
+		Status:       structs.DeploymentStatusRunning,
+		Eval:         true,
+	}))).Return(nil).Once()
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusPending))
+	m.AssertExpectations(t)
+
+	// The deployment in west has the same job version but belongs to another
+	// multiregion registration
+	w, m = testMultiregionWatcher(t, "", structs.DeploymentStatusPending, map[string]string{
+		"west": structs.DeploymentStatusBlocked,
+	})
+	w.d.MultiregionID = "registration"
+	w.JobRPC.(*mockJobRPC).deploys["west"].MultiregionID = "other"
+	require.NoError(t, w.nextRegion(structs.DeploymentStatusPending))
+	m.AssertNotCalled(t, "UpdateDeploymentStatus", mocker.Anything)
+}
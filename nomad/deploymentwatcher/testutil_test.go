@@ -173,6 +173,11 @@ func matchDeploymentPromoteRequest(c *matchDeploymentPromoteRequestConfig) func(
 			return false
 		}
 
+		// The leader sets the promotion time
+		if args.PromotedAt.IsZero() {
+			return false
+		}
+
 		return true
 	}
 }
@@ -182,6 +187,12 @@ func (m *mockBackend) UpdateDeploymentAllocHealth(req *structs.ApplyDeploymentAl
 	return i, m.state.UpdateDeploymentAllocHealth(structs.MsgTypeTestSetup, i, req)
 }
 
+func (m *mockBackend) UpdateDeploymentCanaryStep(req *structs.ApplyDeploymentCanaryStepRequest) (uint64, error) {
+	m.Called(req)
+	i := m.nextIndex()
+	return i, m.state.UpdateDeploymentCanaryStep(structs.MsgTypeTestSetup, i, req)
+}
+
 // matchDeploymentAllocHealthRequestConfig is used to configure the matching
 // function
 type matchDeploymentAllocHealthRequestConfig struct {
@@ -0,0 +1,256 @@
+package nomad
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// DrainBatch is the server RPC endpoint for drain batches
+type DrainBatch struct {
+	srv    *Server
+	logger log.Logger
+}
+
+const drainBatchTable = "drain_batches"
+
+// Create creates a drain batch of the nodes matching its filter. The leader
+// drains the nodes of the batch within its concurrency limits.
+func (b *DrainBatch) Create(args *structs.DrainBatchCreateRequest, reply *structs.DrainBatchCreateResponse) error {
+	if done, err := b.srv.forward("DrainBatch.Create", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "drain_batch", "create"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := b.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Batch == nil {
+		return fmt.Errorf("missing drain batch")
+	}
+	batch := args.Batch.Copy()
+	if err := batch.Validate(); err != nil {
+		return err
+	}
+
+	snap, err := b.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	nodes, err := drainBatchNodes(snap, batch.Filter)
+	if err != nil {
+		return err
+	}
+	if len(nodes) == 0 {
+		return fmt.Errorf("no nodes that aren't down or draining match the filter")
+	}
+
+	now := time.Now().UTC().UnixNano()
+	batch.ID = uuid.Generate()
+	batch.Nodes = nodes
+	batch.Status = structs.DrainBatchStatusRunning
+	batch.StatusDescription = structs.DrainBatchStatusDescriptionRunning
+	batch.CreateTime = now
+	batch.ModifyTime = now
+
+	req := &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{batch},
+		WriteRequest: args.WriteRequest,
+	}
+	fsmErr, index, err := b.srv.raftApply(structs.DrainBatchUpsertRequestType, req)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		b.logger.Error("drain batch create failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		b.logger.Error("drain batch create failed", "error", err, "raft", true)
+		return err
+	}
+
+	reply.Batch = batch
+	reply.Index = index
+	return nil
+}
+
+// drainBatchNodes returns the nodes matching the filter that aren't down or
+// already draining, ordered by datacenter so that a batch limited across
+// datacenters drains them one datacenter at a time.
+func drainBatchNodes(snap *state.StateSnapshot, filter *structs.DrainBatchFilter) ([]*structs.DrainBatchNode, error) {
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if node.Status == structs.NodeStatusDown || node.DrainStrategy != nil {
+			continue
+		}
+		if filter.Matches(node) {
+			matches = append(matches, node)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Datacenter != matches[j].Datacenter {
+			return matches[i].Datacenter < matches[j].Datacenter
+		}
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	nodes := make([]*structs.DrainBatchNode, len(matches))
+	for i, node := range matches {
+		nodes[i] = &structs.DrainBatchNode{
+			NodeID:     node.ID,
+			Datacenter: node.Datacenter,
+			Status:     structs.DrainBatchNodeStatusPending,
+		}
+	}
+	return nodes, nil
+}
+
+// Cancel cancels a running drain batch. Nodes that the batch is draining
+// keep draining, and the batch doesn't drain any more of its nodes.
+func (b *DrainBatch) Cancel(args *structs.DrainBatchCancelRequest, reply *structs.DrainBatchCancelResponse) error {
+	if done, err := b.srv.forward("DrainBatch.Cancel", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "drain_batch", "cancel"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := b.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.BatchID == "" {
+		return fmt.Errorf("missing drain batch ID")
+	}
+
+	batch, err := b.srv.State().DrainBatchByID(nil, args.BatchID)
+	if err != nil {
+		return err
+	}
+	if batch == nil {
+		return fmt.Errorf("drain batch not found")
+	}
+	if batch.Terminal() {
+		return fmt.Errorf("drain batch %q is %s", batch.ID, batch.Status)
+	}
+
+	// The cancellation only applies to the batch as it was read, so that it
+	// doesn't undo the progress the leader made in the meantime
+	modifyIndex := batch.ModifyIndex
+	batch = batch.Copy()
+	batch.Status = structs.DrainBatchStatusCancelled
+	batch.StatusDescription = structs.DrainBatchStatusDescriptionCancelled
+	batch.ModifyTime = time.Now().UTC().UnixNano()
+
+	req := &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{batch},
+		EnforceIndex: true,
+		ModifyIndex:  modifyIndex,
+		WriteRequest: args.WriteRequest,
+	}
+	fsmErr, index, err := b.srv.raftApply(structs.DrainBatchUpsertRequestType, req)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		b.logger.Error("drain batch cancel failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		b.logger.Error("drain batch cancel failed", "error", err, "raft", true)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// GetBatch is used to request information about a specific drain batch
+func (b *DrainBatch) GetBatch(args *structs.DrainBatchSpecificRequest, reply *structs.DrainBatchResponse) error {
+	if done, err := b.srv.forward("DrainBatch.GetBatch", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "drain_batch", "get_batch"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := b.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			batch, err := state.DrainBatchByID(ws, args.BatchID)
+			if err != nil {
+				return err
+			}
+
+			reply.Batch = batch
+			if batch != nil {
+				reply.Index = batch.ModifyIndex
+				return nil
+			}
+			return b.srv.replySetIndex(drainBatchTable, &reply.QueryMeta)
+		}}
+	return b.srv.blockingRPC(&opts)
+}
+
+// List is used to list the drain batches
+func (b *DrainBatch) List(args *structs.DrainBatchListRequest, reply *structs.DrainBatchListResponse) error {
+	if done, err := b.srv.forward("DrainBatch.List", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "drain_batch", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := b.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			var iter memdb.ResultIterator
+			var err error
+			if args.Prefix != "" {
+				iter, err = state.DrainBatchesByIDPrefix(ws, args.Prefix)
+			} else {
+				iter, err = state.DrainBatches(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			batches := []*structs.DrainBatch{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				batches = append(batches, raw.(*structs.DrainBatch))
+			}
+
+			reply.Batches = batches
+			return b.srv.replySetIndex(drainBatchTable, &reply.QueryMeta)
+		}}
+	return b.srv.blockingRPC(&opts)
+}
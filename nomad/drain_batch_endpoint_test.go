@@ -0,0 +1,255 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainBatchEndpoint_Create(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable the batch drainer so that the batch doesn't move forward
+	s1.batchDrainer.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	n1, n2, n3 := mock.Node(), mock.Node(), mock.Node()
+	n2.Datacenter = "dc2"
+	n3.Status = structs.NodeStatusDown
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, n1))
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1001, n2))
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1002, n3))
+
+	req := &structs.DrainBatchCreateRequest{
+		Batch: &structs.DrainBatch{
+			Filter:      &structs.DrainBatchFilter{Datacenters: []string{"dc1"}},
+			DrainSpec:   &structs.DrainSpec{Deadline: time.Hour},
+			MaxParallel: 1,
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.DrainBatchCreateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp))
+	require.NotZero(resp.Index)
+	require.NotNil(resp.Batch)
+
+	out, err := state.DrainBatchByID(nil, resp.Batch.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(structs.DrainBatchStatusRunning, out.Status)
+	require.Len(out.Nodes, 1)
+	require.Equal(n1.ID, out.Nodes[0].NodeID)
+	require.Equal(structs.DrainBatchNodeStatusPending, out.Nodes[0].Status)
+
+	// An invalid batch is rejected
+	req.Batch.MaxParallel = 0
+	err = msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "max parallel or max parallel percent must be set")
+
+	// A filter without matching nodes is rejected
+	req.Batch.MaxParallel = 1
+	req.Batch.Filter.Datacenters = []string{"dc3"}
+	err = msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "no nodes that aren't down or draining match the filter")
+}
+
+func TestDrainBatchEndpoint_Create_ACL(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	s1.batchDrainer.SetEnabled(false, nil)
+
+	node := mock.Node()
+	require.NoError(s1.fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	req := &structs.DrainBatchCreateRequest{
+		Batch: &structs.DrainBatch{
+			Filter:      &structs.DrainBatchFilter{NodeClass: node.NodeClass},
+			DrainSpec:   &structs.DrainSpec{Deadline: time.Hour},
+			MaxParallel: 1,
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+
+	// Try without a token
+	var resp structs.DrainBatchCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with a read token
+	readToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "read", mock.NodePolicy(acl.PolicyRead))
+	req.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with a write token
+	writeToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1003, "write", mock.NodePolicy(acl.PolicyWrite))
+	req.AuthToken = writeToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp))
+
+	// Try with a root token
+	req.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp))
+}
+
+func TestDrainBatchEndpoint_Cancel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	s1.batchDrainer.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	batch := mock.DrainBatch()
+	require.NoError(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1000,
+		&structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{batch}}))
+
+	req := &structs.DrainBatchCancelRequest{
+		BatchID:      batch.ID,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.DrainBatchCancelResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.Cancel", req, &resp))
+	require.NotZero(resp.Index)
+
+	out, err := state.DrainBatchByID(nil, batch.ID)
+	require.NoError(err)
+	require.Equal(structs.DrainBatchStatusCancelled, out.Status)
+	require.Equal(structs.DrainBatchStatusDescriptionCancelled, out.StatusDescription)
+
+	// A terminal batch can't be cancelled
+	err = msgpackrpc.CallWithCodec(codec, "DrainBatch.Cancel", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "is cancelled")
+
+	// An unknown batch can't be cancelled
+	req.BatchID = mock.DrainBatch().ID
+	err = msgpackrpc.CallWithCodec(codec, "DrainBatch.Cancel", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "drain batch not found")
+}
+
+func TestDrainBatchEndpoint_GetBatch_List(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	s1.batchDrainer.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	b1, b2 := mock.DrainBatch(), mock.DrainBatch()
+	require.NoError(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1000,
+		&structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{b1}}))
+	require.NoError(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1001,
+		&structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{b2}}))
+
+	get := &structs.DrainBatchSpecificRequest{
+		BatchID:      b1.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.DrainBatchResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.GetBatch", get, &getResp))
+	require.EqualValues(1000, getResp.Index)
+	require.Equal(b1, getResp.Batch)
+
+	// Lookup an unknown batch
+	get.BatchID = mock.DrainBatch().ID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.GetBatch", get, &getResp))
+	require.EqualValues(1001, getResp.Index)
+	require.Nil(getResp.Batch)
+
+	list := &structs.DrainBatchListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.DrainBatchListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.List", list, &listResp))
+	require.EqualValues(1001, listResp.Index)
+	require.Len(listResp.Batches, 2)
+
+	// List by prefix
+	list.Prefix = b2.ID[:8]
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.List", list, &listResp))
+	require.Len(listResp.Batches, 1)
+	require.Equal(b2.ID, listResp.Batches[0].ID)
+}
+
+func TestDrainBatchEndpoint_Create_DrainsNodes(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the nodes through raft so that the drainers watch them
+	state := s1.fsm.State()
+	n1, n2 := mock.Node(), mock.Node()
+	for _, node := range []*structs.Node{n1, n2} {
+		reg := &structs.NodeRegisterRequest{
+			Node:         node,
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var regResp structs.NodeUpdateResponse
+		require.NoError(msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &regResp))
+	}
+
+	req := &structs.DrainBatchCreateRequest{
+		Batch: &structs.DrainBatch{
+			Filter:      &structs.DrainBatchFilter{Datacenters: []string{"dc1"}},
+			DrainSpec:   &structs.DrainSpec{Deadline: time.Hour},
+			MaxParallel: 1,
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.DrainBatchCreateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "DrainBatch.Create", req, &resp))
+
+	// The nodes without allocations are drained one after the other
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.DrainBatchByID(nil, resp.Batch.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Status != structs.DrainBatchStatusComplete {
+			return false, fmt.Errorf("batch status %q", out.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	for _, id := range []string{n1.ID, n2.ID} {
+		node, err := state.NodeByID(nil, id)
+		require.NoError(err)
+		require.Nil(node.DrainStrategy)
+		require.Equal(structs.NodeSchedulingIneligible, node.SchedulingEligibility)
+		require.Equal(structs.DrainStatusComplete, node.LastDrain.Status)
+		require.Equal(resp.Batch.ID, node.LastDrain.Meta["batch_id"])
+	}
+}
@@ -0,0 +1,420 @@
+package drainer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// NodeDrainEventBatchStarted is the node event message used when a
+	// drain batch starts draining a node
+	NodeDrainEventBatchStarted = "Node drain strategy set by drain batch"
+
+	// NodeDrainEventDetailBatchID is the node event detail key of the ID of
+	// the drain batch that drains the node
+	NodeDrainEventDetailBatchID = "batch_id"
+)
+
+// BatchRaftApplier contains methods for applying the raft requests required
+// by the BatchDrainer.
+type BatchRaftApplier interface {
+	// UpsertDrainBatch writes the batch if its ModifyIndex in the state
+	// store is still modifyIndex, along with the drains of its nodes.
+	UpsertDrainBatch(batch *structs.DrainBatch, modifyIndex uint64, drains map[string]*structs.DrainUpdate,
+		events map[string]*structs.NodeEvent) (uint64, error)
+}
+
+// BatchDrainerConfig is used to configure a new batch drainer.
+type BatchDrainerConfig struct {
+	Logger log.Logger
+	Raft   BatchRaftApplier
+
+	// StateQueriesPerSecond configures the query limit against the state store
+	// that is allowed by the batch drainer.
+	StateQueriesPerSecond float64
+}
+
+// BatchDrainer is used to move drain batches forward. It drains the nodes of
+// each running batch a few at a time, within the batch's concurrency limits,
+// and starts draining the next nodes once the allocations migrated off the
+// drained nodes are healthy. The progress of the batches is written to raft
+// so that the next leader continues where it stopped.
+type BatchDrainer struct {
+	enabled bool
+	logger  log.Logger
+
+	// state is the state that is watched for state changes.
+	state *state.StateStore
+
+	// queryLimiter is used to limit the rate of blocking queries
+	queryLimiter *rate.Limiter
+
+	// raft is a shim around the raft messages necessary for draining
+	raft BatchRaftApplier
+
+	// ctx and exitFn are used to cancel the watcher
+	ctx    context.Context
+	exitFn context.CancelFunc
+
+	l sync.Mutex
+}
+
+// NewBatchDrainer returns a new batch drainer.
+func NewBatchDrainer(c *BatchDrainerConfig) *BatchDrainer {
+	return &BatchDrainer{
+		raft:         c.Raft,
+		logger:       c.Logger.Named("drain_batch"),
+		queryLimiter: rate.NewLimiter(rate.Limit(c.StateQueriesPerSecond), 100),
+	}
+}
+
+// SetEnabled will start or stop the batch draining goroutine depending on the
+// enabled boolean.
+func (b *BatchDrainer) SetEnabled(enabled bool, state *state.StateStore) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	if b.exitFn != nil {
+		b.exitFn()
+		b.exitFn = nil
+	}
+
+	b.enabled = enabled
+	if enabled {
+		if state != nil {
+			b.state = state
+		}
+		b.ctx, b.exitFn = context.WithCancel(context.Background())
+		go b.run(b.ctx)
+	}
+}
+
+// run is the long lived routine that watches the running batches, and the
+// nodes and allocations they depend on, and moves the batches forward.
+func (b *BatchDrainer) run(ctx context.Context) {
+	index := uint64(1)
+	for {
+		next, err := b.getRunningBatches(ctx, index)
+		if err != nil {
+			if err == context.Canceled {
+				return
+			}
+
+			b.logger.Error("error watching drain batches at index", "index", index, "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(stateReadErrorDelay):
+				continue
+			}
+		}
+		index = next
+
+		snap, err := b.state.Snapshot()
+		if err != nil {
+			b.logger.Error("failed to snapshot state", "error", err)
+			continue
+		}
+
+		iter, err := snap.DrainBatches(nil)
+		if err != nil {
+			b.logger.Error("failed to list drain batches", "error", err)
+			continue
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			batch := raw.(*structs.DrainBatch)
+			if batch.Terminal() {
+				continue
+			}
+			if err := b.handleBatch(snap, batch); err != nil {
+				b.logger.Error("failed to update drain batch", "batch_id", batch.ID, "error", err)
+			}
+		}
+	}
+}
+
+// handleBatch moves a running batch forward and writes its progress, along
+// with the drains of the nodes it starts draining. The write fails if the
+// batch changed since the snapshot, such as when it was cancelled, and the
+// batch is handled again once the change wakes the drainer.
+func (b *BatchDrainer) handleBatch(snap *state.StateSnapshot, batch *structs.DrainBatch) error {
+	updated, drains, err := advanceBatch(snap, batch, time.Now().UTC())
+	if err != nil || updated == nil {
+		return err
+	}
+
+	events := make(map[string]*structs.NodeEvent, len(drains))
+	for nodeID := range drains {
+		events[nodeID] = structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemDrain).
+			SetMessage(NodeDrainEventBatchStarted).
+			AddDetail(NodeDrainEventDetailBatchID, batch.ID)
+	}
+
+	index, err := b.raft.UpsertDrainBatch(updated, batch.ModifyIndex, drains, events)
+	if err != nil {
+		return err
+	}
+	b.logger.Debug("updated drain batch", "batch_id", batch.ID, "status", updated.Status,
+		"started_nodes", len(drains), "index", index)
+	return nil
+}
+
+// getRunningBatches blocks until the drain batches, or the nodes or
+// allocations running batches depend on, change after the given index.
+func (b *BatchDrainer) getRunningBatches(ctx context.Context, minIndex uint64) (uint64, error) {
+	if err := b.queryLimiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+
+	_, index, err := b.state.BlockingQuery(b.getRunningBatchesImpl, minIndex, ctx)
+	return index, err
+}
+
+// getRunningBatchesImpl watches the drain batches and, while any batch is
+// running, the nodes and allocations.
+func (b *BatchDrainer) getRunningBatchesImpl(ws memdb.WatchSet, state *state.StateStore) (interface{}, uint64, error) {
+	iter, err := state.DrainBatches(ws)
+	if err != nil {
+		return nil, 0, err
+	}
+	running := false
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if !raw.(*structs.DrainBatch).Terminal() {
+			running = true
+			break
+		}
+	}
+
+	tables := []string{"drain_batches"}
+	if running {
+		if _, err := state.Nodes(ws); err != nil {
+			return nil, 0, err
+		}
+		if _, err := state.Allocs(ws); err != nil {
+			return nil, 0, err
+		}
+		tables = append(tables, "nodes", "allocs")
+	}
+
+	index, err := state.Index(tables[0])
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, table := range tables[1:] {
+		i, err := state.Index(table)
+		if err != nil {
+			return nil, 0, err
+		}
+		if i > index {
+			index = i
+		}
+	}
+	return nil, index, nil
+}
+
+// advanceBatch computes the next state of a running batch. Draining nodes
+// are complete once their drain is done and the allocations migrated off them
+// are healthy, and pending nodes start draining within the limits of the
+// batch. It returns the updated batch and the drains of the nodes to start
+// draining, or a nil batch if the batch doesn't change.
+func advanceBatch(snap *state.StateSnapshot, batch *structs.DrainBatch, now time.Time) (
+	*structs.DrainBatch, map[string]*structs.DrainUpdate, error) {
+
+	updated := batch.Copy()
+	changed := false
+
+	// Check the progress of the draining nodes
+	for _, n := range updated.Nodes {
+		if n.Status != structs.DrainBatchNodeStatusDraining {
+			continue
+		}
+
+		node, err := snap.NodeByID(nil, n.NodeID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch {
+		case node == nil,
+			node.DrainStrategy == nil && node.LastDrain != nil && node.LastDrain.Status == structs.DrainStatusCanceled:
+			n.Status = structs.DrainBatchNodeStatusSkipped
+		case node.DrainStrategy != nil:
+			continue
+		default:
+			healthy, err := migrationsHealthy(snap, node.ID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !healthy {
+				continue
+			}
+			n.Status = structs.DrainBatchNodeStatusComplete
+		}
+		n.CompletedAt = now
+		changed = true
+	}
+
+	// Count the draining nodes against the limits of the batch
+	draining := 0
+	dcDraining := make(map[string]int)
+	for _, n := range updated.Nodes {
+		if n.Status == structs.DrainBatchNodeStatusDraining {
+			draining++
+			dcDraining[n.Datacenter]++
+		}
+	}
+
+	var dcLimits map[string]int
+	if updated.MaxParallelPercent > 0 {
+		var err error
+		if dcLimits, err = datacenterLimits(snap, updated.MaxParallelPercent); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Start draining the pending nodes in order
+	drains := make(map[string]*structs.DrainUpdate)
+	for _, n := range updated.Nodes {
+		if n.Status != structs.DrainBatchNodeStatusPending {
+			continue
+		}
+		if updated.MaxParallel > 0 && draining >= updated.MaxParallel {
+			break
+		}
+		if dcLimits != nil && dcDraining[n.Datacenter] >= dcLimits[n.Datacenter] {
+			continue
+		}
+
+		node, err := snap.NodeByID(nil, n.NodeID)
+		if err != nil {
+			return nil, nil, err
+		}
+		changed = true
+		if node == nil {
+			n.Status = structs.DrainBatchNodeStatusSkipped
+			n.CompletedAt = now
+			continue
+		}
+
+		// A node that was drained by an operator in the meantime keeps its
+		// drain strategy
+		if node.DrainStrategy == nil {
+			strategy := &structs.DrainStrategy{
+				DrainSpec: *updated.DrainSpec.Copy(),
+				StartedAt: now,
+			}
+			strategy.SetForceDeadlines(now)
+			drains[node.ID] = &structs.DrainUpdate{DrainStrategy: strategy}
+		}
+
+		n.Status = structs.DrainBatchNodeStatusDraining
+		n.StartedAt = now
+		draining++
+		dcDraining[n.Datacenter]++
+	}
+
+	// The batch is complete once none of its nodes are left to drain
+	if draining == 0 {
+		done := true
+		for _, n := range updated.Nodes {
+			if n.Status == structs.DrainBatchNodeStatusPending {
+				done = false
+				break
+			}
+		}
+		if done {
+			updated.Status = structs.DrainBatchStatusComplete
+			updated.StatusDescription = structs.DrainBatchStatusDescriptionComplete
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil, nil, nil
+	}
+	updated.ModifyTime = now.UnixNano()
+	return updated, drains, nil
+}
+
+// datacenterLimits returns how many nodes of each datacenter can drain at the
+// same time for the given percent of its nodes, which is at least one.
+func datacenterLimits(snap *state.StateSnapshot, percent int) (map[string]int, error) {
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[string]int)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		sizes[raw.(*structs.Node).Datacenter]++
+	}
+
+	limits := make(map[string]int, len(sizes))
+	for dc, size := range sizes {
+		limit := size * percent / 100
+		if limit < 1 {
+			limit = 1
+		}
+		limits[dc] = limit
+	}
+	return limits, nil
+}
+
+// migrationsHealthy returns whether the service allocations migrated off a
+// node have been replaced by healthy allocations. Allocations of stopped or
+// removed jobs are not replaced and are ignored.
+func migrationsHealthy(snap *state.StateSnapshot, nodeID string) (bool, error) {
+	allocs, err := snap.AllocsByNode(nil, nodeID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, alloc := range allocs {
+		if !alloc.DesiredTransition.ShouldMigrate() {
+			continue
+		}
+		if alloc.Job == nil || alloc.Job.Type != structs.JobTypeService {
+			continue
+		}
+
+		// Follow the replacements of the allocation to the latest one
+		next := alloc
+		for next.NextAllocation != "" {
+			replacement, err := snap.AllocByID(nil, next.NextAllocation)
+			if err != nil {
+				return false, err
+			}
+			if replacement == nil {
+				break
+			}
+			next = replacement
+		}
+
+		if next != alloc {
+			if next.TerminalStatus() || !next.DeploymentStatus.IsHealthy() {
+				return false, nil
+			}
+			continue
+		}
+
+		// The allocation hasn't been replaced, which is expected only if its
+		// job was stopped
+		job, err := snap.JobByID(nil, alloc.Namespace, alloc.JobID)
+		if err != nil {
+			return false, err
+		}
+		if job != nil && !job.Stopped() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
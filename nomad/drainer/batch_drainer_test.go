@@ -0,0 +1,321 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// testBatch upserts the nodes and returns a running batch draining them in
+// order
+func testBatch(t *testing.T, store *state.StateStore, nodes ...*structs.Node) *structs.DrainBatch {
+	t.Helper()
+	batch := mock.DrainBatch()
+	batch.Nodes = nil
+	for i, node := range nodes {
+		require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		batch.Nodes = append(batch.Nodes, &structs.DrainBatchNode{
+			NodeID:     node.ID,
+			Datacenter: node.Datacenter,
+			Status:     structs.DrainBatchNodeStatusPending,
+		})
+	}
+	return batch
+}
+
+func nodeStatuses(batch *structs.DrainBatch) []string {
+	statuses := make([]string, len(batch.Nodes))
+	for i, n := range batch.Nodes {
+		statuses[i] = n.Status
+	}
+	return statuses
+}
+
+// stateBatchApplier applies the drain batch updates of the batch drainer
+// directly to a state store
+type stateBatchApplier struct {
+	store *state.StateStore
+	index uint64
+}
+
+func (a *stateBatchApplier) UpsertDrainBatch(batch *structs.DrainBatch, modifyIndex uint64,
+	drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error) {
+	a.index++
+	return a.index, a.store.UpsertDrainBatches(structs.MsgTypeTestSetup, a.index, &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{batch},
+		NodeDrains:   drains,
+		NodeEvents:   events,
+		EnforceIndex: true,
+		ModifyIndex:  modifyIndex,
+	})
+}
+
+func TestBatchDrainer_AdvanceBatch_MaxParallel(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	n1, n2, n3 := mock.Node(), mock.Node(), mock.Node()
+	batch := testBatch(t, store, n1, n2, n3)
+	batch.MaxParallel = 2
+	now := time.Now().UTC()
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	updated, drains, err := advanceBatch(snap, batch, now)
+	require.NoError(err)
+	require.NotNil(updated)
+	require.Equal([]string{
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusPending,
+	}, nodeStatuses(updated))
+	require.Len(drains, 2)
+	require.Contains(drains, n1.ID)
+	require.Contains(drains, n2.ID)
+	require.Equal(time.Hour, drains[n1.ID].DrainStrategy.Deadline)
+	require.Equal(now.Add(time.Hour), drains[n1.ID].DrainStrategy.ForceDeadline)
+	require.Equal(now, updated.Nodes[0].StartedAt)
+	require.Equal(structs.DrainBatchStatusRunning, updated.Status)
+
+	// The batch doesn't change while the nodes are draining
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 200, n1.ID, drains[n1.ID].DrainStrategy, false, 0, nil, nil, ""))
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 201, n2.ID, drains[n2.ID].DrainStrategy, false, 0, nil, nil, ""))
+
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	next, drains, err := advanceBatch(snap, updated, now)
+	require.NoError(err)
+	require.Nil(next)
+	require.Nil(drains)
+
+	// Once the first node is drained, the next node starts draining
+	require.NoError(store.BatchUpdateNodeDrain(structs.MsgTypeTestSetup, 202, 0,
+		map[string]*structs.DrainUpdate{n1.ID: {}}, nil))
+
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	next, drains, err = advanceBatch(snap, updated, now)
+	require.NoError(err)
+	require.NotNil(next)
+	require.Equal([]string{
+		structs.DrainBatchNodeStatusComplete,
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusDraining,
+	}, nodeStatuses(next))
+	require.Len(drains, 1)
+	require.Contains(drains, n3.ID)
+}
+
+func TestBatchDrainer_AdvanceBatch_MaxParallelPercent(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	var nodes []*structs.Node
+	for i := 0; i < 4; i++ {
+		nodes = append(nodes, mock.Node())
+	}
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		node.Datacenter = "dc2"
+		nodes = append(nodes, node)
+	}
+	batch := testBatch(t, store, nodes...)
+	batch.MaxParallel = 0
+	batch.MaxParallelPercent = 50
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	updated, drains, err := advanceBatch(snap, batch, time.Now().UTC())
+	require.NoError(err)
+	require.Equal([]string{
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusPending,
+		structs.DrainBatchNodeStatusPending,
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusPending,
+	}, nodeStatuses(updated))
+	require.Len(drains, 3)
+}
+
+func TestBatchDrainer_AdvanceBatch_Skipped(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	n1, n2 := mock.Node(), mock.Node()
+	batch := testBatch(t, store, n1, n2)
+	batch.MaxParallel = 2
+	batch.Nodes[0].Status = structs.DrainBatchNodeStatusDraining
+	batch.Nodes = append(batch.Nodes, &structs.DrainBatchNode{
+		NodeID:     uuid.Generate(),
+		Datacenter: "dc1",
+		Status:     structs.DrainBatchNodeStatusPending,
+	})
+
+	// The drain of the first node was cancelled by an operator
+	strategy := &structs.DrainStrategy{DrainSpec: structs.DrainSpec{Deadline: -1}}
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 200, n1.ID, strategy, false, 0, nil, nil, ""))
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 201, n1.ID, nil, false, 0, nil, nil, ""))
+
+	// The second node was drained by an operator, and keeps its drain
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 202, n2.ID, strategy, false, 0, nil, nil, ""))
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	updated, drains, err := advanceBatch(snap, batch, time.Now().UTC())
+	require.NoError(err)
+	require.Equal([]string{
+		structs.DrainBatchNodeStatusSkipped,
+		structs.DrainBatchNodeStatusDraining,
+		structs.DrainBatchNodeStatusSkipped,
+	}, nodeStatuses(updated))
+	require.Empty(drains)
+	require.Equal(structs.DrainBatchStatusRunning, updated.Status)
+
+	// The batch is complete once the last node is drained
+	require.NoError(store.BatchUpdateNodeDrain(structs.MsgTypeTestSetup, 203, 0,
+		map[string]*structs.DrainUpdate{n2.ID: {}}, nil))
+
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	updated, _, err = advanceBatch(snap, updated, time.Now().UTC())
+	require.NoError(err)
+	require.Equal(structs.DrainBatchNodeStatusComplete, updated.Nodes[1].Status)
+	require.Equal(structs.DrainBatchStatusComplete, updated.Status)
+	require.Equal(structs.DrainBatchStatusDescriptionComplete, updated.StatusDescription)
+}
+
+func TestBatchDrainer_MigrationsHealthy(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	node := mock.Node()
+	require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	job := mock.Job()
+	require.NoError(store.UpsertJob(structs.MsgTypeTestSetup, 101, job))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 102, []*structs.Allocation{alloc}))
+
+	healthy := func() bool {
+		snap, err := store.Snapshot()
+		require.NoError(err)
+		ok, err := migrationsHealthy(snap, node.ID)
+		require.NoError(err)
+		return ok
+	}
+
+	// The allocation wasn't replaced yet
+	require.False(healthy())
+
+	// The replacement isn't healthy yet
+	replacement := mock.Alloc()
+	replacement.Job = job
+	replacement.JobID = job.ID
+	replacement.PreviousAllocation = alloc.ID
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 103, []*structs.Allocation{replacement}))
+	require.False(healthy())
+
+	replacement = replacement.Copy()
+	replacement.DeploymentStatus = &structs.AllocDeploymentStatus{Healthy: helper.BoolToPtr(true)}
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 104, []*structs.Allocation{replacement}))
+	require.True(healthy())
+
+	// The replacement of the replacement failed
+	failed := replacement.Copy()
+	failed.ClientStatus = structs.AllocClientStatusFailed
+	next := mock.Alloc()
+	next.Job = job
+	next.JobID = job.ID
+	next.PreviousAllocation = replacement.ID
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 105, []*structs.Allocation{failed, next}))
+	require.False(healthy())
+}
+
+func TestBatchDrainer_MigrationsHealthy_StoppedJob(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	node := mock.Node()
+	require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	job := mock.Job()
+	job.Stop = true
+	require.NoError(store.UpsertJob(structs.MsgTypeTestSetup, 101, job))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	require.NoError(store.UpsertAllocs(structs.MsgTypeTestSetup, 102, []*structs.Allocation{alloc}))
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	ok, err := migrationsHealthy(snap, node.ID)
+	require.NoError(err)
+	require.True(ok)
+}
+
+func TestBatchDrainer_HandleBatch_Cancelled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	n1 := mock.Node()
+	batch := testBatch(t, store, n1)
+	require.NoError(store.UpsertDrainBatches(structs.MsgTypeTestSetup, 200,
+		&structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{batch}}))
+	batch, err := store.DrainBatchByID(nil, batch.ID)
+	require.NoError(err)
+
+	applier := &stateBatchApplier{store: store, index: 300}
+	b := NewBatchDrainer(&BatchDrainerConfig{
+		Logger:                testlog.HCLogger(t),
+		Raft:                  applier,
+		StateQueriesPerSecond: 100,
+	})
+
+	// The batch is cancelled after the drainer took its snapshot
+	snap, err := store.Snapshot()
+	require.NoError(err)
+
+	cancelled := batch.Copy()
+	cancelled.Status = structs.DrainBatchStatusCancelled
+	cancelled.StatusDescription = structs.DrainBatchStatusDescriptionCancelled
+	require.NoError(store.UpsertDrainBatches(structs.MsgTypeTestSetup, 201, &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{cancelled},
+		EnforceIndex: true,
+		ModifyIndex:  batch.ModifyIndex,
+	}))
+
+	// The drainer's update doesn't overwrite the cancellation or start
+	// draining the node
+	err = b.handleBatch(snap, batch)
+	require.Error(err)
+	require.Contains(err.Error(), "cancelled")
+
+	out, err := store.DrainBatchByID(nil, batch.ID)
+	require.NoError(err)
+	require.Equal(structs.DrainBatchStatusCancelled, out.Status)
+	require.Equal([]string{structs.DrainBatchNodeStatusPending}, nodeStatuses(out))
+
+	node, err := store.NodeByID(nil, n1.ID)
+	require.NoError(err)
+	require.Nil(node.DrainStrategy)
+}
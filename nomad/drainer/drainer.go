@@ -238,11 +238,15 @@ func (n *NodeDrainer) run(ctx context.Context) {
 }
 
 // handleDeadlinedNodes handles a set of nodes reaching their drain deadline.
-// The handler detects the remaining allocations on the nodes and immediately
-// marks them for migration.
+// The handler detects the remaining allocations on the nodes whose deadline
+// has been reached and immediately marks them for migration. Nodes with
+// allocations whose deadline is overridden to a later time keep draining.
 func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
+	now := time.Now()
+
 	// Retrieve the set of allocations that will be force stopped.
 	var forceStop []*structs.Allocation
+	var deadlined []string
 	n.l.RLock()
 	for _, node := range nodes {
 		draining, ok := n.nodes[node]
@@ -251,7 +255,7 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 			continue
 		}
 
-		allocs, err := draining.RemainingAllocs()
+		allocs, pending, next, err := draining.DeadlinedAllocs(now)
 		if err != nil {
 			n.logger.Error("failed to retrieve allocs on deadlined node", "node_id", node, "error", err)
 			continue
@@ -259,6 +263,16 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 
 		n.logger.Debug("node deadlined causing allocs to be force stopped", "node_id", node, "num_allocs", len(allocs))
 		forceStop = append(forceStop, allocs...)
+
+		// Allocations with a later deadline keep the node draining until
+		// their own deadline or until they migrate
+		if pending {
+			if !next.IsZero() {
+				n.deadlineNotifier.Watch(node, next)
+			}
+			continue
+		}
+		deadlined = append(deadlined, node)
 	}
 	n.l.RUnlock()
 	n.batchDrainAllocs(forceStop)
@@ -271,7 +285,7 @@ func (n *NodeDrainer) handleDeadlinedNodes(nodes []string) {
 
 	// Submit the node transitions in a sharded form to ensure a reasonable
 	// Raft transaction size.
-	for _, nodes := range partitionIds(defaultMaxIdsPerTxn, nodes) {
+	for _, nodes := range partitionIds(defaultMaxIdsPerTxn, deadlined) {
 		if _, err := n.raft.NodesDrainComplete(nodes, event); err != nil {
 			n.logger.Error("failed to unset drain for nodes", "error", err)
 		}
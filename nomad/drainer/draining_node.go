@@ -34,17 +34,80 @@ func (n *drainingNode) Update(node *structs.Node) {
 	n.node = node
 }
 
-// DeadlineTime returns if the node has a deadline and if so what it is
-func (n *drainingNode) DeadlineTime() (bool, time.Time) {
+// DeadlineTime returns if the node has a deadline and if so what it is. When
+// the drain overrides the deadline of some jobs, the deadline is the earliest
+// deadline of the remaining allocations.
+func (n *drainingNode) DeadlineTime() (bool, time.Time, error) {
 	n.l.RLock()
 	defer n.l.RUnlock()
 
 	// Should never happen
 	if n.node == nil || n.node.DrainStrategy == nil {
-		return false, time.Time{}
+		return false, time.Time{}, nil
 	}
 
-	return n.node.DrainStrategy.DeadlineTime()
+	strategy := n.node.DrainStrategy
+	if len(strategy.DeadlineOverrides) == 0 {
+		inf, deadline := strategy.DeadlineTime()
+		return inf, deadline, nil
+	}
+
+	allocs, err := n.remainingAllocs()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	infinite, earliest := true, time.Time{}
+	for _, alloc := range allocs {
+		inf, deadline := strategy.JobDeadlineTime(alloc.Job)
+		if inf {
+			continue
+		}
+		if infinite || deadline.Before(earliest) {
+			earliest = deadline
+		}
+		infinite = false
+	}
+	return infinite, earliest, nil
+}
+
+// DeadlinedAllocs returns the remaining allocations whose deadline has been
+// reached at the given time. It also returns whether other allocations remain
+// with a later or an infinite deadline, and the earliest of the later
+// deadlines, which is zero if there are none.
+func (n *drainingNode) DeadlinedAllocs(now time.Time) ([]*structs.Allocation, bool, time.Time, error) {
+	n.l.RLock()
+	defer n.l.RUnlock()
+
+	// Should never happen
+	if n.node == nil || n.node.DrainStrategy == nil {
+		return nil, false, time.Time{}, fmt.Errorf("node doesn't have a drain strategy set")
+	}
+
+	allocs, err := n.remainingAllocs()
+	if err != nil {
+		return nil, false, time.Time{}, err
+	}
+
+	var deadlined []*structs.Allocation
+	pending := false
+	var next time.Time
+	for _, alloc := range allocs {
+		inf, deadline := n.node.DrainStrategy.JobDeadlineTime(alloc.Job)
+		switch {
+		case inf:
+			pending = true
+		case deadline.After(now):
+			pending = true
+			if next.IsZero() || deadline.Before(next) {
+				next = deadline
+			}
+		default:
+			deadlined = append(deadlined, alloc)
+		}
+	}
+
+	return deadlined, pending, next, nil
 }
 
 // IsDone returns if the node is done draining batch and service allocs. System
@@ -72,6 +135,11 @@ func (n *drainingNode) IsDone() (bool, error) {
 			continue
 		}
 
+		// Skipped jobs stay on the node
+		if n.node.DrainStrategy.SkipsJob(alloc.Namespace, alloc.JobID) {
+			continue
+		}
+
 		// If there is a non-terminal we aren't done
 		if !alloc.TerminalStatus() {
 			return false, nil
@@ -92,6 +160,12 @@ func (n *drainingNode) RemainingAllocs() ([]*structs.Allocation, error) {
 		return nil, fmt.Errorf("node doesn't have a drain strategy set")
 	}
 
+	return n.remainingAllocs()
+}
+
+// remainingAllocs returns the allocations remaining on the node that still
+// need to be drained. The lock must be held.
+func (n *drainingNode) remainingAllocs() ([]*structs.Allocation, error) {
 	// Grab the relevant drain info
 	ignoreSystem := n.node.DrainStrategy.IgnoreSystemJobs
 
@@ -113,6 +187,11 @@ func (n *drainingNode) RemainingAllocs() ([]*structs.Allocation, error) {
 			continue
 		}
 
+		// Skipped jobs stay on the node
+		if n.node.DrainStrategy.SkipsJob(alloc.Namespace, alloc.JobID) {
+			continue
+		}
+
 		drain = append(drain, alloc)
 	}
 
@@ -142,6 +221,9 @@ func (n *drainingNode) DrainingJobs() ([]structs.NamespacedID, error) {
 		if alloc.TerminalStatus() || alloc.Job.Type == structs.JobTypeSystem {
 			continue
 		}
+		if n.node.DrainStrategy.SkipsJob(alloc.Namespace, alloc.JobID) {
+			continue
+		}
 
 		jns := structs.NamespacedID{Namespace: alloc.Namespace, ID: alloc.JobID}
 		if _, ok := jobIDs[jns]; ok {
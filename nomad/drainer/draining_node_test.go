@@ -213,3 +213,87 @@ func TestDrainingNode_Table(t *testing.T) {
 		})
 	}
 }
+
+func TestDrainingNode_SkipJobs(t *testing.T) {
+	t.Parallel()
+	dn := testDrainingNode(t)
+
+	skipped, drained := mock.Alloc(), mock.BatchAlloc()
+	for _, a := range []*structs.Allocation{skipped, drained} {
+		a.NodeID = dn.node.ID
+		require.Nil(t, dn.state.UpsertJob(structs.MsgTypeTestSetup, 101, a.Job))
+	}
+	require.Nil(t, dn.state.UpsertAllocs(structs.MsgTypeTestSetup, 102, []*structs.Allocation{skipped, drained}))
+
+	node := dn.GetNode().Copy()
+	node.DrainStrategy.SkipJobs = []structs.NamespacedID{
+		structs.NewNamespacedID(skipped.JobID, skipped.Namespace),
+		// A job of the same ID in another namespace isn't skipped
+		structs.NewNamespacedID(drained.JobID, "other"),
+	}
+	dn.Update(node)
+	assertDrainingNode(t, dn, false, 1, 1)
+
+	// The drain is done once the other job is drained
+	drained = drained.Copy()
+	drained.ClientStatus = structs.AllocClientStatusComplete
+	require.Nil(t, dn.state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 103, []*structs.Allocation{drained}))
+	assertDrainingNode(t, dn, true, 0, 0)
+}
+
+func TestDrainingNode_DeadlineOverrides(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	dn := testDrainingNode(t)
+	now := time.Now()
+
+	short, long, infinite := mock.Alloc(), mock.Alloc(), mock.Alloc()
+	short.Job.Priority = 80
+	infinite.Job.Priority = 20
+	allocs := []*structs.Allocation{short, long, infinite}
+	for _, a := range allocs {
+		a.NodeID = dn.node.ID
+		require.Nil(dn.state.UpsertJob(structs.MsgTypeTestSetup, 101, a.Job))
+	}
+	require.Nil(dn.state.UpsertAllocs(structs.MsgTypeTestSetup, 102, allocs))
+
+	node := dn.GetNode().Copy()
+	node.DrainStrategy.DeadlineOverrides = []*structs.DrainDeadline{
+		{MinPriority: 70, MaxPriority: 100, Deadline: time.Minute},
+		{Namespace: infinite.Namespace, JobID: infinite.JobID, Deadline: 0},
+		// A job of the same ID in another namespace isn't overridden
+		{Namespace: "other", JobID: long.JobID, Deadline: -1},
+	}
+	node.DrainStrategy.SetForceDeadlines(now)
+	dn.Update(node)
+
+	// The node's deadline is the earliest deadline of its allocations
+	inf, deadline, err := dn.DeadlineTime()
+	require.NoError(err)
+	require.False(inf)
+	require.Equal(now.Add(time.Minute), deadline)
+
+	deadlined, pending, next, err := dn.DeadlinedAllocs(now.Add(time.Minute))
+	require.NoError(err)
+	require.Len(deadlined, 1)
+	require.Equal(short.ID, deadlined[0].ID)
+	require.True(pending)
+	require.Equal(now.Add(time.Hour), next)
+
+	deadlined, pending, next, err = dn.DeadlinedAllocs(now.Add(time.Hour))
+	require.NoError(err)
+	require.Len(deadlined, 2)
+	require.True(pending)
+	require.True(next.IsZero())
+
+	// Once the allocations with a deadline are stopped, only the allocation
+	// without a deadline remains
+	for _, a := range []*structs.Allocation{short, long} {
+		a = a.Copy()
+		a.ClientStatus = structs.AllocClientStatusComplete
+		require.Nil(dn.state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 103, []*structs.Allocation{a}))
+	}
+	inf, _, err = dn.DeadlineTime()
+	require.NoError(err)
+	require.True(inf)
+}
@@ -0,0 +1,368 @@
+package drainer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// NodeDrainEventMaintenanceStarted is the node event message used when a
+	// maintenance window starts draining a node
+	NodeDrainEventMaintenanceStarted = "Node drain strategy set by maintenance window"
+
+	// NodeDrainEventMaintenanceRestored is the node event message used when a
+	// maintenance window ends and restores the eligibility of a node
+	NodeDrainEventMaintenanceRestored = "Node eligibility restored by maintenance window"
+
+	// NodeDrainEventMaintenanceUnhealthy is the node event message used when a
+	// maintenance window ends but leaves a node that isn't ready ineligible
+	NodeDrainEventMaintenanceUnhealthy = "Node left ineligible by maintenance window as it isn't ready"
+
+	// NodeDrainEventDetailMaintenanceWindowID is the node event detail key of
+	// the ID of the maintenance window of the node
+	NodeDrainEventDetailMaintenanceWindowID = structs.NodeMaintenanceWindowDrainMetaKey
+)
+
+// MaintenanceRaftApplier contains methods for applying the raft requests
+// required by the MaintenanceWatcher.
+type MaintenanceRaftApplier interface {
+	// UpsertNodeMaintenanceWindow writes the window if its ModifyIndex in the
+	// state store is still modifyIndex, along with the drains of its nodes.
+	UpsertNodeMaintenanceWindow(window *structs.NodeMaintenanceWindow, modifyIndex uint64,
+		drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error)
+}
+
+// MaintenanceWatcherConfig is used to configure a new maintenance watcher.
+type MaintenanceWatcherConfig struct {
+	Logger log.Logger
+	Raft   MaintenanceRaftApplier
+
+	// StateQueriesPerSecond configures the query limit against the state store
+	// that is allowed by the maintenance watcher.
+	StateQueriesPerSecond float64
+}
+
+// MaintenanceWatcher is used to start and end node maintenance windows. It
+// drains the nodes of a window when it starts, and restores the eligibility
+// of the nodes that are ready again when it ends. The progress of the windows
+// is written to raft so that the next leader continues where it stopped.
+type MaintenanceWatcher struct {
+	enabled bool
+	logger  log.Logger
+
+	// state is the state that is watched for state changes.
+	state *state.StateStore
+
+	// queryLimiter is used to limit the rate of blocking queries
+	queryLimiter *rate.Limiter
+
+	// raft is a shim around the raft messages necessary for the windows
+	raft MaintenanceRaftApplier
+
+	// ctx and exitFn are used to cancel the watcher
+	ctx    context.Context
+	exitFn context.CancelFunc
+
+	l sync.Mutex
+}
+
+// NewMaintenanceWatcher returns a new maintenance watcher.
+func NewMaintenanceWatcher(c *MaintenanceWatcherConfig) *MaintenanceWatcher {
+	return &MaintenanceWatcher{
+		raft:         c.Raft,
+		logger:       c.Logger.Named("maintenance_watcher"),
+		queryLimiter: rate.NewLimiter(rate.Limit(c.StateQueriesPerSecond), 100),
+	}
+}
+
+// SetEnabled will start or stop the maintenance goroutine depending on the
+// enabled boolean.
+func (w *MaintenanceWatcher) SetEnabled(enabled bool, state *state.StateStore) {
+	w.l.Lock()
+	defer w.l.Unlock()
+
+	if w.exitFn != nil {
+		w.exitFn()
+		w.exitFn = nil
+	}
+
+	w.enabled = enabled
+	if enabled {
+		if state != nil {
+			w.state = state
+		}
+		w.ctx, w.exitFn = context.WithCancel(context.Background())
+		go w.run(w.ctx)
+	}
+}
+
+// run is the long lived routine that watches the maintenance windows, and
+// starts or ends them when their start or end time is reached.
+func (w *MaintenanceWatcher) run(ctx context.Context) {
+	index := uint64(1)
+	var next time.Time
+	for {
+		// Wait for the windows to change, or for the next window to start or
+		// end
+		queryCtx, cancel := ctx, context.CancelFunc(func() {})
+		if !next.IsZero() {
+			queryCtx, cancel = context.WithDeadline(ctx, next)
+		}
+		newIndex, err := w.getWindows(ctx, queryCtx, index)
+		cancel()
+		switch {
+		case err == nil:
+			index = newIndex
+		case ctx.Err() != nil:
+			return
+		case err == context.DeadlineExceeded:
+		default:
+			w.logger.Error("error watching node maintenance windows at index", "index", index, "error", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(stateReadErrorDelay):
+				continue
+			}
+		}
+
+		snap, err := w.state.Snapshot()
+		if err != nil {
+			w.logger.Error("failed to snapshot state", "error", err)
+			continue
+		}
+
+		iter, err := snap.NodeMaintenanceWindows(nil)
+		if err != nil {
+			w.logger.Error("failed to list node maintenance windows", "error", err)
+			continue
+		}
+
+		now := time.Now().UTC()
+		next = time.Time{}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			window := raw.(*structs.NodeMaintenanceWindow)
+			if window.Terminal() {
+				continue
+			}
+
+			at, err := w.handleWindow(snap, window, now)
+			if err != nil {
+				w.logger.Error("failed to update node maintenance window", "window_id", window.ID, "error", err)
+				at = now.Add(stateReadErrorDelay)
+			}
+			if !at.IsZero() && (next.IsZero() || at.Before(next)) {
+				next = at
+			}
+		}
+	}
+}
+
+// handleWindow starts or ends a window and writes its progress, along with
+// the drains of its nodes. The write fails if the window changed since the
+// snapshot, such as when it was cancelled. It returns when the window should
+// next be handled.
+func (w *MaintenanceWatcher) handleWindow(snap *state.StateSnapshot, window *structs.NodeMaintenanceWindow,
+	now time.Time) (time.Time, error) {
+
+	updated, drains, next, err := advanceWindow(snap, window, now)
+	if err != nil || updated == nil {
+		return next, err
+	}
+
+	index, err := w.raft.UpsertNodeMaintenanceWindow(updated, window.ModifyIndex, drains,
+		NodeMaintenanceEvents(window.ID, drains))
+	if err != nil {
+		return time.Time{}, err
+	}
+	w.logger.Debug("updated node maintenance window", "window_id", window.ID, "status", updated.Status,
+		"updated_nodes", len(drains), "index", index)
+	return next, nil
+}
+
+// getWindows blocks until the maintenance windows change after the given
+// index, or until the query context is done. The query limiter waits on the
+// watcher's context so that the deadline of the query context is only used
+// to wake up the watcher.
+func (w *MaintenanceWatcher) getWindows(ctx, queryCtx context.Context, minIndex uint64) (uint64, error) {
+	if err := w.queryLimiter.Wait(ctx); err != nil {
+		return 0, err
+	}
+
+	_, index, err := w.state.BlockingQuery(getWindowsImpl, minIndex, queryCtx)
+	return index, err
+}
+
+// getWindowsImpl watches the maintenance windows.
+func getWindowsImpl(ws memdb.WatchSet, state *state.StateStore) (interface{}, uint64, error) {
+	if _, err := state.NodeMaintenanceWindows(ws); err != nil {
+		return nil, 0, err
+	}
+
+	index, err := state.Index("node_maintenance_windows")
+	if err != nil {
+		return nil, 0, err
+	}
+	return nil, index, nil
+}
+
+// advanceWindow computes the next state of a pending or active window. A
+// pending window starts draining the nodes it selects at its start time, and
+// an active window ends at its end time. It returns the updated window, the
+// drain updates of its nodes and when the window should next be advanced, or
+// a nil window if the window doesn't change.
+func advanceWindow(snap *state.StateSnapshot, window *structs.NodeMaintenanceWindow, now time.Time) (
+	*structs.NodeMaintenanceWindow, map[string]*structs.DrainUpdate, time.Time, error) {
+
+	switch window.Status {
+	case structs.NodeMaintenanceWindowStatusPending:
+		if now.Before(window.StartTime) {
+			return nil, nil, window.StartTime, nil
+		}
+
+		// The window may have been missed while there was no leader
+		if !now.Before(window.EndTime()) {
+			updated := window.Copy()
+			updated.Status = structs.NodeMaintenanceWindowStatusComplete
+			updated.StatusDescription = structs.NodeMaintenanceWindowStatusDescriptionMissed
+			updated.ModifyTime = now.UnixNano()
+			return updated, nil, time.Time{}, nil
+		}
+
+		updated, drains, err := startWindow(snap, window, now)
+		return updated, drains, window.EndTime(), err
+
+	case structs.NodeMaintenanceWindowStatusActive:
+		if now.Before(window.EndTime()) {
+			return nil, nil, window.EndTime(), nil
+		}
+
+		updated, drains, err := EndNodeMaintenanceWindow(snap, window, now,
+			structs.NodeMaintenanceWindowStatusComplete, structs.NodeMaintenanceWindowStatusDescriptionComplete)
+		return updated, drains, time.Time{}, err
+	}
+
+	return nil, nil, time.Time{}, nil
+}
+
+// startWindow selects the nodes of a window and starts draining them. Nodes
+// that are down are also selected, so that they are kept ineligible when they
+// come back during the window. Nodes drained by another window that overlaps
+// this one are taken over by this window, so that they are kept ineligible
+// until both windows ended.
+func startWindow(snap *state.StateSnapshot, window *structs.NodeMaintenanceWindow, now time.Time) (
+	*structs.NodeMaintenanceWindow, map[string]*structs.DrainUpdate, error) {
+
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updated := window.Copy()
+	updated.Nodes = nil
+	drains := make(map[string]*structs.DrainUpdate)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !window.Selects(node) {
+			continue
+		}
+
+		n := &structs.NodeMaintenanceWindowNode{
+			NodeID:    node.ID,
+			StartedAt: now,
+		}
+		updated.Nodes = append(updated.Nodes, n)
+
+		// A node that was drained by an operator keeps its drain, and its
+		// eligibility isn't restored when the window ends
+		if node.DrainStrategy != nil && structs.NodeMaintenanceWindowID(node) == "" {
+			n.Status = structs.NodeMaintenanceWindowNodeStatusSkipped
+			n.CompletedAt = now
+			continue
+		}
+
+		strategy := &structs.DrainStrategy{
+			DrainSpec: *window.DrainSpec.Copy(),
+			StartedAt: now,
+		}
+		strategy.SetForceDeadlines(now)
+		drains[node.ID] = &structs.DrainUpdate{DrainStrategy: strategy}
+		n.Status = structs.NodeMaintenanceWindowNodeStatusActive
+	}
+
+	updated.Status = structs.NodeMaintenanceWindowStatusActive
+	updated.StatusDescription = structs.NodeMaintenanceWindowStatusDescriptionActive
+	updated.ModifyTime = now.UnixNano()
+	return updated, drains, nil
+}
+
+// EndNodeMaintenanceWindow ends a window with the given status. The drains of
+// the window's nodes that are ready are cancelled if they aren't done, and
+// their eligibility is restored. Nodes that aren't ready are left ineligible
+// for an operator to look into, and nodes whose last drain wasn't set by the
+// window are left to whoever drained them. It returns the updated window and the drain updates of
+// its nodes.
+func EndNodeMaintenanceWindow(snap *state.StateSnapshot, window *structs.NodeMaintenanceWindow, now time.Time,
+	status, description string) (*structs.NodeMaintenanceWindow, map[string]*structs.DrainUpdate, error) {
+
+	updated := window.Copy()
+	drains := make(map[string]*structs.DrainUpdate)
+	for _, n := range updated.Nodes {
+		if n.Status != structs.NodeMaintenanceWindowNodeStatusActive {
+			continue
+		}
+
+		node, err := snap.NodeByID(nil, n.NodeID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		n.CompletedAt = now
+		switch {
+		case node == nil, window.TakenOver(node):
+			n.Status = structs.NodeMaintenanceWindowNodeStatusSkipped
+		case node.Status == structs.NodeStatusReady:
+			drains[node.ID] = &structs.DrainUpdate{MarkEligible: true}
+			n.Status = structs.NodeMaintenanceWindowNodeStatusRestored
+		default:
+			if node.DrainStrategy != nil {
+				drains[node.ID] = &structs.DrainUpdate{}
+			}
+			n.Status = structs.NodeMaintenanceWindowNodeStatusUnhealthy
+		}
+	}
+
+	updated.Status = status
+	updated.StatusDescription = description
+	updated.ModifyTime = now.UnixNano()
+	return updated, drains, nil
+}
+
+// NodeMaintenanceEvents returns the node events of the drain updates of a
+// window's nodes.
+func NodeMaintenanceEvents(windowID string, drains map[string]*structs.DrainUpdate) map[string]*structs.NodeEvent {
+	events := make(map[string]*structs.NodeEvent, len(drains))
+	for nodeID, update := range drains {
+		message := NodeDrainEventMaintenanceUnhealthy
+		switch {
+		case update.DrainStrategy != nil:
+			message = NodeDrainEventMaintenanceStarted
+		case update.MarkEligible:
+			message = NodeDrainEventMaintenanceRestored
+		}
+
+		events[nodeID] = structs.NewNodeEvent().
+			SetSubsystem(structs.NodeEventSubsystemDrain).
+			SetMessage(message).
+			AddDetail(NodeDrainEventDetailMaintenanceWindowID, windowID)
+	}
+	return events
+}
@@ -0,0 +1,359 @@
+package drainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// stateMaintenanceApplier applies the window updates of the maintenance
+// watcher directly to a state store
+type stateMaintenanceApplier struct {
+	store *state.StateStore
+	index uint64
+}
+
+func (a *stateMaintenanceApplier) UpsertNodeMaintenanceWindow(window *structs.NodeMaintenanceWindow, modifyIndex uint64,
+	drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error) {
+	a.index++
+	return a.index, a.store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, a.index, &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{window},
+		NodeDrains:   drains,
+		NodeEvents:   events,
+		EnforceIndex: true,
+		ModifyIndex:  modifyIndex,
+	})
+}
+
+func TestMaintenanceWatcher_AdvanceWindow_Pending(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	window := mock.NodeMaintenanceWindow()
+	now := window.StartTime.Add(-time.Minute)
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	updated, drains, next, err := advanceWindow(snap, window, now)
+	require.NoError(err)
+	require.Nil(updated)
+	require.Nil(drains)
+	require.Equal(window.StartTime, next)
+
+	// A window that ends before the leader got to start it is missed
+	updated, drains, _, err = advanceWindow(snap, window, window.EndTime())
+	require.NoError(err)
+	require.Empty(drains)
+	require.Equal(structs.NodeMaintenanceWindowStatusComplete, updated.Status)
+	require.Equal(structs.NodeMaintenanceWindowStatusDescriptionMissed, updated.StatusDescription)
+}
+
+func TestMaintenanceWatcher_AdvanceWindow_Lifecycle(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	ready, down, draining := mock.Node(), mock.Node(), mock.Node()
+	down.Status = structs.NodeStatusDown
+	other := mock.Node()
+	other.Datacenter = "dc2"
+	for i, node := range []*structs.Node{ready, down, draining, other} {
+		require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+	}
+
+	// The third node was drained by an operator before the window started
+	strategy := &structs.DrainStrategy{DrainSpec: structs.DrainSpec{Deadline: -1}}
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 200, draining.ID, strategy, false, 0, nil, nil, ""))
+
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = nil
+	now := window.StartTime
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	updated, drains, next, err := advanceWindow(snap, window, now)
+	require.NoError(err)
+	require.Equal(window.EndTime(), next)
+	require.Equal(structs.NodeMaintenanceWindowStatusActive, updated.Status)
+	require.Len(updated.Nodes, 3)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusActive, updated.LookupNode(ready.ID).Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusActive, updated.LookupNode(down.ID).Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusSkipped, updated.LookupNode(draining.ID).Status)
+	require.Nil(updated.LookupNode(other.ID))
+	require.Len(drains, 2)
+	require.Equal(now.Add(time.Hour), drains[ready.ID].DrainStrategy.ForceDeadline)
+
+	events := NodeMaintenanceEvents(window.ID, drains)
+	require.Equal(NodeDrainEventMaintenanceStarted, events[ready.ID].Message)
+	require.Equal(window.ID, events[ready.ID].Details[NodeDrainEventDetailMaintenanceWindowID])
+
+	// The window doesn't change until it ends
+	require.NoError(store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 201,
+		&structs.NodeMaintenanceWindowUpsertRequest{
+			Windows:    []*structs.NodeMaintenanceWindow{updated},
+			NodeDrains: drains,
+		}))
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	unchanged, drains, _, err := advanceWindow(snap, updated, now.Add(time.Minute))
+	require.NoError(err)
+	require.Nil(unchanged)
+	require.Nil(drains)
+
+	// The ready node is made eligible again when the window ends, while the
+	// down node stays ineligible
+	updated, drains, _, err = advanceWindow(snap, updated, window.EndTime())
+	require.NoError(err)
+	require.Equal(structs.NodeMaintenanceWindowStatusComplete, updated.Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusRestored, updated.LookupNode(ready.ID).Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusUnhealthy, updated.LookupNode(down.ID).Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusSkipped, updated.LookupNode(draining.ID).Status)
+	require.Len(drains, 2)
+	require.True(drains[ready.ID].MarkEligible)
+	require.Nil(drains[ready.ID].DrainStrategy)
+	require.False(drains[down.ID].MarkEligible)
+
+	events = NodeMaintenanceEvents(window.ID, drains)
+	require.Equal(NodeDrainEventMaintenanceRestored, events[ready.ID].Message)
+	require.Equal(NodeDrainEventMaintenanceUnhealthy, events[down.ID].Message)
+
+	require.NoError(store.BatchUpdateNodeDrain(structs.MsgTypeTestSetup, 202, 0, drains, nil))
+	out, err := store.NodeByID(nil, ready.ID)
+	require.NoError(err)
+	require.Nil(out.DrainStrategy)
+	require.Equal(structs.NodeSchedulingEligible, out.SchedulingEligibility)
+	out, err = store.NodeByID(nil, down.ID)
+	require.NoError(err)
+	require.Nil(out.DrainStrategy)
+	require.Equal(structs.NodeSchedulingIneligible, out.SchedulingEligibility)
+	out, err = store.NodeByID(nil, draining.ID)
+	require.NoError(err)
+	require.NotNil(out.DrainStrategy)
+}
+
+func TestMaintenanceWatcher_HandleWindow_Cancelled(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	node := mock.Node()
+	require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = []string{node.ID}
+	require.NoError(store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 200,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{window}}))
+	window, err := store.NodeMaintenanceWindowByID(nil, window.ID)
+	require.NoError(err)
+
+	applier := &stateMaintenanceApplier{store: store, index: 300}
+	w := NewMaintenanceWatcher(&MaintenanceWatcherConfig{
+		Logger:                testlog.HCLogger(t),
+		Raft:                  applier,
+		StateQueriesPerSecond: 100,
+	})
+
+	// The window is cancelled after the watcher took its snapshot
+	snap, err := store.Snapshot()
+	require.NoError(err)
+
+	cancelled := window.Copy()
+	cancelled.Status = structs.NodeMaintenanceWindowStatusCancelled
+	cancelled.StatusDescription = structs.NodeMaintenanceWindowStatusDescriptionCancelled
+	require.NoError(store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 201,
+		&structs.NodeMaintenanceWindowUpsertRequest{
+			Windows:      []*structs.NodeMaintenanceWindow{cancelled},
+			EnforceIndex: true,
+			ModifyIndex:  window.ModifyIndex,
+		}))
+
+	// Starting the window doesn't overwrite the cancellation or drain the
+	// node
+	_, err = w.handleWindow(snap, window, window.StartTime)
+	require.Error(err)
+	require.Contains(err.Error(), "cancelled")
+
+	out, err := store.NodeMaintenanceWindowByID(nil, window.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeMaintenanceWindowStatusCancelled, out.Status)
+	require.Empty(out.Nodes)
+
+	outNode, err := store.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Nil(outNode.DrainStrategy)
+	require.Equal(structs.NodeSchedulingEligible, outNode.SchedulingEligibility)
+}
+
+func TestMaintenanceWatcher_HandleWindow_Overlapping(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	node := mock.Node()
+	require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	// The second window starts while the first is active, and ends after it
+	first := mock.NodeMaintenanceWindow()
+	first.NodeIDs = []string{node.ID}
+	second := mock.NodeMaintenanceWindow()
+	second.NodeIDs = []string{node.ID}
+	second.StartTime = first.StartTime.Add(first.Duration / 2)
+	require.NoError(store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 200,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{first, second}}))
+
+	applier := &stateMaintenanceApplier{store: store, index: 300}
+	w := NewMaintenanceWatcher(&MaintenanceWatcherConfig{
+		Logger:                testlog.HCLogger(t),
+		Raft:                  applier,
+		StateQueriesPerSecond: 100,
+	})
+	handle := func(id string, now time.Time) *structs.NodeMaintenanceWindow {
+		snap, err := store.Snapshot()
+		require.NoError(err)
+		window, err := snap.NodeMaintenanceWindowByID(nil, id)
+		require.NoError(err)
+		_, err = w.handleWindow(snap, window, now)
+		require.NoError(err)
+		out, err := store.NodeMaintenanceWindowByID(nil, id)
+		require.NoError(err)
+		return out
+	}
+	ineligible := func() {
+		out, err := store.NodeByID(nil, node.ID)
+		require.NoError(err)
+		require.NotNil(out.DrainStrategy)
+		require.Equal(structs.NodeSchedulingIneligible, out.SchedulingEligibility)
+	}
+
+	out := handle(first.ID, first.StartTime)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusActive, out.Nodes[0].Status)
+	ineligible()
+
+	// The second window takes over the node that the first is draining
+	out = handle(second.ID, second.StartTime)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusActive, out.Nodes[0].Status)
+	ineligible()
+
+	// The first window ending leaves the node to the second window
+	out = handle(first.ID, first.EndTime())
+	require.Equal(structs.NodeMaintenanceWindowStatusComplete, out.Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusSkipped, out.Nodes[0].Status)
+	ineligible()
+
+	out = handle(second.ID, second.EndTime())
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusRestored, out.Nodes[0].Status)
+	outNode, err := store.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Nil(outNode.DrainStrategy)
+	require.Equal(structs.NodeSchedulingEligible, outNode.SchedulingEligibility)
+}
+
+func TestMaintenanceWatcher_HandleWindow_OverlappingRace(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	node := mock.Node()
+	require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	first := mock.NodeMaintenanceWindow()
+	first.NodeIDs = []string{node.ID}
+	second := mock.NodeMaintenanceWindow()
+	second.NodeIDs = []string{node.ID}
+	second.StartTime = first.EndTime()
+	require.NoError(store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 200,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{first, second}}))
+
+	applier := &stateMaintenanceApplier{store: store, index: 300}
+	w := NewMaintenanceWatcher(&MaintenanceWatcherConfig{
+		Logger:                testlog.HCLogger(t),
+		Raft:                  applier,
+		StateQueriesPerSecond: 100,
+	})
+
+	snap, err := store.Snapshot()
+	require.NoError(err)
+	first, err = snap.NodeMaintenanceWindowByID(nil, first.ID)
+	require.NoError(err)
+	_, err = w.handleWindow(snap, first, first.StartTime)
+	require.NoError(err)
+
+	// The first window ends and the second starts from the same snapshot,
+	// and the second window's update is applied first
+	snap, err = store.Snapshot()
+	require.NoError(err)
+	first, err = snap.NodeMaintenanceWindowByID(nil, first.ID)
+	require.NoError(err)
+	second, err = snap.NodeMaintenanceWindowByID(nil, second.ID)
+	require.NoError(err)
+
+	_, err = w.handleWindow(snap, second, second.StartTime)
+	require.NoError(err)
+	_, err = w.handleWindow(snap, first, first.EndTime())
+	require.NoError(err)
+
+	// The node is kept ineligible by the second window
+	outNode, err := store.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.NotNil(outNode.DrainStrategy)
+	require.Equal(structs.NodeSchedulingIneligible, outNode.SchedulingEligibility)
+	require.Equal(second.ID, structs.NodeMaintenanceWindowID(outNode))
+}
+
+func TestMaintenanceWatcher_HandleWindow_OperatorDrain(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	store := state.TestStateStore(t)
+
+	node := mock.Node()
+	require.NoError(store.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = []string{node.ID}
+	require.NoError(store.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 200,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{window}}))
+
+	applier := &stateMaintenanceApplier{store: store, index: 300}
+	w := NewMaintenanceWatcher(&MaintenanceWatcherConfig{
+		Logger:                testlog.HCLogger(t),
+		Raft:                  applier,
+		StateQueriesPerSecond: 100,
+	})
+	handle := func(now time.Time) *structs.NodeMaintenanceWindow {
+		snap, err := store.Snapshot()
+		require.NoError(err)
+		out, err := snap.NodeMaintenanceWindowByID(nil, window.ID)
+		require.NoError(err)
+		_, err = w.handleWindow(snap, out, now)
+		require.NoError(err)
+		out, err = store.NodeMaintenanceWindowByID(nil, window.ID)
+		require.NoError(err)
+		return out
+	}
+
+	out := handle(window.StartTime)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusActive, out.Nodes[0].Status)
+
+	// An operator drains the node during the window, which takes it over
+	// from the window
+	strategy := &structs.DrainStrategy{DrainSpec: structs.DrainSpec{Deadline: -1}}
+	require.NoError(store.UpdateNodeDrain(structs.MsgTypeTestSetup, 400, node.ID, strategy, false, 0, nil, nil, ""))
+	outNode, err := store.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Empty(structs.NodeMaintenanceWindowID(outNode))
+
+	// The operator's drain survives the window's end
+	out = handle(window.EndTime())
+	require.Equal(structs.NodeMaintenanceWindowStatusComplete, out.Status)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusSkipped, out.Nodes[0].Status)
+	outNode, err = store.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.NotNil(outNode.DrainStrategy)
+	require.Equal(structs.NodeSchedulingIneligible, outNode.SchedulingEligibility)
+}
@@ -353,7 +353,9 @@ func handleTaskGroup(snap *state.StateSnapshot, batch bool, tg *structs.TaskGrou
 			}
 
 			// Check if the node exists and whether it has a drain strategy
-			onDrainingNode = node != nil && node.DrainStrategy != nil
+			// that doesn't skip the job
+			onDrainingNode = node != nil && node.DrainStrategy != nil &&
+				!node.DrainStrategy.SkipsJob(alloc.Namespace, alloc.JobID)
 			drainingNodes[alloc.NodeID] = onDrainingNode
 		}
 
@@ -736,3 +736,47 @@ func TestHandleTaskGroup_GarbageCollectedNode(t *testing.T) {
 	require.Empty(res.migrated)
 	require.True(res.done)
 }
+
+// This test asserts that the allocations of jobs skipped by the drain of their
+// node aren't drained
+func TestHandleTaskGroup_SkipJobs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Create a draining node skipping the job
+	state := state.TestStateStore(t)
+	job := mock.Job()
+	n := mock.Node()
+	n.DrainStrategy = &structs.DrainStrategy{
+		DrainSpec: structs.DrainSpec{
+			Deadline: 5 * time.Minute,
+			SkipJobs: []structs.NamespacedID{job.NamespacedID()},
+		},
+		ForceDeadline: time.Now().Add(5 * time.Minute),
+	}
+	require.Nil(state.UpsertNode(structs.MsgTypeTestSetup, 100, n))
+	require.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 101, job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		a := mock.Alloc()
+		a.JobID = job.ID
+		a.Job = job
+		a.TaskGroup = job.TaskGroups[0].Name
+		a.NodeID = n.ID
+		a.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+		}
+		allocs = append(allocs, a)
+	}
+	require.Nil(state.UpsertAllocs(structs.MsgTypeTestSetup, 102, allocs))
+
+	snap, err := state.Snapshot()
+	require.Nil(err)
+
+	res := newJobResult()
+	require.Nil(handleTaskGroup(snap, false, job.TaskGroups[0], allocs, 101, res))
+	require.Empty(res.drain)
+	require.Empty(res.migrated)
+	require.True(res.done)
+}
@@ -59,7 +59,12 @@ func (n *NodeDrainer) Update(node *structs.Node) {
 	}
 
 	// TODO test the notifier is updated
-	if inf, deadline := node.DrainStrategy.DeadlineTime(); !inf {
+	inf, deadline, err := draining.DeadlineTime()
+	if err != nil {
+		n.logger.Error("error retrieving drain deadline of node", "node_id", node.ID, "error", err)
+		return
+	}
+	if !inf {
 		n.deadlineNotifier.Watch(node.ID, deadline)
 	} else {
 		// There is an infinite deadline so it shouldn't be tracked for
@@ -321,6 +321,96 @@ func TestDrainer_Simple_ServiceOnly_Deadline(t *testing.T) {
 	require.Contains(node.Events[2].Details, drainer.NodeDrainEventDetailDeadlined)
 }
 
+func TestDrainer_DeadlineOverrides_SkipJobs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a node
+	n1 := mock.Node()
+	nodeReg := &structs.NodeRegisterRequest{
+		Node:         n1,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var nodeResp structs.NodeUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.Register", nodeReg, &nodeResp))
+
+	// Create two jobs running on the node
+	forced, skipped := mock.Job(), mock.Job()
+	for _, job := range []*structs.Job{forced, skipped} {
+		job.TaskGroups[0].Count = 1
+		req := &structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: job.Namespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.Nil(msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+		require.NotZero(resp.Index)
+	}
+
+	// Wait for the allocations to be placed
+	state := s1.State()
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, err := state.AllocsByNode(nil, n1.ID)
+		if err != nil {
+			return false, err
+		}
+		return len(allocs) == 2, fmt.Errorf("got %d allocs", len(allocs))
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Drain the node without a deadline, forcing the first job to stop and
+	// keeping the second job on the node
+	drainReq := &structs.NodeUpdateDrainRequest{
+		NodeID: n1.ID,
+		DrainStrategy: &structs.DrainStrategy{
+			DrainSpec: structs.DrainSpec{
+				Deadline: 0,
+				DeadlineOverrides: []*structs.DrainDeadline{
+					{Namespace: forced.Namespace, JobID: forced.ID, Deadline: -1},
+				},
+				SkipJobs: []structs.NamespacedID{skipped.NamespacedID()},
+			},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var drainResp structs.NodeDrainUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", drainReq, &drainResp))
+
+	// Check that the node drain is removed
+	testutil.WaitForResult(func() (bool, error) {
+		node, err := state.NodeByID(nil, n1.ID)
+		if err != nil {
+			return false, err
+		}
+		return node.DrainStrategy == nil, fmt.Errorf("has drain strategy still set")
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// Check that only the allocation of the first job was stopped
+	allocs, err := state.AllocsByNode(nil, n1.ID)
+	require.NoError(err)
+	require.Len(allocs, 2)
+	for _, alloc := range allocs {
+		switch alloc.JobID {
+		case forced.ID:
+			require.Equal(structs.AllocDesiredStatusStop, alloc.DesiredStatus)
+		case skipped.ID:
+			require.Equal(structs.AllocDesiredStatusRun, alloc.DesiredStatus)
+			require.False(alloc.DesiredTransition.ShouldMigrate())
+		}
+	}
+}
+
 func TestDrainer_DrainEmptyNode(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
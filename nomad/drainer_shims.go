@@ -6,8 +6,9 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
-// drainerShim implements the drainer.RaftApplier interface required by the
-// NodeDrainer.
+// drainerShim implements the drainer.RaftApplier, drainer.BatchRaftApplier and
+// drainer.MaintenanceRaftApplier interfaces required by the NodeDrainer,
+// BatchDrainer and MaintenanceWatcher.
 type drainerShim struct {
 	s *Server
 }
@@ -42,6 +43,36 @@ func (d drainerShim) AllocUpdateDesiredTransition(allocs map[string]*structs.Des
 	return d.convertApplyErrors(resp, index, err)
 }
 
+func (d drainerShim) UpsertDrainBatch(batch *structs.DrainBatch, modifyIndex uint64,
+	drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error) {
+	args := &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{batch},
+		NodeDrains:   drains,
+		NodeEvents:   events,
+		EnforceIndex: true,
+		ModifyIndex:  modifyIndex,
+		WriteRequest: structs.WriteRequest{Region: d.s.config.Region},
+		UpdatedAt:    time.Now().Unix(),
+	}
+	resp, index, err := d.s.raftApply(structs.DrainBatchUpsertRequestType, args)
+	return d.convertApplyErrors(resp, index, err)
+}
+
+func (d drainerShim) UpsertNodeMaintenanceWindow(window *structs.NodeMaintenanceWindow, modifyIndex uint64,
+	drains map[string]*structs.DrainUpdate, events map[string]*structs.NodeEvent) (uint64, error) {
+	args := &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{window},
+		NodeDrains:   drains,
+		NodeEvents:   events,
+		EnforceIndex: true,
+		ModifyIndex:  modifyIndex,
+		WriteRequest: structs.WriteRequest{Region: d.s.config.Region},
+		UpdatedAt:    time.Now().Unix(),
+	}
+	resp, index, err := d.s.raftApply(structs.NodeMaintenanceWindowUpsertRequestType, args)
+	return d.convertApplyErrors(resp, index, err)
+}
+
 // convertApplyErrors parses the results of a raftApply and returns the index at
 // which it was applied and any error that occurred. Raft Apply returns two
 // separate errors, Raft library errors and user returned errors from the FSM.
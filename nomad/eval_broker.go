@@ -65,6 +65,18 @@ type EvalBroker struct {
 	// ready tracks the ready jobs by scheduler in a priority queue
 	ready map[string]PendingEvaluations
 
+	// fairness enables dequeuing ready evaluations of equal priority fairly
+	// across namespaces. When enabled, ready evaluations are tracked in
+	// fairReady instead of ready.
+	fairness bool
+
+	// fairReady tracks the ready jobs by scheduler when fairness is enabled
+	fairReady map[string]*fairQueue
+
+	// namespaceWeight returns the weight of a namespace when fairness is
+	// enabled
+	namespaceWeight func(namespace string) int
+
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
 
@@ -136,6 +148,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		jobEvals:             make(map[structs.NamespacedID]string),
 		blocked:              make(map[structs.NamespacedID]PendingEvaluations),
 		ready:                make(map[string]PendingEvaluations),
+		fairReady:            make(map[string]*fairQueue),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -146,10 +159,56 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	return b, nil
 }
 
+// SetFairness is used to control if ready evaluations of equal priority are
+// dequeued fairly across namespaces rather than by index. The weight function
+// returns the share of dequeues a namespace receives relative to the others;
+// weights below one are treated as one.
+func (b *EvalBroker) SetFairness(enabled bool, weight func(namespace string) int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.namespaceWeight = weight
+	if b.fairness == enabled {
+		return
+	}
+
+	// Move the ready evaluations over to the new queues
+	var evals []*structs.Evaluation
+	queues := make(map[*structs.Evaluation]string)
+	for sched, pending := range b.ready {
+		for _, eval := range pending {
+			evals = append(evals, eval)
+			queues[eval] = sched
+		}
+	}
+	for sched, pending := range b.fairReady {
+		for _, eval := range pending.Evals() {
+			evals = append(evals, eval)
+			queues[eval] = sched
+		}
+	}
+
+	b.fairness = enabled
+	b.ready = make(map[string]PendingEvaluations)
+	b.fairReady = make(map[string]*fairQueue)
+	for _, eval := range evals {
+		b.pushReady(queues[eval], eval)
+	}
+}
+
+// Fairness returns whether ready evaluations are dequeued fairly across
+// namespaces.
+func (b *EvalBroker) Fairness() bool {
+	b.l.RLock()
+	defer b.l.RUnlock()
+	return b.fairness
+}
+
 // Enabled is used to check if the broker is enabled.
 func (b *EvalBroker) Enabled() bool {
 	b.l.RLock()
@@ -296,18 +355,11 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		return
 	}
 
-	// Find the pending by scheduler class
-	pending, ok := b.ready[queue]
-	if !ok {
-		pending = make([]*structs.Evaluation, 0, 16)
-		if _, ok := b.waiting[queue]; !ok {
-			b.waiting[queue] = make(chan struct{}, 1)
-		}
+	// Push onto the pending queue of the scheduler class
+	if _, ok := b.waiting[queue]; !ok {
+		b.waiting[queue] = make(chan struct{}, 1)
 	}
-
-	// Push onto the heap
-	heap.Push(&pending, eval)
-	b.ready[queue] = pending
+	b.pushReady(queue, eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -317,6 +369,7 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		b.stats.ByScheduler[queue] = bySched
 	}
 	bySched.Ready += 1
+	b.namespaceStats(eval.Namespace).Ready += 1
 
 	// Unblock any blocked dequeues
 	select {
@@ -376,14 +429,8 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 	var eligibleSched []string
 	var eligiblePriority int
 	for _, sched := range schedulers {
-		// Get the pending queue
-		pending, ok := b.ready[sched]
-		if !ok {
-			continue
-		}
-
 		// Peek at the next item
-		ready := pending.Peek()
+		ready := b.peekReady(sched)
 		if ready == nil {
 			continue
 		}
@@ -422,11 +469,8 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 // dequeueForSched is used to dequeue the next work item for a given scheduler.
 // This assumes locks are held and that this scheduler has work
 func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
-	// Get the pending queue
-	pending := b.ready[sched]
-	raw := heap.Pop(&pending)
-	b.ready[sched] = pending
-	eval := raw.(*structs.Evaluation)
+	// Get the next item
+	eval := b.popReady(sched)
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -452,10 +496,84 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	byNs := b.namespaceStats(eval.Namespace)
+	byNs.Ready -= 1
+	byNs.Unacked += 1
 
 	return eval, token, nil
 }
 
+// pushReady adds an evaluation to the ready queue of a scheduler. This
+// assumes locks are held.
+func (b *EvalBroker) pushReady(sched string, eval *structs.Evaluation) {
+	if b.fairness {
+		pending, ok := b.fairReady[sched]
+		if !ok {
+			pending = newFairQueue()
+			b.fairReady[sched] = pending
+		}
+		pending.Push(eval)
+		return
+	}
+
+	pending, ok := b.ready[sched]
+	if !ok {
+		pending = make([]*structs.Evaluation, 0, 16)
+	}
+	heap.Push(&pending, eval)
+	b.ready[sched] = pending
+}
+
+// peekReady returns the next evaluation in the ready queue of a scheduler
+// without removing it. This assumes locks are held.
+func (b *EvalBroker) peekReady(sched string) *structs.Evaluation {
+	if b.fairness {
+		pending, ok := b.fairReady[sched]
+		if !ok {
+			return nil
+		}
+		return pending.Peek()
+	}
+
+	pending, ok := b.ready[sched]
+	if !ok {
+		return nil
+	}
+	return pending.Peek()
+}
+
+// popReady removes the next evaluation from the ready queue of a scheduler.
+// This assumes locks are held and that this scheduler has work.
+func (b *EvalBroker) popReady(sched string) *structs.Evaluation {
+	if b.fairness {
+		return b.fairReady[sched].Pop(b.namespaceWeight)
+	}
+
+	pending := b.ready[sched]
+	raw := heap.Pop(&pending)
+	b.ready[sched] = pending
+	return raw.(*structs.Evaluation)
+}
+
+// namespaceStats returns the stats of a namespace, creating them if needed.
+// This assumes locks are held.
+func (b *EvalBroker) namespaceStats(namespace string) *NamespaceStats {
+	byNs, ok := b.stats.ByNamespace[namespace]
+	if !ok {
+		byNs = &NamespaceStats{}
+		b.stats.ByNamespace[namespace] = byNs
+	}
+	return byNs
+}
+
+// releaseNamespaceStats removes the stats of a namespace once nothing of it
+// is queued. This assumes locks are held.
+func (b *EvalBroker) releaseNamespaceStats(namespace string) {
+	if byNs, ok := b.stats.ByNamespace[namespace]; ok && byNs.Ready == 0 && byNs.Unacked == 0 {
+		delete(b.stats.ByNamespace, namespace)
+	}
+}
+
 // waitForSchedulers is used to wait for work on any of the scheduler or until a timeout.
 // Returns if there is work waiting potentially.
 func (b *EvalBroker) waitForSchedulers(schedulers []string, timeoutCh <-chan time.Time) bool {
@@ -559,6 +677,8 @@ func (b *EvalBroker) Ack(evalID, token string) error {
 	}
 	bySched := b.stats.ByScheduler[queue]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
+	b.releaseNamespaceStats(unack.Eval.Namespace)
 
 	// Cleanup
 	delete(b.unack, evalID)
@@ -619,6 +739,8 @@ func (b *EvalBroker) Nack(evalID, token string) error {
 	b.stats.TotalUnacked -= 1
 	bySched := b.stats.ByScheduler[unack.Eval.Type]
 	bySched.Unacked -= 1
+	b.namespaceStats(unack.Eval.Namespace).Unacked -= 1
+	b.releaseNamespaceStats(unack.Eval.Namespace)
 
 	// Check if we've hit the delivery limit, and re-enqueue
 	// in the failedQueue
@@ -720,10 +842,12 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalBlocked = 0
 	b.stats.TotalWaiting = 0
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
 	b.ready = make(map[string]PendingEvaluations)
+	b.fairReady = make(map[string]*fairQueue)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -804,6 +928,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	// Allocate a new stats struct
 	stats := new(BrokerStats)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -818,11 +943,19 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		*subStatCopy = *subStat
 		stats.ByScheduler[sched] = subStatCopy
 	}
+	for namespace, subStat := range b.stats.ByNamespace {
+		subStatCopy := new(NamespaceStats)
+		*subStatCopy = *subStat
+		stats.ByNamespace[namespace] = subStatCopy
+	}
 	return stats
 }
 
 // EmitStats is used to export metrics about the broker while enabled
 func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
+	// namespaces tracks the namespaces with gauges so they are zeroed once
+	// nothing of the namespace is queued
+	namespaces := make(map[string]struct{})
 	for {
 		select {
 		case <-time.After(period):
@@ -835,6 +968,20 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace := range namespaces {
+				if _, ok := stats.ByNamespace[namespace]; !ok {
+					stats.ByNamespace[namespace] = &NamespaceStats{}
+					delete(namespaces, namespace)
+				}
+			}
+			for namespace, nsStats := range stats.ByNamespace {
+				labels := []metrics.Label{{Name: "namespace", Value: namespace}}
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "ready"}, float32(nsStats.Ready), labels)
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace", "unacked"}, float32(nsStats.Unacked), labels)
+				if nsStats.Ready != 0 || nsStats.Unacked != 0 {
+					namespaces[namespace] = struct{}{}
+				}
+			}
 
 		case <-stopCh:
 			return
@@ -849,6 +996,7 @@ type BrokerStats struct {
 	TotalBlocked int
 	TotalWaiting int
 	ByScheduler  map[string]*SchedulerStats
+	ByNamespace  map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -857,6 +1005,12 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace
+type NamespaceStats struct {
+	Ready   int
+	Unacked int
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
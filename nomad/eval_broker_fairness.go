@@ -0,0 +1,155 @@
+package nomad
+
+import (
+	"container/heap"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// vtimeUnit is how much virtual time a namespace of weight one is charged per
+// dequeue. It is divisible by every weight up to 16, so that the virtual
+// times of namespaces with common weights advance exactly.
+const vtimeUnit = 720720
+
+// fairQueue holds the ready evaluations of a scheduler when the broker
+// dequeues fairly across namespaces. Evaluations are still dequeued highest
+// priority first, but evaluations of equal priority are dequeued using
+// weighted fair queuing across namespaces instead of by index, so a namespace
+// submitting a burst of evaluations does not starve the others.
+//
+// Every namespace with ready evaluations has a virtual time that advances by
+// vtimeUnit divided by its weight each time one of its evaluations is
+// dequeued. The namespace with the lowest virtual time is dequeued next. A
+// namespace that becomes ready starts at the lowest virtual time of the ready
+// namespaces so it can't claim credit for the time it was idle.
+type fairQueue struct {
+	// pending is the ready evaluations by namespace
+	pending map[string]PendingEvaluations
+
+	// vtime is the virtual time of each namespace in pending
+	vtime map[string]uint64
+
+	// size is the total number of ready evaluations
+	size int
+}
+
+// newFairQueue returns an empty fairQueue.
+func newFairQueue() *fairQueue {
+	return &fairQueue{
+		pending: make(map[string]PendingEvaluations),
+		vtime:   make(map[string]uint64),
+	}
+}
+
+// Len returns the number of ready evaluations.
+func (q *fairQueue) Len() int {
+	return q.size
+}
+
+// Push adds a ready evaluation.
+func (q *fairQueue) Push(eval *structs.Evaluation) {
+	pending, ok := q.pending[eval.Namespace]
+	if !ok {
+		q.vtime[eval.Namespace] = q.minVirtualTime()
+	}
+	heap.Push(&pending, eval)
+	q.pending[eval.Namespace] = pending
+	q.size++
+}
+
+// Peek returns the evaluation that would be dequeued next, or nil if there
+// are no ready evaluations.
+func (q *fairQueue) Peek() *structs.Evaluation {
+	namespace := q.next()
+	if namespace == "" {
+		return nil
+	}
+	return q.pending[namespace].Peek()
+}
+
+// Pop dequeues the next evaluation, charging its namespace according to the
+// weight returned by the weight function.
+func (q *fairQueue) Pop(weight func(namespace string) int) *structs.Evaluation {
+	namespace := q.next()
+	if namespace == "" {
+		return nil
+	}
+
+	pending := q.pending[namespace]
+	eval := heap.Pop(&pending).(*structs.Evaluation)
+	q.size--
+
+	if len(pending) == 0 {
+		delete(q.pending, namespace)
+		delete(q.vtime, namespace)
+		return eval
+	}
+
+	q.pending[namespace] = pending
+	w := 1
+	if weight != nil {
+		if nw := weight(namespace); nw > 1 {
+			w = nw
+		}
+	}
+	q.vtime[namespace] += vtimeUnit / uint64(w)
+	return eval
+}
+
+// Evals returns all ready evaluations in no particular order.
+func (q *fairQueue) Evals() []*structs.Evaluation {
+	evals := make([]*structs.Evaluation, 0, q.size)
+	for _, pending := range q.pending {
+		evals = append(evals, pending...)
+	}
+	return evals
+}
+
+// next returns the namespace to dequeue from next, or the empty string if
+// there are no ready evaluations. Of the namespaces whose next evaluation has
+// the highest priority, it picks the one with the lowest virtual time, breaking
+// ties by index.
+func (q *fairQueue) next() string {
+	var namespace string
+	var best *structs.Evaluation
+	for ns, pending := range q.pending {
+		eval := pending.Peek()
+		if best == nil {
+			namespace, best = ns, eval
+			continue
+		}
+
+		switch {
+		case eval.Priority != best.Priority:
+			if eval.Priority < best.Priority {
+				continue
+			}
+		case q.vtime[ns] != q.vtime[namespace]:
+			if q.vtime[ns] > q.vtime[namespace] {
+				continue
+			}
+		case eval.CreateIndex != best.CreateIndex:
+			if eval.CreateIndex > best.CreateIndex {
+				continue
+			}
+		case ns > namespace:
+			continue
+		}
+		namespace, best = ns, eval
+	}
+	return namespace
+}
+
+// minVirtualTime returns the lowest virtual time of the namespaces with ready
+// evaluations.
+func (q *fairQueue) minVirtualTime() uint64 {
+	first := true
+	var min uint64
+	for _, t := range q.vtime {
+		if first || t < min {
+			min = t
+			first = false
+		}
+	}
+	return min
+}
@@ -869,6 +869,106 @@ func TestEvalBroker_Dequeue_Fairness(t *testing.T) {
 	}
 }
 
+// Ensure fairness between namespaces of equal priority
+func TestEvalBroker_Dequeue_NamespaceFairness(t *testing.T) {
+	t.Parallel()
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Queue a burst from one namespace before enabling fairness
+	for i := 1; i <= 6; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "burst"
+		eval.CreateIndex = uint64(i)
+		b.Enqueue(eval)
+	}
+	b.SetFairness(true, nil)
+	require.True(t, b.Fairness())
+
+	for i := 7; i <= 9; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "other"
+		eval.CreateIndex = uint64(i)
+		b.Enqueue(eval)
+	}
+
+	// Higher priorities are still dequeued first
+	urgent := mock.Eval()
+	urgent.Namespace = "burst"
+	urgent.Priority = 60
+	urgent.CreateIndex = 10
+	b.Enqueue(urgent)
+
+	stats := b.Stats()
+	require.Equal(t, 10, stats.TotalReady)
+	require.Equal(t, 7, stats.ByNamespace["burst"].Ready)
+	require.Equal(t, 3, stats.ByNamespace["other"].Ready)
+
+	var order []string
+	for i := 0; i < 10; i++ {
+		out, token, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		if i == 0 {
+			require.Equal(t, urgent, out)
+		}
+		order = append(order, out.Namespace)
+		require.NoError(t, b.Ack(out.ID, token))
+	}
+
+	expected := []string{
+		"burst",
+		"other", "burst", "other", "burst", "other",
+		"burst", "burst", "burst", "burst",
+	}
+	require.Equal(t, expected, order)
+	require.Empty(t, b.Stats().ByNamespace)
+}
+
+// Ensure namespaces are dequeued in proportion to their weights
+func TestEvalBroker_Dequeue_NamespaceFairness_Weights(t *testing.T) {
+	t.Parallel()
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairness(true, func(namespace string) int {
+		if namespace == "heavy" {
+			return 3
+		}
+		return 0
+	})
+
+	for i := 1; i <= 16; i++ {
+		eval := mock.Eval()
+		eval.Namespace = "light"
+		if i > 8 {
+			eval.Namespace = "heavy"
+		}
+		eval.CreateIndex = uint64(i)
+		b.Enqueue(eval)
+	}
+
+	var order []string
+	for i := 0; i < 12; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		order = append(order, out.Namespace)
+	}
+
+	expected := []string{
+		"light", "heavy", "heavy", "heavy",
+		"light", "heavy", "heavy", "heavy",
+		"light", "heavy", "heavy", "light",
+	}
+	require.Equal(t, expected, order)
+
+	stats := b.Stats()
+	require.Equal(t, 4, stats.ByNamespace["light"].Ready)
+	require.Equal(t, 4, stats.ByNamespace["light"].Unacked)
+	require.Equal(t, 8, stats.ByNamespace["heavy"].Unacked)
+	require.NotContains(t, stats.ByNamespace, "default")
+}
+
 // Ensure we get unblocked
 func TestEvalBroker_Dequeue_Blocked(t *testing.T) {
 	t.Parallel()
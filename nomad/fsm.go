@@ -53,6 +53,10 @@ const (
 	CSIVolumeSnapshot                    SnapshotType = 18
 	ScalingEventsSnapshot                SnapshotType = 19
 	EventSinkSnapshot                    SnapshotType = 20
+	PreemptionHistorySnapshot            SnapshotType = 21
+	HostVolumeSnapshot                   SnapshotType = 22
+	DrainBatchSnapshot                   SnapshotType = 23
+	NodeMaintenanceWindowSnapshot        SnapshotType = 24
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
 )
@@ -241,6 +245,8 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyDeploymentPromotion(msgType, buf[1:], log.Index)
 	case structs.DeploymentAllocHealthRequestType:
 		return n.applyDeploymentAllocHealth(msgType, buf[1:], log.Index)
+	case structs.DeploymentCanaryStepRequestType:
+		return n.applyDeploymentCanaryStep(msgType, buf[1:], log.Index)
 	case structs.DeploymentDeleteRequestType:
 		return n.applyDeploymentDelete(buf[1:], log.Index)
 	case structs.JobStabilityRequestType:
@@ -283,6 +289,8 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyCSIVolumeDeregister(buf[1:], log.Index)
 	case structs.CSIVolumeClaimRequestType:
 		return n.applyCSIVolumeClaim(buf[1:], log.Index)
+	case structs.CSIVolumeExpandRequestType:
+		return n.applyCSIVolumeExpand(buf[1:], log.Index)
 	case structs.ScalingEventRegisterRequestType:
 		return n.applyUpsertScalingEvent(buf[1:], log.Index)
 	case structs.CSIVolumeClaimBatchRequestType:
@@ -305,6 +313,14 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyOneTimeTokenDelete(msgType, buf[1:], log.Index)
 	case structs.OneTimeTokenExpireRequestType:
 		return n.applyOneTimeTokenExpire(msgType, buf[1:], log.Index)
+	case structs.HostVolumeUpsertRequestType:
+		return n.applyHostVolumeUpsert(buf[1:], log.Index)
+	case structs.HostVolumeDeleteRequestType:
+		return n.applyHostVolumeDelete(buf[1:], log.Index)
+	case structs.DrainBatchUpsertRequestType:
+		return n.applyDrainBatchUpsert(msgType, buf[1:], log.Index)
+	case structs.NodeMaintenanceWindowUpsertRequestType:
+		return n.applyNodeMaintenanceWindowUpsert(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -465,6 +481,34 @@ func (n *nomadFSM) applyBatchDrainUpdate(msgType structs.MessageType, buf []byte
 	return nil
 }
 
+func (n *nomadFSM) applyDrainBatchUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "drain_batch_upsert"}, time.Now())
+	var req structs.DrainBatchUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertDrainBatches(msgType, index, &req); err != nil {
+		n.logger.Error("UpsertDrainBatches failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+func (n *nomadFSM) applyNodeMaintenanceWindowUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_maintenance_window_upsert"}, time.Now())
+	var req structs.NodeMaintenanceWindowUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertNodeMaintenanceWindows(msgType, index, &req); err != nil {
+		n.logger.Error("UpsertNodeMaintenanceWindows failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "node_eligibility_update"}, time.Now())
 	var req structs.NodeUpdateEligibilityRequest
@@ -1045,6 +1089,24 @@ func (n *nomadFSM) applyDeploymentAllocHealth(msgType structs.MessageType, buf [
 	return nil
 }
 
+// applyDeploymentCanaryStep is used to move a deployment through its canary
+// steps
+func (n *nomadFSM) applyDeploymentCanaryStep(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_canary_step"}, time.Now())
+	var req structs.ApplyDeploymentCanaryStepRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpdateDeploymentCanaryStep(msgType, index, &req); err != nil {
+		n.logger.Error("UpdateDeploymentCanaryStep failed", "error", err)
+		return err
+	}
+
+	n.handleUpsertedEval(req.Eval)
+	return nil
+}
+
 // applyDeploymentDelete is used to delete a set of deployments
 func (n *nomadFSM) applyDeploymentDelete(buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_deployment_delete"}, time.Now())
@@ -1248,6 +1310,36 @@ func (n *nomadFSM) applyCSIVolumeRegister(buf []byte, index uint64) interface{}
 	return nil
 }
 
+func (n *nomadFSM) applyHostVolumeUpsert(buf []byte, index uint64) interface{} {
+	var req structs.HostVolumeUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_host_volume_upsert"}, time.Now())
+
+	if err := n.state.UpsertHostVolumes(index, req.Volumes); err != nil {
+		n.logger.Error("UpsertHostVolumes failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (n *nomadFSM) applyHostVolumeDelete(buf []byte, index uint64) interface{} {
+	var req structs.HostVolumeDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_host_volume_delete"}, time.Now())
+
+	if err := n.state.DeleteHostVolume(index, req.RequestNamespace(), req.VolumeID); err != nil {
+		n.logger.Error("DeleteHostVolume failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
 func (n *nomadFSM) applyCSIVolumeDeregister(buf []byte, index uint64) interface{} {
 	var req structs.CSIVolumeDeregisterRequest
 	if err := structs.Decode(buf, &req); err != nil {
@@ -1288,6 +1380,15 @@ func (n *nomadFSM) applyCSIVolumeClaim(buf []byte, index uint64) interface{} {
 	}
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_csi_volume_claim"}, time.Now())
 
+	if req.Error != "" {
+		err := n.state.CSIVolumeClaimFailed(index, req.RequestNamespace(), req.VolumeID, req.ToClaim(), req.Error)
+		if err != nil {
+			n.logger.Error("CSIVolumeClaimFailed failed", "error", err)
+			return err
+		}
+		return nil
+	}
+
 	if err := n.state.CSIVolumeClaim(index, req.RequestNamespace(), req.VolumeID, req.ToClaim()); err != nil {
 		n.logger.Error("CSIVolumeClaim failed", "error", err)
 		return err
@@ -1295,6 +1396,21 @@ func (n *nomadFSM) applyCSIVolumeClaim(buf []byte, index uint64) interface{} {
 	return nil
 }
 
+func (n *nomadFSM) applyCSIVolumeExpand(buf []byte, index uint64) interface{} {
+	var req structs.CSIVolumeExpandRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_csi_volume_expand"}, time.Now())
+
+	if err := n.state.CSIVolumeExpand(index, req.RequestNamespace(), req.VolumeID,
+		req.Capacity, req.RequestedCapacityMin, req.RequestedCapacityMax); err != nil {
+		n.logger.Error("CSIVolumeExpand failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (n *nomadFSM) applyCSIPluginDelete(buf []byte, index uint64) interface{} {
 	var req structs.CSIPluginDeleteRequest
 	if err := structs.Decode(buf, &req); err != nil {
@@ -1597,6 +1713,16 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case PreemptionHistorySnapshot:
+			history := new(structs.JobPreemptionHistory)
+			if err := dec.Decode(history); err != nil {
+				return err
+			}
+
+			if err := restore.PreemptionHistoryRestore(history); err != nil {
+				return err
+			}
+
 		case ScalingPolicySnapshot:
 			scalingPolicy := new(structs.ScalingPolicy)
 			if err := dec.Decode(scalingPolicy); err != nil {
@@ -1621,6 +1747,36 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case HostVolumeSnapshot:
+			volume := new(structs.HostVolume)
+			if err := dec.Decode(volume); err != nil {
+				return err
+			}
+
+			if err := restore.HostVolumeRestore(volume); err != nil {
+				return err
+			}
+
+		case DrainBatchSnapshot:
+			batch := new(structs.DrainBatch)
+			if err := dec.Decode(batch); err != nil {
+				return err
+			}
+
+			if err := restore.DrainBatchRestore(batch); err != nil {
+				return err
+			}
+
+		case NodeMaintenanceWindowSnapshot:
+			window := new(structs.NodeMaintenanceWindow)
+			if err := dec.Decode(window); err != nil {
+				return err
+			}
+
+			if err := restore.NodeMaintenanceWindowRestore(window); err != nil {
+				return err
+			}
+
 		case CSIVolumeSnapshot:
 			plugin := new(structs.CSIVolume)
 			if err := dec.Decode(plugin); err != nil {
@@ -1929,6 +2085,10 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistPreemptionHistories(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistCSIPlugins(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -1937,6 +2097,18 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistHostVolumes(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistDrainBatches(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistNodeMaintenanceWindows(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	if err := s.persistACLPolicies(sink, encoder); err != nil {
 		sink.Cancel()
 		return err
@@ -2434,6 +2606,33 @@ func (s *nomadSnapshot) persistScalingEvents(sink raft.SnapshotSink, encoder *co
 	return nil
 }
 
+func (s *nomadSnapshot) persistPreemptionHistories(sink raft.SnapshotSink, encoder *codec.Encoder) error {
+	// Get the preemption history of all jobs
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.PreemptionHistories(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		history := raw.(*structs.JobPreemptionHistory)
+
+		// Write out a preemption history snapshot
+		sink.Write([]byte{byte(PreemptionHistorySnapshot)})
+		if err := encoder.Encode(history); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistCSIPlugins(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
@@ -2463,6 +2662,93 @@ func (s *nomadSnapshot) persistCSIPlugins(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *nomadSnapshot) persistHostVolumes(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the dynamic host volumes
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.HostVolumes(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		volume := raw.(*structs.HostVolume)
+
+		// Write out a host volume snapshot
+		sink.Write([]byte{byte(HostVolumeSnapshot)})
+		if err := encoder.Encode(volume); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistDrainBatches(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the drain batches
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.DrainBatches(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		batch := raw.(*structs.DrainBatch)
+
+		// Write out a drain batch snapshot
+		sink.Write([]byte{byte(DrainBatchSnapshot)})
+		if err := encoder.Encode(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistNodeMaintenanceWindows(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the node maintenance windows
+	ws := memdb.NewWatchSet()
+	iter, err := s.snap.NodeMaintenanceWindows(ws)
+	if err != nil {
+		return err
+	}
+
+	for {
+		// Get the next item
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		// Prepare the request struct
+		window := raw.(*structs.NodeMaintenanceWindow)
+
+		// Write out a node maintenance window snapshot
+		sink.Write([]byte{byte(NodeMaintenanceWindowSnapshot)})
+		if err := encoder.Encode(window); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *nomadSnapshot) persistCSIVolumes(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 
@@ -426,6 +426,78 @@ func TestFSM_UpdateNodeDrain(t *testing.T) {
 	require.Len(node.Events, 2)
 }
 
+func TestFSM_UpsertDrainBatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	node := mock.Node()
+	require.NoError(fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1, node))
+
+	batch := mock.DrainBatch()
+	batch.Nodes[0].NodeID = node.ID
+	batch.Nodes[0].Status = structs.DrainBatchNodeStatusDraining
+	strategy := &structs.DrainStrategy{
+		DrainSpec: *batch.DrainSpec,
+	}
+	req := structs.DrainBatchUpsertRequest{
+		Batches:    []*structs.DrainBatch{batch},
+		NodeDrains: map[string]*structs.DrainUpdate{node.ID: {DrainStrategy: strategy}},
+	}
+	buf, err := structs.Encode(structs.DrainBatchUpsertRequestType, req)
+	require.Nil(err)
+
+	resp := fsm.Apply(makeLog(buf))
+	require.Nil(resp)
+
+	out, err := fsm.State().DrainBatchByID(nil, batch.ID)
+	require.Nil(err)
+	require.NotNil(out)
+	require.Equal(structs.DrainBatchNodeStatusDraining, out.Nodes[0].Status)
+
+	node, err = fsm.State().NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.Equal(strategy, node.DrainStrategy)
+}
+
+func TestFSM_UpsertNodeMaintenanceWindows(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	fsm := testFSM(t)
+
+	node := mock.Node()
+	require.NoError(fsm.State().UpsertNode(structs.MsgTypeTestSetup, 1, node))
+
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = []string{node.ID}
+	window.Status = structs.NodeMaintenanceWindowStatusActive
+	window.Nodes = []*structs.NodeMaintenanceWindowNode{{
+		NodeID: node.ID,
+		Status: structs.NodeMaintenanceWindowNodeStatusActive,
+	}}
+	strategy := &structs.DrainStrategy{
+		DrainSpec: *window.DrainSpec,
+	}
+	req := structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:    []*structs.NodeMaintenanceWindow{window},
+		NodeDrains: map[string]*structs.DrainUpdate{node.ID: {DrainStrategy: strategy}},
+	}
+	buf, err := structs.Encode(structs.NodeMaintenanceWindowUpsertRequestType, req)
+	require.Nil(err)
+
+	resp := fsm.Apply(makeLog(buf))
+	require.Nil(resp)
+
+	out, err := fsm.State().NodeMaintenanceWindowByID(nil, window.ID)
+	require.Nil(err)
+	require.NotNil(out)
+	require.Equal(structs.NodeMaintenanceWindowStatusActive, out.Status)
+
+	node, err = fsm.State().NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.Equal(strategy, node.DrainStrategy)
+}
+
 func TestFSM_UpdateNodeEligibility(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -2785,6 +2857,52 @@ func TestFSM_SnapshotRestore_Deployments(t *testing.T) {
 	}
 }
 
+func TestFSM_SnapshotRestore_DrainBatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	b1 := mock.DrainBatch()
+	b2 := mock.DrainBatch()
+	req := &structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{b1, b2}}
+	require.NoError(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1000, req))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, err := state2.DrainBatchByID(nil, b1.ID)
+	require.NoError(err)
+	out2, err := state2.DrainBatchByID(nil, b2.ID)
+	require.NoError(err)
+	require.Equal(b1, out1)
+	require.Equal(b2, out2)
+}
+
+func TestFSM_SnapshotRestore_NodeMaintenanceWindows(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	w1 := mock.NodeMaintenanceWindow()
+	w2 := mock.NodeMaintenanceWindow()
+	req := &structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{w1, w2}}
+	require.NoError(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1000, req))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, err := state2.NodeMaintenanceWindowByID(nil, w1.ID)
+	require.NoError(err)
+	out2, err := state2.NodeMaintenanceWindowByID(nil, w2.ID)
+	require.NoError(err)
+	require.Equal(w1, out1)
+	require.Equal(w2, out2)
+}
+
 func TestFSM_SnapshotRestore_ACLPolicy(t *testing.T) {
 	t.Parallel()
 	// Add some state
@@ -3259,6 +3377,106 @@ func TestFSM_SnapshotRestore_Namespaces(t *testing.T) {
 	}
 }
 
+func TestFSM_SnapshotRestore_PreemptionHistory(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+
+	job := mock.Job()
+	job.Preemption = &structs.PreemptionPolicy{
+		Preemptible:    true,
+		MaxPreemptions: 1,
+		Window:         time.Hour,
+	}
+	preempted := mock.Alloc()
+	preempted.Job = job
+	preempted.JobID = job.ID
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{preempted}))
+
+	alloc := mock.Alloc()
+	req := &structs.ApplyPlanResultsRequest{
+		AllocUpdateRequest: structs.AllocUpdateRequest{
+			AllocsUpdated: []*structs.Allocation{alloc},
+			Job:           alloc.Job,
+		},
+		AllocsPreempted: []*structs.AllocationDiff{{
+			ID:                    preempted.ID,
+			PreemptedByAllocation: alloc.ID,
+			ModifyTime:            time.Now().UnixNano(),
+		}},
+	}
+	require.NoError(t, state.UpsertPlanResults(structs.MsgTypeTestSetup, 1002, req))
+	history, err := state.PreemptionHistoryByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, history)
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out, err := state2.PreemptionHistoryByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, history, out)
+}
+
+func TestFSM_SnapshotRestore_HostVolumes(t *testing.T) {
+	t.Parallel()
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+
+	node := mock.Node()
+	vol1 := mock.HostVolume(node)
+	vol2 := mock.HostVolume(node)
+	vol2.Name = "other-host-vol"
+	require.NoError(t, state.UpsertHostVolumes(1000, []*structs.HostVolume{vol1, vol2}))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	out1, err := state2.HostVolumeByID(nil, vol1.Namespace, vol1.ID)
+	require.NoError(t, err)
+	require.Equal(t, vol1, out1)
+	out2, err := state2.HostVolumeByID(nil, vol2.Namespace, vol2.ID)
+	require.NoError(t, err)
+	require.Equal(t, vol2, out2)
+}
+
+func TestFSM_HostVolumes(t *testing.T) {
+	t.Parallel()
+	fsm := testFSM(t)
+
+	vol := mock.HostVolume(mock.Node())
+	req := structs.HostVolumeUpsertRequest{
+		Volumes: []*structs.HostVolume{vol},
+	}
+	buf, err := structs.Encode(structs.HostVolumeUpsertRequestType, req)
+	require.NoError(t, err)
+	resp := fsm.Apply(makeLog(buf))
+	require.Nil(t, resp)
+
+	out, err := fsm.State().HostVolumeByID(nil, vol.Namespace, vol.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, uint64(1), out.CreateIndex)
+
+	req2 := structs.HostVolumeDeleteRequest{
+		VolumeID: vol.ID,
+		WriteRequest: structs.WriteRequest{
+			Namespace: vol.Namespace,
+		},
+	}
+	buf, err = structs.Encode(structs.HostVolumeDeleteRequestType, req2)
+	require.NoError(t, err)
+	resp = fsm.Apply(makeLog(buf))
+	require.Nil(t, resp)
+
+	out, err = fsm.State().HostVolumeByID(nil, vol.Namespace, vol.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
 func TestFSM_ACLEvents(t *testing.T) {
 	t.Parallel()
 
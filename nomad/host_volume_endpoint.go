@@ -0,0 +1,404 @@
+package nomad
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+)
+
+// HostVolume is the server RPC endpoint for dynamic host volumes
+type HostVolume struct {
+	srv    *Server
+	logger log.Logger
+}
+
+const hostVolumeTable = "host_volumes"
+
+// Create creates a dynamic host volume on a client. The volume is written to
+// raft as pending before the client creates it, so that its name is reserved
+// on the node, and is marked ready once the client has created it.
+func (v *HostVolume) Create(args *structs.HostVolumeCreateRequest, reply *structs.HostVolumeCreateResponse) error {
+	if done, err := v.srv.forward("HostVolume.Create", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "create"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeWrite)
+	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
+	if err != nil {
+		return err
+	}
+
+	if !allowVolume(aclObj, args.RequestNamespace()) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Volume == nil {
+		return fmt.Errorf("missing volume definition")
+	}
+
+	// This is the only namespace we ACL checked, force the volume to use it.
+	vol := args.Volume.Copy()
+	vol.Namespace = args.RequestNamespace()
+	if err := vol.Validate(); err != nil {
+		return err
+	}
+
+	vol.ID = uuid.Generate()
+	vol.State = structs.HostVolumeStatePending
+	vol.HostPath = ""
+	vol.CapacityBytes = 0
+
+	node, err := v.placeHostVolume(vol)
+	if err != nil {
+		return fmt.Errorf("could not place volume %q: %w", vol.Name, err)
+	}
+	vol.NodeID = node.ID
+
+	// NOTE: creating the volume on the client can't be made atomic with
+	// writing it to raft. We reserve the name on the node first so that
+	// concurrent requests can't create two volumes with the same name, and
+	// remove the reservation if the client fails.
+	if _, err := v.upsertVolume(vol, args.WriteRequest); err != nil {
+		return err
+	}
+
+	cReq := &cstructs.ClientHostVolumeCreateRequest{
+		ID:                        vol.ID,
+		Name:                      vol.Name,
+		PluginID:                  vol.PluginID,
+		NodeID:                    vol.NodeID,
+		RequestedCapacityMinBytes: vol.RequestedCapacityMinBytes,
+		RequestedCapacityMaxBytes: vol.RequestedCapacityMaxBytes,
+		Parameters:                vol.Parameters,
+		UID:                       vol.UID,
+		GID:                       vol.GID,
+		Mode:                      vol.Mode,
+	}
+	cResp := &cstructs.ClientHostVolumeCreateResponse{}
+	err = v.srv.RPC("ClientHostVolume.Create", cReq, cResp)
+	if err != nil {
+		v.logger.Error("failed to create host volume on client",
+			"volume_id", vol.ID, "node_id", vol.NodeID, "error", err)
+		if _, derr := v.deleteVolume(vol, args.WriteRequest); derr != nil {
+			v.logger.Error("failed to remove pending host volume", "volume_id", vol.ID, "error", derr)
+		}
+		return err
+	}
+
+	vol.State = structs.HostVolumeStateReady
+	vol.HostPath = cResp.HostPath
+	vol.CapacityBytes = cResp.CapacityBytes
+
+	index, err := v.upsertVolume(vol, args.WriteRequest)
+	if err != nil {
+		return err
+	}
+
+	vol.ModifyIndex = index
+	reply.Volume = vol
+	reply.Index = index
+	return nil
+}
+
+// placeHostVolume returns the node the volume should be created on: the
+// requested node, or a random ready node with the volume's plugin that
+// satisfies its constraints.
+func (v *HostVolume) placeHostVolume(vol *structs.HostVolume) (*structs.Node, error) {
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	pluginAttr := structs.HostVolumePluginAttribute(vol.PluginID)
+
+	if vol.NodeID != "" {
+		node, err := snap.NodeByID(nil, vol.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, fmt.Errorf("no such node %s", vol.NodeID)
+		}
+		if node.Status != structs.NodeStatusReady {
+			return nil, fmt.Errorf("node %s is not ready", vol.NodeID)
+		}
+		if _, ok := node.Attributes[pluginAttr]; !ok {
+			return nil, fmt.Errorf("node %s does not have plugin %q", vol.NodeID, vol.PluginID)
+		}
+		if hasStaticHostVolume(node, vol.Name) {
+			return nil, fmt.Errorf("node %s has a host volume %q in its configuration", vol.NodeID, vol.Name)
+		}
+		return node, nil
+	}
+
+	iter, err := snap.Nodes(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := scheduler.NewEvalContext(snap, &structs.Plan{}, v.logger)
+	checker := scheduler.NewConstraintChecker(ctx, vol.Constraints)
+
+	var nodes []*structs.Node
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+		if _, ok := node.Attributes[pluginAttr]; !ok {
+			continue
+		}
+		if hasStaticHostVolume(node, vol.Name) {
+			continue
+		}
+		if !checker.Feasible(node) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 0 {
+		return nil, errors.New("no node meets constraints")
+	}
+	return nodes[rand.Intn(len(nodes))], nil
+}
+
+// hasStaticHostVolume returns whether the node has a host volume with the name
+// in its client configuration, which a dynamic host volume would shadow.
+func hasStaticHostVolume(node *structs.Node, name string) bool {
+	hv, ok := node.HostVolumes[name]
+	return ok && hv.ID == ""
+}
+
+func (v *HostVolume) upsertVolume(vol *structs.HostVolume, wr structs.WriteRequest) (uint64, error) {
+	req := &structs.HostVolumeUpsertRequest{
+		Volumes:      []*structs.HostVolume{vol},
+		WriteRequest: wr,
+	}
+	resp, index, err := v.srv.raftApply(structs.HostVolumeUpsertRequestType, req)
+	if err != nil {
+		v.logger.Error("raft apply failed", "error", err, "method", "upsert")
+		return 0, err
+	}
+	if respErr, ok := resp.(error); ok {
+		return 0, respErr
+	}
+	return index, nil
+}
+
+func (v *HostVolume) deleteVolume(vol *structs.HostVolume, wr structs.WriteRequest) (uint64, error) {
+	req := &structs.HostVolumeDeleteRequest{
+		VolumeID:     vol.ID,
+		WriteRequest: wr,
+	}
+	req.Namespace = vol.Namespace
+	resp, index, err := v.srv.raftApply(structs.HostVolumeDeleteRequestType, req)
+	if err != nil {
+		v.logger.Error("raft apply failed", "error", err, "method", "delete")
+		return 0, err
+	}
+	if respErr, ok := resp.(error); ok {
+		return 0, respErr
+	}
+	return index, nil
+}
+
+// Delete deletes a dynamic host volume from its client and from the state
+// store. Volumes used by running allocations can't be deleted.
+func (v *HostVolume) Delete(args *structs.HostVolumeDeleteRequest, reply *structs.HostVolumeDeleteResponse) error {
+	if done, err := v.srv.forward("HostVolume.Delete", args, args, reply); done {
+		return err
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "delete"}, time.Now())
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeWrite)
+	aclObj, err := v.srv.WriteACLObj(&args.WriteRequest, false)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.VolumeID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	snap, err := v.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	vol, err := snap.HostVolumeByID(nil, ns, args.VolumeID)
+	if err != nil {
+		return err
+	}
+	if vol == nil {
+		return fmt.Errorf("host volume not found: %s", args.VolumeID)
+	}
+
+	allocs, err := snap.AllocsByNode(nil, vol.NodeID)
+	if err != nil {
+		return err
+	}
+	for _, alloc := range allocs {
+		if alloc.TerminalStatus() || alloc.Namespace != vol.Namespace {
+			continue
+		}
+		if allocUsesHostVolume(alloc, vol.Name) {
+			return fmt.Errorf("volume %s in use by allocation %s", vol.ID, alloc.ID)
+		}
+	}
+
+	// NOTE: deleting the volume on the client can't be made atomic with
+	// removing it from raft. A pending volume may never have been created on
+	// the client, but deleting it there is idempotent.
+	cReq := &cstructs.ClientHostVolumeDeleteRequest{
+		ID:         vol.ID,
+		Name:       vol.Name,
+		PluginID:   vol.PluginID,
+		NodeID:     vol.NodeID,
+		HostPath:   vol.HostPath,
+		Parameters: vol.Parameters,
+	}
+	cResp := &cstructs.ClientHostVolumeDeleteResponse{}
+	err = v.srv.RPC("ClientHostVolume.Delete", cReq, cResp)
+	if err != nil {
+		return err
+	}
+
+	index, err := v.deleteVolume(vol, args.WriteRequest)
+	if err != nil {
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// allocUsesHostVolume returns whether the allocation's task group has a host
+// volume with the source name
+func allocUsesHostVolume(alloc *structs.Allocation, name string) bool {
+	if alloc.Job == nil {
+		return false
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return false
+	}
+	for _, req := range tg.Volumes {
+		if req.Type == structs.VolumeTypeHost && req.Source == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Get fetches detailed information about a dynamic host volume
+func (v *HostVolume) Get(args *structs.HostVolumeGetRequest, reply *structs.HostVolumeGetResponse) error {
+	if done, err := v.srv.forward("HostVolume.Get", args, args, reply); done {
+		return err
+	}
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.QueryACLObj(&args.QueryOptions, true)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "get"}, time.Now())
+
+	if args.ID == "" {
+		return fmt.Errorf("missing volume ID")
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			vol, err := state.HostVolumeByID(ws, ns, args.ID)
+			if err != nil {
+				return err
+			}
+
+			reply.Volume = vol
+			return v.srv.replySetIndex(hostVolumeTable, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
+
+// List lists the dynamic host volumes of a namespace, optionally filtered by
+// node
+func (v *HostVolume) List(args *structs.HostVolumeListRequest, reply *structs.HostVolumeListResponse) error {
+	if done, err := v.srv.forward("HostVolume.List", args, args, reply); done {
+		return err
+	}
+
+	allowVolume := acl.NamespaceValidator(acl.NamespaceCapabilityHostVolumeRead)
+	aclObj, err := v.srv.QueryACLObj(&args.QueryOptions, false)
+	if err != nil {
+		return err
+	}
+
+	ns := args.RequestNamespace()
+	if !allowVolume(aclObj, ns) {
+		return structs.ErrPermissionDenied
+	}
+
+	defer metrics.MeasureSince([]string{"nomad", "host_volume", "list"}, time.Now())
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			var iter memdb.ResultIterator
+			var err error
+			if args.NodeID != "" {
+				iter, err = state.HostVolumesByNodeID(ws, args.NodeID)
+			} else {
+				iter, err = state.HostVolumesByNamespace(ws, ns)
+			}
+			if err != nil {
+				return err
+			}
+
+			vols := []*structs.HostVolume{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				vol := raw.(*structs.HostVolume)
+
+				// HostVolumesByNodeID hasn't filtered by namespace
+				if vol.Namespace != ns {
+					continue
+				}
+				if args.Prefix != "" && !strings.HasPrefix(vol.ID, args.Prefix) {
+					continue
+				}
+				vols = append(vols, vol)
+			}
+
+			reply.Volumes = vols
+			return v.srv.replySetIndex(hostVolumeTable, &reply.QueryMeta)
+		}}
+	return v.srv.blockingRPC(&opts)
+}
@@ -0,0 +1,241 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/client"
+	"github.com/hashicorp/nomad/client/config"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostVolumeEndpoint_CreateDelete(t *testing.T) {
+	t.Parallel()
+
+	srv, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{srv.config.RPCAddr.String()}
+	})
+	defer cleanupC()
+	waitForNodes(t, srv, 1, 1)
+
+	// volumes can't be placed on nodes without the plugin
+	req := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{
+			Name:     "example",
+			PluginID: "no-such-plugin",
+			UID:      -1,
+			GID:      -1,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.HostVolumeCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.EqualError(t, err, `could not place volume "example": no node meets constraints`)
+
+	// the mkdir plugin is builtin on all clients
+	req.Volume.PluginID = structs.HostVolumePluginMkdir
+	req.Volume.Constraints = []*structs.Constraint{{
+		LTarget: "${node.unique.id}",
+		RTarget: c.NodeID(),
+		Operand: "=",
+	}}
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.NoError(t, err)
+
+	vol := resp.Volume
+	require.Equal(t, c.NodeID(), vol.NodeID)
+	require.Equal(t, structs.HostVolumeStateReady, vol.State)
+	require.DirExists(t, vol.HostPath)
+
+	// the client fingerprints the volume
+	testutil.WaitForResult(func() (bool, error) {
+		node, err := srv.State().NodeByID(nil, c.NodeID())
+		if err != nil {
+			return false, err
+		}
+		nodeVol := node.HostVolumes["example"]
+		return nodeVol != nil && nodeVol.ID == vol.ID, nil
+	}, func(err error) {
+		t.Fatalf("volume not fingerprinted: %v", err)
+	})
+
+	// names are unique on a node
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.Error(t, err)
+
+	var getResp structs.HostVolumeGetResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Get", &structs.HostVolumeGetRequest{
+		ID: vol.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}, &getResp)
+	require.NoError(t, err)
+	require.Equal(t, vol.ID, getResp.Volume.ID)
+
+	var listResp structs.HostVolumeListResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.List", &structs.HostVolumeListRequest{
+		NodeID: c.NodeID(),
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}, &listResp)
+	require.NoError(t, err)
+	require.Len(t, listResp.Volumes, 1)
+
+	// volumes claimed by running allocations can't be deleted
+	alloc := mock.Alloc()
+	alloc.NodeID = c.NodeID()
+	alloc.Job.TaskGroups[0].Volumes = map[string]*structs.VolumeRequest{
+		"data": {Name: "data", Type: structs.VolumeTypeHost, Source: "example"},
+	}
+	index, _ := srv.State().LatestIndex()
+	require.NoError(t, srv.State().UpsertJob(structs.MsgTypeTestSetup, index+1, alloc.Job))
+	require.NoError(t, srv.State().UpsertAllocs(structs.MsgTypeTestSetup, index+2, []*structs.Allocation{alloc}))
+
+	delReq := &structs.HostVolumeDeleteRequest{
+		VolumeID: vol.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var delResp structs.HostVolumeDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp)
+	require.EqualError(t, err, "volume "+vol.ID+" in use by allocation "+alloc.ID)
+
+	stopped := alloc.Copy()
+	stopped.DesiredStatus = structs.AllocDesiredStatusStop
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, srv.State().UpsertAllocs(structs.MsgTypeTestSetup, index+3, []*structs.Allocation{stopped}))
+
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp)
+	require.NoError(t, err)
+	require.NoDirExists(t, vol.HostPath)
+
+	got, err := srv.State().HostVolumeByID(nil, structs.DefaultNamespace, vol.ID)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	testutil.WaitForResult(func() (bool, error) {
+		node, err := srv.State().NodeByID(nil, c.NodeID())
+		if err != nil {
+			return false, err
+		}
+		return node.HostVolumes["example"] == nil, nil
+	}, func(err error) {
+		t.Fatalf("volume not removed from node: %v", err)
+	})
+}
+
+func TestHostVolumeEndpoint_Create_StaticVolume(t *testing.T) {
+	t.Parallel()
+
+	srv, cleanupS := TestServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	c, cleanupC := client.TestClient(t, func(c *config.Config) {
+		c.Servers = []string{srv.config.RPCAddr.String()}
+		c.HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+			"static": {Name: "static", Path: t.TempDir()},
+		}
+	})
+	defer cleanupC()
+	waitForNodes(t, srv, 1, 1)
+
+	// volumes can't shadow a host volume in the client configuration
+	req := &structs.HostVolumeCreateRequest{
+		Volume: &structs.HostVolume{
+			Name:     "static",
+			PluginID: structs.HostVolumePluginMkdir,
+			NodeID:   c.NodeID(),
+			UID:      -1,
+			GID:      -1,
+		},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var resp structs.HostVolumeCreateResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.EqualError(t, err, `could not place volume "static": node `+
+		c.NodeID()+` has a host volume "static" in its configuration`)
+
+	req.Volume.NodeID = ""
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Create", req, &resp)
+	require.EqualError(t, err, `could not place volume "static": no node meets constraints`)
+}
+
+func TestHostVolumeEndpoint_ACL(t *testing.T) {
+	t.Parallel()
+
+	srv, rootToken, cleanupS := TestACLServer(t, nil)
+	defer cleanupS()
+	testutil.WaitForLeader(t, srv.RPC)
+	codec := rpcClient(t, srv)
+
+	vol := mock.HostVolume(mock.Node())
+	index, _ := srv.State().LatestIndex()
+	require.NoError(t, srv.State().UpsertHostVolumes(index+1, []*structs.HostVolume{vol}))
+
+	readToken := mock.CreatePolicyAndToken(t, srv.State(), index+2, "host-volume-read",
+		mock.NamespacePolicy(structs.DefaultNamespace, "",
+			[]string{acl.NamespaceCapabilityHostVolumeRead}))
+
+	getReq := &structs.HostVolumeGetRequest{
+		ID: vol.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+		},
+	}
+	var getResp structs.HostVolumeGetResponse
+	err := msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	getReq.AuthToken = readToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Get", getReq, &getResp)
+	require.NoError(t, err)
+	require.Equal(t, vol.ID, getResp.Volume.ID)
+
+	// reading a volume doesn't allow deleting it
+	delReq := &structs.HostVolumeDeleteRequest{
+		VolumeID: vol.ID,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var delResp structs.HostVolumeDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.Delete", delReq, &delResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	var listResp structs.HostVolumeListResponse
+	err = msgpackrpc.CallWithCodec(codec, "HostVolume.List", &structs.HostVolumeListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.DefaultNamespace,
+			AuthToken: rootToken.SecretID,
+		},
+	}, &listResp)
+	require.NoError(t, err)
+	require.Len(t, listResp.Volumes, 1)
+}
@@ -72,6 +72,7 @@ func NewJobEndpoints(s *Server) *Job {
 			jobExposeCheckHook{},
 			jobValidate{},
 			&memoryOversubscriptionValidate{srv: s},
+			&deploymentAnalysisValidate{srv: s},
 		},
 	}
 }
@@ -336,7 +337,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		}
 	}
 
-	// Submit a multiregion job to other regions (enterprise only).
+	// Submit a multiregion job to other regions.
 	// The job will have its region interpolated.
 	var newVersion uint64
 	if existingJob != nil {
@@ -347,6 +348,13 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return err
 	}
 
+	// A multiregion job registered directly in one of its regions, such as
+	// to retry a failed region, remains part of the previous multiregion
+	// registration
+	if !isRunner && args.Job.IsMultiregion() && args.Job.MultiregionID == "" && existingJob != nil {
+		args.Job.MultiregionID = existingJob.MultiregionID
+	}
+
 	// Create a new evaluation
 	now := time.Now().UnixNano()
 	submittedEval := false
@@ -410,9 +418,11 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 	if eval == nil {
 		// For dispatch jobs we return early, so we need to drop regions
 		// here rather than after eval for deployments is kicked off
-		err = j.multiregionDrop(args, reply)
-		if err != nil {
-			return err
+		if isRunner {
+			err = j.multiregionDrop(args, reply)
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	}
@@ -437,7 +447,7 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		reply.Index = evalIndex
 	}
 
-	// Kick off a multiregion deployment (enterprise only).
+	// Kick off a multiregion deployment.
 	if isRunner {
 		err = j.multiregionStart(args, reply)
 		if err != nil {
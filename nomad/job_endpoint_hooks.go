@@ -242,3 +242,27 @@ func (v *memoryOversubscriptionValidate) Validate(job *structs.Job) (warnings []
 
 	return warnings, err
 }
+
+// deploymentAnalysisValidate rejects deployment analyses querying metrics
+// servers the server configuration doesn't allow, since the leader makes
+// the requests on behalf of the job.
+type deploymentAnalysisValidate struct {
+	srv *Server
+}
+
+func (*deploymentAnalysisValidate) Name() string {
+	return "deployment_analysis"
+}
+
+func (v *deploymentAnalysisValidate) Validate(job *structs.Job) (warnings []error, err error) {
+	var mErr multierror.Error
+	for _, tg := range job.TaskGroups {
+		if tg.Update == nil || tg.Update.Analysis == nil {
+			continue
+		}
+		if !tg.Update.Analysis.AddressAllowed(v.srv.config.DeploymentAnalysisAddresses) {
+			_ = multierror.Append(&mErr, fmt.Errorf("Task group %q analysis address %q is not allowed by the server's deployment_analysis_addresses", tg.Name, tg.Update.Analysis.Address))
+		}
+	}
+	return nil, mErr.ErrorOrNil()
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"strings"
 
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
 	vapi "github.com/hashicorp/vault/api"
 )
@@ -15,12 +17,94 @@ func (j *Job) enforceSubmitJob(override bool, job *structs.Job) (error, error) {
 	return nil, nil
 }
 
-// multiregionRegister is used to send a job across multiple regions
+// multiregionRegister is used to send a job across multiple regions. The
+// region the job was submitted to registers a copy of the job interpolated
+// for each of the other regions, and interpolates args.Job for its own
+// region. It returns whether this region is the one coordinating the
+// registration.
 func (j *Job) multiregionRegister(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse, newVersion uint64) (bool, error) {
-	return false, nil
+	// Jobs registered by the coordinating region already have their region
+	// interpolated
+	if !args.Job.IsMultiregion() || args.Job.Region != structs.GlobalRegion {
+		return false, nil
+	}
+
+	// Every region's copy of the job shares an ID for this registration, by
+	// which the deployment watchers match the deployments of their peers
+	multiregionID := uuid.Generate()
+
+	var local *structs.Job
+	for _, region := range args.Job.Multiregion.Regions {
+		job := regionalJob(args.Job, region)
+		job.Version = newVersion
+		job.MultiregionID = multiregionID
+
+		if region.Name == j.srv.Region() {
+			local = job
+			continue
+		}
+
+		req := &structs.JobRegisterRequest{
+			Job:            job,
+			PreserveCounts: args.PreserveCounts,
+			PolicyOverride: args.PolicyOverride,
+			WriteRequest: structs.WriteRequest{
+				Region:    region.Name,
+				Namespace: job.Namespace,
+				AuthToken: args.AuthToken,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		if err := j.srv.forwardRegion(region.Name, "Job.Register", req, &resp); err != nil {
+			j.logger.Error("failed to register job in peer region", "region", region.Name, "error", err)
+			return false, fmt.Errorf("failed to register job in region %q: %v", region.Name, err)
+		}
+	}
+
+	if local == nil {
+		return false, fmt.Errorf("multiregion job must be registered in one of its regions, not %q", j.srv.Region())
+	}
+
+	args.Job = local
+	return true, nil
 }
 
-// multiregionStart is used to kick-off a deployment across multiple regions
+// regionalJob returns a copy of the multiregion job interpolated for the given
+// region: the region's datacenters replace the job's, its meta is merged into
+// the job's, and its count replaces the count of task groups with a count of
+// zero.
+func regionalJob(job *structs.Job, region *structs.MultiregionRegion) *structs.Job {
+	copy := job.Copy()
+	copy.Region = region.Name
+
+	if len(region.Datacenters) > 0 {
+		copy.Datacenters = append([]string(nil), region.Datacenters...)
+	}
+
+	if len(region.Meta) > 0 {
+		if copy.Meta == nil {
+			copy.Meta = make(map[string]string, len(region.Meta))
+		}
+		for k, v := range region.Meta {
+			copy.Meta[k] = v
+		}
+	}
+
+	if region.Count > 0 {
+		for _, tg := range copy.TaskGroups {
+			if tg.Count == 0 {
+				tg.Count = region.Count
+			}
+		}
+	}
+
+	return copy
+}
+
+// multiregionStart is used to kick-off a deployment across multiple regions.
+// The deployments of a multiregion job are created pending, and the
+// deployment watcher of each region starts its deployment once the regions
+// ahead of it have completed theirs, so there is nothing to do here.
 func (j *Job) multiregionStart(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
 	return nil
 }
@@ -28,18 +112,102 @@ func (j *Job) multiregionStart(args *structs.JobRegisterRequest, reply *structs.
 // multiregionDrop is used to deregister regions from a previous version of the
 // job that are no longer in use
 func (j *Job) multiregionDrop(args *structs.JobRegisterRequest, reply *structs.JobRegisterResponse) error {
+	if !args.Job.IsMultiregion() {
+		return nil
+	}
+
+	snap, err := j.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	ws := memdb.NewWatchSet()
+	versions, err := snap.JobVersionsByID(ws, args.Job.Namespace, args.Job.ID)
+	if err != nil {
+		return err
+	}
+
+	// Find the version registered before this one
+	var prev *structs.Job
+	for _, version := range versions {
+		if version.Version < args.Job.Version {
+			prev = version
+			break
+		}
+	}
+	if prev == nil || !prev.IsMultiregion() {
+		return nil
+	}
+
+	regions := make(map[string]struct{}, len(args.Job.Multiregion.Regions))
+	for _, region := range args.Job.Multiregion.Regions {
+		regions[region.Name] = struct{}{}
+	}
+
+	for _, region := range prev.Multiregion.Regions {
+		if _, ok := regions[region.Name]; ok || region.Name == j.srv.Region() {
+			continue
+		}
+
+		req := &structs.JobDeregisterRequest{
+			JobID: args.Job.ID,
+			WriteRequest: structs.WriteRequest{
+				Region:    region.Name,
+				Namespace: args.Job.Namespace,
+				AuthToken: args.AuthToken,
+			},
+		}
+		var resp structs.JobDeregisterResponse
+		if err := j.srv.forwardRegion(region.Name, "Job.Deregister", req, &resp); err != nil {
+			j.logger.Error("failed to deregister job from dropped region", "region", region.Name, "error", err)
+			return fmt.Errorf("failed to deregister job from region %q: %v", region.Name, err)
+		}
+	}
 	return nil
 }
 
 // multiregionStop is used to fan-out Job.Deregister RPCs to all regions if
 // the global flag is passed to Job.Deregister
 func (j *Job) multiregionStop(job *structs.Job, args *structs.JobDeregisterRequest, reply *structs.JobDeregisterResponse) error {
+	if job == nil || !job.IsMultiregion() || !args.Global {
+		return nil
+	}
+
+	for _, region := range job.Multiregion.Regions {
+		if region.Name == j.srv.Region() {
+			continue
+		}
+
+		req := &structs.JobDeregisterRequest{
+			JobID: args.JobID,
+			Purge: args.Purge,
+			WriteRequest: structs.WriteRequest{
+				Region:    region.Name,
+				Namespace: job.Namespace,
+				AuthToken: args.AuthToken,
+			},
+		}
+		var resp structs.JobDeregisterResponse
+		if err := j.srv.forwardRegion(region.Name, "Job.Deregister", req, &resp); err != nil {
+			j.logger.Error("failed to deregister job from peer region", "region", region.Name, "error", err)
+			return fmt.Errorf("failed to deregister job from region %q: %v", region.Name, err)
+		}
+	}
 	return nil
 }
 
 // interpolateMultiregionFields interpolates a job for a specific region
 func (j *Job) interpolateMultiregionFields(args *structs.JobPlanRequest) error {
-	return nil
+	if !args.Job.IsMultiregion() || args.Job.Region != structs.GlobalRegion {
+		return nil
+	}
+
+	for _, region := range args.Job.Multiregion.Regions {
+		if region.Name == j.srv.Region() {
+			args.Job = regionalJob(args.Job, region)
+			return nil
+		}
+	}
+	return fmt.Errorf("multiregion job must be planned in one of its regions, not %q", j.srv.Region())
 }
 
 // multiVaultNamespaceValidation provides a convience check to ensure
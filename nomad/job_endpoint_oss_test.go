@@ -330,3 +330,154 @@ func TestJobEndpoint_Register_Connect_AllowUnauthenticatedFalse_oss(t *testing.T
 		})
 	})
 }
+
+func TestJobEndpoint_Register_Multiregion(t *testing.T) {
+	t.Parallel()
+
+	west, cleanupWest := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Region = "west"
+	})
+	defer cleanupWest()
+
+	east, cleanupEast := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Region = "east"
+	})
+	defer cleanupEast()
+
+	TestJoin(t, west, east)
+	testutil.WaitForLeader(t, west.RPC)
+	testutil.WaitForLeader(t, east.RPC)
+	codec := rpcClient(t, west)
+
+	// Register the job in the global region, with a group whose count is
+	// overridden by each region
+	job := mock.MultiregionJob()
+	job.Region = structs.GlobalRegion
+	job.TaskGroups[0].Count = 0
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "west",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// Each region has the job interpolated for it
+	for _, tc := range []struct {
+		srv         *Server
+		datacenters []string
+		count       int
+		code        string
+	}{
+		{west, []string{"west-1", "west-2"}, 2, "W"},
+		{east, []string{"east-1"}, 1, "E"},
+	} {
+		out, err := tc.srv.fsm.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		require.NotNil(t, out, "job not registered in region %s", tc.srv.Region())
+		require.Equal(t, tc.srv.Region(), out.Region)
+		require.Equal(t, tc.datacenters, out.Datacenters)
+		require.Equal(t, tc.count, out.TaskGroups[0].Count)
+		require.Equal(t, tc.code, out.Meta["region_code"])
+	}
+
+	// Every region shares the ID of the multiregion registration
+	westJob, err := west.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, westJob.MultiregionID)
+	eastJob, err := east.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, westJob.MultiregionID, eastJob.MultiregionID)
+
+	// Registering the job again in a single region keeps the ID, even though
+	// the job versions of the regions diverge
+	regional := eastJob.Copy()
+	regional.MultiregionID = ""
+	regional.Meta["region_code"] = "E2"
+	eastReq := &structs.JobRegisterRequest{
+		Job: regional,
+		WriteRequest: structs.WriteRequest{
+			Region:    "east",
+			Namespace: job.Namespace,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", eastReq, &resp))
+	out, err := east.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, eastJob.Version+1, out.Version)
+	require.Equal(t, westJob.MultiregionID, out.MultiregionID)
+
+	// Dropping a region deregisters the job from it
+	job = job.Copy()
+	job.Multiregion.Regions = job.Multiregion.Regions[:1]
+	req.Job = job
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err = east.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.True(t, out.Stop)
+
+	// Registering in a region the job isn't in fails
+	job = mock.MultiregionJob()
+	job.Region = structs.GlobalRegion
+	job.Multiregion.Regions = job.Multiregion.Regions[1:]
+	req.Job = job
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be registered in one of its regions")
+}
+
+func TestJobEndpoint_Deregister_Multiregion(t *testing.T) {
+	t.Parallel()
+
+	west, cleanupWest := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Region = "west"
+	})
+	defer cleanupWest()
+
+	east, cleanupEast := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.Region = "east"
+	})
+	defer cleanupEast()
+
+	TestJoin(t, west, east)
+	testutil.WaitForLeader(t, west.RPC)
+	testutil.WaitForLeader(t, east.RPC)
+	codec := rpcClient(t, west)
+
+	job := mock.MultiregionJob()
+	job.Region = structs.GlobalRegion
+	reg := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "west",
+			Namespace: job.Namespace,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", reg, &structs.JobRegisterResponse{}))
+
+	// Deregister globally and purge
+	dereg := &structs.JobDeregisterRequest{
+		JobID:  job.ID,
+		Purge:  true,
+		Global: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    "west",
+			Namespace: job.Namespace,
+		},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Deregister", dereg, &structs.JobDeregisterResponse{}))
+
+	for _, srv := range []*Server{west, east} {
+		out, err := srv.fsm.State().JobByID(nil, job.Namespace, job.ID)
+		require.NoError(t, err)
+		require.Nil(t, out, "job not purged from region %s", srv.Region())
+	}
+}
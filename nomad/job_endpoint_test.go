@@ -1194,6 +1194,45 @@ func TestJobEndpoint_Register_Dispatched(t *testing.T) {
 	require.Contains(err.Error(), "job can't be submitted with 'Dispatched'")
 }
 
+func TestJobEndpoint_Register_DeploymentAnalysis(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+		c.DeploymentAnalysisAddresses = []string{"http://prometheus.service.consul:9090"}
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	job.TaskGroups[0].Update = structs.DefaultUpdateStrategy.Copy()
+	job.TaskGroups[0].Update.Analysis = &structs.DeploymentAnalysis{
+		Address:   "http://169.254.169.254",
+		Query:     "errors",
+		Operator:  "<",
+		Threshold: 0.1,
+		Interval:  time.Second,
+	}
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// Addresses missing from the server configuration are rejected
+	var resp structs.JobRegisterResponse
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not allowed")
+
+	job.TaskGroups[0].Update.Analysis.Address = "http://prometheus.service.consul:9090/"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+	require.NotZero(t, resp.Index)
+}
+
 func TestJobEndpoint_Register_EnforceIndex(t *testing.T) {
 	t.Parallel()
 
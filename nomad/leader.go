@@ -264,6 +264,12 @@ func (s *Server) establishLeadership(stopCh chan struct{}) error {
 	// Enable the NodeDrainer
 	s.nodeDrainer.SetEnabled(true, s.State())
 
+	// Enable the BatchDrainer
+	s.batchDrainer.SetEnabled(true, s.State())
+
+	// Enable the MaintenanceWatcher
+	s.maintenanceWatcher.SetEnabled(true, s.State())
+
 	// Enable the volume watcher, since we are now the leader
 	s.volumeWatcher.SetEnabled(true, s.State())
 
@@ -1065,6 +1071,12 @@ func (s *Server) revokeLeadership() error {
 	// Disable the node drainer
 	s.nodeDrainer.SetEnabled(false, nil)
 
+	// Disable the batch drainer
+	s.batchDrainer.SetEnabled(false, nil)
+
+	// Disable the maintenance watcher
+	s.maintenanceWatcher.SetEnabled(false, nil)
+
 	// Disable the volume watcher
 	s.volumeWatcher.SetEnabled(false, nil)
 
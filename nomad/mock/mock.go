@@ -1697,6 +1697,46 @@ func Deployment() *structs.Deployment {
 	}
 }
 
+func DrainBatch() *structs.DrainBatch {
+	return &structs.DrainBatch{
+		ID: uuid.Generate(),
+		Filter: &structs.DrainBatchFilter{
+			Datacenters: []string{"dc1"},
+		},
+		DrainSpec: &structs.DrainSpec{
+			Deadline: time.Hour,
+		},
+		MaxParallel: 1,
+		Nodes: []*structs.DrainBatchNode{
+			{
+				NodeID:     uuid.Generate(),
+				Datacenter: "dc1",
+				Status:     structs.DrainBatchNodeStatusPending,
+			},
+		},
+		Status:            structs.DrainBatchStatusRunning,
+		StatusDescription: structs.DrainBatchStatusDescriptionRunning,
+	}
+}
+
+func NodeMaintenanceWindow() *structs.NodeMaintenanceWindow {
+	return &structs.NodeMaintenanceWindow{
+		ID:      uuid.Generate(),
+		NodeIDs: []string{uuid.Generate()},
+		Filter: &structs.DrainBatchFilter{
+			Datacenters: []string{"dc1"},
+		},
+		StartTime: time.Now().Add(time.Hour).UTC(),
+		Duration:  time.Hour,
+		LeadTime:  time.Hour,
+		DrainSpec: &structs.DrainSpec{
+			Deadline: time.Hour,
+		},
+		Status:            structs.NodeMaintenanceWindowStatusPending,
+		StatusDescription: structs.NodeMaintenanceWindowStatusDescriptionPending,
+	}
+}
+
 func Plan() *structs.Plan {
 	return &structs.Plan{
 		Priority: 50,
@@ -1863,6 +1903,22 @@ func CSIVolume(plugin *structs.CSIPlugin) *structs.CSIVolume {
 	}
 }
 
+func HostVolume(node *structs.Node) *structs.HostVolume {
+	return &structs.HostVolume{
+		ID:                        uuid.Generate(),
+		Name:                      "test-host-vol",
+		Namespace:                 structs.DefaultNamespace,
+		PluginID:                  structs.HostVolumePluginMkdir,
+		NodeID:                    node.ID,
+		RequestedCapacityMinBytes: 1 << 20,
+		Parameters:                map[string]string{},
+		UID:                       -1,
+		GID:                       -1,
+		HostPath:                  "/var/nomad/host_volumes/test-host-vol",
+		State:                     structs.HostVolumeStateReady,
+	}
+}
+
 func Events(index uint64) *structs.Events {
 	return &structs.Events{
 		Index: index,
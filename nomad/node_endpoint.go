@@ -575,6 +575,11 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 	if args.NodeEvent != nil {
 		return fmt.Errorf("node event must not be set")
 	}
+	if args.DrainStrategy != nil {
+		if err := args.DrainStrategy.Validate(); err != nil {
+			return err
+		}
+	}
 
 	// Look for the node
 	snap, err := n.srv.fsm.State().Snapshot()
@@ -589,6 +594,21 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 		return fmt.Errorf("node not found")
 	}
 
+	// Only maintenance windows record themselves in the drain metadata. A
+	// drain set by an operator takes the node over from the window that
+	// drained it, which then leaves the node's drain and eligibility alone
+	// when it ends
+	if args.DrainStrategy != nil {
+		delete(args.Meta, structs.NodeMaintenanceWindowDrainMetaKey)
+	}
+
+	// Nodes are kept ineligible during their maintenance window
+	if args.DrainStrategy == nil && args.MarkEligible {
+		if err := checkNodeMaintenanceEligibility(snap, node); err != nil {
+			return err
+		}
+	}
+
 	now := time.Now().UTC()
 
 	// Update the timestamp of when the node status was updated
@@ -603,10 +623,8 @@ func (n *Node) UpdateDrain(args *structs.NodeUpdateDrainRequest,
 			args.DrainStrategy.StartedAt = node.DrainStrategy.StartedAt
 		}
 
-		// Mark the deadline time
-		if args.DrainStrategy.Deadline.Nanoseconds() > 0 {
-			args.DrainStrategy.ForceDeadline = now.Add(args.DrainStrategy.Deadline)
-		}
+		// Mark the deadline times
+		args.DrainStrategy.SetForceDeadlines(now)
 	}
 
 	// Construct the node event
@@ -692,6 +710,11 @@ func (n *Node) UpdateEligibility(args *structs.NodeUpdateEligibilityRequest,
 	if node.DrainStrategy != nil && args.Eligibility == structs.NodeSchedulingEligible {
 		return fmt.Errorf("can not set node's scheduling eligibility to eligible while it is draining")
 	}
+	if args.Eligibility == structs.NodeSchedulingEligible {
+		if err := checkNodeMaintenanceEligibility(snap, node); err != nil {
+			return err
+		}
+	}
 
 	switch args.Eligibility {
 	case structs.NodeSchedulingEligible, structs.NodeSchedulingIneligible:
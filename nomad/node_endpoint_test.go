@@ -1173,6 +1173,72 @@ func TestClientEndpoint_UpdatedDrainNoop(t *testing.T) {
 // TestClientEndpoint_UpdateDrain_ACL asserts that Node.UpdateDrain() enforces
 // node.write ACLs, and that token accessor ID is properly persisted in
 // Node.LastDrain.AccessorID
+func TestClientEndpoint_UpdateDrain_DeadlineOverrides(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable drainer to prevent drain from completing during test
+	s1.nodeDrainer.SetEnabled(false, nil)
+
+	// Create the register request
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeUpdateResponse
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &resp))
+
+	// An invalid override is rejected
+	dereg := &structs.NodeUpdateDrainRequest{
+		NodeID: node.ID,
+		DrainStrategy: &structs.DrainStrategy{
+			DrainSpec: structs.DrainSpec{
+				Deadline: time.Hour,
+				DeadlineOverrides: []*structs.DrainDeadline{
+					{MinPriority: 80, MaxPriority: 20, Deadline: time.Minute},
+				},
+			},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp2 structs.NodeDrainUpdateResponse
+	err := msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp2)
+	require.Error(err)
+	require.Contains(err.Error(), "invalid priority band")
+
+	// Jobs must be given with their namespace
+	dereg.DrainStrategy.DeadlineOverrides = []*structs.DrainDeadline{
+		{JobID: "example", Deadline: -1},
+	}
+	err = msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp2)
+	require.Error(err)
+	require.Contains(err.Error(), "must set the namespace of the job")
+
+	// The deadline times of the overrides are set by the server
+	beforeUpdate := time.Now()
+	skipped := []structs.NamespacedID{structs.NewNamespacedID("cache", structs.DefaultNamespace)}
+	dereg.DrainStrategy.DeadlineOverrides = []*structs.DrainDeadline{
+		{MinPriority: 20, MaxPriority: 80, Deadline: time.Minute},
+		{Namespace: structs.DefaultNamespace, JobID: "example", Deadline: -1},
+	}
+	dereg.DrainStrategy.SkipJobs = skipped
+	require.Nil(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", dereg, &resp2))
+
+	out, err := s1.fsm.State().NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.NotNil(out.DrainStrategy)
+	require.Len(out.DrainStrategy.DeadlineOverrides, 2)
+	require.WithinDuration(beforeUpdate.Add(time.Minute), out.DrainStrategy.DeadlineOverrides[0].ForceDeadline, time.Second)
+	require.True(out.DrainStrategy.DeadlineOverrides[1].ForceDeadline.IsZero())
+	require.Equal(skipped, out.DrainStrategy.SkipJobs)
+}
+
 func TestClientEndpoint_UpdateDrain_ACL(t *testing.T) {
 	t.Parallel()
 
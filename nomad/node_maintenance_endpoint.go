@@ -0,0 +1,280 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/drainer"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// NodeMaintenance is the server RPC endpoint for node maintenance windows
+type NodeMaintenance struct {
+	srv    *Server
+	logger log.Logger
+}
+
+const nodeMaintenanceWindowTable = "node_maintenance_windows"
+
+// Create schedules a maintenance window. The leader drains the nodes of the
+// window when it starts, and restores their eligibility when it ends.
+func (m *NodeMaintenance) Create(args *structs.NodeMaintenanceWindowCreateRequest, reply *structs.NodeMaintenanceWindowCreateResponse) error {
+	if done, err := m.srv.forward("NodeMaintenance.Create", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "create"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Window == nil {
+		return fmt.Errorf("missing maintenance window")
+	}
+	window := args.Window.Copy()
+	window.Canonicalize()
+	if err := window.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if !now.Before(window.EndTime()) {
+		return fmt.Errorf("maintenance window must end in the future")
+	}
+
+	snap, err := m.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, nodeID := range window.NodeIDs {
+		node, err := snap.NodeByID(nil, nodeID)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("node %q not found", nodeID)
+		}
+	}
+
+	window.ID = uuid.Generate()
+	window.Nodes = nil
+	window.Status = structs.NodeMaintenanceWindowStatusPending
+	window.StatusDescription = structs.NodeMaintenanceWindowStatusDescriptionPending
+	window.CreateTime = now.UnixNano()
+	window.ModifyTime = now.UnixNano()
+
+	req := &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{window},
+		WriteRequest: args.WriteRequest,
+	}
+	_, index, err := m.srv.raftApply(structs.NodeMaintenanceWindowUpsertRequestType, req)
+	if err != nil {
+		m.logger.Error("node maintenance window create failed", "error", err)
+		return err
+	}
+
+	reply.Window = window
+	reply.Index = index
+	return nil
+}
+
+// Cancel cancels a maintenance window. A pending window doesn't start, and an
+// active window ends immediately.
+func (m *NodeMaintenance) Cancel(args *structs.NodeMaintenanceWindowCancelRequest, reply *structs.NodeMaintenanceWindowCancelResponse) error {
+	if done, err := m.srv.forward("NodeMaintenance.Cancel", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "cancel"}, time.Now())
+
+	// Check node write permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeWrite() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.WindowID == "" {
+		return fmt.Errorf("missing maintenance window ID")
+	}
+
+	snap, err := m.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	window, err := snap.NodeMaintenanceWindowByID(nil, args.WindowID)
+	if err != nil {
+		return err
+	}
+	if window == nil {
+		return fmt.Errorf("maintenance window not found")
+	}
+	if window.Terminal() {
+		return fmt.Errorf("maintenance window %q is %s", window.ID, window.Status)
+	}
+
+	now := time.Now().UTC()
+	updated, drains, err := drainer.EndNodeMaintenanceWindow(snap, window, now,
+		structs.NodeMaintenanceWindowStatusCancelled, structs.NodeMaintenanceWindowStatusDescriptionCancelled)
+	if err != nil {
+		return err
+	}
+
+	// The cancellation only applies to the window as it was read, so that the
+	// drains of its nodes match the window's progress
+	req := &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{updated},
+		NodeDrains:   drains,
+		NodeEvents:   drainer.NodeMaintenanceEvents(window.ID, drains),
+		UpdatedAt:    now.Unix(),
+		EnforceIndex: true,
+		ModifyIndex:  window.ModifyIndex,
+		WriteRequest: args.WriteRequest,
+	}
+	fsmErr, index, err := m.srv.raftApply(structs.NodeMaintenanceWindowUpsertRequestType, req)
+	if err, ok := fsmErr.(error); ok && err != nil {
+		m.logger.Error("node maintenance window cancel failed", "error", err, "fsm", true)
+		return err
+	}
+	if err != nil {
+		m.logger.Error("node maintenance window cancel failed", "error", err, "raft", true)
+		return err
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// GetWindow is used to request information about a specific maintenance
+// window
+func (m *NodeMaintenance) GetWindow(args *structs.NodeMaintenanceWindowSpecificRequest, reply *structs.NodeMaintenanceWindowResponse) error {
+	if done, err := m.srv.forward("NodeMaintenance.GetWindow", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "get_window"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			window, err := state.NodeMaintenanceWindowByID(ws, args.WindowID)
+			if err != nil {
+				return err
+			}
+
+			reply.Window = window
+			if window != nil {
+				reply.Index = window.ModifyIndex
+				return nil
+			}
+			return m.srv.replySetIndex(nodeMaintenanceWindowTable, &reply.QueryMeta)
+		}}
+	return m.srv.blockingRPC(&opts)
+}
+
+// List is used to list the maintenance windows, or the windows that include a
+// node
+func (m *NodeMaintenance) List(args *structs.NodeMaintenanceWindowListRequest, reply *structs.NodeMaintenanceWindowListResponse) error {
+	if done, err := m.srv.forward("NodeMaintenance.List", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "node_maintenance", "list"}, time.Now())
+
+	// Check node read permissions
+	if aclObj, err := m.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNodeRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, state *state.StateStore) error {
+			var node *structs.Node
+			if args.NodeID != "" {
+				var err error
+				if node, err = state.NodeByID(ws, args.NodeID); err != nil {
+					return err
+				}
+				if node == nil {
+					return fmt.Errorf("node %q not found", args.NodeID)
+				}
+			}
+
+			var iter memdb.ResultIterator
+			var err error
+			if args.Prefix != "" {
+				iter, err = state.NodeMaintenanceWindowsByIDPrefix(ws, args.Prefix)
+			} else {
+				iter, err = state.NodeMaintenanceWindows(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			windows := []*structs.NodeMaintenanceWindow{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				window := raw.(*structs.NodeMaintenanceWindow)
+				if node != nil && !window.Includes(node) {
+					continue
+				}
+				windows = append(windows, window)
+			}
+
+			reply.Windows = windows
+			return m.srv.replySetIndex(nodeMaintenanceWindowTable, &reply.QueryMeta)
+		}}
+	return m.srv.blockingRPC(&opts)
+}
+
+// checkNodeMaintenanceEligibility returns an error if the node can't be marked
+// eligible because it is kept ineligible by an active maintenance window.
+func checkNodeMaintenanceEligibility(snap *state.StateSnapshot, node *structs.Node) error {
+	window, err := activeNodeMaintenanceWindow(snap, node)
+	if err != nil {
+		return err
+	}
+	if window != nil {
+		return fmt.Errorf("can not set node's scheduling eligibility to eligible during maintenance window %q", window.ID)
+	}
+	return nil
+}
+
+// activeNodeMaintenanceWindow returns the active maintenance window that keeps
+// the node ineligible, or nil if there is none. Windows that the node was
+// taken over from don't keep it ineligible.
+func activeNodeMaintenanceWindow(snap *state.StateSnapshot, node *structs.Node) (*structs.NodeMaintenanceWindow, error) {
+	iter, err := snap.NodeMaintenanceWindows(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		window := raw.(*structs.NodeMaintenanceWindow)
+		if window.Status != structs.NodeMaintenanceWindowStatusActive {
+			continue
+		}
+		if window.TakenOver(node) {
+			continue
+		}
+		if n := window.LookupNode(node.ID); n != nil && n.Status == structs.NodeMaintenanceWindowNodeStatusActive {
+			return window, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,320 @@
+package nomad
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMaintenanceEndpoint_Create(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Disable the maintenance watcher so that the window doesn't start
+	s1.maintenanceWatcher.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	start := time.Now().Add(time.Hour).UTC()
+	req := &structs.NodeMaintenanceWindowCreateRequest{
+		Window: &structs.NodeMaintenanceWindow{
+			NodeIDs:   []string{node.ID},
+			StartTime: start,
+			Duration:  time.Hour,
+			DrainSpec: &structs.DrainSpec{Deadline: time.Hour},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeMaintenanceWindowCreateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMaintenance.Create", req, &resp))
+	require.NotZero(resp.Index)
+	require.NotNil(resp.Window)
+
+	out, err := state.NodeMaintenanceWindowByID(nil, resp.Window.ID)
+	require.NoError(err)
+	require.NotNil(out)
+	require.Equal(structs.NodeMaintenanceWindowStatusPending, out.Status)
+	require.Equal(structs.DefaultNodeMaintenanceWindowLeadTime, out.LeadTime)
+	require.True(start.Equal(out.StartTime))
+
+	// A window that already ended is rejected
+	req.Window.StartTime = time.Now().Add(-2 * time.Hour)
+	err = msgpackrpc.CallWithCodec(codec, "NodeMaintenance.Create", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "must end in the future")
+
+	// An unknown node is rejected
+	req.Window.StartTime = start
+	req.Window.NodeIDs = []string{mock.Node().ID}
+	err = msgpackrpc.CallWithCodec(codec, "NodeMaintenance.Create", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestNodeMaintenanceEndpoint_Cancel_Active(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	s1.maintenanceWatcher.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Start the window and drain its node
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = []string{node.ID}
+	window.StartTime = time.Now().UTC()
+	window.Status = structs.NodeMaintenanceWindowStatusActive
+	window.Nodes = []*structs.NodeMaintenanceWindowNode{{
+		NodeID: node.ID,
+		Status: structs.NodeMaintenanceWindowNodeStatusActive,
+	}}
+	strategy := &structs.DrainStrategy{DrainSpec: *window.DrainSpec}
+	require.NoError(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1001,
+		&structs.NodeMaintenanceWindowUpsertRequest{
+			Windows:    []*structs.NodeMaintenanceWindow{window},
+			NodeDrains: map[string]*structs.DrainUpdate{node.ID: {DrainStrategy: strategy}},
+		}))
+
+	// The drain completes, but the node can't be made eligible during the
+	// window
+	require.NoError(state.UpdateNodeDrain(structs.MsgTypeTestSetup, 1002, node.ID, nil, false, 0, nil, nil, ""))
+	elig := &structs.NodeUpdateEligibilityRequest{
+		NodeID:       node.ID,
+		Eligibility:  structs.NodeSchedulingEligible,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var eligResp structs.NodeEligibilityUpdateResponse
+	err := msgpackrpc.CallWithCodec(codec, "Node.UpdateEligibility", elig, &eligResp)
+	require.Error(err)
+	require.Contains(err.Error(), "during maintenance window")
+
+	// Cancelling the window restores the node's eligibility
+	req := &structs.NodeMaintenanceWindowCancelRequest{
+		WindowID:     window.ID,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeMaintenanceWindowCancelResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMaintenance.Cancel", req, &resp))
+	require.NotZero(resp.Index)
+
+	out, err := state.NodeMaintenanceWindowByID(nil, window.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeMaintenanceWindowStatusCancelled, out.Status)
+	require.Equal(structs.NodeMaintenanceWindowStatusDescriptionCancelled, out.StatusDescription)
+	require.Equal(structs.NodeMaintenanceWindowNodeStatusRestored, out.Nodes[0].Status)
+
+	outNode, err := state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeSchedulingEligible, outNode.SchedulingEligibility)
+
+	// A terminal window can't be cancelled
+	err = msgpackrpc.CallWithCodec(codec, "NodeMaintenance.Cancel", req, &resp)
+	require.Error(err)
+	require.Contains(err.Error(), "is cancelled")
+}
+
+// TestNodeMaintenanceEndpoint_OperatorDrain asserts an operator can drain a
+// node during its maintenance window, which takes the node over from the
+// window.
+func TestNodeMaintenanceEndpoint_OperatorDrain(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	s1.maintenanceWatcher.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// Start the window and drain its node
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = []string{node.ID}
+	window.StartTime = time.Now().UTC()
+	window.Status = structs.NodeMaintenanceWindowStatusActive
+	window.Nodes = []*structs.NodeMaintenanceWindowNode{{
+		NodeID: node.ID,
+		Status: structs.NodeMaintenanceWindowNodeStatusActive,
+	}}
+	strategy := &structs.DrainStrategy{DrainSpec: *window.DrainSpec}
+	require.NoError(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1001,
+		&structs.NodeMaintenanceWindowUpsertRequest{
+			Windows:    []*structs.NodeMaintenanceWindow{window},
+			NodeDrains: map[string]*structs.DrainUpdate{node.ID: {DrainStrategy: strategy}},
+		}))
+
+	// The operator's drain replaces the window's, and can't claim to be set
+	// by the window
+	drain := &structs.NodeUpdateDrainRequest{
+		NodeID: node.ID,
+		DrainStrategy: &structs.DrainStrategy{
+			DrainSpec: structs.DrainSpec{Deadline: 10 * time.Second},
+		},
+		Meta:         map[string]string{structs.NodeMaintenanceWindowDrainMetaKey: window.ID},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var drainResp structs.NodeDrainUpdateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", drain, &drainResp))
+
+	outNode, err := state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Equal(10*time.Second, outNode.DrainStrategy.Deadline)
+	require.Empty(structs.NodeMaintenanceWindowID(outNode))
+
+	// The window no longer keeps the node ineligible, so the operator can
+	// cancel their drain and make it eligible
+	drain.DrainStrategy = nil
+	drain.MarkEligible = true
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Node.UpdateDrain", drain, &drainResp))
+
+	outNode, err = state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Nil(outNode.DrainStrategy)
+	require.Equal(structs.NodeSchedulingEligible, outNode.SchedulingEligibility)
+}
+
+func TestNodeMaintenanceEndpoint_GetWindow_List(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	s1.maintenanceWatcher.SetEnabled(false, nil)
+
+	state := s1.fsm.State()
+	n1, n2 := mock.Node(), mock.Node()
+	n2.Datacenter = "dc2"
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1000, n1))
+	require.NoError(state.UpsertNode(structs.MsgTypeTestSetup, 1001, n2))
+
+	// The first window selects the first node by datacenter, and the second
+	// window the second node by ID
+	w1, w2 := mock.NodeMaintenanceWindow(), mock.NodeMaintenanceWindow()
+	w1.NodeIDs = nil
+	w2.NodeIDs = []string{n2.ID}
+	w2.Filter = nil
+	require.NoError(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1002,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{w1}}))
+	require.NoError(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1003,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{w2}}))
+
+	get := &structs.NodeMaintenanceWindowSpecificRequest{
+		WindowID:     w1.ID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.NodeMaintenanceWindowResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMaintenance.GetWindow", get, &getResp))
+	require.EqualValues(1002, getResp.Index)
+	require.Equal(w1.ID, getResp.Window.ID)
+
+	list := &structs.NodeMaintenanceWindowListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.NodeMaintenanceWindowListResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMaintenance.List", list, &listResp))
+	require.EqualValues(1003, listResp.Index)
+	require.Len(listResp.Windows, 2)
+
+	// List the windows of a node
+	list.NodeID = n2.ID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMaintenance.List", list, &listResp))
+	require.Len(listResp.Windows, 1)
+	require.Equal(w2.ID, listResp.Windows[0].ID)
+
+	list.NodeID = mock.Node().ID
+	err := msgpackrpc.CallWithCodec(codec, "NodeMaintenance.List", list, &listResp)
+	require.Error(err)
+	require.Contains(err.Error(), "not found")
+}
+
+func TestNodeMaintenanceEndpoint_Create_DrainsAndRestores(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Register the node through raft so that the drainers watch it
+	state := s1.fsm.State()
+	node := mock.Node()
+	reg := &structs.NodeRegisterRequest{
+		Node:         node,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var regResp structs.NodeUpdateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Node.Register", reg, &regResp))
+
+	req := &structs.NodeMaintenanceWindowCreateRequest{
+		Window: &structs.NodeMaintenanceWindow{
+			NodeIDs:   []string{node.ID},
+			StartTime: time.Now().UTC(),
+			Duration:  2 * time.Second,
+			DrainSpec: &structs.DrainSpec{Deadline: time.Hour},
+		},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.NodeMaintenanceWindowCreateResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "NodeMaintenance.Create", req, &resp))
+
+	// The node is drained and kept ineligible during the window
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.NodeByID(nil, node.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.LastDrain == nil || out.LastDrain.Status != structs.DrainStatusComplete {
+			return false, fmt.Errorf("node drain not complete: %#v", out.LastDrain)
+		}
+		if out.SchedulingEligibility != structs.NodeSchedulingIneligible {
+			return false, fmt.Errorf("node eligibility %q", out.SchedulingEligibility)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	// The node is eligible again once the window ends
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := state.NodeMaintenanceWindowByID(nil, resp.Window.ID)
+		if err != nil {
+			return false, err
+		}
+		if out.Status != structs.NodeMaintenanceWindowStatusComplete {
+			return false, fmt.Errorf("window status %q", out.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+
+	out, err := state.NodeByID(nil, node.ID)
+	require.NoError(err)
+	require.Equal(structs.NodeSchedulingEligible, out.SchedulingEligibility)
+	require.Equal(resp.Window.ID, out.LastDrain.Meta["maintenance_window_id"])
+}
@@ -368,6 +368,67 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// EvalQueue is used to inspect the evaluations queued on the leader.
+func (op *Operator) EvalQueue(args *structs.GenericRequest, reply *structs.EvalQueueResponse) error {
+	// The queues only exist on the leader, so we fix the args since we are
+	// re-using a structure where we don't support all the options.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.EvalQueue", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	broker := op.srv.evalBroker.Stats()
+	blocked := op.srv.blockedEvals.Stats()
+
+	queue := &structs.EvalQueueStats{
+		NamespaceFairness: op.srv.evalBroker.Fairness(),
+		TotalReady:        broker.TotalReady,
+		TotalUnacked:      broker.TotalUnacked,
+		TotalPending:      broker.TotalBlocked,
+		TotalWaiting:      broker.TotalWaiting,
+		TotalBlocked:      blocked.TotalBlocked,
+		ByScheduler:       make(map[string]*structs.EvalQueueSchedulerStats, len(broker.ByScheduler)),
+		ByNamespace:       make(map[string]*structs.EvalQueueNamespaceStats),
+	}
+	for sched, stats := range broker.ByScheduler {
+		queue.ByScheduler[sched] = &structs.EvalQueueSchedulerStats{
+			Ready:   stats.Ready,
+			Unacked: stats.Unacked,
+		}
+	}
+
+	byNamespace := func(namespace string) *structs.EvalQueueNamespaceStats {
+		stats, ok := queue.ByNamespace[namespace]
+		if !ok {
+			stats = &structs.EvalQueueNamespaceStats{
+				Weight: op.srv.namespaceEvalWeight(namespace),
+			}
+			queue.ByNamespace[namespace] = stats
+		}
+		return stats
+	}
+	for namespace, stats := range broker.ByNamespace {
+		nsStats := byNamespace(namespace)
+		nsStats.Ready = stats.Ready
+		nsStats.Unacked = stats.Unacked
+	}
+	for namespace, count := range blocked.ByNamespace {
+		byNamespace(namespace).Blocked = count
+	}
+
+	reply.Queue = queue
+	op.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -394,6 +394,87 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	require.True(reply.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled)
 }
 
+func TestOperator_EvalQueue(t *testing.T) {
+	t.Parallel()
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.EvalNamespaceFairness = true
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	ns := mock.Namespace()
+	ns.Weight = 3
+	require.NoError(s1.fsm.State().UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// Queue evaluations in both namespaces and block one
+	for i := 0; i < 2; i++ {
+		eval := mock.Eval()
+		eval.Namespace = ns.Name
+		s1.evalBroker.Enqueue(eval)
+	}
+	s1.evalBroker.Enqueue(mock.Eval())
+
+	blocked := mock.Eval()
+	blocked.Namespace = ns.Name
+	blocked.Status = structs.EvalStatusBlocked
+	s1.blockedEvals.Block(blocked)
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.EvalQueueResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.EvalQueue", &arg, &reply))
+
+	queue := reply.Queue
+	require.NotNil(queue)
+	require.True(queue.NamespaceFairness)
+	require.Equal(3, queue.TotalReady)
+	require.Equal(1, queue.TotalBlocked)
+	require.Equal(3, queue.ByScheduler[structs.JobTypeService].Ready)
+
+	require.Equal(&structs.EvalQueueNamespaceStats{Weight: 3, Ready: 2, Blocked: 1}, queue.ByNamespace[ns.Name])
+	require.Equal(&structs.EvalQueueNamespaceStats{Weight: 1, Ready: 1}, queue.ByNamespace[structs.DefaultNamespace])
+}
+
+func TestOperator_EvalQueue_ACL(t *testing.T) {
+	t.Parallel()
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	require := require.New(t)
+
+	invalidToken := mock.CreatePolicyAndToken(t, s1.fsm.State(), 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+	var reply structs.EvalQueueResponse
+
+	// Try with no token and expect permission denied
+	err := msgpackrpc.CallWithCodec(codec, "Operator.EvalQueue", &arg, &reply)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with an invalid token and expect permission denied
+	arg.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.EvalQueue", &arg, &reply)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	// Try with root token, should succeed
+	arg.AuthToken = root.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Operator.EvalQueue", &arg, &reply))
+	require.False(reply.Queue.NamespaceFairness)
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	t.Parallel()
 
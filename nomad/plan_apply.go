@@ -466,6 +466,7 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 	// errors since we are processing in parallel.
 	var mErr multierror.Error
 	partialCommit := false
+	rejected := make(map[string]struct{})
 
 	// handleResult is used to process the result of evaluateNodePlan
 	handleResult := func(nodeID string, fit bool, reason string, err error) (cancel bool) {
@@ -481,6 +482,7 @@ func evaluatePlanPlacements(pool *EvaluatePool, snap *state.StateSnapshot, plan
 			}
 			// Set that this is a partial commit
 			partialCommit = true
+			rejected[nodeID] = struct{}{}
 
 			// If we require all-at-once scheduling, there is no point
 			// to continue the evaluation, as we've already failed.
@@ -565,6 +567,11 @@ OUTER:
 		outstanding--
 	}
 
+	// Gangs losing any of their nodes may not be applied in part
+	if partialCommit && !didCancel {
+		dropGangs(result, plan, rejected)
+	}
+
 	// If the plan resulted in a partial commit, we need to determine
 	// a minimum refresh index to force the scheduler to work on a more
 	// up-to-date state to avoid the failures.
@@ -588,6 +595,40 @@ OUTER:
 	return result, mErr.ErrorOrNil()
 }
 
+// dropGangs removes all the nodes of every gang of the plan that had any of its
+// nodes rejected from the result, so that all_or_nothing task groups are placed
+// as a whole or not at all. The nodes are dropped entirely, as removing only
+// the gang's stops and preemptions could overcommit them. Dropping a node may
+// break further gangs, so this repeats until no more nodes are dropped.
+func dropGangs(result *structs.PlanResult, plan *structs.Plan, rejected map[string]struct{}) {
+	for dropped := true; dropped; {
+		dropped = false
+		for _, nodeIDs := range plan.Gangs {
+			broken := false
+			for _, nodeID := range nodeIDs {
+				if _, ok := rejected[nodeID]; ok {
+					broken = true
+					break
+				}
+			}
+			if !broken {
+				continue
+			}
+
+			for _, nodeID := range nodeIDs {
+				if _, ok := rejected[nodeID]; ok {
+					continue
+				}
+				rejected[nodeID] = struct{}{}
+				delete(result.NodeUpdate, nodeID)
+				delete(result.NodeAllocation, nodeID)
+				delete(result.NodePreemptions, nodeID)
+				dropped = true
+			}
+		}
+	}
+}
+
 // correctDeploymentCanaries ensures that the deployment object doesn't list any
 // canaries as placed if they didn't actually get placed. This could happen if
 // the plan had a partial commit.
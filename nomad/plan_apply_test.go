@@ -656,6 +656,56 @@ func TestPlanApply_EvalPlan_Partial_AllAtOnce(t *testing.T) {
 	}
 }
 
+func TestPlanApply_EvalPlan_Partial_Gang(t *testing.T) {
+	t.Parallel()
+	state := testStateStore(t)
+	node := mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1000, node)
+	node2 := mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1001, node2)
+	node3 := mock.Node()
+	state.UpsertNode(structs.MsgTypeTestSetup, 1002, node3)
+
+	stopped := mock.Alloc()
+	stopped.NodeID = node.ID
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1003, []*structs.Allocation{stopped}))
+	snap, _ := state.Snapshot()
+
+	// Place a gang across two nodes, one of which doesn't fit
+	alloc := mock.Alloc()
+	alloc2 := mock.Alloc()
+	alloc2.AllocatedResources = structs.NodeResourcesToAllocatedResources(node2.NodeResources)
+	other := mock.Alloc()
+	other.TaskGroup = "other"
+
+	plan := &structs.Plan{
+		Job: alloc.Job,
+		NodeUpdate: map[string][]*structs.Allocation{
+			node.ID: {stopped},
+		},
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID:  {alloc},
+			node2.ID: {alloc2},
+			node3.ID: {other},
+		},
+		Gangs: map[string][]string{
+			"web": {node.ID, node2.ID},
+		},
+	}
+
+	pool := NewEvaluatePool(workerPoolSize, workerPoolBufferSize)
+	defer pool.Shutdown()
+
+	result, err := evaluatePlan(pool, snap, plan, testlog.HCLogger(t))
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	// Ensure the whole gang was dropped, without affecting other nodes
+	require.Equal(t, map[string][]*structs.Allocation{node3.ID: {other}}, result.NodeAllocation)
+	require.Empty(t, result.NodeUpdate)
+	require.Equal(t, uint64(1003), result.RefreshIndex)
+}
+
 func TestPlanApply_EvalNodePlan_Simple(t *testing.T) {
 	t.Parallel()
 	state := testStateStore(t)
@@ -22,7 +22,7 @@ func TestPlanNormalize(t *testing.T) {
 	// The newer format uses OmitEmpty and uses a minimal set of fields for the diff of the
 	// stopped and preempted allocs. The file for the older format hasn't been checked in, because
 	// it's not a good idea to check-in a 20mb file to the git repo.
-	unoptimizedLogSize := 19460168
+	unoptimizedLogSize := 20660168
 
 	numUpdatedAllocs := 10000
 	numStoppedAllocs := 8000
@@ -195,6 +195,12 @@ type Server struct {
 	// nodeDrainer is used to drain allocations from nodes.
 	nodeDrainer *drainer.NodeDrainer
 
+	// batchDrainer is used to drain the nodes of drain batches.
+	batchDrainer *drainer.BatchDrainer
+
+	// maintenanceWatcher is used to start and end node maintenance windows.
+	maintenanceWatcher *drainer.MaintenanceWatcher
+
 	// volumeWatcher is used to release volume claims
 	volumeWatcher *volumewatcher.Watcher
 
@@ -258,25 +264,28 @@ type Server struct {
 
 // Holds the RPC endpoints
 type endpoints struct {
-	Status     *Status
-	Node       *Node
-	Job        *Job
-	Eval       *Eval
-	Plan       *Plan
-	Alloc      *Alloc
-	CSIVolume  *CSIVolume
-	CSIPlugin  *CSIPlugin
-	Deployment *Deployment
-	Region     *Region
-	Search     *Search
-	Periodic   *Periodic
-	System     *System
-	Operator   *Operator
-	ACL        *ACL
-	Scaling    *Scaling
-	Enterprise *EnterpriseEndpoints
-	Event      *Event
-	Namespace  *Namespace
+	Status          *Status
+	Node            *Node
+	Job             *Job
+	Eval            *Eval
+	Plan            *Plan
+	Alloc           *Alloc
+	CSIVolume       *CSIVolume
+	CSIPlugin       *CSIPlugin
+	HostVolume      *HostVolume
+	Deployment      *Deployment
+	DrainBatch      *DrainBatch
+	NodeMaintenance *NodeMaintenance
+	Region          *Region
+	Search          *Search
+	Periodic        *Periodic
+	System          *System
+	Operator        *Operator
+	ACL             *ACL
+	Scaling         *Scaling
+	Enterprise      *EnterpriseEndpoints
+	Event           *Event
+	Namespace       *Namespace
 
 	// Client endpoints
 	ClientStats       *ClientStats
@@ -284,6 +293,7 @@ type endpoints struct {
 	Agent             *Agent
 	ClientAllocations *ClientAllocations
 	ClientCSI         *ClientCSI
+	ClientHostVolume  *ClientHostVolume
 }
 
 // NewServer is used to construct a new Nomad server from the
@@ -347,6 +357,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
 	s.shutdownCh = s.shutdownCtx.Done()
 
+	// Dequeue evaluations fairly across namespaces if configured
+	s.evalBroker.SetFairness(config.EvalNamespaceFairness, s.namespaceEvalWeight)
+
 	// Create the RPC handler
 	s.rpcHandler = newRpcHandler(s)
 
@@ -1023,6 +1036,7 @@ func (s *Server) setupDeploymentWatcher() error {
 		s.staticEndpoints.Job,
 		s.config.DeploymentQueryRateLimit,
 		deploymentwatcher.CrossDeploymentUpdateBatchDuration,
+		s.config.DeploymentAnalysisAddresses,
 	)
 
 	return nil
@@ -1036,8 +1050,8 @@ func (s *Server) setupVolumeWatcher() error {
 	return nil
 }
 
-// setupNodeDrainer creates a node drainer which will be enabled when a server
-// becomes a leader.
+// setupNodeDrainer creates the node drainer, the batch drainer and the
+// maintenance watcher, which will be enabled when a server becomes a leader.
 func (s *Server) setupNodeDrainer() {
 	// Create a shim around Raft requests
 	shim := drainerShim{s}
@@ -1051,6 +1065,18 @@ func (s *Server) setupNodeDrainer() {
 		BatchUpdateInterval:   drainer.BatchUpdateInterval,
 	}
 	s.nodeDrainer = drainer.NewNodeDrainer(c)
+
+	s.batchDrainer = drainer.NewBatchDrainer(&drainer.BatchDrainerConfig{
+		Logger:                s.logger,
+		Raft:                  shim,
+		StateQueriesPerSecond: drainer.LimitStateQueriesPerSecond,
+	})
+
+	s.maintenanceWatcher = drainer.NewMaintenanceWatcher(&drainer.MaintenanceWatcherConfig{
+		Logger:                s.logger,
+		Raft:                  shim,
+		StateQueriesPerSecond: drainer.LimitStateQueriesPerSecond,
+	})
 }
 
 // setupConsul is used to setup Server specific consul components.
@@ -1143,7 +1169,10 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.Node = &Node{srv: s, logger: s.logger.Named("client")} // Add but don't register
 		s.staticEndpoints.CSIVolume = &CSIVolume{srv: s, logger: s.logger.Named("csi_volume")}
 		s.staticEndpoints.CSIPlugin = &CSIPlugin{srv: s, logger: s.logger.Named("csi_plugin")}
+		s.staticEndpoints.HostVolume = &HostVolume{srv: s, logger: s.logger.Named("host_volume")}
 		s.staticEndpoints.Deployment = &Deployment{srv: s, logger: s.logger.Named("deployment")}
+		s.staticEndpoints.DrainBatch = &DrainBatch{srv: s, logger: s.logger.Named("drain_batch")}
+		s.staticEndpoints.NodeMaintenance = &NodeMaintenance{srv: s, logger: s.logger.Named("node_maintenance")}
 		s.staticEndpoints.Operator = &Operator{srv: s, logger: s.logger.Named("operator")}
 		s.staticEndpoints.Operator.register()
 
@@ -1162,6 +1191,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.ClientAllocations = &ClientAllocations{srv: s, logger: s.logger.Named("client_allocs")}
 		s.staticEndpoints.ClientAllocations.register()
 		s.staticEndpoints.ClientCSI = &ClientCSI{srv: s, logger: s.logger.Named("client_csi")}
+		s.staticEndpoints.ClientHostVolume = &ClientHostVolume{srv: s, logger: s.logger.Named("client_host_volume")}
 
 		// Streaming endpoints
 		s.staticEndpoints.FileSystem = &FileSystem{srv: s, logger: s.logger.Named("client_fs")}
@@ -1182,7 +1212,10 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.Job)
 	server.Register(s.staticEndpoints.CSIVolume)
 	server.Register(s.staticEndpoints.CSIPlugin)
+	server.Register(s.staticEndpoints.HostVolume)
 	server.Register(s.staticEndpoints.Deployment)
+	server.Register(s.staticEndpoints.DrainBatch)
+	server.Register(s.staticEndpoints.NodeMaintenance)
 	server.Register(s.staticEndpoints.Operator)
 	server.Register(s.staticEndpoints.Periodic)
 	server.Register(s.staticEndpoints.Plan)
@@ -1195,6 +1228,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.ClientStats)
 	server.Register(s.staticEndpoints.ClientAllocations)
 	server.Register(s.staticEndpoints.ClientCSI)
+	server.Register(s.staticEndpoints.ClientHostVolume)
 	server.Register(s.staticEndpoints.FileSystem)
 	server.Register(s.staticEndpoints.Agent)
 	server.Register(s.staticEndpoints.Namespace)
@@ -1520,6 +1554,16 @@ func (s *Server) State() *state.StateStore {
 	return s.fsm.State()
 }
 
+// namespaceEvalWeight returns the weight of a namespace for dequeuing
+// evaluations fairly across namespaces.
+func (s *Server) namespaceEvalWeight(namespace string) int {
+	ns, err := s.fsm.State().NamespaceByName(nil, namespace)
+	if err != nil || ns == nil || ns.Weight < 1 {
+		return 1
+	}
+	return ns.Weight
+}
+
 // setLeaderAcl stores the given ACL token as the current leader's ACL token.
 func (s *Server) setLeaderAcl(token string) {
 	s.leaderAclLock.Lock()
@@ -20,9 +20,12 @@ var MsgTypeEvents = map[structs.MessageType]string{
 	structs.NodeUpdateEligibilityRequestType:        structs.TypeNodeDrain,
 	structs.NodeUpdateDrainRequestType:              structs.TypeNodeDrain,
 	structs.BatchNodeUpdateDrainRequestType:         structs.TypeNodeDrain,
+	structs.DrainBatchUpsertRequestType:             structs.TypeNodeDrain,
+	structs.NodeMaintenanceWindowUpsertRequestType:  structs.TypeNodeDrain,
 	structs.DeploymentStatusUpdateRequestType:       structs.TypeDeploymentUpdate,
 	structs.DeploymentPromoteRequestType:            structs.TypeDeploymentPromotion,
 	structs.DeploymentAllocHealthRequestType:        structs.TypeDeploymentAllocHealth,
+	structs.DeploymentCanaryStepRequestType:         structs.TypeDeploymentCanaryStep,
 	structs.ApplyPlanResultsRequestType:             structs.TypePlanResult,
 	structs.ACLTokenDeleteRequestType:               structs.TypeACLTokenDeleted,
 	structs.ACLTokenUpsertRequestType:               structs.TypeACLTokenUpserted,
@@ -58,6 +58,10 @@ func init() {
 		scalingPolicyTableSchema,
 		scalingEventTableSchema,
 		namespaceTableSchema,
+		preemptionHistoryTableSchema,
+		hostVolumeTableSchema,
+		drainBatchTableSchema,
+		nodeMaintenanceWindowTableSchema,
 	}...)
 }
 
@@ -960,3 +964,100 @@ func namespaceTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// preemptionHistoryTableSchema returns the MemDB schema for the preemption
+// history of jobs with a preemption budget.
+func preemptionHistoryTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "preemption_history",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+
+				// Use a compound index so the tuple of (Namespace, JobID) is
+				// uniquely identifying
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+
+						&memdb.StringFieldIndex{
+							Field: "JobID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// hostVolumeTableSchema returns the MemDB schema for dynamic host volumes,
+// which are identified by namespace and ID and searchable by node.
+func hostVolumeTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "host_volumes",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "ID",
+						},
+					},
+				},
+			},
+			"node_id": {
+				Name:         "node_id",
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "NodeID",
+				},
+			},
+		},
+	}
+}
+
+// drainBatchTableSchema returns the MemDB schema for drain batches
+func drainBatchTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "drain_batches",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
+
+// nodeMaintenanceWindowTableSchema returns the MemDB schema for node
+// maintenance windows
+func nodeMaintenanceWindowTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: "node_maintenance_windows",
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.UUIDFieldIndex{
+					Field: "ID",
+				},
+			},
+		},
+	}
+}
@@ -394,6 +394,13 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 		return err
 	}
 
+	// Record the preemptions against the budgets of the preempted jobs
+	// COMPAT 0.11: Remove NodePreemptions when it is removed
+	preempted := append(allocsPreempted, results.NodePreemptions...)
+	if err := s.recordPreemptions(index, preempted, txn); err != nil {
+		return err
+	}
+
 	// Upsert followup evals for allocs that were preempted
 	for _, eval := range results.PreemptionEvals {
 		if err := s.nestedUpsertEval(txn, index, eval); err != nil {
@@ -404,6 +411,117 @@ func (s *StateStore) UpsertPlanResults(msgType structs.MessageType, index uint64
 	return txn.Commit()
 }
 
+// recordPreemptions appends the preempted allocations to the preemption
+// history of their jobs, pruning preemptions that have left the budget window.
+// Jobs without a preemption budget have no history.
+func (s *StateStore) recordPreemptions(index uint64, allocs []*structs.Allocation, txn *txn) error {
+	histories := make(map[structs.NamespacedID]*structs.JobPreemptionHistory)
+	windows := make(map[structs.NamespacedID]time.Duration)
+	for _, alloc := range allocs {
+		id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+		history, ok := histories[id]
+		if !ok {
+			job, err := s.JobByIDTxn(nil, alloc.Namespace, alloc.JobID, txn)
+			if err != nil {
+				return fmt.Errorf("job lookup failed: %v", err)
+			}
+			if job == nil {
+				histories[id] = nil
+				continue
+			}
+			if _, ok := job.Preemption.Budget(job); !ok {
+				histories[id] = nil
+				continue
+			}
+
+			existing, err := txn.First("preemption_history", "id", alloc.Namespace, alloc.JobID)
+			if err != nil {
+				return fmt.Errorf("preemption history lookup failed: %v", err)
+			}
+			if existing != nil {
+				history = existing.(*structs.JobPreemptionHistory).Copy()
+			} else {
+				history = &structs.JobPreemptionHistory{
+					Namespace:   alloc.Namespace,
+					JobID:       alloc.JobID,
+					CreateIndex: index,
+				}
+			}
+			histories[id] = history
+			windows[id] = job.Preemption.Window
+		}
+		if history == nil {
+			continue
+		}
+
+		history.Preemptions = append(history.Preemptions, alloc.ModifyTime)
+	}
+
+	if len(windows) == 0 {
+		return nil
+	}
+
+	for id, history := range histories {
+		if history == nil {
+			continue
+		}
+
+		sort.Slice(history.Preemptions, func(i, j int) bool {
+			return history.Preemptions[i] < history.Preemptions[j]
+		})
+
+		// Prune the preemptions that are no longer within the window of the
+		// latest one
+		latest := history.Preemptions[len(history.Preemptions)-1]
+		since := latest - windows[id].Nanoseconds()
+		if n := history.PreemptionsSince(since); n < len(history.Preemptions) {
+			history.Preemptions = history.Preemptions[len(history.Preemptions)-n:]
+		}
+
+		history.ModifyIndex = index
+		if err := txn.Insert("preemption_history", history); err != nil {
+			return fmt.Errorf("preemption history insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"preemption_history", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// PreemptionHistories returns an iterator over the preemption history of all
+// jobs.
+func (s *StateStore) PreemptionHistories(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("preemption_history", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// PreemptionHistoryByJob returns the preemption history of a job, or nil if
+// none of its allocations were preempted within its budget window.
+func (s *StateStore) PreemptionHistoryByJob(ws memdb.WatchSet, namespace, jobID string) (*structs.JobPreemptionHistory, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("preemption_history", "id", namespace, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("preemption history lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.JobPreemptionHistory), nil
+	}
+	return nil, nil
+}
+
 // addComputedAllocAttrs adds the computed/derived attributes to the allocation.
 // This method is used when an allocation is being denormalized.
 func addComputedAllocAttrs(allocs []*structs.Allocation, job *structs.Job) {
@@ -1046,6 +1164,15 @@ func (s *StateStore) updateNodeDrainImpl(txn *txn, index uint64, nodeID string,
 			updatedNode.LastDrain.Meta = drainMeta
 		}
 
+		// a drain that isn't set by a maintenance window takes the node over
+		// from the window that drained it
+		if drain != nil && drainMeta[structs.NodeMaintenanceWindowDrainMetaKey] == "" &&
+			structs.NodeMaintenanceWindowID(updatedNode) != "" {
+			meta := helper.CopyMapStringString(updatedNode.LastDrain.Meta)
+			delete(meta, structs.NodeMaintenanceWindowDrainMetaKey)
+			updatedNode.LastDrain.Meta = meta
+		}
+
 		// we won't have an accessor ID on drain complete, so don't overwrite the existing one
 		if accessorId != "" {
 			updatedNode.LastDrain.AccessorID = accessorId
@@ -1073,6 +1200,221 @@ func (s *StateStore) updateNodeDrainImpl(txn *txn, index uint64, nodeID string,
 	return nil
 }
 
+// UpsertDrainBatches is used to register or update drain batches, and to
+// start draining the nodes the leader moves the batches onto. Batches that
+// are terminal can't be updated.
+func (s *StateStore) UpsertDrainBatches(msgType structs.MessageType, index uint64, req *structs.DrainBatchUpsertRequest) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	if req.EnforceIndex && len(req.Batches) != 1 {
+		return fmt.Errorf("enforcing the modify index requires a single drain batch")
+	}
+	for _, batch := range req.Batches {
+		existing, err := txn.First("drain_batches", "id", batch.ID)
+		if err != nil {
+			return fmt.Errorf("drain batch lookup failed: %v", err)
+		}
+
+		var existingIndex uint64
+		if existing != nil {
+			existingBatch := existing.(*structs.DrainBatch)
+			if existingBatch.Terminal() {
+				return fmt.Errorf("drain batch %q is %s", batch.ID, existingBatch.Status)
+			}
+			existingIndex = existingBatch.ModifyIndex
+			batch.CreateIndex = existingBatch.CreateIndex
+		} else {
+			batch.CreateIndex = index
+		}
+		if req.EnforceIndex && existingIndex != req.ModifyIndex {
+			return fmt.Errorf("enforcing modify index %d: drain batch %q has conflicting modify index %d",
+				req.ModifyIndex, batch.ID, existingIndex)
+		}
+		batch.ModifyIndex = index
+
+		if err := txn.Insert("drain_batches", batch); err != nil {
+			return fmt.Errorf("drain batch insert failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"drain_batches", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Node drains are started by a single batch, which is recorded in the
+	// drain metadata of the nodes
+	if len(req.NodeDrains) != 0 && len(req.Batches) != 1 {
+		return fmt.Errorf("node drains must be started by a single drain batch")
+	}
+	for nodeID, update := range req.NodeDrains {
+		drainMeta := map[string]string{"batch_id": req.Batches[0].ID}
+		if err := s.updateNodeDrainImpl(txn, index, nodeID, update.DrainStrategy, update.MarkEligible, req.UpdatedAt,
+			req.NodeEvents[nodeID], drainMeta, "", false); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// DrainBatchByID is used to lookup a drain batch by its ID
+func (s *StateStore) DrainBatchByID(ws memdb.WatchSet, id string) (*structs.DrainBatch, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("drain_batches", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("drain batch lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.DrainBatch), nil
+	}
+	return nil, nil
+}
+
+// DrainBatchesByIDPrefix is used to lookup drain batches by prefix
+func (s *StateStore) DrainBatchesByIDPrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("drain_batches", "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("drain batch lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// DrainBatches returns an iterator over all the drain batches
+func (s *StateStore) DrainBatches(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("drain_batches", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// UpsertNodeMaintenanceWindows is used to register or update node maintenance
+// windows, and to update the drains of the nodes of a window the leader starts
+// or ends. Windows that are terminal can't be updated, and a window doesn't
+// cancel the drain or restore the eligibility of a node that another window
+// drained last.
+func (s *StateStore) UpsertNodeMaintenanceWindows(msgType structs.MessageType, index uint64, req *structs.NodeMaintenanceWindowUpsertRequest) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	if req.EnforceIndex && len(req.Windows) != 1 {
+		return fmt.Errorf("enforcing the modify index requires a single node maintenance window")
+	}
+	for _, window := range req.Windows {
+		existing, err := txn.First("node_maintenance_windows", "id", window.ID)
+		if err != nil {
+			return fmt.Errorf("node maintenance window lookup failed: %v", err)
+		}
+
+		var existingIndex uint64
+		if existing != nil {
+			existingWindow := existing.(*structs.NodeMaintenanceWindow)
+			if existingWindow.Terminal() {
+				return fmt.Errorf("node maintenance window %q is %s", window.ID, existingWindow.Status)
+			}
+			existingIndex = existingWindow.ModifyIndex
+			window.CreateIndex = existingWindow.CreateIndex
+		} else {
+			window.CreateIndex = index
+		}
+		if req.EnforceIndex && existingIndex != req.ModifyIndex {
+			return fmt.Errorf("enforcing modify index %d: node maintenance window %q has conflicting modify index %d",
+				req.ModifyIndex, window.ID, existingIndex)
+		}
+		window.ModifyIndex = index
+
+		if err := txn.Insert("node_maintenance_windows", window); err != nil {
+			return fmt.Errorf("node maintenance window insert failed: %v", err)
+		}
+	}
+	if err := txn.Insert("index", &IndexEntry{"node_maintenance_windows", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	// Node drains are updated by a single window, which is recorded in the
+	// drain metadata of the nodes
+	if len(req.NodeDrains) != 0 && len(req.Windows) != 1 {
+		return fmt.Errorf("node drains must be updated by a single node maintenance window")
+	}
+	for nodeID, update := range req.NodeDrains {
+		// A window that ends leaves alone the nodes that another window took
+		// over since the update was computed
+		if update.DrainStrategy == nil {
+			existing, err := txn.First("nodes", "id", nodeID)
+			if err != nil {
+				return fmt.Errorf("node lookup failed: %v", err)
+			}
+			if existing != nil && req.Windows[0].TakenOver(existing.(*structs.Node)) {
+				continue
+			}
+		}
+
+		drainMeta := map[string]string{structs.NodeMaintenanceWindowDrainMetaKey: req.Windows[0].ID}
+		if err := s.updateNodeDrainImpl(txn, index, nodeID, update.DrainStrategy, update.MarkEligible, req.UpdatedAt,
+			req.NodeEvents[nodeID], drainMeta, "", false); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// NodeMaintenanceWindowByID is used to lookup a node maintenance window by its
+// ID
+func (s *StateStore) NodeMaintenanceWindowByID(ws memdb.WatchSet, id string) (*structs.NodeMaintenanceWindow, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("node_maintenance_windows", "id", id)
+	if err != nil {
+		return nil, fmt.Errorf("node maintenance window lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.NodeMaintenanceWindow), nil
+	}
+	return nil, nil
+}
+
+// NodeMaintenanceWindowsByIDPrefix is used to lookup node maintenance windows
+// by prefix
+func (s *StateStore) NodeMaintenanceWindowsByIDPrefix(ws memdb.WatchSet, prefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("node_maintenance_windows", "id_prefix", prefix)
+	if err != nil {
+		return nil, fmt.Errorf("node maintenance window lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// NodeMaintenanceWindows returns an iterator over all the node maintenance
+// windows
+func (s *StateStore) NodeMaintenanceWindows(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("node_maintenance_windows", "id")
+	if err != nil {
+		return nil, err
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // UpdateNodeEligibility is used to update the scheduling eligibility of a node
 func (s *StateStore) UpdateNodeEligibility(msgType structs.MessageType, index uint64, nodeID string, eligibility string, updatedAt int64, event *structs.NodeEvent) error {
 
@@ -1729,6 +2071,14 @@ func (s *StateStore) DeleteJobTxn(index uint64, namespace, jobID string, txn Txn
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// Delete the preemption history
+	if _, err = txn.DeleteAll("preemption_history", "id", namespace, jobID); err != nil {
+		return fmt.Errorf("deleting job preemption history failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"preemption_history", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
 	return nil
 }
 
@@ -2293,6 +2643,136 @@ func (s *StateStore) CSIVolumesByNamespace(ws memdb.WatchSet, namespace, prefix
 	return iter, nil
 }
 
+// UpsertHostVolumes is used to register or update dynamic host volumes. The
+// name of a volume must be unique on its node.
+func (s *StateStore) UpsertHostVolumes(index uint64, volumes []*structs.HostVolume) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	for _, v := range volumes {
+		if exists, err := s.namespaceExists(txn, v.Namespace); err != nil {
+			return err
+		} else if !exists {
+			return fmt.Errorf("host volume %s is in nonexistent namespace %s", v.ID, v.Namespace)
+		}
+
+		iter, err := txn.Get("host_volumes", "node_id", v.NodeID)
+		if err != nil {
+			return fmt.Errorf("host volume lookup failed: %v", err)
+		}
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			other := raw.(*structs.HostVolume)
+			if other.ID != v.ID && other.Name == v.Name {
+				return fmt.Errorf("host volume %q already exists on node %s", v.Name, v.NodeID)
+			}
+		}
+
+		existing, err := txn.First("host_volumes", "id", v.Namespace, v.ID)
+		if err != nil {
+			return fmt.Errorf("host volume lookup failed: %v", err)
+		}
+		if existing != nil {
+			v.CreateIndex = existing.(*structs.HostVolume).CreateIndex
+		} else {
+			v.CreateIndex = index
+		}
+		v.ModifyIndex = index
+
+		if err := txn.Insert("host_volumes", v); err != nil {
+			return fmt.Errorf("host volume insert failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert("index", &IndexEntry{"host_volumes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// DeleteHostVolume is used to remove a dynamic host volume
+func (s *StateStore) DeleteHostVolume(index uint64, namespace, id string) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	existing, err := txn.First("host_volumes", "id", namespace, id)
+	if err != nil {
+		return fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("host volume not found: %s", id)
+	}
+
+	if err := txn.Delete("host_volumes", existing); err != nil {
+		return fmt.Errorf("host volume delete failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"host_volumes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// HostVolumeByID is used to lookup a dynamic host volume
+func (s *StateStore) HostVolumeByID(ws memdb.WatchSet, namespace, id string) (*structs.HostVolume, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch("host_volumes", "id", namespace, id)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.HostVolume), nil
+	}
+	return nil, nil
+}
+
+// HostVolumes returns an iterator over all the dynamic host volumes
+func (s *StateStore) HostVolumes(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("host_volumes", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// HostVolumesByNamespace returns an iterator over the dynamic host volumes of
+// a namespace
+func (s *StateStore) HostVolumesByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("host_volumes", "id_prefix", namespace, "")
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// HostVolumesByNodeID returns an iterator over the dynamic host volumes of a
+// node
+func (s *StateStore) HostVolumesByNodeID(ws memdb.WatchSet, nodeID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get("host_volumes", "node_id", nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("host volume lookup failed: %v", err)
+	}
+
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
 // CSIVolumeClaim updates the volume's claim count and allocation list
 func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *structs.CSIVolumeClaim) error {
 	txn := s.db.WriteTxn(index)
@@ -2339,10 +2819,19 @@ func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *s
 	// for the claim but we still want to write an updated index to the volume
 	// so that volume reaping is triggered
 	if claim.AllocationID != "" {
+		// clients claim their volumes again when they restart, which
+		// doesn't change the claim
+		_, isReader := volume.ReadClaims[claim.AllocationID]
+		_, isWriter := volume.WriteClaims[claim.AllocationID]
+		reclaim := claim.State == structs.CSIVolumeClaimStateTaken && (isReader || isWriter)
+
 		err = volume.Claim(claim, alloc)
 		if err != nil {
 			return err
 		}
+		if !reclaim {
+			volume.AppendClaimEvent(structs.NewCSIVolumeClaimEvent(claim, ""))
+		}
 	}
 
 	volume.ModifyIndex = index
@@ -2369,6 +2858,78 @@ func (s *StateStore) CSIVolumeClaim(index uint64, namespace, id string, claim *s
 	return txn.Commit()
 }
 
+// CSIVolumeClaimFailed records the failure to move a claim to its state in
+// the volume's ClaimHistory, without changing the claim. The volume's
+// ModifyIndex and the csi_volumes index are left alone, because the
+// volumewatcher retries unpublishing claims whenever they change, and the
+// retry of a stuck claim would only fail again.
+func (s *StateStore) CSIVolumeClaimFailed(index uint64, namespace, id string, claim *structs.CSIVolumeClaim, claimErr string) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	row, err := txn.First("csi_volumes", "id", namespace, id)
+	if err != nil {
+		return fmt.Errorf("volume lookup failed: %s: %v", id, err)
+	}
+	if row == nil {
+		return fmt.Errorf("volume not found: %s", id)
+	}
+
+	orig, ok := row.(*structs.CSIVolume)
+	if !ok {
+		return fmt.Errorf("volume row conversion error")
+	}
+
+	event := structs.NewCSIVolumeClaimEvent(claim, claimErr)
+	if !orig.ShouldRecordClaimFailure(event) {
+		return nil
+	}
+
+	volume := orig.Copy()
+	volume.AppendClaimEvent(event)
+
+	if err = txn.Insert("csi_volumes", volume); err != nil {
+		return fmt.Errorf("volume update failed: %s: %v", id, err)
+	}
+
+	return txn.Commit()
+}
+
+// CSIVolumeExpand sets the capacity of a volume after its plugins expanded it,
+// leaving its claims untouched
+func (s *StateStore) CSIVolumeExpand(index uint64, namespace, id string, capacity, min, max int64) error {
+	txn := s.db.WriteTxn(index)
+	defer txn.Abort()
+
+	row, err := txn.First("csi_volumes", "id", namespace, id)
+	if err != nil {
+		return fmt.Errorf("volume lookup failed: %s: %v", id, err)
+	}
+	if row == nil {
+		return fmt.Errorf("volume not found: %s", id)
+	}
+
+	orig, ok := row.(*structs.CSIVolume)
+	if !ok {
+		return fmt.Errorf("volume row conversion error")
+	}
+
+	volume := orig.Copy()
+	volume.Capacity = capacity
+	volume.RequestedCapacityMin = min
+	volume.RequestedCapacityMax = max
+	volume.ModifyIndex = index
+
+	if err = txn.Insert("csi_volumes", volume); err != nil {
+		return fmt.Errorf("volume update failed: %s: %v", id, err)
+	}
+	if err = txn.Insert("index", &IndexEntry{"csi_volumes", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
 // CSIVolumeDeregister removes the volume from the server
 func (s *StateStore) CSIVolumeDeregister(index uint64, namespace string, ids []string, force bool) error {
 	txn := s.db.WriteTxn(index)
@@ -3972,6 +4533,14 @@ func (s *StateStore) updateDeploymentStatusImpl(index uint64, u *structs.Deploym
 		return fmt.Errorf("index update failed: %v", err)
 	}
 
+	// If a blue/green deployment fails while it retains the previous
+	// allocations, switch back to them.
+	if copy.Status == structs.DeploymentStatusFailed {
+		if err := s.restorePreviousAllocsImpl(index, copy, txn); err != nil {
+			return err
+		}
+	}
+
 	// If the deployment is being marked as complete, set the job to stable.
 	if copy.Status == structs.DeploymentStatusSuccessful {
 		if err := s.updateJobStabilityImpl(index, copy.Namespace, copy.JobID, copy.JobVersion, true, txn); err != nil {
@@ -4110,6 +4679,12 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 		return err
 	}
 
+	// Promotions applied by older leaders don't carry the promotion time
+	promotedAt := req.PromotedAt
+	if promotedAt.IsZero() {
+		promotedAt = time.Now()
+	}
+
 	// Update deployment
 	copy := deployment.Copy()
 	copy.ModifyIndex = index
@@ -4121,14 +4696,35 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 
 		// reset the progress deadline
 		if status.ProgressDeadline > 0 && !status.RequireProgressBy.IsZero() {
-			status.RequireProgressBy = time.Now().Add(status.ProgressDeadline)
+			status.RequireProgressBy = promotedAt.Add(status.ProgressDeadline)
 		}
 		status.Promoted = true
+
+		// A blue/green deployment keeps the previous allocations on standby
+		if status.BlueGreen && status.RetainPrevious > 0 {
+			previous, err := s.previousAllocsImpl(copy, tg, txn)
+			if err != nil {
+				return err
+			}
+			for _, alloc := range previous {
+				if err := s.standbyAllocImpl(index, alloc, true, txn); err != nil {
+					return err
+				}
+				status.PreviousAllocs = append(status.PreviousAllocs, alloc.ID)
+			}
+			status.RetainUntil = promotedAt.Add(status.RetainPrevious)
+		}
 	}
 
 	// If the deployment no longer needs promotion, update its status
 	if !copy.RequiresPromotion() && copy.Status == structs.DeploymentStatusRunning {
 		copy.StatusDescription = structs.DeploymentStatusDescriptionRunning
+		for _, status := range copy.TaskGroups {
+			if len(status.PreviousAllocs) != 0 {
+				copy.StatusDescription = structs.DeploymentStatusDescriptionRunningRetainPrevious
+				break
+			}
+		}
 	}
 
 	// Insert the deployment
@@ -4164,6 +4760,157 @@ func (s *StateStore) UpdateDeploymentPromotion(msgType structs.MessageType, inde
 	return txn.Commit()
 }
 
+// previousAllocsImpl returns the running allocations of a task group that are
+// not part of the given deployment
+func (s *StateStore) previousAllocsImpl(deployment *structs.Deployment, group string, txn *txn) ([]*structs.Allocation, error) {
+	iter, err := txn.Get("allocs", "job", deployment.Namespace, deployment.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*structs.Allocation
+	for {
+		raw := iter.Next()
+		if raw == nil {
+			break
+		}
+
+		alloc := raw.(*structs.Allocation)
+		if alloc.TaskGroup != group || alloc.DeploymentID == deployment.ID || alloc.TerminalStatus() {
+			continue
+		}
+		if alloc.Job != nil && alloc.Job.CreateIndex != deployment.JobCreateIndex {
+			continue
+		}
+		out = append(out, alloc)
+	}
+	return out, nil
+}
+
+// standbyAllocImpl puts an allocation on standby or makes it active again.
+// The allocation modify index is updated so that clients switch the service
+// tags of the allocation.
+func (s *StateStore) standbyAllocImpl(index uint64, alloc *structs.Allocation, standby bool, txn *txn) error {
+	copy := alloc.Copy()
+	if copy.DeploymentStatus == nil {
+		copy.DeploymentStatus = &structs.AllocDeploymentStatus{}
+	}
+	copy.DeploymentStatus.Standby = standby
+	copy.DeploymentStatus.ModifyIndex = index
+	copy.ModifyIndex = index
+	copy.AllocModifyIndex = index
+
+	if err := txn.Insert("allocs", copy); err != nil {
+		return fmt.Errorf("alloc insert failed: %v", err)
+	}
+	if err := txn.Insert("index", &IndexEntry{"allocs", index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// restorePreviousAllocsImpl makes the previous allocations retained by a
+// blue/green deployment active again, and puts the allocations placed by the
+// deployment on standby until the scheduler stops them.
+func (s *StateStore) restorePreviousAllocsImpl(index uint64, deployment *structs.Deployment, txn *txn) error {
+	lookup := func(ids []string) ([]*structs.Allocation, error) {
+		var out []*structs.Allocation
+		for _, id := range ids {
+			raw, err := txn.First("allocs", "id", id)
+			if err != nil {
+				return nil, fmt.Errorf("alloc %q lookup failed: %v", id, err)
+			}
+			if raw == nil {
+				continue
+			}
+			if alloc := raw.(*structs.Allocation); !alloc.TerminalStatus() {
+				out = append(out, alloc)
+			}
+		}
+		return out, nil
+	}
+
+	for _, dstate := range deployment.TaskGroups {
+		if !dstate.BlueGreen || len(dstate.PreviousAllocs) == 0 {
+			continue
+		}
+
+		previous, err := lookup(dstate.PreviousAllocs)
+		if err != nil {
+			return err
+		}
+		if len(previous) == 0 {
+			continue
+		}
+		placed, err := lookup(dstate.PlacedCanaries)
+		if err != nil {
+			return err
+		}
+
+		for _, alloc := range previous {
+			if err := s.standbyAllocImpl(index, alloc, false, txn); err != nil {
+				return err
+			}
+		}
+		for _, alloc := range placed {
+			if err := s.standbyAllocImpl(index, alloc, true, txn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateDeploymentCanaryStep is used to move the task groups of a deployment
+// through their canary steps and potentially make a evaluation
+func (s *StateStore) UpdateDeploymentCanaryStep(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentCanaryStepRequest) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Retrieve deployment and ensure it is not terminal and is active
+	ws := memdb.NewWatchSet()
+	deployment, err := s.deploymentByIDImpl(ws, req.DeploymentID, txn)
+	if err != nil {
+		return err
+	} else if deployment == nil {
+		return fmt.Errorf("Deployment ID %q couldn't be updated as it does not exist", req.DeploymentID)
+	} else if !deployment.Active() {
+		return fmt.Errorf("Deployment %q has terminal status %q:", deployment.ID, deployment.Status)
+	}
+
+	copy := deployment.Copy()
+	copy.ModifyIndex = index
+	for _, u := range req.Updates {
+		dstate, ok := copy.TaskGroups[u.TaskGroup]
+		if !ok {
+			return fmt.Errorf("Deployment %q has no task group %q", deployment.ID, u.TaskGroup)
+		} else if dstate.Promoted {
+			return fmt.Errorf("Task group %q of deployment %q is already promoted", u.TaskGroup, deployment.ID)
+		}
+
+		if !u.RequireProgressBy.IsZero() {
+			dstate.RequireProgressBy = u.RequireProgressBy
+		}
+		dstate.CanaryStep = u.CanaryStep
+		dstate.DesiredCanaries = u.DesiredCanaries
+		dstate.NextCanaryStepAt = u.NextCanaryStepAt
+	}
+
+	// Insert the deployment
+	if err := s.upsertDeploymentImpl(index, copy, txn); err != nil {
+		return err
+	}
+
+	// Upsert the optional eval
+	if req.Eval != nil {
+		if err := s.nestedUpsertEval(txn, index, req.Eval); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
 // UpdateDeploymentAllocHealth is used to update the health of allocations as
 // part of the deployment and potentially make a evaluation
 func (s *StateStore) UpdateDeploymentAllocHealth(msgType structs.MessageType, index uint64, req *structs.ApplyDeploymentAllocHealthRequest) error {
@@ -6436,6 +7183,38 @@ func (r *StateRestore) ScalingEventsRestore(jobEvents *structs.JobScalingEvents)
 	return nil
 }
 
+// PreemptionHistoryRestore is used to restore the preemption history of a job
+func (r *StateRestore) PreemptionHistoryRestore(history *structs.JobPreemptionHistory) error {
+	if err := r.txn.Insert("preemption_history", history); err != nil {
+		return fmt.Errorf("preemption history insert failed: %v", err)
+	}
+	return nil
+}
+
+// HostVolumeRestore is used to restore a dynamic host volume
+func (r *StateRestore) HostVolumeRestore(volume *structs.HostVolume) error {
+	if err := r.txn.Insert("host_volumes", volume); err != nil {
+		return fmt.Errorf("host volume insert failed: %v", err)
+	}
+	return nil
+}
+
+// DrainBatchRestore is used to restore a drain batch
+func (r *StateRestore) DrainBatchRestore(batch *structs.DrainBatch) error {
+	if err := r.txn.Insert("drain_batches", batch); err != nil {
+		return fmt.Errorf("drain batch insert failed: %v", err)
+	}
+	return nil
+}
+
+// NodeMaintenanceWindowRestore is used to restore a node maintenance window
+func (r *StateRestore) NodeMaintenanceWindowRestore(window *structs.NodeMaintenanceWindow) error {
+	if err := r.txn.Insert("node_maintenance_windows", window); err != nil {
+		return fmt.Errorf("node maintenance window insert failed: %v", err)
+	}
+	return nil
+}
+
 // NamespaceRestore is used to restore a namespace
 func (r *StateRestore) NamespaceRestore(ns *structs.Namespace) error {
 	if err := r.txn.Insert(TableNamespaces, ns); err != nil {
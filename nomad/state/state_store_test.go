@@ -435,6 +435,76 @@ func TestStateStore_UpsertPlanResults_PreemptedAllocs(t *testing.T) {
 
 }
 
+// This test checks that preemptions are recorded against the budget of the
+// preempted job and pruned once they leave the budget window
+func TestStateStore_UpsertPlanResults_PreemptionHistory(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	alloc := mock.Alloc()
+	job := alloc.Job
+	alloc.Job = nil
+
+	preemptedJob := mock.Job()
+	preemptedJob.Preemption = &structs.PreemptionPolicy{
+		Preemptible:    true,
+		MaxPreemptions: 2,
+		Window:         time.Hour,
+	}
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 900, job))
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 901, preemptedJob))
+
+	var preemptedAllocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		a := mock.Alloc()
+		a.Job = preemptedJob
+		a.JobID = preemptedJob.ID
+		preemptedAllocs = append(preemptedAllocs, a)
+	}
+	require.NoError(state.UpsertAllocs(structs.MsgTypeTestSetup, 902, preemptedAllocs))
+
+	now := time.Now()
+	for i, preemptedAt := range []time.Time{now.Add(-2 * time.Hour), now} {
+		res := structs.ApplyPlanResultsRequest{
+			AllocUpdateRequest: structs.AllocUpdateRequest{
+				AllocsUpdated: []*structs.Allocation{alloc},
+				Job:           job,
+			},
+			AllocsPreempted: []*structs.AllocationDiff{{
+				ID:                    preemptedAllocs[i].ID,
+				PreemptedByAllocation: alloc.ID,
+				ModifyTime:            preemptedAt.UnixNano(),
+			}},
+		}
+		require.NoError(state.UpsertPlanResults(structs.MsgTypeTestSetup, uint64(1000+i), &res))
+	}
+
+	// Only the preemption within the window of the latest is kept
+	history, err := state.PreemptionHistoryByJob(nil, preemptedJob.Namespace, preemptedJob.ID)
+	require.NoError(err)
+	require.NotNil(history)
+	require.Equal([]int64{now.UnixNano()}, history.Preemptions)
+	require.EqualValues(1000, history.CreateIndex)
+	require.EqualValues(1001, history.ModifyIndex)
+	require.Equal(1, history.PreemptionsSince(now.Add(-time.Hour).UnixNano()))
+
+	index, err := state.Index("preemption_history")
+	require.NoError(err)
+	require.EqualValues(1001, index)
+
+	// Jobs without a budget have no history
+	history, err = state.PreemptionHistoryByJob(nil, job.Namespace, job.ID)
+	require.NoError(err)
+	require.Nil(history)
+
+	// Purging the job deletes its history
+	require.NoError(state.DeleteJob(1002, preemptedJob.Namespace, preemptedJob.ID))
+	history, err = state.PreemptionHistoryByJob(nil, preemptedJob.Namespace, preemptedJob.ID)
+	require.NoError(err)
+	require.Nil(history)
+}
+
 // This test checks that deployment updates are applied correctly
 func TestStateStore_UpsertPlanResults_DeploymentUpdates(t *testing.T) {
 	t.Parallel()
@@ -2836,6 +2906,64 @@ func TestStateStore_RestoreJobSummary(t *testing.T) {
 }
 
 // TestStateStore_CSIVolume checks register, list and deregister for csi_volumes
+func TestStateStore_HostVolumes(t *testing.T) {
+	t.Parallel()
+	state := testStateStore(t)
+
+	node := mock.Node()
+	vol0 := mock.HostVolume(node)
+	vol1 := mock.HostVolume(node)
+	vol1.Name = "other-host-vol"
+	vol2 := mock.HostVolume(mock.Node())
+
+	require.NoError(t, state.UpsertHostVolumes(1000, []*structs.HostVolume{vol0, vol1, vol2}))
+
+	out, err := state.HostVolumeByID(nil, vol0.Namespace, vol0.ID)
+	require.NoError(t, err)
+	require.Equal(t, vol0, out)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+
+	iter, err := state.HostVolumesByNodeID(nil, node.ID)
+	require.NoError(t, err)
+	count := 0
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(t, 2, count)
+
+	// names are unique per node
+	dupe := mock.HostVolume(node)
+	err = state.UpsertHostVolumes(1001, []*structs.HostVolume{dupe})
+	require.EqualError(t, err, fmt.Sprintf(
+		"host volume %q already exists on node %s", dupe.Name, node.ID))
+
+	// updates keep the create index
+	update := vol0.Copy()
+	update.CapacityBytes = 2048
+	require.NoError(t, state.UpsertHostVolumes(1002, []*structs.HostVolume{update}))
+	out, err = state.HostVolumeByID(nil, vol0.Namespace, vol0.ID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), out.CreateIndex)
+	require.Equal(t, uint64(1002), out.ModifyIndex)
+	require.Equal(t, int64(2048), out.CapacityBytes)
+
+	// volumes must be in a namespace that exists
+	missing := mock.HostVolume(node)
+	missing.Name = "missing-ns"
+	missing.Namespace = "missing"
+	require.Error(t, state.UpsertHostVolumes(1003, []*structs.HostVolume{missing}))
+
+	require.NoError(t, state.DeleteHostVolume(1004, vol0.Namespace, vol0.ID))
+	out, err = state.HostVolumeByID(nil, vol0.Namespace, vol0.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	require.Error(t, state.DeleteHostVolume(1005, vol0.Namespace, vol0.ID))
+
+	index, err := state.Index("host_volumes")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1004), index)
+}
+
 func TestStateStore_CSIVolume(t *testing.T) {
 	state := testStateStore(t)
 	index := uint64(1000)
@@ -2996,6 +3124,28 @@ func TestStateStore_CSIVolume(t *testing.T) {
 	index++
 	err = state.CSIVolumeRegister(index, []*structs.CSIVolume{v0})
 	require.Error(t, err, fmt.Sprintf("volume exists: %s", vol0))
+
+	// even with its claims carried over
+	index++
+	v3, err := state.CSIVolumeByID(nil, ns, vol0)
+	require.NoError(t, err)
+	v3 = v3.Copy()
+	v3.Capacity = 2048
+	err = state.CSIVolumeRegister(index, []*structs.CSIVolume{v3})
+	require.Error(t, err, fmt.Sprintf("volume exists: %s", vol0))
+
+	// but its capacity can be expanded without touching its claims
+	index++
+	err = state.CSIVolumeExpand(index, ns, vol0, 2048, 2000, 4000)
+	require.NoError(t, err)
+	v3, err = state.CSIVolumeByID(nil, ns, vol0)
+	require.NoError(t, err)
+	require.Equal(t, int64(2048), v3.Capacity)
+	require.Equal(t, int64(2000), v3.RequestedCapacityMin)
+	require.Equal(t, int64(4000), v3.RequestedCapacityMax)
+	require.Equal(t, index, v3.ModifyIndex)
+	require.True(t, v3.InUse())
+
 	// as is deregistration
 	index++
 	err = state.CSIVolumeDeregister(index, ns, []string{vol0}, false)
@@ -7187,6 +7337,149 @@ func TestStateStore_UpsertDeploymentPromotion_Subset(t *testing.T) {
 	require.True(aout3.DeploymentStatus.Canary)
 }
 
+// Test promoting a blue/green deployment puts the previous allocations on
+// standby, and that failing it makes them active again
+func TestStateStore_UpsertDeploymentPromotion_BlueGreen(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+
+	j := mock.Job()
+	require.Nil(state.UpsertJob(structs.MsgTypeTestSetup, 1, j))
+
+	// Create a blue/green deployment retaining the previous allocations
+	d := mock.Deployment()
+	d.JobID = j.ID
+	d.JobCreateIndex = j.CreateIndex
+	d.TaskGroups["web"].DesiredCanaries = 1
+	d.TaskGroups["web"].BlueGreen = true
+	d.TaskGroups["web"].RetainPrevious = time.Hour
+	require.Nil(state.UpsertDeployment(2, d))
+
+	// Create a previous allocation and a healthy canary
+	prev := mock.Alloc()
+	prev.Job = j
+	prev.JobID = j.ID
+
+	c := mock.Alloc()
+	c.Job = j
+	c.JobID = j.ID
+	c.DeploymentID = d.ID
+	c.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: helper.BoolToPtr(true),
+		Canary:  true,
+	}
+	d.TaskGroups["web"].PlacedCanaries = []string{c.ID}
+	require.Nil(state.UpsertAllocs(structs.MsgTypeTestSetup, 3, []*structs.Allocation{prev, c}))
+
+	promotedAt := time.Now().Add(-time.Minute)
+	req := &structs.ApplyDeploymentPromoteRequest{
+		DeploymentPromoteRequest: structs.DeploymentPromoteRequest{
+			DeploymentID: d.ID,
+			All:          true,
+		},
+		PromotedAt: promotedAt,
+	}
+	require.Nil(state.UpdateDeploymentPromotion(structs.MsgTypeTestSetup, 4, req))
+
+	ws := memdb.NewWatchSet()
+	dout, err := state.DeploymentByID(ws, d.ID)
+	require.Nil(err)
+	dstate := dout.TaskGroups["web"]
+	require.True(dstate.Promoted)
+	require.Equal([]string{prev.ID}, dstate.PreviousAllocs)
+	require.Equal(promotedAt.Add(time.Hour), dstate.RetainUntil)
+	require.True(dstate.Retaining(time.Now()))
+	require.Equal(structs.DeploymentStatusDescriptionRunningRetainPrevious, dout.StatusDescription)
+
+	pout, err := state.AllocByID(ws, prev.ID)
+	require.Nil(err)
+	require.True(pout.DeploymentStatus.IsStandby())
+	require.EqualValues(4, pout.AllocModifyIndex)
+
+	cout, err := state.AllocByID(ws, c.ID)
+	require.Nil(err)
+	require.False(cout.DeploymentStatus.IsCanary())
+	require.False(cout.DeploymentStatus.IsStandby())
+
+	// Failing the deployment switches back to the previous allocations
+	update := &structs.DeploymentStatusUpdateRequest{
+		DeploymentUpdate: &structs.DeploymentStatusUpdate{
+			DeploymentID:      d.ID,
+			Status:            structs.DeploymentStatusFailed,
+			StatusDescription: structs.DeploymentStatusDescriptionRolledBackByUser,
+		},
+	}
+	require.Nil(state.UpdateDeploymentStatus(structs.MsgTypeTestSetup, 5, update))
+
+	pout, err = state.AllocByID(ws, prev.ID)
+	require.Nil(err)
+	require.False(pout.DeploymentStatus.IsStandby())
+
+	cout, err = state.AllocByID(ws, c.ID)
+	require.Nil(err)
+	require.True(cout.DeploymentStatus.IsStandby())
+	require.EqualValues(5, cout.AllocModifyIndex)
+}
+
+// Test moving a deployment through its canary steps
+func TestStateStore_UpdateDeploymentCanaryStep(t *testing.T) {
+	t.Parallel()
+
+	state := testStateStore(t)
+	require := require.New(t)
+
+	d := mock.Deployment()
+	d.TaskGroups["web"].CanarySteps = []*structs.CanaryStep{
+		{Percent: 10},
+		{Percent: 50},
+	}
+	d.TaskGroups["web"].DesiredCanaries = 1
+	d.TaskGroups["web"].ProgressDeadline = time.Minute
+	d.TaskGroups["web"].RequireProgressBy = time.Now().Add(-time.Minute)
+	require.NoError(state.UpsertDeployment(1, d))
+
+	// Advance to the next step with an eval
+	e := mock.Eval()
+	deadline := time.Now().Add(time.Minute).Round(0)
+	req := &structs.ApplyDeploymentCanaryStepRequest{
+		DeploymentID: d.ID,
+		Updates: []*structs.DeploymentCanaryStepUpdate{{
+			TaskGroup:         "web",
+			CanaryStep:        1,
+			DesiredCanaries:   5,
+			RequireProgressBy: deadline,
+		}},
+		Eval: e,
+	}
+	require.NoError(state.UpdateDeploymentCanaryStep(structs.MsgTypeTestSetup, 2, req))
+
+	out, err := state.DeploymentByID(nil, d.ID)
+	require.NoError(err)
+	dstate := out.TaskGroups["web"]
+	require.Equal(1, dstate.CanaryStep)
+	require.Equal(5, dstate.DesiredCanaries)
+	require.Equal(deadline, dstate.RequireProgressBy)
+	require.Equal(uint64(2), out.ModifyIndex)
+
+	eval, err := state.EvalByID(nil, e.ID)
+	require.NoError(err)
+	require.NotNil(eval)
+
+	// Unknown groups and terminal deployments aren't updated
+	req.Updates[0].TaskGroup = "foo"
+	require.Error(state.UpdateDeploymentCanaryStep(structs.MsgTypeTestSetup, 3, req))
+
+	out = out.Copy()
+	out.Status = structs.DeploymentStatusFailed
+	require.NoError(state.UpsertDeployment(4, out))
+	req.Updates[0].TaskGroup = "web"
+	err = state.UpdateDeploymentCanaryStep(structs.MsgTypeTestSetup, 5, req)
+	require.Error(err)
+	require.Contains(err.Error(), "has terminal status")
+}
+
 // Test that allocation health can't be set against a nonexistent deployment
 func TestStateStore_UpsertDeploymentAllocHealth_Nonexistent(t *testing.T) {
 	t.Parallel()
@@ -9878,3 +10171,213 @@ func (n AllocIDSort) Less(i, j int) bool {
 func (n AllocIDSort) Swap(i, j int) {
 	n[i], n[j] = n[j], n[i]
 }
+
+func TestStateStore_UpsertDrainBatches(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	require.Nil(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	batch := mock.DrainBatch()
+	batch.Nodes[0].NodeID = node.ID
+
+	// Create a watchset so we can test that the upsert fires the watch
+	ws := memdb.NewWatchSet()
+	_, err := state.DrainBatchByID(ws, batch.ID)
+	require.Nil(err)
+
+	req := &structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{batch}}
+	require.Nil(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1001, req))
+	require.True(watchFired(ws))
+
+	out, err := state.DrainBatchByID(nil, batch.ID)
+	require.Nil(err)
+	require.Equal(batch, out)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	// Start draining the node of the batch
+	updated := batch.Copy()
+	updated.Nodes[0].Status = structs.DrainBatchNodeStatusDraining
+	strategy := &structs.DrainStrategy{
+		DrainSpec: *batch.DrainSpec,
+	}
+	event := &structs.NodeEvent{
+		Message:   "Node drain strategy set by drain batch",
+		Subsystem: structs.NodeEventSubsystemDrain,
+		Timestamp: time.Now(),
+	}
+	req = &structs.DrainBatchUpsertRequest{
+		Batches:    []*structs.DrainBatch{updated},
+		NodeDrains: map[string]*structs.DrainUpdate{node.ID: {DrainStrategy: strategy}},
+		NodeEvents: map[string]*structs.NodeEvent{node.ID: event},
+		UpdatedAt:  7,
+	}
+	require.Nil(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1002, req))
+
+	out, err = state.DrainBatchByID(nil, batch.ID)
+	require.Nil(err)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1002, out.ModifyIndex)
+	require.Equal(structs.DrainBatchNodeStatusDraining, out.Nodes[0].Status)
+
+	outNode, err := state.NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.Equal(strategy, outNode.DrainStrategy)
+	require.NotNil(outNode.LastDrain)
+	require.Equal(batch.ID, outNode.LastDrain.Meta["batch_id"])
+	require.Len(outNode.Events, 2)
+	require.EqualValues(1002, outNode.ModifyIndex)
+
+	iter, err := state.DrainBatchesByIDPrefix(nil, batch.ID[:4])
+	require.Nil(err)
+	require.NotNil(iter.Next())
+	require.Nil(iter.Next())
+
+	index, err := state.Index("drain_batches")
+	require.Nil(err)
+	require.EqualValues(1002, index)
+
+	// Node drains must come with a single batch
+	req.Batches = append(req.Batches, mock.DrainBatch())
+	require.Error(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1003, req))
+
+	// Updates computed from an earlier version of the batch are rejected
+	out, err = state.DrainBatchByID(nil, batch.ID)
+	require.Nil(err)
+	stale := out.Copy()
+	req = &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{stale},
+		EnforceIndex: true,
+		ModifyIndex:  1001,
+	}
+	require.Error(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1004, req))
+
+	cancelled := out.Copy()
+	cancelled.Status = structs.DrainBatchStatusCancelled
+	req = &structs.DrainBatchUpsertRequest{
+		Batches:      []*structs.DrainBatch{cancelled},
+		EnforceIndex: true,
+		ModifyIndex:  out.ModifyIndex,
+	}
+	require.Nil(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1005, req))
+
+	// Terminal batches can't be updated
+	running := out.Copy()
+	req = &structs.DrainBatchUpsertRequest{Batches: []*structs.DrainBatch{running}}
+	require.Error(state.UpsertDrainBatches(structs.MsgTypeTestSetup, 1006, req))
+
+	out, err = state.DrainBatchByID(nil, batch.ID)
+	require.Nil(err)
+	require.Equal(structs.DrainBatchStatusCancelled, out.Status)
+	require.EqualValues(1005, out.ModifyIndex)
+}
+
+func TestStateStore_UpsertNodeMaintenanceWindows(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	state := testStateStore(t)
+	node := mock.Node()
+	require.Nil(state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	window := mock.NodeMaintenanceWindow()
+	window.NodeIDs = []string{node.ID}
+
+	// Create a watchset so we can test that the upsert fires the watch
+	ws := memdb.NewWatchSet()
+	_, err := state.NodeMaintenanceWindowByID(ws, window.ID)
+	require.Nil(err)
+
+	req := &structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{window}}
+	require.Nil(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1001, req))
+	require.True(watchFired(ws))
+
+	out, err := state.NodeMaintenanceWindowByID(nil, window.ID)
+	require.Nil(err)
+	require.Equal(window, out)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1001, out.ModifyIndex)
+
+	// Start the window and drain its node
+	updated := window.Copy()
+	updated.Status = structs.NodeMaintenanceWindowStatusActive
+	updated.Nodes = []*structs.NodeMaintenanceWindowNode{{
+		NodeID: node.ID,
+		Status: structs.NodeMaintenanceWindowNodeStatusActive,
+	}}
+	strategy := &structs.DrainStrategy{
+		DrainSpec: *window.DrainSpec,
+	}
+	event := &structs.NodeEvent{
+		Message:   "Node drain strategy set by maintenance window",
+		Subsystem: structs.NodeEventSubsystemDrain,
+		Timestamp: time.Now(),
+	}
+	req = &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:    []*structs.NodeMaintenanceWindow{updated},
+		NodeDrains: map[string]*structs.DrainUpdate{node.ID: {DrainStrategy: strategy}},
+		NodeEvents: map[string]*structs.NodeEvent{node.ID: event},
+		UpdatedAt:  7,
+	}
+	require.Nil(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1002, req))
+
+	out, err = state.NodeMaintenanceWindowByID(nil, window.ID)
+	require.Nil(err)
+	require.EqualValues(1001, out.CreateIndex)
+	require.EqualValues(1002, out.ModifyIndex)
+	require.Equal(structs.NodeMaintenanceWindowStatusActive, out.Status)
+
+	outNode, err := state.NodeByID(nil, node.ID)
+	require.Nil(err)
+	require.Equal(strategy, outNode.DrainStrategy)
+	require.Equal(structs.NodeSchedulingIneligible, outNode.SchedulingEligibility)
+	require.NotNil(outNode.LastDrain)
+	require.Equal(window.ID, outNode.LastDrain.Meta["maintenance_window_id"])
+	require.Len(outNode.Events, 2)
+
+	iter, err := state.NodeMaintenanceWindowsByIDPrefix(nil, window.ID[:4])
+	require.Nil(err)
+	require.NotNil(iter.Next())
+	require.Nil(iter.Next())
+
+	index, err := state.Index("node_maintenance_windows")
+	require.Nil(err)
+	require.EqualValues(1002, index)
+
+	// Node drains must come with a single window
+	req.Windows = append(req.Windows, mock.NodeMaintenanceWindow())
+	require.Error(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1003, req))
+
+	// Updates computed from an earlier version of the window are rejected
+	out, err = state.NodeMaintenanceWindowByID(nil, window.ID)
+	require.Nil(err)
+	stale := out.Copy()
+	req = &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{stale},
+		EnforceIndex: true,
+		ModifyIndex:  1001,
+	}
+	require.Error(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1004, req))
+
+	cancelled := out.Copy()
+	cancelled.Status = structs.NodeMaintenanceWindowStatusCancelled
+	req = &structs.NodeMaintenanceWindowUpsertRequest{
+		Windows:      []*structs.NodeMaintenanceWindow{cancelled},
+		EnforceIndex: true,
+		ModifyIndex:  out.ModifyIndex,
+	}
+	require.Nil(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1005, req))
+
+	// Terminal windows can't be updated
+	active := out.Copy()
+	req = &structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{active}}
+	require.Error(state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1006, req))
+
+	out, err = state.NodeMaintenanceWindowByID(nil, window.ID)
+	require.Nil(err)
+	require.Equal(structs.NodeMaintenanceWindowStatusCancelled, out.Status)
+	require.EqualValues(1005, out.ModifyIndex)
+}
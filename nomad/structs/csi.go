@@ -227,6 +227,9 @@ type CSIVolumeClaim struct {
 	AccessMode     CSIVolumeAccessMode
 	AttachmentMode CSIVolumeAttachmentMode
 	State          CSIVolumeClaimState
+
+	// UpdatedAt is the server time of the last change to the claim
+	UpdatedAt int64
 }
 
 type CSIVolumeClaimState int
@@ -239,6 +242,57 @@ const (
 	CSIVolumeClaimStateUnpublishing
 )
 
+// csiVolumeClaimHistoryLimit is the number of events kept in a volume's
+// ClaimHistory
+const csiVolumeClaimHistoryLimit = 20
+
+// csiVolumeClaimFailureInterval is the minimum time between two recorded
+// failures of a claim to move to the same state
+const csiVolumeClaimFailureInterval = 5 * time.Minute
+
+const (
+	CSIVolumeClaimEventClaimed               = "claimed"
+	CSIVolumeClaimEventUnpublishing          = "unpublishing"
+	CSIVolumeClaimEventUnpublishedNode       = "unpublished-node"
+	CSIVolumeClaimEventUnpublishedController = "unpublished-controller"
+	CSIVolumeClaimEventFreed                 = "freed"
+)
+
+// CSIVolumeClaimEvent is an entry in a volume's ClaimHistory, recording a
+// claim moving to a new state. If Error is set, the claim failed to move to
+// that state.
+type CSIVolumeClaimEvent struct {
+	AllocationID string
+	NodeID       string
+	Type         string
+	Error        string
+	Time         int64 // Unix Nanosecond timestamp
+}
+
+// NewCSIVolumeClaimEvent returns the event for a claim moving to its current
+// state.
+func NewCSIVolumeClaimEvent(claim *CSIVolumeClaim, err string) *CSIVolumeClaimEvent {
+	event := &CSIVolumeClaimEvent{
+		AllocationID: claim.AllocationID,
+		NodeID:       claim.NodeID,
+		Error:        err,
+		Time:         claim.UpdatedAt,
+	}
+	switch claim.State {
+	case CSIVolumeClaimStateTaken:
+		event.Type = CSIVolumeClaimEventClaimed
+	case CSIVolumeClaimStateNodeDetached:
+		event.Type = CSIVolumeClaimEventUnpublishedNode
+	case CSIVolumeClaimStateControllerDetached:
+		event.Type = CSIVolumeClaimEventUnpublishedController
+	case CSIVolumeClaimStateReadyToFree:
+		event.Type = CSIVolumeClaimEventFreed
+	case CSIVolumeClaimStateUnpublishing:
+		event.Type = CSIVolumeClaimEventUnpublishing
+	}
+	return event
+}
+
 // CSIVolume is the full representation of a CSI Volume
 type CSIVolume struct {
 	// ID is a namespace unique URL safe identifier for the volume
@@ -263,6 +317,7 @@ type CSIVolume struct {
 	RequestedCapacityMin  int64 // bytes
 	RequestedCapacityMax  int64 // bytes
 	RequestedCapabilities []*CSIVolumeCapability
+	RequestedTopologies   *CSITopologyRequest
 	CloneID               string
 	SnapshotID            string
 
@@ -274,6 +329,9 @@ type CSIVolume struct {
 	WriteClaims map[string]*CSIVolumeClaim // AllocID -> claim
 	PastClaims  map[string]*CSIVolumeClaim // AllocID -> claim
 
+	// ClaimHistory is the most recent claim events, oldest first
+	ClaimHistory []*CSIVolumeClaimEvent
+
 	// Schedulable is true if all the denormalized plugin health fields are true, and the
 	// volume has not been marked for garbage collection
 	Schedulable         bool
@@ -453,6 +511,7 @@ func (v *CSIVolume) Copy() *CSIVolume {
 	for _, t := range v.Topologies {
 		out.Topologies = append(out.Topologies, t.Copy())
 	}
+	out.RequestedTopologies = v.RequestedTopologies.Copy()
 	if v.MountOptions != nil {
 		*out.MountOptions = *v.MountOptions
 	}
@@ -473,6 +532,12 @@ func (v *CSIVolume) Copy() *CSIVolume {
 		out.WriteAllocs[k] = alloc.Copy()
 	}
 
+	out.ClaimHistory = nil
+	for _, e := range v.ClaimHistory {
+		event := *e
+		out.ClaimHistory = append(out.ClaimHistory, &event)
+	}
+
 	for k, v := range v.ReadClaims {
 		claim := *v
 		out.ReadClaims[k] = &claim
@@ -582,6 +647,32 @@ func (v *CSIVolume) claimWrite(claim *CSIVolumeClaim, alloc *Allocation) error {
 	return nil
 }
 
+// AppendClaimEvent adds the event to the volume's ClaimHistory, dropping the
+// oldest events past the limit.
+func (v *CSIVolume) AppendClaimEvent(event *CSIVolumeClaimEvent) {
+	v.ClaimHistory = append(v.ClaimHistory, event)
+	if n := len(v.ClaimHistory); n > csiVolumeClaimHistoryLimit {
+		v.ClaimHistory = v.ClaimHistory[n-csiVolumeClaimHistoryLimit:]
+	}
+}
+
+// ShouldRecordClaimFailure returns whether the failure event should be added
+// to the volume's ClaimHistory. The volumewatcher retries unpublishing stuck
+// claims, so a failure of the claim that's anywhere in the history isn't
+// recorded again, and other failures of the claim to move to the same state
+// are recorded at most once per csiVolumeClaimFailureInterval.
+func (v *CSIVolume) ShouldRecordClaimFailure(event *CSIVolumeClaimEvent) bool {
+	for _, e := range v.ClaimHistory {
+		if e.Error == "" || e.AllocationID != event.AllocationID || e.Type != event.Type {
+			continue
+		}
+		if e.Error == event.Error || event.Time-e.Time < int64(csiVolumeClaimFailureInterval) {
+			return false
+		}
+	}
+	return true
+}
+
 // setModesFromClaim sets the volume AttachmentMode and AccessMode based on
 // the first claim we make.  Originally the volume AccessMode and
 // AttachmentMode were set during registration, but this is incorrect once we
@@ -667,6 +758,18 @@ func (v *CSIVolume) Validate() error {
 	if len(v.RequestedCapabilities) == 0 {
 		errs = append(errs, "must include at least one capability block")
 	}
+	if v.RequestedTopologies != nil {
+		for _, t := range v.RequestedTopologies.Required {
+			if t == nil || len(t.Segments) == 0 {
+				errs = append(errs, "required topology is missing segments field")
+			}
+		}
+		for _, t := range v.RequestedTopologies.Preferred {
+			if t == nil || len(t.Segments) == 0 {
+				errs = append(errs, "preferred topology is missing segments field")
+			}
+		}
+	}
 
 	// TODO: Volume Topologies are optional - We should check to see if the plugin
 	//       the volume is being registered with requires them.
@@ -697,6 +800,17 @@ type CSIVolumeRegisterResponse struct {
 	QueryMeta
 }
 
+// CSIVolumeExpandRequest records the capacity of a volume after its plugins
+// expanded it. It's only applied through raft, and leaves the claims of the
+// volume alone so that volumes in use can be expanded.
+type CSIVolumeExpandRequest struct {
+	VolumeID             string
+	Capacity             int64
+	RequestedCapacityMin int64
+	RequestedCapacityMax int64
+	WriteRequest
+}
+
 type CSIVolumeDeregisterRequest struct {
 	VolumeIDs []string
 	Force     bool
@@ -751,6 +865,14 @@ type CSIVolumeClaimRequest struct {
 	AccessMode     CSIVolumeAccessMode
 	AttachmentMode CSIVolumeAttachmentMode
 	State          CSIVolumeClaimState
+
+	// UpdatedAt represents server time of receiving request
+	UpdatedAt int64
+
+	// Error is set when the request only records a failure to move the
+	// claim to State in the volume's ClaimHistory
+	Error string
+
 	WriteRequest
 }
 
@@ -763,6 +885,7 @@ func (req *CSIVolumeClaimRequest) ToClaim() *CSIVolumeClaim {
 		AccessMode:     req.AccessMode,
 		AttachmentMode: req.AttachmentMode,
 		State:          req.State,
+		UpdatedAt:      req.UpdatedAt,
 	}
 }
 
@@ -852,6 +975,36 @@ type CSIVolumeUnpublishResponse struct {
 	QueryMeta
 }
 
+const (
+	CSIVolumeReclaimAllocGC       = "allocation garbage collected"
+	CSIVolumeReclaimAllocTerminal = "allocation terminal"
+	CSIVolumeReclaimNodeLost      = "node lost"
+)
+
+// CSIVolumeReclaimRequest releases the claims on a volume of allocations
+// that are terminal or garbage collected.
+type CSIVolumeReclaimRequest struct {
+	VolumeID string
+
+	// DryRun returns the claims that would be released without changing
+	// them
+	DryRun bool
+
+	WriteRequest
+}
+
+type CSIVolumeReclaimResponse struct {
+	Claims []*CSIVolumeReclaimedClaim
+	QueryMeta
+}
+
+// CSIVolumeReclaimedClaim is a claim released by CSIVolume.Reclaim
+type CSIVolumeReclaimedClaim struct {
+	AllocationID string
+	NodeID       string
+	Reason       string
+}
+
 // CSISnapshot is the storage provider's view of a volume snapshot
 type CSISnapshot struct {
 	// These fields map to those returned by the storage provider plugin
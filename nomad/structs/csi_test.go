@@ -521,6 +521,11 @@ func TestVolume_Copy(t *testing.T) {
 		WriteClaims: map[string]*CSIVolumeClaim{a3.ID: c3},
 		PastClaims:  map[string]*CSIVolumeClaim{},
 
+		ClaimHistory: []*CSIVolumeClaimEvent{
+			NewCSIVolumeClaimEvent(c1, ""),
+			NewCSIVolumeClaimEvent(c2, "could not detach from node"),
+		},
+
 		Schedulable:         true,
 		PluginID:            "moosefs",
 		Provider:            "n/a",
@@ -542,6 +547,7 @@ func TestVolume_Copy(t *testing.T) {
 	v1.ReadAllocs[a2.ID] = a2
 	v1.WriteAllocs[a3.ID].ClientStatus = AllocClientStatusComplete
 	v1.MountOptions.FSType = "zfs"
+	v1.ClaimHistory[1].Error = ""
 
 	if v2.ReadClaims[a1.ID].State == CSIVolumeClaimStateReadyToFree {
 		t.Fatalf("Volume.Copy() failed; changes to original ReadClaims seen in copy")
@@ -555,9 +561,71 @@ func TestVolume_Copy(t *testing.T) {
 	if v2.MountOptions.FSType == "zfs" {
 		t.Fatalf("Volume.Copy() failed; changes to original MountOptions seen in copy")
 	}
+	if v2.ClaimHistory[1].Error == "" {
+		t.Fatalf("Volume.Copy() failed; changes to original ClaimHistory seen in copy")
+	}
 
 }
 
+func TestCSIVolume_AppendClaimEvent(t *testing.T) {
+	vol := &CSIVolume{}
+	claim := &CSIVolumeClaim{
+		AllocationID: "a1",
+		NodeID:       "n1",
+		State:        CSIVolumeClaimStateTaken,
+		UpdatedAt:    1,
+	}
+	vol.AppendClaimEvent(NewCSIVolumeClaimEvent(claim, ""))
+	require.Equal(t, []*CSIVolumeClaimEvent{{
+		AllocationID: "a1",
+		NodeID:       "n1",
+		Type:         CSIVolumeClaimEventClaimed,
+		Time:         1,
+	}}, vol.ClaimHistory)
+
+	// the oldest events are dropped past the limit
+	for i := 0; i < csiVolumeClaimHistoryLimit; i++ {
+		claim.State = CSIVolumeClaimStateNodeDetached
+		claim.UpdatedAt = int64(i + 2)
+		vol.AppendClaimEvent(NewCSIVolumeClaimEvent(claim, "could not detach from node"))
+	}
+	require.Len(t, vol.ClaimHistory, csiVolumeClaimHistoryLimit)
+	require.Equal(t, int64(2), vol.ClaimHistory[0].Time)
+	require.Equal(t, CSIVolumeClaimEventUnpublishedNode, vol.ClaimHistory[0].Type)
+	require.Equal(t, "could not detach from node", vol.ClaimHistory[0].Error)
+}
+
+func TestCSIVolume_ShouldRecordClaimFailure(t *testing.T) {
+	claim := &CSIVolumeClaim{
+		AllocationID: "a1",
+		NodeID:       "n1",
+		State:        CSIVolumeClaimStateNodeDetached,
+		UpdatedAt:    1,
+	}
+	vol := &CSIVolume{}
+	vol.AppendClaimEvent(NewCSIVolumeClaimEvent(claim, "could not detach from node"))
+	other := *claim
+	other.AllocationID = "a2"
+	vol.AppendClaimEvent(NewCSIVolumeClaimEvent(&other, "could not detach from node"))
+
+	later := *claim
+	later.UpdatedAt = 1 + int64(2*csiVolumeClaimFailureInterval)
+
+	// the same failure isn't recorded again, even behind another claim's
+	require.False(t, vol.ShouldRecordClaimFailure(NewCSIVolumeClaimEvent(&later, "could not detach from node")))
+
+	// another failure of the claim is only recorded after the interval
+	soon := *claim
+	soon.UpdatedAt = 2
+	require.False(t, vol.ShouldRecordClaimFailure(NewCSIVolumeClaimEvent(&soon, "timeout")))
+	require.True(t, vol.ShouldRecordClaimFailure(NewCSIVolumeClaimEvent(&later, "timeout")))
+
+	// failures to move to another state are recorded
+	controller := soon
+	controller.State = CSIVolumeClaimStateControllerDetached
+	require.True(t, vol.ShouldRecordClaimFailure(NewCSIVolumeClaimEvent(&controller, "timeout")))
+}
+
 func TestCSIPluginJobs(t *testing.T) {
 	plug := NewCSIPlugin("foo", 1000)
 	controller := &Job{
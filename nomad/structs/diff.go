@@ -61,7 +61,8 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 	diff := &JobDiff{Type: DiffTypeNone}
 	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
 	filter := []string{"ID", "Status", "StatusDescription", "Version", "Stable", "CreateIndex",
-		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID"}
+		"ModifyIndex", "JobModifyIndex", "Update", "SubmitTime", "NomadTokenID",
+		"MultiregionID"}
 
 	if j == nil && other == nil {
 		return diff, nil
@@ -137,6 +138,16 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.Objects = append(diff.Objects, mrDiff)
 	}
 
+	// Scoring diff
+	if sDiff := primitiveObjectDiff(j.Scoring, other.Scoring, nil, "Scoring", contextual); sDiff != nil {
+		diff.Objects = append(diff.Objects, sDiff)
+	}
+
+	// Preemption diff
+	if pDiff := preemptionPolicyDiff(j.Preemption, other.Preemption, contextual); pDiff != nil {
+		diff.Objects = append(diff.Objects, pDiff)
+	}
+
 	// Check to see if there is a diff. We don't use reflect because we are
 	// filtering quite a few fields that will change on each diff.
 	if diff.Type == DiffTypeNone {
@@ -306,8 +317,7 @@ func (tg *TaskGroup) Diff(other *TaskGroup, contextual bool) (*TaskGroupDiff, er
 	}
 
 	// Update diff
-	// COMPAT: Remove "Stagger" in 0.7.0.
-	if uDiff := primitiveObjectDiff(tg.Update, other.Update, []string{"Stagger"}, "Update", contextual); uDiff != nil {
+	if uDiff := updateStrategyDiff(tg.Update, other.Update, contextual); uDiff != nil {
 		diff.Objects = append(diff.Objects, uDiff)
 	}
 
@@ -1541,6 +1551,87 @@ func parameterizedJobDiff(old, new *ParameterizedJobConfig, contextual bool) *Ob
 	return diff
 }
 
+func preemptionPolicyDiff(old, new *PreemptionPolicy, contextual bool) *ObjectDiff {
+	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Preemption"}
+	var oldPrimitiveFlat, newPrimitiveFlat map[string]string
+
+	if reflect.DeepEqual(old, new) {
+		return nil
+	} else if old == nil {
+		old = &PreemptionPolicy{}
+		diff.Type = DiffTypeAdded
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	} else if new == nil {
+		new = &PreemptionPolicy{}
+		diff.Type = DiffTypeDeleted
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+	} else {
+		diff.Type = DiffTypeEdited
+		oldPrimitiveFlat = flatmap.Flatten(old, nil, true)
+		newPrimitiveFlat = flatmap.Flatten(new, nil, true)
+	}
+
+	// Diff the primitive fields.
+	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, contextual)
+
+	// Maintenance windows diff
+	windowsDiff := primitiveObjectSetDiff(
+		interfaceSlice(old.MaintenanceWindows),
+		interfaceSlice(new.MaintenanceWindows),
+		nil,
+		"MaintenanceWindow",
+		contextual)
+	if windowsDiff != nil {
+		diff.Objects = append(diff.Objects, windowsDiff...)
+	}
+
+	return diff
+}
+
+// updateStrategyDiff returns the diff of two update strategies, including the
+// diff of each canary step.
+func updateStrategyDiff(old, new *UpdateStrategy, contextual bool) *ObjectDiff {
+	// COMPAT: Remove "Stagger" in 0.7.0.
+	diff := primitiveObjectDiff(old, new, []string{"Stagger"}, "Update", contextual)
+
+	var oldSteps, newSteps []*CanaryStep
+	var oldAnalysis, newAnalysis *DeploymentAnalysis
+	if old != nil {
+		oldSteps = old.CanarySteps
+		oldAnalysis = old.Analysis
+	}
+	if new != nil {
+		newSteps = new.CanarySteps
+		newAnalysis = new.Analysis
+	}
+
+	var objDiffs []*ObjectDiff
+	if aDiff := primitiveObjectDiff(oldAnalysis, newAnalysis, nil, "Analysis", contextual); aDiff != nil {
+		objDiffs = append(objDiffs, aDiff)
+	}
+	for i := 0; i < len(oldSteps) || i < len(newSteps); i++ {
+		var oldStep, newStep *CanaryStep
+		if i < len(oldSteps) {
+			oldStep = oldSteps[i]
+		}
+		if i < len(newSteps) {
+			newStep = newSteps[i]
+		}
+		if sDiff := primitiveObjectDiff(oldStep, newStep, nil, "CanaryStep", contextual); sDiff != nil {
+			objDiffs = append(objDiffs, sDiff)
+		}
+	}
+	if len(objDiffs) == 0 {
+		return diff
+	}
+
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "Update"}
+	}
+	diff.Objects = append(diff.Objects, objDiffs...)
+	return diff
+}
+
 func multiregionDiff(old, new *Multiregion, contextual bool) *ObjectDiff {
 
 	diff := &ObjectDiff{Type: DiffTypeNone, Name: "Multiregion"}
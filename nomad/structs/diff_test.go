@@ -2236,6 +2236,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "0",
 								New:  "",
 							},
+							{
+								Type: DiffTypeDeleted,
+								Name: "RetainPrevious",
+								Old:  "0",
+								New:  "",
+							},
 						},
 					},
 				},
@@ -2298,6 +2304,12 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "",
 								New:  "0",
 							},
+							{
+								Type: DiffTypeAdded,
+								Name: "RetainPrevious",
+								Old:  "",
+								New:  "0",
+							},
 						},
 					},
 				},
@@ -2471,6 +2483,125 @@ func TestTaskGroupDiff(t *testing.T) {
 								Old:  "30000000000",
 								New:  "30000000000",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "RetainPrevious",
+								Old:  "0",
+								New:  "0",
+							},
+							{
+								Type: DiffTypeNone,
+								Name: "Strategy",
+								Old:  "",
+								New:  "",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Update strategy canary steps edited",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					CanarySteps: []*CanaryStep{
+						{Percent: 10, Pause: time.Minute},
+					},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					CanarySteps: []*CanaryStep{
+						{Percent: 20, Pause: time.Minute},
+						{Percent: 100},
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "CanaryStep",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Percent",
+										Old:  "10",
+										New:  "20",
+									},
+								},
+							},
+							{
+								Type: DiffTypeAdded,
+								Name: "CanaryStep",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeAdded,
+										Name: "Pause",
+										Old:  "",
+										New:  "0",
+									},
+									{
+										Type: DiffTypeAdded,
+										Name: "Percent",
+										Old:  "",
+										New:  "100",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TestCase: "Update strategy analysis edited",
+			Old: &TaskGroup{
+				Update: &UpdateStrategy{
+					Analysis: &DeploymentAnalysis{
+						Address:   "http://127.0.0.1:9090",
+						Query:     "errors",
+						Operator:  "<",
+						Threshold: 0.1,
+						Interval:  time.Second,
+					},
+				},
+			},
+			New: &TaskGroup{
+				Update: &UpdateStrategy{
+					Analysis: &DeploymentAnalysis{
+						Address:   "http://127.0.0.1:9090",
+						Query:     "errors",
+						Operator:  "<",
+						Threshold: 0.5,
+						Interval:  time.Second,
+					},
+				},
+			},
+			Expected: &TaskGroupDiff{
+				Type: DiffTypeEdited,
+				Objects: []*ObjectDiff{
+					{
+						Type: DiffTypeEdited,
+						Name: "Update",
+						Objects: []*ObjectDiff{
+							{
+								Type: DiffTypeEdited,
+								Name: "Analysis",
+								Fields: []*FieldDiff{
+									{
+										Type: DiffTypeEdited,
+										Name: "Threshold",
+										Old:  "0.1",
+										New:  "0.5",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -2493,6 +2624,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeAdded,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeAdded,
+								Name: "Checkpoint",
+								Old:  "",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeAdded,
 								Name: "Migrate",
@@ -2533,6 +2670,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeDeleted,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeDeleted,
+								Name: "Checkpoint",
+								Old:  "false",
+								New:  "",
+							},
 							{
 								Type: DiffTypeDeleted,
 								Name: "Migrate",
@@ -2627,6 +2770,12 @@ func TestTaskGroupDiff(t *testing.T) {
 						Type: DiffTypeEdited,
 						Name: "EphemeralDisk",
 						Fields: []*FieldDiff{
+							{
+								Type: DiffTypeNone,
+								Name: "Checkpoint",
+								Old:  "false",
+								New:  "false",
+							},
 							{
 								Type: DiffTypeEdited,
 								Name: "Migrate",
@@ -3492,6 +3641,12 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "",
 										New:  "bar",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Protocol",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeAdded,
 										Name: "To",
@@ -3548,6 +3703,12 @@ func TestTaskGroupDiff(t *testing.T) {
 										Old:  "foo",
 										New:  "",
 									},
+									{
+										Type: DiffTypeNone,
+										Name: "Protocol",
+										Old:  "",
+										New:  "",
+									},
 									{
 										Type: DiffTypeDeleted,
 										Name: "To",
@@ -5388,6 +5549,12 @@ func TestTaskDiff(t *testing.T) {
 								Old:  "boom_port",
 								New:  "boom_port",
 							},
+							{
+								Type: DiffTypeNone,
+								Name: "boom.Protocol",
+								Old:  "",
+								New:  "",
+							},
 							{
 								Type: DiffTypeNone,
 								Name: "boom.To",
@@ -0,0 +1,254 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// DrainBatchStatusRunning is the status of a batch that is draining its
+	// nodes.
+	DrainBatchStatusRunning = "running"
+
+	// DrainBatchStatusComplete is the status of a batch whose nodes have all
+	// been drained.
+	DrainBatchStatusComplete = "complete"
+
+	// DrainBatchStatusCancelled is the status of a batch that was cancelled
+	// by an operator. Nodes that were already draining keep draining.
+	DrainBatchStatusCancelled = "cancelled"
+)
+
+const (
+	// DrainBatchNodeStatusPending is the status of a node that is waiting for
+	// its turn to be drained.
+	DrainBatchNodeStatusPending = "pending"
+
+	// DrainBatchNodeStatusDraining is the status of a node that is draining,
+	// or whose drain is done but whose migrated allocations are not all
+	// healthy yet.
+	DrainBatchNodeStatusDraining = "draining"
+
+	// DrainBatchNodeStatusComplete is the status of a node that was drained
+	// and whose migrated allocations are healthy.
+	DrainBatchNodeStatusComplete = "complete"
+
+	// DrainBatchNodeStatusSkipped is the status of a node that was removed or
+	// whose drain was cancelled before it completed.
+	DrainBatchNodeStatusSkipped = "skipped"
+)
+
+const (
+	DrainBatchStatusDescriptionRunning   = "Batch is draining nodes"
+	DrainBatchStatusDescriptionComplete  = "Batch drained all nodes"
+	DrainBatchStatusDescriptionCancelled = "Batch was cancelled by user"
+)
+
+// DrainBatch drains the set of nodes matching a filter, draining at most a
+// limited number of them at the same time. The leader moves the batch
+// forward, and persists its progress so that a new leader continues it.
+type DrainBatch struct {
+	// ID is a UUID-format ID generated by the server
+	ID string
+
+	// Filter selects the nodes of the batch when it is created
+	Filter *DrainBatchFilter
+
+	// DrainSpec is the drain specification applied to each node
+	DrainSpec *DrainSpec
+
+	// MaxParallel is the maximum number of nodes of the batch draining at
+	// the same time. Zero means there is no limit across datacenters.
+	MaxParallel int
+
+	// MaxParallelPercent is the maximum percent of the nodes of a datacenter
+	// that the batch drains at the same time. At least one node of each
+	// datacenter is drained at a time. Zero means there is no limit per
+	// datacenter.
+	MaxParallelPercent int
+
+	// Nodes are the nodes of the batch in the order they are drained
+	Nodes []*DrainBatchNode
+
+	Status            string
+	StatusDescription string
+
+	CreateTime  int64
+	ModifyTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// DrainBatchFilter selects the nodes of a drain batch. A node matches if it
+// matches all of the set fields.
+type DrainBatchFilter struct {
+	// Datacenters matches nodes in any of the datacenters
+	Datacenters []string
+
+	// NodeClass matches nodes of the node class
+	NodeClass string
+
+	// Meta matches nodes with all of the metadata values
+	Meta map[string]string
+}
+
+// DrainBatchNode is the progress of a node of a drain batch
+type DrainBatchNode struct {
+	NodeID     string
+	Datacenter string
+	Status     string
+
+	// StartedAt is when the batch started draining the node, and CompletedAt
+	// when the node was complete or skipped
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Copy returns a deep copy of the batch.
+func (b *DrainBatch) Copy() *DrainBatch {
+	if b == nil {
+		return nil
+	}
+
+	nb := new(DrainBatch)
+	*nb = *b
+	nb.Filter = b.Filter.Copy()
+	nb.DrainSpec = b.DrainSpec.Copy()
+	if b.Nodes != nil {
+		nb.Nodes = make([]*DrainBatchNode, len(b.Nodes))
+		for i, n := range b.Nodes {
+			nn := *n
+			nb.Nodes[i] = &nn
+		}
+	}
+	return nb
+}
+
+// Validate validates the batch as submitted by a user, before the server
+// selects its nodes.
+func (b *DrainBatch) Validate() error {
+	var mErr multierror.Error
+
+	if b.Filter == nil || b.Filter.IsEmpty() {
+		mErr.Errors = append(mErr.Errors, errors.New("filter must select nodes by datacenter, node class or meta"))
+	}
+	if b.DrainSpec == nil {
+		mErr.Errors = append(mErr.Errors, errors.New("missing drain spec"))
+	} else if err := b.DrainSpec.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if b.MaxParallel < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max parallel must not be negative: %d", b.MaxParallel))
+	}
+	if b.MaxParallelPercent < 0 || b.MaxParallelPercent > 100 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("max parallel percent must be between 0 and 100: %d", b.MaxParallelPercent))
+	}
+	if b.MaxParallel == 0 && b.MaxParallelPercent == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("max parallel or max parallel percent must be set"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Terminal returns whether the batch is no longer draining nodes.
+func (b *DrainBatch) Terminal() bool {
+	return b.Status != DrainBatchStatusRunning
+}
+
+// Copy returns a deep copy of the filter.
+func (f *DrainBatchFilter) Copy() *DrainBatchFilter {
+	if f == nil {
+		return nil
+	}
+
+	nf := new(DrainBatchFilter)
+	*nf = *f
+	nf.Datacenters = helper.CopySliceString(f.Datacenters)
+	nf.Meta = helper.CopyMapStringString(f.Meta)
+	return nf
+}
+
+// IsEmpty returns whether the filter doesn't restrict the nodes it matches.
+func (f *DrainBatchFilter) IsEmpty() bool {
+	return len(f.Datacenters) == 0 && f.NodeClass == "" && len(f.Meta) == 0
+}
+
+// Matches returns whether the node matches the filter.
+func (f *DrainBatchFilter) Matches(node *Node) bool {
+	if len(f.Datacenters) != 0 && !helper.SliceStringContains(f.Datacenters, node.Datacenter) {
+		return false
+	}
+	if f.NodeClass != "" && f.NodeClass != node.NodeClass {
+		return false
+	}
+	for k, v := range f.Meta {
+		if actual, ok := node.Meta[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
+
+// DrainBatchCreateRequest is used to create a drain batch
+type DrainBatchCreateRequest struct {
+	Batch *DrainBatch
+	WriteRequest
+}
+
+type DrainBatchCreateResponse struct {
+	Batch *DrainBatch
+	WriteMeta
+}
+
+// DrainBatchUpsertRequest is the raft request used to write drain batches to
+// the state store. When the leader starts draining nodes of a batch, it sets
+// their drain strategy in the same request as the batch's progress, which
+// must then be the only batch of the request.
+type DrainBatchUpsertRequest struct {
+	Batches    []*DrainBatch
+	NodeDrains map[string]*DrainUpdate
+	NodeEvents map[string]*NodeEvent
+	UpdatedAt  int64
+
+	// EnforceIndex is used to only update the single batch of the request if
+	// its ModifyIndex in the state store is ModifyIndex. Updates computed
+	// from an earlier read of the batch set it, so that they don't overwrite
+	// a change made in the meantime, such as the batch being cancelled.
+	EnforceIndex bool
+	ModifyIndex  uint64
+
+	WriteRequest
+}
+
+// DrainBatchCancelRequest is used to cancel a drain batch
+type DrainBatchCancelRequest struct {
+	BatchID string
+	WriteRequest
+}
+
+type DrainBatchCancelResponse struct {
+	WriteMeta
+}
+
+type DrainBatchSpecificRequest struct {
+	BatchID string
+	QueryOptions
+}
+
+type DrainBatchResponse struct {
+	Batch *DrainBatch
+	QueryMeta
+}
+
+type DrainBatchListRequest struct {
+	QueryOptions
+}
+
+type DrainBatchListResponse struct {
+	Batches []*DrainBatch
+	QueryMeta
+}
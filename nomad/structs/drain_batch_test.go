@@ -0,0 +1,150 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainBatch_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *DrainBatch {
+		return &DrainBatch{
+			Filter:      &DrainBatchFilter{Datacenters: []string{"dc1"}},
+			DrainSpec:   &DrainSpec{Deadline: time.Hour},
+			MaxParallel: 1,
+		}
+	}
+
+	cases := []struct {
+		name  string
+		batch func(*DrainBatch)
+		err   string
+	}{
+		{
+			name:  "valid",
+			batch: func(*DrainBatch) {},
+		},
+		{
+			name:  "missing filter",
+			batch: func(b *DrainBatch) { b.Filter = nil },
+			err:   "filter must select nodes",
+		},
+		{
+			name:  "empty filter",
+			batch: func(b *DrainBatch) { b.Filter = &DrainBatchFilter{} },
+			err:   "filter must select nodes",
+		},
+		{
+			name:  "missing drain spec",
+			batch: func(b *DrainBatch) { b.DrainSpec = nil },
+			err:   "missing drain spec",
+		},
+		{
+			name:  "negative max parallel",
+			batch: func(b *DrainBatch) { b.MaxParallel = -1 },
+			err:   "max parallel must not be negative",
+		},
+		{
+			name:  "max parallel percent too high",
+			batch: func(b *DrainBatch) { b.MaxParallelPercent = 101 },
+			err:   "max parallel percent must be between 0 and 100",
+		},
+		{
+			name: "no limits",
+			batch: func(b *DrainBatch) {
+				b.MaxParallel = 0
+				b.MaxParallelPercent = 0
+			},
+			err: "max parallel or max parallel percent must be set",
+		},
+		{
+			name: "percent only",
+			batch: func(b *DrainBatch) {
+				b.MaxParallel = 0
+				b.MaxParallelPercent = 25
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := valid()
+			tc.batch(b)
+			err := b.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestDrainBatchFilter_Matches(t *testing.T) {
+	t.Parallel()
+
+	node := &Node{
+		Datacenter: "dc1",
+		NodeClass:  "large",
+		Meta:       map[string]string{"rack": "r1", "zone": "a"},
+	}
+
+	cases := []struct {
+		name    string
+		filter  *DrainBatchFilter
+		matches bool
+	}{
+		{"datacenter", &DrainBatchFilter{Datacenters: []string{"dc2", "dc1"}}, true},
+		{"other datacenter", &DrainBatchFilter{Datacenters: []string{"dc2"}}, false},
+		{"node class", &DrainBatchFilter{NodeClass: "large"}, true},
+		{"other node class", &DrainBatchFilter{NodeClass: "small"}, false},
+		{"meta", &DrainBatchFilter{Meta: map[string]string{"rack": "r1"}}, true},
+		{"other meta value", &DrainBatchFilter{Meta: map[string]string{"rack": "r2"}}, false},
+		{"missing meta", &DrainBatchFilter{Meta: map[string]string{"row": "1"}}, false},
+		{"all fields", &DrainBatchFilter{
+			Datacenters: []string{"dc1"},
+			NodeClass:   "large",
+			Meta:        map[string]string{"rack": "r1", "zone": "a"},
+		}, true},
+		{"one field mismatch", &DrainBatchFilter{
+			Datacenters: []string{"dc1"},
+			NodeClass:   "small",
+		}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.matches, tc.filter.Matches(node))
+		})
+	}
+}
+
+func TestDrainBatch_Copy(t *testing.T) {
+	t.Parallel()
+
+	b := &DrainBatch{
+		Filter: &DrainBatchFilter{
+			Datacenters: []string{"dc1"},
+			Meta:        map[string]string{"rack": "r1"},
+		},
+		DrainSpec: &DrainSpec{Deadline: time.Hour},
+		Nodes:     []*DrainBatchNode{{NodeID: "a", Status: DrainBatchNodeStatusPending}},
+	}
+
+	c := b.Copy()
+	require.Equal(t, b, c)
+
+	c.Filter.Datacenters[0] = "dc2"
+	c.Filter.Meta["rack"] = "r2"
+	c.DrainSpec.Deadline = time.Minute
+	c.Nodes[0].Status = DrainBatchNodeStatusDraining
+
+	require.Equal(t, "dc1", b.Filter.Datacenters[0])
+	require.Equal(t, "r1", b.Filter.Meta["rack"])
+	require.Equal(t, time.Hour, b.DrainSpec.Deadline)
+	require.Equal(t, DrainBatchNodeStatusPending, b.Nodes[0].Status)
+}
@@ -36,6 +36,7 @@ const (
 	errDeploymentTerminalNoResume    = "can't resume terminal deployment"
 	errDeploymentTerminalNoUnblock   = "can't unblock terminal deployment"
 	errDeploymentTerminalNoRun       = "can't run terminal deployment"
+	errDeploymentTerminalNoRollback  = "can't roll back terminal deployment"
 	errDeploymentTerminalNoSetHealth = "can't set health of allocations for a terminal deployment"
 	errDeploymentRunningNoUnblock    = "can't unblock running deployment"
 )
@@ -61,6 +62,7 @@ var (
 	ErrDeploymentTerminalNoResume    = errors.New(errDeploymentTerminalNoResume)
 	ErrDeploymentTerminalNoUnblock   = errors.New(errDeploymentTerminalNoUnblock)
 	ErrDeploymentTerminalNoRun       = errors.New(errDeploymentTerminalNoRun)
+	ErrDeploymentTerminalNoRollback  = errors.New(errDeploymentTerminalNoRollback)
 	ErrDeploymentTerminalNoSetHealth = errors.New(errDeploymentTerminalNoSetHealth)
 	ErrDeploymentRunningNoUnblock    = errors.New(errDeploymentRunningNoUnblock)
 
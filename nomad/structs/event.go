@@ -33,6 +33,7 @@ const (
 	TypeDeploymentUpdate              = "DeploymentStatusUpdate"
 	TypeDeploymentPromotion           = "DeploymentPromotion"
 	TypeDeploymentAllocHealth         = "DeploymentAllocHealth"
+	TypeDeploymentCanaryStep          = "DeploymentCanaryStep"
 	TypeAllocationCreated             = "AllocationCreated"
 	TypeAllocationUpdated             = "AllocationUpdated"
 	TypeAllocationUpdateDesiredStatus = "AllocationUpdateDesiredStatus"
@@ -274,6 +274,112 @@ func ScoreFitSpread(node *Node, util *ComparableResources) float64 {
 	return score
 }
 
+// ScoreFit computes a fit score in [0, 18] using the fit function and the
+// resource weights of the scoring config. With the default weights of cpu and
+// memory, best-fit and worst-fit score like ScoreFitBinPack and
+// ScoreFitSpread. Resources the node doesn't have are ignored so nodes
+// without devices aren't preferred or penalized for it.
+func ScoreFit(node *Node, util *ComparableResources, scoring *ScoringConfig) float64 {
+	used, ok := computeUsedFractions(node, util)
+	weights := [...]float64{scoring.CPUWeight, scoring.MemoryWeight, scoring.DiskWeight,
+		scoring.NetworkWeight, scoring.DevicesWeight}
+
+	maxWeight := 0.0
+	for i, w := range weights {
+		if ok[i] && w > maxWeight {
+			maxWeight = w
+		}
+	}
+
+	var total, sumWeights, dominant float64
+	for i, w := range weights {
+		if !ok[i] || w <= 0 {
+			continue
+		}
+		sumWeights += w
+		total += w * math.Pow(10, 1-used[i])
+		dominant = math.Max(dominant, used[i]*w/maxWeight)
+	}
+	if sumWeights == 0 {
+		return 0
+	}
+
+	// Scale the weighted total to the range of two equally weighted
+	// resources, [2, 20], so the weights are only relative
+	total = total / sumWeights * 2
+
+	var score float64
+	switch scoring.FitFunction {
+	case FitFunctionWorstFit:
+		score = total - 2
+	case FitFunctionDominantResource:
+		score = dominant * 18
+	default:
+		score = 20 - total
+	}
+
+	// Bound the score, just in case
+	if score > 18.0 {
+		score = 18.0
+	} else if score < 0 {
+		score = 0
+	}
+	return score
+}
+
+// computeUsedFractions returns the fraction of the node's available cpu,
+// memory, disk, network bandwidth and device instances that util uses, and
+// whether the node has any of each.
+func computeUsedFractions(node *Node, util *ComparableResources) (used [5]float64, ok [5]bool) {
+	res := node.ComparableResources()
+	if reserved := node.ComparableReservedResources(); reserved != nil {
+		res.Subtract(reserved)
+	}
+
+	var nodeMBits, usedMBits int
+	for _, n := range res.Flattened.Networks {
+		nodeMBits += n.MBits
+	}
+	for _, n := range util.Flattened.Networks {
+		usedMBits += n.MBits
+	}
+	for _, n := range util.Shared.Networks {
+		usedMBits += n.MBits
+	}
+
+	var nodeDevices, usedDevices int
+	if node.NodeResources != nil {
+		for _, d := range node.NodeResources.Devices {
+			nodeDevices += len(d.Instances)
+		}
+	}
+	for _, d := range util.Flattened.Devices {
+		usedDevices += len(d.DeviceIDs)
+	}
+
+	capacity := [...]float64{
+		float64(res.Flattened.Cpu.CpuShares),
+		float64(res.Flattened.Memory.MemoryMB),
+		float64(res.Shared.DiskMB),
+		float64(nodeMBits),
+		float64(nodeDevices),
+	}
+	usage := [...]float64{
+		float64(util.Flattened.Cpu.CpuShares),
+		float64(util.Flattened.Memory.MemoryMB),
+		float64(util.Shared.DiskMB),
+		float64(usedMBits),
+		float64(usedDevices),
+	}
+	for i := range capacity {
+		if capacity[i] > 0 {
+			used[i] = usage[i] / capacity[i]
+			ok[i] = true
+		}
+	}
+	return used, ok
+}
+
 func CopySliceConstraints(s []*Constraint) []*Constraint {
 	l := len(s)
 	if l == 0 {
@@ -109,7 +109,7 @@ func TestAllocsFit_PortsOvercommitted_Old(t *testing.T) {
 						Device:        "eth0",
 						IP:            "10.0.0.1",
 						MBits:         50,
-						ReservedPorts: []Port{{"main", 8000, 80, ""}},
+						ReservedPorts: []Port{{"main", 8000, 80, "", ""}},
 					},
 				},
 			},
@@ -161,7 +161,7 @@ func TestAllocsFit_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 80, 0, ""}},
+					ReservedPorts: []Port{{"main", 80, 0, "", ""}},
 				},
 			},
 		},
@@ -177,7 +177,7 @@ func TestAllocsFit_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 8000, 80, ""}},
+					ReservedPorts: []Port{{"main", 8000, 80, "", ""}},
 				},
 			},
 		},
@@ -224,7 +224,7 @@ func TestAllocsFit_TerminalAlloc_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 80, 0, ""}},
+					ReservedPorts: []Port{{"main", 80, 0, "", ""}},
 				},
 			},
 		},
@@ -240,7 +240,7 @@ func TestAllocsFit_TerminalAlloc_Old(t *testing.T) {
 					Device:        "eth0",
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"main", 8000, 0, ""}},
+					ReservedPorts: []Port{{"main", 8000, 0, "", ""}},
 				},
 			},
 		},
@@ -333,7 +333,7 @@ func TestAllocsFit(t *testing.T) {
 					{
 						Mode:          "host",
 						IP:            "10.0.0.1",
-						ReservedPorts: []Port{{"main", 8000, 0, ""}},
+						ReservedPorts: []Port{{"main", 8000, 0, "", ""}},
 					},
 				},
 				Ports: AllocatedPorts{
@@ -458,7 +458,7 @@ func TestAllocsFit_TerminalAlloc(t *testing.T) {
 							Device:        "eth0",
 							IP:            "10.0.0.1",
 							MBits:         50,
-							ReservedPorts: []Port{{"main", 8000, 80, ""}},
+							ReservedPorts: []Port{{"main", 8000, 80, "", ""}},
 						},
 					},
 				},
@@ -737,6 +737,113 @@ func TestScoreFitBinPack(t *testing.T) {
 	}
 }
 
+func TestScoreFit(t *testing.T) {
+	node := &Node{}
+	node.NodeResources = &NodeResources{
+		Cpu: NodeCpuResources{
+			CpuShares: 4096,
+		},
+		Memory: NodeMemoryResources{
+			MemoryMB: 8192,
+		},
+	}
+	node.ReservedResources = &NodeReservedResources{
+		Cpu: NodeReservedCpuResources{
+			CpuShares: 2048,
+		},
+		Memory: NodeReservedMemoryResources{
+			MemoryMB: 4096,
+		},
+	}
+
+	gpuNode := node.Copy()
+	gpuNode.NodeResources.Devices = []*NodeDeviceResource{
+		{
+			Vendor:    "nvidia",
+			Type:      "gpu",
+			Name:      "1080ti",
+			Instances: []*NodeDevice{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		node    *Node
+		scoring *ScoringConfig
+		util    AllocatedTaskResources
+		score   float64
+	}{
+		{
+			name:    "default weights match binpack",
+			node:    node,
+			scoring: &ScoringConfig{FitFunction: FitFunctionBestFit, CPUWeight: 1, MemoryWeight: 1},
+			util: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			score: 13.675,
+		},
+		{
+			name:    "default weights match spread",
+			node:    node,
+			scoring: &ScoringConfig{FitFunction: FitFunctionWorstFit, CPUWeight: 1, MemoryWeight: 1},
+			util: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			score: 4.325,
+		},
+		{
+			name:    "only memory is weighted",
+			node:    node,
+			scoring: &ScoringConfig{FitFunction: FitFunctionBestFit, MemoryWeight: 1},
+			util: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 4096},
+			},
+			score: 18,
+		},
+		{
+			name:    "dominant resource is memory",
+			node:    node,
+			scoring: &ScoringConfig{FitFunction: FitFunctionDominantResource, CPUWeight: 1, MemoryWeight: 1},
+			util: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 512},
+				Memory: AllocatedMemoryResources{MemoryMB: 3072},
+			},
+			score: 13.5,
+		},
+		{
+			name:    "missing devices are ignored",
+			node:    node,
+			scoring: &ScoringConfig{FitFunction: FitFunctionBestFit, CPUWeight: 1, MemoryWeight: 1, DevicesWeight: 1},
+			util: AllocatedTaskResources{
+				Cpu:    AllocatedCpuResources{CpuShares: 1024},
+				Memory: AllocatedMemoryResources{MemoryMB: 2048},
+			},
+			score: 13.675,
+		},
+		{
+			name:    "only devices are weighted",
+			node:    gpuNode,
+			scoring: &ScoringConfig{FitFunction: FitFunctionBestFit, DevicesWeight: 1},
+			util: AllocatedTaskResources{
+				Devices: []*AllocatedDeviceResource{
+					{Vendor: "nvidia", Type: "gpu", Name: "1080ti", DeviceIDs: []string{"1", "2"}},
+				},
+			},
+			score: 13.675,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			util := &ComparableResources{Flattened: c.util}
+			require.InDelta(t, c.score, ScoreFit(c.node, util, c.scoring), 0.001)
+		})
+	}
+}
+
 func TestACLPolicyListHash(t *testing.T) {
 	h1 := ACLPolicyListHash(nil)
 	assert.NotEqual(t, "", h1)
@@ -0,0 +1,222 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+// HostVolumePluginMkdir is the name of the builtin plugin for dynamic host
+// volumes, which creates a directory in the client's host volumes directory.
+const HostVolumePluginMkdir = "mkdir"
+
+// HostVolumePluginAttribute is the node attribute with the version of a host
+// volume plugin, which clients set for each plugin they fingerprint.
+func HostVolumePluginAttribute(pluginID string) string {
+	return "plugins.host_volume." + pluginID + ".version"
+}
+
+// HostVolumeState is the state of a dynamic host volume.
+type HostVolumeState string
+
+const (
+	// HostVolumeStatePending is the state of a volume that the client has
+	// not created yet.
+	HostVolumeStatePending HostVolumeState = "pending"
+
+	// HostVolumeStateReady is the state of a volume that has been created on
+	// its node and can be claimed by allocations.
+	HostVolumeStateReady HostVolumeState = "ready"
+)
+
+// validHostVolumeName is the same restriction as the names of static host
+// volumes in the client configuration, so that dynamic host volumes can be
+// used as the source of a job's volume.
+var validHostVolumeName = regexp.MustCompile("^[a-zA-Z0-9-_.]{1,128}$")
+
+// HostVolume is a volume on a client host that was created by Nomad, as
+// opposed to the static host volumes in the client configuration. The client
+// fingerprints ready volumes into the node's HostVolumes so that jobs can
+// claim them by name.
+type HostVolume struct {
+	// ID is a UUID-format ID generated by the server
+	ID string
+
+	// Name is the name of the volume on the node, which jobs use as the source
+	// of their host volumes. Names are unique per node.
+	Name string
+
+	// Namespace is the namespace of the volume. Only jobs in this namespace
+	// can claim the volume.
+	Namespace string
+
+	// PluginID is the name of the plugin that creates and deletes the volume
+	// on the client. The "mkdir" plugin is builtin, and other plugins are
+	// executables in the client's host volume plugin directory.
+	PluginID string
+
+	// NodeID is the node the volume is created on. If the NodeID isn't set
+	// when the volume is created, the server picks a node that satisfies the
+	// Constraints and has the plugin.
+	NodeID string
+
+	// Constraints restrict the nodes the volume can be created on when no
+	// NodeID is set.
+	Constraints []*Constraint
+
+	// RequestedCapacityMinBytes and RequestedCapacityMaxBytes are passed to
+	// the plugin, and CapacityBytes is the capacity the plugin reports.
+	RequestedCapacityMinBytes int64
+	RequestedCapacityMaxBytes int64
+	CapacityBytes             int64
+
+	// Parameters are passed to the plugin
+	Parameters map[string]string
+
+	// UID, GID and Mode set the ownership and permissions of the volume's
+	// directory once it's created. A UID or GID of -1 leaves it unchanged
+	// and an empty Mode keeps the mode set by the plugin.
+	UID  int
+	GID  int
+	Mode string
+
+	// HostPath is the path of the volume on the node, set by the plugin
+	HostPath string
+
+	State HostVolumeState
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NewHostVolume returns a volume with the ownership fields unset.
+func NewHostVolume() *HostVolume {
+	return &HostVolume{
+		UID: -1,
+		GID: -1,
+	}
+}
+
+// Copy returns a deep copy of the volume.
+func (v *HostVolume) Copy() *HostVolume {
+	if v == nil {
+		return nil
+	}
+
+	nv := new(HostVolume)
+	*nv = *v
+	nv.Constraints = CopySliceConstraints(v.Constraints)
+	nv.Parameters = helper.CopyMapStringString(v.Parameters)
+	return nv
+}
+
+// Validate validates the volume as submitted by a user, before the server
+// fills in the fields that are set when the volume is created.
+func (v *HostVolume) Validate() error {
+	var mErr multierror.Error
+
+	if !validHostVolumeName.MatchString(v.Name) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid name %q", v.Name))
+	}
+	if v.PluginID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing plugin ID"))
+	}
+	if v.RequestedCapacityMinBytes < 0 || v.RequestedCapacityMaxBytes < 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("capacity must not be negative"))
+	}
+	if v.RequestedCapacityMaxBytes > 0 &&
+		v.RequestedCapacityMaxBytes < v.RequestedCapacityMinBytes {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf(
+			"capacity_max (%d) must be larger than capacity_min (%d)",
+			v.RequestedCapacityMaxBytes, v.RequestedCapacityMinBytes))
+	}
+	if v.UID < -1 || v.GID < -1 {
+		mErr.Errors = append(mErr.Errors, errors.New("uid and gid must not be less than -1"))
+	}
+	if v.Mode != "" {
+		if _, err := v.FileMode(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+	}
+	for _, c := range v.Constraints {
+		if err := c.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid constraint: %v", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// FileMode parses the octal Mode of the volume.
+func (v *HostVolume) FileMode() (uint32, error) {
+	mode, err := strconv.ParseUint(v.Mode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("invalid mode %q: must be octal permission bits", v.Mode)
+	}
+	return uint32(mode), nil
+}
+
+// ClientConfig returns the host volume the client fingerprints into the node
+// for a ready volume.
+func (v *HostVolume) ClientConfig() *ClientHostVolumeConfig {
+	return &ClientHostVolumeConfig{
+		Name: v.Name,
+		Path: v.HostPath,
+		ID:   v.ID,
+	}
+}
+
+// HostVolumeCreateRequest is used to create a dynamic host volume
+type HostVolumeCreateRequest struct {
+	Volume *HostVolume
+	WriteRequest
+}
+
+type HostVolumeCreateResponse struct {
+	Volume *HostVolume
+	WriteMeta
+}
+
+// HostVolumeUpsertRequest is the raft request used to write dynamic host
+// volumes to the state store
+type HostVolumeUpsertRequest struct {
+	Volumes []*HostVolume
+	WriteRequest
+}
+
+// HostVolumeDeleteRequest is used to delete a dynamic host volume from its
+// node and from the state store
+type HostVolumeDeleteRequest struct {
+	VolumeID string
+	WriteRequest
+}
+
+type HostVolumeDeleteResponse struct {
+	WriteMeta
+}
+
+type HostVolumeGetRequest struct {
+	ID string
+	QueryOptions
+}
+
+type HostVolumeGetResponse struct {
+	Volume *HostVolume
+	QueryMeta
+}
+
+// HostVolumeListRequest lists the dynamic host volumes of a namespace, or of
+// a single node if the NodeID is set
+type HostVolumeListRequest struct {
+	NodeID string
+	QueryOptions
+}
+
+type HostVolumeListResponse struct {
+	Volumes []*HostVolume
+	QueryMeta
+}
@@ -39,6 +39,10 @@ type NetworkIndex struct {
 	AvailBandwidth map[string]int                  // Bandwidth by device
 	UsedPorts      map[string]Bitmap               // Ports by IP
 	UsedBandwidth  map[string]int                  // Bandwidth by device
+
+	// UsedProtocolPorts tracks the ports in UsedPorts by IP and protocol so
+	// the same port can be used for different protocols
+	UsedProtocolPorts map[string]map[string]Bitmap
 }
 
 // NewNetworkIndex is used to construct a new network index
@@ -48,31 +52,82 @@ func NewNetworkIndex() *NetworkIndex {
 		AvailBandwidth: make(map[string]int),
 		UsedPorts:      make(map[string]Bitmap),
 		UsedBandwidth:  make(map[string]int),
+
+		UsedProtocolPorts: make(map[string]map[string]Bitmap),
 	}
 }
 
+// allPortProtocols are the protocols ports reserved by the node are reserved
+// for
+var allPortProtocols = []string{PortProtocolTCP, PortProtocolUDP, PortProtocolSCTP}
+
 func (idx *NetworkIndex) getUsedPortsFor(ip string) Bitmap {
 	used := idx.UsedPorts[ip]
 	if used == nil {
-		// Try to get a bitmap from the pool, else create
-		raw := bitmapPool.Get()
-		if raw != nil {
-			used = raw.(Bitmap)
-			used.Clear()
-		} else {
-			used, _ = NewBitmap(maxValidPort)
-		}
+		used = getPooledBitmap()
 		idx.UsedPorts[ip] = used
 	}
 	return used
 }
 
+// getPooledBitmap tries to get a bitmap from the pool, else creates one
+func getPooledBitmap() Bitmap {
+	raw := bitmapPool.Get()
+	if raw != nil {
+		used := raw.(Bitmap)
+		used.Clear()
+		return used
+	}
+	used, _ := NewBitmap(maxValidPort)
+	return used
+}
+
+// portUsed returns true if the port is used on the IP for any of the
+// protocols.
+func (idx *NetworkIndex) portUsed(ip string, port int, protocols []string) bool {
+	for _, proto := range protocols {
+		used := idx.UsedProtocolPorts[ip][proto]
+		if used != nil && used.Check(uint(port)) {
+			return true
+		}
+	}
+	return false
+}
+
+// usePort marks the port as used on the IP for the protocols. It returns
+// true if the port was already used for one of them.
+func (idx *NetworkIndex) usePort(ip string, port int, protocols []string) (collide bool) {
+	collide = idx.portUsed(ip, port, protocols)
+
+	idx.getUsedPortsFor(ip).Set(uint(port))
+
+	byProto := idx.UsedProtocolPorts[ip]
+	if byProto == nil {
+		byProto = make(map[string]Bitmap, len(protocols))
+		idx.UsedProtocolPorts[ip] = byProto
+	}
+	for _, proto := range protocols {
+		used := byProto[proto]
+		if used == nil {
+			used = getPooledBitmap()
+			byProto[proto] = used
+		}
+		used.Set(uint(port))
+	}
+	return
+}
+
 // Release is called when the network index is no longer needed
 // to attempt to re-use some of the memory it has allocated
 func (idx *NetworkIndex) Release() {
 	for _, b := range idx.UsedPorts {
 		bitmapPool.Put(b)
 	}
+	for _, byProto := range idx.UsedProtocolPorts {
+		for _, b := range byProto {
+			bitmapPool.Put(b)
+		}
+	}
 }
 
 // Overcommitted checks if the network is overcommitted
@@ -195,7 +250,7 @@ func (idx *NetworkIndex) AddAllocs(allocs []*Allocation) (collide bool) {
 // if there is a port collision
 func (idx *NetworkIndex) AddReserved(n *NetworkResource) (collide bool) {
 	// Add the port usage
-	used := idx.getUsedPortsFor(n.IP)
+	idx.getUsedPortsFor(n.IP)
 
 	for _, ports := range [][]Port{n.ReservedPorts, n.DynamicPorts} {
 		for _, port := range ports {
@@ -203,10 +258,8 @@ func (idx *NetworkIndex) AddReserved(n *NetworkResource) (collide bool) {
 			if port.Value < 0 || port.Value >= maxValidPort {
 				return true
 			}
-			if used.Check(uint(port.Value)) {
+			if idx.usePort(n.IP, port.Value, port.Protocols()) {
 				collide = true
-			} else {
-				used.Set(uint(port.Value))
 			}
 		}
 	}
@@ -218,14 +271,11 @@ func (idx *NetworkIndex) AddReserved(n *NetworkResource) (collide bool) {
 
 func (idx *NetworkIndex) AddReservedPorts(ports AllocatedPorts) (collide bool) {
 	for _, port := range ports {
-		used := idx.getUsedPortsFor(port.HostIP)
 		if port.Value < 0 || port.Value >= maxValidPort {
 			return true
 		}
-		if used.Check(uint(port.Value)) {
+		if idx.usePort(port.HostIP, port.Value, port.Protocols()) {
 			collide = true
-		} else {
-			used.Set(uint(port.Value))
 		}
 	}
 
@@ -247,16 +297,14 @@ func (idx *NetworkIndex) AddReservedPortRange(ports string) (collide bool) {
 		idx.getUsedPortsFor(n.IP)
 	}
 
-	for _, used := range idx.UsedPorts {
+	for ip := range idx.UsedPorts {
 		for _, port := range resPorts {
 			// Guard against invalid port
 			if port >= maxValidPort {
 				return true
 			}
-			if used.Check(uint(port)) {
+			if idx.usePort(ip, int(port), allPortProtocols) {
 				collide = true
-			} else {
-				used.Set(uint(port))
 			}
 		}
 	}
@@ -273,16 +321,14 @@ func (idx *NetworkIndex) AddReservedPortsForIP(ports string, ip string) (collide
 		return
 	}
 
-	used := idx.getUsedPortsFor(ip)
+	idx.getUsedPortsFor(ip)
 	for _, port := range resPorts {
 		// Guard against invalid port
 		if port >= maxValidPort {
 			return true
 		}
-		if used.Check(uint(port)) {
+		if idx.usePort(ip, int(port), allPortProtocols) {
 			collide = true
-		} else {
-			used.Set(uint(port))
 		}
 	}
 
@@ -328,22 +374,22 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 		var allocPort *AllocatedPortMapping
 		var addrErr error
 		for _, addr := range idx.AvailAddresses[port.HostNetwork] {
-			used := idx.getUsedPortsFor(addr.Address)
 			// Guard against invalid port
 			if port.Value < 0 || port.Value >= maxValidPort {
 				return nil, fmt.Errorf("invalid port %d (out of range)", port.Value)
 			}
 
-			// Check if in use
-			if used != nil && used.Check(uint(port.Value)) {
+			// Check if in use for any of the port's protocols
+			if idx.portUsed(addr.Address, port.Value, port.Protocols()) {
 				return nil, fmt.Errorf("reserved port collision %s=%d", port.Label, port.Value)
 			}
 
 			allocPort = &AllocatedPortMapping{
-				Label:  port.Label,
-				Value:  port.Value,
-				To:     port.To,
-				HostIP: addr.Address,
+				Label:    port.Label,
+				Value:    port.Value,
+				To:       port.To,
+				HostIP:   addr.Address,
+				Protocol: port.Protocol,
 			}
 			break
 		}
@@ -378,10 +424,11 @@ func (idx *NetworkIndex) AssignPorts(ask *NetworkResource) (AllocatedPorts, erro
 			}
 
 			allocPort = &AllocatedPortMapping{
-				Label:  port.Label,
-				Value:  dynPorts[0],
-				To:     port.To,
-				HostIP: addr.Address,
+				Label:    port.Label,
+				Value:    dynPorts[0],
+				To:       port.To,
+				HostIP:   addr.Address,
+				Protocol: port.Protocol,
 			}
 			if allocPort.To == -1 {
 				allocPort.To = allocPort.Value
@@ -428,8 +475,8 @@ func (idx *NetworkIndex) AssignNetwork(ask *NetworkResource) (out *NetworkResour
 				return
 			}
 
-			// Check if in use
-			if used != nil && used.Check(uint(port.Value)) {
+			// Check if in use for any of the port's protocols
+			if idx.portUsed(ipStr, port.Value, port.Protocols()) {
 				err = fmt.Errorf("reserved port collision %s=%d", port.Label, port.Value)
 				return
 			}
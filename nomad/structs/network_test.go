@@ -18,7 +18,7 @@ func TestNetworkIndex_Overcommitted(t *testing.T) {
 		Device:        "eth0",
 		IP:            "192.168.0.100",
 		MBits:         505,
-		ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+		ReservedPorts: []Port{{"one", 8000, 0, "", ""}, {"two", 9000, 0, "", ""}},
 	}
 	collide := idx.AddReserved(reserved)
 	if collide {
@@ -99,7 +99,7 @@ func TestNetworkIndex_AddAllocs(t *testing.T) {
 								Device:        "eth0",
 								IP:            "192.168.0.100",
 								MBits:         20,
-								ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+								ReservedPorts: []Port{{"one", 8000, 0, "", ""}, {"two", 9000, 0, "", ""}},
 							},
 						},
 					},
@@ -115,7 +115,7 @@ func TestNetworkIndex_AddAllocs(t *testing.T) {
 								Device:        "eth0",
 								IP:            "192.168.0.100",
 								MBits:         50,
-								ReservedPorts: []Port{{"one", 10000, 0, ""}},
+								ReservedPorts: []Port{{"one", 10000, 0, "", ""}},
 							},
 						},
 					},
@@ -149,7 +149,7 @@ func TestNetworkIndex_AddReserved(t *testing.T) {
 		Device:        "eth0",
 		IP:            "192.168.0.100",
 		MBits:         20,
-		ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+		ReservedPorts: []Port{{"one", 8000, 0, "", ""}, {"two", 9000, 0, "", ""}},
 	}
 	collide := idx.AddReserved(reserved)
 	if collide {
@@ -173,6 +173,75 @@ func TestNetworkIndex_AddReserved(t *testing.T) {
 	}
 }
 
+func TestNetworkIndex_AddReservedPorts_Protocol(t *testing.T) {
+	require := require.New(t)
+	idx := NewNetworkIndex()
+
+	// The same port can be used for different protocols
+	require.False(idx.AddReservedPorts(AllocatedPorts{
+		{Label: "dns-udp", Value: 53, HostIP: "192.168.0.100", Protocol: PortProtocolUDP},
+	}))
+	require.False(idx.AddReservedPorts(AllocatedPorts{
+		{Label: "dns-tcp", Value: 53, HostIP: "192.168.0.100", Protocol: PortProtocolTCP},
+	}))
+	require.True(idx.UsedPorts["192.168.0.100"].Check(53))
+
+	// A port without a protocol uses both tcp and udp
+	require.True(idx.AddReservedPorts(AllocatedPorts{
+		{Label: "dns", Value: 53, HostIP: "192.168.0.100"},
+	}))
+	require.False(idx.AddReservedPorts(AllocatedPorts{
+		{Label: "diameter", Value: 53, HostIP: "192.168.0.100", Protocol: PortProtocolSCTP},
+	}))
+
+	// Ports reserved by the node are reserved for all protocols
+	require.True(idx.AddReservedPortsForIP("53", "192.168.0.100"))
+	require.False(idx.AddReservedPortsForIP("22", "192.168.0.100"))
+	require.True(idx.AddReservedPorts(AllocatedPorts{
+		{Label: "ssh", Value: 22, HostIP: "192.168.0.100", Protocol: PortProtocolSCTP},
+	}))
+}
+
+func TestNetworkIndex_AssignPorts_Protocol(t *testing.T) {
+	require := require.New(t)
+	idx := NewNetworkIndex()
+	n := &Node{
+		NodeResources: &NodeResources{
+			NodeNetworks: []*NodeNetworkResource{
+				{
+					Mode:   "host",
+					Device: "eth0",
+					Addresses: []NodeNetworkAddress{
+						{
+							Alias:   "default",
+							Address: "192.168.0.100",
+							Family:  NodeNetworkAF_IPv4,
+						},
+					},
+				},
+			},
+		},
+	}
+	require.False(idx.SetNode(n))
+	require.False(idx.AddReservedPorts(AllocatedPorts{
+		{Label: "dns", Value: 53, HostIP: "192.168.0.100", Protocol: PortProtocolUDP},
+	}))
+
+	// TCP 53 is still free
+	offer, err := idx.AssignPorts(&NetworkResource{
+		ReservedPorts: []Port{{Label: "dns", Value: 53, HostNetwork: "default", Protocol: PortProtocolTCP}},
+	})
+	require.NoError(err)
+	require.Len(offer, 1)
+	require.Equal(PortProtocolTCP, offer[0].Protocol)
+
+	// A port without a protocol also needs UDP 53
+	_, err = idx.AssignPorts(&NetworkResource{
+		ReservedPorts: []Port{{Label: "dns", Value: 53, HostNetwork: "default"}},
+	})
+	require.EqualError(err, "reserved port collision dns=53")
+}
+
 // XXX Reserving ports doesn't work when yielding from a CIDR block. This is
 // okay for now since we do not actually fingerprint CIDR blocks.
 func TestNetworkIndex_yieldIP(t *testing.T) {
@@ -227,7 +296,7 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         20,
-							ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+							ReservedPorts: []Port{{"one", 8000, 0, "", ""}, {"two", 9000, 0, "", ""}},
 						},
 					},
 				},
@@ -241,7 +310,7 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         50,
-							ReservedPorts: []Port{{"main", 10000, 0, ""}},
+							ReservedPorts: []Port{{"main", 10000, 0, "", ""}},
 						},
 					},
 				},
@@ -252,19 +321,19 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 
 	// Ask for a reserved port
 	ask := &NetworkResource{
-		ReservedPorts: []Port{{"main", 8000, 0, ""}},
+		ReservedPorts: []Port{{"main", 8000, 0, "", ""}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	require.NoError(t, err)
 	require.NotNil(t, offer)
 	require.Equal(t, "192.168.0.101", offer.IP)
-	rp := Port{"main", 8000, 0, ""}
+	rp := Port{"main", 8000, 0, "", ""}
 	require.Len(t, offer.ReservedPorts, 1)
 	require.Exactly(t, rp, offer.ReservedPorts[0])
 
 	// Ask for dynamic ports
 	ask = &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, -1, ""}},
+		DynamicPorts: []Port{{"http", 0, 80, "", ""}, {"https", 0, 443, "", ""}, {"admin", 0, -1, "", ""}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	require.NoError(t, err)
@@ -282,15 +351,15 @@ func TestNetworkIndex_AssignNetwork(t *testing.T) {
 
 	// Ask for reserved + dynamic ports
 	ask = &NetworkResource{
-		ReservedPorts: []Port{{"main", 2345, 0, ""}},
-		DynamicPorts:  []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+		ReservedPorts: []Port{{"main", 2345, 0, "", ""}},
+		DynamicPorts:  []Port{{"http", 0, 80, "", ""}, {"https", 0, 443, "", ""}, {"admin", 0, 8080, "", ""}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	require.NoError(t, err)
 	require.NotNil(t, offer)
 	require.Equal(t, "192.168.0.100", offer.IP)
 
-	rp = Port{"main", 2345, 0, ""}
+	rp = Port{"main", 2345, 0, "", ""}
 	require.Len(t, offer.ReservedPorts, 1)
 	require.Exactly(t, rp, offer.ReservedPorts[0])
 
@@ -331,7 +400,7 @@ func TestNetworkIndex_AssignNetwork_Dynamic_Contention(t *testing.T) {
 
 	// Ask for dynamic ports
 	ask := &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}},
+		DynamicPorts: []Port{{"http", 0, 80, "", ""}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	if err != nil {
@@ -369,7 +438,7 @@ func TestNetworkIndex_SetNode_Old(t *testing.T) {
 				{
 					Device:        "eth0",
 					IP:            "192.168.0.100",
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 					MBits:         1,
 				},
 			},
@@ -406,7 +475,7 @@ func TestNetworkIndex_AddAllocs_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         20,
-							ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+							ReservedPorts: []Port{{"one", 8000, 0, "", ""}, {"two", 9000, 0, "", ""}},
 						},
 					},
 				},
@@ -420,7 +489,7 @@ func TestNetworkIndex_AddAllocs_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         50,
-							ReservedPorts: []Port{{"one", 10000, 0, ""}},
+							ReservedPorts: []Port{{"one", 10000, 0, "", ""}},
 						},
 					},
 				},
@@ -464,7 +533,7 @@ func TestNetworkIndex_yieldIP_Old(t *testing.T) {
 				{
 					Device:        "eth0",
 					IP:            "192.168.0.100",
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 					MBits:         1,
 				},
 			},
@@ -503,7 +572,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 				{
 					Device:        "eth0",
 					IP:            "192.168.0.100",
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 					MBits:         1,
 				},
 			},
@@ -520,7 +589,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         20,
-							ReservedPorts: []Port{{"one", 8000, 0, ""}, {"two", 9000, 0, ""}},
+							ReservedPorts: []Port{{"one", 8000, 0, "", ""}, {"two", 9000, 0, "", ""}},
 						},
 					},
 				},
@@ -534,7 +603,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 							Device:        "eth0",
 							IP:            "192.168.0.100",
 							MBits:         50,
-							ReservedPorts: []Port{{"main", 10000, 0, ""}},
+							ReservedPorts: []Port{{"main", 10000, 0, "", ""}},
 						},
 					},
 				},
@@ -545,7 +614,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 
 	// Ask for a reserved port
 	ask := &NetworkResource{
-		ReservedPorts: []Port{{"main", 8000, 0, ""}},
+		ReservedPorts: []Port{{"main", 8000, 0, "", ""}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	if err != nil {
@@ -557,14 +626,14 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 	if offer.IP != "192.168.0.101" {
 		t.Fatalf("bad: %#v", offer)
 	}
-	rp := Port{"main", 8000, 0, ""}
+	rp := Port{"main", 8000, 0, "", ""}
 	if len(offer.ReservedPorts) != 1 || offer.ReservedPorts[0] != rp {
 		t.Fatalf("bad: %#v", offer)
 	}
 
 	// Ask for dynamic ports
 	ask = &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+		DynamicPorts: []Port{{"http", 0, 80, "", ""}, {"https", 0, 443, "", ""}, {"admin", 0, 8080, "", ""}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	if err != nil {
@@ -587,8 +656,8 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 
 	// Ask for reserved + dynamic ports
 	ask = &NetworkResource{
-		ReservedPorts: []Port{{"main", 2345, 0, ""}},
-		DynamicPorts:  []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+		ReservedPorts: []Port{{"main", 2345, 0, "", ""}},
+		DynamicPorts:  []Port{{"http", 0, 80, "", ""}, {"https", 0, 443, "", ""}, {"admin", 0, 8080, "", ""}},
 	}
 	offer, err = idx.AssignNetwork(ask)
 	if err != nil {
@@ -601,7 +670,7 @@ func TestNetworkIndex_AssignNetwork_Old(t *testing.T) {
 		t.Fatalf("bad: %#v", offer)
 	}
 
-	rp = Port{"main", 2345, 0, ""}
+	rp = Port{"main", 2345, 0, "", ""}
 	if len(offer.ReservedPorts) != 1 || offer.ReservedPorts[0] != rp {
 		t.Fatalf("bad: %#v", offer)
 	}
@@ -654,7 +723,7 @@ func TestNetworkIndex_AssignNetwork_Dynamic_Contention_Old(t *testing.T) {
 
 	// Ask for dynamic ports
 	ask := &NetworkResource{
-		DynamicPorts: []Port{{"http", 0, 80, ""}},
+		DynamicPorts: []Port{{"http", 0, 80, "", ""}},
 	}
 	offer, err := idx.AssignNetwork(ask)
 	if err != nil {
@@ -62,6 +62,56 @@ func (t *CSITopology) Equal(o *CSITopology) bool {
 	return helper.CompareMapStringString(t.Segments, o.Segments)
 }
 
+// MatchFound returns true if the topology is within any of the topologies in
+// o. A topology is within another if it has all of the other's segments, so a
+// node in {"region": "R1", "zone": "Z1"} can reach a volume in {"zone": "Z1"}.
+func (t *CSITopology) MatchFound(o []*CSITopology) bool {
+	if t == nil {
+		return false
+	}
+
+	for _, other := range o {
+		if other == nil {
+			continue
+		}
+		match := true
+		for k, v := range other.Segments {
+			if seg, ok := t.Segments[k]; !ok || seg != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// CSITopologyRequest are the topologies submitted to the storage provider
+// when a volume is created. The storage provider must create the volume so
+// that it's accessible from at least one of the Required topologies, and
+// should create it in the first of the Preferred topologies it can.
+type CSITopologyRequest struct {
+	Required  []*CSITopology
+	Preferred []*CSITopology
+}
+
+func (tr *CSITopologyRequest) Copy() *CSITopologyRequest {
+	if tr == nil {
+		return nil
+	}
+
+	out := &CSITopologyRequest{}
+	for _, t := range tr.Required {
+		out.Required = append(out.Required, t.Copy())
+	}
+	for _, t := range tr.Preferred {
+		out.Preferred = append(out.Preferred, t.Copy())
+	}
+	return out
+}
+
 // CSINodeInfo is the fingerprinted data from a CSI Plugin that is specific to
 // the Node API.
 type CSINodeInfo struct {
@@ -0,0 +1,298 @@
+package structs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// DefaultNodeMaintenanceWindowLeadTime is how long before a maintenance
+	// window starts the scheduler avoids placing service allocations on its
+	// nodes, if the window doesn't set a lead time.
+	DefaultNodeMaintenanceWindowLeadTime = time.Hour
+)
+
+const (
+	// NodeMaintenanceWindowStatusPending is the status of a window that hasn't
+	// started yet.
+	NodeMaintenanceWindowStatusPending = "pending"
+
+	// NodeMaintenanceWindowStatusActive is the status of a window whose nodes
+	// are drained and kept ineligible.
+	NodeMaintenanceWindowStatusActive = "active"
+
+	// NodeMaintenanceWindowStatusComplete is the status of a window that ended.
+	NodeMaintenanceWindowStatusComplete = "complete"
+
+	// NodeMaintenanceWindowStatusCancelled is the status of a window that was
+	// cancelled by an operator.
+	NodeMaintenanceWindowStatusCancelled = "cancelled"
+)
+
+const (
+	// NodeMaintenanceWindowNodeStatusActive is the status of a node that is
+	// draining or drained, and kept ineligible until the window ends.
+	NodeMaintenanceWindowNodeStatusActive = "active"
+
+	// NodeMaintenanceWindowNodeStatusRestored is the status of a node that was
+	// healthy when the window ended, and whose eligibility was restored.
+	NodeMaintenanceWindowNodeStatusRestored = "restored"
+
+	// NodeMaintenanceWindowNodeStatusUnhealthy is the status of a node that
+	// wasn't ready when the window ended, and that was left ineligible.
+	NodeMaintenanceWindowNodeStatusUnhealthy = "unhealthy"
+
+	// NodeMaintenanceWindowNodeStatusSkipped is the status of a node that was
+	// already draining when the window started, that was removed during the
+	// window, or that another window or an operator drained during the
+	// window.
+	NodeMaintenanceWindowNodeStatusSkipped = "skipped"
+)
+
+// NodeMaintenanceWindowDrainMetaKey is the key of the drain metadata of a node
+// that records the maintenance window that drained the node.
+const NodeMaintenanceWindowDrainMetaKey = "maintenance_window_id"
+
+const (
+	NodeMaintenanceWindowStatusDescriptionPending   = "Window is waiting for its start time"
+	NodeMaintenanceWindowStatusDescriptionActive    = "Window is active"
+	NodeMaintenanceWindowStatusDescriptionComplete  = "Window ended"
+	NodeMaintenanceWindowStatusDescriptionMissed    = "Window ended before it could start"
+	NodeMaintenanceWindowStatusDescriptionCancelled = "Window was cancelled by user"
+)
+
+// NodeMaintenanceWindow schedules the maintenance of a set of nodes. When the
+// window starts, the leader drains its nodes and keeps them ineligible until
+// the window ends, when the eligibility of the nodes that are healthy again
+// is restored. The window's progress is written to raft so that a new leader
+// continues it.
+type NodeMaintenanceWindow struct {
+	// ID is a UUID-format ID generated by the server
+	ID string
+
+	// NodeIDs selects nodes by ID, and Filter selects the nodes matching it.
+	// The window applies to the nodes selected by either.
+	NodeIDs []string
+	Filter  *DrainBatchFilter
+
+	// StartTime is when the leader starts draining the nodes, and Duration
+	// how long they are kept ineligible
+	StartTime time.Time
+	Duration  time.Duration
+
+	// LeadTime is how long before StartTime the scheduler avoids placing
+	// service allocations on the nodes of the window
+	LeadTime time.Duration
+
+	// DrainSpec is the drain specification applied to each node
+	DrainSpec *DrainSpec
+
+	// Nodes are the nodes selected when the window started
+	Nodes []*NodeMaintenanceWindowNode
+
+	Status            string
+	StatusDescription string
+
+	CreateTime  int64
+	ModifyTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// NodeMaintenanceWindowNode is the state of a node of a maintenance window
+type NodeMaintenanceWindowNode struct {
+	NodeID string
+	Status string
+
+	// StartedAt is when the window started the node's maintenance, and
+	// CompletedAt when the node was restored, left unhealthy or skipped
+	StartedAt   time.Time
+	CompletedAt time.Time
+}
+
+// Copy returns a deep copy of the window.
+func (w *NodeMaintenanceWindow) Copy() *NodeMaintenanceWindow {
+	if w == nil {
+		return nil
+	}
+
+	nw := new(NodeMaintenanceWindow)
+	*nw = *w
+	nw.NodeIDs = helper.CopySliceString(w.NodeIDs)
+	nw.Filter = w.Filter.Copy()
+	nw.DrainSpec = w.DrainSpec.Copy()
+	if w.Nodes != nil {
+		nw.Nodes = make([]*NodeMaintenanceWindowNode, len(w.Nodes))
+		for i, n := range w.Nodes {
+			nn := *n
+			nw.Nodes[i] = &nn
+		}
+	}
+	return nw
+}
+
+// Validate validates the window as submitted by a user.
+func (w *NodeMaintenanceWindow) Validate() error {
+	var mErr multierror.Error
+
+	if len(w.NodeIDs) == 0 && (w.Filter == nil || w.Filter.IsEmpty()) {
+		mErr.Errors = append(mErr.Errors, errors.New("window must select nodes by ID, datacenter, node class or meta"))
+	}
+	for _, id := range w.NodeIDs {
+		if len(id) != 36 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid node ID %q", id))
+		}
+	}
+	if w.StartTime.IsZero() {
+		mErr.Errors = append(mErr.Errors, errors.New("missing start time"))
+	}
+	if w.Duration <= 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("duration must be positive: %v", w.Duration))
+	}
+	if w.LeadTime < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("lead time must not be negative: %v", w.LeadTime))
+	}
+	if w.DrainSpec == nil {
+		mErr.Errors = append(mErr.Errors, errors.New("missing drain spec"))
+	} else if err := w.DrainSpec.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Canonicalize sets the defaults of the window.
+func (w *NodeMaintenanceWindow) Canonicalize() {
+	if w.LeadTime == 0 {
+		w.LeadTime = DefaultNodeMaintenanceWindowLeadTime
+	}
+}
+
+// EndTime returns when the window ends.
+func (w *NodeMaintenanceWindow) EndTime() time.Time {
+	return w.StartTime.Add(w.Duration)
+}
+
+// Terminal returns whether the window ended or was cancelled.
+func (w *NodeMaintenanceWindow) Terminal() bool {
+	return w.Status == NodeMaintenanceWindowStatusComplete || w.Status == NodeMaintenanceWindowStatusCancelled
+}
+
+// Selects returns whether the window selects the node by ID or filter.
+func (w *NodeMaintenanceWindow) Selects(node *Node) bool {
+	if helper.SliceStringContains(w.NodeIDs, node.ID) {
+		return true
+	}
+	return w.Filter != nil && !w.Filter.IsEmpty() && w.Filter.Matches(node)
+}
+
+// Includes returns whether the node is selected by the window or was selected
+// when the window started.
+func (w *NodeMaintenanceWindow) Includes(node *Node) bool {
+	return w.Selects(node) || w.LookupNode(node.ID) != nil
+}
+
+// LookupNode returns the node of the window with the given ID, or nil if the
+// node wasn't selected when the window started.
+func (w *NodeMaintenanceWindow) LookupNode(nodeID string) *NodeMaintenanceWindowNode {
+	for _, n := range w.Nodes {
+		if n.NodeID == nodeID {
+			return n
+		}
+	}
+	return nil
+}
+
+// NodeMaintenanceWindowID returns the ID of the maintenance window that set
+// the node's last drain, or the empty string if it wasn't set by a window.
+func NodeMaintenanceWindowID(node *Node) string {
+	if node.LastDrain == nil {
+		return ""
+	}
+	return node.LastDrain.Meta[NodeMaintenanceWindowDrainMetaKey]
+}
+
+// TakenOver returns whether the node's last drain wasn't set by this window,
+// because another maintenance window, a drain batch or an operator drained the
+// node after this window did.
+func (w *NodeMaintenanceWindow) TakenOver(node *Node) bool {
+	return NodeMaintenanceWindowID(node) != w.ID
+}
+
+// Imminent returns whether the window is active, or starts within its lead
+// time, at the given time.
+func (w *NodeMaintenanceWindow) Imminent(now time.Time) bool {
+	if w.Terminal() || !now.Before(w.EndTime()) {
+		return false
+	}
+	return !now.Before(w.StartTime.Add(-w.LeadTime))
+}
+
+// NodeMaintenanceWindowCreateRequest is used to create a maintenance window
+type NodeMaintenanceWindowCreateRequest struct {
+	Window *NodeMaintenanceWindow
+	WriteRequest
+}
+
+type NodeMaintenanceWindowCreateResponse struct {
+	Window *NodeMaintenanceWindow
+	WriteMeta
+}
+
+// NodeMaintenanceWindowUpsertRequest is the raft request used to write
+// maintenance windows to the state store. When the leader starts or ends a
+// window, it updates the drains and the eligibility of the window's nodes in
+// the same request as the window, which must then be the only window of the
+// request.
+type NodeMaintenanceWindowUpsertRequest struct {
+	Windows    []*NodeMaintenanceWindow
+	NodeDrains map[string]*DrainUpdate
+	NodeEvents map[string]*NodeEvent
+	UpdatedAt  int64
+
+	// EnforceIndex is used to only update the single window of the request
+	// if its ModifyIndex in the state store is ModifyIndex. Updates computed
+	// from an earlier read of the window set it, so that they don't
+	// overwrite a change made in the meantime, such as the window being
+	// cancelled.
+	EnforceIndex bool
+	ModifyIndex  uint64
+
+	WriteRequest
+}
+
+// NodeMaintenanceWindowCancelRequest is used to cancel a maintenance window
+type NodeMaintenanceWindowCancelRequest struct {
+	WindowID string
+	WriteRequest
+}
+
+type NodeMaintenanceWindowCancelResponse struct {
+	WriteMeta
+}
+
+type NodeMaintenanceWindowSpecificRequest struct {
+	WindowID string
+	QueryOptions
+}
+
+type NodeMaintenanceWindowResponse struct {
+	Window *NodeMaintenanceWindow
+	QueryMeta
+}
+
+// NodeMaintenanceWindowListRequest is used to list the maintenance windows,
+// or the windows that include a node if NodeID is set
+type NodeMaintenanceWindowListRequest struct {
+	NodeID string
+	QueryOptions
+}
+
+type NodeMaintenanceWindowListResponse struct {
+	Windows []*NodeMaintenanceWindow
+	QueryMeta
+}
@@ -0,0 +1,154 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeMaintenanceWindow_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := func() *NodeMaintenanceWindow {
+		return &NodeMaintenanceWindow{
+			NodeIDs:   []string{uuid.Generate()},
+			StartTime: time.Now(),
+			Duration:  time.Hour,
+			DrainSpec: &DrainSpec{Deadline: time.Hour},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		window func(*NodeMaintenanceWindow)
+		err    string
+	}{
+		{
+			name:   "valid",
+			window: func(*NodeMaintenanceWindow) {},
+		},
+		{
+			name: "filter only",
+			window: func(w *NodeMaintenanceWindow) {
+				w.NodeIDs = nil
+				w.Filter = &DrainBatchFilter{NodeClass: "large"}
+			},
+		},
+		{
+			name: "no nodes",
+			window: func(w *NodeMaintenanceWindow) {
+				w.NodeIDs = nil
+				w.Filter = &DrainBatchFilter{}
+			},
+			err: "window must select nodes",
+		},
+		{
+			name:   "invalid node ID",
+			window: func(w *NodeMaintenanceWindow) { w.NodeIDs = []string{"1234"} },
+			err:    "invalid node ID",
+		},
+		{
+			name:   "missing start time",
+			window: func(w *NodeMaintenanceWindow) { w.StartTime = time.Time{} },
+			err:    "missing start time",
+		},
+		{
+			name:   "zero duration",
+			window: func(w *NodeMaintenanceWindow) { w.Duration = 0 },
+			err:    "duration must be positive",
+		},
+		{
+			name:   "negative lead time",
+			window: func(w *NodeMaintenanceWindow) { w.LeadTime = -time.Minute },
+			err:    "lead time must not be negative",
+		},
+		{
+			name:   "missing drain spec",
+			window: func(w *NodeMaintenanceWindow) { w.DrainSpec = nil },
+			err:    "missing drain spec",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := valid()
+			tc.window(w)
+			err := w.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestNodeMaintenanceWindow_Imminent(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	w := &NodeMaintenanceWindow{
+		StartTime: start,
+		Duration:  time.Hour,
+		LeadTime:  10 * time.Minute,
+		Status:    NodeMaintenanceWindowStatusPending,
+	}
+
+	require.False(t, w.Imminent(start.Add(-11*time.Minute)))
+	require.True(t, w.Imminent(start.Add(-10*time.Minute)))
+	require.True(t, w.Imminent(start.Add(30*time.Minute)))
+	require.False(t, w.Imminent(start.Add(time.Hour)))
+
+	w.Status = NodeMaintenanceWindowStatusCancelled
+	require.False(t, w.Imminent(start))
+}
+
+func TestNodeMaintenanceWindow_Includes(t *testing.T) {
+	t.Parallel()
+
+	byID := &Node{ID: uuid.Generate(), Datacenter: "dc2"}
+	byFilter := &Node{ID: uuid.Generate(), Datacenter: "dc1"}
+	started := &Node{ID: uuid.Generate(), Datacenter: "dc2"}
+	other := &Node{ID: uuid.Generate(), Datacenter: "dc2"}
+
+	w := &NodeMaintenanceWindow{
+		NodeIDs: []string{byID.ID},
+		Filter:  &DrainBatchFilter{Datacenters: []string{"dc1"}},
+		Nodes: []*NodeMaintenanceWindowNode{
+			{NodeID: started.ID, Status: NodeMaintenanceWindowNodeStatusActive},
+		},
+	}
+
+	require.True(t, w.Selects(byID))
+	require.True(t, w.Selects(byFilter))
+	require.False(t, w.Selects(started))
+	require.True(t, w.Includes(started))
+	require.False(t, w.Includes(other))
+}
+
+func TestNodeMaintenanceWindow_Copy(t *testing.T) {
+	t.Parallel()
+
+	w := &NodeMaintenanceWindow{
+		NodeIDs:   []string{"a"},
+		Filter:    &DrainBatchFilter{Datacenters: []string{"dc1"}},
+		DrainSpec: &DrainSpec{Deadline: time.Hour},
+		Nodes:     []*NodeMaintenanceWindowNode{{NodeID: "a", Status: NodeMaintenanceWindowNodeStatusActive}},
+	}
+
+	c := w.Copy()
+	require.Equal(t, w, c)
+
+	c.NodeIDs[0] = "b"
+	c.Filter.Datacenters[0] = "dc2"
+	c.DrainSpec.Deadline = time.Minute
+	c.Nodes[0].Status = NodeMaintenanceWindowNodeStatusRestored
+
+	require.Equal(t, "a", w.NodeIDs[0])
+	require.Equal(t, "dc1", w.Filter.Datacenters[0])
+	require.Equal(t, time.Hour, w.DrainSpec.Deadline)
+	require.Equal(t, NodeMaintenanceWindowNodeStatusActive, w.Nodes[0].Status)
+}
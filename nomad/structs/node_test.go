@@ -60,3 +60,27 @@ func TestDriverInfoEquals(t *testing.T) {
 		require.Equal(testCase.expected, first.HealthCheckEquals(second), testCase.errorMsg)
 	}
 }
+
+func TestCSITopology_MatchFound(t *testing.T) {
+	node := &CSITopology{Segments: map[string]string{"region": "R1", "zone": "Z1"}}
+
+	require.True(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "Z2"}},
+		{Segments: map[string]string{"zone": "Z1"}},
+	}))
+	require.True(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"region": "R1", "zone": "Z1"}},
+	}))
+	require.False(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"region": "R1", "zone": "Z1", "rack": "A"}},
+	}))
+	require.False(t, node.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "Z2"}},
+	}))
+	require.False(t, node.MatchFound(nil))
+
+	var missing *CSITopology
+	require.False(t, missing.MatchFound([]*CSITopology{
+		{Segments: map[string]string{"zone": "Z1"}},
+	}))
+}
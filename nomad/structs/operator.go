@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/raft"
 )
 
@@ -140,6 +141,87 @@ const (
 	SchedulerAlgorithmSpread SchedulerAlgorithm = "spread"
 )
 
+// FitFunction is an enum string that encapsulates the valid options for how
+// the scheduler turns the utilization of a node into a fit score.
+type FitFunction string
+
+const (
+	// FitFunctionBestFit prefers the nodes with the least free weighted
+	// resources left after placement. It is the default of the binpack
+	// algorithm.
+	FitFunctionBestFit FitFunction = "best-fit"
+
+	// FitFunctionWorstFit prefers the nodes with the most free weighted
+	// resources left after placement. It is the default of the spread
+	// algorithm.
+	FitFunctionWorstFit FitFunction = "worst-fit"
+
+	// FitFunctionDominantResource prefers the nodes whose most utilized
+	// weighted resource, the dominant resource, is the most utilized after
+	// placement. This packs nodes along whichever dimension is scarcest.
+	FitFunctionDominantResource FitFunction = "dominant-resource"
+)
+
+// ScoringConfig configures how the scheduler scores the fit of an allocation
+// on a node. Each weight is the relative importance of the utilization of a
+// resource. If no weight is set, only cpu and memory are scored, equally.
+type ScoringConfig struct {
+	// FitFunction selects how utilization is scored. Defaults to the
+	// function of the scheduler algorithm.
+	FitFunction FitFunction `hcl:"fit_function"`
+
+	CPUWeight     float64 `hcl:"cpu_weight"`
+	MemoryWeight  float64 `hcl:"memory_weight"`
+	DiskWeight    float64 `hcl:"disk_weight"`
+	NetworkWeight float64 `hcl:"network_weight"`
+	DevicesWeight float64 `hcl:"devices_weight"`
+}
+
+func (s *ScoringConfig) Copy() *ScoringConfig {
+	if s == nil {
+		return nil
+	}
+	ns := new(ScoringConfig)
+	*ns = *s
+	return ns
+}
+
+// HasWeights returns whether any weight is set.
+func (s *ScoringConfig) HasWeights() bool {
+	return s != nil && (s.CPUWeight != 0 || s.MemoryWeight != 0 || s.DiskWeight != 0 ||
+		s.NetworkWeight != 0 || s.DevicesWeight != 0)
+}
+
+func (s *ScoringConfig) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	switch s.FitFunction {
+	case "", FitFunctionBestFit, FitFunctionWorstFit, FitFunctionDominantResource:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid fit function: %v", s.FitFunction))
+	}
+
+	weights := []struct {
+		name   string
+		weight float64
+	}{
+		{"cpu", s.CPUWeight},
+		{"memory", s.MemoryWeight},
+		{"disk", s.DiskWeight},
+		{"network", s.NetworkWeight},
+		{"devices", s.DevicesWeight},
+	}
+	for _, w := range weights {
+		if w.weight < 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("%s weight must not be negative", w.name))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
 // SchedulerConfiguration is the config for controlling scheduler behavior
 type SchedulerConfiguration struct {
 	// SchedulerAlgorithm lets you select between available scheduling algorithms.
@@ -151,6 +233,10 @@ type SchedulerConfiguration struct {
 
 	MemoryOversubscriptionEnabled bool `hcl:"memory_oversubscription_enabled"`
 
+	// Scoring configures how the fit of allocations on nodes is scored.
+	// Jobs may override it.
+	Scoring *ScoringConfig `hcl:"scoring"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -164,6 +250,50 @@ func (s *SchedulerConfiguration) EffectiveSchedulerAlgorithm() SchedulerAlgorith
 	return s.SchedulerAlgorithm
 }
 
+// EffectiveScoringConfig returns the scoring config used to place the job, or
+// nil if neither the cluster nor the job configure scoring. The fit function
+// and the weights of the job take precedence over those of the cluster, and
+// the fit function defaults to that of the scheduler algorithm.
+func (s *SchedulerConfiguration) EffectiveScoringConfig(job *Job) *ScoringConfig {
+	var cluster, override *ScoringConfig
+	if s != nil {
+		cluster = s.Scoring
+	}
+	if job != nil {
+		override = job.Scoring
+	}
+	if cluster == nil && override == nil {
+		return nil
+	}
+
+	scoring := cluster.Copy()
+	if scoring == nil {
+		scoring = new(ScoringConfig)
+	}
+	if override != nil {
+		if override.FitFunction != "" {
+			scoring.FitFunction = override.FitFunction
+		}
+		if override.HasWeights() {
+			fitFunction := scoring.FitFunction
+			scoring = override.Copy()
+			scoring.FitFunction = fitFunction
+		}
+	}
+
+	if scoring.FitFunction == "" {
+		scoring.FitFunction = FitFunctionBestFit
+		if s.EffectiveSchedulerAlgorithm() == SchedulerAlgorithmSpread {
+			scoring.FitFunction = FitFunctionWorstFit
+		}
+	}
+	if !scoring.HasWeights() {
+		scoring.CPUWeight = 1
+		scoring.MemoryWeight = 1
+	}
+	return scoring
+}
+
 func (s *SchedulerConfiguration) Canonicalize() {
 	if s != nil && s.SchedulerAlgorithm == "" {
 		s.SchedulerAlgorithm = SchedulerAlgorithmBinpack
@@ -181,6 +311,10 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
+	if err := s.Scoring.Validate(); err != nil {
+		return fmt.Errorf("invalid scoring config: %v", err)
+	}
+
 	return nil
 }
 
@@ -192,6 +326,63 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// EvalQueueResponse is the response object that wraps EvalQueueStats
+type EvalQueueResponse struct {
+	// Queue describes the evaluations queued on the leader
+	Queue *EvalQueueStats
+
+	QueryMeta
+}
+
+// EvalQueueStats describes the evaluations queued in the eval broker and the
+// blocked evaluations tracker of the leader.
+type EvalQueueStats struct {
+	// NamespaceFairness is whether evaluations of equal priority are dequeued
+	// fairly across namespaces.
+	NamespaceFairness bool
+
+	// TotalReady is the number of evaluations ready to be dequeued.
+	TotalReady int
+
+	// TotalUnacked is the number of evaluations dequeued but not acknowledged
+	// yet.
+	TotalUnacked int
+
+	// TotalPending is the number of evaluations waiting for an earlier
+	// evaluation of the same job to complete.
+	TotalPending int
+
+	// TotalWaiting is the number of evaluations delayed until a later time.
+	TotalWaiting int
+
+	// TotalBlocked is the number of evaluations blocked until capacity is
+	// available.
+	TotalBlocked int
+
+	// ByScheduler describes the queued evaluations by scheduler type.
+	ByScheduler map[string]*EvalQueueSchedulerStats
+
+	// ByNamespace describes the queued evaluations by namespace.
+	ByNamespace map[string]*EvalQueueNamespaceStats
+}
+
+// EvalQueueSchedulerStats describes the queued evaluations of a scheduler
+// type.
+type EvalQueueSchedulerStats struct {
+	Ready   int
+	Unacked int
+}
+
+// EvalQueueNamespaceStats describes the queued evaluations of a namespace.
+type EvalQueueNamespaceStats struct {
+	// Weight is the weight of the namespace when dequeuing fairly.
+	Weight int
+
+	Ready   int
+	Unacked int
+	Blocked int
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -0,0 +1,91 @@
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerConfiguration_EffectiveScoringConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   *SchedulerConfiguration
+		job      *Job
+		expected *ScoringConfig
+	}{
+		{
+			name:     "not configured",
+			config:   &SchedulerConfiguration{},
+			job:      &Job{},
+			expected: nil,
+		},
+		{
+			name: "cluster defaults",
+			config: &SchedulerConfiguration{
+				SchedulerAlgorithm: SchedulerAlgorithmSpread,
+				Scoring:            &ScoringConfig{},
+			},
+			job: &Job{},
+			expected: &ScoringConfig{
+				FitFunction:  FitFunctionWorstFit,
+				CPUWeight:    1,
+				MemoryWeight: 1,
+			},
+		},
+		{
+			name:   "job only",
+			config: nil,
+			job: &Job{
+				Scoring: &ScoringConfig{MemoryWeight: 2, DevicesWeight: 1},
+			},
+			expected: &ScoringConfig{
+				FitFunction:   FitFunctionBestFit,
+				MemoryWeight:  2,
+				DevicesWeight: 1,
+			},
+		},
+		{
+			name: "job overrides fit function",
+			config: &SchedulerConfiguration{
+				Scoring: &ScoringConfig{FitFunction: FitFunctionWorstFit, CPUWeight: 3, MemoryWeight: 1},
+			},
+			job: &Job{
+				Scoring: &ScoringConfig{FitFunction: FitFunctionDominantResource},
+			},
+			expected: &ScoringConfig{
+				FitFunction:  FitFunctionDominantResource,
+				CPUWeight:    3,
+				MemoryWeight: 1,
+			},
+		},
+		{
+			name: "job overrides weights",
+			config: &SchedulerConfiguration{
+				Scoring: &ScoringConfig{FitFunction: FitFunctionWorstFit, CPUWeight: 3, MemoryWeight: 1},
+			},
+			job: &Job{
+				Scoring: &ScoringConfig{DiskWeight: 1},
+			},
+			expected: &ScoringConfig{
+				FitFunction: FitFunctionWorstFit,
+				DiskWeight:  1,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, c.config.EffectiveScoringConfig(c.job))
+		})
+	}
+}
+
+func TestScoringConfig_Validate(t *testing.T) {
+	require.NoError(t, (*ScoringConfig)(nil).Validate())
+	require.NoError(t, (&ScoringConfig{FitFunction: FitFunctionDominantResource, CPUWeight: 1}).Validate())
+
+	err := (&ScoringConfig{FitFunction: "first-fit", NetworkWeight: -1}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid fit function")
+	require.Contains(t, err.Error(), "network weight must not be negative")
+}
@@ -16,6 +16,7 @@ import (
 	"hash/crc32"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -23,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/hashicorp/nomad/lib/cpuset"
@@ -105,6 +107,12 @@ const (
 	OneTimeTokenUpsertRequestType                MessageType = 44
 	OneTimeTokenDeleteRequestType                MessageType = 45
 	OneTimeTokenExpireRequestType                MessageType = 46
+	HostVolumeUpsertRequestType                  MessageType = 47
+	HostVolumeDeleteRequestType                  MessageType = 48
+	DeploymentCanaryStepRequestType              MessageType = 49
+	DrainBatchUpsertRequestType                  MessageType = 50
+	NodeMaintenanceWindowUpsertRequestType       MessageType = 51
+	CSIVolumeExpandRequestType                   MessageType = 52
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -1130,6 +1138,42 @@ type ApplyDeploymentPromoteRequest struct {
 
 	// An optional evaluation to create after promoting the canaries
 	Eval *Evaluation
+
+	// PromotedAt is the time the leader promoted the deployment. Progress
+	// deadlines and the retention of previous allocations start from it.
+	PromotedAt time.Time
+}
+
+// DeploymentCanaryStepUpdate moves a task group of a deployment through its
+// canary steps
+type DeploymentCanaryStepUpdate struct {
+	TaskGroup string
+
+	// CanaryStep is the index of the canary step the group is at
+	CanaryStep int
+
+	// DesiredCanaries is the number of canaries of the canary step
+	DesiredCanaries int
+
+	// NextCanaryStepAt is the time the group can leave the canary step, or
+	// zero if the canaries of the step aren't healthy yet
+	NextCanaryStepAt time.Time
+
+	// RequireProgressBy is the progress deadline of the group reset when it
+	// advances to the next canary step, or zero to leave it unchanged
+	RequireProgressBy time.Time
+}
+
+// ApplyDeploymentCanaryStepRequest is used to apply canary step updates via
+// Raft
+type ApplyDeploymentCanaryStepRequest struct {
+	DeploymentID string
+	Updates      []*DeploymentCanaryStepUpdate
+
+	// An optional evaluation to create to place the canaries of the next step
+	Eval *Evaluation
+
+	WriteRequest
 }
 
 // DeploymentPauseRequest is used to pause a deployment
@@ -1179,6 +1223,13 @@ type DeploymentFailRequest struct {
 	WriteRequest
 }
 
+// DeploymentRollbackRequest is used to roll back a blue/green deployment to
+// the previous allocations it retains
+type DeploymentRollbackRequest struct {
+	DeploymentID string
+	WriteRequest
+}
+
 // ScalingPolicySpecificRequest is used when we just need to specify a target scaling policy
 type ScalingPolicySpecificRequest struct {
 	ID string
@@ -1701,6 +1752,125 @@ type DrainSpec struct {
 	// IgnoreSystemJobs allows systems jobs to remain on the node even though it
 	// has been marked for draining.
 	IgnoreSystemJobs bool
+
+	// DeadlineOverrides overrides Deadline for the allocations of specific
+	// jobs, or of the jobs within priority bands.
+	DeadlineOverrides []*DrainDeadline
+
+	// SkipJobs are the jobs whose allocations stay on the node. The drain
+	// completes without migrating them.
+	SkipJobs []NamespacedID
+}
+
+// DrainDeadline overrides the deadline of a drain for the allocations of a job,
+// or of the jobs whose priority is within a band. An override of a job takes
+// precedence over priority bands, and the first matching band is used.
+type DrainDeadline struct {
+	// Namespace and JobID select the allocations of the job
+	Namespace string
+	JobID     string
+
+	// MinPriority and MaxPriority select the allocations of the jobs whose
+	// priority is within the inclusive band, if JobID isn't set
+	MinPriority int
+	MaxPriority int
+
+	// Deadline is the duration after which the selected allocations are told
+	// to stop. As for the drain's deadline, a negative deadline stops them
+	// immediately and a zero deadline never forces them to stop.
+	Deadline time.Duration
+
+	// ForceDeadline is the deadline time after which the selected allocations
+	// are told to stop. It is set by the server.
+	ForceDeadline time.Time
+}
+
+// Copy returns a copy of the override.
+func (d *DrainDeadline) Copy() *DrainDeadline {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(DrainDeadline)
+	*nd = *d
+	return nd
+}
+
+// Matches returns whether the override applies to the job.
+func (d *DrainDeadline) Matches(job *Job) bool {
+	if d.JobID != "" {
+		return d.Namespace == job.Namespace && d.JobID == job.ID
+	}
+	return job.Priority >= d.MinPriority && job.Priority <= d.MaxPriority
+}
+
+// Copy returns a deep copy of the drain specification.
+func (d *DrainSpec) Copy() *DrainSpec {
+	if d == nil {
+		return nil
+	}
+
+	nd := new(DrainSpec)
+	*nd = *d
+	if d.DeadlineOverrides != nil {
+		nd.DeadlineOverrides = make([]*DrainDeadline, len(d.DeadlineOverrides))
+		for i, o := range d.DeadlineOverrides {
+			nd.DeadlineOverrides[i] = o.Copy()
+		}
+	}
+	if d.SkipJobs != nil {
+		nd.SkipJobs = make([]NamespacedID, len(d.SkipJobs))
+		copy(nd.SkipJobs, d.SkipJobs)
+	}
+	return nd
+}
+
+// Validate validates the drain specification.
+func (d *DrainSpec) Validate() error {
+	var mErr multierror.Error
+	for i, o := range d.DeadlineOverrides {
+		if o == nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("deadline override %d is empty", i+1))
+			continue
+		}
+		if o.JobID != "" {
+			if o.MinPriority != 0 || o.MaxPriority != 0 {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("deadline override %d must select a job or a priority band, not both", i+1))
+			}
+			if err := validateDrainJobNamespace(o.Namespace); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("deadline override %d %v", i+1, err))
+			}
+			continue
+		}
+		if o.Namespace != "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("deadline override %d must not set a namespace without a job", i+1))
+		}
+		if o.MinPriority < JobMinPriority || o.MaxPriority > JobMaxPriority || o.MinPriority > o.MaxPriority {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("deadline override %d has an invalid priority band [%d, %d]: must be within [%d, %d]",
+				i+1, o.MinPriority, o.MaxPriority, JobMinPriority, JobMaxPriority))
+		}
+	}
+	for _, job := range d.SkipJobs {
+		if job.ID == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("skipped job ID must not be empty"))
+		}
+		if err := validateDrainJobNamespace(job.Namespace); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("skipped job %q %v", job.ID, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// validateDrainJobNamespace validates the namespace of a job selected by a
+// drain specification.
+func validateDrainJobNamespace(namespace string) error {
+	switch namespace {
+	case "":
+		return errors.New("must set the namespace of the job")
+	case AllNamespacesSentinel:
+		return errors.New("must not select the jobs of all namespaces")
+	}
+	return nil
 }
 
 // DrainStrategy describes a Node's drain behavior.
@@ -1723,9 +1893,23 @@ func (d *DrainStrategy) Copy() *DrainStrategy {
 
 	nd := new(DrainStrategy)
 	*nd = *d
+	nd.DrainSpec = *d.DrainSpec.Copy()
 	return nd
 }
 
+// SetForceDeadlines sets the deadline times of the drain and of its deadline
+// overrides, for a drain updated at the given time.
+func (d *DrainStrategy) SetForceDeadlines(now time.Time) {
+	if d.Deadline > 0 {
+		d.ForceDeadline = now.Add(d.Deadline)
+	}
+	for _, o := range d.DeadlineOverrides {
+		if o.Deadline > 0 {
+			o.ForceDeadline = now.Add(o.Deadline)
+		}
+	}
+}
+
 // DeadlineTime returns a boolean whether the drain strategy allows an infinite
 // duration or otherwise the deadline time. The force drain is captured by the
 // deadline time being in the past.
@@ -1748,6 +1932,61 @@ func (d *DrainStrategy) DeadlineTime() (infinite bool, deadline time.Time) {
 	}
 }
 
+// JobDeadlineTime returns whether the drain allows an infinite duration for
+// the allocations of the job or otherwise their deadline time, honoring the
+// deadline overrides.
+func (d *DrainStrategy) JobDeadlineTime(job *Job) (infinite bool, deadline time.Time) {
+	if d == nil || job == nil {
+		return d.DeadlineTime()
+	}
+
+	override := d.deadlineOverride(job)
+	if override == nil {
+		return d.DeadlineTime()
+	}
+
+	ns := override.Deadline.Nanoseconds()
+	switch {
+	case ns < 0: // Force
+		return false, time.Time{}
+	case ns == 0: // Infinite
+		return true, time.Time{}
+	default:
+		return false, override.ForceDeadline
+	}
+}
+
+// deadlineOverride returns the deadline override of the job, preferring an
+// override of the job to priority bands.
+func (d *DrainStrategy) deadlineOverride(job *Job) *DrainDeadline {
+	var band *DrainDeadline
+	for _, o := range d.DeadlineOverrides {
+		if !o.Matches(job) {
+			continue
+		}
+		if o.JobID != "" {
+			return o
+		}
+		if band == nil {
+			band = o
+		}
+	}
+	return band
+}
+
+// SkipsJob returns whether the allocations of the job stay on the node.
+func (d *DrainStrategy) SkipsJob(namespace, jobID string) bool {
+	if d == nil {
+		return false
+	}
+	for _, job := range d.SkipJobs {
+		if job.Namespace == namespace && job.ID == jobID {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 	if d == nil && o == nil {
 		return true
@@ -1764,6 +2003,22 @@ func (d *DrainStrategy) Equal(o *DrainStrategy) bool {
 		return false
 	} else if d.IgnoreSystemJobs != o.IgnoreSystemJobs {
 		return false
+	} else if len(d.SkipJobs) != len(o.SkipJobs) {
+		return false
+	} else if len(d.DeadlineOverrides) != len(o.DeadlineOverrides) {
+		return false
+	}
+
+	for _, job := range d.SkipJobs {
+		if !o.SkipsJob(job.Namespace, job.ID) {
+			return false
+		}
+	}
+
+	for i, override := range d.DeadlineOverrides {
+		if *override != *o.DeadlineOverrides[i] {
+			return false
+		}
 	}
 
 	return true
@@ -2066,6 +2321,21 @@ func (n *Node) TerminalStatus() bool {
 	}
 }
 
+// DriverResizeAttribute returns the node attribute set when the given driver
+// can update the resources of running tasks in place.
+func DriverResizeAttribute(driver string) string {
+	return fmt.Sprintf("driver.%s.resize", driver)
+}
+
+// DriverSupportsResize returns whether the given driver on the node can update
+// the resources of running tasks in place.
+func (n *Node) DriverSupportsResize(driver string) bool {
+	if n == nil {
+		return false
+	}
+	return n.Attributes[DriverResizeAttribute(driver)] == "true"
+}
+
 // ComparableReservedResources returns the reserved resouces on the node
 // handling upgrade paths. Reserved networks must be handled separately. After
 // 0.11 calls to this should be replaced with:
@@ -2478,10 +2748,16 @@ type NodeNetworkAddress struct {
 }
 
 type AllocatedPortMapping struct {
-	Label  string
-	Value  int
-	To     int
-	HostIP string
+	Label    string
+	Value    int
+	To       int
+	HostIP   string
+	Protocol string
+}
+
+// Protocols returns the protocols the port is published for.
+func (p AllocatedPortMapping) Protocols() []string {
+	return PortProtocols(p.Protocol)
 }
 
 type AllocatedPorts []AllocatedPortMapping
@@ -2513,6 +2789,45 @@ type Port struct {
 	// to. Jobs with a HostNetwork set can only be placed on nodes with
 	// that host network available.
 	HostNetwork string
+
+	// Protocol is the protocol the port is published for. Ports without a
+	// protocol are published for both TCP and UDP.
+	Protocol string
+}
+
+// Protocols returns the protocols the port is published for.
+func (p Port) Protocols() []string {
+	return PortProtocols(p.Protocol)
+}
+
+const (
+	PortProtocolTCP  = "tcp"
+	PortProtocolUDP  = "udp"
+	PortProtocolSCTP = "sctp"
+)
+
+// PortProtocols returns the protocols a port with the given protocol is
+// published for. An empty protocol means both TCP and UDP for backwards
+// compatibility.
+func PortProtocols(protocol string) []string {
+	switch protocol {
+	case "":
+		return []string{PortProtocolTCP, PortProtocolUDP}
+	default:
+		return []string{protocol}
+	}
+}
+
+// ValidatePortProtocol returns an error if protocol isn't a supported port
+// protocol.
+func ValidatePortProtocol(protocol string) error {
+	switch protocol {
+	case "", PortProtocolTCP, PortProtocolUDP, PortProtocolSCTP:
+		return nil
+	default:
+		return fmt.Errorf("unsupported protocol %q, must be one of %q, %q or %q",
+			protocol, PortProtocolTCP, PortProtocolUDP, PortProtocolSCTP)
+	}
 }
 
 type DNSConfig struct {
@@ -2553,11 +2868,11 @@ func (nr *NetworkResource) Hash() uint32 {
 	data = append(data, []byte(fmt.Sprintf("%s%s%s%s%d", nr.Mode, nr.Device, nr.CIDR, nr.IP, nr.MBits))...)
 
 	for i, port := range nr.ReservedPorts {
-		data = append(data, []byte(fmt.Sprintf("r%d%s%d%d", i, port.Label, port.Value, port.To))...)
+		data = append(data, []byte(fmt.Sprintf("r%d%s%d%d%s", i, port.Label, port.Value, port.To, port.Protocol))...)
 	}
 
 	for i, port := range nr.DynamicPorts {
-		data = append(data, []byte(fmt.Sprintf("d%d%s%d%d", i, port.Label, port.Value, port.To))...)
+		data = append(data, []byte(fmt.Sprintf("d%d%s%d%d%s", i, port.Label, port.Value, port.To, port.Protocol))...)
 	}
 
 	return crc32.ChecksumIEEE(data)
@@ -3509,10 +3824,11 @@ func (a *AllocatedResources) Canonicalize() {
 		for _, nw := range r.Networks {
 			for _, port := range append(nw.DynamicPorts, nw.ReservedPorts...) {
 				a.Shared.Ports = append(a.Shared.Ports, AllocatedPortMapping{
-					Label:  port.Label,
-					Value:  port.Value,
-					To:     port.To,
-					HostIP: nw.IP,
+					Label:    port.Label,
+					Value:    port.Value,
+					To:       port.To,
+					HostIP:   nw.IP,
+					Protocol: port.Protocol,
 				})
 			}
 		}
@@ -4009,6 +4325,14 @@ type Job struct {
 	// allocations across a desired attribute, such as datacenter
 	Spreads []*Spread
 
+	// Scoring is a hint overriding how the scheduler scores the fit of the
+	// job's allocations on nodes
+	Scoring *ScoringConfig
+
+	// Preemption controls whether and how often the allocations of the job
+	// may be preempted to place allocations of higher priority jobs
+	Preemption *PreemptionPolicy
+
 	// TaskGroups are the collections of task groups that this job needs
 	// to run. Each task group is an atomic unit of scheduling and placement.
 	TaskGroups []*TaskGroup
@@ -4019,6 +4343,12 @@ type Job struct {
 
 	Multiregion *Multiregion
 
+	// MultiregionID identifies the multiregion registration this version of
+	// the job was submitted with. It is set by the region coordinating the
+	// registration and is shared by the job in every region, even when the
+	// job versions of the regions differ.
+	MultiregionID string
+
 	// Periodic is used to define the interval the job is run at.
 	Periodic *PeriodicConfig
 
@@ -4145,6 +4475,8 @@ func (j *Job) Copy() *Job {
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
 	nj.Multiregion = nj.Multiregion.Copy()
+	nj.Scoring = nj.Scoring.Copy()
+	nj.Preemption = nj.Preemption.Copy()
 
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(nj.TaskGroups))
@@ -4236,6 +4568,16 @@ func (j *Job) Validate() error {
 		}
 	}
 
+	if err := j.Scoring.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Scoring validation failed: %s", err))
+	}
+
+	if j.Preemption != nil {
+		if err := j.Preemption.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Preemption validation failed: %s", err))
+		}
+	}
+
 	// Check for duplicate task groups
 	taskGroups := make(map[string]int)
 	for idx, tg := range j.TaskGroups {
@@ -4327,7 +4669,7 @@ func (j *Job) Warnings() error {
 			hasAutoPromote = hasAutoPromote || u.AutoPromote
 
 			// Having no canaries implies auto-promotion since there are no canaries to promote.
-			allAutoPromote = allAutoPromote && (u.Canary == 0 || u.AutoPromote)
+			allAutoPromote = allAutoPromote && (!u.HasCanaries() || u.AutoPromote)
 		}
 	}
 
@@ -4674,6 +5016,16 @@ const (
 	UpdateStrategyHealthCheck_Manual = "manual"
 )
 
+const (
+	// UpdateStrategyRolling replaces the allocations of a group at a rate of
+	// MaxParallel, optionally after deploying canaries.
+	UpdateStrategyRolling = "rolling"
+
+	// UpdateStrategyBlueGreen brings up the full new set of allocations
+	// alongside the previous set, and switches to it on promotion.
+	UpdateStrategyBlueGreen = "blue_green"
+)
+
 var (
 	// DefaultUpdateStrategy provides a baseline that can be used to upgrade
 	// jobs with the old policy or for populating field defaults.
@@ -4731,6 +5083,27 @@ type UpdateStrategy struct {
 	// Canary is the number of canaries to deploy when a change to the task
 	// group is detected.
 	Canary int
+
+	// CanarySteps are the steps of a progressive canary deployment. At each
+	// step a percentage of the group's count is placed as canaries, and the
+	// deployment advances to the next step once the canaries have been
+	// healthy for the step's pause.
+	CanarySteps []*CanaryStep
+
+	// Analysis is an optional metric query evaluated while the group's
+	// allocations are waiting to be marked healthy. The deployment fails if
+	// the query result does not satisfy the analysis threshold.
+	Analysis *DeploymentAnalysis
+
+	// Strategy is how the allocations of the group are replaced. It defaults
+	// to a rolling update.
+	Strategy string
+
+	// RetainPrevious is how long a blue/green deployment keeps the previous
+	// set of allocations on standby after promotion. While they are retained,
+	// failing or rolling back the deployment switches back to them without
+	// rescheduling.
+	RetainPrevious time.Duration
 }
 
 func (u *UpdateStrategy) Copy() *UpdateStrategy {
@@ -4740,6 +5113,8 @@ func (u *UpdateStrategy) Copy() *UpdateStrategy {
 
 	copy := new(UpdateStrategy)
 	*copy = *u
+	copy.CanarySteps = copyCanarySteps(u.CanarySteps)
+	copy.Analysis = u.Analysis.Copy()
 	return copy
 }
 
@@ -4761,9 +5136,42 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Canary < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Canary count can not be less than zero: %d < 0", u.Canary))
 	}
-	if u.Canary == 0 && u.AutoPromote {
+	if !u.HasCanaries() && u.AutoPromote {
 		_ = multierror.Append(&mErr, fmt.Errorf("Auto Promote requires a Canary count greater than zero"))
 	}
+	if u.Canary != 0 && len(u.CanarySteps) != 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Canary count can not be set with canary steps"))
+	}
+	switch u.Strategy {
+	case "", UpdateStrategyRolling:
+		if u.RetainPrevious != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Retain previous requires the %q strategy", UpdateStrategyBlueGreen))
+		}
+	case UpdateStrategyBlueGreen:
+		if u.Canary != 0 || len(u.CanarySteps) != 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canaries can not be set with the %q strategy", UpdateStrategyBlueGreen))
+		}
+		if u.RetainPrevious < 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Retain previous may not be less than zero: %v", u.RetainPrevious))
+		}
+	default:
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid strategy given: %q", u.Strategy))
+	}
+	lastPercent := 0
+	for i, step := range u.CanarySteps {
+		if step == nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canary step %d is empty", i+1))
+			continue
+		}
+		if step.Percent <= lastPercent || step.Percent > 100 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canary step %d percent must be greater than the previous step and at most 100: %d", i+1, step.Percent))
+		} else {
+			lastPercent = step.Percent
+		}
+		if step.Pause < 0 {
+			_ = multierror.Append(&mErr, fmt.Errorf("Canary step %d pause may not be less than zero: %v", i+1, step.Pause))
+		}
+	}
 	if u.MinHealthyTime < 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Minimum healthy time may not be less than zero: %v", u.MinHealthyTime))
 	}
@@ -4782,6 +5190,11 @@ func (u *UpdateStrategy) Validate() error {
 	if u.Stagger <= 0 {
 		_ = multierror.Append(&mErr, fmt.Errorf("Stagger must be greater than zero: %v", u.Stagger))
 	}
+	if u.Analysis != nil {
+		if err := u.Analysis.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, "Analysis:"))
+		}
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -4794,12 +5207,147 @@ func (u *UpdateStrategy) IsEmpty() bool {
 	return u.MaxParallel == 0
 }
 
+// HasCanaries returns whether the update strategy places canaries
+func (u *UpdateStrategy) HasCanaries() bool {
+	return u != nil && (u.Canary != 0 || len(u.CanarySteps) != 0 || u.BlueGreen())
+}
+
+// BlueGreen returns whether the update strategy is a blue/green deployment
+func (u *UpdateStrategy) BlueGreen() bool {
+	return u != nil && u.Strategy == UpdateStrategyBlueGreen
+}
+
 // Rolling returns if a rolling strategy should be used.
 // TODO(alexdadgar): Remove once no longer used by the scheduler.
 func (u *UpdateStrategy) Rolling() bool {
 	return u.Stagger > 0 && u.MaxParallel > 0
 }
 
+// CanaryStep is a step of a progressive canary deployment
+type CanaryStep struct {
+	// Percent is the percentage of the group's count to place as canaries
+	Percent int
+
+	// Pause is how long the canaries of the step must be healthy before the
+	// deployment advances to the next step
+	Pause time.Duration
+}
+
+// Canaries returns the number of canaries of the step for a group's count,
+// rounded up so that every step places at least one canary.
+func (s *CanaryStep) Canaries(count int) int {
+	return (count*s.Percent + 99) / 100
+}
+
+func copyCanarySteps(steps []*CanaryStep) []*CanaryStep {
+	if steps == nil {
+		return nil
+	}
+
+	out := make([]*CanaryStep, len(steps))
+	for i, step := range steps {
+		if step != nil {
+			c := *step
+			out[i] = &c
+		}
+	}
+	return out
+}
+
+const (
+	// DeploymentAnalysisMinInterval is the minimum interval at which a
+	// deployment analysis query may be run.
+	DeploymentAnalysisMinInterval = time.Second
+)
+
+// DeploymentAnalysis is a query against a Prometheus compatible HTTP API whose
+// result is compared against a threshold to determine whether a deployment is
+// healthy.
+type DeploymentAnalysis struct {
+	// Address is the address of the Prometheus compatible HTTP API
+	Address string
+
+	// Query is the PromQL expression to evaluate. It is rendered as a Go
+	// template with the Namespace, JobID, JobVersion, TaskGroup and
+	// DeploymentID of the deployment.
+	Query string
+
+	// Operator is used to compare the query result against the threshold. The
+	// analysis passes when "result Operator Threshold" holds.
+	Operator string
+
+	// Threshold is the value the query result is compared against
+	Threshold float64
+
+	// Interval is how often the query is evaluated
+	Interval time.Duration
+}
+
+func (a *DeploymentAnalysis) Copy() *DeploymentAnalysis {
+	if a == nil {
+		return nil
+	}
+
+	copy := new(DeploymentAnalysis)
+	*copy = *a
+	return copy
+}
+
+func (a *DeploymentAnalysis) Validate() error {
+	var mErr multierror.Error
+	if a.Address == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Missing address"))
+	} else if u, err := url.Parse(a.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Address must be an http or https URL: %q", a.Address))
+	}
+	if a.Query == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Missing query"))
+	} else if _, err := template.New("query").Option("missingkey=error").Parse(a.Query); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid query template: %v", err))
+	}
+	if _, err := a.Compare(0); err != nil {
+		_ = multierror.Append(&mErr, err)
+	}
+	if a.Interval < DeploymentAnalysisMinInterval {
+		_ = multierror.Append(&mErr, fmt.Errorf("Interval must be at least %v: %v", DeploymentAnalysisMinInterval, a.Interval))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// AddressAllowed returns whether the analysis address is one of the allowed
+// metrics server addresses. Trailing slashes are ignored.
+func (a *DeploymentAnalysis) AddressAllowed(allowed []string) bool {
+	address := strings.TrimSuffix(a.Address, "/")
+	for _, addr := range allowed {
+		if strings.TrimSuffix(addr, "/") == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Compare returns whether the given query result satisfies the analysis
+// threshold.
+func (a *DeploymentAnalysis) Compare(value float64) (bool, error) {
+	switch a.Operator {
+	case "<":
+		return value < a.Threshold, nil
+	case "<=":
+		return value <= a.Threshold, nil
+	case ">":
+		return value > a.Threshold, nil
+	case ">=":
+		return value >= a.Threshold, nil
+	case "==":
+		return value == a.Threshold, nil
+	case "!=":
+		return value != a.Threshold, nil
+	default:
+		return false, fmt.Errorf("Invalid operator given: %q", a.Operator)
+	}
+}
+
 type Multiregion struct {
 	Strategy *MultiregionStrategy
 	Regions  []*MultiregionRegion
@@ -4846,6 +5394,21 @@ func (m *Multiregion) Copy() *Multiregion {
 	return copy
 }
 
+const (
+	// GlobalRegion is the region of a multiregion job before it is
+	// interpolated for each of the regions it is deployed to.
+	GlobalRegion = "global"
+
+	// MultiregionOnFailureFailAll fails the deployments of all regions when
+	// the deployment of one region fails.
+	MultiregionOnFailureFailAll = "fail_all"
+
+	// MultiregionOnFailureFailLocal fails only the deployment of the region
+	// that failed. The other regions complete their deployments but remain
+	// blocked until they are unblocked manually.
+	MultiregionOnFailureFailLocal = "fail_local"
+)
+
 type MultiregionStrategy struct {
 	MaxParallel int
 	OnFailure   string
@@ -4870,6 +5433,11 @@ type Namespace struct {
 	// against.
 	Quota string
 
+	// Weight is the share of evaluation dequeues the namespace receives
+	// relative to other namespaces when the eval broker dequeues fairly.
+	// Zero is the default weight of one.
+	Weight int
+
 	// Hash is the hash of the namespace which is used to efficiently replicate
 	// cross-regions.
 	Hash []byte
@@ -4891,6 +5459,9 @@ func (n *Namespace) Validate() error {
 		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
 		mErr.Errors = append(mErr.Errors, err)
 	}
+	if n.Weight < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("weight must not be negative"))
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -4907,6 +5478,9 @@ func (n *Namespace) SetHash() []byte {
 	_, _ = hash.Write([]byte(n.Name))
 	_, _ = hash.Write([]byte(n.Description))
 	_, _ = hash.Write([]byte(n.Quota))
+	if n.Weight != 0 {
+		_, _ = hash.Write([]byte(strconv.Itoa(n.Weight)))
+	}
 
 	// Finalize the hash
 	hashVal := hash.Sum(nil)
@@ -4971,6 +5545,209 @@ type NamespaceUpsertRequest struct {
 	WriteRequest
 }
 
+// PreemptionPolicy controls whether and how often the allocations of a job may
+// be preempted to place allocations of higher priority jobs. A job without a
+// preemption policy may be preempted at any time without limit.
+type PreemptionPolicy struct {
+	// Preemptible is whether the allocations of the job may be preempted
+	Preemptible bool
+
+	// MaxPreemptions is the maximum number of allocations of the job that
+	// may be preempted within Window. Zero means no limit.
+	MaxPreemptions int
+
+	// MaxPreemptionsPercent is the maximum percentage of the job's count
+	// that may be preempted within Window, rounded down. Zero means no
+	// limit.
+	MaxPreemptionsPercent int
+
+	// Window is the rolling window the preemption budget applies to
+	Window time.Duration
+
+	// MaintenanceWindows restricts preemption to the given windows. If
+	// empty the job may be preempted at any time.
+	MaintenanceWindows []*MaintenanceWindow
+}
+
+func (p *PreemptionPolicy) Copy() *PreemptionPolicy {
+	if p == nil {
+		return nil
+	}
+	np := new(PreemptionPolicy)
+	*np = *p
+	if p.MaintenanceWindows != nil {
+		np.MaintenanceWindows = make([]*MaintenanceWindow, len(p.MaintenanceWindows))
+		for i, w := range p.MaintenanceWindows {
+			np.MaintenanceWindows[i] = w.Copy()
+		}
+	}
+	return np
+}
+
+func (p *PreemptionPolicy) Validate() error {
+	var mErr multierror.Error
+	if p.MaxPreemptions < 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max preemptions must not be negative"))
+	}
+	if p.MaxPreemptionsPercent < 0 || p.MaxPreemptionsPercent > 100 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Max preemptions percent must be between 0 and 100"))
+	}
+	if p.hasBudget() && p.Window <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Window must be positive when limiting preemptions"))
+	}
+	for i, w := range p.MaintenanceWindows {
+		if err := w.Validate(); err != nil {
+			_ = multierror.Append(&mErr, multierror.Prefix(err, fmt.Sprintf("Maintenance window %d:", i+1)))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// hasBudget returns whether the policy limits the number of preemptions.
+func (p *PreemptionPolicy) hasBudget() bool {
+	return p.MaxPreemptions > 0 || p.MaxPreemptionsPercent > 0
+}
+
+// Budget returns the number of allocations of the job that may be preempted
+// within the policy's window, and false if the policy doesn't limit them.
+func (p *PreemptionPolicy) Budget(job *Job) (int, bool) {
+	if p == nil || !p.hasBudget() {
+		return 0, false
+	}
+
+	budget := -1
+	if p.MaxPreemptions > 0 {
+		budget = p.MaxPreemptions
+	}
+	if p.MaxPreemptionsPercent > 0 {
+		count := 0
+		for _, tg := range job.TaskGroups {
+			count += tg.Count
+		}
+		if b := count * p.MaxPreemptionsPercent / 100; budget < 0 || b < budget {
+			budget = b
+		}
+	}
+	return budget, true
+}
+
+// InMaintenanceWindow returns whether preemption is allowed at the given time
+// by the policy's maintenance windows.
+func (p *PreemptionPolicy) InMaintenanceWindow(now time.Time) bool {
+	if p == nil || len(p.MaintenanceWindows) == 0 {
+		return true
+	}
+	for _, w := range p.MaintenanceWindows {
+		if w.Active(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceWindow is a recurring window of time starting at each time
+// matching a cron expression.
+type MaintenanceWindow struct {
+	// Cron is the cron expression matching the start of each window
+	Cron string
+
+	// Duration is how long each window lasts
+	Duration time.Duration
+
+	// TimeZone is the time zone the cron expression is evaluated in. It
+	// defaults to UTC.
+	TimeZone string
+}
+
+func (w *MaintenanceWindow) Copy() *MaintenanceWindow {
+	if w == nil {
+		return nil
+	}
+	nw := new(MaintenanceWindow)
+	*nw = *w
+	return nw
+}
+
+func (w *MaintenanceWindow) Validate() error {
+	var mErr multierror.Error
+	if w.Cron == "" {
+		_ = multierror.Append(&mErr, fmt.Errorf("Must specify a cron expression"))
+	} else if _, err := cronexpr.Parse(w.Cron); err != nil {
+		_ = multierror.Append(&mErr, fmt.Errorf("Invalid cron expression %q: %v", w.Cron, err))
+	}
+	if w.Duration <= 0 {
+		_ = multierror.Append(&mErr, fmt.Errorf("Duration must be positive"))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			_ = multierror.Append(&mErr, fmt.Errorf("Invalid time zone %q: %v", w.TimeZone, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// Active returns whether the given time falls within one of the windows.
+func (w *MaintenanceWindow) Active(now time.Time) bool {
+	e, err := cronexpr.Parse(w.Cron)
+	if err != nil {
+		return false
+	}
+	loc := time.UTC
+	if w.TimeZone != "" {
+		if l, err := time.LoadLocation(w.TimeZone); err == nil {
+			loc = l
+		}
+	}
+
+	// The time is within a window if one started within the window's
+	// duration before it
+	start, err := CronParseNext(e, now.In(loc).Add(-w.Duration), w.Cron)
+	if err != nil || start.IsZero() {
+		return false
+	}
+	return !start.After(now)
+}
+
+// JobPreemptionHistory records when allocations of a job with a preemption
+// budget were preempted, so the budget holds across leader elections.
+type JobPreemptionHistory struct {
+	Namespace string
+	JobID     string
+
+	// Preemptions is the time in Unix nanoseconds of each preemption within
+	// the job's budget window, oldest first
+	Preemptions []int64
+
+	// Raft indexes
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func (h *JobPreemptionHistory) Copy() *JobPreemptionHistory {
+	if h == nil {
+		return nil
+	}
+	nh := new(JobPreemptionHistory)
+	*nh = *h
+	if h.Preemptions != nil {
+		nh.Preemptions = make([]int64, len(h.Preemptions))
+		copy(nh.Preemptions, h.Preemptions)
+	}
+	return nh
+}
+
+// PreemptionsSince returns the number of preemptions at or after the given
+// time in Unix nanoseconds.
+func (h *JobPreemptionHistory) PreemptionsSince(since int64) int {
+	if h == nil {
+		return 0
+	}
+	i := sort.Search(len(h.Preemptions), func(i int) bool {
+		return h.Preemptions[i] >= since
+	})
+	return len(h.Preemptions) - i
+}
+
 const (
 	// PeriodicSpecCron is used for a cron spec.
 	PeriodicSpecCron = "cron"
@@ -5986,6 +6763,27 @@ type TaskGroup struct {
 	// StopAfterClientDisconnect, if set, configures the client to stop the task group
 	// after this duration since the last known good heartbeat
 	StopAfterClientDisconnect *time.Duration
+
+	// Placement controls whether the scheduler may place only some of the
+	// allocations of the task group. When set to all_or_nothing, the
+	// allocations are placed together or not at all.
+	Placement string
+}
+
+const (
+	// TaskGroupPlacementBestEffort places as many allocations of the task
+	// group as fit, blocking the rest until capacity is available.
+	TaskGroupPlacementBestEffort = "best_effort"
+
+	// TaskGroupPlacementAllOrNothing places the allocations of the task group
+	// only if every one of them fits.
+	TaskGroupPlacementAllOrNothing = "all_or_nothing"
+)
+
+// AllOrNothing returns whether the allocations of the task group must be
+// placed together.
+func (tg *TaskGroup) AllOrNothing() bool {
+	return tg.Placement == TaskGroupPlacementAllOrNothing
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6145,6 +6943,17 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		}
 	}
 
+	switch tg.Placement {
+	case "", TaskGroupPlacementBestEffort:
+	case TaskGroupPlacementAllOrNothing:
+		if j.Type == JobTypeSystem || j.Type == JobTypeSysBatch {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Placement %q is not supported for %s jobs", tg.Placement, j.Type))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid placement %q; must be %q or %q",
+			tg.Placement, TaskGroupPlacementBestEffort, TaskGroupPlacementAllOrNothing))
+	}
+
 	if j.Type == JobTypeSystem {
 		if tg.ReschedulePolicy != nil {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("System jobs should not have a reschedule policy"))
@@ -6218,6 +7027,12 @@ func (tg *TaskGroup) Validate(j *Job) error {
 	var canaries int
 	if tg.Update != nil {
 		canaries = tg.Update.Canary
+		if n := len(tg.Update.CanarySteps); n != 0 && tg.Update.CanarySteps[n-1] != nil {
+			canaries = tg.Update.CanarySteps[n-1].Canaries(tg.Count)
+		}
+		if tg.Update.BlueGreen() {
+			canaries = tg.Count
+		}
 	}
 	for name, volReq := range tg.Volumes {
 		if err := volReq.Validate(canaries); err != nil {
@@ -6276,8 +7091,40 @@ func (tg *TaskGroup) Validate(j *Job) error {
 func (tg *TaskGroup) validateNetworks() error {
 	var mErr multierror.Error
 	portLabels := make(map[string]string)
-	// host_network -> static port tracking
-	staticPortsIndex := make(map[string]map[int]string)
+	// host_network -> static port and protocol tracking
+	type staticPort struct {
+		value    int
+		protocol string
+	}
+	staticPortsIndex := make(map[string]map[staticPort]string)
+
+	// reserveStatic records the static port for each of its protocols and
+	// returns an error if it was already reserved for one of them
+	reserveStatic := func(port Port, owner string) error {
+		if port.Value > math.MaxUint16 {
+			return fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
+		}
+
+		hostNetwork := port.HostNetwork
+		if hostNetwork == "" {
+			hostNetwork = "default"
+		}
+		staticPorts, ok := staticPortsIndex[hostNetwork]
+		if !ok {
+			staticPorts = make(map[staticPort]string)
+			staticPortsIndex[hostNetwork] = staticPorts
+		}
+
+		for _, proto := range port.Protocols() {
+			if other, ok := staticPorts[staticPort{port.Value, proto}]; ok {
+				return fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
+			}
+		}
+		for _, proto := range port.Protocols() {
+			staticPorts[staticPort{port.Value, proto}] = owner
+		}
+		return nil
+	}
 
 	for _, net := range tg.Networks {
 		for _, port := range append(net.ReservedPorts, net.DynamicPorts...) {
@@ -6287,25 +7134,14 @@ func (tg *TaskGroup) validateNetworks() error {
 				portLabels[port.Label] = "taskgroup network"
 			}
 
+			if err := ValidatePortProtocol(port.Protocol); err != nil {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Port %q has %v", port.Label, err))
+			}
+
 			if port.Value != 0 {
-				hostNetwork := port.HostNetwork
-				if hostNetwork == "" {
-					hostNetwork = "default"
-				}
-				staticPorts, ok := staticPortsIndex[hostNetwork]
-				if !ok {
-					staticPorts = make(map[int]string)
-				}
 				// static port
-				if other, ok := staticPorts[port.Value]; ok {
-					err := fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
-					mErr.Errors = append(mErr.Errors, err)
-				} else if port.Value > math.MaxUint16 {
-					err := fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
+				if err := reserveStatic(port, fmt.Sprintf("taskgroup network:%s", port.Label)); err != nil {
 					mErr.Errors = append(mErr.Errors, err)
-				} else {
-					staticPorts[port.Value] = fmt.Sprintf("taskgroup network:%s", port.Label)
-					staticPortsIndex[hostNetwork] = staticPorts
 				}
 			}
 
@@ -6330,24 +7166,13 @@ func (tg *TaskGroup) validateNetworks() error {
 					mErr.Errors = append(mErr.Errors, fmt.Errorf("Port label %s already in use by %s", port.Label, other))
 				}
 
+				if err := ValidatePortProtocol(port.Protocol); err != nil {
+					mErr.Errors = append(mErr.Errors, fmt.Errorf("Port %q has %v", port.Label, err))
+				}
+
 				if port.Value != 0 {
-					hostNetwork := port.HostNetwork
-					if hostNetwork == "" {
-						hostNetwork = "default"
-					}
-					staticPorts, ok := staticPortsIndex[hostNetwork]
-					if !ok {
-						staticPorts = make(map[int]string)
-					}
-					if other, ok := staticPorts[port.Value]; ok {
-						err := fmt.Errorf("Static port %d already reserved by %s", port.Value, other)
+					if err := reserveStatic(port, fmt.Sprintf("%s:%s", task.Name, port.Label)); err != nil {
 						mErr.Errors = append(mErr.Errors, err)
-					} else if port.Value > math.MaxUint16 {
-						err := fmt.Errorf("Port %s (%d) cannot be greater than %d", port.Label, port.Value, math.MaxUint16)
-						mErr.Errors = append(mErr.Errors, err)
-					} else {
-						staticPorts[port.Value] = fmt.Sprintf("%s:%s", task.Name, port.Label)
-						staticPortsIndex[hostNetwork] = staticPorts
 					}
 				}
 			}
@@ -6453,7 +7278,7 @@ func (tg *TaskGroup) validateScalingPolicy(j *Job) error {
 			fmt.Errorf("Scaling policy invalid: task group count must not be greater than maximum count in scaling policy"))
 	}
 
-	if int64(tg.Count) < tg.Scaling.Min && !(j.IsMultiregion() && tg.Count == 0 && j.Region == "global") {
+	if int64(tg.Count) < tg.Scaling.Min && !(j.IsMultiregion() && tg.Count == 0 && j.Region == GlobalRegion) {
 		mErr.Errors = append(mErr.Errors,
 			fmt.Errorf("Scaling policy invalid: task group count must not be less than minimum count in scaling policy"))
 	}
@@ -7675,6 +8500,26 @@ const (
 
 	// TaskPluginHealthy indicates that a plugin managed by Nomad became healthy
 	TaskPluginHealthy = "Plugin became healthy"
+
+	// TaskCheckpointed indicates the task was checkpointed to disk before
+	// being stopped so it can be restored on another node.
+	TaskCheckpointed = "Checkpointed"
+
+	// TaskCheckpointFailed indicates the task could not be checkpointed and
+	// will be restarted from scratch instead.
+	TaskCheckpointFailed = "Checkpoint Failed"
+
+	// TaskRestoredFromCheckpoint indicates the task was started from a
+	// checkpoint taken on the previous node.
+	TaskRestoredFromCheckpoint = "Restored From Checkpoint"
+
+	// TaskOOMKilled indicates the kernel OOM killer killed the task for
+	// exceeding its memory limit.
+	TaskOOMKilled = "OOM Killed"
+
+	// TaskResized indicates the resources of the running task were updated
+	// in place without restarting it.
+	TaskResized = "Resized"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -7886,6 +8731,22 @@ func (event *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Leader Task in Group dead"
 	case TaskMainDead:
 		desc = "Main tasks in the group died"
+	case TaskCheckpointed:
+		desc = "Task state checkpointed to disk"
+	case TaskCheckpointFailed:
+		if event.Message != "" {
+			desc = fmt.Sprintf("Failed to checkpoint task: %s", event.Message)
+		} else {
+			desc = "Failed to checkpoint task"
+		}
+	case TaskRestoredFromCheckpoint:
+		desc = "Task restored from checkpoint"
+	case TaskResized:
+		desc = "Task resources updated in place"
+	case TaskOOMKilled:
+		desc = oomKilledDisplayMessage(event.Details["memory_peak"], event.Details["memory_limit"])
+	case TaskDiskExceeded:
+		desc = diskExceededDisplayMessage(event.Details["disk_usage"], event.Details["disk_limit"])
 	default:
 		desc = event.Message
 	}
@@ -8067,6 +8928,61 @@ func (e *TaskEvent) SetOOMKilled(oom bool) *TaskEvent {
 	return e
 }
 
+// SetMemoryUsage sets the peak memory usage and memory limit in bytes of a
+// task. Unknown values are left unset.
+func (e *TaskEvent) SetMemoryUsage(peak, limit uint64) *TaskEvent {
+	if peak != 0 {
+		e.Details["memory_peak"] = strconv.FormatUint(peak, 10)
+	}
+	if limit != 0 {
+		e.Details["memory_limit"] = strconv.FormatUint(limit, 10)
+	}
+	return e
+}
+
+// oomKilledDisplayMessage returns the display message of a TaskOOMKilled
+// event given its memory_peak and memory_limit details.
+func oomKilledDisplayMessage(peak, limit string) string {
+	var parts []string
+	if b, err := strconv.ParseUint(peak, 10, 64); err == nil {
+		parts = append(parts, fmt.Sprintf("Memory Peak: %d MiB", b/1024/1024))
+	}
+	if b, err := strconv.ParseUint(limit, 10, 64); err == nil {
+		parts = append(parts, fmt.Sprintf("Memory Limit: %d MiB", b/1024/1024))
+	}
+
+	desc := "Task killed by the OOM killer"
+	if len(parts) > 0 {
+		desc = fmt.Sprintf("%s (%s)", desc, strings.Join(parts, ", "))
+	}
+	return desc
+}
+
+// SetDiskUsage sets the disk usage in bytes of the allocation.
+func (e *TaskEvent) SetDiskUsage(used uint64) *TaskEvent {
+	e.Details["disk_usage"] = strconv.FormatUint(used, 10)
+	return e
+}
+
+// diskExceededDisplayMessage returns the display message of a
+// TaskDiskExceeded event given its disk_usage (bytes) and disk_limit (MB)
+// details.
+func diskExceededDisplayMessage(usage, limit string) string {
+	var parts []string
+	if b, err := strconv.ParseUint(usage, 10, 64); err == nil {
+		parts = append(parts, fmt.Sprintf("Disk Usage: %d MiB", b/1024/1024))
+	}
+	if mb, err := strconv.ParseUint(limit, 10, 64); err == nil && mb > 0 {
+		parts = append(parts, fmt.Sprintf("Disk Limit: %d MiB", mb))
+	}
+
+	desc := "Ephemeral disk is full"
+	if len(parts) > 0 {
+		desc = fmt.Sprintf("%s (%s)", desc, strings.Join(parts, ", "))
+	}
+	return desc
+}
+
 // TaskArtifact is an artifact to download before running the task.
 type TaskArtifact struct {
 	// GetterSource is the source to download an artifact using go-getter
@@ -8469,6 +9385,12 @@ type Spread struct {
 	// Attribute is the node attribute used as the spread criteria
 	Attribute string
 
+	// Hierarchy is an ordered list of node attributes describing nested
+	// failure domains, from the outermost to the innermost, such as zone
+	// then rack. It is used instead of Attribute to spread allocations
+	// evenly at every level at once.
+	Hierarchy []string
+
 	// Weight is the relative weight of this spread, useful when there are multiple
 	// spread and affinities
 	Weight int8
@@ -8512,6 +9434,7 @@ func (s *Spread) Copy() *Spread {
 	ns := new(Spread)
 	*ns = *s
 
+	ns.Hierarchy = helper.CopySliceString(s.Hierarchy)
 	ns.SpreadTarget = CopySliceSpreadTarget(s.SpreadTarget)
 	return ns
 }
@@ -8520,13 +9443,35 @@ func (s *Spread) String() string {
 	if s.str != "" {
 		return s.str
 	}
+	if len(s.Hierarchy) > 0 {
+		s.str = fmt.Sprintf("%v %v", s.Hierarchy, s.Weight)
+		return s.str
+	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
 	return s.str
 }
 
 func (s *Spread) Validate() error {
 	var mErr multierror.Error
-	if s.Attribute == "" {
+	if len(s.Hierarchy) > 0 {
+		if s.Attribute != "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread stanza can't have both an attribute and a hierarchy"))
+		}
+		if len(s.SpreadTarget) > 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread stanza with a hierarchy can't have targets"))
+		}
+		seenAttrs := make(map[string]struct{}, len(s.Hierarchy))
+		for _, attr := range s.Hierarchy {
+			if attr == "" {
+				mErr.Errors = append(mErr.Errors, errors.New("Spread hierarchy attributes must not be empty"))
+				continue
+			}
+			if _, ok := seenAttrs[attr]; ok {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread hierarchy attribute %q already defined", attr))
+			}
+			seenAttrs[attr] = struct{}{}
+		}
+	} else if s.Attribute == "" {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing spread attribute"))
 	}
 	if s.Weight <= 0 || s.Weight > 100 {
@@ -8595,6 +9540,12 @@ type EphemeralDisk struct {
 	// Migrate determines if Nomad client should migrate the allocation dir for
 	// sticky allocations
 	Migrate bool
+
+	// Checkpoint determines if the Nomad client should checkpoint running
+	// tasks when the allocation is migrated and restore them from the
+	// checkpoint on the new node. Tasks whose driver can't checkpoint are
+	// restarted as usual.
+	Checkpoint bool
 }
 
 // DefaultEphemeralDisk returns a EphemeralDisk with default configurations
@@ -8609,6 +9560,9 @@ func (d *EphemeralDisk) Validate() error {
 	if d.SizeMB < 10 {
 		return fmt.Errorf("minimum DiskMB value is 10; got %d", d.SizeMB)
 	}
+	if d.Checkpoint && !d.Migrate {
+		return fmt.Errorf("checkpoint requires migrate to be enabled")
+	}
 	return nil
 }
 
@@ -8736,12 +9690,16 @@ const (
 	DeploymentStatusDescriptionFailedAllocations     = "Failed due to unhealthy allocations"
 	DeploymentStatusDescriptionProgressDeadline      = "Failed due to progress deadline"
 	DeploymentStatusDescriptionFailedByUser          = "Deployment marked as failed"
+	DeploymentStatusDescriptionFailedAnalysis        = "Failed due to deployment analysis"
+	DeploymentStatusDescriptionRunningRetainPrevious = "Deployment is running and retaining the previous allocations"
+	DeploymentStatusDescriptionRolledBackByUser      = "Deployment rolled back by user"
 
 	// used only in multiregion deployments
-	DeploymentStatusDescriptionFailedByPeer   = "Failed because of an error in peer region"
-	DeploymentStatusDescriptionBlocked        = "Deployment is complete but waiting for peer region"
-	DeploymentStatusDescriptionUnblocking     = "Deployment is unblocking remaining regions"
-	DeploymentStatusDescriptionPendingForPeer = "Deployment is pending, waiting for peer region"
+	DeploymentStatusDescriptionFailedByPeer    = "Failed because of an error in peer region"
+	DeploymentStatusDescriptionBlocked         = "Deployment is complete but waiting for peer region"
+	DeploymentStatusDescriptionUnblocking      = "Deployment is unblocking remaining regions"
+	DeploymentStatusDescriptionPendingForPeer  = "Deployment is pending, waiting for peer region"
+	DeploymentStatusDescriptionCancelledByUser = "Deployment cancelled by user"
 )
 
 // DeploymentStatusDescriptionRollback is used to get the status description of
@@ -8793,6 +9751,11 @@ type Deployment struct {
 	// Multiregion specifies if deployment is part of multiregion deployment
 	IsMultiregion bool
 
+	// MultiregionID is the MultiregionID of the job the deployment is
+	// tracking. It matches the deployments of a multiregion registration
+	// across regions.
+	MultiregionID string
+
 	// TaskGroups is the set of task groups effected by the deployment and their
 	// current deployment status.
 	TaskGroups map[string]*DeploymentState
@@ -8819,6 +9782,7 @@ func NewDeployment(job *Job) *Deployment {
 		JobSpecModifyIndex: job.JobModifyIndex,
 		JobCreateIndex:     job.CreateIndex,
 		IsMultiregion:      job.IsMultiregion(),
+		MultiregionID:      job.MultiregionID,
 		Status:             DeploymentStatusRunning,
 		StatusDescription:  DeploymentStatusDescriptionRunning,
 		TaskGroups:         make(map[string]*DeploymentState, len(job.TaskGroups)),
@@ -8951,6 +9915,56 @@ type DeploymentState struct {
 
 	// UnhealthyAllocs are allocations that have been marked as unhealthy.
 	UnhealthyAllocs int
+
+	// CanarySteps are the canary steps copied from TaskGroup UpdateStrategy
+	// in scheduler.reconcile
+	CanarySteps []*CanaryStep
+
+	// CanaryStep is the index of the canary step the deployment is at.
+	// DesiredCanaries is the number of canaries of this step.
+	CanaryStep int
+
+	// NextCanaryStepAt is the time the canaries of the current step have been
+	// healthy for the step's pause, and the deployment can advance to the next
+	// step or be promoted. It is zero until the canaries are healthy.
+	NextCanaryStepAt time.Time
+
+	// BlueGreen marks whether the task group is deployed with the blue/green
+	// strategy, copied from TaskGroup UpdateStrategy in scheduler.reconcile
+	BlueGreen bool
+
+	// RetainPrevious is how long the previous allocations are retained on
+	// standby after promotion, copied from TaskGroup UpdateStrategy in
+	// scheduler.reconcile
+	RetainPrevious time.Duration
+
+	// PreviousAllocs is the set of allocations put on standby when the
+	// blue/green deployment was promoted
+	PreviousAllocs []string
+
+	// RetainUntil is the time until which the previous allocations are
+	// retained
+	RetainUntil time.Time
+}
+
+// Retaining returns whether the group is retaining its previous allocations on
+// standby at the given time.
+func (d *DeploymentState) Retaining(now time.Time) bool {
+	return d != nil && d.BlueGreen && d.Promoted && len(d.PreviousAllocs) != 0 && now.Before(d.RetainUntil)
+}
+
+// CurrentCanaryStep returns the canary step the deployment is at, or nil if
+// the group doesn't have canary steps.
+func (d *DeploymentState) CurrentCanaryStep() *CanaryStep {
+	if d == nil || d.CanaryStep >= len(d.CanarySteps) {
+		return nil
+	}
+	return d.CanarySteps[d.CanaryStep]
+}
+
+// LastCanaryStep returns whether the deployment is at its last canary step.
+func (d *DeploymentState) LastCanaryStep() bool {
+	return d.CanaryStep >= len(d.CanarySteps)-1
 }
 
 func (d *DeploymentState) GoString() string {
@@ -8963,6 +9977,13 @@ func (d *DeploymentState) GoString() string {
 	base += fmt.Sprintf("\n\tUnhealthy: %d", d.UnhealthyAllocs)
 	base += fmt.Sprintf("\n\tAutoRevert: %v", d.AutoRevert)
 	base += fmt.Sprintf("\n\tAutoPromote: %v", d.AutoPromote)
+	if len(d.CanarySteps) != 0 {
+		base += fmt.Sprintf("\n\tCanary Step: %d/%d", d.CanaryStep+1, len(d.CanarySteps))
+	}
+	if d.BlueGreen {
+		base += fmt.Sprintf("\n\tPrevious Allocs: %#v", d.PreviousAllocs)
+		base += fmt.Sprintf("\n\tRetain Until: %v", d.RetainUntil)
+	}
 	return base
 }
 
@@ -8970,6 +9991,8 @@ func (d *DeploymentState) Copy() *DeploymentState {
 	c := &DeploymentState{}
 	*c = *d
 	c.PlacedCanaries = helper.CopySliceString(d.PlacedCanaries)
+	c.CanarySteps = copyCanarySteps(d.CanarySteps)
+	c.PreviousAllocs = helper.CopySliceString(d.PreviousAllocs)
 	return c
 }
 
@@ -10051,8 +11074,15 @@ func (s *NodeScoreMeta) Data() interface{} {
 // systems in Nomad such as service registration.
 type AllocNetworkStatus struct {
 	InterfaceName string
-	Address       string
-	DNS           *DNSConfig
+
+	// Address is the primary address of the allocation. It is the IPv4
+	// address if the allocation has one and the IPv6 address otherwise.
+	Address string
+
+	// AddressIPv6 is the IPv6 address of the allocation, if it has one.
+	AddressIPv6 string
+
+	DNS *DNSConfig
 }
 
 func (a *AllocNetworkStatus) Copy() *AllocNetworkStatus {
@@ -10062,6 +11092,7 @@ func (a *AllocNetworkStatus) Copy() *AllocNetworkStatus {
 	return &AllocNetworkStatus{
 		InterfaceName: a.InterfaceName,
 		Address:       a.Address,
+		AddressIPv6:   a.AddressIPv6,
 		DNS:           a.DNS.Copy(),
 	}
 }
@@ -10082,6 +11113,11 @@ type AllocDeploymentStatus struct {
 	// been promoted will have this field set to false.
 	Canary bool
 
+	// Standby marks an allocation that a blue/green deployment retains while
+	// another set of allocations is active. Like canaries, standby allocations
+	// register their services with the canary tags.
+	Standby bool
+
 	// ModifyIndex is the raft index in which the deployment status was last
 	// changed.
 	ModifyIndex uint64
@@ -10121,6 +11157,22 @@ func (a *AllocDeploymentStatus) IsCanary() bool {
 	return a.Canary
 }
 
+// IsStandby returns if the allocation is retained on standby by a blue/green
+// deployment
+func (a *AllocDeploymentStatus) IsStandby() bool {
+	if a == nil {
+		return false
+	}
+
+	return a.Standby
+}
+
+// UsesCanaryTags returns if the services of the allocation should be
+// registered with their canary tags
+func (a *AllocDeploymentStatus) UsesCanaryTags() bool {
+	return a.IsCanary() || a.IsStandby()
+}
+
 func (a *AllocDeploymentStatus) Copy() *AllocDeploymentStatus {
 	if a == nil {
 		return nil
@@ -10556,6 +11608,11 @@ type Plan struct {
 	// as evicted.
 	NodePreemptions map[string][]*Allocation
 
+	// Gangs maps the all_or_nothing task groups placed by the plan to the IDs
+	// of the nodes their placements and stops touch. A gang is only applied if
+	// every one of its nodes fits, otherwise all of its nodes are dropped.
+	Gangs map[string][]string
+
 	// SnapshotIndex is the Raft index of the snapshot used to create the
 	// Plan. The leader will wait to evaluate the plan until its StateStore
 	// has reached at least this index.
@@ -10701,6 +11758,76 @@ func (p *Plan) PopUpdate(alloc *Allocation) {
 	}
 }
 
+// RemoveUpdate removes the stop or eviction of the allocation from the plan.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	existing := p.NodeUpdate[alloc.NodeID]
+	for i, update := range existing {
+		if update.ID != alloc.ID {
+			continue
+		}
+		existing = append(existing[:i], existing[i+1:]...)
+		if len(existing) > 0 {
+			p.NodeUpdate[alloc.NodeID] = existing
+		} else {
+			delete(p.NodeUpdate, alloc.NodeID)
+		}
+		return
+	}
+}
+
+// RemoveAlloc removes a placed allocation from the plan, along with the
+// preemptions it required.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	existing := p.NodeAllocation[alloc.NodeID]
+	for i, placed := range existing {
+		if placed.ID != alloc.ID {
+			continue
+		}
+		existing = append(existing[:i], existing[i+1:]...)
+		if len(existing) > 0 {
+			p.NodeAllocation[alloc.NodeID] = existing
+		} else {
+			delete(p.NodeAllocation, alloc.NodeID)
+		}
+		break
+	}
+
+	for node, preempted := range p.NodePreemptions {
+		remaining := preempted[:0]
+		for _, stop := range preempted {
+			if stop.PreemptedByAllocation != alloc.ID {
+				remaining = append(remaining, stop)
+			}
+		}
+		if len(remaining) > 0 {
+			p.NodePreemptions[node] = remaining
+		} else {
+			delete(p.NodePreemptions, node)
+		}
+	}
+}
+
+// AppendGang records the placements of an all_or_nothing task group, which
+// must be applied as a whole, along with the allocations they stop.
+func (p *Plan) AppendGang(taskGroup string, placed, stopped []*Allocation) {
+	seen := make(map[string]struct{}, len(placed))
+	var nodeIDs []string
+	for _, allocs := range [][]*Allocation{placed, stopped} {
+		for _, alloc := range allocs {
+			if _, ok := seen[alloc.NodeID]; ok {
+				continue
+			}
+			seen[alloc.NodeID] = struct{}{}
+			nodeIDs = append(nodeIDs, alloc.NodeID)
+		}
+	}
+
+	if p.Gangs == nil {
+		p.Gangs = make(map[string][]string)
+	}
+	p.Gangs[taskGroup] = nodeIDs
+}
+
 // AppendAlloc appends the alloc to the plan allocations.
 // Uses the passed job if explicitly passed, otherwise
 // it is assumed the alloc will use the plan Job version.
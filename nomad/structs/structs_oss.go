@@ -10,11 +10,49 @@ import (
 )
 
 func (m *Multiregion) Validate(jobType string, jobDatacenters []string) error {
-	if m != nil {
-		return errors.New("Multiregion jobs are unlicensed.")
+	if m == nil {
+		return nil
 	}
 
-	return nil
+	var mErr multierror.Error
+	if m.Strategy != nil {
+		if m.Strategy.MaxParallel < 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Multiregion max_parallel must be non-negative"))
+		}
+		switch m.Strategy.OnFailure {
+		case "", MultiregionOnFailureFailAll, MultiregionOnFailureFailLocal:
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion on_failure %q is invalid, must be one of %q or %q",
+				m.Strategy.OnFailure, MultiregionOnFailureFailAll, MultiregionOnFailureFailLocal))
+		}
+	}
+
+	seen := make(map[string]struct{}, len(m.Regions))
+	for i, region := range m.Regions {
+		if region.Name == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion region %d missing name", i+1))
+			continue
+		}
+		if region.Name == GlobalRegion {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion region name %q is reserved", GlobalRegion))
+		}
+		if _, ok := seen[region.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion region %q defined more than once", region.Name))
+		}
+		seen[region.Name] = struct{}{}
+
+		if len(region.Datacenters) == 0 && len(jobDatacenters) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion region %q must have at least one datacenter", region.Name))
+		}
+		if region.Count < 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion region %q count must be non-negative", region.Name))
+		}
+		if region.Count > 0 && (jobType == JobTypeSystem || jobType == JobTypeSysBatch) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Multiregion region %q count is not supported for %s jobs", region.Name, jobType))
+		}
+	}
+
+	return mErr.ErrorOrNil()
 }
 
 func (p *ScalingPolicy) validateType() multierror.Error {
@@ -0,0 +1,92 @@
+// +build !ent
+
+package structs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiregion_Validate(t *testing.T) {
+	valid := func() *Multiregion {
+		return &Multiregion{
+			Strategy: &MultiregionStrategy{
+				MaxParallel: 1,
+				OnFailure:   MultiregionOnFailureFailAll,
+			},
+			Regions: []*MultiregionRegion{
+				{Name: "west", Count: 2, Datacenters: []string{"west-1"}},
+				{Name: "east", Count: 1},
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		jobType string
+		modify  func(*Multiregion)
+		err     string
+	}{
+		{
+			name:    "valid",
+			jobType: JobTypeService,
+			modify:  func(*Multiregion) {},
+		},
+		{
+			name:    "negative max_parallel",
+			jobType: JobTypeService,
+			modify:  func(m *Multiregion) { m.Strategy.MaxParallel = -1 },
+			err:     "max_parallel must be non-negative",
+		},
+		{
+			name:    "invalid on_failure",
+			jobType: JobTypeService,
+			modify:  func(m *Multiregion) { m.Strategy.OnFailure = "fail_sometimes" },
+			err:     "on_failure \"fail_sometimes\" is invalid",
+		},
+		{
+			name:    "missing name",
+			jobType: JobTypeService,
+			modify:  func(m *Multiregion) { m.Regions[0].Name = "" },
+			err:     "region 1 missing name",
+		},
+		{
+			name:    "global region",
+			jobType: JobTypeService,
+			modify:  func(m *Multiregion) { m.Regions[0].Name = GlobalRegion },
+			err:     "name \"global\" is reserved",
+		},
+		{
+			name:    "duplicate region",
+			jobType: JobTypeService,
+			modify:  func(m *Multiregion) { m.Regions[1].Name = "west" },
+			err:     "region \"west\" defined more than once",
+		},
+		{
+			name:    "count on system job",
+			jobType: JobTypeSystem,
+			modify:  func(*Multiregion) {},
+			err:     "count is not supported for system jobs",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := valid()
+			tc.modify(m)
+			err := m.Validate(tc.jobType, []string{"dc1"})
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+
+	// Regions without datacenters use the job's
+	err := valid().Validate(JobTypeService, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "region \"east\" must have at least one datacenter")
+}
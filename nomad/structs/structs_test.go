@@ -1085,10 +1085,28 @@ func TestTaskGroup_Validate(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 
+	tg = &TaskGroup{
+		Placement: "some",
+	}
+	j.Type = JobTypeService
+	err = tg.Validate(j)
+	require.Contains(t, err.Error(), `Invalid placement "some"`)
+
+	tg = &TaskGroup{
+		Placement: TaskGroupPlacementAllOrNothing,
+	}
+	err = tg.Validate(j)
+	require.NotContains(t, err.Error(), "placement")
+	require.NotContains(t, err.Error(), "Placement")
+
+	j.Type = JobTypeSystem
+	err = tg.Validate(j)
+	require.Contains(t, err.Error(), `Placement "all_or_nothing" is not supported for system jobs`)
+
 	tg = &TaskGroup{
 		Networks: []*NetworkResource{
 			{
-				DynamicPorts: []Port{{"http", 0, 80, ""}},
+				DynamicPorts: []Port{{"http", 0, 80, "", ""}},
 			},
 		},
 		Tasks: []*Task{
@@ -1096,7 +1114,7 @@ func TestTaskGroup_Validate(t *testing.T) {
 				Resources: &Resources{
 					Networks: []*NetworkResource{
 						{
-							DynamicPorts: []Port{{"http", 0, 80, ""}},
+							DynamicPorts: []Port{{"http", 0, 80, "", ""}},
 						},
 					},
 				},
@@ -1442,6 +1460,64 @@ func TestTaskGroupNetwork_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			TG: &TaskGroup{
+				Name: "same-port-different-protocols",
+				Networks: Networks{
+					&NetworkResource{
+						ReservedPorts: []Port{
+							{
+								Label:    "dns_udp",
+								Value:    53,
+								Protocol: "udp",
+							},
+							{
+								Label:    "dns_tcp",
+								Value:    53,
+								Protocol: "tcp",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			TG: &TaskGroup{
+				Name: "same-port-overlapping-protocols",
+				Networks: Networks{
+					&NetworkResource{
+						ReservedPorts: []Port{
+							{
+								Label:    "dns_udp",
+								Value:    53,
+								Protocol: "udp",
+							},
+							{
+								Label: "dns",
+								Value: 53,
+							},
+						},
+					},
+				},
+			},
+			ErrContains: "already reserved by",
+		},
+		{
+			TG: &TaskGroup{
+				Name: "invalid-protocol",
+				Networks: Networks{
+					&NetworkResource{
+						DynamicPorts: []Port{
+							{
+								Label:    "http",
+								Protocol: "quic",
+							},
+						},
+					},
+				},
+			},
+			ErrContains: "unsupported protocol",
+		},
 	}
 
 	for i := range cases {
@@ -2260,6 +2336,17 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	require.Error(t, err, "log storage")
 }
 
+func TestEphemeralDisk_Validate_Checkpoint(t *testing.T) {
+	disk := &EphemeralDisk{
+		SizeMB:     300,
+		Checkpoint: true,
+	}
+	require.EqualError(t, disk.Validate(), "checkpoint requires migrate to be enabled")
+
+	disk.Migrate = true
+	require.NoError(t, disk.Validate())
+}
+
 func TestLogConfig_Equals(t *testing.T) {
 	t.Run("both nil", func(t *testing.T) {
 		a := (*LogConfig)(nil)
@@ -2670,6 +2757,120 @@ func TestUpdateStrategy_Validate(t *testing.T) {
 	)
 }
 
+func TestUpdateStrategy_Validate_CanarySteps(t *testing.T) {
+	u := DefaultUpdateStrategy.Copy()
+	u.AutoPromote = true
+	u.CanarySteps = []*CanaryStep{
+		{Percent: 10, Pause: time.Minute},
+		{Percent: 50},
+		{Percent: 100},
+	}
+	require.NoError(t, u.Validate())
+
+	u.Canary = 1
+	u.CanarySteps = []*CanaryStep{
+		{Percent: 50},
+		{Percent: 50, Pause: -1},
+		nil,
+		{Percent: 101},
+	}
+	requireErrors(t, u.Validate(),
+		"Canary count can not be set with canary steps",
+		"Canary step 2 percent must be greater than the previous step",
+		"Canary step 2 pause may not be less than zero",
+		"Canary step 3 is empty",
+		"Canary step 4 percent must be greater than the previous step and at most 100",
+	)
+}
+
+func TestUpdateStrategy_Validate_BlueGreen(t *testing.T) {
+	u := DefaultUpdateStrategy.Copy()
+	u.Strategy = UpdateStrategyBlueGreen
+	u.AutoPromote = true
+	u.RetainPrevious = 10 * time.Minute
+	require.NoError(t, u.Validate())
+	require.True(t, u.BlueGreen())
+	require.True(t, u.HasCanaries())
+
+	u.Canary = 1
+	u.RetainPrevious = -1
+	requireErrors(t, u.Validate(),
+		`Canaries can not be set with the "blue_green" strategy`,
+		"Retain previous may not be less than zero",
+	)
+
+	u = DefaultUpdateStrategy.Copy()
+	u.RetainPrevious = time.Minute
+	requireErrors(t, u.Validate(), `Retain previous requires the "blue_green" strategy`)
+
+	u.Strategy = "recreate"
+	u.RetainPrevious = 0
+	requireErrors(t, u.Validate(), `Invalid strategy given: "recreate"`)
+}
+
+func TestCanaryStep_Canaries(t *testing.T) {
+	cases := []struct {
+		percent, count, expected int
+	}{
+		{10, 10, 1},
+		{10, 3, 1},
+		{25, 10, 3},
+		{100, 7, 7},
+		{50, 0, 0},
+	}
+	for _, tc := range cases {
+		step := &CanaryStep{Percent: tc.percent}
+		require.Equal(t, tc.expected, step.Canaries(tc.count), "%d%% of %d", tc.percent, tc.count)
+	}
+}
+
+func TestUpdateStrategy_Validate_Analysis(t *testing.T) {
+	u := DefaultUpdateStrategy.Copy()
+	u.Analysis = &DeploymentAnalysis{
+		Address:   "http://127.0.0.1:9090",
+		Query:     `sum(rate(http_errors{job="{{.JobID}}"}[1m]))`,
+		Operator:  "<",
+		Threshold: 0.5,
+		Interval:  10 * time.Second,
+	}
+	require.NoError(t, u.Validate())
+
+	u.Analysis = &DeploymentAnalysis{
+		Address:  "127.0.0.1:9090",
+		Query:    "{{.JobID",
+		Operator: "~",
+		Interval: time.Millisecond,
+	}
+	requireErrors(t, u.Validate(),
+		"Analysis: Address must be an http or https URL",
+		"Invalid query template",
+		"Invalid operator given",
+		"Interval must be at least 1s",
+	)
+}
+
+func TestDeploymentAnalysis_Compare(t *testing.T) {
+	cases := []struct {
+		operator string
+		value    float64
+		expected bool
+	}{
+		{"<", 0.5, true},
+		{"<", 1, false},
+		{"<=", 1, true},
+		{">", 1, false},
+		{">=", 1, true},
+		{"==", 1, true},
+		{"!=", 1, false},
+	}
+	for _, tc := range cases {
+		a := &DeploymentAnalysis{Operator: tc.operator, Threshold: 1}
+		passed, err := a.Compare(tc.value)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, passed, "%v %s 1", tc.value, tc.operator)
+	}
+}
+
 func TestResource_NetIndex(t *testing.T) {
 	r := &Resources{
 		Networks: []*NetworkResource{
@@ -2698,7 +2899,7 @@ func TestResource_Add(t *testing.T) {
 			{
 				CIDR:          "10.0.0.0/8",
 				MBits:         100,
-				ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+				ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 			},
 		},
 	}
@@ -2710,7 +2911,7 @@ func TestResource_Add(t *testing.T) {
 			{
 				IP:            "10.0.0.1",
 				MBits:         50,
-				ReservedPorts: []Port{{"web", 80, 0, ""}},
+				ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 			},
 		},
 	}
@@ -2725,7 +2926,7 @@ func TestResource_Add(t *testing.T) {
 			{
 				CIDR:          "10.0.0.0/8",
 				MBits:         150,
-				ReservedPorts: []Port{{"ssh", 22, 0, ""}, {"web", 80, 0, ""}},
+				ReservedPorts: []Port{{"ssh", 22, 0, "", ""}, {"web", 80, 0, "", ""}},
 			},
 		},
 	}
@@ -2741,7 +2942,7 @@ func TestResource_Add_Network(t *testing.T) {
 		Networks: []*NetworkResource{
 			{
 				MBits:        50,
-				DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}},
+				DynamicPorts: []Port{{"http", 0, 80, "", ""}, {"https", 0, 443, "", ""}},
 			},
 		},
 	}
@@ -2749,7 +2950,7 @@ func TestResource_Add_Network(t *testing.T) {
 		Networks: []*NetworkResource{
 			{
 				MBits:        25,
-				DynamicPorts: []Port{{"admin", 0, 8080, ""}},
+				DynamicPorts: []Port{{"admin", 0, 8080, "", ""}},
 			},
 		},
 	}
@@ -2761,7 +2962,7 @@ func TestResource_Add_Network(t *testing.T) {
 		Networks: []*NetworkResource{
 			{
 				MBits:        75,
-				DynamicPorts: []Port{{"http", 0, 80, ""}, {"https", 0, 443, ""}, {"admin", 0, 8080, ""}},
+				DynamicPorts: []Port{{"http", 0, 80, "", ""}, {"https", 0, 443, "", ""}, {"admin", 0, 8080, "", ""}},
 			},
 		},
 	}
@@ -2786,7 +2987,7 @@ func TestComparableResources_Subtract(t *testing.T) {
 				{
 					CIDR:          "10.0.0.0/8",
 					MBits:         100,
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 				},
 			},
 		},
@@ -2809,7 +3010,7 @@ func TestComparableResources_Subtract(t *testing.T) {
 				{
 					CIDR:          "10.0.0.0/8",
 					MBits:         20,
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 				},
 			},
 		},
@@ -2833,7 +3034,7 @@ func TestComparableResources_Subtract(t *testing.T) {
 				{
 					CIDR:          "10.0.0.0/8",
 					MBits:         100,
-					ReservedPorts: []Port{{"ssh", 22, 0, ""}},
+					ReservedPorts: []Port{{"ssh", 22, 0, "", ""}},
 				},
 			},
 		},
@@ -3251,6 +3452,92 @@ func TestJob_CombinedTaskMeta(t *testing.T) {
 
 }
 
+func TestPreemptionPolicy_Validate(t *testing.T) {
+	p := &PreemptionPolicy{
+		Preemptible:           true,
+		MaxPreemptions:        -1,
+		MaxPreemptionsPercent: 101,
+		MaintenanceWindows: []*MaintenanceWindow{
+			{Cron: "foo", Duration: 0, TimeZone: "Invalid/Zone"},
+			{Cron: "0 2 * * *", Duration: time.Hour, TimeZone: "America/New_York"},
+		},
+	}
+	err := p.Validate()
+	require.Error(t, err)
+	mErr := err.(*multierror.Error)
+	require.Len(t, mErr.Errors, 6)
+	require.Contains(t, mErr.Errors[0].Error(), "Max preemptions must not be negative")
+	require.Contains(t, mErr.Errors[1].Error(), "percent must be between 0 and 100")
+	require.Contains(t, mErr.Errors[2].Error(), "Window must be positive")
+	require.Contains(t, mErr.Errors[3].Error(), "Invalid cron expression")
+	require.Contains(t, mErr.Errors[4].Error(), "Duration must be positive")
+	require.Contains(t, mErr.Errors[5].Error(), "Invalid time zone")
+
+	p.MaxPreemptions = 2
+	p.MaxPreemptionsPercent = 0
+	p.Window = time.Hour
+	p.MaintenanceWindows = p.MaintenanceWindows[1:]
+	require.NoError(t, p.Validate())
+}
+
+func TestPreemptionPolicy_Budget(t *testing.T) {
+	job := &Job{TaskGroups: []*TaskGroup{{Count: 6}, {Count: 4}}}
+
+	var p *PreemptionPolicy
+	_, ok := p.Budget(job)
+	require.False(t, ok)
+
+	p = &PreemptionPolicy{Preemptible: true}
+	_, ok = p.Budget(job)
+	require.False(t, ok)
+
+	p.MaxPreemptions = 3
+	budget, ok := p.Budget(job)
+	require.True(t, ok)
+	require.Equal(t, 3, budget)
+
+	// The lower of the limits applies
+	p.MaxPreemptionsPercent = 25
+	budget, _ = p.Budget(job)
+	require.Equal(t, 2, budget)
+
+	p.MaxPreemptions = 0
+	p.MaxPreemptionsPercent = 5
+	budget, ok = p.Budget(job)
+	require.True(t, ok)
+	require.Equal(t, 0, budget)
+}
+
+func TestMaintenanceWindow_Active(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	w := &MaintenanceWindow{
+		Cron:     "0 2 * * *",
+		Duration: 2 * time.Hour,
+		TimeZone: "America/New_York",
+	}
+
+	cases := []struct {
+		time   time.Time
+		active bool
+	}{
+		{time.Date(2021, 3, 1, 1, 59, 0, 0, loc), false},
+		{time.Date(2021, 3, 1, 2, 0, 0, 0, loc), true},
+		{time.Date(2021, 3, 1, 3, 59, 0, 0, loc), true},
+		{time.Date(2021, 3, 1, 4, 0, 0, 0, loc), false},
+		{time.Date(2021, 3, 1, 7, 30, 0, 0, time.UTC), true},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.active, w.Active(c.time), "time %v", c.time)
+	}
+
+	p := &PreemptionPolicy{MaintenanceWindows: []*MaintenanceWindow{w}}
+	require.True(t, p.InMaintenanceWindow(time.Date(2021, 3, 1, 2, 30, 0, 0, loc)))
+	require.False(t, p.InMaintenanceWindow(time.Date(2021, 3, 1, 12, 0, 0, 0, loc)))
+	require.True(t, (&PreemptionPolicy{}).InMaintenanceWindow(time.Now()))
+}
+
 func TestPeriodicConfig_EnabledInvalid(t *testing.T) {
 	// Create a config that is enabled but with no interval specified.
 	p := &PeriodicConfig{Enabled: true}
@@ -4037,6 +4324,53 @@ func TestPlan_AppendPreemptedAllocAppendsAllocWithUpdatedAttrs(t *testing.T) {
 	assert.Equal(t, expectedAlloc, appendedAlloc)
 }
 
+func TestPlan_RemoveAlloc(t *testing.T) {
+	t.Parallel()
+	plan := &Plan{
+		NodeUpdate:      make(map[string][]*Allocation),
+		NodeAllocation:  make(map[string][]*Allocation),
+		NodePreemptions: make(map[string][]*Allocation),
+	}
+
+	kept := MockAlloc()
+	removed := MockAlloc()
+	removed.NodeID = kept.NodeID
+	plan.AppendAlloc(kept, nil)
+	plan.AppendAlloc(removed, nil)
+
+	preempted := MockAlloc()
+	plan.AppendPreemptedAlloc(preempted, removed.ID)
+
+	stopped := MockAlloc()
+	plan.AppendStoppedAlloc(stopped, "replaced", "", "")
+
+	plan.RemoveAlloc(removed)
+	plan.RemoveUpdate(stopped)
+
+	require.Len(t, plan.NodeAllocation[kept.NodeID], 1)
+	require.Equal(t, kept.ID, plan.NodeAllocation[kept.NodeID][0].ID)
+	require.Empty(t, plan.NodePreemptions)
+	require.Empty(t, plan.NodeUpdate)
+
+	plan.RemoveAlloc(kept)
+	require.Empty(t, plan.NodeAllocation)
+}
+
+func TestPlan_AppendGang(t *testing.T) {
+	t.Parallel()
+	plan := &Plan{}
+
+	placed := []*Allocation{MockAlloc(), MockAlloc()}
+	placed[1].NodeID = placed[0].NodeID
+	stopped := MockAlloc()
+	stopped.NodeID = "other"
+
+	plan.AppendGang("web", placed, []*Allocation{stopped})
+	require.Equal(t, map[string][]string{
+		"web": {placed[0].NodeID, stopped.NodeID},
+	}, plan.Gangs)
+}
+
 func TestAllocation_MsgPackTags(t *testing.T) {
 	t.Parallel()
 	planType := reflect.TypeOf(Allocation{})
@@ -5373,6 +5707,11 @@ func TestTaskEventPopulate(t *testing.T) {
 		{NewTaskEvent(TaskRestartSignal), "Task signaled to restart"},
 		{NewTaskEvent(TaskRestartSignal).SetRestartReason("Chaos Monkey restarted it"), "Chaos Monkey restarted it"},
 		{NewTaskEvent(TaskDriverMessage).SetDriverMessage("YOLO"), "YOLO"},
+		{NewTaskEvent(TaskOOMKilled), "Task killed by the OOM killer"},
+		{NewTaskEvent(TaskOOMKilled).SetMemoryUsage(0, 256*1024*1024), "Task killed by the OOM killer (Memory Limit: 256 MiB)"},
+		{NewTaskEvent(TaskOOMKilled).SetMemoryUsage(255*1024*1024, 256*1024*1024), "Task killed by the OOM killer (Memory Peak: 255 MiB, Memory Limit: 256 MiB)"},
+		{NewTaskEvent(TaskDiskExceeded), "Ephemeral disk is full"},
+		{NewTaskEvent(TaskDiskExceeded).SetDiskLimit(300).SetDiskUsage(299 * 1024 * 1024), "Ephemeral disk is full (Disk Usage: 299 MiB, Disk Limit: 300 MiB)"},
 		{NewTaskEvent("Unknown Type, No message"), ""},
 		{NewTaskEvent("Unknown Type").SetMessage("Hello world"), "Hello world"},
 	}
@@ -5397,12 +5736,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 			},
 			true,
@@ -5413,12 +5752,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.0",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 			},
 			false,
@@ -5429,12 +5768,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         40,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 			},
 			false,
@@ -5445,12 +5784,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}, {"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}, {"web", 80, 0, "", ""}},
 				},
 			},
 			false,
@@ -5461,7 +5800,7 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:            "10.0.0.1",
@@ -5477,12 +5816,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"web", 80, 0, ""}},
+					ReservedPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:            "10.0.0.1",
 					MBits:         50,
-					ReservedPorts: []Port{{"notweb", 80, 0, ""}},
+					ReservedPorts: []Port{{"notweb", 80, 0, "", ""}},
 				},
 			},
 			false,
@@ -5493,12 +5832,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}},
+					DynamicPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}, {"web", 80, 0, ""}},
+					DynamicPorts: []Port{{"web", 80, 0, "", ""}, {"web", 80, 0, "", ""}},
 				},
 			},
 			false,
@@ -5509,7 +5848,7 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}},
+					DynamicPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:           "10.0.0.1",
@@ -5525,12 +5864,12 @@ func TestNetworkResourcesEquals(t *testing.T) {
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"web", 80, 0, ""}},
+					DynamicPorts: []Port{{"web", 80, 0, "", ""}},
 				},
 				{
 					IP:           "10.0.0.1",
 					MBits:        50,
-					DynamicPorts: []Port{{"notweb", 80, 0, ""}},
+					DynamicPorts: []Port{{"notweb", 80, 0, "", ""}},
 				},
 			},
 			false,
@@ -5801,6 +6140,45 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+				Weight:    50,
+			},
+			err:  fmt.Errorf("Spread stanza can't have both an attribute and a hierarchy"),
+			name: "Attribute and hierarchy",
+		},
+		{
+			spread: &Spread{
+				Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+				Weight:    50,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "a",
+						Percent: 50,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread stanza with a hierarchy can't have targets"),
+			name: "Hierarchy with targets",
+		},
+		{
+			spread: &Spread{
+				Hierarchy: []string{"${meta.zone}", "${meta.zone}"},
+				Weight:    50,
+			},
+			err:  fmt.Errorf("Spread hierarchy attribute \"${meta.zone}\" already defined"),
+			name: "Duplicate hierarchy attributes",
+		},
+		{
+			spread: &Spread{
+				Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+				Weight:    50,
+			},
+			err:  nil,
+			name: "Valid hierarchy",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -5963,7 +6341,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{"admin", 8080, 0, "default", ""}},
 							},
 						},
 					},
@@ -5975,7 +6353,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{"admin", 8080, 0, "default", ""}},
 							},
 						},
 					},
@@ -5999,7 +6377,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{"admin", 8080, 0, "default", ""}},
 							},
 						},
 					},
@@ -6021,7 +6399,7 @@ func TestAllocatedResources_Canonicalize(t *testing.T) {
 						Networks: Networks{
 							{
 								IP:           "127.0.0.1",
-								DynamicPorts: []Port{{"admin", 8080, 0, "default"}},
+								DynamicPorts: []Port{{"admin", 8080, 0, "default", ""}},
 							},
 						},
 					},
@@ -6238,3 +6616,119 @@ func requireErrors(t *testing.T, err error, expected ...string) {
 
 	require.Equal(t, expected, found)
 }
+
+func TestDrainSpec_Validate(t *testing.T) {
+	spec := &DrainSpec{
+		Deadline: time.Hour,
+		DeadlineOverrides: []*DrainDeadline{
+			{Namespace: DefaultNamespace, JobID: "web", Deadline: time.Minute},
+			{MinPriority: 70, MaxPriority: 100, Deadline: -1},
+		},
+		SkipJobs: []NamespacedID{NewNamespacedID("db", DefaultNamespace)},
+	}
+	require.NoError(t, spec.Validate())
+
+	spec.DeadlineOverrides = append(spec.DeadlineOverrides,
+		nil,
+		&DrainDeadline{Namespace: DefaultNamespace, JobID: "api", MinPriority: 10, MaxPriority: 20},
+		&DrainDeadline{MinPriority: 60, MaxPriority: 50},
+		&DrainDeadline{MinPriority: 0, MaxPriority: 50},
+		&DrainDeadline{JobID: "cache"},
+		&DrainDeadline{Namespace: AllNamespacesSentinel, JobID: "cache"},
+		&DrainDeadline{Namespace: DefaultNamespace, MinPriority: 10, MaxPriority: 20},
+	)
+	spec.SkipJobs = append(spec.SkipJobs,
+		NewNamespacedID("", DefaultNamespace),
+		NewNamespacedID("cache", ""),
+	)
+	requireErrors(t, spec.Validate(),
+		"deadline override 3 is empty",
+		"deadline override 4 must select a job or a priority band, not both",
+		"deadline override 5 has an invalid priority band [60, 50]",
+		"deadline override 6 has an invalid priority band [0, 50]",
+		"deadline override 7 must set the namespace of the job",
+		"deadline override 8 must not select the jobs of all namespaces",
+		"deadline override 9 must not set a namespace without a job",
+		"skipped job ID must not be empty",
+		`skipped job "cache" must set the namespace of the job`,
+	)
+}
+
+func TestDrainStrategy_JobDeadlineTime(t *testing.T) {
+	now := time.Now()
+	strategy := &DrainStrategy{
+		DrainSpec: DrainSpec{
+			Deadline: time.Hour,
+			DeadlineOverrides: []*DrainDeadline{
+				{MinPriority: 70, MaxPriority: 100, Deadline: -1},
+				{MinPriority: 60, MaxPriority: 80, Deadline: 2 * time.Hour},
+				{Namespace: DefaultNamespace, JobID: "web", Deadline: time.Minute},
+				{Namespace: DefaultNamespace, JobID: "db", Deadline: 0},
+			},
+		},
+	}
+	strategy.SetForceDeadlines(now)
+	require.Equal(t, now.Add(time.Hour), strategy.ForceDeadline)
+
+	cases := []struct {
+		name      string
+		namespace string
+		jobID     string
+		priority  int
+		infinite  bool
+		deadline  time.Time
+	}{
+		{"Default", DefaultNamespace, "api", 50, false, now.Add(time.Hour)},
+		{"Band", DefaultNamespace, "api", 65, false, now.Add(2 * time.Hour)},
+		{"FirstBand", DefaultNamespace, "api", 75, false, time.Time{}},
+		{"Job", DefaultNamespace, "web", 75, false, now.Add(time.Minute)},
+		{"JobInfinite", DefaultNamespace, "db", 50, true, time.Time{}},
+		{"JobOtherNamespace", "other", "db", 50, false, now.Add(time.Hour)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &Job{Namespace: tc.namespace, ID: tc.jobID, Priority: tc.priority}
+			infinite, deadline := strategy.JobDeadlineTime(job)
+			require.Equal(t, tc.infinite, infinite)
+			require.Equal(t, tc.deadline, deadline)
+		})
+	}
+}
+
+func TestDrainStrategy_CopyEqual(t *testing.T) {
+	strategy := &DrainStrategy{
+		DrainSpec: DrainSpec{
+			Deadline: time.Hour,
+			DeadlineOverrides: []*DrainDeadline{
+				{Namespace: DefaultNamespace, JobID: "web", Deadline: time.Minute},
+			},
+			SkipJobs: []NamespacedID{
+				NewNamespacedID("db", DefaultNamespace),
+				NewNamespacedID("cache", DefaultNamespace),
+			},
+		},
+	}
+	require.True(t, strategy.SkipsJob(DefaultNamespace, "db"))
+	require.False(t, strategy.SkipsJob("other", "db"))
+	require.False(t, strategy.SkipsJob(DefaultNamespace, "web"))
+	require.False(t, (*DrainStrategy)(nil).SkipsJob(DefaultNamespace, "db"))
+
+	c := strategy.Copy()
+	require.True(t, strategy.Equal(c))
+
+	// The skipped jobs are compared as a set
+	c.SkipJobs = []NamespacedID{
+		NewNamespacedID("cache", DefaultNamespace),
+		NewNamespacedID("db", DefaultNamespace),
+	}
+	require.True(t, strategy.Equal(c))
+
+	c.DeadlineOverrides[0].Deadline = time.Second
+	require.Equal(t, time.Minute, strategy.DeadlineOverrides[0].Deadline)
+	require.False(t, strategy.Equal(c))
+
+	c = strategy.Copy()
+	c.SkipJobs[0].Namespace = "other"
+	require.Equal(t, DefaultNamespace, strategy.SkipJobs[0].Namespace)
+	require.False(t, strategy.Equal(c))
+}
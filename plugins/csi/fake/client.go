@@ -75,6 +75,10 @@ type Client struct {
 	NextControllerListSnapshotsErr      error
 	ControllerListSnapshotsCallCount    int64
 
+	NextControllerExpandVolumeResponse *csi.ControllerExpandVolumeResponse
+	NextControllerExpandVolumeErr      error
+	ControllerExpandVolumeCallCount    int64
+
 	NextNodeGetCapabilitiesResponse *csi.NodeCapabilitySet
 	NextNodeGetCapabilitiesErr      error
 	NodeGetCapabilitiesCallCount    int64
@@ -95,6 +99,11 @@ type Client struct {
 
 	NextNodeUnpublishVolumeErr   error
 	NodeUnpublishVolumeCallCount int64
+
+	PrevNodeExpandVolumeRequest  *csi.NodeExpandVolumeRequest
+	NextNodeExpandVolumeResponse *csi.NodeExpandVolumeResponse
+	NextNodeExpandVolumeErr      error
+	NodeExpandVolumeCallCount    int64
 }
 
 // PluginInfo describes the type and version of a plugin.
@@ -130,7 +139,7 @@ func (c *Client) PluginProbe(ctx context.Context) (bool, error) {
 
 // PluginGetInfo is used to return semantic data about the plugin.
 // Response:
-//  - string: name, the name of the plugin in domain notation format.
+//   - string: name, the name of the plugin in domain notation format.
 func (c *Client) PluginGetInfo(ctx context.Context) (string, string, error) {
 	c.Mu.Lock()
 	defer c.Mu.Unlock()
@@ -232,6 +241,13 @@ func (c *Client) ControllerListSnapshots(ctx context.Context, req *csi.Controlle
 	return c.NextControllerListSnapshotsResponse, c.NextControllerListSnapshotsErr
 }
 
+func (c *Client) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest, opts ...grpc.CallOption) (*csi.ControllerExpandVolumeResponse, error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+	c.ControllerExpandVolumeCallCount++
+	return c.NextControllerExpandVolumeResponse, c.NextControllerExpandVolumeErr
+}
+
 func (c *Client) NodeGetCapabilities(ctx context.Context) (*csi.NodeCapabilitySet, error) {
 	c.Mu.Lock()
 	defer c.Mu.Unlock()
@@ -298,6 +314,18 @@ func (c *Client) NodeUnpublishVolume(ctx context.Context, volumeID, targetPath s
 }
 
 // Close the client and ensure any connections are cleaned up.
+// NodeExpandVolume is used when a plugin has the EXPAND_VOLUME node
+// capability to expand the filesystem of a volume on the node.
+func (c *Client) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest, opts ...grpc.CallOption) (*csi.NodeExpandVolumeResponse, error) {
+	c.Mu.Lock()
+	defer c.Mu.Unlock()
+
+	c.PrevNodeExpandVolumeRequest = req
+	c.NodeExpandVolumeCallCount++
+
+	return c.NextNodeExpandVolumeResponse, c.NextNodeExpandVolumeErr
+}
+
 func (c *Client) Close() error {
 
 	c.NextPluginInfoResponse = nil
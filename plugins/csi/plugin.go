@@ -69,6 +69,10 @@ type CSIPlugin interface {
 	// in the external storage provider
 	ControllerListSnapshots(ctx context.Context, req *ControllerListSnapshotsRequest, opts ...grpc.CallOption) (*ControllerListSnapshotsResponse, error)
 
+	// ControllerExpandVolume is used to expand the capacity of a volume in
+	// the external storage provider
+	ControllerExpandVolume(ctx context.Context, req *ControllerExpandVolumeRequest, opts ...grpc.CallOption) (*ControllerExpandVolumeResponse, error)
+
 	// NodeGetCapabilities is used to return the available capabilities from the
 	// Node Service.
 	NodeGetCapabilities(ctx context.Context) (*NodeCapabilitySet, error)
@@ -98,6 +102,11 @@ type CSIPlugin interface {
 	// for the given volume.
 	NodeUnpublishVolume(ctx context.Context, volumeID, targetPath string, opts ...grpc.CallOption) error
 
+	// NodeExpandVolume is used when a plugin has the EXPAND_VOLUME node
+	// capability to expand the filesystem of a volume on the node after the
+	// volume has been expanded by the controller.
+	NodeExpandVolume(ctx context.Context, req *NodeExpandVolumeRequest, opts ...grpc.CallOption) (*NodeExpandVolumeResponse, error)
+
 	// Shutdown the client and ensure any connections are cleaned up.
 	Close() error
 }
@@ -621,6 +630,37 @@ func (r *ControllerDeleteVolumeRequest) Validate() error {
 	return nil
 }
 
+type ControllerExpandVolumeRequest struct {
+	ExternalVolumeID string
+	Capacity         *CapacityRange
+	Secrets          structs.CSISecrets
+	Capability       *VolumeCapability
+}
+
+func (r *ControllerExpandVolumeRequest) ToCSIRepresentation() *csipbv1.ControllerExpandVolumeRequest {
+	if r == nil {
+		return nil
+	}
+	return &csipbv1.ControllerExpandVolumeRequest{
+		VolumeId:         r.ExternalVolumeID,
+		CapacityRange:    r.Capacity.ToCSIRepresentation(),
+		Secrets:          r.Secrets,
+		VolumeCapability: r.Capability.ToCSIRepresentation(),
+	}
+}
+
+func (r *ControllerExpandVolumeRequest) Validate() error {
+	if r.ExternalVolumeID == "" {
+		return errors.New("missing ExternalVolumeID")
+	}
+	return r.Capacity.Validate()
+}
+
+type ControllerExpandVolumeResponse struct {
+	CapacityBytes         int64
+	NodeExpansionRequired bool
+}
+
 type ControllerListVolumesRequest struct {
 	MaxEntries    int32
 	StartingToken string
@@ -838,6 +878,43 @@ func NewNodeCapabilitySet(resp *csipbv1.NodeGetCapabilitiesResponse) *NodeCapabi
 	return cs
 }
 
+type NodeExpandVolumeRequest struct {
+	ExternalVolumeID  string
+	Capacity          *CapacityRange
+	TargetPath        string
+	StagingTargetPath string
+	Capability        *VolumeCapability
+}
+
+func (r *NodeExpandVolumeRequest) ToCSIRepresentation() *csipbv1.NodeExpandVolumeRequest {
+	if r == nil {
+		return nil
+	}
+	return &csipbv1.NodeExpandVolumeRequest{
+		VolumeId:          r.ExternalVolumeID,
+		VolumePath:        r.TargetPath,
+		CapacityRange:     r.Capacity.ToCSIRepresentation(),
+		StagingTargetPath: r.StagingTargetPath,
+		VolumeCapability:  r.Capability.ToCSIRepresentation(),
+	}
+}
+
+func (r *NodeExpandVolumeRequest) Validate() error {
+	if r.ExternalVolumeID == "" {
+		return errors.New("missing ExternalVolumeID")
+	}
+
+	if r.TargetPath == "" {
+		return errors.New("missing TargetPath")
+	}
+
+	return r.Capacity.Validate()
+}
+
+type NodeExpandVolumeResponse struct {
+	CapacityBytes int64
+}
+
 // VolumeAccessMode represents the desired access mode of the CSI Volume
 type VolumeAccessMode csipbv1.VolumeCapability_AccessMode_Mode
 
@@ -963,6 +1040,21 @@ type CapacityRange struct {
 	LimitBytes    int64
 }
 
+// Validate checks that the limit of the capacity range, if set, is not smaller
+// than the required capacity.
+func (c *CapacityRange) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.RequiredBytes < 0 || c.LimitBytes < 0 {
+		return errors.New("capacity must not be negative")
+	}
+	if c.LimitBytes > 0 && c.LimitBytes < c.RequiredBytes {
+		return fmt.Errorf("capacity limit %d is less than required capacity %d", c.LimitBytes, c.RequiredBytes)
+	}
+	return nil
+}
+
 func (c *CapacityRange) ToCSIRepresentation() *csipbv1.CapacityRange {
 	if c == nil {
 		return nil
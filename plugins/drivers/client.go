@@ -74,6 +74,8 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.Checkpoint = resp.Capabilities.Checkpoint
+		caps.Resize = resp.Capabilities.Resize
 	}
 
 	return caps, nil
@@ -196,6 +198,8 @@ func (d *driverPluginClient) handleWaitTask(ctx context.Context, id string, ch c
 		result.ExitCode = int(resp.Result.ExitCode)
 		result.Signal = int(resp.Result.Signal)
 		result.OOMKilled = resp.Result.OomKilled
+		result.MemoryPeak = resp.Result.MemoryPeak
+		result.MemoryLimit = resp.Result.MemoryLimit
 		if len(resp.Err) > 0 {
 			result.Err = errors.New(resp.Err)
 		}
@@ -494,3 +498,18 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+// UpdateTaskResources applies new resources to a running task in place.
+func (d *driverPluginClient) UpdateTaskResources(taskID string, resources *Resources) error {
+	req := &proto.UpdateTaskResourcesRequest{
+		TaskId:    taskID,
+		Resources: ResourcesToProto(resources),
+	}
+
+	_, err := d.client.UpdateTaskResources(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
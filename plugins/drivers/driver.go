@@ -94,6 +94,13 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// ResizeDriver is the interface implemented by drivers that can apply new
+// resources to a running task in place, without restarting it. Drivers
+// implementing it should set the Resize capability.
+type ResizeDriver interface {
+	UpdateTaskResources(taskID string, resources *Resources) error
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -171,6 +178,14 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// Checkpoint indicates the driver can checkpoint a running task to disk
+	// and restore it later, possibly on another node. See CheckpointDriver.
+	Checkpoint bool
+
+	// Resize indicates the driver can update the resources of a running task
+	// in place. See ResizeDriver.
+	Resize bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -459,6 +474,12 @@ type ExitResult struct {
 	Signal    int
 	OOMKilled bool
 	Err       error
+
+	// MemoryPeak and MemoryLimit are the peak memory usage and the memory
+	// limit of the task in bytes. Drivers set them when the task was OOM
+	// killed, if known.
+	MemoryPeak  uint64
+	MemoryLimit uint64
 }
 
 func (r *ExitResult) Successful() bool {
@@ -602,6 +623,21 @@ type InternalCapabilitiesDriver interface {
 	InternalCapabilities() InternalCapabilities
 }
 
+// CheckpointDriver is an experimental interface enabling a driver to dump the
+// state of a running task to disk and restore it from that image later, for
+// example when an allocation is migrated off of a draining node.
+//
+// Intended for internal drivers only while the interface is stabalized.
+type CheckpointDriver interface {
+	// CheckpointTask writes the state of the running task into dir. The task
+	// is stopped once the checkpoint has been written.
+	CheckpointTask(taskID string, dir string) error
+
+	// RestoreTask starts the task described by config from the checkpoint
+	// image previously written to dir by CheckpointTask.
+	RestoreTask(config *TaskConfig, dir string) (*TaskHandle, *DriverNetwork, error)
+}
+
 // InternalCapabilities flags disabled functionality.
 // Zero value means all is supported.
 type InternalCapabilities struct {
@@ -1839,7 +1839,13 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// checkpoint indicates whether the driver can checkpoint a running task
+	// to disk and restore it later, possibly on another node.
+	Checkpoint bool `protobuf:"varint,8,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	// resize indicates whether the driver can update the resources of a running
+	// task in place.
+	Resize               bool     `protobuf:"varint,9,opt,name=resize,proto3" json:"resize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1919,6 +1925,20 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
+func (m *DriverCapabilities) GetResize() bool {
+	if m != nil {
+		return m.Resize
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -3027,7 +3047,11 @@ type ExitResult struct {
 	// Signal is set if a signal was sent to the task
 	Signal int32 `protobuf:"varint,2,opt,name=signal,proto3" json:"signal,omitempty"`
 	// OomKilled is true if the task exited as a result of the OOM Killer
-	OomKilled            bool     `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	OomKilled bool `protobuf:"varint,3,opt,name=oom_killed,json=oomKilled,proto3" json:"oom_killed,omitempty"`
+	// MemoryPeak is the peak memory usage of the task in bytes, if known
+	MemoryPeak uint64 `protobuf:"varint,4,opt,name=memory_peak,json=memoryPeak,proto3" json:"memory_peak,omitempty"`
+	// MemoryLimit is the memory limit of the task in bytes, if known
+	MemoryLimit          uint64   `protobuf:"varint,5,opt,name=memory_limit,json=memoryLimit,proto3" json:"memory_limit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3079,6 +3103,20 @@ func (m *ExitResult) GetOomKilled() bool {
 	return false
 }
 
+func (m *ExitResult) GetMemoryPeak() uint64 {
+	if m != nil {
+		return m.MemoryPeak
+	}
+	return 0
+}
+
+func (m *ExitResult) GetMemoryLimit() uint64 {
+	if m != nil {
+		return m.MemoryLimit
+	}
+	return 0
+}
+
 // TaskStatus includes information of a specific task
 type TaskStatus struct {
 	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -3590,6 +3628,86 @@ func (m *DriverTaskEvent) GetAnnotations() map[string]string {
 	return nil
 }
 
+type UpdateTaskResourcesRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Resources are the new resources of the task
+	Resources            *Resources `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *UpdateTaskResourcesRequest) Reset()         { *m = UpdateTaskResourcesRequest{} }
+func (m *UpdateTaskResourcesRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesRequest) ProtoMessage()    {}
+func (*UpdateTaskResourcesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{57}
+}
+
+func (m *UpdateTaskResourcesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesRequest.Merge(m, src)
+}
+func (m *UpdateTaskResourcesRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesRequest.Size(m)
+}
+func (m *UpdateTaskResourcesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesRequest proto.InternalMessageInfo
+
+func (m *UpdateTaskResourcesRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *UpdateTaskResourcesRequest) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+type UpdateTaskResourcesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateTaskResourcesResponse) Reset()         { *m = UpdateTaskResourcesResponse{} }
+func (m *UpdateTaskResourcesResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateTaskResourcesResponse) ProtoMessage()    {}
+func (*UpdateTaskResourcesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{58}
+}
+
+func (m *UpdateTaskResourcesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Unmarshal(m, b)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Marshal(b, m, deterministic)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateTaskResourcesResponse.Merge(m, src)
+}
+func (m *UpdateTaskResourcesResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateTaskResourcesResponse.Size(m)
+}
+func (m *UpdateTaskResourcesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateTaskResourcesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateTaskResourcesResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.TaskState", TaskState_name, TaskState_value)
 	proto.RegisterEnum("hashicorp.nomad.plugins.drivers.proto.FingerprintResponse_HealthState", FingerprintResponse_HealthState_name, FingerprintResponse_HealthState_value)
@@ -3665,6 +3783,8 @@ func init() {
 	proto.RegisterType((*CPUUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.CPUUsage")
 	proto.RegisterType((*MemoryUsage)(nil), "hashicorp.nomad.plugins.drivers.proto.MemoryUsage")
 	proto.RegisterType((*DriverTaskEvent)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent")
+	proto.RegisterType((*UpdateTaskResourcesRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesRequest")
+	proto.RegisterType((*UpdateTaskResourcesResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.UpdateTaskResourcesResponse")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverTaskEvent.AnnotationsEntry")
 }
 
@@ -3673,243 +3793,249 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3771 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0xdc, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd1, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xfe,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x37, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0xfb, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xcc, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x68, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x04, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xf8, 0x6d, 0xe6, 0x21, 0x4d, 0x74,
-	0x2a, 0x07, 0x2c, 0xe6, 0x7f, 0x96, 0xfb, 0x54, 0xd1, 0x1f, 0x40, 0x35, 0xc1, 0x37, 0xaa, 0x03,
-	0x9c, 0xf4, 0x76, 0xbb, 0x83, 0x6e, 0x67, 0xd0, 0xdd, 0xd5, 0xd6, 0xd0, 0x3a, 0x54, 0x4e, 0x7a,
-	0x7b, 0xdd, 0xf6, 0xc1, 0x60, 0xef, 0xb9, 0xa6, 0xa0, 0x2a, 0x94, 0x22, 0x22, 0xa7, 0x5f, 0x02,
-	0xc2, 0x64, 0xe8, 0x5d, 0x90, 0x80, 0x19, 0xb2, 0xd4, 0x2a, 0xba, 0x0d, 0xa5, 0xd0, 0xa4, 0xe7,
-	0x86, 0x6d, 0x49, 0x9e, 0x8b, 0x8c, 0xdc, 0xb7, 0xd0, 0x3e, 0x14, 0xcf, 0x4c, 0xd7, 0x1a, 0xbf,
-	0x9d, 0xef, 0xb4, 0xa8, 0x19, 0xf8, 0x1e, 0x9f, 0x88, 0x25, 0x00, 0xb3, 0xee, 0xd4, 0xca, 0x42,
-	0x01, 0xfa, 0x73, 0xd0, 0xfa, 0xa1, 0x19, 0x84, 0x49, 0x76, 0xba, 0x90, 0x67, 0xeb, 0x4b, 0x8b,
-	0x5e, 0x66, 0x4d, 0xb1, 0x33, 0x31, 0x9f, 0xae, 0xff, 0x6f, 0x0e, 0x36, 0x13, 0xd8, 0xd2, 0x52,
-	0x9f, 0x41, 0x31, 0x20, 0x74, 0x32, 0x0e, 0x39, 0x7c, 0x7d, 0xe7, 0x51, 0x46, 0xf8, 0x39, 0xa4,
-	0x16, 0xe6, 0x30, 0x58, 0xc2, 0xa1, 0x6d, 0xd0, 0xc4, 0x0c, 0x83, 0x04, 0x81, 0x17, 0x18, 0x0e,
-	0x1d, 0x71, 0xa9, 0x55, 0x70, 0x5d, 0xb4, 0x77, 0x59, 0xf3, 0x21, 0x1d, 0x25, 0xa4, 0xaa, 0x5e,
-	0x53, 0xaa, 0xc8, 0x04, 0xcd, 0x25, 0xe1, 0x6b, 0x2f, 0x38, 0x37, 0x98, 0x68, 0x03, 0xdb, 0x22,
-	0x8d, 0x3c, 0x07, 0xfd, 0x24, 0x23, 0x68, 0x4f, 0x4c, 0x3f, 0x92, 0xb3, 0xf1, 0x86, 0x9b, 0x6e,
-	0xd0, 0x7f, 0x08, 0x45, 0xf1, 0x4f, 0x99, 0x25, 0xf5, 0x4f, 0x3a, 0x9d, 0x6e, 0xbf, 0xaf, 0xad,
-	0xa1, 0x0a, 0x14, 0x70, 0x77, 0x80, 0x99, 0x85, 0x55, 0xa0, 0xf0, 0xb8, 0x3d, 0x68, 0x1f, 0x68,
-	0x39, 0xfd, 0x07, 0xb0, 0xf1, 0xcc, 0xb4, 0xc3, 0x2c, 0xc6, 0xa5, 0x7b, 0xa0, 0x4d, 0xc7, 0x4a,
-	0xed, 0xec, 0xa7, 0xb4, 0x93, 0x5d, 0x34, 0xdd, 0x4b, 0x3b, 0x9c, 0xd1, 0x87, 0x06, 0x2a, 0x09,
-	0x02, 0xa9, 0x02, 0xf6, 0xa9, 0xbf, 0x86, 0x8d, 0x7e, 0xe8, 0xf9, 0x99, 0x2c, 0xff, 0x47, 0x50,
-	0x62, 0xa7, 0x8d, 0x37, 0x09, 0xa5, 0xe9, 0xdf, 0x69, 0x89, 0xd3, 0xa8, 0x15, 0x9d, 0x46, 0xad,
-	0x5d, 0x79, 0x5a, 0xe1, 0x68, 0x24, 0xba, 0x05, 0x45, 0x6a, 0x8f, 0x5c, 0x73, 0x2c, 0xbd, 0x85,
-	0xa4, 0x74, 0xc4, 0x8c, 0x3c, 0x5a, 0x58, 0x1a, 0x7e, 0x07, 0xd0, 0x2e, 0xa1, 0x61, 0xe0, 0x5d,
-	0x65, 0xe2, 0x67, 0x0b, 0x0a, 0x2f, 0xbd, 0x60, 0x28, 0x36, 0x62, 0x19, 0x0b, 0x82, 0x6d, 0xaa,
-	0x14, 0x88, 0xc4, 0xfe, 0x10, 0xd0, 0xbe, 0xcb, 0xce, 0x94, 0x6c, 0x8a, 0xf8, 0x87, 0x1c, 0xdc,
-	0x48, 0x8d, 0x97, 0xca, 0x58, 0x7d, 0x1f, 0x32, 0xc7, 0x34, 0xa1, 0x62, 0x1f, 0xa2, 0x23, 0x28,
-	0x8a, 0x11, 0x52, 0x92, 0xf7, 0x97, 0x00, 0x12, 0xc7, 0x94, 0x84, 0x93, 0x30, 0x0b, 0x8d, 0x5e,
-	0x7d, 0xb7, 0x46, 0xff, 0x1a, 0xb4, 0xe8, 0x7f, 0xd0, 0xb7, 0xea, 0xe6, 0x2b, 0xb8, 0x31, 0xf4,
-	0xc6, 0x63, 0x32, 0x64, 0xd6, 0x60, 0xd8, 0x6e, 0x48, 0x82, 0x0b, 0x73, 0xfc, 0x76, 0xbb, 0x41,
-	0xd3, 0x59, 0xfb, 0x72, 0x92, 0xfe, 0x02, 0x36, 0x13, 0x0b, 0x4b, 0x45, 0x3c, 0x86, 0x02, 0x65,
-	0x0d, 0x52, 0x13, 0x1f, 0x2d, 0xa9, 0x09, 0x8a, 0xc5, 0x74, 0xfd, 0x86, 0x00, 0xef, 0x5e, 0x10,
-	0x37, 0xfe, 0x5b, 0xfa, 0x2e, 0x6c, 0xf6, 0xb9, 0x99, 0x66, 0xb2, 0xc3, 0xa9, 0x89, 0xe7, 0x52,
-	0x26, 0xbe, 0x05, 0x28, 0x89, 0x22, 0x0d, 0xf1, 0x0a, 0x36, 0xba, 0x97, 0x64, 0x98, 0x09, 0xb9,
-	0x01, 0xa5, 0xa1, 0xe7, 0x38, 0xa6, 0x6b, 0x35, 0x72, 0x77, 0xd5, 0xed, 0x0a, 0x8e, 0xc8, 0xe4,
-	0x5e, 0x54, 0xb3, 0xee, 0x45, 0xfd, 0xef, 0x14, 0xd0, 0xa6, 0x6b, 0x4b, 0x41, 0x32, 0xee, 0x43,
-	0x8b, 0x01, 0xb1, 0xb5, 0x6b, 0x58, 0x52, 0xb2, 0x3d, 0x72, 0x17, 0xa2, 0x9d, 0x04, 0x41, 0xc2,
-	0x1d, 0xa9, 0xd7, 0x74, 0x47, 0xfa, 0x1e, 0xfc, 0x4e, 0xc4, 0x4e, 0x3f, 0x0c, 0x88, 0xe9, 0xd8,
-	0xee, 0x68, 0xff, 0xe8, 0xc8, 0x27, 0x82, 0x71, 0x84, 0x20, 0x6f, 0x99, 0xa1, 0x29, 0x19, 0xe3,
-	0xdf, 0x6c, 0xd3, 0x0f, 0xc7, 0x1e, 0x8d, 0x37, 0x3d, 0x27, 0xf4, 0xff, 0x50, 0xa1, 0x31, 0x07,
-	0x15, 0x89, 0xf7, 0x05, 0x14, 0x28, 0x09, 0x27, 0xbe, 0x34, 0x95, 0x6e, 0x66, 0x86, 0x17, 0xe3,
-	0xb5, 0xfa, 0x0c, 0x0c, 0x0b, 0x4c, 0x34, 0x82, 0x72, 0x18, 0x5e, 0x19, 0xd4, 0xfe, 0x79, 0x14,
-	0x10, 0x1c, 0x5c, 0x17, 0x7f, 0x40, 0x02, 0xc7, 0x76, 0xcd, 0x71, 0xdf, 0xfe, 0x39, 0xc1, 0xa5,
-	0x30, 0xbc, 0x62, 0x1f, 0xe8, 0x39, 0x33, 0x78, 0xcb, 0x76, 0xa5, 0xd8, 0x3b, 0xab, 0xae, 0x92,
-	0x10, 0x30, 0x16, 0x88, 0xcd, 0x03, 0x28, 0xf0, 0xff, 0xb4, 0x8a, 0x21, 0x6a, 0xa0, 0x86, 0xe1,
-	0x15, 0x67, 0xaa, 0x8c, 0xd9, 0x67, 0xf3, 0x21, 0xd4, 0x92, 0xff, 0x80, 0x19, 0xd2, 0x19, 0xb1,
-	0x47, 0x67, 0xc2, 0xc0, 0x0a, 0x58, 0x52, 0x4c, 0x93, 0xaf, 0x6d, 0x4b, 0x86, 0xac, 0x05, 0x2c,
-	0x08, 0xfd, 0x5f, 0x73, 0x70, 0x67, 0x81, 0x64, 0xa4, 0xb1, 0xbe, 0x48, 0x19, 0xeb, 0x3b, 0x92,
-	0x42, 0x64, 0xf1, 0x2f, 0x52, 0x16, 0xff, 0x0e, 0xc1, 0xd9, 0xb6, 0xb9, 0x05, 0x45, 0x72, 0x69,
-	0x87, 0xc4, 0x92, 0xa2, 0x92, 0x54, 0x62, 0x3b, 0xe5, 0xaf, 0xbb, 0x9d, 0x3e, 0x86, 0xad, 0x4e,
-	0x40, 0xcc, 0x90, 0x48, 0x57, 0x1e, 0xd9, 0xff, 0x1d, 0x28, 0x9b, 0xe3, 0xb1, 0x37, 0x9c, 0xaa,
-	0xb5, 0xc4, 0xe9, 0x7d, 0x4b, 0xff, 0x56, 0x81, 0x9b, 0x33, 0x73, 0xa4, 0xa4, 0x4f, 0xa1, 0x6e,
-	0x53, 0x6f, 0xcc, 0xff, 0x84, 0x91, 0xb8, 0xc5, 0xfd, 0x78, 0xb9, 0xe3, 0x64, 0x3f, 0xc2, 0xe0,
-	0x97, 0xba, 0x75, 0x3b, 0x49, 0x72, 0xab, 0xe2, 0x8b, 0x5b, 0x72, 0x37, 0x47, 0xa4, 0xfe, 0x8f,
-	0x0a, 0xdc, 0x94, 0xa7, 0x78, 0xe6, 0x3f, 0xb3, 0x80, 0xe5, 0xdc, 0xbb, 0x66, 0x59, 0x6f, 0xc0,
-	0xad, 0x59, 0xbe, 0xa4, 0x5f, 0xff, 0xbf, 0x3c, 0xa0, 0xf9, 0x1b, 0x24, 0xfa, 0x3e, 0xd4, 0x28,
-	0x71, 0x2d, 0x43, 0x9c, 0x09, 0xe2, 0xb8, 0x2a, 0xe3, 0x2a, 0x6b, 0x13, 0x87, 0x03, 0x65, 0x6e,
-	0x8e, 0x5c, 0x4a, 0x6e, 0xcb, 0x98, 0x7f, 0xa3, 0x33, 0xa8, 0xbd, 0xa4, 0x46, 0xbc, 0x36, 0x37,
-	0x9a, 0x7a, 0x66, 0xd7, 0x35, 0xcf, 0x47, 0xeb, 0x71, 0x3f, 0xfe, 0x5f, 0xb8, 0xfa, 0x92, 0xc6,
-	0x04, 0xfa, 0xa5, 0x02, 0xb7, 0xa3, 0xd0, 0x61, 0x2a, 0x3e, 0xc7, 0xb3, 0x08, 0x6d, 0xe4, 0xef,
-	0xaa, 0xdb, 0xf5, 0x9d, 0xe3, 0x6b, 0xc8, 0x6f, 0xae, 0xf1, 0xd0, 0xb3, 0x08, 0xbe, 0xe9, 0x2e,
-	0x68, 0xa5, 0xa8, 0x05, 0x37, 0x9c, 0x09, 0x0d, 0x0d, 0x61, 0x05, 0x86, 0x1c, 0xd4, 0x28, 0x70,
-	0xb9, 0x6c, 0xb2, 0xae, 0x94, 0xad, 0xa2, 0x73, 0x58, 0x77, 0xbc, 0x89, 0x1b, 0x1a, 0x43, 0x7e,
-	0xc7, 0xa1, 0x8d, 0xe2, 0x52, 0x97, 0xdf, 0x05, 0x52, 0x3a, 0x64, 0x70, 0xe2, 0xc6, 0x44, 0x71,
-	0xcd, 0x49, 0x50, 0x4c, 0x91, 0x01, 0x71, 0xbc, 0x90, 0x18, 0xcc, 0x27, 0xd2, 0x46, 0x49, 0x28,
-	0x52, 0xb4, 0xb1, 0xed, 0x4f, 0xf5, 0x16, 0x54, 0x13, 0x62, 0x46, 0x65, 0xc8, 0xf7, 0x8e, 0x7a,
-	0x5d, 0x6d, 0x0d, 0x01, 0x14, 0x3b, 0x7b, 0xf8, 0xe8, 0x68, 0x20, 0x6e, 0x06, 0xfb, 0x87, 0xed,
-	0x27, 0x5d, 0x2d, 0xa7, 0x77, 0xa1, 0x96, 0x5c, 0x10, 0x21, 0xa8, 0x9f, 0xf4, 0x9e, 0xf6, 0x8e,
-	0x9e, 0xf5, 0x8c, 0xc3, 0xa3, 0x93, 0xde, 0x80, 0xdd, 0x29, 0xea, 0x00, 0xed, 0xde, 0xf3, 0x29,
-	0xbd, 0x0e, 0x95, 0xde, 0x51, 0x44, 0x2a, 0xcd, 0x9c, 0xa6, 0xe8, 0xff, 0xae, 0xc2, 0xd6, 0x22,
-	0xd9, 0x23, 0x0b, 0xf2, 0x4c, 0x8f, 0xf2, 0x56, 0xf7, 0xee, 0xd5, 0xc8, 0xd1, 0x99, 0xf9, 0xfa,
-	0xa6, 0x74, 0xe3, 0x15, 0xcc, 0xbf, 0x91, 0x01, 0xc5, 0xb1, 0x79, 0x4a, 0xc6, 0xb4, 0xa1, 0xf2,
-	0xbc, 0xc7, 0x93, 0xeb, 0xac, 0x7d, 0xc0, 0x91, 0x44, 0xd2, 0x43, 0xc2, 0xa2, 0x01, 0x54, 0xcf,
-	0x3c, 0x1a, 0x52, 0x21, 0x3a, 0xe9, 0x3b, 0x77, 0x32, 0xae, 0xb2, 0x37, 0x9d, 0x89, 0x93, 0x30,
-	0xcd, 0x07, 0x50, 0x4d, 0x2c, 0xb6, 0x20, 0x67, 0xb1, 0x95, 0xcc, 0x59, 0x54, 0x92, 0x09, 0x88,
-	0x47, 0xf3, 0x3a, 0x60, 0x32, 0x62, 0x46, 0xb0, 0x77, 0xd4, 0x1f, 0x88, 0xdb, 0xe1, 0x13, 0x7c,
-	0x74, 0x72, 0xac, 0x29, 0xac, 0x71, 0xd0, 0xee, 0x3f, 0xd5, 0x72, 0xb1, 0x8d, 0xa8, 0x7a, 0x07,
-	0xaa, 0x09, 0xbe, 0x50, 0x13, 0xca, 0x8c, 0x33, 0xd7, 0x74, 0x88, 0x64, 0x20, 0xa6, 0x99, 0xdf,
-	0x34, 0x2d, 0x2b, 0x20, 0x94, 0x4a, 0x3e, 0x22, 0x52, 0x7f, 0x01, 0x95, 0xdd, 0x5e, 0x5f, 0x42,
-	0x34, 0xa0, 0x44, 0x49, 0xc0, 0xfe, 0x37, 0xcf, 0x3e, 0x55, 0x70, 0x44, 0x32, 0x70, 0x4a, 0xcc,
-	0x60, 0x78, 0x46, 0xa8, 0x3c, 0xcf, 0x63, 0x9a, 0xcd, 0xf2, 0x78, 0x16, 0x47, 0xe8, 0xae, 0x82,
-	0x23, 0x52, 0xff, 0x9f, 0x12, 0xc0, 0x34, 0xa3, 0x80, 0xea, 0x90, 0x8b, 0x7d, 0x70, 0xce, 0xb6,
-	0x98, 0x1d, 0x70, 0x6e, 0xa5, 0x1d, 0x70, 0x4e, 0x77, 0xe0, 0xa6, 0x43, 0x47, 0xbe, 0x39, 0x3c,
-	0x37, 0x64, 0x22, 0x40, 0x6c, 0x55, 0xee, 0xcf, 0x6a, 0xf8, 0x86, 0xec, 0x94, 0x3b, 0x51, 0xe0,
-	0x1e, 0x80, 0x4a, 0xdc, 0x0b, 0xee, 0x7b, 0xaa, 0x3b, 0x9f, 0x2d, 0x9d, 0xe9, 0x68, 0x75, 0xdd,
-	0x0b, 0x61, 0x2b, 0x0c, 0x06, 0x19, 0x00, 0x16, 0xb9, 0xb0, 0x87, 0xc4, 0x60, 0xa0, 0x05, 0x0e,
-	0xfa, 0xc5, 0xf2, 0xa0, 0xbb, 0x1c, 0x23, 0x86, 0xae, 0x58, 0x11, 0x8d, 0x7a, 0x50, 0x09, 0x08,
-	0xf5, 0x26, 0xc1, 0x90, 0x08, 0x07, 0x94, 0xfd, 0x32, 0x82, 0xa3, 0x79, 0x78, 0x0a, 0x81, 0x76,
-	0xa1, 0xc8, 0xfd, 0x0e, 0xf3, 0x30, 0xea, 0x77, 0xa6, 0x4d, 0xd3, 0x60, 0xdc, 0x93, 0x60, 0x39,
-	0x17, 0x3d, 0x81, 0x92, 0x60, 0x91, 0x36, 0xca, 0x1c, 0xe6, 0xc3, 0xac, 0x4e, 0x91, 0xcf, 0xc2,
-	0xd1, 0x6c, 0xa6, 0xd5, 0x09, 0x25, 0x41, 0xa3, 0x22, 0xb4, 0xca, 0xbe, 0xd1, 0xf7, 0xa0, 0x22,
-	0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda, 0x01, 0x7a, 0x0f, 0xaa, 0x22,
-	0x9e, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc, 0x37, 0x88, 0x01, 0x24, 0x08,
-	0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0, 0xc1, 0xa3, 0xd0, 0x51, 0xe0,
-	0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f, 0xb0, 0xd6, 0x1e, 0x33, 0xae,
-	0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e, 0x79, 0xa7, 0x51, 0x57, 0x1c,
-	0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7, 0xa3, 0x05, 0xed, 0xfa, 0xd1,
-	0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6, 0xe6, 0x52, 0xc6, 0x11, 0xef,
-	0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8, 0xa5, 0xe6, 0x43, 0xa8, 0xa7,
-	0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a, 0x5c, 0xb8, 0xc1, 0xa5, 0xc8,
-	0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff, 0x6a, 0x47, 0x08, 0xf2, 0x16,
-	0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63, 0xdb, 0x9d, 0x5c, 0x26, 0xd6,
-	0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae, 0x51, 0x1f, 0xa7, 0x68, 0xb4,
-	0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c, 0x7b, 0x41, 0x78, 0x68, 0xfa,
-	0x3e, 0xbb, 0x98, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc, 0xc7, 0x50, 0x0f, 0xd4, 0xa1,
-	0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca, 0x3f, 0x03, 0x42, 0xcf, 0xa0,
-	0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79, 0xc8, 0x67, 0x4f, 0x51, 0x25,
-	0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0x75, 0x14, 0x41, 0xe2, 0x18, 0x47,
-	0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe, 0xc4, 0xe0, 0xa9, 0x7d, 0x61,
-	0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0, 0xf1, 0x26, 0x7e, 0x99, 0xf7,
-	0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1, 0xf0, 0x14, 0xe9, 0xb0, 0x1e,
-	0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3, 0xf2, 0xf0, 0x54, 0xff, 0x55,
-	0x0e, 0x36, 0x66, 0x58, 0x66, 0xd7, 0x33, 0xe1, 0xf1, 0xa2, 0x8b, 0xaf, 0xa0, 0x98, 0xfb, 0x1b,
-	0xda, 0x56, 0x94, 0x32, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0x3a, 0x33, 0x67, 0xfb, 0x6c, 0xfb,
-	0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf, 0xa1, 0x1e, 0x10, 0x7e, 0xe0,
-	0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6, 0x86, 0xd7, 0x23, 0x24, 0x46,
-	0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44, 0x2e, 0xae, 0x8c, 0x5c, 0x93,
-	0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c, 0xdc, 0x92, 0x32, 0x11, 0x44,
-	0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17, 0xcb, 0x4c, 0x65, 0xf2, 0x0c,
-	0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16, 0xea, 0x18, 0xb6, 0xcf, 0x25,
-	0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f, 0x6f, 0xe9, 0xc8, 0x8e, 0x7c,
-	0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85, 0x75, 0x7f, 0x33, 0xf1, 0x42,
-	0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0, 0x3a, 0x63, 0x83, 0xe8, 0x03,
-	0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48, 0x84, 0x8e, 0x55, 0xac, 0x89,
-	0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e, 0x41, 0x87, 0x5e, 0x40, 0x0c,
-	0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67, 0x6d, 0x6d, 0xeb, 0x15, 0x3b,
-	0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8, 0x60, 0x10, 0x4d, 0x1d, 0x7f,
-	0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4, 0xad, 0xc9, 0x21, 0xbc, 0x0d,
-	0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e, 0xb3, 0xf3, 0x5d, 0xd9, 0x56,
-	0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a, 0x43, 0x1c, 0xaa, 0xff, 0x0c,
-	0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65, 0x68, 0xc9, 0x1a, 0xf8, 0xd9,
-	0xfb, 0x3d, 0xa8, 0x70, 0xd9, 0x27, 0x22, 0x7a, 0x1e, 0x77, 0xf2, 0xce, 0x26, 0x94, 0x03, 0x62,
-	0x5a, 0x9e, 0x3b, 0x8e, 0x12, 0x3e, 0x31, 0xad, 0x7f, 0x03, 0x45, 0x71, 0xce, 0x5c, 0x03, 0xff,
-	0x43, 0x40, 0xe2, 0x7f, 0x33, 0x7d, 0x3a, 0x36, 0xa5, 0x32, 0x0a, 0xe5, 0xaf, 0x8c, 0xa2, 0xe7,
-	0x78, 0xda, 0xa1, 0xff, 0xa7, 0x22, 0xe2, 0x51, 0xf1, 0xfe, 0xc3, 0x02, 0x57, 0x66, 0xe4, 0xec,
-	0xb6, 0x2c, 0x12, 0x4d, 0x11, 0x89, 0xf6, 0xa1, 0x28, 0xc3, 0xce, 0xdc, 0xaa, 0xcf, 0x67, 0x12,
-	0x20, 0x4a, 0x3b, 0x13, 0x79, 0x21, 0x5f, 0x36, 0xed, 0x4c, 0x44, 0xda, 0x99, 0xb0, 0xdb, 0xa4,
-	0x0c, 0x88, 0x05, 0x5c, 0x9e, 0xc7, 0xc3, 0x55, 0x2b, 0xce, 0xed, 0x13, 0xfd, 0xbf, 0x95, 0xd8,
-	0x4d, 0x45, 0x39, 0x78, 0xf4, 0x35, 0x94, 0xd9, 0x8e, 0x37, 0x1c, 0xd3, 0x97, 0x2f, 0xca, 0x9d,
-	0xd5, 0xd2, 0xfb, 0xd1, 0x21, 0x26, 0xc2, 0xd9, 0x92, 0x2f, 0x28, 0xe6, 0xee, 0xd8, 0x55, 0x22,
-	0x72, 0x77, 0xec, 0x1b, 0xbd, 0x0f, 0x75, 0x73, 0x12, 0x7a, 0x86, 0x69, 0x5d, 0x90, 0x20, 0xb4,
-	0x29, 0x91, 0xba, 0x5f, 0x67, 0xad, 0xed, 0xa8, 0xb1, 0xf9, 0x19, 0xd4, 0x92, 0x98, 0x6f, 0x0b,
-	0x33, 0x0a, 0xc9, 0x30, 0xe3, 0x4f, 0x01, 0xa6, 0xf9, 0x2c, 0x66, 0x23, 0xe4, 0xd2, 0x66, 0xb7,
-	0x7a, 0x79, 0x77, 0x2d, 0xe0, 0x32, 0x6b, 0xe8, 0xb0, 0xfb, 0x54, 0x3a, 0xd9, 0x5e, 0x88, 0x92,
-	0xed, 0x6c, 0x33, 0xb3, 0xfd, 0x77, 0x6e, 0x8f, 0xc7, 0x71, 0x8e, 0xad, 0xe2, 0x79, 0xce, 0x53,
-	0xde, 0xa0, 0xff, 0x36, 0x27, 0x6c, 0x45, 0x3c, 0x9b, 0x64, 0xba, 0xbb, 0xbc, 0x2b, 0x55, 0x3f,
-	0x00, 0xa0, 0xa1, 0x19, 0xb0, 0x98, 0xc9, 0x8c, 0xb2, 0x7c, 0xcd, 0xb9, 0x6c, 0xfd, 0x20, 0xaa,
-	0xe3, 0xc0, 0x15, 0x39, 0xba, 0x1d, 0xa2, 0xcf, 0xa1, 0x36, 0xf4, 0x1c, 0x7f, 0x4c, 0xe4, 0xe4,
-	0xc2, 0x5b, 0x27, 0x57, 0xe3, 0xf1, 0xed, 0x30, 0x91, 0x5b, 0x2c, 0x5e, 0x37, 0xb7, 0xf8, 0x1b,
-	0x45, 0xbc, 0xfe, 0x24, 0x1f, 0x9f, 0xd0, 0x68, 0x41, 0x85, 0xc3, 0x93, 0x15, 0x5f, 0xb2, 0xbe,
-	0xab, 0xbc, 0xa1, 0xf9, 0x79, 0x96, 0x7a, 0x82, 0x37, 0x47, 0xb1, 0xff, 0xa6, 0x42, 0x25, 0x7e,
-	0xf8, 0x99, 0xd3, 0xfd, 0xa7, 0x50, 0x89, 0x8b, 0x68, 0xa4, 0x83, 0xf8, 0x4e, 0xf5, 0xc4, 0x83,
-	0xd1, 0x4b, 0x40, 0xe6, 0x68, 0x14, 0x47, 0xa7, 0xc6, 0x84, 0x9a, 0xa3, 0xe8, 0xd9, 0xed, 0xd3,
-	0x25, 0xe4, 0x10, 0x1d, 0x67, 0x27, 0x6c, 0x3e, 0xd6, 0xcc, 0xd1, 0x28, 0xd5, 0x82, 0xfe, 0x0c,
-	0x6e, 0xa6, 0xd7, 0x30, 0x4e, 0xaf, 0x0c, 0xdf, 0xb6, 0xe4, 0x1d, 0x79, 0x6f, 0xd9, 0xb7, 0xaf,
-	0x56, 0x0a, 0xfe, 0xcb, 0xab, 0x63, 0xdb, 0x12, 0x32, 0x47, 0xc1, 0x5c, 0x47, 0xf3, 0x2f, 0xe0,
-	0xf6, 0x1b, 0x86, 0x2f, 0xd0, 0x41, 0x2f, 0x5d, 0xd3, 0xb1, 0xba, 0x10, 0x12, 0xda, 0xfb, 0xb5,
-	0x22, 0x9e, 0xe8, 0xd2, 0x32, 0x69, 0x27, 0xc3, 0xea, 0x7b, 0x19, 0xd7, 0xe9, 0x1c, 0x9f, 0x08,
-	0x78, 0x1e, 0x49, 0x7f, 0x35, 0x13, 0x49, 0x67, 0x8d, 0x9f, 0x44, 0x40, 0x2a, 0x80, 0x24, 0x82,
-	0xfe, 0x2f, 0x2a, 0x94, 0x23, 0x74, 0x7e, 0xc3, 0xbd, 0xa2, 0x21, 0x71, 0x8c, 0x38, 0xfd, 0xa6,
-	0x60, 0x10, 0x4d, 0x3c, 0x29, 0xf4, 0x3d, 0xa8, 0xb0, 0x8b, 0xb4, 0xe8, 0xce, 0xf1, 0xee, 0x32,
-	0x6b, 0xe0, 0x9d, 0xef, 0x41, 0x35, 0xf4, 0x42, 0x73, 0x6c, 0x84, 0xfc, 0x78, 0x57, 0xc5, 0x6c,
-	0xde, 0xc4, 0x0f, 0x77, 0xf4, 0x43, 0xd8, 0x0c, 0xcf, 0x02, 0x2f, 0x0c, 0xc7, 0x2c, 0xb4, 0xe4,
-	0x81, 0x8e, 0x88, 0x4b, 0xf2, 0x58, 0x8b, 0x3b, 0x44, 0x00, 0x44, 0x99, 0xf7, 0x9e, 0x0e, 0x66,
-	0xa6, 0xcb, 0x9d, 0x48, 0x1e, 0xaf, 0xc7, 0xad, 0xcc, 0xb4, 0xd9, 0xe1, 0xe9, 0x8b, 0x00, 0x82,
-	0xfb, 0x0a, 0x05, 0x47, 0x24, 0x32, 0x60, 0xc3, 0x21, 0x26, 0x9d, 0x04, 0xc4, 0x32, 0x5e, 0xda,
-	0x64, 0x6c, 0x89, 0xc4, 0x44, 0x3d, 0xf3, 0xed, 0x20, 0x12, 0x4b, 0xeb, 0x31, 0x9f, 0x8d, 0xeb,
-	0x11, 0x9c, 0xa0, 0x59, 0xe4, 0x20, 0xbe, 0xd0, 0x06, 0x54, 0xfb, 0xcf, 0xfb, 0x83, 0xee, 0xa1,
-	0x71, 0x78, 0xb4, 0xdb, 0x95, 0x65, 0x3b, 0xfd, 0x2e, 0x16, 0xa4, 0xc2, 0xfa, 0x07, 0x47, 0x83,
-	0xf6, 0x81, 0x31, 0xd8, 0xef, 0x3c, 0xed, 0x6b, 0x39, 0x74, 0x13, 0x36, 0x07, 0x7b, 0xf8, 0x68,
-	0x30, 0x38, 0xe8, 0xee, 0x1a, 0xc7, 0x5d, 0xbc, 0x7f, 0xb4, 0xdb, 0xd7, 0x54, 0x84, 0xa0, 0x3e,
-	0x6d, 0x1e, 0xec, 0x1f, 0x76, 0xb5, 0x3c, 0xaa, 0x42, 0xe9, 0xb8, 0x8b, 0x3b, 0xdd, 0xde, 0x40,
-	0x2b, 0xe8, 0xbf, 0x52, 0xa1, 0x9a, 0xd0, 0x22, 0x33, 0xe4, 0x80, 0x8a, 0x6b, 0x48, 0x1e, 0xb3,
-	0x4f, 0xfe, 0xcc, 0x68, 0x0e, 0xcf, 0x84, 0x76, 0xf2, 0x58, 0x10, 0xfc, 0xea, 0x61, 0x5e, 0x26,
-	0xf6, 0x79, 0x1e, 0x97, 0x1d, 0xf3, 0x52, 0x80, 0x7c, 0x1f, 0x6a, 0xe7, 0x24, 0x70, 0xc9, 0x58,
-	0xf6, 0x0b, 0x8d, 0x54, 0x45, 0x9b, 0x18, 0xb2, 0x0d, 0x9a, 0x1c, 0x32, 0x85, 0x11, 0xea, 0xa8,
-	0x8b, 0xf6, 0xc3, 0x08, 0x6c, 0x0b, 0x0a, 0xa2, 0xbb, 0x24, 0xd6, 0xe7, 0x04, 0x3b, 0xa6, 0xe8,
-	0x6b, 0xd3, 0xe7, 0x21, 0x5f, 0x1e, 0xf3, 0x6f, 0x74, 0x3a, 0xaf, 0x9f, 0x22, 0xd7, 0xcf, 0x83,
-	0xe5, 0xcd, 0xf9, 0x4d, 0x2a, 0x3a, 0x8b, 0x55, 0x54, 0x02, 0x15, 0x47, 0xb5, 0x2e, 0x9d, 0x76,
-	0x67, 0x8f, 0xa9, 0x65, 0x1d, 0x2a, 0x87, 0xed, 0x9f, 0x1a, 0x27, 0x7d, 0x9e, 0xd5, 0x46, 0x1a,
-	0xd4, 0x9e, 0x76, 0x71, 0xaf, 0x7b, 0x20, 0x5b, 0x54, 0xb4, 0x05, 0x9a, 0x6c, 0x99, 0x8e, 0xcb,
-	0x33, 0x04, 0xf1, 0x59, 0x40, 0x65, 0xc8, 0xf7, 0x9f, 0xb5, 0x8f, 0xb5, 0xa2, 0xfe, 0x5f, 0x39,
-	0xd8, 0x10, 0xc7, 0x42, 0xfc, 0x2a, 0xff, 0xe6, 0x57, 0xc9, 0x64, 0x96, 0x27, 0x97, 0xce, 0xf2,
-	0x44, 0x41, 0x28, 0x3f, 0xd5, 0xd5, 0x69, 0x10, 0xca, 0xb3, 0x43, 0x29, 0x8f, 0x9f, 0x5f, 0xc6,
-	0xe3, 0x37, 0xa0, 0xe4, 0x10, 0x1a, 0xeb, 0xad, 0x82, 0x23, 0x12, 0xd9, 0x50, 0x35, 0x5d, 0xd7,
-	0x0b, 0x4d, 0x91, 0x3a, 0x2d, 0x2e, 0x75, 0x18, 0xce, 0xfc, 0xe3, 0x56, 0x7b, 0x8a, 0x24, 0x1c,
-	0x73, 0x12, 0xbb, 0xf9, 0x13, 0xd0, 0x66, 0x07, 0x2c, 0x73, 0x1c, 0xfe, 0xe0, 0xe3, 0xe9, 0x69,
-	0x48, 0xd8, 0xbe, 0x90, 0x6f, 0x0e, 0xda, 0x1a, 0x23, 0xf0, 0x49, 0xaf, 0xb7, 0xdf, 0x7b, 0xa2,
-	0x29, 0x08, 0xa0, 0xd8, 0xfd, 0xe9, 0xfe, 0xa0, 0xbb, 0xab, 0xe5, 0x76, 0x7e, 0xbd, 0x09, 0x45,
-	0xc1, 0x24, 0xfa, 0x56, 0x46, 0x02, 0xc9, 0x8a, 0x4f, 0xf4, 0x93, 0xa5, 0x23, 0xea, 0x54, 0x15,
-	0x69, 0xf3, 0xd1, 0xca, 0xf3, 0xe5, 0xeb, 0xdb, 0x1a, 0xfa, 0x1b, 0x05, 0x6a, 0xa9, 0x97, 0xb7,
-	0xac, 0xa9, 0xe3, 0x05, 0x05, 0xa6, 0xcd, 0x1f, 0xaf, 0x34, 0x37, 0xe6, 0xe5, 0x97, 0x0a, 0x54,
-	0x13, 0xa5, 0x95, 0xe8, 0xc1, 0x2a, 0xe5, 0x98, 0x82, 0x93, 0xcf, 0x56, 0xaf, 0xe4, 0xd4, 0xd7,
-	0x3e, 0x52, 0xd0, 0x5f, 0x2b, 0x50, 0x4d, 0x14, 0x19, 0x66, 0x66, 0x65, 0xbe, 0x24, 0x32, 0x33,
-	0x2b, 0x8b, 0x6a, 0x1a, 0xd7, 0xd0, 0x5f, 0x2a, 0x50, 0x89, 0x0b, 0x06, 0xd1, 0xfd, 0xe5, 0x4b,
-	0x0c, 0x05, 0x13, 0x9f, 0xae, 0x5a, 0x9b, 0xa8, 0xaf, 0xa1, 0x3f, 0x87, 0x72, 0x54, 0x5d, 0x87,
-	0xb2, 0x9e, 0x5e, 0x33, 0xa5, 0x7b, 0xcd, 0xfb, 0x4b, 0xcf, 0x4b, 0x2e, 0x1f, 0x95, 0xbc, 0x65,
-	0x5e, 0x7e, 0xa6, 0x38, 0xaf, 0x79, 0x7f, 0xe9, 0x79, 0xf1, 0xf2, 0xcc, 0x12, 0x12, 0x95, 0x71,
-	0x99, 0x2d, 0x61, 0xbe, 0x24, 0x2f, 0xb3, 0x25, 0x2c, 0x2a, 0xc4, 0x13, 0x8c, 0x24, 0x6a, 0xeb,
-	0x32, 0x33, 0x32, 0x5f, 0xbf, 0x97, 0x99, 0x91, 0x05, 0xa5, 0x7c, 0xfa, 0x1a, 0xfa, 0x85, 0x92,
-	0xbc, 0x17, 0xdc, 0x5f, 0xba, 0x84, 0x6c, 0x49, 0x93, 0x9c, 0x2b, 0x62, 0xe3, 0x1b, 0xf4, 0x17,
-	0x32, 0x8b, 0x21, 0x2a, 0xd0, 0xd0, 0x32, 0x60, 0xa9, 0xa2, 0xb5, 0xe6, 0x27, 0xab, 0x1d, 0x36,
-	0x9c, 0x89, 0xbf, 0x52, 0x00, 0xa6, 0xb5, 0x6a, 0x99, 0x99, 0x98, 0x2b, 0x92, 0x6b, 0x3e, 0x58,
-	0x61, 0x66, 0x72, 0x83, 0x44, 0xb5, 0x34, 0x99, 0x37, 0xc8, 0x4c, 0x2d, 0x5d, 0xe6, 0x0d, 0x32,
-	0x5b, 0x07, 0xa7, 0xaf, 0xa1, 0x7f, 0x52, 0x60, 0x73, 0xae, 0x96, 0x07, 0x3d, 0xba, 0x66, 0x39,
-	0x57, 0xf3, 0x8b, 0xd5, 0x01, 0x22, 0xd6, 0xb6, 0x95, 0x8f, 0x14, 0xf4, 0xb7, 0x0a, 0xac, 0xa7,
-	0xeb, 0x1f, 0x32, 0x9f, 0x52, 0x0b, 0xaa, 0x82, 0x9a, 0x0f, 0x57, 0x9b, 0x1c, 0x4b, 0xeb, 0xef,
-	0x15, 0xa8, 0xa7, 0x4b, 0x61, 0xd0, 0xc3, 0xe5, 0xdc, 0xc2, 0x0c, 0x43, 0x9f, 0xaf, 0x38, 0x3b,
-	0xe2, 0xe8, 0xcb, 0xd2, 0x1f, 0x17, 0x44, 0xf4, 0x56, 0xe4, 0x3f, 0x3f, 0xfa, 0xff, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x2c, 0x87, 0xc3, 0x62, 0x98, 0x33, 0x00, 0x00,
+	// 3859 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0x4f, 0x6f, 0x1b, 0x49,
+	0x76, 0x77, 0xf3, 0x9f, 0xc8, 0x47, 0x89, 0x6a, 0x95, 0x65, 0x0f, 0xcd, 0xc9, 0xee, 0x78, 0x3b,
+	0x98, 0x40, 0xd8, 0x9d, 0xa1, 0x67, 0xb4, 0xc8, 0x78, 0xec, 0xf5, 0xac, 0x87, 0xa6, 0x68, 0x4b,
+	0x63, 0x89, 0x52, 0x8a, 0x14, 0xbc, 0x8e, 0xb3, 0xd3, 0x68, 0x75, 0x97, 0xc9, 0xb6, 0xc8, 0xee,
+	0x9e, 0xae, 0xa2, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x20, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b,
+	0x09, 0x72, 0x08, 0x92, 0x53, 0x0e, 0xb9, 0x06, 0x1b, 0xec, 0x69, 0x0f, 0xf9, 0x12, 0xb9, 0xe4,
+	0x96, 0x63, 0xf2, 0x0d, 0x82, 0xfa, 0xd3, 0xcd, 0x6e, 0x91, 0x5e, 0x37, 0x29, 0x9f, 0xd8, 0xef,
+	0x55, 0xd5, 0xaf, 0x1e, 0x5f, 0xbd, 0x7a, 0xf5, 0xea, 0xd5, 0x03, 0x23, 0x18, 0x4d, 0x06, 0xae,
+	0x47, 0xef, 0x38, 0xa1, 0x7b, 0x46, 0x42, 0x7a, 0x27, 0x08, 0x7d, 0xe6, 0x2b, 0xaa, 0x29, 0x08,
+	0xf4, 0xe1, 0xd0, 0xa2, 0x43, 0xd7, 0xf6, 0xc3, 0xa0, 0xe9, 0xf9, 0x63, 0xcb, 0x69, 0xaa, 0x31,
+	0x4d, 0x35, 0x46, 0x76, 0x6b, 0x7c, 0x77, 0xe0, 0xfb, 0x83, 0x11, 0x91, 0x08, 0x27, 0x93, 0x97,
+	0x77, 0x9c, 0x49, 0x68, 0x31, 0xd7, 0xf7, 0x54, 0xfb, 0x07, 0x97, 0xdb, 0x99, 0x3b, 0x26, 0x94,
+	0x59, 0xe3, 0x40, 0x75, 0xf8, 0x30, 0x92, 0x85, 0x0e, 0xad, 0x90, 0x38, 0x77, 0x86, 0xf6, 0x88,
+	0x06, 0xc4, 0xe6, 0xbf, 0x26, 0xff, 0x50, 0xdd, 0x3e, 0xba, 0xd4, 0x8d, 0xb2, 0x70, 0x62, 0xb3,
+	0x48, 0x72, 0x8b, 0xb1, 0xd0, 0x3d, 0x99, 0x30, 0x22, 0x7b, 0x1b, 0xb7, 0xe0, 0xbd, 0xbe, 0x45,
+	0x4f, 0xdb, 0xbe, 0xf7, 0xd2, 0x1d, 0xf4, 0xec, 0x21, 0x19, 0x5b, 0x98, 0x7c, 0x33, 0x21, 0x94,
+	0x19, 0x7f, 0x04, 0xf5, 0xd9, 0x26, 0x1a, 0xf8, 0x1e, 0x25, 0xe8, 0x4b, 0x28, 0xf0, 0x29, 0xeb,
+	0xda, 0x6d, 0x6d, 0xab, 0xba, 0xfd, 0x51, 0xf3, 0x4d, 0x2a, 0x90, 0x32, 0x34, 0x95, 0xa8, 0xcd,
+	0x5e, 0x40, 0x6c, 0x2c, 0x46, 0x1a, 0x37, 0xe0, 0x7a, 0xdb, 0x0a, 0xac, 0x13, 0x77, 0xe4, 0x32,
+	0x97, 0xd0, 0x68, 0xd2, 0x09, 0x6c, 0xa6, 0xd9, 0x6a, 0xc2, 0x9f, 0xc2, 0xaa, 0x9d, 0xe0, 0xab,
+	0x89, 0xef, 0x35, 0x33, 0xe9, 0xbe, 0xb9, 0x23, 0xa8, 0x14, 0x70, 0x0a, 0xce, 0xd8, 0x04, 0xf4,
+	0xd8, 0xf5, 0x06, 0x24, 0x0c, 0x42, 0xd7, 0x63, 0x91, 0x30, 0xbf, 0xce, 0xc3, 0xf5, 0x14, 0x5b,
+	0x09, 0xf3, 0x0a, 0x20, 0xd6, 0x23, 0x17, 0x25, 0xbf, 0x55, 0xdd, 0xfe, 0x2a, 0xa3, 0x28, 0x73,
+	0xf0, 0x9a, 0xad, 0x18, 0xac, 0xe3, 0xb1, 0xf0, 0x02, 0x27, 0xd0, 0xd1, 0xd7, 0x50, 0x1a, 0x12,
+	0x6b, 0xc4, 0x86, 0xf5, 0xdc, 0x6d, 0x6d, 0xab, 0xb6, 0xfd, 0xf8, 0x0a, 0xf3, 0xec, 0x0a, 0xa0,
+	0x1e, 0xb3, 0x18, 0xc1, 0x0a, 0x15, 0x7d, 0x0c, 0x48, 0x7e, 0x99, 0x0e, 0xa1, 0x76, 0xe8, 0x06,
+	0xdc, 0x24, 0xeb, 0xf9, 0xdb, 0xda, 0x56, 0x05, 0x6f, 0xc8, 0x96, 0x9d, 0x69, 0x43, 0x23, 0x80,
+	0xf5, 0x4b, 0xd2, 0x22, 0x1d, 0xf2, 0xa7, 0xe4, 0x42, 0xac, 0x48, 0x05, 0xf3, 0x4f, 0xf4, 0x04,
+	0x8a, 0x67, 0xd6, 0x68, 0x42, 0x84, 0xc8, 0xd5, 0xed, 0x4f, 0xdf, 0x66, 0x1e, 0xca, 0x44, 0xa7,
+	0x7a, 0xc0, 0x72, 0xfc, 0xfd, 0xdc, 0xe7, 0x9a, 0x71, 0x0f, 0xaa, 0x09, 0xb9, 0x51, 0x0d, 0xe0,
+	0xb8, 0xbb, 0xd3, 0xe9, 0x77, 0xda, 0xfd, 0xce, 0x8e, 0x7e, 0x0d, 0xad, 0x41, 0xe5, 0xb8, 0xbb,
+	0xdb, 0x69, 0xed, 0xf7, 0x77, 0x9f, 0xeb, 0x1a, 0xaa, 0xc2, 0x4a, 0x44, 0xe4, 0x8c, 0x73, 0x40,
+	0x98, 0xd8, 0xfe, 0x19, 0x09, 0xb9, 0x21, 0xab, 0x55, 0x45, 0xef, 0xc1, 0x0a, 0xb3, 0xe8, 0xa9,
+	0xe9, 0x3a, 0x4a, 0xe6, 0x12, 0x27, 0xf7, 0x1c, 0xb4, 0x07, 0xa5, 0xa1, 0xe5, 0x39, 0xa3, 0xb7,
+	0xcb, 0x9d, 0x56, 0x35, 0x07, 0xdf, 0x15, 0x03, 0xb1, 0x02, 0xe0, 0xd6, 0x9d, 0x9a, 0x59, 0x2e,
+	0x80, 0xf1, 0x1c, 0xf4, 0x1e, 0xb3, 0x42, 0x96, 0x14, 0xa7, 0x03, 0x05, 0x3e, 0x7f, 0x5d, 0x5b,
+	0x78, 0x4e, 0xb9, 0x33, 0xb1, 0x18, 0x6e, 0xfc, 0x5f, 0x0e, 0x36, 0x12, 0xd8, 0xca, 0x52, 0x9f,
+	0x41, 0x29, 0x24, 0x74, 0x32, 0x62, 0x02, 0xbe, 0xb6, 0xfd, 0x30, 0x23, 0xfc, 0x0c, 0x52, 0x13,
+	0x0b, 0x18, 0xac, 0xe0, 0xd0, 0x16, 0xe8, 0x72, 0x84, 0x49, 0xc2, 0xd0, 0x0f, 0xcd, 0x31, 0x1d,
+	0x08, 0xad, 0x55, 0x70, 0x4d, 0xf2, 0x3b, 0x9c, 0x7d, 0x40, 0x07, 0x09, 0xad, 0xe6, 0xaf, 0xa8,
+	0x55, 0x64, 0x81, 0xee, 0x11, 0xf6, 0xda, 0x0f, 0x4f, 0x4d, 0xae, 0xda, 0xd0, 0x75, 0x48, 0xbd,
+	0x20, 0x40, 0x3f, 0xcb, 0x08, 0xda, 0x95, 0xc3, 0x0f, 0xd5, 0x68, 0xbc, 0xee, 0xa5, 0x19, 0xc6,
+	0x0f, 0xa0, 0x24, 0xff, 0x29, 0xb7, 0xa4, 0xde, 0x71, 0xbb, 0xdd, 0xe9, 0xf5, 0xf4, 0x6b, 0xa8,
+	0x02, 0x45, 0xdc, 0xe9, 0x63, 0x6e, 0x61, 0x15, 0x28, 0x3e, 0x6e, 0xf5, 0x5b, 0xfb, 0x7a, 0xce,
+	0xf8, 0x3e, 0xac, 0x3f, 0xb3, 0x5c, 0x96, 0xc5, 0xb8, 0x0c, 0x1f, 0xf4, 0x69, 0x5f, 0xb5, 0x3a,
+	0x7b, 0xa9, 0xd5, 0xc9, 0xae, 0x9a, 0xce, 0xb9, 0xcb, 0x2e, 0xad, 0x87, 0x0e, 0x79, 0x12, 0x86,
+	0x6a, 0x09, 0xf8, 0xa7, 0xf1, 0x1a, 0xd6, 0x7b, 0xcc, 0x0f, 0x32, 0x59, 0xfe, 0x0f, 0x61, 0x85,
+	0x9f, 0x36, 0xfe, 0x84, 0x29, 0xd3, 0xbf, 0xd5, 0x94, 0xa7, 0x51, 0x33, 0x3a, 0x8d, 0x9a, 0x3b,
+	0xea, 0xb4, 0xc2, 0x51, 0x4f, 0x74, 0x13, 0x4a, 0xd4, 0x1d, 0x78, 0xd6, 0x48, 0x79, 0x0b, 0x45,
+	0x19, 0x08, 0xf4, 0xe9, 0xc4, 0xca, 0xf0, 0xdb, 0x80, 0x76, 0x08, 0x65, 0xa1, 0x7f, 0x91, 0x49,
+	0x9e, 0x4d, 0x28, 0xbe, 0xf4, 0x43, 0x5b, 0x6e, 0xc4, 0x32, 0x96, 0x04, 0xdf, 0x54, 0x29, 0x10,
+	0x85, 0xfd, 0x31, 0xa0, 0x3d, 0x8f, 0x9f, 0x29, 0xd9, 0x16, 0xe2, 0xef, 0x72, 0x70, 0x3d, 0xd5,
+	0x5f, 0x2d, 0xc6, 0xf2, 0xfb, 0x90, 0x3b, 0xa6, 0x09, 0x95, 0xfb, 0x10, 0x1d, 0x42, 0x49, 0xf6,
+	0x50, 0x9a, 0xbc, 0xbb, 0x00, 0x90, 0x3c, 0xa6, 0x14, 0x9c, 0x82, 0x99, 0x6b, 0xf4, 0xf9, 0x77,
+	0x6b, 0xf4, 0xaf, 0x41, 0x8f, 0xfe, 0x07, 0x7d, 0xeb, 0xda, 0x7c, 0x05, 0xd7, 0x6d, 0x7f, 0x34,
+	0x22, 0x36, 0xb7, 0x06, 0xd3, 0xf5, 0x18, 0x09, 0xcf, 0xac, 0xd1, 0xdb, 0xed, 0x06, 0x4d, 0x47,
+	0xed, 0xa9, 0x41, 0xc6, 0x0b, 0xd8, 0x48, 0x4c, 0xac, 0x16, 0xe2, 0x31, 0x14, 0x29, 0x67, 0xa8,
+	0x95, 0xf8, 0x64, 0xc1, 0x95, 0xa0, 0x58, 0x0e, 0x37, 0xae, 0x4b, 0xf0, 0xce, 0x19, 0xf1, 0xe2,
+	0xbf, 0x65, 0xec, 0xc0, 0x46, 0x4f, 0x98, 0x69, 0x26, 0x3b, 0x9c, 0x9a, 0x78, 0x2e, 0x65, 0xe2,
+	0x9b, 0x80, 0x92, 0x28, 0xca, 0x10, 0x2f, 0x60, 0xbd, 0x73, 0x4e, 0xec, 0x4c, 0xc8, 0x75, 0x58,
+	0xb1, 0xfd, 0xf1, 0xd8, 0xf2, 0x9c, 0x7a, 0xee, 0x76, 0x7e, 0xab, 0x82, 0x23, 0x32, 0xb9, 0x17,
+	0xf3, 0x59, 0xf7, 0xa2, 0xf1, 0x37, 0x1a, 0xe8, 0xd3, 0xb9, 0x95, 0x22, 0xb9, 0xf4, 0xcc, 0xe1,
+	0x40, 0x7c, 0xee, 0x55, 0xac, 0x28, 0xc5, 0x8f, 0xdc, 0x85, 0xe4, 0x93, 0x30, 0x4c, 0xb8, 0xa3,
+	0xfc, 0x15, 0xdd, 0x91, 0xb1, 0x0b, 0xbf, 0x13, 0x89, 0xd3, 0x63, 0x21, 0xb1, 0xc6, 0xae, 0x37,
+	0xd8, 0x3b, 0x3c, 0x0c, 0x88, 0x14, 0x1c, 0x21, 0x28, 0x38, 0x16, 0xb3, 0x94, 0x60, 0xe2, 0x9b,
+	0x6f, 0x7a, 0x7b, 0xe4, 0xd3, 0x78, 0xd3, 0x0b, 0xc2, 0xf8, 0xcf, 0x3c, 0xd4, 0x67, 0xa0, 0x22,
+	0xf5, 0xbe, 0x80, 0x22, 0x25, 0x6c, 0x12, 0x28, 0x53, 0xe9, 0x64, 0x16, 0x78, 0x3e, 0x5e, 0xb3,
+	0xc7, 0xc1, 0xb0, 0xc4, 0x44, 0x03, 0x28, 0x33, 0x76, 0x61, 0x52, 0xf7, 0x67, 0x51, 0x40, 0xb0,
+	0x7f, 0x55, 0xfc, 0x3e, 0x09, 0xc7, 0xae, 0x67, 0x8d, 0x7a, 0xee, 0xcf, 0x08, 0x5e, 0x61, 0xec,
+	0x82, 0x7f, 0xa0, 0xe7, 0xdc, 0xe0, 0x1d, 0xd7, 0x53, 0x6a, 0x6f, 0x2f, 0x3b, 0x4b, 0x42, 0xc1,
+	0x58, 0x22, 0x36, 0xf6, 0xa1, 0x28, 0xfe, 0xd3, 0x32, 0x86, 0xa8, 0x43, 0x9e, 0xb1, 0x0b, 0x21,
+	0x54, 0x19, 0xf3, 0xcf, 0xc6, 0x03, 0x58, 0x4d, 0xfe, 0x03, 0x6e, 0x48, 0x43, 0xe2, 0x0e, 0x86,
+	0xd2, 0xc0, 0x8a, 0x58, 0x51, 0x7c, 0x25, 0x5f, 0xbb, 0x8e, 0x0a, 0x59, 0x8b, 0x58, 0x12, 0xc6,
+	0xbf, 0xe7, 0xe0, 0xd6, 0x1c, 0xcd, 0x28, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0xa4, 0x85, 0xc8,
+	0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xf3, 0x6d, 0x73, 0x13, 0x4a, 0xe4, 0xdc, 0x65, 0xc4,
+	0x51, 0xaa, 0x52, 0x54, 0x62, 0x3b, 0x15, 0xae, 0xba, 0x9d, 0x3e, 0x85, 0xcd, 0x76, 0x48, 0x2c,
+	0x46, 0x94, 0x2b, 0x8f, 0xec, 0xff, 0x16, 0x94, 0xad, 0xd1, 0xc8, 0xb7, 0xa7, 0xcb, 0xba, 0x22,
+	0xe8, 0x3d, 0xc7, 0xf8, 0x56, 0x83, 0x1b, 0x97, 0xc6, 0x28, 0x4d, 0x9f, 0x40, 0xcd, 0xa5, 0xfe,
+	0x48, 0xfc, 0x09, 0x33, 0x71, 0x8b, 0xfb, 0xd1, 0x62, 0xc7, 0xc9, 0x5e, 0x84, 0x21, 0x2e, 0x75,
+	0x6b, 0x6e, 0x92, 0x14, 0x56, 0x25, 0x26, 0x77, 0xd4, 0x6e, 0x8e, 0x48, 0xe3, 0xef, 0x35, 0xb8,
+	0xa1, 0x4e, 0xf1, 0xcc, 0x7f, 0x66, 0x8e, 0xc8, 0xb9, 0x77, 0x2d, 0xb2, 0x51, 0x87, 0x9b, 0x97,
+	0xe5, 0x52, 0x7e, 0xfd, 0xdf, 0x8a, 0x80, 0x66, 0x6f, 0x90, 0xe8, 0x7b, 0xb0, 0x4a, 0x89, 0xe7,
+	0x98, 0xf2, 0x4c, 0x90, 0xc7, 0x55, 0x19, 0x57, 0x39, 0x4f, 0x1e, 0x0e, 0x94, 0xbb, 0x39, 0x72,
+	0xae, 0xa4, 0x2d, 0x63, 0xf1, 0x8d, 0x86, 0xb0, 0xfa, 0x92, 0x9a, 0xf1, 0xdc, 0xc2, 0x68, 0x6a,
+	0x99, 0x5d, 0xd7, 0xac, 0x1c, 0xcd, 0xc7, 0xbd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8,
+	0x17, 0x1a, 0xbc, 0x17, 0x85, 0x0e, 0x53, 0xf5, 0x8d, 0x7d, 0x87, 0xd0, 0x7a, 0xe1, 0x76, 0x7e,
+	0xab, 0xb6, 0x7d, 0x74, 0x05, 0xfd, 0xcd, 0x30, 0x0f, 0x7c, 0x87, 0xe0, 0x1b, 0xde, 0x1c, 0x2e,
+	0x45, 0x4d, 0xb8, 0x3e, 0x9e, 0x50, 0x66, 0x4a, 0x2b, 0x30, 0x55, 0xa7, 0x7a, 0x51, 0xe8, 0x65,
+	0x83, 0x37, 0xa5, 0x6c, 0x15, 0x9d, 0xc2, 0xda, 0xd8, 0x9f, 0x78, 0xcc, 0xb4, 0xc5, 0x1d, 0x87,
+	0xd6, 0x4b, 0x0b, 0x5d, 0x7e, 0xe7, 0x68, 0xe9, 0x80, 0xc3, 0xc9, 0x1b, 0x13, 0xc5, 0xab, 0xe3,
+	0x04, 0xc5, 0x17, 0x32, 0x24, 0x63, 0x9f, 0x11, 0x93, 0xfb, 0x44, 0x5a, 0x5f, 0x91, 0x0b, 0x29,
+	0x79, 0x7c, 0xfb, 0x53, 0xf4, 0x5d, 0x00, 0x7b, 0x48, 0xec, 0xd3, 0xc0, 0x77, 0x3d, 0x56, 0x2f,
+	0x8b, 0x0e, 0x09, 0x0e, 0xf7, 0x01, 0x21, 0x11, 0x27, 0x45, 0x45, 0xfa, 0x00, 0x49, 0x19, 0x4d,
+	0xa8, 0x26, 0x96, 0x07, 0x95, 0xa1, 0xd0, 0x3d, 0xec, 0x76, 0xf4, 0x6b, 0x08, 0xa0, 0xd4, 0xde,
+	0xc5, 0x87, 0x87, 0x7d, 0x79, 0xa3, 0xd8, 0x3b, 0x68, 0x3d, 0xe9, 0xe8, 0x39, 0xa3, 0x03, 0xab,
+	0x49, 0x41, 0x11, 0x82, 0xda, 0x71, 0xf7, 0x69, 0xf7, 0xf0, 0x59, 0xd7, 0x3c, 0x38, 0x3c, 0xee,
+	0xf6, 0xf9, 0x5d, 0xa4, 0x06, 0xd0, 0xea, 0x3e, 0x9f, 0xd2, 0x6b, 0x50, 0xe9, 0x1e, 0x46, 0xa4,
+	0xd6, 0xc8, 0xe9, 0x9a, 0xf1, 0x9b, 0x3c, 0x6c, 0xce, 0x5b, 0x33, 0xe4, 0x40, 0x81, 0xaf, 0xbf,
+	0xba, 0x0d, 0xbe, 0xfb, 0xe5, 0x17, 0xe8, 0xdc, 0xec, 0x03, 0x4b, 0xb9, 0xff, 0x0a, 0x16, 0xdf,
+	0xc8, 0x84, 0xd2, 0xc8, 0x3a, 0x21, 0x23, 0x5a, 0xcf, 0x8b, 0x7c, 0xc9, 0x93, 0xab, 0xcc, 0xbd,
+	0x2f, 0x90, 0x64, 0xb2, 0x44, 0xc1, 0xa2, 0x3e, 0x54, 0x87, 0x3e, 0x65, 0x54, 0xaa, 0x4e, 0xf9,
+	0xdc, 0xed, 0x8c, 0xb3, 0xec, 0x4e, 0x47, 0xe2, 0x24, 0x4c, 0xe3, 0x1e, 0x54, 0x13, 0x93, 0xcd,
+	0xc9, 0x75, 0x6c, 0x26, 0x73, 0x1d, 0x95, 0x64, 0xe2, 0xe2, 0x21, 0x6c, 0xce, 0xd3, 0x11, 0x37,
+	0x82, 0xdd, 0xc3, 0x5e, 0x5f, 0xde, 0x2a, 0x9f, 0xe0, 0xc3, 0xe3, 0x23, 0x5d, 0xe3, 0xcc, 0x7e,
+	0xab, 0xf7, 0x54, 0xcf, 0xc5, 0x36, 0x92, 0x37, 0xda, 0x50, 0x4d, 0xc8, 0x85, 0x1a, 0x50, 0xe6,
+	0x92, 0x79, 0xd6, 0x98, 0x28, 0x01, 0x62, 0x9a, 0xfb, 0x5b, 0xcb, 0x71, 0x42, 0x42, 0xa9, 0x92,
+	0x23, 0x22, 0x8d, 0x17, 0x50, 0xd9, 0xe9, 0xf6, 0x14, 0x44, 0x1d, 0x56, 0x28, 0x09, 0xf9, 0xff,
+	0x16, 0x59, 0xab, 0x0a, 0x8e, 0x48, 0x0e, 0x4e, 0x89, 0x15, 0xda, 0x43, 0x42, 0x55, 0x1c, 0x10,
+	0xd3, 0x7c, 0x94, 0x2f, 0xb2, 0x3f, 0x72, 0xed, 0x2a, 0x38, 0x22, 0x8d, 0xff, 0x5d, 0x01, 0x98,
+	0x66, 0x22, 0x50, 0x0d, 0x72, 0xb1, 0xef, 0xce, 0xb9, 0x0e, 0xb7, 0x03, 0x21, 0xad, 0xb2, 0x03,
+	0x21, 0xe9, 0x36, 0xdc, 0x18, 0xd3, 0x41, 0x60, 0xd9, 0xa7, 0xa6, 0x4a, 0x20, 0xc8, 0x2d, 0x2e,
+	0xfc, 0xe0, 0x2a, 0xbe, 0xae, 0x1a, 0xd5, 0x0e, 0x96, 0xb8, 0xfb, 0x90, 0x27, 0xde, 0x99, 0xf0,
+	0x59, 0xd5, 0xed, 0xfb, 0x0b, 0x67, 0x48, 0x9a, 0x1d, 0xef, 0x4c, 0xda, 0x0a, 0x87, 0x41, 0x26,
+	0x80, 0x43, 0xce, 0x5c, 0x9b, 0x98, 0x1c, 0xb4, 0x28, 0x40, 0xbf, 0x5c, 0x1c, 0x74, 0x47, 0x60,
+	0xc4, 0xd0, 0x15, 0x27, 0xa2, 0x51, 0x17, 0x2a, 0x21, 0xa1, 0xfe, 0x24, 0xb4, 0x89, 0x74, 0x5c,
+	0xd9, 0x2f, 0x31, 0x38, 0x1a, 0x87, 0xa7, 0x10, 0x68, 0x07, 0x4a, 0xc2, 0x5f, 0x71, 0xcf, 0x94,
+	0xff, 0xad, 0xe9, 0xd6, 0x34, 0x98, 0xf0, 0x24, 0x58, 0x8d, 0x45, 0x4f, 0x60, 0x45, 0x8a, 0x48,
+	0xeb, 0x65, 0x01, 0xf3, 0x71, 0x56, 0x67, 0x2a, 0x46, 0xe1, 0x68, 0x34, 0x5f, 0xd5, 0x09, 0x25,
+	0xa1, 0xf0, 0x74, 0x15, 0x2c, 0xbe, 0xd1, 0xfb, 0x50, 0x91, 0x67, 0xb7, 0xe3, 0x86, 0x75, 0x90,
+	0xc6, 0x29, 0x18, 0x3b, 0x6e, 0x88, 0x3e, 0x80, 0xaa, 0x8c, 0xc3, 0x4c, 0xe1, 0x15, 0xaa, 0xa2,
+	0x19, 0x24, 0xeb, 0x88, 0xfb, 0x06, 0xd9, 0x81, 0x84, 0xa1, 0xec, 0xb0, 0x1a, 0x77, 0x20, 0x61,
+	0x28, 0x3a, 0xfc, 0x1e, 0xac, 0x8b, 0xe8, 0x75, 0x10, 0xfa, 0x93, 0xc0, 0x14, 0x36, 0xb5, 0x26,
+	0x3a, 0xad, 0x71, 0xf6, 0x13, 0xce, 0xed, 0x72, 0xe3, 0xba, 0x05, 0xe5, 0x57, 0xfe, 0x89, 0xec,
+	0x50, 0x93, 0xfb, 0xe0, 0x95, 0x7f, 0x12, 0x35, 0xc5, 0xd1, 0xc5, 0x7a, 0x3a, 0xba, 0xf8, 0x06,
+	0x6e, 0xce, 0x1e, 0x93, 0x22, 0xca, 0xd0, 0xaf, 0x1e, 0x65, 0x6c, 0x7a, 0x73, 0xb8, 0xe8, 0x11,
+	0xe4, 0x1d, 0x8f, 0xd6, 0x37, 0x16, 0x32, 0x8e, 0x78, 0x1f, 0x63, 0x3e, 0xb8, 0xf1, 0x19, 0x94,
+	0x23, 0xeb, 0x5b, 0xc4, 0x2f, 0x35, 0x1e, 0x40, 0x2d, 0x6d, 0xbb, 0x0b, 0x79, 0xb5, 0x7f, 0xc9,
+	0x41, 0x25, 0xb6, 0x52, 0xe4, 0xc1, 0x75, 0xa1, 0x45, 0x8b, 0x11, 0xc7, 0x9c, 0x1a, 0xbd, 0x0c,
+	0x28, 0xbf, 0xc8, 0xf8, 0xbf, 0x5a, 0x11, 0x82, 0xba, 0xbd, 0xaa, 0x1d, 0x80, 0x62, 0xe4, 0xe9,
+	0x7c, 0x5f, 0xc3, 0xfa, 0xc8, 0xf5, 0x26, 0xe7, 0x89, 0xb9, 0x64, 0x24, 0xf8, 0xfb, 0x19, 0xe7,
+	0xda, 0xe7, 0xa3, 0xa7, 0x73, 0xd4, 0x46, 0x29, 0x1a, 0xed, 0x42, 0x31, 0xf0, 0x43, 0x16, 0x1d,
+	0x52, 0x59, 0x8f, 0x8f, 0x23, 0x3f, 0x64, 0x07, 0x56, 0x10, 0xf0, 0x0b, 0x8d, 0x04, 0x30, 0xbe,
+	0xcd, 0xc1, 0xcd, 0xf9, 0x7f, 0x0c, 0x75, 0x21, 0x6f, 0x07, 0x13, 0xa5, 0xa4, 0x07, 0x8b, 0x2a,
+	0xa9, 0x1d, 0x4c, 0xa6, 0xf2, 0x73, 0x20, 0x9e, 0xe4, 0x1d, 0x93, 0xb1, 0x1f, 0x5e, 0x28, 0x5d,
+	0x3c, 0x5c, 0x14, 0xf2, 0x40, 0x8c, 0x9e, 0xa2, 0x2a, 0x38, 0x84, 0xa1, 0xac, 0xac, 0x97, 0x2a,
+	0x3f, 0xb9, 0x60, 0xca, 0x29, 0x82, 0xc4, 0x31, 0x8e, 0xf1, 0x19, 0xdc, 0x98, 0xfb, 0x57, 0xd0,
+	0x77, 0x00, 0xec, 0x60, 0x62, 0x8a, 0x27, 0x01, 0x69, 0x41, 0x79, 0x5c, 0xb1, 0x83, 0x49, 0x4f,
+	0x30, 0x8c, 0x17, 0x50, 0x7f, 0x93, 0xbc, 0xdc, 0xfb, 0x48, 0x89, 0xcd, 0xf1, 0x89, 0xd0, 0x41,
+	0x1e, 0x97, 0x25, 0xe3, 0xe0, 0x04, 0x19, 0xb0, 0x16, 0x35, 0x5a, 0xe7, 0xbc, 0x43, 0x5e, 0x74,
+	0xa8, 0xaa, 0x0e, 0xd6, 0xf9, 0xc1, 0x89, 0xf1, 0xcb, 0x1c, 0xac, 0x5f, 0x12, 0x99, 0x87, 0x74,
+	0xd2, 0xe3, 0x45, 0x17, 0x66, 0x49, 0x71, 0xf7, 0x67, 0xbb, 0x4e, 0x94, 0x6a, 0x15, 0xdf, 0xe2,
+	0xe0, 0x0b, 0x54, 0x1a, 0x34, 0xe7, 0x06, 0x7c, 0xfb, 0x8c, 0x4f, 0x5c, 0x46, 0x45, 0x14, 0x52,
+	0xc4, 0x92, 0x40, 0xcf, 0xa1, 0x16, 0x12, 0x71, 0xe0, 0x3a, 0xa6, 0xb4, 0xb2, 0xe2, 0x42, 0x56,
+	0xa6, 0x24, 0xe4, 0xc6, 0x86, 0xd7, 0x22, 0x24, 0x4e, 0x51, 0xf4, 0x0c, 0xd6, 0x9c, 0x0b, 0xcf,
+	0x1a, 0xbb, 0xb6, 0x42, 0x2e, 0x2d, 0x8d, 0xbc, 0xaa, 0x80, 0x04, 0x30, 0x7f, 0x7d, 0x49, 0x34,
+	0xf2, 0x3f, 0x26, 0xc2, 0x2d, 0xa5, 0x13, 0x49, 0xa4, 0xbd, 0x45, 0x51, 0x79, 0x0b, 0xe3, 0x04,
+	0xaa, 0x89, 0x7d, 0xb1, 0xc8, 0x50, 0xae, 0x4f, 0xe6, 0x0b, 0x7d, 0x16, 0x71, 0x8e, 0xf9, 0x3c,
+	0x7b, 0xc1, 0x43, 0x1d, 0xd3, 0x0d, 0x84, 0x46, 0x2b, 0xb8, 0xc4, 0xc9, 0xbd, 0xc0, 0xf8, 0x55,
+	0x0e, 0x6a, 0xe9, 0x2d, 0x1d, 0xd9, 0x51, 0x40, 0x42, 0xd7, 0x77, 0x12, 0x76, 0x74, 0x24, 0x18,
+	0xdc, 0x56, 0x78, 0xf3, 0x37, 0x13, 0x9f, 0x59, 0x91, 0xad, 0xd8, 0xc1, 0xe4, 0x0f, 0x38, 0x7d,
+	0xc9, 0x06, 0xf3, 0x97, 0x6c, 0x10, 0x7d, 0x04, 0x48, 0x99, 0xd2, 0xc8, 0x1d, 0xbb, 0xcc, 0x3c,
+	0xb9, 0x60, 0x44, 0xae, 0x71, 0x1e, 0xeb, 0xb2, 0x65, 0x9f, 0x37, 0x3c, 0xe2, 0x7c, 0x6e, 0x78,
+	0xbe, 0x3f, 0x36, 0xa9, 0xed, 0x87, 0xc4, 0xb4, 0x9c, 0x57, 0xe2, 0xb6, 0x93, 0xc7, 0x55, 0xdf,
+	0x1f, 0xf7, 0x38, 0xaf, 0xe5, 0xbc, 0xe2, 0x27, 0x9f, 0x1d, 0x4c, 0x28, 0x61, 0x26, 0xff, 0x11,
+	0xc1, 0x42, 0x05, 0x83, 0x64, 0xb5, 0x83, 0x09, 0x45, 0xbf, 0x0b, 0x6b, 0x51, 0x07, 0x71, 0xf8,
+	0xa9, 0x53, 0x77, 0x55, 0x75, 0x11, 0x3c, 0x64, 0xc0, 0xea, 0x11, 0x09, 0x6d, 0xe2, 0xb1, 0xbe,
+	0x6b, 0x9f, 0x52, 0x71, 0x3f, 0xd1, 0x70, 0x8a, 0xf7, 0x55, 0xa1, 0xbc, 0xa2, 0x97, 0x71, 0x34,
+	0xdb, 0x98, 0x8c, 0xa9, 0xf1, 0x53, 0x28, 0x8a, 0x10, 0x81, 0xeb, 0x44, 0x1c, 0xaf, 0xe2, 0xf4,
+	0x55, 0xa1, 0x25, 0x67, 0x88, 0xb3, 0xf7, 0x7d, 0xa8, 0x08, 0xdd, 0x27, 0x22, 0x7a, 0x11, 0x77,
+	0x8a, 0xc6, 0x06, 0x94, 0x43, 0x62, 0x39, 0xbe, 0x37, 0x8a, 0x12, 0x45, 0x31, 0x6d, 0x7c, 0x03,
+	0x25, 0x79, 0xce, 0x5c, 0x01, 0xff, 0x63, 0x40, 0xf2, 0x7f, 0xf3, 0xf5, 0x1c, 0xbb, 0x94, 0xaa,
+	0x28, 0x54, 0xbc, 0x4e, 0xca, 0x96, 0xa3, 0x69, 0x83, 0xf1, 0x5f, 0x1a, 0xc0, 0xf4, 0xdd, 0x88,
+	0x07, 0xae, 0xdc, 0xc8, 0xf9, 0x2d, 0x5b, 0x26, 0xa8, 0x22, 0x92, 0xe7, 0x66, 0x54, 0xd8, 0x99,
+	0x5b, 0xf6, 0xd9, 0x4d, 0x01, 0x44, 0xe9, 0x6a, 0xa2, 0x2e, 0xf2, 0x8b, 0xa6, 0xab, 0x89, 0x4c,
+	0x57, 0x13, 0x7e, 0x0b, 0x55, 0x01, 0xb1, 0x84, 0x2b, 0x88, 0x78, 0xb8, 0xea, 0xc4, 0x6f, 0x02,
+	0xc4, 0xf8, 0x1f, 0x2d, 0x76, 0x53, 0x51, 0xee, 0x1e, 0x7d, 0x0d, 0x65, 0xbe, 0xe3, 0xcd, 0xb1,
+	0x15, 0xa8, 0x97, 0xe8, 0xf6, 0x72, 0xcf, 0x02, 0xd1, 0x21, 0x26, 0xc3, 0xd9, 0x95, 0x40, 0x52,
+	0xdc, 0xdd, 0xf1, 0xab, 0x44, 0xe4, 0xee, 0xf8, 0x37, 0xfa, 0x10, 0x6a, 0xd6, 0x84, 0xf9, 0xa6,
+	0xe5, 0x9c, 0x91, 0x90, 0xb9, 0x94, 0xa8, 0xb5, 0x5f, 0xe3, 0xdc, 0x56, 0xc4, 0x6c, 0xdc, 0x87,
+	0xd5, 0x24, 0xe6, 0xdb, 0xc2, 0x8c, 0x62, 0x32, 0xcc, 0xf8, 0x67, 0x0d, 0x60, 0x9a, 0x08, 0xe3,
+	0x46, 0xc2, 0xb3, 0x6a, 0xa6, 0x1d, 0x5d, 0x5e, 0x8b, 0xb8, 0xcc, 0x19, 0x6d, 0x7e, 0xa1, 0x4a,
+	0x67, 0xe9, 0x8b, 0x51, 0x96, 0x9e, 0xef, 0x66, 0xbe, 0x01, 0x4f, 0xdd, 0xd1, 0x28, 0x4e, 0xce,
+	0x55, 0x7c, 0x7f, 0xfc, 0x54, 0x30, 0xf8, 0xde, 0x53, 0xbb, 0x39, 0x20, 0xd6, 0xa9, 0xd0, 0x77,
+	0x01, 0x83, 0x64, 0x1d, 0x11, 0xeb, 0x94, 0xaf, 0x48, 0x72, 0xbb, 0x8b, 0xfd, 0x5b, 0xc0, 0xd5,
+	0xc4, 0x46, 0x37, 0x7e, 0x9d, 0x93, 0x06, 0x27, 0xdf, 0x6c, 0x32, 0x5d, 0x80, 0xde, 0x95, 0xbd,
+	0xdc, 0x03, 0xa0, 0xcc, 0x0a, 0x79, 0xe0, 0x65, 0x45, 0x29, 0xc6, 0xc6, 0xcc, 0x53, 0x41, 0x3f,
+	0x2a, 0x22, 0xc1, 0x15, 0xd5, 0xbb, 0xc5, 0xd0, 0x17, 0xb0, 0x6a, 0xfb, 0xe3, 0x60, 0x44, 0xd4,
+	0xe0, 0xe2, 0x5b, 0x07, 0x57, 0xe3, 0xfe, 0x2d, 0x96, 0x48, 0x6c, 0x96, 0xae, 0x9a, 0xd8, 0xfc,
+	0x95, 0x26, 0x9f, 0x9e, 0x92, 0x2f, 0x5f, 0x68, 0x30, 0xa7, 0xbc, 0xe2, 0xc9, 0x92, 0xcf, 0x68,
+	0xbf, 0xad, 0xb6, 0xa2, 0xf1, 0x45, 0x96, 0x62, 0x86, 0x37, 0x87, 0xc2, 0xff, 0x91, 0x87, 0x4a,
+	0xb4, 0x2c, 0xb3, 0x6b, 0xff, 0x39, 0x54, 0xe2, 0x0a, 0x9e, 0x7a, 0xee, 0xad, 0x1a, 0x9e, 0x76,
+	0x46, 0x2f, 0x01, 0x59, 0x83, 0x41, 0x1c, 0xe2, 0x9a, 0x13, 0x6a, 0x0d, 0xa2, 0x37, 0xbf, 0xcf,
+	0x17, 0xd0, 0x43, 0x74, 0x26, 0x1e, 0xf3, 0xf1, 0x58, 0xb7, 0x06, 0x83, 0x14, 0x07, 0xfd, 0x31,
+	0xdc, 0x48, 0xcf, 0x61, 0x9e, 0x5c, 0x98, 0x81, 0xeb, 0xa8, 0x8b, 0xf6, 0xee, 0xa2, 0x0f, 0x6f,
+	0xcd, 0x14, 0xfc, 0xa3, 0x8b, 0x23, 0xd7, 0x91, 0x3a, 0x47, 0xe1, 0x4c, 0x43, 0xe3, 0x4f, 0xe1,
+	0xbd, 0x37, 0x74, 0x9f, 0xb3, 0x06, 0xdd, 0x74, 0x41, 0xc9, 0xf2, 0x4a, 0x48, 0xac, 0xde, 0x3f,
+	0x69, 0xb0, 0x31, 0xd3, 0x01, 0xb5, 0x92, 0xb1, 0xf9, 0x9d, 0x8c, 0xf3, 0xb4, 0x8f, 0x8e, 0x25,
+	0x3c, 0x1f, 0x8b, 0xbe, 0xba, 0x14, 0x8e, 0x67, 0x0d, 0xc2, 0x64, 0x54, 0x2b, 0x81, 0x14, 0x82,
+	0xf1, 0xaf, 0x79, 0x28, 0x47, 0xe8, 0xe2, 0x9a, 0x7c, 0x41, 0x19, 0x19, 0x9b, 0x71, 0x0e, 0x4f,
+	0xc3, 0x20, 0x59, 0x22, 0xb3, 0xf4, 0x3e, 0x54, 0xf8, 0x6d, 0x5c, 0x36, 0xe7, 0x44, 0x73, 0x99,
+	0x33, 0x44, 0xe3, 0x07, 0x50, 0x65, 0x3e, 0xb3, 0x46, 0x26, 0x13, 0x31, 0x42, 0x5e, 0x8e, 0x16,
+	0x2c, 0x11, 0x21, 0xa0, 0x1f, 0xc0, 0x06, 0x1b, 0x86, 0x3e, 0x63, 0x23, 0x1e, 0x9f, 0x8a, 0x68,
+	0x89, 0x2a, 0xaf, 0xa8, 0xc7, 0x0d, 0x32, 0x8a, 0xa2, 0xfc, 0x08, 0x98, 0x76, 0xe6, 0xa6, 0xab,
+	0xbc, 0xe3, 0x5a, 0xcc, 0xe5, 0xa6, 0xcd, 0x4f, 0xe0, 0x40, 0x46, 0x21, 0xc2, 0x57, 0x68, 0x38,
+	0x22, 0x91, 0x09, 0xeb, 0x63, 0x62, 0xd1, 0x49, 0x48, 0x1c, 0xf3, 0xa5, 0x4b, 0x46, 0x8e, 0xcc,
+	0x6e, 0xd4, 0x32, 0x5f, 0x31, 0x22, 0xb5, 0x34, 0x1f, 0x8b, 0xd1, 0xb8, 0x16, 0xc1, 0x49, 0x9a,
+	0x87, 0x1f, 0xf2, 0x0b, 0xad, 0x43, 0xb5, 0xf7, 0xbc, 0xd7, 0xef, 0x1c, 0x98, 0x07, 0x87, 0x3b,
+	0x1d, 0x55, 0x33, 0xd4, 0xeb, 0x60, 0x49, 0x6a, 0xbc, 0xbd, 0x7f, 0xd8, 0x6f, 0xed, 0x9b, 0xfd,
+	0xbd, 0xf6, 0xd3, 0x9e, 0x9e, 0x43, 0x37, 0x60, 0xa3, 0xbf, 0x8b, 0x0f, 0xfb, 0xfd, 0xfd, 0xce,
+	0x8e, 0x79, 0xd4, 0xc1, 0x7b, 0x87, 0x3b, 0x3d, 0x3d, 0xcf, 0x93, 0xb1, 0x53, 0x76, 0x7f, 0xef,
+	0xa0, 0xa3, 0x17, 0x78, 0x95, 0xc8, 0x51, 0x07, 0xb7, 0x3b, 0xdd, 0xbe, 0x5e, 0x34, 0x7e, 0x99,
+	0x87, 0x6a, 0x62, 0x15, 0xb9, 0x21, 0x87, 0x54, 0xde, 0x65, 0x0a, 0x98, 0x7f, 0x8a, 0x37, 0x4e,
+	0xcb, 0x1e, 0xca, 0xd5, 0x29, 0x60, 0x49, 0x88, 0xfb, 0x8b, 0x75, 0x9e, 0xd8, 0xe7, 0x05, 0x5c,
+	0x1e, 0x5b, 0xe7, 0x12, 0xe4, 0x7b, 0xb0, 0x7a, 0x4a, 0x42, 0x8f, 0x8c, 0x54, 0xbb, 0x5c, 0x91,
+	0xaa, 0xe4, 0xc9, 0x2e, 0x5b, 0xa0, 0xab, 0x2e, 0x53, 0x18, 0xb9, 0x1c, 0x35, 0xc9, 0x3f, 0x88,
+	0xc0, 0x36, 0xa1, 0x28, 0x9b, 0x57, 0xe4, 0xfc, 0x82, 0xe0, 0xc7, 0x14, 0x7d, 0x6d, 0x05, 0x22,
+	0x6e, 0x2c, 0x60, 0xf1, 0x8d, 0x4e, 0x66, 0xd7, 0xa7, 0x24, 0xd6, 0xe7, 0xde, 0xe2, 0xe6, 0xfc,
+	0xa6, 0x25, 0x1a, 0xc6, 0x4b, 0xb4, 0x02, 0x79, 0x1c, 0x15, 0xda, 0xb4, 0x5b, 0xed, 0x5d, 0xbe,
+	0x2c, 0x6b, 0x50, 0x39, 0x68, 0xfd, 0xc4, 0x3c, 0xee, 0x89, 0xd4, 0x38, 0xd2, 0x61, 0xf5, 0x69,
+	0x07, 0x77, 0x3b, 0xfb, 0x8a, 0x93, 0x47, 0x9b, 0xa0, 0x2b, 0xce, 0xb4, 0x5f, 0x81, 0x23, 0xc8,
+	0xcf, 0x22, 0x4f, 0xa5, 0xf6, 0x9e, 0xb5, 0x8e, 0xf4, 0x92, 0xf1, 0xdf, 0x39, 0x58, 0x97, 0xc7,
+	0x42, 0x5c, 0x12, 0xf0, 0xe6, 0x27, 0xd1, 0x64, 0xaa, 0x28, 0x97, 0x4e, 0x15, 0x45, 0x91, 0xac,
+	0x38, 0xd5, 0xf3, 0xd3, 0x48, 0x56, 0xa4, 0x98, 0x52, 0x1e, 0xbf, 0xb0, 0x88, 0xc7, 0xaf, 0xc3,
+	0xca, 0x98, 0xd0, 0x78, 0xdd, 0x2a, 0x38, 0x22, 0x91, 0x0b, 0x55, 0xcb, 0xf3, 0x7c, 0x66, 0xc9,
+	0xfc, 0x6b, 0x69, 0xa1, 0xc3, 0xf0, 0xd2, 0x3f, 0x6e, 0xb6, 0xa6, 0x48, 0xd2, 0x31, 0x27, 0xb1,
+	0x1b, 0x3f, 0x06, 0xfd, 0x72, 0x87, 0x85, 0x8e, 0xc3, 0xbf, 0xd0, 0xa0, 0x71, 0x1c, 0x38, 0x16,
+	0x23, 0x49, 0xb7, 0xfa, 0xf6, 0x82, 0x92, 0x54, 0xba, 0x34, 0x77, 0xe5, 0x74, 0xa9, 0xf1, 0x1d,
+	0x78, 0x7f, 0xae, 0x18, 0xf2, 0x35, 0xef, 0xfb, 0x9f, 0x4e, 0x0f, 0x6d, 0xc2, 0xb7, 0xaf, 0x7a,
+	0x5f, 0xd1, 0xaf, 0x71, 0x02, 0x1f, 0x77, 0xbb, 0x7b, 0xdd, 0x27, 0xba, 0xc6, 0x1f, 0x68, 0x3a,
+	0x3f, 0xd9, 0xe3, 0x35, 0x86, 0xb9, 0xed, 0xdf, 0x20, 0x28, 0x49, 0x5d, 0xa2, 0x6f, 0x55, 0xc0,
+	0x92, 0xac, 0x8a, 0x45, 0x3f, 0x5e, 0xf8, 0xf6, 0x90, 0xaa, 0xb4, 0x6d, 0x3c, 0x5c, 0x7a, 0xbc,
+	0x7a, 0xa1, 0xbc, 0x86, 0xfe, 0x4a, 0x83, 0xd5, 0xd4, 0xeb, 0x64, 0xd6, 0x34, 0xf9, 0x9c, 0x22,
+	0xdc, 0xc6, 0x8f, 0x96, 0x1a, 0x1b, 0xcb, 0xf2, 0x0b, 0x0d, 0xaa, 0x89, 0xf2, 0x53, 0x74, 0x6f,
+	0x99, 0x92, 0x55, 0x29, 0xc9, 0xfd, 0xe5, 0xab, 0x5d, 0x8d, 0x6b, 0x9f, 0x68, 0xe8, 0x2f, 0x35,
+	0xa8, 0x26, 0x0a, 0x31, 0x33, 0x8b, 0x32, 0x5b, 0x36, 0xda, 0xb8, 0xbf, 0xcc, 0xd0, 0x58, 0x27,
+	0x7f, 0xa6, 0x41, 0x25, 0x2e, 0xaa, 0x44, 0x77, 0x17, 0x2f, 0xc3, 0x94, 0x42, 0x7c, 0xbe, 0x6c,
+	0xfd, 0xa6, 0x71, 0x0d, 0xfd, 0x09, 0x94, 0xa3, 0x0a, 0x44, 0x94, 0xf5, 0x90, 0xbd, 0x54, 0xde,
+	0xd8, 0xb8, 0xbb, 0xf0, 0xb8, 0xe4, 0xf4, 0x51, 0x59, 0x60, 0xe6, 0xe9, 0x2f, 0x15, 0x30, 0x36,
+	0xee, 0x2e, 0x3c, 0x2e, 0x9e, 0x9e, 0x5b, 0x42, 0xa2, 0x7a, 0x30, 0xb3, 0x25, 0xcc, 0x96, 0x2d,
+	0x36, 0xee, 0x2f, 0x33, 0x34, 0x25, 0x48, 0xa2, 0xfe, 0x30, 0xb3, 0x20, 0xb3, 0x35, 0x8e, 0x8d,
+	0xfb, 0xcb, 0x0c, 0x8d, 0x05, 0xf9, 0xb9, 0x96, 0xbc, 0xbe, 0xdc, 0x5d, 0xb8, 0xcc, 0x6e, 0x41,
+	0x93, 0x9c, 0x29, 0xf4, 0x13, 0x1b, 0xf4, 0xe7, 0x2a, 0x63, 0x23, 0xab, 0xf4, 0xd0, 0x22, 0x60,
+	0xa9, 0xc2, 0xbe, 0xc6, 0x67, 0xcb, 0x9d, 0x89, 0x42, 0x88, 0x3f, 0xd7, 0x00, 0xa6, 0xf5, 0x7c,
+	0x99, 0x85, 0x98, 0x29, 0x24, 0x6c, 0xdc, 0x5b, 0x62, 0x64, 0x72, 0x83, 0x44, 0xf5, 0x46, 0x99,
+	0x37, 0xc8, 0xa5, 0x7a, 0xc3, 0xc6, 0xdd, 0x85, 0xc7, 0xc5, 0xd3, 0xff, 0x83, 0x06, 0x1b, 0x33,
+	0xf5, 0x4e, 0xe8, 0xe1, 0x15, 0x4b, 0xde, 0x1a, 0x5f, 0x2e, 0x0f, 0x10, 0x89, 0xb6, 0xa5, 0x7d,
+	0xa2, 0xa1, 0xbf, 0xd6, 0x60, 0x2d, 0x5d, 0x23, 0x92, 0xf9, 0x94, 0x9a, 0x53, 0x39, 0xd5, 0x78,
+	0xb0, 0xdc, 0xe0, 0x58, 0x5b, 0x7f, 0xab, 0x41, 0x4d, 0xed, 0xef, 0x48, 0x9e, 0x07, 0x8b, 0xb9,
+	0x85, 0x4b, 0x02, 0x7d, 0xb1, 0xe4, 0xe8, 0x58, 0xa2, 0x7f, 0xd4, 0xe0, 0xfa, 0x9c, 0xb8, 0x07,
+	0xb5, 0x32, 0x02, 0xbf, 0x39, 0x74, 0x6b, 0x3c, 0xba, 0x0a, 0x44, 0x24, 0xe0, 0xa3, 0x95, 0x3f,
+	0x2c, 0xca, 0x28, 0xb8, 0x24, 0x7e, 0x7e, 0xf8, 0xff, 0x03, 0x00, 0xdf, 0x85, 0x15, 0x11, 0x5d,
+	0x35, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3977,6 +4103,7 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error)
 }
 
 type driverClient struct {
@@ -4222,6 +4349,15 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) UpdateTaskResources(ctx context.Context, in *UpdateTaskResourcesRequest, opts ...grpc.CallOption) (*UpdateTaskResourcesResponse, error) {
+	out := new(UpdateTaskResourcesResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4277,6 +4413,7 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	UpdateTaskResources(context.Context, *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4331,6 +4468,9 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) UpdateTaskResources(ctx context.Context, req *UpdateTaskResourcesRequest) (*UpdateTaskResourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTaskResources not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4641,6 +4781,24 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_UpdateTaskResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskResourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).UpdateTaskResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/UpdateTaskResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).UpdateTaskResources(ctx, req.(*UpdateTaskResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4693,6 +4851,10 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "UpdateTaskResources",
+			Handler:    _Driver_UpdateTaskResources_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
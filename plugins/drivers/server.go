@@ -44,6 +44,8 @@ func (b *driverPluginServer) Capabilities(ctx context.Context, req *proto.Capabi
 			MustCreateNetwork:     caps.MustInitiateNetwork,
 			NetworkIsolationModes: []proto.NetworkIsolationSpec_NetworkIsolationMode{},
 			RemoteTasks:           caps.RemoteTasks,
+			Checkpoint:            caps.Checkpoint,
+			Resize:                caps.Resize,
 		},
 	}
 
@@ -164,9 +166,11 @@ func (b *driverPluginServer) WaitTask(ctx context.Context, req *proto.WaitTaskRe
 	resp := &proto.WaitTaskResponse{
 		Err: errStr,
 		Result: &proto.ExitResult{
-			ExitCode:  int32(result.ExitCode),
-			Signal:    int32(result.Signal),
-			OomKilled: result.OOMKilled,
+			ExitCode:    int32(result.ExitCode),
+			Signal:      int32(result.Signal),
+			OomKilled:   result.OOMKilled,
+			MemoryPeak:  result.MemoryPeak,
+			MemoryLimit: result.MemoryLimit,
 		},
 	}
 
@@ -413,3 +417,16 @@ func (b *driverPluginServer) DestroyNetwork(ctx context.Context, req *proto.Dest
 
 	return &proto.DestroyNetworkResponse{}, nil
 }
+
+func (b *driverPluginServer) UpdateTaskResources(ctx context.Context, req *proto.UpdateTaskResourcesRequest) (*proto.UpdateTaskResourcesResponse, error) {
+	rd, ok := b.impl.(ResizeDriver)
+	if !ok {
+		return nil, fmt.Errorf("UpdateTaskResources RPC not supported by driver")
+	}
+
+	if err := rd.UpdateTaskResources(req.TaskId, ResourcesFromProto(req.Resources)); err != nil {
+		return nil, err
+	}
+
+	return &proto.UpdateTaskResourcesResponse{}, nil
+}
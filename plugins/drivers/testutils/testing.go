@@ -214,8 +214,13 @@ func (d *MockDriver) TaskConfigSchema() (*hclspec.Spec, error) { return d.TaskCo
 func (d *MockDriver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
 	return d.FingerprintF(ctx)
 }
-func (d *MockDriver) Capabilities() (*drivers.Capabilities, error) { return d.CapabilitiesF() }
-func (d *MockDriver) RecoverTask(h *drivers.TaskHandle) error      { return d.RecoverTaskF(h) }
+func (d *MockDriver) Capabilities() (*drivers.Capabilities, error) {
+	if d.CapabilitiesF == nil {
+		return &drivers.Capabilities{}, nil
+	}
+	return d.CapabilitiesF()
+}
+func (d *MockDriver) RecoverTask(h *drivers.TaskHandle) error { return d.RecoverTaskF(h) }
 func (d *MockDriver) StartTask(c *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	return d.StartTaskF(c)
 }
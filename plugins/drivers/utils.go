@@ -361,17 +361,21 @@ func exitResultToProto(result *ExitResult) *proto.ExitResult {
 		return &proto.ExitResult{}
 	}
 	return &proto.ExitResult{
-		ExitCode:  int32(result.ExitCode),
-		Signal:    int32(result.Signal),
-		OomKilled: result.OOMKilled,
+		ExitCode:    int32(result.ExitCode),
+		Signal:      int32(result.Signal),
+		OomKilled:   result.OOMKilled,
+		MemoryPeak:  result.MemoryPeak,
+		MemoryLimit: result.MemoryLimit,
 	}
 }
 
 func exitResultFromProto(pb *proto.ExitResult) *ExitResult {
 	return &ExitResult{
-		ExitCode:  int(pb.ExitCode),
-		Signal:    int(pb.Signal),
-		OOMKilled: pb.OomKilled,
+		ExitCode:    int(pb.ExitCode),
+		Signal:      int(pb.Signal),
+		OOMKilled:   pb.OomKilled,
+		MemoryPeak:  pb.MemoryPeak,
+		MemoryLimit: pb.MemoryLimit,
 	}
 }
 
@@ -19,6 +19,7 @@ const (
 	FilterConstraintCSIPluginTemplate           = "CSI plugin %s is missing from client %s"
 	FilterConstraintCSIPluginUnhealthyTemplate  = "CSI plugin %s is unhealthy on client %s"
 	FilterConstraintCSIPluginMaxVolumesTemplate = "CSI plugin %s has the maximum number of volumes on client %s"
+	FilterConstraintCSIPluginTopologyTemplate   = "CSI plugin %s on client %s is not in the topology of CSI volume %s"
 	FilterConstraintCSIVolumesLookupFailed      = "CSI volume lookup failed"
 	FilterConstraintCSIVolumeNotFoundTemplate   = "missing CSI Volume %s"
 	FilterConstraintCSIVolumeNoReadTemplate     = "CSI volume %s is unschedulable or has exhausted its available reader claims"
@@ -130,7 +131,8 @@ func NewRandomIterator(ctx Context, nodes []*structs.Node) *StaticIterator {
 // HostVolumeChecker is a FeasibilityChecker which returns whether a node has
 // the host volumes necessary to schedule a task group.
 type HostVolumeChecker struct {
-	ctx Context
+	ctx       Context
+	namespace string
 
 	// volumes is a map[HostVolumeName][]RequestedVolume. The requested volumes are
 	// a slice because a single task group may request the same volume multiple times.
@@ -144,6 +146,12 @@ func NewHostVolumeChecker(ctx Context) *HostVolumeChecker {
 	}
 }
 
+// SetNamespace sets the namespace of the job, which must match the namespace
+// of dynamic host volumes.
+func (h *HostVolumeChecker) SetNamespace(namespace string) {
+	h.namespace = namespace
+}
+
 // SetVolumes takes the volumes required by a task group and updates the checker.
 func (h *HostVolumeChecker) SetVolumes(volumes map[string]*structs.VolumeRequest) {
 	lookupMap := make(map[string][]*structs.VolumeRequest)
@@ -188,6 +196,12 @@ func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
 			return false
 		}
 
+		// Dynamic host volumes belong to a namespace, and can only be
+		// claimed once the client has created them.
+		if nodeVolume.ID != "" && !h.dynamicVolumeReady(nodeVolume.ID) {
+			return false
+		}
+
 		// If the volume supports being mounted as ReadWrite, we do not need to
 		// do further validation for readonly placement.
 		if !nodeVolume.ReadOnly {
@@ -206,6 +220,15 @@ func (h *HostVolumeChecker) hasVolumes(n *structs.Node) bool {
 	return true
 }
 
+func (h *HostVolumeChecker) dynamicVolumeReady(id string) bool {
+	vol, err := h.ctx.State().HostVolumeByID(nil, h.namespace, id)
+	if err != nil {
+		h.ctx.Logger().Error("failed to lookup host volume", "volume_id", id, "error", err)
+		return false
+	}
+	return vol != nil && vol.State == structs.HostVolumeStateReady
+}
+
 type CSIVolumeChecker struct {
 	ctx       Context
 	namespace string
@@ -262,7 +285,8 @@ func (c *CSIVolumeChecker) isFeasible(n *structs.Node) (bool, string) {
 	// We can mount the volume if
 	// - if required, a healthy controller plugin is running the driver
 	// - the volume has free claims, or this job owns the claims
-	// - this node is running the node plugin, implies matching topology
+	// - this node is running the node plugin
+	// - the node plugin's topology is one the volume is accessible from
 
 	// Fast path: Requested no volumes. No need to check further.
 	if len(c.volumes) == 0 {
@@ -310,6 +334,10 @@ func (c *CSIVolumeChecker) isFeasible(n *structs.Node) (bool, string) {
 		if pluginCount[vol.PluginID] >= plugin.NodeInfo.MaxVolumes {
 			return false, fmt.Sprintf(FilterConstraintCSIPluginMaxVolumesTemplate, vol.PluginID, n.ID)
 		}
+		if len(vol.Topologies) > 0 &&
+			!plugin.NodeInfo.AccessibleTopology.MatchFound(vol.Topologies) {
+			return false, fmt.Sprintf(FilterConstraintCSIPluginTopologyTemplate, vol.PluginID, n.ID, vol.ID)
+		}
 
 		if req.ReadOnly {
 			if !vol.ReadSchedulable() {
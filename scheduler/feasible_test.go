@@ -231,6 +231,78 @@ func TestHostVolumeChecker_ReadOnly(t *testing.T) {
 	}
 }
 
+func TestHostVolumeChecker_Dynamic(t *testing.T) {
+	state, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+
+	ready := mock.HostVolume(nodes[0])
+	pending := mock.HostVolume(nodes[1])
+	pending.State = structs.HostVolumeStatePending
+	require.NoError(t, state.UpsertHostVolumes(1000, []*structs.HostVolume{ready, pending}))
+
+	nodes[0].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		ready.Name: ready.ClientConfig(),
+	}
+	nodes[1].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		pending.Name: pending.ClientConfig(),
+	}
+	// the volume was deleted but the node hasn't been updated yet
+	nodes[2].HostVolumes = map[string]*structs.ClientHostVolumeConfig{
+		ready.Name: {Name: ready.Name, Path: "/var/nomad/gone", ID: uuid.Generate()},
+	}
+
+	volumes := map[string]*structs.VolumeRequest{
+		"data": {
+			Type:   structs.VolumeTypeHost,
+			Source: ready.Name,
+		},
+	}
+
+	checker := NewHostVolumeChecker(ctx)
+	checker.SetVolumes(volumes)
+	cases := []struct {
+		Name      string
+		Node      *structs.Node
+		Namespace string
+		Result    bool
+	}{
+		{
+			Name:      "ready volume",
+			Node:      nodes[0],
+			Namespace: structs.DefaultNamespace,
+			Result:    true,
+		},
+		{
+			Name:      "ready volume in other namespace",
+			Node:      nodes[0],
+			Namespace: "other",
+			Result:    false,
+		},
+		{
+			Name:      "pending volume",
+			Node:      nodes[1],
+			Namespace: structs.DefaultNamespace,
+			Result:    false,
+		},
+		{
+			Name:      "deleted volume",
+			Node:      nodes[2],
+			Namespace: structs.DefaultNamespace,
+			Result:    false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			checker.SetNamespace(c.Namespace)
+			require.Equal(t, c.Result, checker.Feasible(c.Node))
+		})
+	}
+}
+
 func TestCSIVolumeChecker(t *testing.T) {
 	t.Parallel()
 	state, ctx := testContext(t)
@@ -427,6 +499,74 @@ func TestCSIVolumeChecker(t *testing.T) {
 
 }
 
+func TestCSIVolumeChecker_Topology(t *testing.T) {
+	t.Parallel()
+	state, ctx := testContext(t)
+
+	// nodes[0] is in the volume's zone, nodes[1] is in another zone and
+	// nodes[2] doesn't report a topology
+	topologies := []*structs.CSITopology{
+		{Segments: map[string]string{"region": "R1", "zone": "Z1"}},
+		{Segments: map[string]string{"region": "R1", "zone": "Z2"}},
+		nil,
+	}
+	nodes := []*structs.Node{mock.Node(), mock.Node(), mock.Node()}
+	index := uint64(999)
+	for i, node := range nodes {
+		node.CSINodePlugins = map[string]*structs.CSIInfo{
+			"foo": {
+				PluginID: "foo",
+				Healthy:  true,
+				NodeInfo: &structs.CSINodeInfo{
+					MaxVolumes:         3,
+					AccessibleTopology: topologies[i],
+				},
+			},
+		}
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, index, node))
+		index++
+	}
+
+	zonal := structs.NewCSIVolume("zonal", index)
+	zonal.PluginID = "foo"
+	zonal.Namespace = structs.DefaultNamespace
+	zonal.AccessMode = structs.CSIVolumeAccessModeMultiNodeMultiWriter
+	zonal.AttachmentMode = structs.CSIVolumeAttachmentModeFilesystem
+	zonal.Topologies = []*structs.CSITopology{
+		{Segments: map[string]string{"zone": "Z1"}},
+	}
+
+	global := zonal.Copy()
+	global.ID = "global"
+	global.Topologies = nil
+	require.NoError(t, state.CSIVolumeRegister(index, []*structs.CSIVolume{zonal, global}))
+
+	checker := NewCSIVolumeChecker(ctx)
+	checker.SetNamespace(structs.DefaultNamespace)
+
+	cases := []struct {
+		name   string
+		node   *structs.Node
+		source string
+		result bool
+	}{
+		{"volume zone", nodes[0], "zonal", true},
+		{"other zone", nodes[1], "zonal", false},
+		{"no node topology", nodes[2], "zonal", false},
+		{"no volume topology", nodes[1], "global", true},
+		{"neither topology", nodes[2], "global", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checker.SetVolumes("group.0", map[string]*structs.VolumeRequest{
+				"data": {Type: "csi", Name: "data", Source: c.source},
+			})
+			require.Equal(t, c.result, checker.Feasible(c.node))
+		})
+	}
+}
+
 func TestNetworkChecker(t *testing.T) {
 	_, ctx := testContext(t)
 
@@ -487,6 +487,10 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements of all_or_nothing task groups so they can be
+	// backed out if any allocation of the group fails to place
+	gangs := make(map[string][]gangPlacement)
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
 	// count was scaled up.
@@ -626,6 +630,14 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 				// Track the placement
 				s.plan.AppendAlloc(alloc, downgradedJob)
 
+				if tg.AllOrNothing() {
+					gang := gangPlacement{alloc: alloc}
+					if stopPrevAlloc {
+						gang.stopped = prevAllocation
+					}
+					gangs[tg.Name] = append(gangs[tg.Name], gang)
+				}
+
 			} else {
 				// Lazy initialize the failed map
 				if s.failedTGAllocs == nil {
@@ -648,9 +660,83 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 		}
 	}
 
+	for name, placed := range gangs {
+		if metric, ok := s.failedTGAllocs[name]; ok {
+			s.rollbackGang(placed, metric)
+			continue
+		}
+
+		// The gang may only be committed as a whole, so a node rejecting
+		// part of it must reject all of it
+		var allocs, stopped []*structs.Allocation
+		for _, p := range placed {
+			allocs = append(allocs, p.alloc)
+			if p.stopped != nil {
+				stopped = append(stopped, p.stopped)
+			}
+		}
+		s.plan.AppendGang(name, allocs, stopped)
+	}
+
 	return nil
 }
 
+// gangPlacement is an allocation placed for an all_or_nothing task group,
+// along with the allocation it replaces, if that is stopped by the plan.
+type gangPlacement struct {
+	alloc   *structs.Allocation
+	stopped *structs.Allocation
+}
+
+// rollbackGang removes the placements of an all_or_nothing task group from the
+// plan after another allocation of the group failed to place, so that no
+// partial gang holds on to resources. The backed out allocations are counted
+// as failed in the metric of the task group.
+func (s *GenericScheduler) rollbackGang(placed []gangPlacement, metric *structs.AllocMetric) {
+	for _, p := range placed {
+		s.plan.RemoveAlloc(p.alloc)
+		if p.stopped != nil {
+			s.plan.RemoveUpdate(p.stopped)
+		}
+
+		// Backed out canaries must not be counted as placed by the deployment
+		if p.alloc.DeploymentStatus.IsCanary() {
+			p.alloc.DeploymentStatus = nil
+			if s.plan.Deployment != nil {
+				if dstate, ok := s.plan.Deployment.TaskGroups[p.alloc.TaskGroup]; ok {
+					canaries := dstate.PlacedCanaries[:0]
+					for _, id := range dstate.PlacedCanaries {
+						if id != p.alloc.ID {
+							canaries = append(canaries, id)
+						}
+					}
+					dstate.PlacedCanaries = canaries
+				}
+			}
+		}
+
+		if len(p.alloc.PreemptedAllocations) != 0 && s.eval.AnnotatePlan && s.plan.Annotations != nil {
+			preempted := make(map[string]struct{}, len(p.alloc.PreemptedAllocations))
+			for _, id := range p.alloc.PreemptedAllocations {
+				preempted[id] = struct{}{}
+			}
+			stubs := s.plan.Annotations.PreemptedAllocs[:0]
+			for _, stub := range s.plan.Annotations.PreemptedAllocs {
+				if _, ok := preempted[stub.ID]; !ok {
+					stubs = append(stubs, stub)
+				}
+			}
+			s.plan.Annotations.PreemptedAllocs = stubs
+
+			if desired := s.plan.Annotations.DesiredTGUpdates[p.alloc.TaskGroup]; desired != nil {
+				desired.Preemptions -= uint64(len(p.alloc.PreemptedAllocations))
+			}
+		}
+	}
+
+	metric.CoalescedFailures += len(placed)
+}
+
 // propagateTaskState copies task handles from previous allocations to
 // replacement allocations when the previous allocation is being drained or was
 // lost. Remote task drivers rely on this to reconnect to remote tasks when the
@@ -1190,6 +1190,218 @@ func TestServiceSched_JobRegister_CreateBlockedEval(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_AllOrNothing_PartialFit(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create a node that only fits two allocations of the gang
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job with a gang that doesn't fit and a group that does
+	job := mock.Job()
+	job.TaskGroups[0].Count = 4
+	job.TaskGroups[0].Placement = structs.TaskGroupPlacementAllOrNothing
+	job.TaskGroups[0].Tasks[0].Resources.CPU = 1500
+	job.TaskGroups[0].Tasks[0].Resources.Networks = nil
+
+	other := job.TaskGroups[0].Copy()
+	other.Name = "other"
+	other.Count = 1
+	other.Placement = ""
+	other.Tasks[0].Resources.CPU = 500
+	job.TaskGroups = append(job.TaskGroups, other)
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the plan only places the group that isn't a gang
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.False(t, plan.AllAtOnce)
+	require.Empty(t, plan.Gangs)
+	var planned []*structs.Allocation
+	for _, allocs := range plan.NodeAllocation {
+		planned = append(planned, allocs...)
+	}
+	require.Len(t, planned, 1)
+	require.Equal(t, "other", planned[0].TaskGroup)
+
+	// Ensure no allocation of the gang was placed
+	ws := memdb.NewWatchSet()
+	out, err := h.State.AllocsByJob(ws, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+
+	// Ensure the whole gang is reported as failed and blocked
+	require.Len(t, h.Evals, 1)
+	outEval := h.Evals[0]
+	require.Len(t, outEval.FailedTGAllocs, 1)
+	metrics, ok := outEval.FailedTGAllocs[job.TaskGroups[0].Name]
+	require.True(t, ok)
+	require.Equal(t, 3, metrics.CoalescedFailures)
+	require.Equal(t, 4, outEval.QueuedAllocations[job.TaskGroups[0].Name])
+	require.Equal(t, 0, outEval.QueuedAllocations["other"])
+
+	require.Len(t, h.CreateEvals, 1)
+	blocked := h.CreateEvals[0]
+	require.Equal(t, structs.EvalStatusBlocked, blocked.Status)
+	require.Equal(t, blocked.ID, outEval.BlockedEval)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+
+	// Add capacity and process the blocked evaluation
+	node2 := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node2))
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{blocked}))
+	require.NoError(t, h.Process(NewServiceScheduler, blocked))
+
+	// Ensure the whole gang is placed at once
+	require.Len(t, h.Plans, 2)
+	plan = h.Plans[1]
+	require.False(t, plan.AllAtOnce)
+	require.ElementsMatch(t, []string{node.ID, node2.ID}, plan.Gangs[job.TaskGroups[0].Name])
+	planned = nil
+	for _, allocs := range plan.NodeAllocation {
+		planned = append(planned, allocs...)
+	}
+	require.Len(t, planned, 4)
+	for _, alloc := range planned {
+		require.Equal(t, job.TaskGroups[0].Name, alloc.TaskGroup)
+	}
+	require.Empty(t, h.Evals[1].FailedTGAllocs)
+}
+
+func TestServiceSched_JobRegister_AllOrNothing_Fits(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create some nodes
+	for i := 0; i < 5; i++ {
+		node := mock.Node()
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a job
+	job := mock.Job()
+	job.TaskGroups[0].Placement = structs.TaskGroupPlacementAllOrNothing
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the gang is placed in a plan that must be applied as a whole
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.False(t, plan.AllAtOnce)
+	var planned []*structs.Allocation
+	var nodeIDs []string
+	for nodeID, allocs := range plan.NodeAllocation {
+		planned = append(planned, allocs...)
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	require.Len(t, planned, 10)
+	require.Len(t, plan.Gangs, 1)
+	require.ElementsMatch(t, nodeIDs, plan.Gangs[job.TaskGroups[0].Name])
+	require.Empty(t, h.Evals[0].FailedTGAllocs)
+	require.Empty(t, h.CreateEvals)
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestServiceSched_JobModify_AllOrNothing_Canaries(t *testing.T) {
+	h := NewHarness(t)
+
+	// Create a node that only fits one of the canaries
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Generate a fake job with allocations
+	job := mock.Job()
+	job.TaskGroups[0].Count = 2
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	var allocs []*structs.Allocation
+	for i := 0; i < 2; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		alloc.Name = fmt.Sprintf("my-job.web[%d]", i)
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), allocs))
+
+	// Update the job to place canaries of a gang
+	job2 := job.Copy()
+	job2.TaskGroups[0].Placement = structs.TaskGroupPlacementAllOrNothing
+	job2.TaskGroups[0].Update = &structs.UpdateStrategy{
+		MaxParallel:     2,
+		Canary:          2,
+		HealthCheck:     structs.UpdateStrategyHealthCheck_Checks,
+		MinHealthyTime:  10 * time.Second,
+		HealthyDeadline: 10 * time.Minute,
+	}
+	job2.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	job2.TaskGroups[0].Tasks[0].Resources.CPU = 1500
+	job2.TaskGroups[0].Tasks[0].Resources.Networks = nil
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job2))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure the canary that fit was backed out of the plan and deployment
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+	require.Empty(t, plan.NodeAllocation)
+	require.Empty(t, plan.Gangs)
+	require.NotNil(t, plan.Deployment)
+
+	deploy, err := h.State.DeploymentByID(nil, plan.Deployment.ID)
+	require.NoError(t, err)
+	dstate := deploy.TaskGroups[job.TaskGroups[0].Name]
+	require.NotNil(t, dstate)
+	require.Equal(t, 2, dstate.DesiredCanaries)
+	require.Empty(t, dstate.PlacedCanaries)
+
+	out, err := h.State.AllocsByJob(nil, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+
+	metrics, ok := h.Evals[0].FailedTGAllocs[job.TaskGroups[0].Name]
+	require.True(t, ok)
+	require.Equal(t, 1, metrics.CoalescedFailures)
+}
+
 func TestServiceSched_JobRegister_FeasibleAndInfeasibleTG(t *testing.T) {
 	h := NewHarness(t)
 
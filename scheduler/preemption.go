@@ -3,6 +3,7 @@ package scheduler
 import (
 	"math"
 	"sort"
+	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -142,10 +143,14 @@ func (p *Preemptor) SetNode(node *structs.Node) {
 	p.nodeRemainingResources = nodeRemainingResources
 }
 
-// SetCandidates initializes the candidate set from which preemptions are chosen
+// SetCandidates initializes the candidate set from which preemptions are chosen.
+// Allocations are excluded if their job's preemption policy doesn't allow them
+// to be preempted now, or if their job's preemption budget is spent.
 func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 	// Reset candidate set
 	p.currentAllocs = []*structs.Allocation{}
+	now := time.Now()
+	budgets := make(map[structs.NamespacedID]int)
 	for _, alloc := range allocs {
 		// Ignore any allocations of the job being placed
 		// This filters out any previous allocs of the job, and any new allocs in the plan
@@ -153,6 +158,10 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 			continue
 		}
 
+		if !p.preemptionAllowed(alloc, now, budgets) {
+			continue
+		}
+
 		maxParallel := 0
 		tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
 		if tg != nil && tg.Migrate != nil {
@@ -163,6 +172,51 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 	}
 }
 
+// preemptionAllowed returns whether the allocation may be preempted at the
+// given time according to its job's preemption policy. The remaining budget of
+// each job is tracked in budgets, and an allowed allocation uses up one of its
+// job's budget so the candidate set never exceeds it.
+func (p *Preemptor) preemptionAllowed(alloc *structs.Allocation, now time.Time, budgets map[structs.NamespacedID]int) bool {
+	if alloc.Job == nil || alloc.Job.Preemption == nil {
+		return true
+	}
+	policy := alloc.Job.Preemption
+	if !policy.Preemptible || !policy.InMaintenanceWindow(now) {
+		return false
+	}
+
+	budget, ok := policy.Budget(alloc.Job)
+	if !ok {
+		return true
+	}
+
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	remaining, ok := budgets[id]
+	if !ok {
+		history, err := p.ctx.State().PreemptionHistoryByJob(nil, alloc.Namespace, alloc.JobID)
+		if err != nil {
+			p.ctx.Logger().Named("preemption").Error("failed to look up preemption history",
+				"job_id", alloc.JobID, "namespace", alloc.Namespace, "error", err)
+			budgets[id] = 0
+			return false
+		}
+
+		// Both the preemptions within the window and those already in the
+		// plan count against the budget
+		remaining = budget - history.PreemptionsSince(now.Add(-policy.Window).UnixNano())
+		for _, count := range p.currentPreemptions[id] {
+			remaining -= count
+		}
+	}
+
+	if remaining <= 0 {
+		budgets[id] = 0
+		return false
+	}
+	budgets[id] = remaining - 1
+	return true
+}
+
 // SetPreemptions initializes a map tracking existing counts of preempted allocations
 // per job/task group. This is used while scoring preemption options
 func (p *Preemptor) SetPreemptions(allocs []*structs.Allocation) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -1381,6 +1382,134 @@ func TestPreemption(t *testing.T) {
 	}
 }
 
+func TestPreemptor_SetCandidates_PreemptionPolicy(t *testing.T) {
+	now := time.Now()
+	later := now.Add(12 * time.Hour).UTC()
+
+	type testCase struct {
+		desc               string
+		policy             *structs.PreemptionPolicy
+		history            []int64
+		currentPreemptions int
+		expected           int
+	}
+
+	testCases := []testCase{
+		{
+			desc:     "no policy",
+			expected: 3,
+		},
+		{
+			desc:     "not preemptible",
+			policy:   &structs.PreemptionPolicy{Preemptible: false},
+			expected: 0,
+		},
+		{
+			desc: "outside maintenance window",
+			policy: &structs.PreemptionPolicy{
+				Preemptible: true,
+				MaintenanceWindows: []*structs.MaintenanceWindow{{
+					Cron:     fmt.Sprintf("%d %d * * *", later.Minute(), later.Hour()),
+					Duration: time.Hour,
+				}},
+			},
+			expected: 0,
+		},
+		{
+			desc: "inside maintenance window",
+			policy: &structs.PreemptionPolicy{
+				Preemptible: true,
+				MaintenanceWindows: []*structs.MaintenanceWindow{{
+					Cron:     "* * * * *",
+					Duration: 2 * time.Minute,
+				}},
+			},
+			expected: 3,
+		},
+		{
+			desc: "budget limits candidates",
+			policy: &structs.PreemptionPolicy{
+				Preemptible:    true,
+				MaxPreemptions: 2,
+				Window:         time.Hour,
+			},
+			expected: 2,
+		},
+		{
+			desc: "budget spent within window",
+			policy: &structs.PreemptionPolicy{
+				Preemptible:    true,
+				MaxPreemptions: 2,
+				Window:         time.Hour,
+			},
+			history: []int64{
+				now.Add(-2 * time.Hour).UnixNano(),
+				now.Add(-30 * time.Minute).UnixNano(),
+			},
+			expected: 1,
+		},
+		{
+			desc: "budget spent by plan",
+			policy: &structs.PreemptionPolicy{
+				Preemptible:    true,
+				MaxPreemptions: 2,
+				Window:         time.Hour,
+			},
+			currentPreemptions: 2,
+			expected:           0,
+		},
+		{
+			desc: "percentage budget",
+			policy: &structs.PreemptionPolicy{
+				Preemptible:           true,
+				MaxPreemptions:        5,
+				MaxPreemptionsPercent: 10,
+				Window:                time.Hour,
+			},
+			expected: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require := require.New(t)
+			state, ctx := testContext(t)
+
+			// mock.Job has a count of 10
+			lowPrioJob := mock.Job()
+			lowPrioJob.Priority = 30
+			lowPrioJob.Preemption = tc.policy
+
+			if tc.history != nil {
+				restore, err := state.Restore()
+				require.NoError(err)
+				require.NoError(restore.PreemptionHistoryRestore(&structs.JobPreemptionHistory{
+					Namespace:   lowPrioJob.Namespace,
+					JobID:       lowPrioJob.ID,
+					Preemptions: tc.history,
+				}))
+				require.NoError(restore.Commit())
+			}
+
+			var allocs []*structs.Allocation
+			for i := 0; i < 3; i++ {
+				allocs = append(allocs, createAlloc(uuid.Generate(), lowPrioJob, &structs.Resources{CPU: 500, MemoryMB: 256}))
+			}
+
+			var current []*structs.Allocation
+			for i := 0; i < tc.currentPreemptions; i++ {
+				current = append(current, createAlloc(uuid.Generate(), lowPrioJob, &structs.Resources{CPU: 500, MemoryMB: 256}))
+			}
+
+			job := mock.Job()
+			preemptor := NewPreemptor(job.Priority, ctx, &structs.NamespacedID{ID: job.ID, Namespace: job.Namespace})
+			preemptor.SetPreemptions(current)
+			preemptor.SetCandidates(allocs)
+			require.Len(preemptor.currentAllocs, tc.expected)
+		})
+	}
+}
+
 // helper method to create allocations with given jobs and resources
 func createAlloc(id string, job *structs.Job, resource *structs.Resources) *structs.Allocation {
 	return createAllocInner(id, job, resource, nil, nil)
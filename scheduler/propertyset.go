@@ -3,6 +3,7 @@ package scheduler
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	log "github.com/hashicorp/go-hclog"
 	memdb "github.com/hashicorp/go-memdb"
@@ -30,6 +31,10 @@ type propertySet struct {
 	// targetAttribute is the attribute this property set is checking
 	targetAttribute string
 
+	// targetAttributes is set instead of targetAttribute when the property
+	// set is checking the combined value of a hierarchy of attributes
+	targetAttributes []string
+
 	// allowedCount is the allowed number of allocations that can have the
 	// distinct property
 	allowedCount uint64
@@ -103,6 +108,14 @@ func (p *propertySet) SetTargetAttribute(targetAttribute string, taskGroup strin
 	p.setTargetAttributeWithCount(targetAttribute, 0, taskGroup)
 }
 
+// SetTargetAttributes is used to populate this property set with the combined
+// value of a hierarchy of attributes, such that the same rack in two zones
+// counts as two values. This is used when evaluating hierarchical spreads
+func (p *propertySet) SetTargetAttributes(targetAttributes []string, taskGroup string) {
+	p.targetAttributes = targetAttributes
+	p.setTargetAttributeWithCount(strings.Join(targetAttributes, propertyPathSeparator), 0, taskGroup)
+}
+
 // setTargetAttributeWithCount is a shared helper for setting a job or task group attribute and allowedCount
 // allowedCount can be zero when this is used in evaluating spread stanzas
 func (p *propertySet) setTargetAttributeWithCount(targetAttribute string, allowedCount uint64, taskGroup string) {
@@ -235,7 +248,7 @@ func (p *propertySet) UsedCount(option *structs.Node, tg string) (string, string
 	}
 
 	// Get the nodes property value
-	nValue, ok := getProperty(option, p.targetAttribute)
+	nValue, ok := p.getProperty(option)
 	if !ok {
 		return nValue, fmt.Sprintf("missing property %q", p.targetAttribute), 0
 	}
@@ -327,7 +340,7 @@ func (p *propertySet) populateProperties(allocs []*structs.Allocation, nodes map
 	properties map[string]uint64) {
 
 	for _, alloc := range allocs {
-		nProperty, ok := getProperty(nodes[alloc.NodeID], p.targetAttribute)
+		nProperty, ok := p.getProperty(nodes[alloc.NodeID])
 		if !ok {
 			continue
 		}
@@ -336,6 +349,32 @@ func (p *propertySet) populateProperties(allocs []*structs.Allocation, nodes map
 	}
 }
 
+// getProperty is used to lookup the value of the property set's target on the
+// node
+func (p *propertySet) getProperty(n *structs.Node) (string, bool) {
+	if len(p.targetAttributes) == 0 {
+		return getProperty(n, p.targetAttribute)
+	}
+	return getPropertyPath(n, p.targetAttributes)
+}
+
+// propertyPathSeparator joins the values of a hierarchy of properties
+const propertyPathSeparator = "/"
+
+// getPropertyPath is used to lookup the values of a hierarchy of properties on
+// the node, joined from the outermost to the innermost
+func getPropertyPath(n *structs.Node, properties []string) (string, bool) {
+	values := make([]string, len(properties))
+	for i, property := range properties {
+		value, ok := getProperty(n, property)
+		if !ok {
+			return "", false
+		}
+		values[i] = value
+	}
+	return strings.Join(values, propertyPathSeparator), true
+}
+
 // getProperty is used to lookup the property value on the node
 func getProperty(n *structs.Node, property string) (string, bool) {
 	if n == nil || property == "" {
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/hashicorp/nomad/lib/cpuset"
 
@@ -156,6 +157,7 @@ type BinPackIterator struct {
 	jobId                  structs.NamespacedID
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
+	schedConfig            *structs.SchedulerConfiguration
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
 }
 
@@ -164,10 +166,7 @@ type BinPackIterator struct {
 func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority int, schedConfig *structs.SchedulerConfiguration) *BinPackIterator {
 
 	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
-	scoreFn := structs.ScoreFitBinPack
-	if algorithm == structs.SchedulerAlgorithmSpread {
-		scoreFn = structs.ScoreFitSpread
-	}
+	scoreFn := algorithmScoreFit(algorithm)
 
 	iter := &BinPackIterator{
 		ctx:                    ctx,
@@ -175,6 +174,7 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 		evict:                  evict,
 		priority:               priority,
 		memoryOversubscription: schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled,
+		schedConfig:            schedConfig,
 		scoreFit:               scoreFn,
 	}
 	iter.ctx.Logger().Named("binpack").Trace("NewBinPackIterator created", "algorithm", algorithm)
@@ -184,6 +184,25 @@ func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority i
 func (iter *BinPackIterator) SetJob(job *structs.Job) {
 	iter.priority = job.Priority
 	iter.jobId = job.NamespacedID()
+
+	// Use the weighted fit function if the cluster or the job configure
+	// scoring, otherwise keep the one of the scheduler algorithm
+	if scoring := iter.schedConfig.EffectiveScoringConfig(job); scoring != nil {
+		iter.scoreFit = func(node *structs.Node, util *structs.ComparableResources) float64 {
+			return structs.ScoreFit(node, util, scoring)
+		}
+		iter.ctx.Logger().Named("binpack").Trace("using scoring config", "fit_function", scoring.FitFunction)
+	} else {
+		iter.scoreFit = algorithmScoreFit(iter.schedConfig.EffectiveSchedulerAlgorithm())
+	}
+}
+
+// algorithmScoreFit returns the fit function of the scheduler algorithm
+func algorithmScoreFit(algorithm structs.SchedulerAlgorithm) func(*structs.Node, *structs.ComparableResources) float64 {
+	if algorithm == structs.SchedulerAlgorithmSpread {
+		return structs.ScoreFitSpread
+	}
+	return structs.ScoreFitBinPack
 }
 
 func (iter *BinPackIterator) SetTaskGroup(taskGroup *structs.TaskGroup) {
@@ -645,6 +664,69 @@ func (iter *NodeReschedulingPenaltyIterator) Reset() {
 	iter.source.Reset()
 }
 
+// NodeMaintenancePenaltyIterator is used to apply a penalty to nodes with an
+// active maintenance window, or with a window starting within its lead time.
+// This avoids placing service allocations on nodes about to be drained.
+type NodeMaintenancePenaltyIterator struct {
+	ctx     Context
+	source  RankIterator
+	windows []*structs.NodeMaintenanceWindow
+}
+
+// NewNodeMaintenancePenaltyIterator is used to create a
+// NodeMaintenancePenaltyIterator that applies a scoring penalty for placement
+// onto nodes with an imminent maintenance window
+func NewNodeMaintenancePenaltyIterator(ctx Context, source RankIterator) *NodeMaintenancePenaltyIterator {
+	return &NodeMaintenancePenaltyIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+// SetJob loads the imminent maintenance windows if the job is a long running
+// service job.
+func (iter *NodeMaintenancePenaltyIterator) SetJob(job *structs.Job) {
+	iter.windows = nil
+	if job.Type != structs.JobTypeService {
+		return
+	}
+
+	windows, err := iter.ctx.State().NodeMaintenanceWindows(nil)
+	if err != nil {
+		iter.ctx.Logger().Named("node_maintenance_penalty").Error("failed retrieving maintenance windows", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for raw := windows.Next(); raw != nil; raw = windows.Next() {
+		window := raw.(*structs.NodeMaintenanceWindow)
+		if window.Imminent(now) {
+			iter.windows = append(iter.windows, window)
+		}
+	}
+}
+
+func (iter *NodeMaintenancePenaltyIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || len(iter.windows) == 0 {
+		return option
+	}
+
+	for _, window := range iter.windows {
+		if window.Includes(option.Node) {
+			option.Scores = append(option.Scores, -1)
+			iter.ctx.Metrics().ScoreNode(option.Node, "node-maintenance-penalty", -1)
+			return option
+		}
+	}
+	iter.ctx.Metrics().ScoreNode(option.Node, "node-maintenance-penalty", 0)
+	return option
+}
+
+func (iter *NodeMaintenancePenaltyIterator) Reset() {
+	iter.source.Reset()
+}
+
 // NodeAffinityIterator is used to resolve any affinity rules in the job or task group,
 // and apply a weighted score to nodes if they match.
 type NodeAffinityIterator struct {
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -136,6 +137,75 @@ func TestBinPackIterator_NoExistingAlloc(t *testing.T) {
 // TestBinPackIterator_NoExistingAlloc_MixedReserve asserts that node's with
 // reserved resources are scored equivalent to as if they had a lower amount of
 // resources.
+// Tests that the scoring weights of the job decide which resource is packed
+func TestBinPackIterator_ScoringWeights(t *testing.T) {
+	_, ctx := testContext(t)
+	newNode := func(cpu, memory int64) *RankedNode {
+		return &RankedNode{
+			Node: &structs.Node{
+				ID: uuid.Generate(),
+				NodeResources: &structs.NodeResources{
+					Cpu: structs.NodeCpuResources{
+						CpuShares: cpu,
+					},
+					Memory: structs.NodeMemoryResources{
+						MemoryMB: memory,
+					},
+				},
+			},
+		}
+	}
+	// The first node is short on memory, the second on cpu
+	nodes := []*RankedNode{newNode(4096, 1024), newNode(1024, 4096)}
+
+	taskGroup := &structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{
+			{
+				Name: "web",
+				Resources: &structs.Resources{
+					CPU:      512,
+					MemoryMB: 512,
+				},
+			},
+		},
+	}
+
+	best := func(scoring *structs.ScoringConfig) *RankedNode {
+		for _, node := range nodes {
+			node.Scores = nil
+			node.FinalScore = 0
+		}
+		job := mock.Job()
+		job.Scoring = scoring
+
+		static := NewStaticRankIterator(ctx, nodes)
+		binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
+		binp.SetJob(job)
+		binp.SetTaskGroup(taskGroup)
+		scoreNorm := NewScoreNormalizationIterator(ctx, binp)
+
+		out := collectRanked(scoreNorm)
+		require.Len(t, out, 2)
+		if out[0].FinalScore > out[1].FinalScore {
+			return out[0]
+		}
+		return out[1]
+	}
+
+	// Packing memory prefers the node short on memory
+	require.Equal(t, nodes[0], best(&structs.ScoringConfig{MemoryWeight: 1}))
+
+	// Packing cpu prefers the node short on cpu
+	require.Equal(t, nodes[1], best(&structs.ScoringConfig{CPUWeight: 1}))
+
+	// Spreading memory prefers the node with the most free memory
+	require.Equal(t, nodes[1], best(&structs.ScoringConfig{
+		FitFunction:  structs.FitFunctionWorstFit,
+		MemoryWeight: 1,
+	}))
+}
+
 func TestBinPackIterator_NoExistingAlloc_MixedReserve(t *testing.T) {
 	_, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -1741,6 +1811,52 @@ func TestNodeAntiAffinity_PenaltyNodes(t *testing.T) {
 
 }
 
+func TestNodeMaintenancePenaltyIterator(t *testing.T) {
+	state, ctx := testContext(t)
+	node1 := mock.Node()
+	node2 := mock.Node()
+	node2.Datacenter = "dc2"
+	node3 := mock.Node()
+	node3.Datacenter = "dc3"
+
+	// The first window is imminent and selects the first node by datacenter,
+	// while the second window for the third node starts too late
+	imminent := mock.NodeMaintenanceWindow()
+	imminent.NodeIDs = nil
+	imminent.StartTime = time.Now().Add(30 * time.Minute)
+	later := mock.NodeMaintenanceWindow()
+	later.NodeIDs = []string{node3.ID}
+	later.Filter = nil
+	later.StartTime = time.Now().Add(2 * time.Hour)
+	require.NoError(t, state.UpsertNodeMaintenanceWindows(structs.MsgTypeTestSetup, 1000,
+		&structs.NodeMaintenanceWindowUpsertRequest{Windows: []*structs.NodeMaintenanceWindow{imminent, later}}))
+
+	nodes := []*RankedNode{{Node: node1}, {Node: node2}, {Node: node3}}
+	static := NewStaticRankIterator(ctx, nodes)
+
+	maintenanceIter := NewNodeMaintenancePenaltyIterator(ctx, static)
+	maintenanceIter.SetJob(mock.Job())
+	scoreNorm := NewScoreNormalizationIterator(ctx, maintenanceIter)
+
+	out := collectRanked(scoreNorm)
+	require.Len(t, out, 3)
+	require.Equal(t, node1.ID, out[0].Node.ID)
+	require.Equal(t, -1.0, out[0].FinalScore)
+	require.Equal(t, 0.0, out[1].FinalScore)
+	require.Equal(t, 0.0, out[2].FinalScore)
+
+	// Batch jobs aren't long running, and aren't penalized
+	static.Reset()
+	for _, n := range nodes {
+		n.Scores = nil
+		n.FinalScore = 0
+	}
+	maintenanceIter.SetJob(mock.BatchJob())
+	out = collectRanked(scoreNorm)
+	require.Len(t, out, 3)
+	require.Equal(t, 0.0, out[0].FinalScore)
+}
+
 func TestScoreNormalizationIterator(t *testing.T) {
 	// Test normalized scores when there is more than one scorer
 	_, ctx := testContext(t)
@@ -370,6 +370,9 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 			dstate.AutoRevert = tg.Update.AutoRevert
 			dstate.AutoPromote = tg.Update.AutoPromote
 			dstate.ProgressDeadline = tg.Update.ProgressDeadline
+			dstate.CanarySteps = tg.Update.Copy().CanarySteps
+			dstate.BlueGreen = tg.Update.BlueGreen()
+			dstate.RetainPrevious = tg.Update.RetainPrevious
 		}
 	}
 
@@ -382,6 +385,10 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	// allocs including the canaries
 	canaries, all := a.handleGroupCanaries(all, desiredChanges)
 
+	// Allocations on standby are left alone while a blue/green deployment
+	// retains them and stopped afterwards
+	all = a.handleGroupStandby(all, dstate, desiredChanges)
+
 	// Determine what set of allocations are on tainted nodes
 	untainted, migrate, lost := all.filterByTainted(a.taintedNodes)
 
@@ -424,15 +431,26 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	}
 
 	// The fact that we have destructive updates and have less canaries than is
-	// desired means we need to create canaries
+	// desired means we need to create canaries. A progressive deployment
+	// places the canaries of its current canary step.
 	strategy := tg.Update
+	desiredCanaries := 0
+	if strategy != nil {
+		desiredCanaries = strategy.Canary
+	}
+	if step := dstate.CurrentCanaryStep(); step != nil {
+		desiredCanaries = step.Canaries(tg.Count)
+	}
+	if strategy.BlueGreen() {
+		desiredCanaries = tg.Count
+	}
 	canariesPromoted := dstate != nil && dstate.Promoted
-	requireCanary := len(destructive) != 0 && strategy != nil && len(canaries) < strategy.Canary && !canariesPromoted
+	requireCanary := len(destructive) != 0 && strategy != nil && len(canaries) < desiredCanaries && !canariesPromoted
 	if requireCanary {
-		dstate.DesiredCanaries = strategy.Canary
+		dstate.DesiredCanaries = desiredCanaries
 	}
 	if requireCanary && !a.deploymentPaused && !a.deploymentFailed {
-		number := strategy.Canary - len(canaries)
+		number := desiredCanaries - len(canaries)
 		desiredChanges.Canary += uint64(number)
 
 		for _, name := range nameIndex.NextCanaries(uint(number), canaries, destructive) {
@@ -577,7 +595,8 @@ func (a *allocReconciler) computeGroup(group string, all allocSet) bool {
 	if deploymentComplete && a.deployment != nil {
 		if dstate, ok := a.deployment.TaskGroups[group]; ok {
 			if dstate.HealthyAllocs < helper.IntMax(dstate.DesiredTotal, dstate.DesiredCanaries) || // Make sure we have enough healthy allocs
-				(dstate.DesiredCanaries > 0 && !dstate.Promoted) { // Make sure we are promoted if we have canaries
+				(dstate.DesiredCanaries > 0 && !dstate.Promoted) || // Make sure we are promoted if we have canaries
+				dstate.Retaining(a.now) { // Make sure we are no longer retaining the previous allocs
 				deploymentComplete = false
 			}
 		}
@@ -660,6 +679,29 @@ func (a *allocReconciler) handleGroupCanaries(all allocSet, desiredChanges *stru
 	return canaries, all
 }
 
+// handleGroupStandby handles the allocations put on standby by a blue/green
+// deployment. They are ignored while the active deployment retains them, and
+// otherwise stopped. It returns the remaining set of allocs.
+func (a *allocReconciler) handleGroupStandby(all allocSet, dstate *structs.DeploymentState, desiredChanges *structs.DesiredUpdates) allocSet {
+	standby := make(allocSet)
+	for id, alloc := range all {
+		if alloc.DeploymentStatus.IsStandby() && !alloc.TerminalStatus() {
+			standby[id] = alloc
+		}
+	}
+	if len(standby) == 0 {
+		return all
+	}
+
+	if a.deployment != nil && a.deployment.Active() && dstate.Retaining(a.now) {
+		desiredChanges.Ignore += uint64(len(standby))
+	} else {
+		a.markStop(standby, "", allocNotNeeded)
+		desiredChanges.Stop += uint64(len(standby))
+	}
+	return all.difference(standby)
+}
+
 // computeLimit returns the placement limit for a particular group. The inputs
 // are the group definition, the untainted, destructive, and migrate allocation
 // set and whether we are in a canary state.
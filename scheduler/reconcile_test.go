@@ -3554,6 +3554,313 @@ func TestReconciler_NewCanaries_FillNames(t *testing.T) {
 	assertNamesHaveIndexes(t, intRange(1, 2), placeResultsToNames(r.place))
 }
 
+// Tests the reconciler places the canaries of the canary step a progressive
+// deployment is at
+func TestReconciler_NewCanaries_CanarySteps(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = &structs.UpdateStrategy{
+		MaxParallel:     2,
+		HealthCheck:     structs.UpdateStrategyHealthCheck_Checks,
+		MinHealthyTime:  10 * time.Second,
+		HealthyDeadline: 10 * time.Minute,
+		CanarySteps: []*structs.CanaryStep{
+			{Percent: 10},
+			{Percent: 25},
+		},
+	}
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	// A new deployment places the canaries of the first step
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	newD := structs.NewDeployment(job)
+	newD.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	newD.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		CanarySteps:     job.TaskGroups[0].Update.CanarySteps,
+		DesiredCanaries: 1,
+		DesiredTotal:    10,
+	}
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  newD,
+		deploymentUpdates: nil,
+		place:             1,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 1,
+				Ignore: 10,
+			},
+		},
+	})
+
+	// A deployment at the second step places the rest of its canaries
+	d := newD.Copy()
+	dstate := d.TaskGroups[job.TaskGroups[0].Name]
+	dstate.CanaryStep = 1
+	dstate.DesiredCanaries = 3
+
+	canary := mock.Alloc()
+	canary.Job = job
+	canary.JobID = job.ID
+	canary.NodeID = uuid.Generate()
+	canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, 0)
+	canary.TaskGroup = job.TaskGroups[0].Name
+	canary.DeploymentID = d.ID
+	dstate.PlacedCanaries = []string{canary.ID}
+	allocs = append(allocs, canary)
+
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job, d, allocs, nil, "")
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		place:             2,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 2,
+				Ignore: 11,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(1, 2), placeResultsToNames(r.place))
+}
+
+// Tests the reconciler places a full set of canaries for a blue/green
+// deployment
+func TestReconciler_NewCanaries_BlueGreen(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.Canary = 0
+	job.TaskGroups[0].Update.Strategy = structs.UpdateStrategyBlueGreen
+	job.TaskGroups[0].Update.RetainPrevious = time.Hour
+
+	// Create 10 allocations from the old job
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		allocs = append(allocs, alloc)
+	}
+
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), allocUpdateFnDestructive, false, job.ID, job, nil, allocs, nil, "")
+	r := reconciler.Compute()
+
+	newD := structs.NewDeployment(job)
+	newD.StatusDescription = structs.DeploymentStatusDescriptionRunningNeedsPromotion
+	newD.TaskGroups[job.TaskGroups[0].Name] = &structs.DeploymentState{
+		BlueGreen:       true,
+		RetainPrevious:  time.Hour,
+		DesiredCanaries: 10,
+		DesiredTotal:    10,
+	}
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  newD,
+		deploymentUpdates: nil,
+		place:             10,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Canary: 10,
+				Ignore: 10,
+			},
+		},
+	})
+
+	assertNamesHaveIndexes(t, intRange(0, 9), placeResultsToNames(r.place))
+}
+
+// Tests the reconciler keeps the previous allocations of a promoted blue/green
+// deployment on standby until the retention period is over
+func TestReconciler_PromoteCanaries_BlueGreen_Retain(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.Canary = 0
+	job.TaskGroups[0].Update.Strategy = structs.UpdateStrategyBlueGreen
+	job.TaskGroups[0].Update.RetainPrevious = time.Hour
+
+	d := structs.NewDeployment(job)
+	s := &structs.DeploymentState{
+		BlueGreen:       true,
+		RetainPrevious:  time.Hour,
+		RetainUntil:     time.Now().Add(time.Hour),
+		Promoted:        true,
+		DesiredTotal:    10,
+		DesiredCanaries: 10,
+		PlacedAllocs:    10,
+		HealthyAllocs:   10,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 10 allocations from the old job that are on standby
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		alloc.DeploymentStatus = &structs.AllocDeploymentStatus{Standby: true}
+		s.PreviousAllocs = append(s.PreviousAllocs, alloc.ID)
+		allocs = append(allocs, alloc)
+	}
+
+	// Create the promoted canaries
+	handled := make(map[string]allocUpdateType)
+	for i := 0; i < 10; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+		}
+		allocs = append(allocs, canary)
+		handled[canary.ID] = allocUpdateFnIgnore
+	}
+
+	// While retaining, the previous allocations are left running
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job, d, allocs, nil, "")
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Ignore: 20,
+			},
+		},
+	})
+
+	// Once the retention period is over they are stopped and the deployment
+	// completes
+	s.RetainUntil = time.Now().Add(-time.Second)
+	reconciler = NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job, d, allocs, nil, "")
+	r = reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment: nil,
+		deploymentUpdates: []*structs.DeploymentStatusUpdate{
+			{
+				DeploymentID:      d.ID,
+				Status:            structs.DeploymentStatusSuccessful,
+				StatusDescription: structs.DeploymentStatusDescriptionSuccessful,
+			},
+		},
+		stop: 10,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   10,
+				Ignore: 10,
+			},
+		},
+	})
+
+	for _, stop := range r.stop {
+		require.True(t, stop.alloc.DeploymentStatus.IsStandby())
+	}
+}
+
+// Tests the reconciler stops the allocations put on standby by a failed
+// blue/green deployment
+func TestReconciler_FailedDeployment_BlueGreen_StopStandby(t *testing.T) {
+	job := mock.Job()
+	job.TaskGroups[0].Update = canaryUpdate.Copy()
+	job.TaskGroups[0].Update.Canary = 0
+	job.TaskGroups[0].Update.Strategy = structs.UpdateStrategyBlueGreen
+	job.TaskGroups[0].Update.RetainPrevious = time.Hour
+
+	d := structs.NewDeployment(job)
+	d.Status = structs.DeploymentStatusFailed
+	s := &structs.DeploymentState{
+		BlueGreen:       true,
+		RetainPrevious:  time.Hour,
+		RetainUntil:     time.Now().Add(time.Hour),
+		Promoted:        true,
+		DesiredTotal:    10,
+		DesiredCanaries: 10,
+		PlacedAllocs:    10,
+	}
+	d.TaskGroups[job.TaskGroups[0].Name] = s
+
+	// Create 10 running allocations that were restored when the deployment
+	// failed
+	handled := make(map[string]allocUpdateType)
+	var allocs []*structs.Allocation
+	for i := 0; i < 10; i++ {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = uuid.Generate()
+		alloc.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		alloc.TaskGroup = job.TaskGroups[0].Name
+		s.PreviousAllocs = append(s.PreviousAllocs, alloc.ID)
+		allocs = append(allocs, alloc)
+		handled[alloc.ID] = allocUpdateFnIgnore
+	}
+
+	// Create the canaries that were put on standby
+	for i := 0; i < 10; i++ {
+		canary := mock.Alloc()
+		canary.Job = job
+		canary.JobID = job.ID
+		canary.NodeID = uuid.Generate()
+		canary.Name = structs.AllocName(job.ID, job.TaskGroups[0].Name, uint(i))
+		canary.TaskGroup = job.TaskGroups[0].Name
+		s.PlacedCanaries = append(s.PlacedCanaries, canary.ID)
+		canary.DeploymentID = d.ID
+		canary.DeploymentStatus = &structs.AllocDeploymentStatus{
+			Healthy: helper.BoolToPtr(true),
+			Standby: true,
+		}
+		allocs = append(allocs, canary)
+	}
+
+	mockUpdateFn := allocUpdateFnMock(handled, allocUpdateFnDestructive)
+	reconciler := NewAllocReconciler(testlog.HCLogger(t), mockUpdateFn, false, job.ID, job, d, allocs, nil, "")
+	r := reconciler.Compute()
+
+	assertResults(t, r, &resultExpectation{
+		createDeployment:  nil,
+		deploymentUpdates: nil,
+		stop:              10,
+		desiredTGUpdates: map[string]*structs.DesiredUpdates{
+			job.TaskGroups[0].Name: {
+				Stop:   10,
+				Ignore: 10,
+			},
+		},
+	})
+
+	for _, stop := range r.stop {
+		require.Contains(t, s.PlacedCanaries, stop.alloc.ID)
+	}
+}
+
 // Tests the reconciler handles canary promotion by unblocking max_parallel
 func TestReconciler_PromoteCanaries_Unblock(t *testing.T) {
 	job := mock.Job()
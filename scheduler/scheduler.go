@@ -107,6 +107,17 @@ type State interface {
 
 	// CSIVolumeByID fetch CSI volumes, containing controller jobs
 	CSIVolumesByNodeID(memdb.WatchSet, string, string) (memdb.ResultIterator, error)
+
+	// HostVolumeByID fetches a dynamic host volume
+	HostVolumeByID(memdb.WatchSet, string, string) (*structs.HostVolume, error)
+
+	// PreemptionHistoryByJob returns when allocations of a job with a
+	// preemption budget were recently preempted
+	PreemptionHistoryByJob(ws memdb.WatchSet, namespace, jobID string) (*structs.JobPreemptionHistory, error)
+
+	// NodeMaintenanceWindows returns an iterator over all the node
+	// maintenance windows
+	NodeMaintenanceWindows(ws memdb.WatchSet) (memdb.ResultIterator, error)
 }
 
 // Planner interface is used to submit a task allocation plan.
@@ -1,6 +1,8 @@
 package scheduler
 
 import (
+	"strings"
+
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -38,6 +40,10 @@ type SpreadIterator struct {
 	// existing allocs are computed once, and allocs from the plan are updated
 	// when Reset is called
 	groupPropertySets map[string][]*propertySet
+
+	// groupHierarchies is a memoized map from task group to the property
+	// sets of its hierarchical spreads
+	groupHierarchies map[string][]*spreadHierarchy
 }
 
 type spreadAttributeMap map[string]*spreadInfo
@@ -47,11 +53,20 @@ type spreadInfo struct {
 	desiredCounts map[string]float64
 }
 
+// spreadHierarchy tracks a hierarchical spread with a property set per level.
+// The property set of each level tracks the combined value of all the
+// attributes up to that level.
+type spreadHierarchy struct {
+	weight int8
+	levels []*propertySet
+}
+
 func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
 	iter := &SpreadIterator{
 		ctx:               ctx,
 		source:            source,
 		groupPropertySets: make(map[string][]*propertySet),
+		groupHierarchies:  make(map[string][]*spreadHierarchy),
 		tgSpreadInfo:      make(map[string]spreadAttributeMap),
 	}
 	return iter
@@ -64,6 +79,13 @@ func (iter *SpreadIterator) Reset() {
 			ps.PopulateProposed()
 		}
 	}
+	for _, hierarchies := range iter.groupHierarchies {
+		for _, h := range hierarchies {
+			for _, ps := range h.levels {
+				ps.PopulateProposed()
+			}
+		}
+	}
 }
 
 func (iter *SpreadIterator) SetJob(job *structs.Job) {
@@ -78,15 +100,19 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 
 	// Build the property set at the taskgroup level
 	if _, ok := iter.groupPropertySets[tg.Name]; !ok {
-		// First add property sets that are at the job level for this task group
-		for _, spread := range iter.jobSpreads {
-			pset := NewPropertySet(iter.ctx, iter.job)
-			pset.SetTargetAttribute(spread.Attribute, tg.Name)
-			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
-		}
+		// First add property sets that are at the job level for this task
+		// group, then include property sets at the task group level
+		spreads := make([]*structs.Spread, 0, len(iter.jobSpreads)+len(tg.Spreads))
+		spreads = append(spreads, iter.jobSpreads...)
+		spreads = append(spreads, tg.Spreads...)
+
+		iter.groupPropertySets[tg.Name] = []*propertySet{}
+		for _, spread := range spreads {
+			if len(spread.Hierarchy) > 0 {
+				iter.groupHierarchies[tg.Name] = append(iter.groupHierarchies[tg.Name], iter.newSpreadHierarchy(spread, tg.Name))
+				continue
+			}
 
-		// Include property sets at the task group level
-		for _, spread := range tg.Spreads {
 			pset := NewPropertySet(iter.ctx, iter.job)
 			pset.SetTargetAttribute(spread.Attribute, tg.Name)
 			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
@@ -94,7 +120,7 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 	}
 
 	// Check if there are any spreads configured
-	iter.hasSpread = len(iter.groupPropertySets[tg.Name]) != 0
+	iter.hasSpread = len(iter.groupPropertySets[tg.Name]) != 0 || len(iter.groupHierarchies[tg.Name]) != 0
 
 	// Build tgSpreadInfo at the task group level
 	if _, ok := iter.tgSpreadInfo[tg.Name]; !ok {
@@ -103,6 +129,21 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 
 }
 
+// newSpreadHierarchy builds the property sets of each level of a hierarchical
+// spread
+func (iter *SpreadIterator) newSpreadHierarchy(spread *structs.Spread, tgName string) *spreadHierarchy {
+	h := &spreadHierarchy{
+		weight: spread.Weight,
+		levels: make([]*propertySet, len(spread.Hierarchy)),
+	}
+	for i := range spread.Hierarchy {
+		pset := NewPropertySet(iter.ctx, iter.job)
+		pset.SetTargetAttributes(spread.Hierarchy[:i+1], tgName)
+		h.levels[i] = pset
+	}
+	return h
+}
+
 func (iter *SpreadIterator) hasSpreads() bool {
 	return iter.hasSpread
 }
@@ -165,6 +206,12 @@ func (iter *SpreadIterator) Next() *RankedNode {
 			}
 		}
 
+		// Add the weighted score of each hierarchical spread
+		for _, h := range iter.groupHierarchies[tgName] {
+			spreadWeight := float64(h.weight) / float64(iter.sumSpreadWeights)
+			totalSpreadScore += iter.hierarchyScore(h, option.Node) * spreadWeight
+		}
+
 		if totalSpreadScore != 0.0 {
 			option.Scores = append(option.Scores, totalSpreadScore)
 			iter.ctx.Metrics().ScoreNode(option.Node, "allocation-spread", totalSpreadScore)
@@ -173,6 +220,50 @@ func (iter *SpreadIterator) Next() *RankedNode {
 	}
 }
 
+// hierarchyScore scores the option for a hierarchical spread. Each level is
+// scored like an even spread, but only against the values sharing the
+// option's value at the level above, so that racks are balanced within each
+// zone while zones are balanced against each other. The score is the average
+// of all levels and each level is recorded in the score metadata.
+func (iter *SpreadIterator) hierarchyScore(h *spreadHierarchy, option *structs.Node) float64 {
+	total := 0.0
+	parent := ""
+	for i, pset := range h.levels {
+		nValue, errorMsg, _ := pset.UsedCount(option, iter.tg.Name)
+		if errorMsg != "" {
+			// Use the maximum possible penalty for this level and every
+			// level below it, as they can't be resolved either
+			iter.ctx.Logger().Named("spread").Debug("error building spread attributes for task group", "task_group", iter.tg.Name, "error", errorMsg)
+			total -= float64(len(h.levels) - i)
+			break
+		}
+
+		siblings := pset.GetCombinedUseMap()
+		if i > 0 {
+			siblings = filterUseMapByParent(siblings, parent)
+		}
+		score := evenSpreadScore(siblings, nValue)
+		iter.ctx.Metrics().ScoreNode(option, "allocation-spread."+pset.targetAttributes[i], score)
+
+		total += score
+		parent = nValue
+	}
+	return total / float64(len(h.levels))
+}
+
+// filterUseMapByParent returns the values of a hierarchical property set's
+// combined use map that are children of parent
+func filterUseMapByParent(useMap map[string]uint64, parent string) map[string]uint64 {
+	prefix := parent + propertyPathSeparator
+	filtered := make(map[string]uint64)
+	for value, count := range useMap {
+		if strings.HasPrefix(value, prefix) {
+			filtered[value] = count
+		}
+	}
+	return filtered
+}
+
 // evenSpreadScoreBoost is a scoring helper that calculates the score
 // for the option when even spread is desired (all attribute values get equal preference)
 func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
@@ -182,12 +273,22 @@ func evenSpreadScoreBoost(pset *propertySet, option *structs.Node) float64 {
 		return 0.0
 	}
 	// Get the nodes property value
-	nValue, ok := getProperty(option, pset.targetAttribute)
+	nValue, ok := pset.getProperty(option)
 
 	// Maximum possible penalty when the attribute isn't set on the node
 	if !ok {
 		return -1.0
 	}
+	return evenSpreadScore(combinedUseMap, nValue)
+}
+
+// evenSpreadScore calculates the score of placing on a node with the
+// attribute value nValue, given the number of times each value is used
+func evenSpreadScore(combinedUseMap map[string]uint64, nValue string) float64 {
+	if len(combinedUseMap) == 0 {
+		// Nothing placed yet, so return 0 as the score
+		return 0.0
+	}
 	currentAttributeCount := combinedUseMap[nValue]
 	minCount := uint64(0)
 	maxCount := uint64(0)
@@ -238,6 +339,12 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	combinedSpreads = append(combinedSpreads, tg.Spreads...)
 	combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
 	for _, spread := range combinedSpreads {
+		if len(spread.Hierarchy) > 0 {
+			// Hierarchical spreads have no targets, only their weight
+			// is needed
+			iter.sumSpreadWeights += int32(spread.Weight)
+			continue
+		}
 		si := &spreadInfo{weight: spread.Weight, desiredCounts: make(map[string]float64)}
 		sumDesiredCounts := 0.0
 		for _, st := range spread.SpreadTarget {
@@ -459,6 +459,121 @@ func TestSpreadIterator_EvenSpread(t *testing.T) {
 }
 
 // Test scenarios where the spread iterator sets maximum penalty (-1.0)
+func TestSpreadIterator_Hierarchy(t *testing.T) {
+	state, ctx := testContext(t)
+	var nodes []*RankedNode
+
+	// Add a node for each rack of two zones to the state store
+	for i, path := range []string{"a/r1", "a/r2", "b/r1", "b/r2"} {
+		node := mock.Node()
+		node.Meta["zone"] = path[:1]
+		node.Meta["rack"] = path[2:]
+		if err := state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node); err != nil {
+			t.Fatalf("failed to upsert node: %v", err)
+		}
+		nodes = append(nodes, &RankedNode{Node: node})
+	}
+	nodePath := func(n *structs.Node) string {
+		return n.Meta["zone"] + "/" + n.Meta["rack"]
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Count = 4
+
+	// Configure a hierarchical spread across zones and racks
+	tg.Spreads = []*structs.Spread{{
+		Weight:    100,
+		Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+	}}
+
+	place := func(node *RankedNode) {
+		ctx.plan.NodeAllocation[node.Node.ID] = append(ctx.plan.NodeAllocation[node.Node.ID], &structs.Allocation{
+			Namespace: structs.DefaultNamespace,
+			TaskGroup: tg.Name,
+			JobID:     job.ID,
+			Job:       job,
+			ID:        uuid.Generate(),
+			NodeID:    node.Node.ID,
+		})
+	}
+	score := func() map[string]float64 {
+		for _, node := range nodes {
+			node.Scores = nil
+			node.FinalScore = 0
+		}
+		static := NewStaticRankIterator(ctx, nodes)
+		spreadIter := NewSpreadIterator(ctx, static)
+		spreadIter.SetJob(job)
+		spreadIter.SetTaskGroup(tg)
+		scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+
+		scores := make(map[string]float64)
+		for _, rn := range collectRanked(scoreNorm) {
+			scores[nodePath(rn.Node)] = rn.FinalScore
+		}
+		return scores
+	}
+
+	// One alloc in r1 of each zone, so the zones are balanced and r2 is
+	// preferred in both
+	place(nodes[0])
+	place(nodes[2])
+	require.Equal(t, map[string]float64{
+		"a/r1": -1,
+		"a/r2": 0,
+		"b/r1": -1,
+		"b/r2": 0,
+	}, score())
+
+	// A second alloc in zone a tips the balance to zone b, and to the
+	// empty rack in it
+	place(nodes[1])
+	require.Equal(t, map[string]float64{
+		"a/r1": -1,
+		"a/r2": -1,
+		"b/r1": 0,
+		"b/r2": 1,
+	}, score())
+
+	// The score of each level is recorded in the metadata
+	ctx.Metrics().PopulateScoreMetaData()
+	require.NotEmpty(t, ctx.Metrics().ScoreMetaData)
+	for _, meta := range ctx.Metrics().ScoreMetaData {
+		require.Contains(t, meta.Scores, "allocation-spread.${meta.zone}")
+		require.Contains(t, meta.Scores, "allocation-spread.${meta.rack}")
+	}
+}
+
+func TestSpreadIterator_Hierarchy_MissingAttribute(t *testing.T) {
+	state, ctx := testContext(t)
+
+	// The node has a zone but no rack
+	node := mock.Node()
+	node.Meta["zone"] = "a"
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 100, node))
+	nodes := []*RankedNode{{Node: node}}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Weight:    100,
+		Hierarchy: []string{"${meta.zone}", "${meta.rack}"},
+	}}
+
+	static := NewStaticRankIterator(ctx, nodes)
+	spreadIter := NewSpreadIterator(ctx, static)
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+	scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+	out := collectRanked(scoreNorm)
+
+	// Nothing is placed so the zone level scores 0, and the rack level
+	// gets the maximum penalty
+	require.Len(t, out, 1)
+	require.Equal(t, -0.5, out[0].FinalScore)
+}
+
 func TestSpreadIterator_MaxPenalty(t *testing.T) {
 	state, ctx := testContext(t)
 	var nodes []*RankedNode
@@ -61,6 +61,7 @@ type GenericStack struct {
 	binPack                    *BinPackIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
+	nodeMaintenancePenalty     *NodeMaintenancePenaltyIterator
 	limit                      *LimitIterator
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
@@ -103,10 +104,12 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	s.distinctPropertyConstraint.SetJob(job)
 	s.binPack.SetJob(job)
 	s.jobAntiAff.SetJob(job)
+	s.nodeMaintenancePenalty.SetJob(job)
 	s.nodeAffinity.SetJob(job)
 	s.spread.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
+	s.taskGroupHostVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
@@ -292,6 +295,7 @@ func (s *SystemStack) SetJob(job *structs.Job) {
 	s.distinctPropertyConstraint.SetJob(job)
 	s.binPack.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
+	s.taskGroupHostVolumes.SetNamespace(job.Namespace)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetJob(job)
@@ -410,8 +414,12 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// node where the allocation failed previously
 	s.nodeReschedulingPenalty = NewNodeReschedulingPenaltyIterator(ctx, s.jobAntiAff)
 
+	// Apply node maintenance penalty. This tries to avoid placing service
+	// allocations on a node that is about to be drained for maintenance
+	s.nodeMaintenancePenalty = NewNodeMaintenancePenaltyIterator(ctx, s.nodeReschedulingPenalty)
+
 	// Apply scores based on affinity stanza
-	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeReschedulingPenalty)
+	s.nodeAffinity = NewNodeAffinityIterator(ctx, s.nodeMaintenancePenalty)
 
 	// Apply scores based on spread stanza
 	s.spread = NewSpreadIterator(ctx, s.nodeAffinity)
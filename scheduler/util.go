@@ -377,6 +377,43 @@ func shuffleNodes(nodes []*structs.Node) {
 // taskUpdated and functions called within assume that the given
 // taskGroup has already been checked to not be nil
 func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
+	return tasksUpdatedImpl(jobA, jobB, taskGroup, false)
+}
+
+// resizeOnlyUpdate returns whether the only changes to the task group that
+// require a destructive update are to the CPU or memory of its tasks. Such
+// changes can be applied in-place on nodes whose task drivers support
+// resizing running tasks, see nodeSupportsResize.
+func resizeOnlyUpdate(jobA, jobB *structs.Job, taskGroup string) bool {
+	return !tasksUpdatedImpl(jobA, jobB, taskGroup, true)
+}
+
+// nodeSupportsResize returns whether the task drivers on the node can resize
+// every task of the task group whose CPU or memory differs between the jobs.
+func nodeSupportsResize(node *structs.Node, jobA, jobB *structs.Job, taskGroup string) bool {
+	a := jobA.LookupTaskGroup(taskGroup)
+	b := jobB.LookupTaskGroup(taskGroup)
+	for _, at := range a.Tasks {
+		bt := b.LookupTask(at.Name)
+		if bt == nil {
+			return false
+		}
+		if resourcesResized(at.Resources, bt.Resources) && !node.DriverSupportsResize(at.Driver) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourcesResized returns whether the CPU or memory differ between the
+// resources.
+func resourcesResized(a, b *structs.Resources) bool {
+	return a.CPU != b.CPU || a.MemoryMB != b.MemoryMB || a.MemoryMaxMB != b.MemoryMaxMB
+}
+
+// tasksUpdatedImpl implements tasksUpdated. If ignoreResize is set, changes
+// to the CPU or memory of tasks are not considered updates.
+func tasksUpdatedImpl(jobA, jobB *structs.Job, taskGroup string, ignoreResize bool) bool {
 	a := jobA.LookupTaskGroup(taskGroup)
 	b := jobB.LookupTaskGroup(taskGroup)
 
@@ -456,14 +493,10 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 		}
 
 		// Inspect the non-network resources
-		if ar, br := at.Resources, bt.Resources; ar.CPU != br.CPU {
+		if ar, br := at.Resources, bt.Resources; !ignoreResize && resourcesResized(ar, br) {
 			return true
 		} else if ar.Cores != br.Cores {
 			return true
-		} else if ar.MemoryMB != br.MemoryMB {
-			return true
-		} else if ar.MemoryMaxMB != br.MemoryMaxMB {
-			return true
 		} else if !ar.Devices.Equals(&br.Devices) {
 			return true
 		}
@@ -709,10 +742,16 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 		update := updates[i]
 
 		// Check if the task drivers or config has changed, requires
-		// a rolling upgrade since that cannot be done in-place.
+		// a rolling upgrade since that cannot be done in-place. Changes to
+		// only the CPU or memory of running tasks can still be done
+		// in-place if the node supports resizing them.
 		existing := update.Alloc.Job
+		resize := false
 		if tasksUpdated(job, existing, update.TaskGroup.Name) {
-			continue
+			if update.Alloc.TerminalStatus() || !resizeOnlyUpdate(job, existing, update.TaskGroup.Name) {
+				continue
+			}
+			resize = true
 		}
 
 		// Terminal batch allocations are not filtered when they are completed
@@ -739,6 +778,11 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 			continue
 		}
 
+		// The task drivers on the node must be able to resize the tasks
+		if resize && !nodeSupportsResize(node, job, existing, update.TaskGroup.Name) {
+			continue
+		}
+
 		// Set the existing node as the base set
 		stack.SetNodes([]*structs.Node{node})
 
@@ -998,9 +1042,15 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 		}
 
 		// Check if the task drivers or config has changed, requires
-		// a destructive upgrade since that cannot be done in-place.
+		// a destructive upgrade since that cannot be done in-place. Changes
+		// to only the CPU or memory of running tasks can still be done
+		// in-place if the node supports resizing them.
+		resize := false
 		if tasksUpdated(newJob, existing.Job, newTG.Name) {
-			return false, true, nil
+			if existing.TerminalStatus() || !resizeOnlyUpdate(newJob, existing.Job, newTG.Name) {
+				return false, true, nil
+			}
+			resize = true
 		}
 
 		// Terminal batch allocations are not filtered when they are completed
@@ -1027,6 +1077,11 @@ func genericAllocUpdateFn(ctx Context, stack Stack, evalID string) allocUpdateTy
 			return false, true, nil
 		}
 
+		// The task drivers on the node must be able to resize the tasks
+		if resize && !nodeSupportsResize(node, newJob, existing.Job, newTG.Name) {
+			return false, true, nil
+		}
+
 		// Set the existing node as the base set
 		stack.SetNodes([]*structs.Node{node})
 
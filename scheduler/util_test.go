@@ -1242,6 +1242,83 @@ func TestInplaceUpdate_Success(t *testing.T) {
 	}
 }
 
+func TestInplaceUpdate_Resize(t *testing.T) {
+	cases := []struct {
+		name    string
+		resize  bool
+		inplace bool
+	}{
+		{name: "driver supports resize", resize: true, inplace: true},
+		{name: "driver does not support resize", resize: false, inplace: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+			eval := mock.Eval()
+			job := mock.Job()
+
+			node := mock.Node()
+			if tc.resize {
+				node.Attributes[structs.DriverResizeAttribute("exec")] = "true"
+			}
+			require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 900, node))
+
+			alloc := mock.Alloc()
+			alloc.NodeID = node.ID
+			alloc.Job = job
+			alloc.JobID = job.ID
+			require.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc.JobID)))
+			require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+			// Update the CPU and memory of the task
+			newJob := job.Copy()
+			newJob.JobModifyIndex++
+			newJob.TaskGroups[0].Tasks[0].Resources.CPU += 100
+			newJob.TaskGroups[0].Tasks[0].Resources.MemoryMB += 128
+			require.True(t, tasksUpdated(newJob, job, newJob.TaskGroups[0].Name))
+			require.True(t, resizeOnlyUpdate(newJob, job, newJob.TaskGroups[0].Name))
+
+			updates := []allocTuple{{Alloc: alloc, TaskGroup: newJob.TaskGroups[0]}}
+			stack := NewGenericStack(false, ctx)
+			stack.SetJob(newJob)
+
+			unplaced, inplace := inplaceUpdate(ctx, eval, newJob, stack, updates)
+			if !tc.inplace {
+				require.Len(t, unplaced, 1)
+				require.Empty(t, inplace)
+				return
+			}
+
+			require.Empty(t, unplaced)
+			require.Len(t, inplace, 1)
+			require.Len(t, ctx.plan.NodeAllocation[node.ID], 1)
+			tr := ctx.plan.NodeAllocation[node.ID][0].AllocatedResources.Tasks["web"]
+			require.Equal(t, int64(600), tr.Cpu.CpuShares)
+			require.Equal(t, int64(384), tr.Memory.MemoryMB)
+		})
+	}
+}
+
+func TestResizeOnlyUpdate(t *testing.T) {
+	j1 := mock.Job()
+	name := j1.TaskGroups[0].Name
+
+	j2 := j1.Copy()
+	j2.TaskGroups[0].Tasks[0].Resources.MemoryMaxMB = 1024
+	require.True(t, resizeOnlyUpdate(j1, j2, name))
+
+	// Changing the driver config is still destructive
+	j3 := j2.Copy()
+	j3.TaskGroups[0].Tasks[0].Config["command"] = "/bin/other"
+	require.False(t, resizeOnlyUpdate(j1, j3, name))
+
+	// Changing the reserved cores is still destructive
+	j4 := j1.Copy()
+	j4.TaskGroups[0].Tasks[0].Resources.Cores = 2
+	require.False(t, resizeOnlyUpdate(j1, j4, name))
+}
+
 func TestEvictAndPlace_LimitGreaterThanAllocs(t *testing.T) {
 	_, ctx := testContext(t)
 	allocs := []allocTuple{